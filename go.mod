@@ -16,6 +16,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/holoplot/go-avahi v1.0.1
 	github.com/matoous/go-nanoid/v2 v2.1.0
+	github.com/pressly/goose/v3 v3.27.1
 	github.com/samber/do/v2 v2.0.0
 	github.com/simonhull/audiometa v0.10.0
 	github.com/stretchr/testify v1.11.1
@@ -65,7 +66,6 @@ require (
 	github.com/mschoch/smat v0.2.0 // indirect
 	github.com/ncruces/go-strftime v1.0.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/pressly/goose/v3 v3.27.1 // indirect
 	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
 	github.com/samber/go-type-to-string v1.8.0 // indirect
 	github.com/sethvargo/go-retry v0.3.0 // indirect
@@ -143,6 +143,9 @@ type AnalyzeABSResponse struct {
 	ProgressReady   int `json:"progress_ready" doc:"Progress records ready to import"`
 	ProgressPending int `json:"progress_pending" doc:"Progress records pending"`
 
+	// Collections, playlists, tags and bookmarks
+	Library ABSLibraryPreviewResponse `json:"library" doc:"How collections, playlists, tags and bookmarks map"`
+
 	// Details (for admin review)
 	UserMatches []ABSUserMatchResponse `json:"user_matches" doc:"Detailed user matching results"`
 	BookMatches []ABSBookMatchResponse `json:"book_matches" doc:"Detailed book matching results"`
@@ -170,7 +173,7 @@ type AnalysisStatusInput struct {
 // AnalysisStatusResponse is the body of the status response.
 type AnalysisStatusResponse struct {
 	Status  string              `json:"status" doc:"running, completed, or failed"`
-	Phase   string              `json:"phase" doc:"Current phase: parsing, matching_users, matching_books, matching_sessions, matching_progress, done"`
+	Phase   string              `json:"phase" doc:"Current phase: parsing, matching_users, matching_books, matching_sessions, matching_progress, matching_library, done"`
 	Current int                 `json:"current" doc:"Current item in phase"`
 	Total   int                 `json:"total" doc:"Total items in phase"`
 	Result  *AnalyzeABSResponse `json:"result,omitempty" doc:"Analysis result when completed"`
@@ -184,12 +187,16 @@ type AnalysisStatusOutput struct {
 
 // ImportABSRequest is the request body for importing from an ABS backup.
 type ImportABSRequest struct {
-	BackupPath      string            `json:"backup_path" doc:"Path to .audiobookshelf backup file"`
-	UserMappings    map[string]string `json:"user_mappings" doc:"Final ABS user ID -> ListenUp user ID mappings"`
-	BookMappings    map[string]string `json:"book_mappings" doc:"Final ABS item ID -> ListenUp book ID mappings"`
-	ImportSessions  bool              `json:"import_sessions" required:"false" default:"true" doc:"Import listening session history"`
-	ImportProgress  bool              `json:"import_progress" required:"false" default:"true" doc:"Import current progress state"`
-	RebuildProgress bool              `json:"rebuild_progress" required:"false" default:"true" doc:"Rebuild progress after import"`
+	BackupPath           string            `json:"backup_path" doc:"Path to .audiobookshelf backup file"`
+	UserMappings         map[string]string `json:"user_mappings" doc:"Final ABS user ID -> ListenUp user ID mappings"`
+	BookMappings         map[string]string `json:"book_mappings" doc:"Final ABS item ID -> ListenUp book ID mappings"`
+	ImportSessions       bool              `json:"import_sessions" required:"false" default:"true" doc:"Import listening session history"`
+	ImportProgress       bool              `json:"import_progress" required:"false" default:"true" doc:"Import current progress state"`
+	RebuildProgress      bool              `json:"rebuild_progress" required:"false" default:"true" doc:"Rebuild progress after import"`
+	ImportShelves        bool              `json:"import_shelves" required:"false" default:"true" doc:"Create shelves from collections and playlists"`
+	ImportTags           bool              `json:"import_tags" required:"false" default:"true" doc:"Apply ABS tags to mapped books"`
+	ImportBookmarks      bool              `json:"import_bookmarks" required:"false" default:"true" doc:"Copy bookmarks"`
+	ImportEbookPositions bool              `json:"import_ebook_positions" required:"false" default:"true" doc:"Copy ebook reading positions"`
 }
 
 // ImportABSInput is the Huma input for importing from an ABS backup.
@@ -200,15 +207,20 @@ type ImportABSInput struct {
 
 // ImportABSResponse is the response from importing an ABS backup.
 type ImportABSResponse struct {
-	SessionsImported int      `json:"sessions_imported" doc:"Number of sessions imported"`
-	SessionsSkipped  int      `json:"sessions_skipped" doc:"Number of sessions skipped"`
-	ProgressImported int      `json:"progress_imported" doc:"Number of progress records imported"`
-	ProgressSkipped  int      `json:"progress_skipped" doc:"Number of progress records skipped"`
-	EventsCreated    int      `json:"events_created" doc:"Total listening events created"`
-	AffectedUsers    int      `json:"affected_users" doc:"Number of users whose progress was affected"`
-	Duration         string   `json:"duration" doc:"Import duration"`
-	Warnings         []string `json:"warnings,omitempty" doc:"Non-fatal warnings during import"`
-	Errors           []string `json:"errors,omitempty" doc:"Non-fatal errors during import"`
+	SessionsImported       int      `json:"sessions_imported" doc:"Number of sessions imported"`
+	SessionsSkipped        int      `json:"sessions_skipped" doc:"Number of sessions skipped"`
+	ProgressImported       int      `json:"progress_imported" doc:"Number of progress records imported"`
+	ProgressSkipped        int      `json:"progress_skipped" doc:"Number of progress records skipped"`
+	EventsCreated          int      `json:"events_created" doc:"Total listening events created"`
+	AffectedUsers          int      `json:"affected_users" doc:"Number of users whose progress was affected"`
+	ShelvesCreated         int      `json:"shelves_created" doc:"Shelves created from collections and playlists"`
+	TagsCreated            int      `json:"tags_created" doc:"New ListenUp tags"`
+	BookTagsApplied        int      `json:"book_tags_applied" doc:"Tags added to books"`
+	BookmarksImported      int      `json:"bookmarks_imported" doc:"Bookmarks copied"`
+	EbookPositionsImported int      `json:"ebook_positions_imported" doc:"Ebook reading positions copied"`
+	Duration               string   `json:"duration" doc:"Import duration"`
+	Warnings               []string `json:"warnings,omitempty" doc:"Non-fatal warnings during import"`
+	Errors                 []string `json:"errors,omitempty" doc:"Non-fatal errors during import"`
 }
 
 // ImportABSOutput is the Huma output for importing from an ABS backup.
@@ -295,6 +307,9 @@ func (s *Server) buildAnalyzeResponse(result *abs.AnalysisResult, backupSummary
 		ProgressPending: result.ProgressPending,
 		Warnings:        result.Warnings,
 	}
+	if result.Library != nil {
+		resp.Library = toABSLibraryPreviewResponse(result.Library)
+	}
 
 	// Convert user matches
 	resp.UserMatches = make([]ABSUserMatchResponse, len(result.UserMatches))
@@ -478,11 +493,15 @@ func (s *Server) handleImportABSBackup(ctx context.Context, input *ImportABSInpu
 
 	// Build import options
 	opts := abs.ImportOptions{
-		UserMappings:   input.Body.UserMappings,
-		BookMappings:   input.Body.BookMappings,
-		ImportSessions: input.Body.ImportSessions,
-		ImportProgress: input.Body.ImportProgress,
-		SkipUnmatched:  true,
+		UserMappings:         input.Body.UserMappings,
+		BookMappings:         input.Body.BookMappings,
+		ImportSessions:       input.Body.ImportSessions,
+		ImportProgress:       input.Body.ImportProgress,
+		ImportShelves:        input.Body.ImportShelves,
+		ImportTags:           input.Body.ImportTags,
+		ImportBookmarks:      input.Body.ImportBookmarks,
+		ImportEbookPositions: input.Body.ImportEbookPositions,
+		SkipUnmatched:        true,
 	}
 
 	// Run import
@@ -491,6 +510,7 @@ func (s *Server) handleImportABSBackup(ctx context.Context, input *ImportABSInpu
 	if err != nil {
 		return nil, huma.Error500InternalServerError("import failed", err)
 	}
+	s.reindexABSTaggedBooks(ctx, result.TaggedBookIDs)
 
 	// Rebuild progress if requested
 	if input.Body.RebuildProgress && len(result.AffectedUserIDs) > 0 {
@@ -501,15 +521,20 @@ func (s *Server) handleImportABSBackup(ctx context.Context, input *ImportABSInpu
 
 	return &ImportABSOutput{
 		Body: ImportABSResponse{
-			SessionsImported: result.SessionsImported,
-			SessionsSkipped:  result.SessionsSkipped,
-			ProgressImported: result.ProgressImported,
-			ProgressSkipped:  result.ProgressSkipped,
-			EventsCreated:    result.EventsCreated,
-			AffectedUsers:    len(result.AffectedUserIDs),
-			Duration:         result.Duration.String(),
-			Warnings:         result.Warnings,
-			Errors:           result.Errors,
+			SessionsImported:       result.SessionsImported,
+			SessionsSkipped:        result.SessionsSkipped,
+			ProgressImported:       result.ProgressImported,
+			ProgressSkipped:        result.ProgressSkipped,
+			EventsCreated:          result.EventsCreated,
+			AffectedUsers:          len(result.AffectedUserIDs),
+			ShelvesCreated:         result.ShelvesCreated,
+			TagsCreated:            result.TagsCreated,
+			BookTagsApplied:        result.BookTagsApplied,
+			BookmarksImported:      result.BookmarksImported,
+			EbookPositionsImported: result.EbookPositionsImported,
+			Duration:               result.Duration.String(),
+			Warnings:               result.Warnings,
+			Errors:                 result.Errors,
 		},
 	}, nil
 }
@@ -2,12 +2,14 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/listenupapp/listenup-server/internal/backup/abs"
 	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
 )
 
 // runImportAnalysis is launched by importJobManager.Submit. The supplied ctx
@@ -15,7 +17,11 @@ import (
 // instead of writing to a database that is being closed. ABSImportStatus has
 // no dedicated canceled state, so on cancellation we log and mark the
 // import as failed for visibility — the user can re-run the analysis.
-func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath string) {
+//
+// Live imports run this again on every re-sync. Users, books and sessions
+// that are already staged keep their mappings and status; only progress
+// positions are refreshed and sessions new since the last sync are added.
+func (s *Server) runImportAnalysis(ctx context.Context, importID string) {
 	// finalizeCtx returns a context safe for the final status write. If ctx
 	// is already canceled (shutdown path) we use a short detached context
 	// so the failure can still be recorded; otherwise we propagate ctx.
@@ -59,10 +65,18 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 		return false
 	}
 
-	// Parse the backup
-	backup, err := abs.Parse(ctx, backupPath)
+	imp, err := s.services.ABSImport.GetABSImport(ctx, importID)
+	if err != nil {
+		setFailed(fmt.Errorf("failed to get import: %w", err))
+		return
+	}
+	resync := imp.LastSyncedAt != nil
+	syncStart := time.Now()
+
+	// Parse the backup, or read it from the ABS server
+	backup, err := loadABSBackup(ctx, imp)
 	if err != nil {
-		setFailed(fmt.Errorf("failed to parse ABS backup: %w", err))
+		setFailed(err)
 		return
 	}
 
@@ -91,7 +105,9 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 	if imp, err := s.services.ABSImport.GetABSImport(ctx, importID); err == nil {
 		imp.TotalUsers = analysis.TotalUsers
 		imp.TotalBooks = analysis.TotalBooks
-		imp.TotalSessions = analysis.TotalSessions
+		if !resync {
+			imp.TotalSessions = analysis.TotalSessions
+		}
 		imp.UpdatedAt = time.Now()
 		if err := s.services.ABSImport.UpdateABSImport(ctx, imp); err != nil {
 			s.logger.Error("failed to write analysis counts to import record",
@@ -143,10 +159,14 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 		}
 
 		if err := s.services.ABSImport.CreateABSImportUser(ctx, user); err != nil {
-			s.logger.Error("failed to store import user",
-				slog.String("abs_user_id", um.ABSUser.ID),
-				slog.String("error", err.Error()))
-			continue
+			if !errors.Is(err, store.ErrAlreadyExists) {
+				s.logger.Error("failed to store import user",
+					slog.String("abs_user_id", um.ABSUser.ID),
+					slog.String("error", err.Error()))
+				continue
+			}
+			// Staged by an earlier sync: keep its mapping, refresh progress below.
+			wasAutoMapped = false
 		}
 
 		// Store user's media progress entries
@@ -177,7 +197,11 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 				finishedAt := time.UnixMilli(mp.FinishedAt)
 				progress.FinishedAt = &finishedAt
 			}
-			if err := s.services.ABSImport.CreateABSImportProgress(ctx, progress); err != nil {
+			err := s.services.ABSImport.CreateABSImportProgress(ctx, progress)
+			if errors.Is(err, store.ErrAlreadyExists) {
+				err = s.services.ABSImport.UpdateABSImportProgress(ctx, progress)
+			}
+			if err != nil {
 				s.logger.Error("failed to store import progress",
 					slog.String("abs_user_id", um.ABSUser.ID),
 					slog.String("abs_media_id", mp.LibraryItemID),
@@ -241,10 +265,12 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 		}
 
 		if err := s.services.ABSImport.CreateABSImportBook(ctx, book); err != nil {
-			s.logger.Error("failed to store import book",
-				slog.String("abs_media_id", bm.ABSItem.MediaID),
-				slog.String("title", bm.ABSItem.Media.Metadata.Title),
-				slog.String("error", err.Error()))
+			if !errors.Is(err, store.ErrAlreadyExists) {
+				s.logger.Error("failed to store import book",
+					slog.String("abs_media_id", bm.ABSItem.MediaID),
+					slog.String("title", bm.ABSItem.Media.Metadata.Title),
+					slog.String("error", err.Error()))
+			}
 			continue
 		}
 
@@ -302,6 +328,9 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 		}
 
 		if err := s.services.ABSImport.CreateABSImportSession(ctx, sess); err != nil {
+			if errors.Is(err, store.ErrAlreadyExists) {
+				continue
+			}
 			s.logger.Error("failed to store import session",
 				slog.String("session_id", session.ID),
 				slog.String("user_id", session.UserID),
@@ -330,7 +359,7 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 	}
 
 	// Update import to active with counts
-	imp, err := s.services.ABSImport.GetABSImport(ctx, importID)
+	imp, err = s.services.ABSImport.GetABSImport(ctx, importID)
 	if err != nil {
 		setFailed(fmt.Errorf("failed to get import for final update: %w", err))
 		return
@@ -338,9 +367,17 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 	imp.Status = domain.ABSImportStatusAnalyzed
 	imp.TotalUsers = analysis.TotalUsers
 	imp.TotalBooks = analysis.TotalBooks
-	imp.TotalSessions = analysis.TotalSessions
-	imp.UsersMapped = usersMapped
-	imp.BooksMapped = booksMapped
+	if resync {
+		// Only sessions since the last sync were fetched.
+		imp.TotalSessions += sessionsStored
+	} else {
+		imp.TotalSessions = analysis.TotalSessions
+		imp.UsersMapped = usersMapped
+		imp.BooksMapped = booksMapped
+	}
+	if imp.IsLive() {
+		imp.LastSyncedAt = &syncStart
+	}
 	imp.UpdatedAt = time.Now()
 	if err := s.services.ABSImport.UpdateABSImport(ctx, imp); err != nil {
 		s.logger.Error("failed to update import to active",
@@ -348,6 +385,10 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 			slog.String("error", err.Error()))
 		return
 	}
+	if resync {
+		// Mappings made since the first sync are kept; recount them.
+		s.updateImportStats(ctx, importID)
+	}
 
 	s.logger.Info("import analysis completed",
 		slog.String("import_id", importID),
@@ -361,6 +402,33 @@ func (s *Server) runImportAnalysis(ctx context.Context, importID, backupPath str
 
 // === Analysis helpers ===
 
+// loadABSBackup parses a file import's backup, or fetches the same data from
+// the ABS server for a live import. On re-sync only sessions updated since
+// the last sync are fetched.
+func loadABSBackup(ctx context.Context, imp *domain.ABSImport) (*abs.Backup, error) {
+	if !imp.IsLive() {
+		backup, err := abs.Parse(ctx, imp.BackupPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABS backup: %w", err)
+		}
+		return backup, nil
+	}
+
+	client, err := abs.NewClient(imp.ServerURL, imp.APIToken)
+	if err != nil {
+		return nil, err
+	}
+	var opts abs.FetchOptions
+	if imp.LastSyncedAt != nil {
+		opts.SessionsSince = *imp.LastSyncedAt
+	}
+	backup, err := client.Fetch(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from ABS server: %w", err)
+	}
+	return backup, nil
+}
+
 func calculateUserListenTime(progress []abs.MediaProgress) int64 {
 	var total int64
 	for _, p := range progress {
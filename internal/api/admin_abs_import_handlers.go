@@ -12,6 +12,7 @@ import (
 //   - admin_abs_import_handlers_users.go    (user mapping)
 //   - admin_abs_import_handlers_books.go    (book mapping)
 //   - admin_abs_import_handlers_sessions.go (session mapping/skip/import)
+//   - admin_abs_import_handlers_library.go  (collections, playlists, tags, bookmarks, podcasts)
 //   - admin_abs_import_analysis.go          (background runImportAnalysis)
 //   - admin_abs_import_types.go             (request/response DTOs)
 func (s *Server) registerAdminABSImportRoutes() {
@@ -21,7 +22,7 @@ func (s *Server) registerAdminABSImportRoutes() {
 		Method:      http.MethodPost,
 		Path:        "/api/v1/admin/abs/imports",
 		Summary:     "Create ABS import",
-		Description: "Creates a new persistent ABS import from an uploaded backup (admin only)",
+		Description: "Creates a new persistent ABS import from an uploaded backup, or from a running ABS server given its URL and an admin API token (admin only)",
 		Tags:        []string{"Admin", "ABS Import"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleCreateABSImport)
@@ -46,6 +47,16 @@ func (s *Server) registerAdminABSImportRoutes() {
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleGetABSImport)
 
+	huma.Register(s.api, huma.Operation{
+		OperationID: "syncABSImport",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/abs/imports/{id}/sync",
+		Summary:     "Re-sync live ABS import",
+		Description: "Fetches new users, books, progress and sessions from the import's ABS server. Existing mappings are kept (admin only)",
+		Tags:        []string{"Admin", "ABS Import"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleSyncABSImport)
+
 	huma.Register(s.api, huma.Operation{
 		OperationID: "deleteABSImport",
 		Method:      http.MethodDelete,
@@ -148,4 +159,36 @@ func (s *Server) registerAdminABSImportRoutes() {
 		Tags:        []string{"Admin", "ABS Import"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleSkipABSSession)
+
+	// Collections, playlists, tags and bookmarks
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getABSImportLibrary",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/abs/imports/{id}/library",
+		Summary:     "Preview ABS library data",
+		Description: "Shows how collections, playlists, tags and bookmarks map under the current user and book mappings (admin only)",
+		Tags:        []string{"Admin", "ABS Import"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetABSImportLibrary)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "importABSLibrary",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/abs/imports/{id}/library/import",
+		Summary:     "Import ABS library data",
+		Description: "Creates shelves from collections and playlists, tags mapped books and copies bookmarks. Safe to repeat after mapping more users or books (admin only)",
+		Tags:        []string{"Admin", "ABS Import"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleImportABSLibrary)
+
+	// Podcasts
+	huma.Register(s.api, huma.Operation{
+		OperationID: "importABSPodcasts",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/abs/imports/{id}/podcasts/import",
+		Summary:     "Import ABS podcasts",
+		Description: "Subscribes a podcast library to every ABS podcast, adds their episodes, keeps downloads readable from this server and copies mapped users' episode progress. Safe to repeat (admin only)",
+		Tags:        []string{"Admin", "ABS Import"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleImportABSPodcasts)
 }
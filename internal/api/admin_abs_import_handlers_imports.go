@@ -2,12 +2,14 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/google/uuid"
+	"github.com/listenupapp/listenup-server/internal/backup/abs"
 	"github.com/listenupapp/listenup-server/internal/domain"
 )
 
@@ -17,11 +19,18 @@ func (s *Server) handleCreateABSImport(ctx context.Context, input *CreateABSImpo
 		return nil, err
 	}
 
-	if input.Body.BackupPath == "" {
-		return nil, huma.Error400BadRequest("backup_path is required")
+	live := input.Body.ServerURL != ""
+	if live == (input.Body.BackupPath != "") {
+		return nil, huma.Error400BadRequest("either backup_path or server_url is required")
 	}
 
-	if _, err := os.Stat(input.Body.BackupPath); os.IsNotExist(err) {
+	var client *abs.Client
+	if live {
+		client, err = s.connectABSServer(ctx, input.Body.ServerURL, input.Body.APIToken)
+		if err != nil {
+			return nil, err
+		}
+	} else if _, err := os.Stat(input.Body.BackupPath); os.IsNotExist(err) {
 		return nil, huma.Error400BadRequest("backup file not found")
 	}
 
@@ -40,6 +49,10 @@ func (s *Server) handleCreateABSImport(ctx context.Context, input *CreateABSImpo
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}
+	if live {
+		imp.ServerURL = client.BaseURL()
+		imp.APIToken = input.Body.APIToken
+	}
 
 	if err := s.services.ABSImport.CreateABSImport(ctx, imp); err != nil {
 		return nil, huma.Error500InternalServerError("failed to create import", err)
@@ -47,13 +60,49 @@ func (s *Server) handleCreateABSImport(ctx context.Context, input *CreateABSImpo
 
 	// Launch background analysis on a tracked goroutine so it can be
 	// canceled and drained on server shutdown (see importJobManager).
-	s.importJobs.Submit(imp.ID, input.Body.BackupPath)
+	s.importJobs.Submit(imp.ID)
 
 	return &CreateABSImportOutput{
 		Body: toABSImportResponse(imp),
 	}, nil
 }
 
+// handleSyncABSImport re-runs analysis for a live import. Only sessions
+// updated since the last sync are fetched; users, books and sessions that
+// are already staged keep their mappings and import status.
+func (s *Server) handleSyncABSImport(ctx context.Context, input *SyncABSImportInput) (*SyncABSImportOutput, error) {
+	_, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	imp, err := s.services.ABSImport.GetABSImport(ctx, input.ID)
+	if err != nil {
+		return nil, huma.Error404NotFound("import not found")
+	}
+	if !imp.IsLive() {
+		return nil, huma.Error400BadRequest("only imports from an ABS server can be re-synced")
+	}
+	if imp.Status == domain.ABSImportStatusAnalyzing {
+		return nil, huma.Error409Conflict("import is already being analyzed")
+	}
+	if _, err := s.connectABSServer(ctx, imp.ServerURL, imp.APIToken); err != nil {
+		return nil, err
+	}
+
+	imp.Status = domain.ABSImportStatusAnalyzing
+	imp.UpdatedAt = time.Now()
+	if err := s.services.ABSImport.UpdateABSImport(ctx, imp); err != nil {
+		return nil, huma.Error500InternalServerError("failed to update import", err)
+	}
+
+	s.importJobs.Submit(imp.ID)
+
+	return &SyncABSImportOutput{
+		Body: toABSImportResponse(imp),
+	}, nil
+}
+
 func (s *Server) handleListABSImports(ctx context.Context, _ *ListABSImportsInput) (*ListABSImportsOutput, error) {
 	_, err := s.RequireAdmin(ctx)
 	if err != nil {
@@ -109,6 +158,27 @@ func (s *Server) handleDeleteABSImport(ctx context.Context, input *DeleteABSImpo
 
 // === Shared helpers ===
 
+// connectABSServer checks that an ABS server is reachable and that the token
+// belongs to one of its admins.
+func (s *Server) connectABSServer(ctx context.Context, serverURL, token string) (*abs.Client, error) {
+	client, err := abs.NewClient(serverURL, token)
+	if err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
+	info, err := client.Authorize(ctx)
+	switch {
+	case errors.Is(err, abs.ErrUnauthorized), errors.Is(err, abs.ErrNotAdmin):
+		return nil, huma.Error400BadRequest(err.Error())
+	case err != nil:
+		return nil, huma.Error400BadRequest("could not reach ABS server: " + err.Error())
+	}
+	s.logger.Info("connected to ABS server",
+		slog.String("url", client.BaseURL()),
+		slog.String("version", info.Version),
+		slog.String("abs_user", info.Username))
+	return client, nil
+}
+
 // updateImportStats refreshes the aggregate counts on an import record
 // (users mapped, books mapped, sessions imported). Called after any mutation
 // to user/book mappings or session imports.
@@ -161,10 +231,11 @@ func (s *Server) updateImportStats(ctx context.Context, importID string) {
 }
 
 func toABSImportResponse(imp *domain.ABSImport) ABSImportResponse {
-	return ABSImportResponse{
+	resp := ABSImportResponse{
 		ID:               imp.ID,
 		Name:             imp.Name,
 		BackupPath:       imp.BackupPath,
+		ServerURL:        imp.ServerURL,
 		Status:           string(imp.Status),
 		CreatedAt:        imp.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:        imp.UpdatedAt.Format(time.RFC3339),
@@ -175,4 +246,8 @@ func toABSImportResponse(imp *domain.ABSImport) ABSImportResponse {
 		BooksMapped:      imp.BooksMapped,
 		SessionsImported: imp.SessionsImported,
 	}
+	if imp.LastSyncedAt != nil {
+		resp.LastSyncedAt = imp.LastSyncedAt.Format(time.RFC3339)
+	}
+	return resp
 }
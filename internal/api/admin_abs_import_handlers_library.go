@@ -0,0 +1,223 @@
+package api
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/backup/abs"
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+func (s *Server) handleGetABSImportLibrary(ctx context.Context, input *GetABSImportLibraryInput) (*GetABSImportLibraryOutput, error) {
+	_, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	backup, userMap, bookMap, err := s.loadABSImportLibrary(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := abs.PreviewLibrary(backup, userMap, bookMap)
+	return &GetABSImportLibraryOutput{Body: toABSLibraryPreviewResponse(preview)}, nil
+}
+
+func (s *Server) handleImportABSLibrary(ctx context.Context, input *ImportABSLibraryInput) (*ImportABSLibraryOutput, error) {
+	_, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	backup, userMap, bookMap, err := s.loadABSImportLibrary(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := abs.ImportOptions{
+		ImportShelves:        input.Body.ImportShelves,
+		ImportTags:           input.Body.ImportTags,
+		ImportBookmarks:      input.Body.ImportBookmarks,
+		ImportEbookPositions: input.Body.ImportEbookPositions,
+	}
+	result := &abs.ImportResult{}
+	importer := abs.NewImporter(s.store, s.sseManager, s.logger)
+	if err := importer.ImportLibrary(ctx, backup, userMap, bookMap, opts, result); err != nil {
+		return nil, huma.Error500InternalServerError("library import failed", err)
+	}
+	s.reindexABSTaggedBooks(ctx, result.TaggedBookIDs)
+
+	return &ImportABSLibraryOutput{
+		Body: ImportABSLibraryResponse{
+			ShelvesCreated:         result.ShelvesCreated,
+			ShelvesSkipped:         result.ShelvesSkipped,
+			TagsCreated:            result.TagsCreated,
+			BookTagsApplied:        result.BookTagsApplied,
+			BookmarksImported:      result.BookmarksImported,
+			BookmarksSkipped:       result.BookmarksSkipped,
+			EbookPositionsImported: result.EbookPositionsImported,
+			EbookPositionsSkipped:  result.EbookPositionsSkipped,
+			Duration:               time.Since(start).String(),
+			Warnings:               result.Warnings,
+		},
+	}, nil
+}
+
+func (s *Server) handleImportABSPodcasts(ctx context.Context, input *ImportABSPodcastsInput) (*ImportABSPodcastsOutput, error) {
+	_, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	lib, err := s.services.Library.GetLibrary(ctx, input.Body.LibraryID)
+	if err != nil {
+		return nil, huma.Error404NotFound("library not found")
+	}
+	if !lib.IsPodcast() {
+		return nil, huma.Error400BadRequest("library is not a podcast library")
+	}
+
+	backup, userMap, _, err := s.loadABSImportLibrary(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	importer := abs.NewImporter(s.store, s.sseManager, s.logger)
+	result, err := importer.ImportPodcasts(ctx, backup, lib, userMap)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("podcast import failed", err)
+	}
+	s.services.Podcast.PublishImported(ctx, result.PodcastIDs)
+
+	return &ImportABSPodcastsOutput{
+		Body: ImportABSPodcastsResponse{
+			PodcastsCreated:  result.PodcastsCreated,
+			PodcastsSkipped:  result.PodcastsSkipped,
+			EpisodesCreated:  result.EpisodesCreated,
+			EpisodesLinked:   result.EpisodesLinked,
+			ProgressImported: result.ProgressImported,
+			ProgressSkipped:  result.ProgressSkipped,
+			Duration:         time.Since(start).String(),
+			Warnings:         result.Warnings,
+		},
+	}, nil
+}
+
+// loadABSImportLibrary re-reads the import's backup file, or the ABS server
+// for a live import, which holds the collections, playlists, tags,
+// bookmarks and podcasts, together with the confirmed user and book mappings.
+func (s *Server) loadABSImportLibrary(ctx context.Context, importID string) (*abs.Backup, map[string]string, map[string]string, error) {
+	imp, err := s.services.ABSImport.GetABSImport(ctx, importID)
+	if err != nil {
+		return nil, nil, nil, huma.Error404NotFound("import not found")
+	}
+
+	var backup *abs.Backup
+	if imp.IsLive() {
+		client, err := s.connectABSServer(ctx, imp.ServerURL, imp.APIToken)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		backup, err = client.Fetch(ctx, abs.FetchOptions{SkipSessions: true})
+		if err != nil {
+			return nil, nil, nil, huma.Error400BadRequest("failed to fetch from ABS server: " + err.Error())
+		}
+	} else {
+		if _, err := os.Stat(imp.BackupPath); os.IsNotExist(err) {
+			return nil, nil, nil, huma.Error400BadRequest("backup file not found")
+		}
+		backup, err = abs.Parse(ctx, imp.BackupPath)
+		if err != nil {
+			return nil, nil, nil, huma.Error400BadRequest("failed to parse ABS backup: " + err.Error())
+		}
+	}
+
+	users, err := s.services.ABSImport.ListABSImportUsers(ctx, importID, domain.MappingFilterMapped)
+	if err != nil {
+		return nil, nil, nil, huma.Error500InternalServerError("failed to load user mappings", err)
+	}
+	books, err := s.services.ABSImport.ListABSImportBooks(ctx, importID, domain.MappingFilterMapped)
+	if err != nil {
+		return nil, nil, nil, huma.Error500InternalServerError("failed to load book mappings", err)
+	}
+
+	userMap := make(map[string]string) // ABS user ID -> ListenUp user ID
+	for _, u := range users {
+		if u.IsMapped() {
+			userMap[u.ABSUserID] = *u.ListenUpID
+		}
+	}
+	bookMap := make(map[string]string) // ABS media ID -> ListenUp book ID
+	for _, b := range books {
+		if b.IsMapped() {
+			bookMap[b.ABSMediaID] = *b.ListenUpID
+		}
+	}
+
+	return backup, userMap, bookMap, nil
+}
+
+// reindexABSTaggedBooks refreshes search documents for books that gained tags.
+func (s *Server) reindexABSTaggedBooks(ctx context.Context, bookIDs []string) {
+	for _, bookID := range bookIDs {
+		s.services.Tag.ReindexBookTags(ctx, bookID)
+	}
+}
+
+func toABSLibraryPreviewResponse(p *abs.LibraryPreview) ABSLibraryPreviewResponse {
+	resp := ABSLibraryPreviewResponse{
+		CollectionsTotal: len(p.Collections),
+		CollectionsReady: p.CollectionsReady,
+		PlaylistsTotal:   len(p.Playlists),
+		PlaylistsReady:   p.PlaylistsReady,
+		TagsTotal:        len(p.Tags),
+		TagsReady:        p.TagsReady,
+		BookmarksTotal:   p.TotalBookmarks,
+		BookmarksReady:   p.BookmarksReady,
+		Collections:      toABSShelfPreviewResponses(p.Collections),
+		Playlists:        toABSShelfPreviewResponses(p.Playlists),
+		Tags:             make([]ABSTagPreviewResponse, len(p.Tags)),
+		Bookmarks:        make([]ABSBookmarkPreviewResponse, len(p.Bookmarks)),
+	}
+	for i, t := range p.Tags {
+		resp.Tags[i] = ABSTagPreviewResponse{
+			Name:        t.Name,
+			Slug:        t.Slug,
+			TotalBooks:  t.TotalBooks,
+			MappedBooks: t.MappedBooks,
+		}
+	}
+	for i, b := range p.Bookmarks {
+		resp.Bookmarks[i] = ABSBookmarkPreviewResponse{
+			ABSUserID:      b.ABSUserID,
+			ABSUsername:    b.ABSUsername,
+			ListenUpUserID: b.ListenUpUserID,
+			Total:          b.Total,
+			Ready:          b.Ready,
+		}
+	}
+	return resp
+}
+
+func toABSShelfPreviewResponses(previews []abs.ShelfPreview) []ABSShelfPreviewResponse {
+	resp := make([]ABSShelfPreviewResponse, len(previews))
+	for i, p := range previews {
+		resp[i] = ABSShelfPreviewResponse{
+			ABSID:          p.ABSID,
+			Name:           p.Name,
+			ABSUserID:      p.ABSUserID,
+			ListenUpUserID: p.ListenUpUserID,
+			TotalBooks:     p.TotalBooks,
+			MappedBooks:    p.MappedBooks,
+			IsReady:        p.IsReady(),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/backup/abs/abstest"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func liveABSData() abstest.Data {
+	return abstest.Data{
+		Libraries: []abstest.Library{{ID: "lib-books", Name: "Audiobooks"}},
+		Items: []abstest.Item{
+			{ID: "li-1", MediaID: "book-1", LibraryID: "lib-books", Title: "Dune", Author: "Frank Herbert", Duration: 75600},
+			{ID: "li-2", MediaID: "book-2", LibraryID: "lib-books", Title: "Hyperion", Author: "Dan Simmons", Duration: 73800},
+		},
+		Users: []abstest.User{
+			{ID: "u-root", Username: "root", Type: "root", Progress: []abstest.Progress{
+				{LibraryItemID: "li-1", MediaItemID: "book-1", Duration: 75600, CurrentTime: 600, Progress: 0.01, LastUpdate: 1700000600000},
+			}},
+		},
+		Sessions: []abstest.Session{
+			{ID: "s-1", UserID: "u-root", LibraryItemID: "li-1", BookID: "book-1", TimeListening: 600, CurrentTime: 600,
+				StartedAt: 1700000000000, UpdatedAt: 1700000600000},
+		},
+	}
+}
+
+// setupLiveABSImport creates a live import against a stub ABS server and runs
+// its first analysis synchronously.
+func setupLiveABSImport(t *testing.T) (*testServer, *abstest.Server, string, string) {
+	t.Helper()
+	ts := setupTestServer(t)
+	ts.registerAdminABSImportRoutes()
+	token := ts.createTestUserAndLogin(t)
+	absServer := abstest.NewServer(t, liveABSData())
+
+	resp := ts.api.Post("/api/v1/admin/abs/imports", "Authorization: Bearer "+token, map[string]any{
+		"name":       "Home ABS",
+		"server_url": absServer.URL,
+		"api_token":  abstest.Token,
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var envelope testEnvelope[ABSImportResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &envelope))
+	assert.Equal(t, absServer.URL, envelope.Data.ServerURL)
+	assert.Empty(t, envelope.Data.BackupPath)
+	assert.NotContains(t, resp.Body.String(), abstest.Token, "the token is never returned")
+
+	importID := envelope.Data.ID
+	ts.runImportAnalysis(t.Context(), importID)
+	return ts, absServer, token, importID
+}
+
+func TestCreateABSImport_Live(t *testing.T) {
+	t.Parallel()
+	ts, _, _, importID := setupLiveABSImport(t)
+	ctx := t.Context()
+
+	imp, err := ts.services.ABSImport.GetABSImport(ctx, importID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ABSImportStatusAnalyzed, imp.Status)
+	assert.Equal(t, abstest.Token, imp.APIToken)
+	require.NotNil(t, imp.LastSyncedAt)
+	assert.Equal(t, 1, imp.TotalUsers)
+	assert.Equal(t, 2, imp.TotalBooks)
+	assert.Equal(t, 1, imp.TotalSessions)
+
+	progress, err := ts.services.ABSImport.GetABSImportProgress(ctx, importID, "u-root", "book-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(600000), progress.CurrentTime)
+
+	sess, err := ts.services.ABSImport.GetABSImportSession(ctx, importID, "s-1")
+	require.NoError(t, err)
+	assert.Equal(t, "book-1", sess.ABSMediaID)
+}
+
+func TestCreateABSImport_LiveRejectsBadToken(t *testing.T) {
+	t.Parallel()
+	ts := setupTestServer(t)
+	ts.registerAdminABSImportRoutes()
+	token := ts.createTestUserAndLogin(t)
+	absServer := abstest.NewServer(t, liveABSData())
+
+	resp := ts.api.Post("/api/v1/admin/abs/imports", "Authorization: Bearer "+token, map[string]any{
+		"name":       "Home ABS",
+		"server_url": absServer.URL,
+		"api_token":  "wrong",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// A backup path and a server URL are mutually exclusive.
+	resp = ts.api.Post("/api/v1/admin/abs/imports", "Authorization: Bearer "+token, map[string]any{
+		"name":        "Both",
+		"backup_path": "/tmp/backup.audiobookshelf",
+		"server_url":  absServer.URL,
+		"api_token":   abstest.Token,
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestSyncABSImport_Incremental(t *testing.T) {
+	t.Parallel()
+	ts, absServer, token, importID := setupLiveABSImport(t)
+	ctx := t.Context()
+
+	// The admin maps the ABS root user before re-syncing.
+	adminID := "lu-admin"
+	require.NoError(t, ts.services.ABSImport.UpdateABSImportUserMapping(ctx, importID, "u-root", &adminID, nil, nil))
+
+	// Meanwhile the user keeps listening in ABS.
+	now := time.Now().Add(time.Minute).UnixMilli()
+	absServer.Update(func(d *abstest.Data) {
+		d.Users[0].Progress[0].CurrentTime = 1800
+		d.Users[0].Progress[0].LastUpdate = now
+		d.Sessions = append(d.Sessions, abstest.Session{
+			ID: "s-2", UserID: "u-root", LibraryItemID: "li-1", BookID: "book-1", TimeListening: 1200,
+			StartTime: 600, CurrentTime: 1800, StartedAt: now - 1200000, UpdatedAt: now,
+		})
+	})
+	absServer.Requests()
+
+	resp := ts.api.Post("/api/v1/admin/abs/imports/"+importID+"/sync", "Authorization: Bearer "+token)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var envelope testEnvelope[ABSImportResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &envelope))
+	assert.Equal(t, string(domain.ABSImportStatusAnalyzing), envelope.Data.Status)
+
+	ts.runImportAnalysis(ctx, importID)
+
+	imp, err := ts.services.ABSImport.GetABSImport(ctx, importID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ABSImportStatusAnalyzed, imp.Status)
+	assert.Equal(t, 2, imp.TotalSessions, "the new session is added to the earlier total")
+	assert.Equal(t, 1, imp.UsersMapped)
+
+	user, err := ts.services.ABSImport.GetABSImportUser(ctx, importID, "u-root")
+	require.NoError(t, err)
+	require.NotNil(t, user.ListenUpID, "mapping survives the re-sync")
+	assert.Equal(t, adminID, *user.ListenUpID)
+
+	progress, err := ts.services.ABSImport.GetABSImportProgress(ctx, importID, "u-root", "book-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1800000), progress.CurrentTime)
+
+	_, err = ts.services.ABSImport.GetABSImportSession(ctx, importID, "s-2")
+	require.NoError(t, err)
+
+	// Sessions are read newest first and stop before the ones already staged.
+	sessionPages := 0
+	for _, req := range absServer.Requests() {
+		if strings.HasPrefix(req, "/api/sessions") {
+			sessionPages++
+		}
+	}
+	assert.Equal(t, 1, sessionPages)
+}
+
+func TestSyncABSImport_RejectsFileImports(t *testing.T) {
+	t.Parallel()
+	ts := setupTestServer(t)
+	ts.registerAdminABSImportRoutes()
+	token := ts.createTestUserAndLogin(t)
+
+	imp := &domain.ABSImport{
+		ID:         "import-file",
+		Name:       "Backup",
+		BackupPath: "/tmp/backup.audiobookshelf",
+		Status:     domain.ABSImportStatusAnalyzed,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	require.NoError(t, ts.services.ABSImport.CreateABSImport(t.Context(), imp))
+
+	resp := ts.api.Post("/api/v1/admin/abs/imports/import-file/sync", "Authorization: Bearer "+token)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
@@ -3,8 +3,11 @@ package api
 // === DTOs for persistent imports ===
 
 // CreateABSImportRequest is the request for creating a persistent import.
+// Either backup_path or server_url (with api_token) is required.
 type CreateABSImportRequest struct {
-	BackupPath string `json:"backup_path" doc:"Path to uploaded .audiobookshelf backup file"`
+	BackupPath string `json:"backup_path,omitempty" doc:"Path to uploaded .audiobookshelf backup file"`
+	ServerURL  string `json:"server_url,omitempty" doc:"Base URL of a running ABS server to import from instead of a backup"`
+	APIToken   string `json:"api_token,omitempty" doc:"API token of an ABS admin user, required with server_url"`
 	Name       string `json:"name,omitempty" doc:"Optional friendly name for this import"`
 }
 
@@ -18,6 +21,8 @@ type ABSImportResponse struct {
 	ID               string `json:"id" doc:"Import ID"`
 	Name             string `json:"name" doc:"Import name"`
 	BackupPath       string `json:"backup_path" doc:"Path to the backup file"`
+	ServerURL        string `json:"server_url,omitempty" doc:"ABS server a live import reads from"`
+	LastSyncedAt     string `json:"last_synced_at,omitempty" doc:"When a live import last fetched from its server"`
 	Status           string `json:"status" doc:"Import status: active, completed, archived"`
 	CreatedAt        string `json:"created_at" doc:"When the import was created"`
 	UpdatedAt        string `json:"updated_at" doc:"When the import was last updated"`
@@ -52,6 +57,15 @@ type GetABSImportOutput struct {
 	Body ABSImportResponse
 }
 
+type SyncABSImportInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Import ID"`
+}
+
+type SyncABSImportOutput struct {
+	Body ABSImportResponse
+}
+
 type DeleteABSImportInput struct {
 	Authorization string `header:"Authorization"`
 	ID            string `path:"id" doc:"Import ID"`
@@ -232,3 +246,111 @@ type SkipABSSessionInput struct {
 type SkipABSSessionOutput struct {
 	Body ABSImportSessionResponse
 }
+
+// Library data DTOs
+
+// ABSShelfPreviewResponse shows how an ABS collection or playlist maps to a shelf.
+type ABSShelfPreviewResponse struct {
+	ABSID          string `json:"abs_id" doc:"ABS collection or playlist ID"`
+	Name           string `json:"name" doc:"Name, used for the shelf"`
+	ABSUserID      string `json:"abs_user_id,omitempty" doc:"ABS owner (the root user for collections)"`
+	ListenUpUserID string `json:"listenup_user_id,omitempty" doc:"ListenUp user who will own the shelf"`
+	TotalBooks     int    `json:"total_books" doc:"Books in ABS"`
+	MappedBooks    int    `json:"mapped_books" doc:"Books mapped to ListenUp books"`
+	IsReady        bool   `json:"is_ready" doc:"Whether the owner and at least one book are mapped"`
+}
+
+// ABSTagPreviewResponse shows an ABS tag and the ListenUp tag it becomes.
+type ABSTagPreviewResponse struct {
+	Name        string `json:"name" doc:"Tag as written in ABS"`
+	Slug        string `json:"slug" doc:"ListenUp tag slug"`
+	TotalBooks  int    `json:"total_books" doc:"ABS books with this tag"`
+	MappedBooks int    `json:"mapped_books" doc:"Of those, books mapped to ListenUp books"`
+}
+
+// ABSBookmarkPreviewResponse summarizes one ABS user's bookmarks.
+type ABSBookmarkPreviewResponse struct {
+	ABSUserID      string `json:"abs_user_id" doc:"ABS user ID"`
+	ABSUsername    string `json:"abs_username" doc:"ABS username"`
+	ListenUpUserID string `json:"listenup_user_id,omitempty" doc:"Mapped ListenUp user ID"`
+	Total          int    `json:"total" doc:"Bookmarks in ABS"`
+	Ready          int    `json:"ready" doc:"Bookmarks whose user and book are mapped"`
+}
+
+// ABSLibraryPreviewResponse previews collections, playlists, tags and bookmarks.
+type ABSLibraryPreviewResponse struct {
+	CollectionsTotal int                          `json:"collections_total" doc:"Collections in backup"`
+	CollectionsReady int                          `json:"collections_ready" doc:"Collections that will become shelves"`
+	PlaylistsTotal   int                          `json:"playlists_total" doc:"Playlists in backup"`
+	PlaylistsReady   int                          `json:"playlists_ready" doc:"Playlists that will become shelves"`
+	TagsTotal        int                          `json:"tags_total" doc:"Distinct tags in backup"`
+	TagsReady        int                          `json:"tags_ready" doc:"Tags with at least one mapped book"`
+	BookmarksTotal   int                          `json:"bookmarks_total" doc:"Bookmarks in backup"`
+	BookmarksReady   int                          `json:"bookmarks_ready" doc:"Bookmarks whose user and book are mapped"`
+	Collections      []ABSShelfPreviewResponse    `json:"collections" doc:"Collection mappings"`
+	Playlists        []ABSShelfPreviewResponse    `json:"playlists" doc:"Playlist mappings"`
+	Tags             []ABSTagPreviewResponse      `json:"tags" doc:"Tag mappings"`
+	Bookmarks        []ABSBookmarkPreviewResponse `json:"bookmarks" doc:"Bookmarks per ABS user"`
+}
+
+type GetABSImportLibraryInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Import ID"`
+}
+
+type GetABSImportLibraryOutput struct {
+	Body ABSLibraryPreviewResponse
+}
+
+type ImportABSLibraryInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Import ID"`
+	Body          struct {
+		ImportShelves        bool `json:"import_shelves" required:"false" default:"true" doc:"Create shelves from collections and playlists"`
+		ImportTags           bool `json:"import_tags" required:"false" default:"true" doc:"Apply ABS tags to mapped books"`
+		ImportBookmarks      bool `json:"import_bookmarks" required:"false" default:"true" doc:"Copy bookmarks"`
+		ImportEbookPositions bool `json:"import_ebook_positions" required:"false" default:"true" doc:"Copy ebook reading positions"`
+	}
+}
+
+// ImportABSLibraryResponse reports what a library data import did.
+type ImportABSLibraryResponse struct {
+	ShelvesCreated         int      `json:"shelves_created" doc:"Shelves created from collections and playlists"`
+	ShelvesSkipped         int      `json:"shelves_skipped" doc:"Unmapped owner, no mapped books, or already imported"`
+	TagsCreated            int      `json:"tags_created" doc:"New ListenUp tags"`
+	BookTagsApplied        int      `json:"book_tags_applied" doc:"Tags added to books"`
+	BookmarksImported      int      `json:"bookmarks_imported" doc:"Bookmarks copied"`
+	BookmarksSkipped       int      `json:"bookmarks_skipped" doc:"Unmapped user or book, or already imported"`
+	EbookPositionsImported int      `json:"ebook_positions_imported" doc:"Ebook reading positions copied"`
+	EbookPositionsSkipped  int      `json:"ebook_positions_skipped" doc:"Unmapped, no ebook in ListenUp, or a newer position exists"`
+	Duration               string   `json:"duration" doc:"Import duration"`
+	Warnings               []string `json:"warnings,omitempty" doc:"Non-fatal warnings during import"`
+}
+
+type ImportABSLibraryOutput struct {
+	Body ImportABSLibraryResponse
+}
+
+type ImportABSPodcastsInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Import ID"`
+	Body          struct {
+		LibraryID string `json:"library_id" doc:"Podcast library to subscribe"`
+	}
+}
+
+// ImportABSPodcastsResponse reports what a podcast import did.
+type ImportABSPodcastsResponse struct {
+	PodcastsCreated  int      `json:"podcasts_created" doc:"Subscriptions created"`
+	PodcastsSkipped  int      `json:"podcasts_skipped" doc:"No feed URL, or already subscribed"`
+	EpisodesCreated  int      `json:"episodes_created" doc:"Episodes added"`
+	EpisodesLinked   int      `json:"episodes_linked" doc:"ABS downloads found on disk and kept"`
+	ProgressImported int      `json:"progress_imported" doc:"Episode progress entries copied"`
+	ProgressSkipped  int      `json:"progress_skipped" doc:"Unmapped user or unknown episode"`
+	Duration         string   `json:"duration" doc:"Import duration"`
+	Warnings         []string `json:"warnings,omitempty" doc:"Non-fatal warnings during import"`
+}
+
+type ImportABSPodcastsOutput struct {
+	Body ImportABSPodcastsResponse
+}
@@ -398,8 +398,16 @@ func (s *Server) handleValidateBackup(ctx context.Context, input *ValidateBackup
 			"collection_shares": result.ExpectedCounts.CollectionShares,
 			"shelves":           result.ExpectedCounts.Shelves,
 			"activities":        result.ExpectedCounts.Activities,
+			"bookmarks":         result.ExpectedCounts.Bookmarks,
+			"book_reviews":      result.ExpectedCounts.BookReviews,
+			"podcasts":          result.ExpectedCounts.Podcasts,
+			"podcast_episodes":  result.ExpectedCounts.PodcastEpisodes,
+			"episode_progress":  result.ExpectedCounts.EpisodeProgress,
+			"ebook_positions":   result.ExpectedCounts.EbookPositions,
+			"listening_goals":   result.ExpectedCounts.ListeningGoals,
 			"listening_events":  result.ExpectedCounts.ListeningEvents,
 			"reading_sessions":  result.ExpectedCounts.ReadingSessions,
+			"episode_events":    result.ExpectedCounts.EpisodeEvents,
 		}
 	}
 
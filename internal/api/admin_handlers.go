@@ -106,6 +106,16 @@ func (s *Server) registerAdminRoutes() {
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleDeleteAdminUser)
 
+	huma.Register(s.api, huma.Operation{
+		OperationID: "createPasswordReset",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/users/{id}/password-reset",
+		Summary:     "Reset user password",
+		Description: "Issues a one-time password reset link for a user, optionally emailing it to them. Any earlier link stops working.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleCreatePasswordReset)
+
 	// Open registration settings
 
 	huma.Register(s.api, huma.Operation{
@@ -273,6 +283,33 @@ type DeleteAdminUserInput struct {
 	ID            string `path:"id" doc:"User ID"`
 }
 
+// CreatePasswordResetRequest is the request body for issuing a password reset.
+type CreatePasswordResetRequest struct {
+	SendEmail      bool `json:"send_email,omitempty" doc:"Also email the link to the user (requires SMTP)"`
+	ExpiresInHours int  `json:"expires_in_hours,omitempty" validate:"omitempty,gte=1,lte=168" doc:"Hours until the link expires (default 24)"`
+}
+
+// CreatePasswordResetInput is the Huma input for issuing a password reset.
+type CreatePasswordResetInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"User ID"`
+	Body          CreatePasswordResetRequest
+}
+
+// PasswordResetResponse is the API response for an issued password reset.
+type PasswordResetResponse struct {
+	UserID    string    `json:"user_id" doc:"User the link resets"`
+	Code      string    `json:"code" doc:"One-time reset code; shown only once"`
+	URL       string    `json:"url" doc:"Reset page URL to share with the user"`
+	ExpiresAt time.Time `json:"expires_at" doc:"Expiration time"`
+	Emailed   bool      `json:"emailed" doc:"Whether the link was emailed to the user"`
+}
+
+// PasswordResetOutput is the Huma output wrapper for an issued password reset.
+type PasswordResetOutput struct {
+	Body PasswordResetResponse
+}
+
 // ListPendingUsersInput is the Huma input for listing pending users.
 type ListPendingUsersInput struct {
 	Authorization string `header:"Authorization"`
@@ -491,6 +528,31 @@ func (s *Server) handleDeleteAdminUser(ctx context.Context, input *DeleteAdminUs
 	return &MessageOutput{Body: MessageResponse{Message: "User deleted"}}, nil
 }
 
+func (s *Server) handleCreatePasswordReset(ctx context.Context, input *CreatePasswordResetInput) (*PasswordResetOutput, error) {
+	adminUserID, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.services.Password.CreatePasswordReset(ctx, adminUserID, input.ID, service.AdminPasswordResetRequest{
+		SendEmail:      input.Body.SendEmail,
+		ExpiresInHours: input.Body.ExpiresInHours,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordResetOutput{
+		Body: PasswordResetResponse{
+			UserID:    resp.Reset.UserID,
+			Code:      resp.Code,
+			URL:       resp.URL,
+			ExpiresAt: resp.Reset.ExpiresAt,
+			Emailed:   resp.Emailed,
+		},
+	}, nil
+}
+
 func (s *Server) handleListPendingUsers(ctx context.Context, _ *ListPendingUsersInput) (*ListUsersOutput, error) {
 	if _, err := s.RequireAdmin(ctx); err != nil {
 		return nil, err
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/listenupapp/listenup-server/internal/domain"
 	"github.com/listenupapp/listenup-server/internal/service"
 )
 
@@ -24,7 +25,7 @@ func (s *Server) registerAdminSettingsRoutes() {
 		Method:      http.MethodPatch,
 		Path:        "/api/v1/admin/settings",
 		Summary:     "Update server settings",
-		Description: "Updates server-wide settings (admin only)",
+		Description: "Updates server-wide settings, including the scheduled backup configuration (admin only)",
 		Tags:        []string{"Admin"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleUpdateServerSettings)
@@ -34,9 +35,25 @@ func (s *Server) registerAdminSettingsRoutes() {
 
 // ServerSettingsResponse is the API response for server settings.
 type ServerSettingsResponse struct {
-	ServerName   string `json:"server_name" doc:"Display name for the server"`
-	InboxEnabled bool   `json:"inbox_enabled" doc:"Whether inbox workflow is enabled"`
-	InboxCount   int    `json:"inbox_count" doc:"Number of books currently in inbox"`
+	ServerName   string                 `json:"server_name" doc:"Display name for the server"`
+	InboxEnabled bool                   `json:"inbox_enabled" doc:"Whether inbox workflow is enabled"`
+	InboxCount   int                    `json:"inbox_count" doc:"Number of books currently in inbox"`
+	Backup       BackupScheduleResponse `json:"backup" doc:"Scheduled backup configuration"`
+	TrashDays    int                    `json:"trash_retention_days" doc:"Days deleted books stay restorable (0 = until purged by hand)"`
+	SignedStream bool                   `json:"require_signed_stream_urls" doc:"Whether audio is only served from signed stream URLs, not access tokens in query strings"`
+	AdminTOTP    bool                   `json:"require_admin_two_factor" doc:"Whether admins must enable two-factor authentication to use admin features"`
+}
+
+// BackupScheduleResponse is the scheduled backup configuration in API responses.
+type BackupScheduleResponse struct {
+	Enabled       bool `json:"enabled" doc:"Whether scheduled backups are enabled"`
+	IntervalHours int  `json:"interval_hours" doc:"Hours between scheduled backups"`
+	IncludeImages bool `json:"include_images" doc:"Include cover images and avatars"`
+	IncludeEvents bool `json:"include_events" doc:"Include listening events"`
+	KeepLast      int  `json:"keep_last" doc:"Always keep this many of the newest scheduled backups"`
+	KeepDaily     int  `json:"keep_daily" doc:"Keep the newest backup for this many days"`
+	KeepWeekly    int  `json:"keep_weekly" doc:"Keep the newest backup for this many weeks"`
+	KeepMonthly   int  `json:"keep_monthly" doc:"Keep the newest backup for this many months"`
 }
 
 // GetServerSettingsInput is the Huma input for getting server settings.
@@ -51,8 +68,25 @@ type GetServerSettingsOutput struct {
 
 // UpdateServerSettingsRequest is the request body for updating settings.
 type UpdateServerSettingsRequest struct {
-	ServerName   *string `json:"server_name,omitempty" doc:"Display name for the server"`
-	InboxEnabled *bool   `json:"inbox_enabled,omitempty" doc:"Enable or disable inbox workflow"`
+	ServerName   *string                      `json:"server_name,omitempty" doc:"Display name for the server"`
+	InboxEnabled *bool                        `json:"inbox_enabled,omitempty" doc:"Enable or disable inbox workflow"`
+	Backup       *UpdateBackupScheduleRequest `json:"backup,omitempty" doc:"Scheduled backup configuration"`
+	TrashDays    *int                         `json:"trash_retention_days,omitempty" doc:"Days deleted books stay restorable (0-365, 0 = until purged by hand)"`
+	SignedStream *bool                        `json:"require_signed_stream_urls,omitempty" doc:"Only serve audio from signed stream URLs; rejects access tokens in query strings"`
+	AdminTOTP    *bool                        `json:"require_admin_two_factor,omitempty" doc:"Make two-factor authentication mandatory for admins"`
+}
+
+// UpdateBackupScheduleRequest is the request body for updating the backup schedule.
+// Omitted fields are left unchanged.
+type UpdateBackupScheduleRequest struct {
+	Enabled       *bool `json:"enabled,omitempty" doc:"Enable or disable scheduled backups"`
+	IntervalHours *int  `json:"interval_hours,omitempty" doc:"Hours between scheduled backups (1-720)"`
+	IncludeImages *bool `json:"include_images,omitempty" doc:"Include cover images and avatars"`
+	IncludeEvents *bool `json:"include_events,omitempty" doc:"Include listening events"`
+	KeepLast      *int  `json:"keep_last,omitempty" doc:"Always keep this many of the newest scheduled backups"`
+	KeepDaily     *int  `json:"keep_daily,omitempty" doc:"Keep the newest backup for this many days"`
+	KeepWeekly    *int  `json:"keep_weekly,omitempty" doc:"Keep the newest backup for this many weeks"`
+	KeepMonthly   *int  `json:"keep_monthly,omitempty" doc:"Keep the newest backup for this many months"`
 }
 
 // UpdateServerSettingsInput is the Huma input for updating server settings.
@@ -90,6 +124,10 @@ func (s *Server) handleGetServerSettings(ctx context.Context, _ *GetServerSettin
 			ServerName:   settings.GetDisplayName(),
 			InboxEnabled: settings.InboxEnabled,
 			InboxCount:   inboxCount,
+			Backup:       backupScheduleResponse(settings.Backup),
+			TrashDays:    settings.TrashRetentionDays,
+			SignedStream: settings.RequireSignedStreamURLs,
+			AdminTOTP:    settings.RequireAdminTwoFactor,
 		},
 	}, nil
 }
@@ -100,10 +138,27 @@ func (s *Server) handleUpdateServerSettings(ctx context.Context, input *UpdateSe
 		return nil, err
 	}
 
-	settings, err := s.services.Settings.UpdateServerSettings(ctx, &service.SettingsUpdate{
-		Name:         input.Body.ServerName,
-		InboxEnabled: input.Body.InboxEnabled,
-	})
+	update := &service.SettingsUpdate{
+		Name:                    input.Body.ServerName,
+		InboxEnabled:            input.Body.InboxEnabled,
+		TrashRetentionDays:      input.Body.TrashDays,
+		RequireSignedStreamURLs: input.Body.SignedStream,
+		RequireAdminTwoFactor:   input.Body.AdminTOTP,
+	}
+	if b := input.Body.Backup; b != nil {
+		update.Backup = &service.BackupScheduleUpdate{
+			Enabled:       b.Enabled,
+			IntervalHours: b.IntervalHours,
+			IncludeImages: b.IncludeImages,
+			IncludeEvents: b.IncludeEvents,
+			KeepLast:      b.KeepLast,
+			KeepDaily:     b.KeepDaily,
+			KeepWeekly:    b.KeepWeekly,
+			KeepMonthly:   b.KeepMonthly,
+		}
+	}
+
+	settings, err := s.services.Settings.UpdateServerSettings(ctx, update)
 	if err != nil {
 		return nil, huma.Error500InternalServerError("failed to update settings", err)
 	}
@@ -119,6 +174,23 @@ func (s *Server) handleUpdateServerSettings(ctx context.Context, input *UpdateSe
 			ServerName:   settings.GetDisplayName(),
 			InboxEnabled: settings.InboxEnabled,
 			InboxCount:   inboxCount,
+			Backup:       backupScheduleResponse(settings.Backup),
+			TrashDays:    settings.TrashRetentionDays,
+			SignedStream: settings.RequireSignedStreamURLs,
+			AdminTOTP:    settings.RequireAdminTwoFactor,
 		},
 	}, nil
 }
+
+func backupScheduleResponse(b domain.BackupSchedule) BackupScheduleResponse {
+	return BackupScheduleResponse{
+		Enabled:       b.Enabled,
+		IntervalHours: b.IntervalHours,
+		IncludeImages: b.IncludeImages,
+		IncludeEvents: b.IncludeEvents,
+		KeepLast:      b.KeepLast,
+		KeepDaily:     b.KeepDaily,
+		KeepWeekly:    b.KeepWeekly,
+		KeepMonthly:   b.KeepMonthly,
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+func (s *Server) registerAdminTrashRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "deleteBook",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/books/{id}",
+		Summary:     "Delete book",
+		Description: "Moves a book to the trash (admin only). With delete_files, the book's folder is removed from disk when the book is purged.",
+		Tags:        []string{"Books", "Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleDeleteBook)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listTrash",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/trash",
+		Summary:     "List trash",
+		Description: "Lists deleted books that can still be restored (admin only)",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListTrash)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "restoreTrashedBook",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/trash/{id}/restore",
+		Summary:     "Restore book",
+		Description: "Restores a book from the trash, putting it back on its shelves and in its collections (admin only)",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRestoreTrashedBook)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "purgeTrashedBook",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/admin/trash/{id}",
+		Summary:     "Purge book",
+		Description: "Permanently deletes a book from the trash without waiting for the retention period (admin only)",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handlePurgeTrashedBook)
+}
+
+// === DTOs ===
+
+// TrashedBookResponse is the API response for a book in the trash.
+type TrashedBookResponse struct {
+	BookID      string     `json:"book_id" doc:"Book ID"`
+	Title       string     `json:"title" doc:"Book title at the time of deletion"`
+	Path        string     `json:"path" doc:"Book folder on disk"`
+	DeletedBy   string     `json:"deleted_by" doc:"ID of the admin who deleted the book"`
+	DeletedAt   time.Time  `json:"deleted_at" doc:"When the book was deleted"`
+	PurgeAt     *time.Time `json:"purge_at,omitempty" doc:"When the book will be purged; absent if it is kept until purged by hand"`
+	DeleteFiles bool       `json:"delete_files" doc:"Whether the book's files are removed from disk on purge"`
+}
+
+// DeleteBookInput is the Huma input for deleting a book.
+type DeleteBookInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	DeleteFiles   bool   `query:"delete_files" doc:"Also delete the book's files from disk when it is purged"`
+}
+
+// TrashedBookOutput is the Huma output wrapper for a trashed book.
+type TrashedBookOutput struct {
+	Body TrashedBookResponse
+}
+
+// ListTrashInput is the Huma input for listing the trash.
+type ListTrashInput struct {
+	Authorization string `header:"Authorization"`
+}
+
+// ListTrashResponse is the API response for listing the trash.
+type ListTrashResponse struct {
+	Books []TrashedBookResponse `json:"books" doc:"Books in the trash, most recently deleted first"`
+	Total int                   `json:"total" doc:"Total count"`
+}
+
+// ListTrashOutput is the Huma output wrapper for listing the trash.
+type ListTrashOutput struct {
+	Body ListTrashResponse
+}
+
+// TrashedBookInput is the Huma input for acting on a trashed book.
+type TrashedBookInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+}
+
+// === Handlers ===
+
+func (s *Server) handleDeleteBook(ctx context.Context, input *DeleteBookInput) (*TrashedBookOutput, error) {
+	adminID, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.services.Trash.DeleteBook(ctx, adminID, input.ID, input.DeleteFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrashedBookOutput{Body: trashedBookResponse(entry)}, nil
+}
+
+func (s *Server) handleListTrash(ctx context.Context, _ *ListTrashInput) (*ListTrashOutput, error) {
+	if _, err := s.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.services.Trash.ListTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]TrashedBookResponse, len(entries))
+	for i, entry := range entries {
+		resp[i] = trashedBookResponse(entry)
+	}
+
+	return &ListTrashOutput{
+		Body: ListTrashResponse{Books: resp, Total: len(resp)},
+	}, nil
+}
+
+func (s *Server) handleRestoreTrashedBook(ctx context.Context, input *TrashedBookInput) (*BookOutput, error) {
+	if _, err := s.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	book, err := s.services.Trash.RestoreBook(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	enriched, err := s.enricher.EnrichBook(ctx, book)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookOutput{Body: mapEnrichedBookResponse(enriched)}, nil
+}
+
+func (s *Server) handlePurgeTrashedBook(ctx context.Context, input *TrashedBookInput) (*MessageOutput, error) {
+	if _, err := s.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Trash.PurgeBook(ctx, input.ID); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Book purged"}}, nil
+}
+
+func trashedBookResponse(entry *domain.TrashedBook) TrashedBookResponse {
+	return TrashedBookResponse{
+		BookID:      entry.BookID,
+		Title:       entry.Title,
+		Path:        entry.Path,
+		DeletedBy:   entry.DeletedBy,
+		DeletedAt:   entry.DeletedAt,
+		PurgeAt:     entry.PurgeAt,
+		DeleteFiles: entry.DeleteFiles,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/listenupapp/listenup-server/internal/domain"
 )
 
 // NOTE: Audio streaming routes are registered directly on chi (not Huma) because they
@@ -15,8 +16,11 @@ import (
 //
 //	GET /api/v1/audio/{bookId}/{fileId} - Stream audio file
 //	GET /api/v1/books/{bookId}/audio/{fileId} - Stream audio file (alias)
+//	GET /api/v1/stream/{signature}/{bookId}/{fileId} - Stream audio file from a signed URL
 //	GET /api/v1/audio/{bookId}/{fileId}/transcode/{*} - Stream transcoded audio
 //	GET /api/v1/books/{bookId}/audio/{fileId}/transcode/{*} - Stream transcoded audio (alias)
+//	    ({*} may start with a variant, e.g. normalized/playlist.m3u8)
+//	GET /api/v1/stream/{signature}/{bookId}/{fileId}/transcode/{*} - Stream transcoded audio from a signed URL
 //
 // registerAudioRoutes sets up audio streaming routes.
 // These are handled directly by chi for performance (not huma).
@@ -29,41 +33,80 @@ func (s *Server) registerAudioRoutes() {
 	s.router.Get("/api/v1/books/{bookId}/audio/{fileId}", s.handleStreamAudio)
 	s.router.Head("/api/v1/books/{bookId}/audio/{fileId}", s.handleStreamAudio)
 
+	// Signed stream URLs (see stream_url_handlers.go)
+	s.router.Get("/api/v1/stream/{signature}/{bookId}/{fileId}", s.handleStreamAudio)
+	s.router.Head("/api/v1/stream/{signature}/{bookId}/{fileId}", s.handleStreamAudio)
+
 	// Transcoded audio (HLS segments, etc.)
 	s.router.Get("/api/v1/audio/{bookId}/{fileId}/transcode/{*}", s.handleTranscodedAudio)
 	s.router.Head("/api/v1/audio/{bookId}/{fileId}/transcode/{*}", s.handleTranscodedAudio)
 	s.router.Get("/api/v1/books/{bookId}/audio/{fileId}/transcode/{*}", s.handleTranscodedAudio)
 	s.router.Head("/api/v1/books/{bookId}/audio/{fileId}/transcode/{*}", s.handleTranscodedAudio)
+	s.router.Get("/api/v1/stream/{signature}/{bookId}/{fileId}/transcode/{*}", s.handleTranscodedAudio)
+	s.router.Head("/api/v1/stream/{signature}/{bookId}/{fileId}/transcode/{*}", s.handleTranscodedAudio)
 }
 
-// handleStreamAudio streams audio files with range request support.
-func (s *Server) handleStreamAudio(w http.ResponseWriter, r *http.Request) {
-	bookID := chi.URLParam(r, "bookId")
-	fileID := chi.URLParam(r, "fileId")
-
-	// Extract token from query or header
-	token := r.URL.Query().Get("token")
-	if token == "" {
-		authHeader := r.Header.Get("Authorization")
-		if strings.HasPrefix(authHeader, "Bearer ") {
-			token = authHeader[7:]
+// authenticateAudio resolves the user behind an audio request. Signed stream
+// URLs carry their signature in the path; otherwise a Bearer header is used,
+// or an access token in the query string unless the server requires signed
+// URLs. On failure it writes the error response and returns false.
+func (s *Server) authenticateAudio(w http.ResponseWriter, r *http.Request, bookID, fileID string) (string, bool) {
+	if signature := chi.URLParam(r, "signature"); signature != "" {
+		userID, err := s.services.StreamURL.Verify(signature, bookID, fileID, remoteIP(r.RemoteAddr))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return "", false
+		}
+		return userID, true
+	}
+
+	var token string
+	if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		token = authHeader[7:]
+	} else if token = r.URL.Query().Get("token"); token != "" {
+		allowed, err := s.services.StreamURL.QueryTokensAllowed(r.Context())
+		if err != nil {
+			s.logger.Error("failed to check stream url setting", "error", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return "", false
+		}
+		if !allowed {
+			http.Error(w, "access tokens in URLs are disabled; use a signed stream URL", http.StatusUnauthorized)
+			return "", false
 		}
 	}
 
 	if token == "" {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
+		return "", false
 	}
 
 	// Verify token
 	user, _, err := s.services.Auth.VerifyAccessToken(r.Context(), token)
 	if err != nil {
 		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return "", false
+	}
+	return user.ID, true
+}
+
+// handleStreamAudio streams audio files with range request support.
+func (s *Server) handleStreamAudio(w http.ResponseWriter, r *http.Request) {
+	bookID := chi.URLParam(r, "bookId")
+	fileID := chi.URLParam(r, "fileId")
+
+	userID, ok := s.authenticateAudio(w, r, bookID, fileID)
+	if !ok {
 		return
 	}
 
+	s.serveAudioFile(w, r, userID, bookID, fileID)
+}
+
+// serveAudioFile streams one of a book's audio files to an authenticated user.
+func (s *Server) serveAudioFile(w http.ResponseWriter, r *http.Request, userID, bookID, fileID string) {
 	// Get book to verify access
-	book, err := s.services.Book.GetBook(r.Context(), user.ID, bookID)
+	book, err := s.services.Book.GetBook(r.Context(), userID, bookID)
 	if err != nil {
 		http.Error(w, "book not found", http.StatusNotFound)
 		return
@@ -103,36 +146,29 @@ func (s *Server) handleTranscodedAudio(w http.ResponseWriter, r *http.Request) {
 	fileID := chi.URLParam(r, "fileId")
 	transcodePath := chi.URLParam(r, "*")
 
-	// Extract token
-	token := r.URL.Query().Get("token")
-	if token == "" {
-		authHeader := r.Header.Get("Authorization")
-		if strings.HasPrefix(authHeader, "Bearer ") {
-			token = authHeader[7:]
-		}
-	}
-
-	if token == "" {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	// Verify token
-	user, _, err := s.services.Auth.VerifyAccessToken(r.Context(), token)
-	if err != nil {
-		http.Error(w, "invalid token", http.StatusUnauthorized)
+	userID, ok := s.authenticateAudio(w, r, bookID, fileID)
+	if !ok {
 		return
 	}
 
 	// Verify book access
-	_, err = s.services.Book.GetBook(r.Context(), user.ID, bookID)
+	_, err := s.services.Book.GetBook(r.Context(), userID, bookID)
 	if err != nil {
 		http.Error(w, "book not found", http.StatusNotFound)
 		return
 	}
 
+	// A leading variant segment (e.g. normalized/playlist.m3u8) picks that
+	// variant's stream; without one, any available transcode is served.
+	var variant *domain.TranscodeVariant
+	if first, rest, found := strings.Cut(transcodePath, "/"); found && domain.TranscodeVariant(first).IsHLS() {
+		v := domain.TranscodeVariant(first)
+		variant = &v
+		transcodePath = rest
+	}
+
 	// Get HLS path from transcode service
-	hlsPath, ok := s.services.Transcode.GetHLSPath(r.Context(), fileID, nil)
+	hlsPath, ok := s.services.Transcode.GetHLSPath(r.Context(), fileID, variant)
 	if !ok {
 		http.Error(w, "transcoded file not ready", http.StatusNotFound)
 		return
@@ -75,6 +75,10 @@ func (s *Server) RequireAdmin(ctx context.Context) (string, error) {
 		return "", domainerrors.Forbidden("Admin access required")
 	}
 
+	if err := s.services.TwoFactor.CheckAdminAccess(ctx, user); err != nil {
+		return "", err
+	}
+
 	return userID, nil
 }
 
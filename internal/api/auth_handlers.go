@@ -38,7 +38,7 @@ func (s *Server) registerAuthRoutes() {
 		Method:      http.MethodPost,
 		Path:        "/api/v1/auth/login",
 		Summary:     "User login",
-		Description: "Authenticates a user and returns access and refresh tokens",
+		Description: "Authenticates a user and returns access and refresh tokens. For users with two-factor authentication, returns two_factor_required and a challenge_token instead; complete the login at /api/v1/auth/login/two-factor.",
 		Tags:        []string{"Authentication"},
 	}, s.handleLogin)
 
@@ -60,6 +60,24 @@ func (s *Server) registerAuthRoutes() {
 		Tags:        []string{"Authentication"},
 	}, s.handleLogout)
 
+	huma.Register(s.api, huma.Operation{
+		OperationID: "forgotPassword",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/forgot-password",
+		Summary:     "Request password reset",
+		Description: "Emails a one-time reset link to the account with this address. Responds the same whether or not the account exists. Fails if the server has no email configured.",
+		Tags:        []string{"Authentication"},
+	}, s.handleForgotPassword)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "resetPassword",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/reset-password",
+		Summary:     "Reset password",
+		Description: "Redeems a one-time reset code and sets a new password. Every session of the user is signed out.",
+		Tags:        []string{"Authentication"},
+	}, s.handleResetPassword)
+
 	huma.Register(s.api, huma.Operation{
 		OperationID: "checkRegistrationStatus",
 		Method:      http.MethodGet,
@@ -172,6 +190,31 @@ type RegisterOutput struct {
 	Body RegisterResponse
 }
 
+// ForgotPasswordRequest is the request body for requesting a reset email.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email,max=254" doc:"Account email address"`
+}
+
+// ForgotPasswordInput wraps the forgot password request for Huma.
+type ForgotPasswordInput struct {
+	Body          ForgotPasswordRequest
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+}
+
+// ResetPasswordRequest is the request body for redeeming a reset code.
+type ResetPasswordRequest struct {
+	Code        string `json:"code" validate:"required,max=100" doc:"One-time reset code"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=1024" doc:"New password"`
+}
+
+// ResetPasswordInput wraps the reset password request for Huma.
+type ResetPasswordInput struct {
+	Body          ResetPasswordRequest
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+}
+
 // CheckRegistrationStatusInput is the Huma input for checking registration status.
 type CheckRegistrationStatusInput struct {
 	UserID string `path:"user_id" doc:"User ID from registration"`
@@ -213,6 +256,9 @@ type AuthResponse struct {
 	TokenType    string       `json:"token_type" doc:"Token type (Bearer)"`
 	ExpiresIn    int          `json:"expires_in" doc:"Token expiry in seconds"`
 	User         UserResponse `json:"user" doc:"Authenticated user"`
+
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty" doc:"Password accepted; send a two-factor code with challenge_token to finish signing in"`
+	ChallengeToken    string `json:"challenge_token,omitempty" doc:"Short-lived token for the two-factor step; expires_in applies to it"`
 }
 
 // AuthOutput wraps the auth response for Huma.
@@ -356,6 +402,36 @@ func (s *Server) handleLogout(ctx context.Context, input *LogoutInput) (*Message
 	return &MessageOutput{Body: MessageResponse{Message: "Logged out successfully"}}, nil
 }
 
+func (s *Server) handleForgotPassword(ctx context.Context, input *ForgotPasswordInput) (*MessageOutput, error) {
+	// Rate limit reset emails (prevents mailbox flooding)
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Password.RequestPasswordReset(ctx, input.Body.Email); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "If an account exists for that address, a reset link has been sent"}}, nil
+}
+
+func (s *Server) handleResetPassword(ctx context.Context, input *ResetPasswordInput) (*MessageOutput, error) {
+	// Rate limit code guesses
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	req := service.ResetPasswordRequest{
+		Code:        input.Body.Code,
+		NewPassword: input.Body.NewPassword,
+	}
+	if err := s.services.Password.ResetPassword(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Password reset. Sign in with your new password."}}, nil
+}
+
 func (s *Server) handleCheckRegistrationStatus(ctx context.Context, input *CheckRegistrationStatusInput) (*RegistrationStatusOutput, error) {
 	user, err := s.services.Auth.GetUser(ctx, input.UserID)
 	if err != nil {
@@ -390,6 +466,14 @@ func (s *Server) handleCheckRegistrationStatus(ctx context.Context, input *Check
 // === Helpers ===
 
 func (s *Server) mapAuthResponse(ctx context.Context, resp *service.AuthResponse) AuthResponse {
+	if c := resp.TwoFactorChallenge; c != nil {
+		return AuthResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    c.Token,
+			ExpiresIn:         c.ExpiresIn,
+		}
+	}
+
 	// Get avatar info from profile (optional - may not exist)
 	avatarType := "auto"
 	avatarValue := ""
@@ -310,3 +310,144 @@ func TestLogout_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, resp.Code)
 }
+
+func TestChangePassword_RevokesOtherSessions(t *testing.T) {
+	t.Parallel()
+	ts := setupTestServer(t)
+
+	resp := ts.api.Post("/api/v1/auth/setup", map[string]any{
+		"email":      "admin@example.com",
+		"password":   "SecurePassword123!",
+		"first_name": "Admin",
+		"last_name":  "User",
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var setupEnvelope testEnvelope[AuthResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &setupEnvelope))
+	token := setupEnvelope.Data.AccessToken
+
+	// Wrong current password is rejected
+	resp = ts.api.Post("/api/v1/users/me/password", "Authorization: Bearer "+token, map[string]any{
+		"current_password": "WrongPassword123!",
+		"new_password":     "NewPassword456!",
+	})
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	resp = ts.api.Post("/api/v1/users/me/password", "Authorization: Bearer "+token, map[string]any{
+		"current_password": "SecurePassword123!",
+		"new_password":     "NewPassword456!",
+		"device_info": map[string]any{
+			"device_type": "mobile",
+			"platform":    "iOS",
+		},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var changeEnvelope testEnvelope[AuthResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &changeEnvelope))
+	assert.NotEmpty(t, changeEnvelope.Data.RefreshToken)
+	assert.NotEqual(t, setupEnvelope.Data.SessionID, changeEnvelope.Data.SessionID)
+
+	// The setup session was revoked; the new one still works
+	resp = ts.api.Post("/api/v1/auth/refresh", map[string]any{
+		"refresh_token": setupEnvelope.Data.RefreshToken,
+	})
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	resp = ts.api.Post("/api/v1/auth/refresh", map[string]any{
+		"refresh_token": changeEnvelope.Data.RefreshToken,
+	})
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// Only the new password logs in
+	login := func(password string) int {
+		return ts.api.Post("/api/v1/auth/login", map[string]any{
+			"email":    "admin@example.com",
+			"password": password,
+			"device_info": map[string]any{
+				"device_type": "mobile",
+				"platform":    "iOS",
+			},
+		}).Code
+	}
+	assert.Equal(t, http.StatusUnauthorized, login("SecurePassword123!"))
+	assert.Equal(t, http.StatusOK, login("NewPassword456!"))
+}
+
+func TestResetPassword_InvalidCode(t *testing.T) {
+	t.Parallel()
+	ts := setupTestServer(t)
+
+	resp := ts.api.Post("/api/v1/auth/reset-password", map[string]any{
+		"code":         "not-a-real-code",
+		"new_password": "NewPassword456!",
+	})
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	// Without SMTP there is no emailed recovery
+	resp = ts.api.Post("/api/v1/auth/forgot-password", map[string]any{
+		"email": "admin@example.com",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestSessions_RevokeOthers(t *testing.T) {
+	t.Parallel()
+	ts := setupTestServer(t)
+
+	resp := ts.api.Post("/api/v1/auth/setup", map[string]any{
+		"email":      "admin@example.com",
+		"password":   "SecurePassword123!",
+		"first_name": "Admin",
+		"last_name":  "User",
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var setupEnvelope testEnvelope[AuthResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &setupEnvelope))
+	token := setupEnvelope.Data.AccessToken
+
+	resp = ts.api.Post("/api/v1/auth/login", map[string]any{
+		"email":    "admin@example.com",
+		"password": "SecurePassword123!",
+		"device_info": map[string]any{
+			"device_type":  "mobile",
+			"platform":     "iOS",
+			"device_model": "iPhone 15 Pro",
+		},
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+	var loginEnvelope testEnvelope[AuthResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &loginEnvelope))
+
+	resp = ts.api.Get("/api/v1/users/me/sessions", "Authorization: Bearer "+token)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var listEnvelope testEnvelope[ListSessionsResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &listEnvelope))
+	require.Len(t, listEnvelope.Data.Sessions, 2)
+	assert.Equal(t, "iPhone 15 Pro", listEnvelope.Data.Sessions[1].DeviceModel)
+	assert.NotContains(t, resp.Body.String(), "refresh_token_hash")
+
+	resp = ts.api.Post("/api/v1/users/me/sessions/revoke-others", "Authorization: Bearer "+token, map[string]any{
+		"current_session_id": setupEnvelope.Data.SessionID,
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var revokeEnvelope testEnvelope[RevokeSessionsResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &revokeEnvelope))
+	assert.Equal(t, 1, revokeEnvelope.Data.Revoked)
+
+	// The phone is signed out; this device is not
+	resp = ts.api.Post("/api/v1/auth/refresh", map[string]any{
+		"refresh_token": loginEnvelope.Data.RefreshToken,
+	})
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	resp = ts.api.Delete("/api/v1/users/me/sessions/"+loginEnvelope.Data.SessionID, "Authorization: Bearer "+token)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = ts.api.Post("/api/v1/auth/refresh", map[string]any{
+		"refresh_token": setupEnvelope.Data.RefreshToken,
+	})
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/listenupapp/listenup-server/internal/domain"
 	"github.com/listenupapp/listenup-server/internal/dto"
+	"github.com/listenupapp/listenup-server/internal/metadata"
 	"github.com/listenupapp/listenup-server/internal/service"
 	"github.com/listenupapp/listenup-server/internal/store"
 )
@@ -89,7 +90,7 @@ func (s *Server) registerBookRoutes() {
 		Method:      http.MethodPost,
 		Path:        "/api/v1/books/{id}/match",
 		Summary:     "Apply metadata match",
-		Description: "Applies Audible metadata to a book based on user selections",
+		Description: "Applies metadata from Audible or another configured provider to a book based on user selections",
 		Tags:        []string{"Books", "Metadata"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleApplyBookMatch)
@@ -103,11 +104,13 @@ type ListBooksInput struct {
 	Limit         int    `query:"limit" default:"50" minimum:"1" maximum:"100" doc:"Items per page"`
 	Cursor        string `query:"cursor" doc:"Pagination cursor"`
 	UpdatedAfter  string `query:"updated_after" doc:"Filter by updated time (RFC3339)"`
+	LibraryID     string `query:"library_id" doc:"Only list books in this library"`
 }
 
 // BookResponse contains book data in API responses.
 type BookResponse struct {
 	ID           string                    `json:"id" doc:"Book ID"`
+	LibraryID    string                    `json:"library_id,omitempty" doc:"Library the book belongs to"`
 	Title        string                    `json:"title" doc:"Book title"`
 	Subtitle     string                    `json:"subtitle,omitempty" doc:"Book subtitle"`
 	Description  string                    `json:"description,omitempty" doc:"Book description"`
@@ -122,6 +125,7 @@ type BookResponse struct {
 	Series       []BookSeriesResponse      `json:"series,omitempty" doc:"Series memberships"`
 	GenreIDs     []string                  `json:"genre_ids,omitempty" doc:"Genre IDs"`
 	AudioFiles   []AudioFileResponse       `json:"audio_files" doc:"Audio files"`
+	EbookFiles   []EbookFileResponse       `json:"ebook_files,omitempty" doc:"Companion ebooks"`
 	CreatedAt    time.Time                 `json:"created_at" doc:"Creation time"`
 	UpdatedAt    time.Time                 `json:"updated_at" doc:"Last update time"`
 }
@@ -151,6 +155,14 @@ type AudioFileResponse struct {
 	Bitrate  int    `json:"bitrate" doc:"Bitrate in bps"`
 }
 
+// EbookFileResponse represents a companion ebook in book responses.
+type EbookFileResponse struct {
+	ID       string `json:"id" doc:"Ebook file ID"`
+	Filename string `json:"filename" doc:"File name"`
+	Format   string `json:"format" doc:"Ebook format (epub or pdf)"`
+	Size     int64  `json:"size" doc:"Size in bytes"`
+}
+
 // ListBooksResponse contains a paginated list of books.
 type ListBooksResponse struct {
 	Items      []BookResponse `json:"items" doc:"Books"`
@@ -259,16 +271,18 @@ type SetGenresInput struct {
 	Body          SetGenresRequest
 }
 
-// ApplyMatchRequest is the request body for applying Audible metadata.
+// ApplyMatchRequest is the request body for applying provider metadata.
 type ApplyMatchRequest struct {
-	ASIN      string             `json:"asin" doc:"Audible ASIN"`
+	Provider  string             `json:"provider,omitempty" doc:"Metadata provider (default audible)" enum:"audible,itunes,openlibrary,googlebooks,hardcover"`
+	ID        string             `json:"id,omitempty" doc:"Provider book ID (for Audible, the ASIN)"`
+	ASIN      string             `json:"asin,omitempty" doc:"Audible ASIN"`
 	Region    string             `json:"region,omitempty" doc:"Audible region"`
 	Fields    MatchFieldsRequest `json:"fields" doc:"Fields to apply"`
-	Authors   []string           `json:"authors,omitempty" doc:"Author ASINs to apply"`
-	Narrators []string           `json:"narrators,omitempty" doc:"Narrator ASINs to apply"`
+	Authors   []string           `json:"authors,omitempty" doc:"Author IDs (or names, for providers without IDs) to apply"`
+	Narrators []string           `json:"narrators,omitempty" doc:"Narrator IDs (or names, for providers without IDs) to apply"`
 	Series    []SeriesMatchInput `json:"series,omitempty" doc:"Series to apply"`
 	Genres    []string           `json:"genres,omitempty" doc:"Genre names to apply"`
-	CoverURL  string             `json:"cover_url,omitempty" doc:"Explicit cover URL to download (overrides Audible cover if provided)"`
+	CoverURL  string             `json:"cover_url,omitempty" doc:"Explicit cover URL to download (overrides the provider cover if provided)"`
 }
 
 // MatchFieldsRequest specifies which metadata fields to apply.
@@ -280,11 +294,13 @@ type MatchFieldsRequest struct {
 	ReleaseDate bool `json:"releaseDate,omitempty" doc:"Apply release date"`
 	Language    bool `json:"language,omitempty" doc:"Apply language"`
 	Cover       bool `json:"cover,omitempty" doc:"Apply cover"`
+	ASIN        bool `json:"asin,omitempty" doc:"Store the provider's ASIN even when nothing else is applied"`
 }
 
 // SeriesMatchInput specifies series metadata to apply.
 type SeriesMatchInput struct {
 	ASIN          string `json:"asin,omitempty" doc:"Series ASIN"`
+	ID            string `json:"id,omitempty" doc:"Provider series ID (or name, for providers without IDs)"`
 	ApplyName     bool   `json:"applyName,omitempty" doc:"Apply series name"`
 	ApplySequence bool   `json:"applySequence,omitempty" doc:"Apply sequence"`
 }
@@ -325,8 +341,9 @@ func (s *Server) handleListBooks(ctx context.Context, input *ListBooksInput) (*L
 	}
 
 	params := store.PaginationParams{
-		Limit:  input.Limit,
-		Cursor: input.Cursor,
+		Limit:     input.Limit,
+		Cursor:    input.Cursor,
+		LibraryID: input.LibraryID,
 	}
 	if input.UpdatedAfter != "" {
 		t, err := time.Parse(time.RFC3339, input.UpdatedAfter)
@@ -529,8 +546,19 @@ func mapEnrichedBookResponse(b *dto.Book) BookResponse {
 		}
 	}
 
+	var ebookFiles []EbookFileResponse
+	for _, eb := range b.EbookFiles {
+		ebookFiles = append(ebookFiles, EbookFileResponse{
+			ID:       eb.ID,
+			Filename: eb.Filename,
+			Format:   eb.Format,
+			Size:     eb.Size,
+		})
+	}
+
 	return BookResponse{
 		ID:           b.ID,
+		LibraryID:    b.LibraryID,
 		Title:        b.Title,
 		Subtitle:     b.Subtitle,
 		Description:  b.Description,
@@ -545,15 +573,26 @@ func mapEnrichedBookResponse(b *dto.Book) BookResponse {
 		Series:       series,
 		GenreIDs:     b.GenreIDs,
 		AudioFiles:   audioFiles,
+		EbookFiles:   ebookFiles,
 		CreatedAt:    b.CreatedAt,
 		UpdatedAt:    b.UpdatedAt,
 	}
 }
 
 func (s *Server) handleApplyBookMatch(ctx context.Context, input *ApplyMatchInput) (*ApplyMatchOutput, error) {
-	// Validate ASIN is present
-	if input.Body.ASIN == "" {
-		return nil, huma.Error400BadRequest("ASIN is required")
+	provider := input.Body.Provider
+	if provider == "" {
+		provider = metadata.ProviderAudible
+	}
+	id := input.Body.ID
+	if id == "" && provider == metadata.ProviderAudible {
+		id = input.Body.ASIN
+	}
+	if id == "" {
+		if provider == metadata.ProviderAudible {
+			return nil, huma.Error400BadRequest("ASIN is required")
+		}
+		return nil, huma.Error400BadRequest("id is required")
 	}
 
 	userID, err := GetUserID(ctx)
@@ -571,6 +610,7 @@ func (s *Server) handleApplyBookMatch(ctx context.Context, input *ApplyMatchInpu
 			ReleaseDate: input.Body.Fields.ReleaseDate,
 			Language:    input.Body.Fields.Language,
 			Cover:       input.Body.Fields.Cover,
+			ASIN:        input.Body.Fields.ASIN,
 		},
 		Authors:   input.Body.Authors,
 		Narrators: input.Body.Narrators,
@@ -580,19 +620,29 @@ func (s *Server) handleApplyBookMatch(ctx context.Context, input *ApplyMatchInpu
 
 	// Convert series entries
 	for _, se := range input.Body.Series {
+		seriesID := se.ID
+		if seriesID == "" {
+			seriesID = se.ASIN
+		}
 		opts.Series = append(opts.Series, service.SeriesMatchEntry{
-			ASIN:          se.ASIN,
+			ID:            seriesID,
 			ApplyName:     se.ApplyName,
 			ApplySequence: se.ApplySequence,
 		})
 	}
 
 	// Apply the match
-	result, err := s.services.Book.ApplyMatchWithCoverResult(ctx, userID, input.ID, input.Body.ASIN, input.Body.Region, opts)
+	var result *service.ApplyMatchResult
+	if provider == metadata.ProviderAudible {
+		result, err = s.services.Book.ApplyMatchWithCoverResult(ctx, userID, input.ID, id, input.Body.Region, opts)
+	} else {
+		result, err = s.services.Book.ApplyProviderMatch(ctx, userID, input.ID, provider, id, opts)
+	}
 	if err != nil {
 		s.logger.ErrorContext(ctx, "failed to apply book match",
 			slog.String("book_id", input.ID),
-			slog.String("asin", input.Body.ASIN),
+			slog.String("provider", provider),
+			slog.String("provider_id", id),
 			slog.String("region", input.Body.Region),
 			slog.String("error", err.Error()),
 		)
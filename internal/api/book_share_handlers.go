@@ -47,7 +47,7 @@ func (s *Server) handleGetBookSharePage(ctx context.Context, input *GetBookShare
 	}
 
 	// Get base URL from instance config
-	instance, err := s.services.Instance.GetInstance(ctx)
+	baseURL, err := s.shareBaseURL(ctx)
 	if err != nil {
 		// Graceful degradation: render a user-friendly HTML error page.
 		return &HTMLOutput{ //nolint:nilerr // graceful HTML error page for share links
@@ -56,12 +56,6 @@ func (s *Server) handleGetBookSharePage(ctx context.Context, input *GetBookShare
 		}, nil
 	}
 
-	baseURL := instance.RemoteURL
-	if baseURL == "" {
-		baseURL = instance.LocalURL
-	}
-	baseURL = strings.TrimRight(baseURL, "/")
-
 	// Detect platform from user agent
 	ua := strings.ToLower(input.UserAgent)
 	isAndroid := strings.Contains(ua, "android")
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+func (s *Server) registerBookmarkRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listAllBookmarks",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bookmarks",
+		Summary:     "List all bookmarks",
+		Description: "Returns the current user's bookmarks and clips across all books they can access, including ones imported from Audiobookshelf",
+		Tags:        []string{"Bookmarks"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListAllBookmarks)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listBookmarks",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/books/{id}/bookmarks",
+		Summary:     "List bookmarks",
+		Description: "Returns the current user's bookmarks and clips for a book, ordered by position",
+		Tags:        []string{"Bookmarks"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListBookmarks)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "createBookmark",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/books/{id}/bookmarks",
+		Summary:     "Create bookmark",
+		Description: "Creates a bookmark at a position, or a clip when end_position_ms is set. Idempotent when a client ID is supplied.",
+		Tags:        []string{"Bookmarks"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleCreateBookmark)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getBookmark",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/books/{id}/bookmarks/{bookmarkId}",
+		Summary:     "Get bookmark",
+		Description: "Returns a single bookmark owned by the current user",
+		Tags:        []string{"Bookmarks"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetBookmark)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "updateBookmark",
+		Method:      http.MethodPatch,
+		Path:        "/api/v1/books/{id}/bookmarks/{bookmarkId}",
+		Summary:     "Update bookmark",
+		Description: "Updates position, clip range, title or note of a bookmark",
+		Tags:        []string{"Bookmarks"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleUpdateBookmark)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "deleteBookmark",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/books/{id}/bookmarks/{bookmarkId}",
+		Summary:     "Delete bookmark",
+		Description: "Deletes a bookmark owned by the current user",
+		Tags:        []string{"Bookmarks"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleDeleteBookmark)
+}
+
+// === DTOs ===
+
+// BookmarkResponse contains bookmark data in API responses.
+type BookmarkResponse struct {
+	ID            string    `json:"id" doc:"Bookmark ID"`
+	BookID        string    `json:"book_id" doc:"Book ID"`
+	AudioFileID   string    `json:"audio_file_id,omitempty" doc:"Audio file the position falls in"`
+	PositionMs    int64     `json:"position_ms" doc:"Book-relative position in milliseconds"`
+	EndPositionMs *int64    `json:"end_position_ms,omitempty" doc:"Clip end position in milliseconds (clips only)"`
+	Title         string    `json:"title,omitempty" doc:"Bookmark title"`
+	Note          string    `json:"note,omitempty" doc:"Free-form note"`
+	CreatedAt     time.Time `json:"created_at" doc:"Created time"`
+	UpdatedAt     time.Time `json:"updated_at" doc:"Updated time"`
+}
+
+// ListAllBookmarksInput contains parameters for listing all of a user's bookmarks.
+type ListAllBookmarksInput struct {
+	Authorization string `header:"Authorization"`
+}
+
+// ListBookmarksInput contains parameters for listing bookmarks on a book.
+type ListBookmarksInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+}
+
+// ListBookmarksResponse contains a list of bookmarks.
+type ListBookmarksResponse struct {
+	Bookmarks []BookmarkResponse `json:"bookmarks" doc:"Bookmarks ordered by position"`
+}
+
+// ListBookmarksOutput wraps the list bookmarks response for Huma.
+type ListBookmarksOutput struct {
+	Body ListBookmarksResponse
+}
+
+// CreateBookmarkRequest is the request body for creating a bookmark.
+type CreateBookmarkRequest struct {
+	ID            string `json:"id,omitempty" doc:"Client-generated ID for offline creation"`
+	AudioFileID   string `json:"audio_file_id,omitempty" doc:"Audio file the position falls in"`
+	PositionMs    int64  `json:"position_ms" minimum:"0" doc:"Book-relative position in milliseconds"`
+	EndPositionMs *int64 `json:"end_position_ms,omitempty" doc:"Clip end position in milliseconds"`
+	Title         string `json:"title,omitempty" maxLength:"200" doc:"Bookmark title"`
+	Note          string `json:"note,omitempty" maxLength:"10000" doc:"Free-form note"`
+}
+
+// CreateBookmarkInput wraps the create bookmark request for Huma.
+type CreateBookmarkInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Body          CreateBookmarkRequest
+}
+
+// BookmarkOutput wraps a single bookmark response for Huma.
+type BookmarkOutput struct {
+	Body BookmarkResponse
+}
+
+// GetBookmarkInput contains parameters for getting a bookmark.
+type GetBookmarkInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	BookmarkID    string `path:"bookmarkId" doc:"Bookmark ID"`
+}
+
+// UpdateBookmarkRequest is the request body for updating a bookmark.
+type UpdateBookmarkRequest struct {
+	AudioFileID      *string `json:"audio_file_id,omitempty" doc:"Audio file the position falls in"`
+	PositionMs       *int64  `json:"position_ms,omitempty" doc:"Book-relative position in milliseconds"`
+	EndPositionMs    *int64  `json:"end_position_ms,omitempty" doc:"Clip end position in milliseconds"`
+	ClearEndPosition bool    `json:"clear_end_position,omitempty" doc:"Convert a clip back into a point bookmark"`
+	Title            *string `json:"title,omitempty" maxLength:"200" doc:"Bookmark title"`
+	Note             *string `json:"note,omitempty" maxLength:"10000" doc:"Free-form note"`
+}
+
+// UpdateBookmarkInput wraps the update bookmark request for Huma.
+type UpdateBookmarkInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	BookmarkID    string `path:"bookmarkId" doc:"Bookmark ID"`
+	Body          UpdateBookmarkRequest
+}
+
+// DeleteBookmarkInput contains parameters for deleting a bookmark.
+type DeleteBookmarkInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	BookmarkID    string `path:"bookmarkId" doc:"Bookmark ID"`
+}
+
+// === Handlers ===
+
+func (s *Server) handleListBookmarks(ctx context.Context, input *ListBookmarksInput) (*ListBookmarksOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks, err := s.services.Bookmark.ListBookmarks(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]BookmarkResponse, len(bookmarks))
+	for i, b := range bookmarks {
+		resp[i] = toBookmarkResponse(b)
+	}
+
+	return &ListBookmarksOutput{Body: ListBookmarksResponse{Bookmarks: resp}}, nil
+}
+
+func (s *Server) handleListAllBookmarks(ctx context.Context, _ *ListAllBookmarksInput) (*ListBookmarksOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks, err := s.services.Bookmark.ListAllBookmarks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]BookmarkResponse, len(bookmarks))
+	for i, b := range bookmarks {
+		resp[i] = toBookmarkResponse(b)
+	}
+
+	return &ListBookmarksOutput{Body: ListBookmarksResponse{Bookmarks: resp}}, nil
+}
+
+func (s *Server) handleCreateBookmark(ctx context.Context, input *CreateBookmarkInput) (*BookmarkOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := s.services.Bookmark.CreateBookmark(ctx, userID, input.ID, service.CreateBookmarkRequest{
+		ID:            input.Body.ID,
+		AudioFileID:   input.Body.AudioFileID,
+		PositionMs:    input.Body.PositionMs,
+		EndPositionMs: input.Body.EndPositionMs,
+		Title:         input.Body.Title,
+		Note:          input.Body.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookmarkOutput{Body: toBookmarkResponse(bookmark)}, nil
+}
+
+func (s *Server) handleGetBookmark(ctx context.Context, input *GetBookmarkInput) (*BookmarkOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := s.services.Bookmark.GetBookmark(ctx, userID, input.ID, input.BookmarkID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookmarkOutput{Body: toBookmarkResponse(bookmark)}, nil
+}
+
+func (s *Server) handleUpdateBookmark(ctx context.Context, input *UpdateBookmarkInput) (*BookmarkOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := s.services.Bookmark.UpdateBookmark(ctx, userID, input.ID, input.BookmarkID, service.UpdateBookmarkRequest{
+		AudioFileID:      input.Body.AudioFileID,
+		PositionMs:       input.Body.PositionMs,
+		EndPositionMs:    input.Body.EndPositionMs,
+		ClearEndPosition: input.Body.ClearEndPosition,
+		Title:            input.Body.Title,
+		Note:             input.Body.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookmarkOutput{Body: toBookmarkResponse(bookmark)}, nil
+}
+
+func (s *Server) handleDeleteBookmark(ctx context.Context, input *DeleteBookmarkInput) (*MessageOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Bookmark.DeleteBookmark(ctx, userID, input.ID, input.BookmarkID); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Bookmark deleted"}}, nil
+}
+
+func toBookmarkResponse(b *domain.Bookmark) BookmarkResponse {
+	return BookmarkResponse{
+		ID:            b.ID,
+		BookID:        b.BookID,
+		AudioFileID:   b.AudioFileID,
+		PositionMs:    b.PositionMs,
+		EndPositionMs: b.EndPositionMs,
+		Title:         b.Title,
+		Note:          b.Note,
+		CreatedAt:     b.CreatedAt,
+		UpdatedAt:     b.UpdatedAt,
+	}
+}
@@ -0,0 +1,371 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/chapters"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+func (s *Server) registerChapterRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getBookChapters",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/books/{id}/chapters",
+		Summary:     "Get book chapters",
+		Description: "Returns the book's chapters and how many have generic names like \"Chapter 1\"",
+		Tags:        []string{"Chapters"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetBookChapters)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "previewChapterNames",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/books/{id}/chapters/suggestions",
+		Summary:     "Preview chapter names",
+		Description: "Aligns the book's chapters with Audible's chapter list and suggests names. Nothing is saved.",
+		Tags:        []string{"Chapters"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handlePreviewChapterNames)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "applyChapterNames",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/books/{id}/chapters/apply",
+		Summary:     "Apply chapter names",
+		Description: "Renames chapters using previewed suggestions. Timestamps are kept.",
+		Tags:        []string{"Chapters"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleApplyChapterNames)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "replaceBookChapters",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/books/{id}/chapters",
+		Summary:     "Replace chapters",
+		Description: "Replaces the full chapter list. End times are derived from the next chapter's start.",
+		Tags:        []string{"Chapters"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleReplaceBookChapters)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "updateBookChapter",
+		Method:      http.MethodPatch,
+		Path:        "/api/v1/books/{id}/chapters/{index}",
+		Summary:     "Update chapter",
+		Description: "Renames a chapter and/or moves its start boundary",
+		Tags:        []string{"Chapters"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleUpdateBookChapter)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "splitBookChapter",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/books/{id}/chapters/{index}/split",
+		Summary:     "Split chapter",
+		Description: "Splits a chapter in two at a book-relative position",
+		Tags:        []string{"Chapters"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleSplitBookChapter)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "mergeBookChapter",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/books/{id}/chapters/{index}/merge",
+		Summary:     "Merge chapter",
+		Description: "Merges a chapter with the chapter that follows it",
+		Tags:        []string{"Chapters"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleMergeBookChapter)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "resetBookChapters",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/books/{id}/chapters/reset",
+		Summary:     "Reset chapters",
+		Description: "Discards chapter edits and restores the chapters embedded in the audio files",
+		Tags:        []string{"Chapters"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleResetBookChapters)
+}
+
+// === DTOs ===
+
+// ChapterResponse represents a chapter in API responses.
+type ChapterResponse struct {
+	Index       int    `json:"index" doc:"Chapter index"`
+	Title       string `json:"title" doc:"Chapter title"`
+	StartTime   int64  `json:"start_time" doc:"Book-relative start in milliseconds"`
+	EndTime     int64  `json:"end_time" doc:"Book-relative end in milliseconds"`
+	AudioFileID string `json:"audio_file_id,omitempty" doc:"Audio file the chapter starts in"`
+	IsGeneric   bool   `json:"is_generic" doc:"Whether the title is a placeholder like \"Chapter 1\""`
+}
+
+// BookChaptersResponse contains a book's chapters.
+type BookChaptersResponse struct {
+	BookID       string            `json:"book_id" doc:"Book ID"`
+	Chapters     []ChapterResponse `json:"chapters" doc:"Chapters in play order"`
+	GenericCount int               `json:"generic_count" doc:"Number of chapters with generic names"`
+	NeedsUpdate  bool              `json:"needs_update" doc:"Whether most chapter names are generic"`
+}
+
+// BookChaptersOutput wraps the book chapters response for Huma.
+type BookChaptersOutput struct {
+	Body BookChaptersResponse
+}
+
+// GetBookChaptersInput contains parameters for getting a book's chapters.
+type GetBookChaptersInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+}
+
+// PreviewChapterNamesInput contains parameters for previewing chapter names.
+type PreviewChapterNamesInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	ASIN          string `query:"asin" doc:"ASIN override (defaults to the book's ASIN)"`
+	Region        string `query:"region" doc:"Audible region override"`
+}
+
+// ChapterSuggestionsOutput wraps the alignment result for Huma.
+type ChapterSuggestionsOutput struct {
+	Body chapters.AlignmentResult
+}
+
+// ApplyChapterNameInput is a single chapter rename from a preview.
+type ApplyChapterNameInput struct {
+	Index int    `json:"index" minimum:"0" doc:"Chapter index"`
+	Name  string `json:"name" maxLength:"500" doc:"New chapter name"`
+}
+
+// ApplyChapterNamesRequest is the request body for applying chapter names.
+type ApplyChapterNamesRequest struct {
+	Chapters []ApplyChapterNameInput `json:"chapters" doc:"Chapter renames to apply"`
+}
+
+// ApplyChapterNamesInput wraps the apply chapter names request for Huma.
+type ApplyChapterNamesInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Body          ApplyChapterNamesRequest
+}
+
+// ChapterEditInput describes a chapter in a full replacement.
+type ChapterEditInput struct {
+	Title     string `json:"title" minLength:"1" maxLength:"500" doc:"Chapter title"`
+	StartTime int64  `json:"start_time" minimum:"0" doc:"Book-relative start in milliseconds"`
+}
+
+// ReplaceChaptersRequest is the request body for replacing all chapters.
+type ReplaceChaptersRequest struct {
+	Chapters []ChapterEditInput `json:"chapters" minItems:"1" doc:"New chapter list"`
+}
+
+// ReplaceChaptersInput wraps the replace chapters request for Huma.
+type ReplaceChaptersInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Body          ReplaceChaptersRequest
+}
+
+// UpdateChapterRequest is the request body for updating a single chapter.
+type UpdateChapterRequest struct {
+	Title     *string `json:"title,omitempty" maxLength:"500" doc:"New chapter title"`
+	StartTime *int64  `json:"start_time,omitempty" doc:"New book-relative start in milliseconds"`
+}
+
+// UpdateChapterInput wraps the update chapter request for Huma.
+type UpdateChapterInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Index         int    `path:"index" doc:"Chapter index"`
+	Body          UpdateChapterRequest
+}
+
+// SplitChapterRequest is the request body for splitting a chapter.
+type SplitChapterRequest struct {
+	At    int64  `json:"at" minimum:"0" doc:"Book-relative split position in milliseconds"`
+	Title string `json:"title,omitempty" maxLength:"500" doc:"Title for the new second half (defaults to the original title)"`
+}
+
+// SplitChapterInput wraps the split chapter request for Huma.
+type SplitChapterInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Index         int    `path:"index" doc:"Chapter index"`
+	Body          SplitChapterRequest
+}
+
+// ChapterIndexInput contains parameters for operations on a single chapter.
+type ChapterIndexInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Index         int    `path:"index" doc:"Chapter index"`
+}
+
+// ResetChaptersInput contains parameters for resetting chapters.
+type ResetChaptersInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+}
+
+// === Handlers ===
+
+func (s *Server) handleGetBookChapters(ctx context.Context, input *GetBookChaptersInput) (*BookChaptersOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookChapters, analysis, err := s.services.Chapter.GetChapters(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toBookChaptersResponse(input.ID, bookChapters)
+	resp.NeedsUpdate = analysis.NeedsUpdate
+	return &BookChaptersOutput{Body: resp}, nil
+}
+
+func (s *Server) handlePreviewChapterNames(ctx context.Context, input *PreviewChapterNamesInput) (*ChapterSuggestionsOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.services.Chapter.SuggestChapterNames(ctx, userID, input.ID, input.ASIN, input.Region)
+	if errors.Is(err, service.ErrNoASIN) {
+		return nil, huma.Error400BadRequest("book has no ASIN; pass ?asin= to look up chapters")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChapterSuggestionsOutput{Body: *result}, nil
+}
+
+func (s *Server) handleApplyChapterNames(ctx context.Context, input *ApplyChapterNamesInput) (*BookChaptersOutput, error) {
+	userID, err := s.RequireCanEdit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]chapters.AlignedChapter, len(input.Body.Chapters))
+	for i, c := range input.Body.Chapters {
+		updates[i] = chapters.AlignedChapter{Index: c.Index, SuggestedName: c.Name}
+	}
+
+	book, err := s.services.Chapter.ApplyChapterNames(ctx, userID, input.ID, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookChaptersOutput{Body: toBookChaptersResponse(book.ID, book.Chapters)}, nil
+}
+
+func (s *Server) handleReplaceBookChapters(ctx context.Context, input *ReplaceChaptersInput) (*BookChaptersOutput, error) {
+	userID, err := s.RequireCanEdit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]service.ChapterInput, len(input.Body.Chapters))
+	for i, c := range input.Body.Chapters {
+		inputs[i] = service.ChapterInput{Title: c.Title, StartTime: c.StartTime}
+	}
+
+	book, err := s.services.Chapter.ReplaceChapters(ctx, userID, input.ID, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookChaptersOutput{Body: toBookChaptersResponse(book.ID, book.Chapters)}, nil
+}
+
+func (s *Server) handleUpdateBookChapter(ctx context.Context, input *UpdateChapterInput) (*BookChaptersOutput, error) {
+	userID, err := s.RequireCanEdit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.services.Chapter.UpdateChapter(ctx, userID, input.ID, input.Index, service.UpdateChapterRequest{
+		Title:     input.Body.Title,
+		StartTime: input.Body.StartTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookChaptersOutput{Body: toBookChaptersResponse(book.ID, book.Chapters)}, nil
+}
+
+func (s *Server) handleSplitBookChapter(ctx context.Context, input *SplitChapterInput) (*BookChaptersOutput, error) {
+	userID, err := s.RequireCanEdit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.services.Chapter.SplitChapter(ctx, userID, input.ID, input.Index, input.Body.At, input.Body.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookChaptersOutput{Body: toBookChaptersResponse(book.ID, book.Chapters)}, nil
+}
+
+func (s *Server) handleMergeBookChapter(ctx context.Context, input *ChapterIndexInput) (*BookChaptersOutput, error) {
+	userID, err := s.RequireCanEdit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.services.Chapter.MergeChapters(ctx, userID, input.ID, input.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookChaptersOutput{Body: toBookChaptersResponse(book.ID, book.Chapters)}, nil
+}
+
+func (s *Server) handleResetBookChapters(ctx context.Context, input *ResetChaptersInput) (*BookChaptersOutput, error) {
+	userID, err := s.RequireCanEdit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.services.Chapter.ResetChapters(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookChaptersOutput{Body: toBookChaptersResponse(book.ID, book.Chapters)}, nil
+}
+
+func toBookChaptersResponse(bookID string, bookChapters []domain.Chapter) BookChaptersResponse {
+	resp := BookChaptersResponse{
+		BookID:   bookID,
+		Chapters: make([]ChapterResponse, len(bookChapters)),
+	}
+	for i, ch := range bookChapters {
+		generic := chapters.IsGenericName(ch.Title)
+		if generic {
+			resp.GenericCount++
+		}
+		resp.Chapters[i] = ChapterResponse{
+			Index:       ch.Index,
+			Title:       ch.Title,
+			StartTime:   ch.StartTime,
+			EndTime:     ch.EndTime,
+			AudioFileID: ch.AudioFileID,
+			IsGeneric:   generic,
+		}
+	}
+	resp.NeedsUpdate = len(bookChapters) > 0 && float64(resp.GenericCount)/float64(len(bookChapters)) > 0.5
+	return resp
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+// NOTE: The ebook download route is registered directly on chi (not Huma) because it
+// serves the raw file with range request support, like audio streaming.
+// It accepts the same credentials as audio streams, except signed stream URLs.
+//
+//	GET /api/v1/books/{bookId}/ebooks/{fileId} - Download a companion ebook
+func (s *Server) registerEbookRoutes() {
+	s.router.Get("/api/v1/books/{bookId}/ebooks/{fileId}", s.handleDownloadEbook)
+	s.router.Head("/api/v1/books/{bookId}/ebooks/{fileId}", s.handleDownloadEbook)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getEbookPosition",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/books/{id}/ebook-position",
+		Summary:     "Get ebook position",
+		Description: "Returns where the current user is in the book's companion ebook",
+		Tags:        []string{"Listening"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetEbookPosition)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "updateEbookPosition",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/books/{id}/ebook-position",
+		Summary:     "Update ebook position",
+		Description: "Saves the current user's reading position (an EPUB CFI for EPUBs) and syncs it to their other devices",
+		Tags:        []string{"Listening"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleUpdateEbookPosition)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listEbookPositions",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/listening/ebook-positions",
+		Summary:     "List ebook positions",
+		Description: "Returns the current user's ebook positions, most recently read first",
+		Tags:        []string{"Listening"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListEbookPositions)
+}
+
+// === DTOs ===
+
+// EbookPositionResponse is a user's position in a companion ebook.
+type EbookPositionResponse struct {
+	BookID      string    `json:"book_id" doc:"Book ID"`
+	EbookFileID string    `json:"ebook_file_id" doc:"Ebook file the position is in"`
+	CFI         string    `json:"cfi" doc:"EPUB CFI, or the reader's locator for PDFs"`
+	Progress    float64   `json:"progress" doc:"Fraction of the ebook read (0-1)"`
+	DeviceID    string    `json:"device_id,omitempty" doc:"Device that last saved the position"`
+	UpdatedAt   time.Time `json:"updated_at" doc:"Last update time"`
+}
+
+// EbookPositionOutput wraps the ebook position response for Huma.
+type EbookPositionOutput struct {
+	Body EbookPositionResponse
+}
+
+// GetEbookPositionInput contains parameters for getting an ebook position.
+type GetEbookPositionInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+}
+
+// UpdateEbookPositionRequest is the request body for saving an ebook position.
+type UpdateEbookPositionRequest struct {
+	EbookFileID string  `json:"ebook_file_id" validate:"required" doc:"Ebook file the position is in"`
+	CFI         string  `json:"cfi" validate:"required" doc:"EPUB CFI, or the reader's locator for PDFs"`
+	Progress    float64 `json:"progress" minimum:"0" maximum:"1" doc:"Fraction of the ebook read (0-1)"`
+	DeviceID    string  `json:"device_id,omitempty" doc:"Device saving the position"`
+}
+
+// UpdateEbookPositionInput wraps the update ebook position request for Huma.
+type UpdateEbookPositionInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Body          UpdateEbookPositionRequest
+}
+
+// ListEbookPositionsInput contains parameters for listing ebook positions.
+type ListEbookPositionsInput struct {
+	Authorization string `header:"Authorization"`
+}
+
+// ListEbookPositionsResponse contains the user's ebook positions.
+type ListEbookPositionsResponse struct {
+	Items []EbookPositionResponse `json:"items" doc:"Ebook positions"`
+}
+
+// ListEbookPositionsOutput wraps the ebook positions response for Huma.
+type ListEbookPositionsOutput struct {
+	Body ListEbookPositionsResponse
+}
+
+// === Handlers ===
+
+// handleDownloadEbook serves a companion ebook to a user who can see the book.
+func (s *Server) handleDownloadEbook(w http.ResponseWriter, r *http.Request) {
+	bookID := chi.URLParam(r, "bookId")
+	fileID := chi.URLParam(r, "fileId")
+
+	userID, ok := s.authenticateAudio(w, r, bookID, fileID)
+	if !ok {
+		return
+	}
+
+	book, err := s.services.Book.GetBook(r.Context(), userID, bookID)
+	if err != nil {
+		http.Error(w, "book not found", http.StatusNotFound)
+		return
+	}
+
+	ebook := book.GetEbookFileByID(fileID)
+	if ebook == nil {
+		http.Error(w, "file not found", http.StatusNotFound)
+		return
+	}
+
+	file, err := os.Open(ebook.Path)
+	if err != nil {
+		http.Error(w, "failed to open file", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		http.Error(w, "failed to stat file", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ebookMimeType(ebook.Format))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": ebook.Filename}))
+	http.ServeContent(w, r, ebook.Path, fileInfo.ModTime(), file)
+}
+
+func (s *Server) handleGetEbookPosition(ctx context.Context, input *GetEbookPositionInput) (*EbookPositionOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := s.services.Listening.GetEbookPosition(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EbookPositionOutput{Body: mapEbookPositionResponse(position)}, nil
+}
+
+func (s *Server) handleUpdateEbookPosition(ctx context.Context, input *UpdateEbookPositionInput) (*EbookPositionOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := s.services.Listening.UpdateEbookPosition(ctx, userID, input.ID, service.UpdateEbookPositionRequest{
+		EbookFileID: input.Body.EbookFileID,
+		CFI:         input.Body.CFI,
+		Progress:    input.Body.Progress,
+		DeviceID:    input.Body.DeviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EbookPositionOutput{Body: mapEbookPositionResponse(position)}, nil
+}
+
+func (s *Server) handleListEbookPositions(ctx context.Context, _ *ListEbookPositionsInput) (*ListEbookPositionsOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.services.Listening.GetEbookPositions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]EbookPositionResponse, len(positions))
+	for i, p := range positions {
+		items[i] = mapEbookPositionResponse(p)
+	}
+
+	return &ListEbookPositionsOutput{Body: ListEbookPositionsResponse{Items: items}}, nil
+}
+
+// === Helpers ===
+
+func mapEbookPositionResponse(p *domain.EbookPosition) EbookPositionResponse {
+	return EbookPositionResponse{
+		BookID:      p.BookID,
+		EbookFileID: p.EbookFileID,
+		CFI:         p.CFI,
+		Progress:    p.Progress,
+		DeviceID:    p.DeviceID,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+// ebookMimeType returns the content type for an ebook format.
+func ebookMimeType(format string) string {
+	switch format {
+	case domain.EbookFormatEPUB:
+		return "application/epub+zip"
+	case domain.EbookFormatPDF:
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -116,6 +116,15 @@ func isNotFoundError(err error) bool {
 		errors.Is(err, store.ErrShelfNotFound) ||
 		errors.Is(err, store.ErrLibraryNotFound) ||
 		errors.Is(err, store.ErrShareNotFound) ||
+		errors.Is(err, store.ErrBookmarkNotFound) ||
+		errors.Is(err, store.ErrEbookPositionNotFound) ||
+		errors.Is(err, store.ErrGoalNotFound) ||
+		errors.Is(err, store.ErrRecapNotFound) ||
+		errors.Is(err, store.ErrTrashedBookNotFound) ||
+		errors.Is(err, store.ErrReviewNotFound) ||
+		errors.Is(err, store.ErrPodcastNotFound) ||
+		errors.Is(err, store.ErrEpisodeNotFound) ||
+		errors.Is(err, store.ErrFeedTokenNotFound) ||
 		errors.Is(err, store.ErrServerNotFound)
 }
 
@@ -0,0 +1,635 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/dto"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/feed"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+// feedPageSize is the number of books per catalog page.
+const feedPageSize = 50
+
+// NOTE: Catalog and podcast routes are registered directly on chi (not Huma)
+// because they serve Atom, RSS and raw audio rather than our JSON envelope.
+// They do NOT appear in /openapi.json. The feed token in the path is the
+// only credential; OPDS readers and podcast apps cannot send headers.
+// Routes:
+//
+//	GET /feeds/{feedToken}/opds[/...]                                 - OPDS 1.2 catalog
+//	GET /feeds/{feedToken}/opds2[/...]                                - OPDS 2.0 catalog
+//	GET /feeds/{feedToken}/opds/opensearch.xml                        - OpenSearch description
+//	GET /feeds/{feedToken}/books/{bookId}/podcast.xml                 - Book as a podcast
+//	GET /feeds/{feedToken}/books/{bookId}/files/{fileId}/chapters.json - Podcasting 2.0 chapters
+//	GET /feeds/{feedToken}/audio/{bookId}/{fileId}                    - Audio file
+//	GET /feeds/{feedToken}/covers/{id}                                - Book cover
+func (s *Server) registerFeedRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listFeedTokens",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/users/me/feed-tokens",
+		Summary:     "List feed tokens",
+		Description: "Returns the current user's OPDS and podcast feed tokens, newest first. The tokens themselves are never returned again after creation.",
+		Tags:        []string{"Feeds"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListFeedTokens)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "createFeedToken",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/users/me/feed-tokens",
+		Summary:     "Create feed token",
+		Description: "Creates a token for OPDS readers and podcast apps. The token is part of the feed URLs and is returned only in this response.",
+		Tags:        []string{"Feeds"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleCreateFeedToken)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "revokeFeedToken",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/users/me/feed-tokens/{id}",
+		Summary:     "Revoke feed token",
+		Description: "Revokes a feed token. Every feed URL containing it stops working immediately.",
+		Tags:        []string{"Feeds"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRevokeFeedToken)
+
+	s.router.Route("/feeds/{feedToken}", func(r chi.Router) {
+		r.Use(s.feedTokenAuth)
+
+		for _, p := range s.feedCatalogPages() {
+			r.Get("/opds"+p.path, s.serveFeedCatalog(feedFormatAtom, p.build))
+			r.Get("/opds2"+p.path, s.serveFeedCatalog(feedFormatOPDS2, p.build))
+		}
+		r.Get("/opds/opensearch.xml", s.handleFeedOpenSearch)
+
+		r.Get("/books/{bookId}/podcast.xml", s.handleBookPodcast)
+		r.Get("/books/{bookId}/files/{fileId}/chapters.json", s.handleFeedChapters)
+		r.Get("/audio/{bookId}/{fileId}", s.handleFeedAudio)
+		r.Head("/audio/{bookId}/{fileId}", s.handleFeedAudio)
+		r.Get("/covers/{id}", s.handleServeCoverByBookID)
+	})
+}
+
+// === DTOs ===
+
+// FeedTokenResponse describes a feed token without the token itself.
+type FeedTokenResponse struct {
+	ID         string     `json:"id" doc:"Feed token ID"`
+	Name       string     `json:"name" doc:"Label chosen by the user"`
+	CreatedAt  time.Time  `json:"created_at" doc:"Created time"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" doc:"Last time a feed was read with this token (minute precision)"`
+}
+
+// ListFeedTokensInput contains parameters for listing feed tokens.
+type ListFeedTokensInput struct {
+	Authorization string `header:"Authorization"`
+}
+
+// ListFeedTokensResponse contains the user's feed tokens.
+type ListFeedTokensResponse struct {
+	FeedTokens []FeedTokenResponse `json:"feed_tokens" doc:"Feed tokens, newest first"`
+}
+
+// ListFeedTokensOutput wraps the list feed tokens response for Huma.
+type ListFeedTokensOutput struct {
+	Body ListFeedTokensResponse
+}
+
+// CreateFeedTokenRequest is the request body for creating a feed token.
+type CreateFeedTokenRequest struct {
+	Name string `json:"name" minLength:"1" maxLength:"100" doc:"Label for the token, e.g. the app it is for"`
+}
+
+// CreateFeedTokenInput wraps the create feed token request for Huma.
+type CreateFeedTokenInput struct {
+	Authorization string `header:"Authorization"`
+	Body          CreateFeedTokenRequest
+}
+
+// CreateFeedTokenResponse contains a new feed token and the URLs using it.
+type CreateFeedTokenResponse struct {
+	FeedToken FeedTokenResponse `json:"feed_token" doc:"The created token's details"`
+	Token     string            `json:"token" doc:"The token itself. It is not shown again."`
+	OPDSPath  string            `json:"opds_path" doc:"OPDS 1.2 catalog path, relative to the server URL"`
+	OPDS2Path string            `json:"opds2_path" doc:"OPDS 2.0 catalog path, relative to the server URL"`
+}
+
+// CreateFeedTokenOutput wraps the create feed token response for Huma.
+type CreateFeedTokenOutput struct {
+	Body CreateFeedTokenResponse
+}
+
+// RevokeFeedTokenInput contains parameters for revoking a feed token.
+type RevokeFeedTokenInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Feed token ID"`
+}
+
+// === Token handlers ===
+
+func (s *Server) handleListFeedTokens(ctx context.Context, _ *ListFeedTokensInput) (*ListFeedTokensOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.services.Feed.ListFeedTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]FeedTokenResponse, len(tokens))
+	for i, t := range tokens {
+		resp[i] = toFeedTokenResponse(t)
+	}
+	return &ListFeedTokensOutput{Body: ListFeedTokensResponse{FeedTokens: resp}}, nil
+}
+
+func (s *Server) handleCreateFeedToken(ctx context.Context, input *CreateFeedTokenInput) (*CreateFeedTokenOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, plaintext, err := s.services.Feed.CreateFeedToken(ctx, userID, input.Body.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	base := "/feeds/" + url.PathEscape(plaintext)
+	return &CreateFeedTokenOutput{Body: CreateFeedTokenResponse{
+		FeedToken: toFeedTokenResponse(token),
+		Token:     plaintext,
+		OPDSPath:  base + "/opds",
+		OPDS2Path: base + "/opds2",
+	}}, nil
+}
+
+func (s *Server) handleRevokeFeedToken(ctx context.Context, input *RevokeFeedTokenInput) (*MessageOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Feed.RevokeFeedToken(ctx, userID, input.ID); err != nil {
+		return nil, err
+	}
+	return &MessageOutput{Body: MessageResponse{Message: "Feed token revoked"}}, nil
+}
+
+func toFeedTokenResponse(t *domain.FeedToken) FeedTokenResponse {
+	return FeedTokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		CreatedAt:  t.CreatedAt,
+		LastUsedAt: t.LastUsedAt,
+	}
+}
+
+// === Feed auth and URLs ===
+
+// feedTokenAuth authenticates the feed token in the path and stores its
+// user in the context, so feed handlers (and the shared cover handler) can
+// use GetUserID like any other.
+func (s *Server) feedTokenAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := s.services.Feed.AuthenticateFeedToken(r.Context(), chi.URLParam(r, "feedToken"))
+		if err != nil {
+			s.writeFeedError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(setUserID(r.Context(), user.ID)))
+	})
+}
+
+// writeFeedError writes err as a plain-text HTTP error.
+func (s *Server) writeFeedError(w http.ResponseWriter, err error) {
+	var domainErr *domainerrors.Error
+	switch {
+	case isNotFoundError(err):
+		http.Error(w, "not found", http.StatusNotFound)
+	case errors.As(err, &domainErr):
+		http.Error(w, domainErr.Message, domainErr.HTTPStatus())
+	default:
+		s.logger.Error("feed request failed", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
+
+type feedFormat int
+
+const (
+	feedFormatAtom feedFormat = iota
+	feedFormatOPDS2
+)
+
+// feedLinks builds absolute URLs under one feed token.
+type feedLinks struct {
+	origin  string // https://host
+	base    string // origin + /feeds/{feedToken}
+	catalog string // base + /opds or /opds2
+}
+
+func newFeedLinks(r *http.Request, format feedFormat) feedLinks {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+
+	l := feedLinks{origin: scheme + "://" + r.Host}
+	l.base = l.origin + "/feeds/" + url.PathEscape(chi.URLParam(r, "feedToken"))
+	l.catalog = l.base + "/opds"
+	if format == feedFormatOPDS2 {
+		l.catalog = l.base + "/opds2"
+	}
+	return l
+}
+
+func (l feedLinks) page(path string) string {
+	return l.catalog + path
+}
+
+func (l feedLinks) cover(bookID string) string {
+	return l.base + "/covers/" + url.PathEscape(bookID)
+}
+
+func (l feedLinks) podcast(bookID string) string {
+	return l.base + "/books/" + url.PathEscape(bookID) + "/podcast.xml"
+}
+
+func (l feedLinks) audio(bookID, fileID string) string {
+	return l.base + "/audio/" + url.PathEscape(bookID) + "/" + url.PathEscape(fileID)
+}
+
+func (l feedLinks) chapters(bookID, fileID string) string {
+	return l.base + "/books/" + url.PathEscape(bookID) + "/files/" + url.PathEscape(fileID) + "/chapters.json"
+}
+
+// withPage returns the request's URL with the page query parameter set.
+func (l feedLinks) withPage(r *http.Request, page int) string {
+	q := r.URL.Query()
+	q.Set("page", strconv.Itoa(page))
+	return l.origin + r.URL.Path + "?" + q.Encode()
+}
+
+// feedPublication converts a book to a catalog entry.
+func (s *Server) feedPublication(l feedLinks, b *dto.Book) feed.Publication {
+	p := feed.Publication{
+		ID:          "urn:listenup:book:" + b.ID,
+		Title:       b.Title,
+		Subtitle:    b.Subtitle,
+		Description: b.Description,
+		Language:    b.Language,
+		Publisher:   b.Publisher,
+		Published:   b.PublishYear,
+		ISBN:        b.ISBN,
+		Updated:     b.UpdatedAt,
+		Subjects:    b.Genres,
+		Duration:    time.Duration(b.TotalDuration) * time.Millisecond,
+		FeedHref:    l.podcast(b.ID),
+	}
+	for _, c := range b.Contributors {
+		if slices.Contains(c.Roles, string(domain.RoleAuthor)) {
+			p.Authors = append(p.Authors, c.Name)
+		}
+		if slices.Contains(c.Roles, string(domain.RoleNarrator)) {
+			p.Narrators = append(p.Narrators, c.Name)
+		}
+	}
+	for _, si := range b.SeriesInfo {
+		p.Series = append(p.Series, feed.SeriesRef{Name: si.Name, Position: si.Sequence})
+	}
+	if s.storage.Covers.Exists(b.ID) {
+		p.CoverHref = l.cover(b.ID)
+	}
+	for _, af := range b.AudioFiles {
+		p.Files = append(p.Files, feed.File{
+			Href:     l.audio(b.ID, af.ID),
+			Type:     getMimeType(af.Format),
+			Title:    af.Filename,
+			Size:     af.Size,
+			Duration: time.Duration(af.Duration) * time.Millisecond,
+		})
+	}
+	return p
+}
+
+// === Catalog ===
+
+// feedPageBuilder builds one catalog page. The self, start and search links
+// are filled in by serveFeedCatalog.
+type feedPageBuilder func(r *http.Request, userID string, l feedLinks) (*feed.Catalog, error)
+
+type feedCatalogPage struct {
+	path  string
+	build feedPageBuilder
+}
+
+// feedCatalogPages lists the catalog pages; each is served in both formats.
+func (s *Server) feedCatalogPages() []feedCatalogPage {
+	return []feedCatalogPage{
+		{"", s.feedRootPage},
+		{"/recent", s.feedRecentPage},
+		{"/books", s.feedAllBooksPage},
+		{"/search", s.feedSearchPage},
+		{"/libraries", s.feedGroupsPage("libraries", "Libraries", s.services.Feed.Libraries)},
+		{"/libraries/{id}", s.feedGroupBooksPage("libraries", s.services.Feed.LibraryBooks)},
+		{"/series", s.feedGroupsPage("series", "Series", s.services.Feed.Series)},
+		{"/series/{id}", s.feedGroupBooksPage("series", s.services.Feed.SeriesBooks)},
+		{"/contributors", s.feedGroupsPage("contributors", "Authors & Narrators", s.services.Feed.Contributors)},
+		{"/contributors/{id}", s.feedGroupBooksPage("contributors", s.services.Feed.ContributorBooks)},
+		{"/genres", s.feedGroupsPage("genres", "Genres", s.services.Feed.Genres)},
+		{"/genres/{id}", s.feedGroupBooksPage("genres", s.services.Feed.GenreBooks)},
+		{"/shelves", s.feedGroupsPage("shelves", "My Shelves", s.services.Feed.Shelves)},
+		{"/shelves/{id}", s.feedGroupBooksPage("shelves", s.services.Feed.ShelfBooks)},
+	}
+}
+
+func (s *Server) serveFeedCatalog(format feedFormat, build feedPageBuilder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := GetUserID(r.Context())
+		if err != nil {
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		l := newFeedLinks(r, format)
+		c, err := build(r, userID, l)
+		if err != nil {
+			s.writeFeedError(w, err)
+			return
+		}
+
+		c.Self = l.origin + r.URL.RequestURI()
+		c.Start = l.page("")
+		c.SearchDescription = l.base + "/opds/opensearch.xml"
+		c.SearchTemplate = l.base + "/opds2/search{?query}"
+		if c.Updated.IsZero() {
+			c.Updated = time.Now()
+		}
+
+		if format == feedFormatOPDS2 {
+			w.Header().Set("Content-Type", feed.MediaTypeOPDS2)
+			err = feed.WriteOPDS2(w, c)
+		} else {
+			contentType := feed.MediaTypeAtomNavigation
+			if c.Kind == feed.KindAcquisition {
+				contentType = feed.MediaTypeAtomAcquisition
+			}
+			w.Header().Set("Content-Type", contentType)
+			err = feed.WriteAtom(w, c)
+		}
+		if err != nil {
+			s.logger.Warn("failed to write catalog", "path", r.URL.Path, "error", err)
+		}
+	}
+}
+
+func (s *Server) feedRootPage(_ *http.Request, _ string, l feedLinks) (*feed.Catalog, error) {
+	nav := func(path, title, summary string, kind feed.Kind) feed.NavEntry {
+		return feed.NavEntry{ID: "urn:listenup:" + path, Title: title, Summary: summary, Href: l.page("/" + path), Kind: kind}
+	}
+	return &feed.Catalog{
+		ID:    "urn:listenup:root",
+		Title: "ListenUp",
+		Kind:  feed.KindNavigation,
+		Navigation: []feed.NavEntry{
+			nav("recent", "Recently Added", "The newest audiobooks in the library", feed.KindAcquisition),
+			nav("books", "All Audiobooks", "Every audiobook, by title", feed.KindAcquisition),
+			nav("libraries", "Libraries", "Browse by library", feed.KindNavigation),
+			nav("series", "Series", "Browse by series", feed.KindNavigation),
+			nav("contributors", "Authors & Narrators", "Browse by author or narrator", feed.KindNavigation),
+			nav("genres", "Genres", "Browse by genre", feed.KindNavigation),
+			nav("shelves", "My Shelves", "Your shelves", feed.KindNavigation),
+		},
+	}, nil
+}
+
+func (s *Server) feedRecentPage(r *http.Request, userID string, l feedLinks) (*feed.Catalog, error) {
+	books, err := s.services.Feed.RecentBooks(r.Context(), userID, feedPageSize)
+	if err != nil {
+		return nil, err
+	}
+	c := &feed.Catalog{ID: "urn:listenup:recent", Title: "Recently Added", Kind: feed.KindAcquisition, Up: l.page("")}
+	for _, b := range books {
+		c.Publications = append(c.Publications, s.feedPublication(l, b))
+	}
+	return c, nil
+}
+
+func (s *Server) feedAllBooksPage(r *http.Request, userID string, l feedLinks) (*feed.Catalog, error) {
+	books, err := s.services.Feed.Books(r.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+	c := &feed.Catalog{ID: "urn:listenup:books", Title: "All Audiobooks", Kind: feed.KindAcquisition, Up: l.page("")}
+	s.paginateFeed(c, r, l, books)
+	return c, nil
+}
+
+func (s *Server) feedSearchPage(r *http.Request, userID string, l feedLinks) (*feed.Catalog, error) {
+	// OpenSearch templates use q; the OPDS 2.0 template uses query.
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		query = r.URL.Query().Get("query")
+	}
+	if query == "" {
+		return nil, domainerrors.Validation("search query is required")
+	}
+
+	books, err := s.services.Feed.Search(r.Context(), userID, query, feedPageSize)
+	if err != nil {
+		return nil, err
+	}
+	c := &feed.Catalog{ID: "urn:listenup:search", Title: "Search: " + query, Kind: feed.KindAcquisition, Up: l.page("")}
+	for _, b := range books {
+		c.Publications = append(c.Publications, s.feedPublication(l, b))
+	}
+	return c, nil
+}
+
+// feedGroupsPage builds a navigation page listing groups (series, genres...)
+// that link to their books.
+func (s *Server) feedGroupsPage(
+	path, title string,
+	list func(ctx context.Context, userID string) ([]service.FeedGroup, error),
+) feedPageBuilder {
+	return func(r *http.Request, userID string, l feedLinks) (*feed.Catalog, error) {
+		groups, err := list(r.Context(), userID)
+		if err != nil {
+			return nil, err
+		}
+		c := &feed.Catalog{ID: "urn:listenup:" + path, Title: title, Kind: feed.KindNavigation, Up: l.page("")}
+		for _, g := range groups {
+			c.Navigation = append(c.Navigation, feed.NavEntry{
+				ID:      "urn:listenup:" + path + ":" + g.ID,
+				Title:   g.Name,
+				Summary: g.Description,
+				Href:    l.page("/" + path + "/" + url.PathEscape(g.ID)),
+				Kind:    feed.KindAcquisition,
+				Count:   g.BookCount,
+			})
+		}
+		return c, nil
+	}
+}
+
+// feedGroupBooksPage builds an acquisition page with one group's books.
+func (s *Server) feedGroupBooksPage(
+	path string,
+	books func(ctx context.Context, userID, id string) (*service.FeedGroup, []*dto.Book, error),
+) feedPageBuilder {
+	return func(r *http.Request, userID string, l feedLinks) (*feed.Catalog, error) {
+		group, list, err := books(r.Context(), userID, chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, err
+		}
+		c := &feed.Catalog{ID: "urn:listenup:" + path + ":" + group.ID, Title: group.Name, Kind: feed.KindAcquisition, Up: l.page("/" + path)}
+		s.paginateFeed(c, r, l, list)
+		return c, nil
+	}
+}
+
+// paginateFeed fills c with the requested page of books.
+func (s *Server) paginateFeed(c *feed.Catalog, r *http.Request, l feedLinks, books []*dto.Book) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	page = max(page, 1)
+
+	c.TotalResults = len(books)
+	c.ItemsPerPage = feedPageSize
+
+	start := min((page-1)*feedPageSize, len(books))
+	end := min(start+feedPageSize, len(books))
+	for _, b := range books[start:end] {
+		c.Publications = append(c.Publications, s.feedPublication(l, b))
+	}
+
+	if end < len(books) {
+		c.Next = l.withPage(r, page+1)
+	}
+	if page > 1 {
+		c.Previous = l.withPage(r, page-1)
+	}
+}
+
+func (s *Server) handleFeedOpenSearch(w http.ResponseWriter, r *http.Request) {
+	l := newFeedLinks(r, feedFormatAtom)
+	w.Header().Set("Content-Type", feed.MediaTypeOpenSearch)
+	if err := feed.WriteOpenSearch(w, "ListenUp", l.page("/search?q={searchTerms}")); err != nil {
+		s.logger.Warn("failed to write opensearch description", "error", err)
+	}
+}
+
+// === Podcast ===
+
+// handleBookPodcast serves a book as a podcast with one episode per audio
+// file, so any podcast app can play it.
+func (s *Server) handleBookPodcast(w http.ResponseWriter, r *http.Request) {
+	userID, err := GetUserID(r.Context())
+	if err != nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	book, err := s.services.Feed.Book(r.Context(), userID, chi.URLParam(r, "bookId"))
+	if err != nil {
+		s.writeFeedError(w, err)
+		return
+	}
+
+	l := newFeedLinks(r, feedFormatAtom)
+	p := &feed.Podcast{
+		Title:       book.Title,
+		Author:      book.Author,
+		Description: book.Description,
+		Language:    book.Language,
+		FeedURL:     l.podcast(book.ID),
+		Updated:     book.UpdatedAt,
+	}
+	if s.storage.Covers.Exists(book.ID) {
+		p.ImageURL = l.cover(book.ID)
+	}
+
+	for i, af := range book.AudioFiles {
+		ep := feed.Episode{
+			GUID:  book.ID + ":" + af.ID,
+			Title: feed.EpisodeTitle(i+1, len(book.AudioFiles)),
+			// Podcast apps order episodes by date, not episode number;
+			// a minute apart keeps the parts in book order.
+			Published: book.CreatedAt.Add(time.Duration(i) * time.Minute),
+			Number:    i + 1,
+			URL:       l.audio(book.ID, af.ID),
+			Type:      getMimeType(af.Format),
+			Length:    af.Size,
+			Duration:  time.Duration(af.Duration) * time.Millisecond,
+		}
+		if len(book.ChaptersForFile(af.ID)) > 0 {
+			ep.ChaptersURL = l.chapters(book.ID, af.ID)
+		}
+		p.Episodes = append(p.Episodes, ep)
+	}
+
+	w.Header().Set("Content-Type", feed.MediaTypeRSS+"; charset=utf-8")
+	if err := feed.WriteRSS(w, p); err != nil {
+		s.logger.Warn("failed to write podcast feed", "book_id", book.ID, "error", err)
+	}
+}
+
+// handleFeedChapters serves one audio file's chapters as Podcasting 2.0 JSON.
+func (s *Server) handleFeedChapters(w http.ResponseWriter, r *http.Request) {
+	userID, err := GetUserID(r.Context())
+	if err != nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	book, err := s.services.Feed.Book(r.Context(), userID, chi.URLParam(r, "bookId"))
+	if err != nil {
+		s.writeFeedError(w, err)
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileId")
+	if book.GetAudioFileByID(fileID) == nil {
+		http.Error(w, "file not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", feed.MediaTypeChapters)
+	if err := feed.WriteChapters(w, book.ChaptersForFile(fileID)); err != nil {
+		s.logger.Warn("failed to write chapters", "book_id", book.ID, "error", err)
+	}
+}
+
+// handleFeedAudio streams an audio file to a feed client.
+func (s *Server) handleFeedAudio(w http.ResponseWriter, r *http.Request) {
+	userID, err := GetUserID(r.Context())
+	if err != nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	bookID := chi.URLParam(r, "bookId")
+	if ok, err := s.services.Feed.CanAccessBook(r.Context(), userID, bookID); err != nil || !ok {
+		http.Error(w, "book not found", http.StatusNotFound)
+		return
+	}
+
+	s.serveAudioFile(w, r, userID, bookID, chi.URLParam(r, "fileId"))
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+func (s *Server) registerGoalRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listGoals",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/goals",
+		Summary:     "List listening goals",
+		Description: "Returns the current user's listening goals with progress in their current period",
+		Tags:        []string{"Goals"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListGoals)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "createGoal",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/goals",
+		Summary:     "Create listening goal",
+		Description: "Creates a goal for books finished or hours listened per week, month or year, or over a custom date range",
+		Tags:        []string{"Goals"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleCreateGoal)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getGoal",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/goals/{id}",
+		Summary:     "Get listening goal",
+		Description: "Returns a single listening goal owned by the current user with its progress",
+		Tags:        []string{"Goals"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetGoal)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "updateGoal",
+		Method:      http.MethodPatch,
+		Path:        "/api/v1/goals/{id}",
+		Summary:     "Update listening goal",
+		Description: "Updates the target of a goal, or the date range of a custom goal",
+		Tags:        []string{"Goals"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleUpdateGoal)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "deleteGoal",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/goals/{id}",
+		Summary:     "Delete listening goal",
+		Description: "Deletes a listening goal owned by the current user",
+		Tags:        []string{"Goals"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleDeleteGoal)
+}
+
+// === DTOs ===
+
+// GoalResponse contains a listening goal and its progress in API responses.
+type GoalResponse struct {
+	ID          string     `json:"id" doc:"Goal ID"`
+	Metric      string     `json:"metric" doc:"What the goal counts (books, hours)"`
+	Period      string     `json:"period" doc:"Goal period (week, month, year, custom)"`
+	Target      int        `json:"target" doc:"Target number of books or hours"`
+	StartsAt    *time.Time `json:"starts_at,omitempty" doc:"Start of a custom goal"`
+	EndsAt      *time.Time `json:"ends_at,omitempty" doc:"End of a custom goal (exclusive)"`
+	WindowStart time.Time  `json:"window_start" doc:"Start of the period progress is measured over"`
+	WindowEnd   time.Time  `json:"window_end" doc:"End of the period progress is measured over (exclusive)"`
+	Current     float64    `json:"current" doc:"Books finished, or hours listened to one decimal, in the period"`
+	Percent     float64    `json:"percent" doc:"Progress toward the target (0-100)"`
+	Completed   bool       `json:"completed" doc:"Whether the target has been reached in the period"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" doc:"When the target was last reached"`
+	CreatedAt   time.Time  `json:"created_at" doc:"Created time"`
+	UpdatedAt   time.Time  `json:"updated_at" doc:"Updated time"`
+}
+
+// ListGoalsInput contains parameters for listing goals.
+type ListGoalsInput struct {
+	Authorization string `header:"Authorization"`
+}
+
+// ListGoalsResponse contains a list of goals.
+type ListGoalsResponse struct {
+	Goals []GoalResponse `json:"goals" doc:"Goals, oldest first"`
+}
+
+// ListGoalsOutput wraps the list goals response for Huma.
+type ListGoalsOutput struct {
+	Body ListGoalsResponse
+}
+
+// CreateGoalRequest is the request body for creating a goal.
+type CreateGoalRequest struct {
+	Metric   string     `json:"metric" enum:"books,hours" doc:"What the goal counts"`
+	Period   string     `json:"period" enum:"week,month,year,custom" doc:"Goal period"`
+	Target   int        `json:"target" minimum:"1" maximum:"10000" doc:"Target number of books or hours"`
+	StartsAt *time.Time `json:"starts_at,omitempty" doc:"Start of a custom goal (custom only)"`
+	EndsAt   *time.Time `json:"ends_at,omitempty" doc:"End of a custom goal, exclusive (custom only)"`
+}
+
+// CreateGoalInput wraps the create goal request for Huma.
+type CreateGoalInput struct {
+	Authorization string `header:"Authorization"`
+	Body          CreateGoalRequest
+}
+
+// GoalOutput wraps a single goal response for Huma.
+type GoalOutput struct {
+	Body GoalResponse
+}
+
+// GetGoalInput contains parameters for getting a goal.
+type GetGoalInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Goal ID"`
+}
+
+// UpdateGoalRequest is the request body for updating a goal.
+type UpdateGoalRequest struct {
+	Target   *int       `json:"target,omitempty" minimum:"1" maximum:"10000" doc:"Target number of books or hours"`
+	StartsAt *time.Time `json:"starts_at,omitempty" doc:"Start of a custom goal (custom only)"`
+	EndsAt   *time.Time `json:"ends_at,omitempty" doc:"End of a custom goal, exclusive (custom only)"`
+}
+
+// UpdateGoalInput wraps the update goal request for Huma.
+type UpdateGoalInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Goal ID"`
+	Body          UpdateGoalRequest
+}
+
+// DeleteGoalInput contains parameters for deleting a goal.
+type DeleteGoalInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Goal ID"`
+}
+
+// === Handlers ===
+
+func (s *Server) handleListGoals(ctx context.Context, _ *ListGoalsInput) (*ListGoalsOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goals, err := s.services.Goal.ListGoals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]GoalResponse, len(goals))
+	for i, g := range goals {
+		resp[i] = toGoalResponse(g)
+	}
+
+	return &ListGoalsOutput{Body: ListGoalsResponse{Goals: resp}}, nil
+}
+
+func (s *Server) handleCreateGoal(ctx context.Context, input *CreateGoalInput) (*GoalOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.services.Goal.CreateGoal(ctx, userID, service.CreateGoalRequest{
+		Metric:   domain.GoalMetric(input.Body.Metric),
+		Period:   domain.GoalPeriod(input.Body.Period),
+		Target:   input.Body.Target,
+		StartsAt: input.Body.StartsAt,
+		EndsAt:   input.Body.EndsAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GoalOutput{Body: toGoalResponse(*progress)}, nil
+}
+
+func (s *Server) handleGetGoal(ctx context.Context, input *GetGoalInput) (*GoalOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.services.Goal.GetGoal(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GoalOutput{Body: toGoalResponse(*progress)}, nil
+}
+
+func (s *Server) handleUpdateGoal(ctx context.Context, input *UpdateGoalInput) (*GoalOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.services.Goal.UpdateGoal(ctx, userID, input.ID, service.UpdateGoalRequest{
+		Target:   input.Body.Target,
+		StartsAt: input.Body.StartsAt,
+		EndsAt:   input.Body.EndsAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GoalOutput{Body: toGoalResponse(*progress)}, nil
+}
+
+func (s *Server) handleDeleteGoal(ctx context.Context, input *DeleteGoalInput) (*MessageOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Goal.DeleteGoal(ctx, userID, input.ID); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Goal deleted"}}, nil
+}
+
+func toGoalResponse(p domain.GoalProgress) GoalResponse {
+	g := p.Goal
+	return GoalResponse{
+		ID:          g.ID,
+		Metric:      string(g.Metric),
+		Period:      string(g.Period),
+		Target:      g.Target,
+		StartsAt:    g.StartsAt,
+		EndsAt:      g.EndsAt,
+		WindowStart: p.WindowStart,
+		WindowEnd:   p.WindowEnd,
+		Current:     p.Current,
+		Percent:     p.Percent,
+		Completed:   p.Completed,
+		CompletedAt: g.CompletedAt,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
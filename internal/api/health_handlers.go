@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/listenupapp/listenup-server/internal/backup"
 	"github.com/listenupapp/listenup-server/internal/store"
 )
 
@@ -23,6 +24,12 @@ type lastTicker interface {
 	LastTick() time.Time
 }
 
+// backupReporter is what health checks need from the scheduled backup job.
+type backupReporter interface {
+	lastTicker
+	LastRun() *backup.ScheduledRun
+}
+
 const (
 	workerStaleThreshold = 5 * time.Minute
 	indexerHighDepth     = 50 // queue depth threshold for "high"
@@ -122,6 +129,13 @@ func (s *Server) handleHealthCheck(ctx context.Context, _ *struct{}) (*HealthOut
 		}
 	}
 
+	// Scheduled backups.
+	backupHealth := s.checkScheduledBackup()
+	components["scheduled_backup"] = backupHealth
+	if backupHealth.Status != statusHealthy && overall == statusHealthy {
+		overall = statusDegraded
+	}
+
 	return &HealthOutput{
 		Body: HealthResponse{
 			Status:     overall,
@@ -269,6 +283,35 @@ func (s *Server) checkWorker(name string, lt lastTicker) ComponentHealth {
 	}
 }
 
+// checkScheduledBackup reports the backup job's liveness and the outcome of
+// the most recent scheduled backup. A failed backup degrades health until the
+// next successful run.
+func (s *Server) checkScheduledBackup() ComponentHealth {
+	if s.backupJob == nil {
+		return s.checkWorker("scheduled_backup", nil)
+	}
+	h := s.checkWorker("scheduled_backup", s.backupJob)
+	if h.Status != statusHealthy {
+		return h
+	}
+
+	run := s.backupJob.LastRun()
+	switch {
+	case run == nil:
+		h.Message = "no scheduled backup since startup"
+	case !run.Succeeded():
+		return ComponentHealth{
+			Status:  statusDegraded,
+			Message: fmt.Sprintf("last backup failed %s ago: %s", time.Since(run.CompletedAt).Round(time.Second), run.Error),
+		}
+	case run.Error != "":
+		h.Message = fmt.Sprintf("last backup %s; pruning failed: %s", run.BackupID, run.Error)
+	default:
+		h.Message = fmt.Sprintf("last backup %s, %s ago", run.BackupID, time.Since(run.CompletedAt).Round(time.Second))
+	}
+	return h
+}
+
 func formatSSEStatus(count int) string {
 	switch count {
 	case 0:
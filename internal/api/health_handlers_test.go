@@ -4,9 +4,12 @@ import (
 	"encoding/json/v2"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/backup"
 )
 
 func TestHealthCheck_Success(t *testing.T) {
@@ -58,9 +61,41 @@ func TestHealthCheck_WorkerComponentsPresent(t *testing.T) {
 	err := json.Unmarshal(resp.Body.Bytes(), &envelope)
 	require.NoError(t, err)
 
-	for _, name := range []string{"file_watcher", "session_cleanup", "event_log_cleanup", "import_jobs"} {
+	for _, name := range []string{"file_watcher", "session_cleanup", "event_log_cleanup", "import_jobs", "scheduled_backup"} {
 		if _, ok := envelope.Data.Components[name]; !ok {
 			t.Errorf("expected %s in Components map", name)
 		}
 	}
 }
+
+type fakeBackupReporter struct {
+	tick time.Time
+	run  *backup.ScheduledRun
+}
+
+func (f fakeBackupReporter) LastTick() time.Time           { return f.tick }
+func (f fakeBackupReporter) LastRun() *backup.ScheduledRun { return f.run }
+
+func TestCheckScheduledBackup(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		run    *backup.ScheduledRun
+		status string
+	}{
+		{"no run yet", nil, statusHealthy},
+		{"succeeded", &backup.ScheduledRun{BackupID: "scheduled-1", CompletedAt: now}, statusHealthy},
+		{"prune failed", &backup.ScheduledRun{BackupID: "scheduled-1", Error: "permission denied", CompletedAt: now}, statusHealthy},
+		{"backup failed", &backup.ScheduledRun{Error: "disk full", CompletedAt: now}, statusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := &Server{backupJob: fakeBackupReporter{tick: now, run: tt.run}}
+			assert.Equal(t, tt.status, s.checkScheduledBackup().Status)
+		})
+	}
+}
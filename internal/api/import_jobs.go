@@ -35,14 +35,14 @@ type importJobManager struct {
 	// run is the work function invoked per submission. It is a field rather
 	// than a hard-coded call so tests can substitute a fake without spinning
 	// up the full analyzer.
-	run func(ctx context.Context, importID, backupPath string)
+	run func(ctx context.Context, importID string)
 }
 
 var importJobManagerExpvarOnce sync.Once
 
 // newImportJobManager constructs a manager whose context derives from the
 // supplied parent. If parent is nil, context.Background is used.
-func newImportJobManager(parent context.Context, logger *slog.Logger, run func(ctx context.Context, importID, backupPath string)) *importJobManager {
+func newImportJobManager(parent context.Context, logger *slog.Logger, run func(ctx context.Context, importID string)) *importJobManager {
 	if parent == nil {
 		parent = context.Background()
 	}
@@ -65,10 +65,10 @@ func newImportJobManager(parent context.Context, logger *slog.Logger, run func(c
 	return m
 }
 
-// Submit launches an import analysis on a tracked goroutine. If the manager
-// has already been shut down, Submit logs and returns without launching a
-// goroutine.
-func (m *importJobManager) Submit(importID, backupPath string) {
+// Submit launches an import analysis (or a live import's re-sync) on a
+// tracked goroutine. If the manager has already been shut down, Submit logs
+// and returns without launching a goroutine.
+func (m *importJobManager) Submit(importID string) {
 	if m == nil {
 		return
 	}
@@ -90,7 +90,7 @@ func (m *importJobManager) Submit(importID, backupPath string) {
 		m.active.Add(1)
 		defer m.active.Add(-1)
 		atomic.StoreInt64(&m.lastTickUnix, time.Now().Unix())
-		m.run(ctx, importID, backupPath)
+		m.run(ctx, importID)
 	}()
 }
 
@@ -43,6 +43,36 @@ func (s *Server) registerLibraryRoutes() {
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleUpdateLibrary)
 
+	huma.Register(s.api, huma.Operation{
+		OperationID: "createLibrary",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/libraries",
+		Summary:     "Create library",
+		Description: "Creates an additional library and starts scanning it. Scan paths must not overlap another library's. Admin only.",
+		Tags:        []string{"Libraries"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleCreateLibrary)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "deleteLibrary",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/libraries/{id}",
+		Summary:     "Delete library",
+		Description: "Deletes an empty library and its collections. The last library cannot be deleted. Admin only.",
+		Tags:        []string{"Libraries"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleDeleteLibrary)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getLibraryStats",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/libraries/{id}/stats",
+		Summary:     "Get library stats",
+		Description: "Returns book, contributor and series counts and total duration and size for a library",
+		Tags:        []string{"Libraries"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetLibraryStats)
+
 	huma.Register(s.api, huma.Operation{
 		OperationID: "getLibraryStatus",
 		Method:      http.MethodGet,
@@ -103,14 +133,17 @@ type ListLibrariesInput struct {
 
 // LibraryResponse contains library data in API responses.
 type LibraryResponse struct {
-	ID         string    `json:"id" doc:"Library ID"`
-	Name       string    `json:"name" doc:"Library name"`
-	OwnerID    string    `json:"owner_id" doc:"Owner user ID"`
-	ScanPaths  []string  `json:"scan_paths" doc:"Paths to scan for audiobooks"`
-	SkipInbox  bool      `json:"skip_inbox" doc:"Whether to skip inbox for new books"`
-	AccessMode string    `json:"access_mode" doc:"Access mode: open or restricted"`
-	CreatedAt  time.Time `json:"created_at" doc:"Creation time"`
-	UpdatedAt  time.Time `json:"updated_at" doc:"Last update time"`
+	ID                string    `json:"id" doc:"Library ID"`
+	Name              string    `json:"name" doc:"Library name"`
+	OwnerID           string    `json:"owner_id" doc:"Owner user ID"`
+	ScanPaths         []string  `json:"scan_paths" doc:"Paths to scan for audiobooks"`
+	SkipInbox         bool      `json:"skip_inbox" doc:"Whether to skip inbox for new books"`
+	AccessMode        string    `json:"access_mode" doc:"Access mode: open or restricted"`
+	Type              string    `json:"type" doc:"Library type: audiobook or podcast"`
+	MetadataProviders []string  `json:"metadata_providers,omitempty" doc:"Metadata providers in priority order (empty uses the default order)"`
+	MetadataRegion    string    `json:"metadata_region,omitempty" doc:"Audible region for matches (empty uses the server default)"`
+	CreatedAt         time.Time `json:"created_at" doc:"Creation time"`
+	UpdatedAt         time.Time `json:"updated_at" doc:"Last update time"`
 }
 
 // ListLibrariesResponse contains a list of libraries.
@@ -136,8 +169,11 @@ type LibraryOutput struct {
 
 // UpdateLibraryRequest is the request body for updating a library.
 type UpdateLibraryRequest struct {
-	Name       *string `json:"name,omitempty" validate:"omitempty,min=1,max=100" doc:"Library name"`
-	AccessMode *string `json:"access_mode,omitempty" validate:"omitempty,oneof=open restricted" doc:"Access mode: open or restricted"`
+	Name              *string   `json:"name,omitempty" validate:"omitempty,min=1,max=100" doc:"Library name"`
+	AccessMode        *string   `json:"access_mode,omitempty" validate:"omitempty,oneof=open restricted" doc:"Access mode: open or restricted"`
+	SkipInbox         *bool     `json:"skip_inbox,omitempty" doc:"Whether new books bypass the inbox"`
+	MetadataRegion    *string   `json:"metadata_region,omitempty" doc:"Audible region for matches (us, uk, de, ...); empty uses the server default"`
+	MetadataProviders *[]string `json:"metadata_providers,omitempty" doc:"Metadata providers in priority order; an empty list restores the default order"`
 }
 
 // UpdateLibraryInput wraps the update library request for Huma.
@@ -147,6 +183,43 @@ type UpdateLibraryInput struct {
 	Body          UpdateLibraryRequest
 }
 
+// CreateLibraryRequest is the request body for creating a library.
+type CreateLibraryRequest struct {
+	Name           string   `json:"name" validate:"required,min=1,max=100" doc:"Library name"`
+	ScanPaths      []string `json:"scan_paths" minItems:"1" doc:"Absolute paths to scan for audiobooks"`
+	SkipInbox      bool     `json:"skip_inbox,omitempty" doc:"Whether new books bypass the inbox"`
+	AccessMode     string   `json:"access_mode,omitempty" validate:"omitempty,oneof=open restricted" doc:"Access mode: open or restricted"`
+	MetadataRegion string   `json:"metadata_region,omitempty" doc:"Audible region for matches; empty uses the server default"`
+	Type           string   `json:"type,omitempty" validate:"omitempty,oneof=audiobook podcast" doc:"Library type: audiobook (default) or podcast. Podcast libraries hold feed subscriptions and are never scanned."`
+}
+
+// CreateLibraryInput wraps the create library request for Huma.
+type CreateLibraryInput struct {
+	Authorization string `header:"Authorization"`
+	Body          CreateLibraryRequest
+}
+
+// DeleteLibraryInput contains parameters for deleting a library.
+type DeleteLibraryInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Library ID"`
+}
+
+// LibraryStatsResponse contains totals for a library.
+type LibraryStatsResponse struct {
+	LibraryID        string `json:"library_id" doc:"Library ID"`
+	BookCount        int    `json:"book_count" doc:"Number of books"`
+	ContributorCount int    `json:"contributor_count" doc:"Number of distinct contributors"`
+	SeriesCount      int    `json:"series_count" doc:"Number of distinct series"`
+	TotalDuration    int64  `json:"total_duration" doc:"Total duration in milliseconds"`
+	TotalSize        int64  `json:"total_size" doc:"Total size in bytes"`
+}
+
+// LibraryStatsOutput wraps the library stats response for Huma.
+type LibraryStatsOutput struct {
+	Body LibraryStatsResponse
+}
+
 // LibraryStatusInput contains parameters for getting library status.
 type LibraryStatusInput struct {
 	Authorization string `header:"Authorization"`
@@ -199,14 +272,17 @@ func (s *Server) handleListLibraries(ctx context.Context, _ *ListLibrariesInput)
 	resp := make([]LibraryResponse, len(libraries))
 	for i, lib := range libraries {
 		resp[i] = LibraryResponse{
-			ID:         lib.ID,
-			Name:       lib.Name,
-			OwnerID:    lib.OwnerID,
-			ScanPaths:  lib.ScanPaths,
-			SkipInbox:  lib.SkipInbox,
-			AccessMode: string(lib.GetAccessMode()),
-			CreatedAt:  lib.CreatedAt,
-			UpdatedAt:  lib.UpdatedAt,
+			ID:                lib.ID,
+			Name:              lib.Name,
+			OwnerID:           lib.OwnerID,
+			ScanPaths:         lib.ScanPaths,
+			SkipInbox:         lib.SkipInbox,
+			AccessMode:        string(lib.GetAccessMode()),
+			Type:              string(lib.GetType()),
+			CreatedAt:         lib.CreatedAt,
+			UpdatedAt:         lib.UpdatedAt,
+			MetadataProviders: lib.MetadataProviders,
+			MetadataRegion:    lib.MetadataRegion,
 		}
 	}
 
@@ -225,14 +301,17 @@ func (s *Server) handleGetLibrary(ctx context.Context, input *GetLibraryInput) (
 
 	return &LibraryOutput{
 		Body: LibraryResponse{
-			ID:         lib.ID,
-			Name:       lib.Name,
-			OwnerID:    lib.OwnerID,
-			ScanPaths:  lib.ScanPaths,
-			SkipInbox:  lib.SkipInbox,
-			AccessMode: string(lib.GetAccessMode()),
-			CreatedAt:  lib.CreatedAt,
-			UpdatedAt:  lib.UpdatedAt,
+			ID:                lib.ID,
+			Name:              lib.Name,
+			OwnerID:           lib.OwnerID,
+			ScanPaths:         lib.ScanPaths,
+			SkipInbox:         lib.SkipInbox,
+			AccessMode:        string(lib.GetAccessMode()),
+			Type:              string(lib.GetType()),
+			CreatedAt:         lib.CreatedAt,
+			UpdatedAt:         lib.UpdatedAt,
+			MetadataProviders: lib.MetadataProviders,
+			MetadataRegion:    lib.MetadataRegion,
 		},
 	}, nil
 }
@@ -242,25 +321,115 @@ func (s *Server) handleUpdateLibrary(ctx context.Context, input *UpdateLibraryIn
 		return nil, err
 	}
 
-	lib, err := s.services.Library.UpdateLibrary(ctx, input.ID, input.Body.Name, input.Body.AccessMode)
+	lib, err := s.services.Library.UpdateLibrary(ctx, input.ID, service.LibraryUpdate{
+		Name:              input.Body.Name,
+		AccessMode:        input.Body.AccessMode,
+		SkipInbox:         input.Body.SkipInbox,
+		MetadataRegion:    input.Body.MetadataRegion,
+		MetadataProviders: input.Body.MetadataProviders,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LibraryOutput{
+		Body: LibraryResponse{
+			ID:                lib.ID,
+			Name:              lib.Name,
+			OwnerID:           lib.OwnerID,
+			ScanPaths:         lib.ScanPaths,
+			SkipInbox:         lib.SkipInbox,
+			AccessMode:        string(lib.GetAccessMode()),
+			Type:              string(lib.GetType()),
+			CreatedAt:         lib.CreatedAt,
+			UpdatedAt:         lib.UpdatedAt,
+			MetadataProviders: lib.MetadataProviders,
+			MetadataRegion:    lib.MetadataRegion,
+		},
+	}, nil
+}
+
+func (s *Server) handleCreateLibrary(ctx context.Context, input *CreateLibraryInput) (*LibraryOutput, error) {
+	adminID, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanPaths, err := validateScanPaths(input.Body.ScanPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	lib, err := s.services.Library.CreateLibrary(ctx, service.CreateLibraryInput{
+		OwnerID:        adminID,
+		Name:           input.Body.Name,
+		ScanPaths:      cleanPaths,
+		SkipInbox:      input.Body.SkipInbox,
+		AccessMode:     input.Body.AccessMode,
+		MetadataRegion: input.Body.MetadataRegion,
+		Type:           input.Body.Type,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Scan the new library unless another scan is running; the admin can
+	// trigger it later otherwise. Podcast libraries are never scanned.
+	if !lib.IsPodcast() && !s.sseManager.IsScanning() {
+		s.sseManager.SetScanning(true)
+		go func() {
+			defer s.sseManager.SetScanning(false)
+
+			if s.services != nil && s.services.Book != nil {
+				if _, err := s.services.Book.TriggerScan(context.Background(), lib.ID, scanner.ScanOptions{}); err != nil {
+					s.logger.Error("initial scan failed", "library_id", lib.ID, "error", err)
+				}
+			}
+		}()
+	}
+
 	return &LibraryOutput{
 		Body: LibraryResponse{
-			ID:         lib.ID,
-			Name:       lib.Name,
-			OwnerID:    lib.OwnerID,
-			ScanPaths:  lib.ScanPaths,
-			SkipInbox:  lib.SkipInbox,
-			AccessMode: string(lib.GetAccessMode()),
-			CreatedAt:  lib.CreatedAt,
-			UpdatedAt:  lib.UpdatedAt,
+			ID:                lib.ID,
+			Name:              lib.Name,
+			OwnerID:           lib.OwnerID,
+			ScanPaths:         lib.ScanPaths,
+			SkipInbox:         lib.SkipInbox,
+			AccessMode:        string(lib.GetAccessMode()),
+			Type:              string(lib.GetType()),
+			CreatedAt:         lib.CreatedAt,
+			UpdatedAt:         lib.UpdatedAt,
+			MetadataProviders: lib.MetadataProviders,
+			MetadataRegion:    lib.MetadataRegion,
 		},
 	}, nil
 }
 
+func (s *Server) handleDeleteLibrary(ctx context.Context, input *DeleteLibraryInput) (*MessageOutput, error) {
+	if _, err := s.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Library.DeleteLibrary(ctx, input.ID); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Library deleted"}}, nil
+}
+
+func (s *Server) handleGetLibraryStats(ctx context.Context, input *GetLibraryInput) (*LibraryStatsOutput, error) {
+	if _, err := GetUserID(ctx); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.services.Library.GetLibraryStats(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LibraryStatsOutput{Body: LibraryStatsResponse(*stats)}, nil
+}
+
 func (s *Server) handleGetLibraryStatus(ctx context.Context, _ *LibraryStatusInput) (*LibraryStatusOutput, error) {
 	userID, err := GetUserID(ctx)
 	if err != nil {
@@ -289,14 +458,17 @@ func (s *Server) handleGetLibraryStatus(ctx context.Context, _ *LibraryStatusInp
 		Body: LibraryStatusResponse{
 			Exists: true,
 			Library: &LibraryResponse{
-				ID:         library.ID,
-				Name:       library.Name,
-				OwnerID:    library.OwnerID,
-				ScanPaths:  library.ScanPaths,
-				SkipInbox:  library.SkipInbox,
-				AccessMode: string(library.GetAccessMode()),
-				CreatedAt:  library.CreatedAt,
-				UpdatedAt:  library.UpdatedAt,
+				ID:                library.ID,
+				Name:              library.Name,
+				OwnerID:           library.OwnerID,
+				ScanPaths:         library.ScanPaths,
+				SkipInbox:         library.SkipInbox,
+				AccessMode:        string(library.GetAccessMode()),
+				Type:              string(library.GetType()),
+				CreatedAt:         library.CreatedAt,
+				UpdatedAt:         library.UpdatedAt,
+				MetadataProviders: library.MetadataProviders,
+				MetadataRegion:    library.MetadataRegion,
 			},
 			NeedsSetup: false,
 			BookCount:  status.BookCount,
@@ -311,26 +483,9 @@ func (s *Server) handleSetupLibrary(ctx context.Context, input *SetupLibraryInpu
 		return nil, err
 	}
 
-	// Validate all paths exist and are accessible
-	cleanPaths := make([]string, len(input.Body.ScanPaths))
-	for i, path := range input.Body.ScanPaths {
-		cleanPath := filepath.Clean(path)
-		if !filepath.IsAbs(cleanPath) {
-			return nil, huma.Error400BadRequest("scan paths must be absolute: " + path)
-		}
-
-		info, err := os.Stat(cleanPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return nil, huma.Error400BadRequest("path does not exist: " + path)
-			}
-			return nil, huma.Error400BadRequest("cannot access path: " + path)
-		}
-
-		if !info.IsDir() {
-			return nil, huma.Error400BadRequest("path is not a directory: " + path)
-		}
-		cleanPaths[i] = cleanPath
+	cleanPaths, err := validateScanPaths(input.Body.ScanPaths)
+	if err != nil {
+		return nil, err
 	}
 
 	name := input.Body.Name
@@ -368,18 +523,47 @@ func (s *Server) handleSetupLibrary(ctx context.Context, input *SetupLibraryInpu
 
 	return &SetupLibraryOutput{
 		Body: LibraryResponse{
-			ID:         library.ID,
-			Name:       library.Name,
-			OwnerID:    library.OwnerID,
-			ScanPaths:  library.ScanPaths,
-			SkipInbox:  library.SkipInbox,
-			AccessMode: string(library.GetAccessMode()),
-			CreatedAt:  library.CreatedAt,
-			UpdatedAt:  library.UpdatedAt,
+			ID:                library.ID,
+			Name:              library.Name,
+			OwnerID:           library.OwnerID,
+			ScanPaths:         library.ScanPaths,
+			SkipInbox:         library.SkipInbox,
+			AccessMode:        string(library.GetAccessMode()),
+			Type:              string(library.GetType()),
+			CreatedAt:         library.CreatedAt,
+			UpdatedAt:         library.UpdatedAt,
+			MetadataProviders: library.MetadataProviders,
+			MetadataRegion:    library.MetadataRegion,
 		},
 	}, nil
 }
 
+// validateScanPaths checks that every path is an absolute, accessible
+// directory and returns the cleaned paths.
+func validateScanPaths(paths []string) ([]string, error) {
+	cleanPaths := make([]string, len(paths))
+	for i, path := range paths {
+		cleanPath := filepath.Clean(path)
+		if !filepath.IsAbs(cleanPath) {
+			return nil, huma.Error400BadRequest("scan paths must be absolute: " + path)
+		}
+
+		info, err := os.Stat(cleanPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, huma.Error400BadRequest("path does not exist: " + path)
+			}
+			return nil, huma.Error400BadRequest("cannot access path: " + path)
+		}
+
+		if !info.IsDir() {
+			return nil, huma.Error400BadRequest("path is not a directory: " + path)
+		}
+		cleanPaths[i] = cleanPath
+	}
+	return cleanPaths, nil
+}
+
 // === Scan Path Management DTOs ===
 
 // ScanPathInput wraps the request for adding/removing scan paths.
@@ -447,14 +631,17 @@ func (s *Server) handleAddScanPath(ctx context.Context, input *ScanPathInput) (*
 
 	return &LibraryOutput{
 		Body: LibraryResponse{
-			ID:         lib.ID,
-			Name:       lib.Name,
-			OwnerID:    lib.OwnerID,
-			ScanPaths:  lib.ScanPaths,
-			SkipInbox:  lib.SkipInbox,
-			AccessMode: string(lib.GetAccessMode()),
-			CreatedAt:  lib.CreatedAt,
-			UpdatedAt:  lib.UpdatedAt,
+			ID:                lib.ID,
+			Name:              lib.Name,
+			OwnerID:           lib.OwnerID,
+			ScanPaths:         lib.ScanPaths,
+			SkipInbox:         lib.SkipInbox,
+			AccessMode:        string(lib.GetAccessMode()),
+			Type:              string(lib.GetType()),
+			CreatedAt:         lib.CreatedAt,
+			UpdatedAt:         lib.UpdatedAt,
+			MetadataProviders: lib.MetadataProviders,
+			MetadataRegion:    lib.MetadataRegion,
 		},
 	}, nil
 }
@@ -482,14 +669,17 @@ func (s *Server) handleRemoveScanPath(ctx context.Context, input *RemoveScanPath
 
 	return &LibraryOutput{
 		Body: LibraryResponse{
-			ID:         lib.ID,
-			Name:       lib.Name,
-			OwnerID:    lib.OwnerID,
-			ScanPaths:  lib.ScanPaths,
-			SkipInbox:  lib.SkipInbox,
-			AccessMode: string(lib.GetAccessMode()),
-			CreatedAt:  lib.CreatedAt,
-			UpdatedAt:  lib.UpdatedAt,
+			ID:                lib.ID,
+			Name:              lib.Name,
+			OwnerID:           lib.OwnerID,
+			ScanPaths:         lib.ScanPaths,
+			SkipInbox:         lib.SkipInbox,
+			AccessMode:        string(lib.GetAccessMode()),
+			Type:              string(lib.GetType()),
+			CreatedAt:         lib.CreatedAt,
+			UpdatedAt:         lib.UpdatedAt,
+			MetadataProviders: lib.MetadataProviders,
+			MetadataRegion:    lib.MetadataRegion,
 		},
 	}, nil
 }
@@ -0,0 +1,327 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+func (s *Server) registerMetadataProviderRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listMetadataProviders",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/metadata/providers",
+		Summary:     "List metadata providers",
+		Description: "Lists the configured metadata providers and the order a library tries them in",
+		Tags:        []string{"Metadata"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListMetadataProviders)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "searchMetadataProviders",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/metadata/providers/search",
+		Summary:     "Search metadata providers",
+		Description: "Searches one provider, or a library's providers in priority order until one returns results",
+		Tags:        []string{"Metadata"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleSearchMetadataProviders)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getProviderBook",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/metadata/providers/{provider}/books/{id}",
+		Summary:     "Get provider book metadata",
+		Description: "Fetches book metadata from a provider by its book ID",
+		Tags:        []string{"Metadata"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetProviderBook)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getProviderChapters",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/metadata/providers/{provider}/books/{id}/chapters",
+		Summary:     "Get provider chapters",
+		Description: "Fetches chapter information from a provider that has it",
+		Tags:        []string{"Metadata"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetProviderChapters)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getProviderContributor",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/metadata/providers/{provider}/contributors/{id}",
+		Summary:     "Get provider contributor profile",
+		Description: "Fetches a contributor profile from a provider that has them",
+		Tags:        []string{"Metadata"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetProviderContributor)
+}
+
+// === DTOs ===
+
+// ListMetadataProvidersInput contains parameters for listing providers.
+type ListMetadataProvidersInput struct {
+	Authorization string `header:"Authorization"`
+	LibraryID     string `query:"library_id" doc:"Library whose priority order to return (default library if empty)"`
+}
+
+// MetadataProvidersResponse lists configured providers.
+type MetadataProvidersResponse struct {
+	Available []string `json:"available" doc:"Configured providers in default order"`
+	Priority  []string `json:"priority" doc:"Order the library tries providers in"`
+}
+
+// MetadataProvidersOutput wraps the providers response for Huma.
+type MetadataProvidersOutput struct {
+	Body MetadataProvidersResponse
+}
+
+// SearchMetadataProvidersInput contains parameters for a provider search.
+type SearchMetadataProvidersInput struct {
+	Authorization string `header:"Authorization"`
+	Query         string `query:"q" doc:"Free-text search"`
+	Title         string `query:"title" doc:"Title to match"`
+	Author        string `query:"author" doc:"Author to match"`
+	ISBN          string `query:"isbn" doc:"ISBN to match"`
+	Provider      string `query:"provider" doc:"Search only this provider"`
+	LibraryID     string `query:"library_id" doc:"Library whose priority order to use (default library if empty)"`
+	Limit         int    `query:"limit" validate:"omitempty,min=1,max=50" doc:"Maximum results"`
+}
+
+// ProviderContributorResponse is a contributor as a provider knows them.
+type ProviderContributorResponse struct {
+	ID   string `json:"id,omitempty" doc:"Provider contributor ID"`
+	Name string `json:"name" doc:"Contributor name"`
+}
+
+// ProviderSearchResultResponse is one provider search result.
+type ProviderSearchResultResponse struct {
+	Provider       string                        `json:"provider" doc:"Provider that returned the result"`
+	ID             string                        `json:"id" doc:"Provider book ID"`
+	Title          string                        `json:"title" doc:"Book title"`
+	Subtitle       string                        `json:"subtitle,omitempty" doc:"Book subtitle"`
+	Authors        []ProviderContributorResponse `json:"authors" doc:"Authors"`
+	Narrators      []ProviderContributorResponse `json:"narrators,omitempty" doc:"Narrators"`
+	CoverURL       string                        `json:"cover_url,omitempty" doc:"Cover image URL"`
+	PublishYear    string                        `json:"publish_year,omitempty" doc:"Year of publication"`
+	RuntimeMinutes int                           `json:"runtime_minutes,omitempty" doc:"Duration in minutes"`
+}
+
+// SearchMetadataProvidersResponse contains provider search results.
+type SearchMetadataProvidersResponse struct {
+	Provider string                         `json:"provider,omitempty" doc:"Provider that returned results"`
+	Results  []ProviderSearchResultResponse `json:"results" doc:"Search results"`
+}
+
+// SearchMetadataProvidersOutput wraps the provider search response for Huma.
+type SearchMetadataProvidersOutput struct {
+	Body SearchMetadataProvidersResponse
+}
+
+// GetProviderItemInput identifies a provider and one of its IDs.
+type GetProviderItemInput struct {
+	Authorization string `header:"Authorization"`
+	Provider      string `path:"provider" doc:"Metadata provider"`
+	ID            string `path:"id" doc:"Provider ID"`
+}
+
+// ProviderSeriesEntryResponse is a series entry as a provider knows it.
+type ProviderSeriesEntryResponse struct {
+	ID       string `json:"id,omitempty" doc:"Provider series ID"`
+	Name     string `json:"name" doc:"Series name"`
+	Position string `json:"position,omitempty" doc:"Position in series"`
+}
+
+// ProviderBookResponse contains book metadata from a provider.
+type ProviderBookResponse struct {
+	Provider       string                        `json:"provider" doc:"Provider"`
+	ID             string                        `json:"id" doc:"Provider book ID"`
+	Title          string                        `json:"title" doc:"Book title"`
+	Subtitle       string                        `json:"subtitle,omitempty" doc:"Book subtitle"`
+	Authors        []ProviderContributorResponse `json:"authors" doc:"Authors"`
+	Narrators      []ProviderContributorResponse `json:"narrators,omitempty" doc:"Narrators"`
+	Publisher      string                        `json:"publisher,omitempty" doc:"Publisher name"`
+	PublishYear    string                        `json:"publish_year,omitempty" doc:"Year of publication"`
+	RuntimeMinutes int                           `json:"runtime_minutes,omitempty" doc:"Duration in minutes"`
+	Description    string                        `json:"description,omitempty" doc:"Book description"`
+	CoverURL       string                        `json:"cover_url,omitempty" doc:"Cover image URL"`
+	Series         []ProviderSeriesEntryResponse `json:"series,omitempty" doc:"Series entries"`
+	Genres         []string                      `json:"genres,omitempty" doc:"Genre names"`
+	Language       string                        `json:"language,omitempty" doc:"Language"`
+	ISBN           string                        `json:"isbn,omitempty" doc:"ISBN"`
+	ASIN           string                        `json:"asin,omitempty" doc:"Audible ASIN"`
+}
+
+// ProviderBookOutput wraps the provider book response for Huma.
+type ProviderBookOutput struct {
+	Body ProviderBookResponse
+}
+
+// ProviderContributorProfileResponse contains a provider's contributor profile.
+type ProviderContributorProfileResponse struct {
+	Provider  string `json:"provider" doc:"Provider"`
+	ID        string `json:"id" doc:"Provider contributor ID"`
+	Name      string `json:"name" doc:"Contributor name"`
+	Biography string `json:"biography,omitempty" doc:"Biography text"`
+	ImageURL  string `json:"image_url,omitempty" doc:"Profile image URL"`
+}
+
+// ProviderContributorProfileOutput wraps the provider contributor response for Huma.
+type ProviderContributorProfileOutput struct {
+	Body ProviderContributorProfileResponse
+}
+
+// === Handlers ===
+
+func (s *Server) handleListMetadataProviders(ctx context.Context, input *ListMetadataProvidersInput) (*MetadataProvidersOutput, error) {
+	if _, err := GetUserID(ctx); err != nil {
+		return nil, err
+	}
+
+	priority, err := s.services.Metadata.ProviderPriority(ctx, input.LibraryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetadataProvidersOutput{
+		Body: MetadataProvidersResponse{
+			Available: s.services.Metadata.AvailableProviders(),
+			Priority:  priority,
+		},
+	}, nil
+}
+
+func (s *Server) handleSearchMetadataProviders(ctx context.Context, input *SearchMetadataProvidersInput) (*SearchMetadataProvidersOutput, error) {
+	if _, err := GetUserID(ctx); err != nil {
+		return nil, err
+	}
+
+	query := metadata.SearchQuery{
+		Keywords: input.Query,
+		Title:    input.Title,
+		Author:   input.Author,
+		ISBN:     input.ISBN,
+		Limit:    input.Limit,
+	}
+
+	var (
+		provider string
+		results  []metadata.SearchResult
+	)
+	if input.Provider != "" {
+		found, err := s.services.Metadata.SearchProvider(ctx, input.Provider, query)
+		if err != nil {
+			return nil, err
+		}
+		provider, results = input.Provider, found
+	} else {
+		found, err := s.services.Metadata.SearchProviders(ctx, input.LibraryID, query)
+		if err != nil {
+			return nil, err
+		}
+		provider, results = found.Provider, found.Results
+	}
+
+	resp := make([]ProviderSearchResultResponse, len(results))
+	for i := range results {
+		r := &results[i]
+		resp[i] = ProviderSearchResultResponse{
+			Provider:       r.Provider,
+			ID:             r.ID,
+			Title:          r.Title,
+			Subtitle:       r.Subtitle,
+			Authors:        mapProviderContributors(r.Authors),
+			Narrators:      mapProviderContributors(r.Narrators),
+			CoverURL:       r.CoverURL,
+			PublishYear:    r.PublishYear,
+			RuntimeMinutes: r.RuntimeMinutes,
+		}
+	}
+
+	return &SearchMetadataProvidersOutput{
+		Body: SearchMetadataProvidersResponse{
+			Provider: provider,
+			Results:  resp,
+		},
+	}, nil
+}
+
+func (s *Server) handleGetProviderBook(ctx context.Context, input *GetProviderItemInput) (*ProviderBookOutput, error) {
+	if _, err := GetUserID(ctx); err != nil {
+		return nil, err
+	}
+
+	book, err := s.services.Metadata.GetProviderBook(ctx, input.Provider, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := ProviderBookResponse{
+		Provider:       book.Provider,
+		ID:             book.ID,
+		Title:          book.Title,
+		Subtitle:       book.Subtitle,
+		Authors:        mapProviderContributors(book.Authors),
+		Narrators:      mapProviderContributors(book.Narrators),
+		Publisher:      book.Publisher,
+		PublishYear:    book.Year(),
+		RuntimeMinutes: book.RuntimeMinutes,
+		Description:    book.Description,
+		CoverURL:       book.CoverURL,
+		Genres:         book.Genres,
+		Language:       book.Language,
+		ISBN:           book.ISBN,
+		ASIN:           book.ASIN,
+	}
+	for _, se := range book.Series {
+		resp.Series = append(resp.Series, ProviderSeriesEntryResponse(se))
+	}
+
+	return &ProviderBookOutput{Body: resp}, nil
+}
+
+func (s *Server) handleGetProviderChapters(ctx context.Context, input *GetProviderItemInput) (*MetadataChaptersOutput, error) {
+	if _, err := GetUserID(ctx); err != nil {
+		return nil, err
+	}
+
+	chapters, err := s.services.Metadata.GetProviderChapters(ctx, input.Provider, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]MetadataChapterResponse, len(chapters))
+	for i, ch := range chapters {
+		resp[i] = MetadataChapterResponse(ch)
+	}
+
+	return &MetadataChaptersOutput{
+		Body: MetadataChaptersResponse{Chapters: resp},
+	}, nil
+}
+
+func (s *Server) handleGetProviderContributor(ctx context.Context, input *GetProviderItemInput) (*ProviderContributorProfileOutput, error) {
+	if _, err := GetUserID(ctx); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.services.Metadata.GetProviderContributor(ctx, input.Provider, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProviderContributorProfileOutput{
+		Body: ProviderContributorProfileResponse(*profile),
+	}, nil
+}
+
+func mapProviderContributors(contributors []metadata.Contributor) []ProviderContributorResponse {
+	resp := make([]ProviderContributorResponse, len(contributors))
+	for i, c := range contributors {
+		resp[i] = ProviderContributorResponse(c)
+	}
+	return resp
+}
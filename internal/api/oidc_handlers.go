@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+func (s *Server) registerOIDCRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getOIDCInfo",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/auth/oidc",
+		Summary:     "Get single sign-on info",
+		Description: "Returns whether single sign-on is configured and the label for its login button",
+		Tags:        []string{"Authentication"},
+	}, s.handleGetOIDCInfo)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "startOIDCLogin",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/oidc/start",
+		Summary:     "Start single sign-on",
+		Description: "Returns the identity provider URL to open in a browser. After login the browser is sent to return_to with a one-time code (or an error), which the client exchanges at /api/v1/auth/oidc/exchange.",
+		Tags:        []string{"Authentication"},
+	}, s.handleStartOIDCLogin)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "oidcCallback",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/auth/oidc/callback",
+		Summary:     "Single sign-on callback",
+		Description: "Redirect target registered with the identity provider. Sends the browser back to the client's return_to URL with code, or error and error_description.",
+		Tags:        []string{"Authentication"},
+	}, s.handleOIDCCallback)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "exchangeOIDCCode",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/oidc/exchange",
+		Summary:     "Complete single sign-on",
+		Description: "Exchanges the one-time code from the callback for access and refresh tokens. Users with two-factor authentication get a challenge_token instead, as with password login.",
+		Tags:        []string{"Authentication"},
+	}, s.handleExchangeOIDCCode)
+}
+
+// === DTOs ===
+
+// OIDCInfoInput is the Huma input for single sign-on info.
+type OIDCInfoInput struct{}
+
+// OIDCInfoResponse is the API response for single sign-on info.
+type OIDCInfoResponse struct {
+	Enabled     bool   `json:"enabled" doc:"Whether single sign-on is configured"`
+	ButtonLabel string `json:"button_label,omitempty" doc:"Label for the login button"`
+}
+
+// OIDCInfoOutput wraps the single sign-on info response for Huma.
+type OIDCInfoOutput struct {
+	Body OIDCInfoResponse
+}
+
+// StartOIDCLoginRequest is the request body for starting single sign-on.
+type StartOIDCLoginRequest struct {
+	ReturnTo string `json:"return_to" validate:"required,max=2048" doc:"Where to send the browser afterwards: a path on this server or an allowed app URL such as listenup://oidc"`
+}
+
+// StartOIDCLoginInput wraps the start request for Huma.
+type StartOIDCLoginInput struct {
+	Body          StartOIDCLoginRequest
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+}
+
+// StartOIDCLoginResponse is the API response for starting single sign-on.
+type StartOIDCLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url" doc:"Identity provider URL to open in a browser"`
+}
+
+// StartOIDCLoginOutput wraps the start response for Huma.
+type StartOIDCLoginOutput struct {
+	Body StartOIDCLoginResponse
+}
+
+// OIDCCallbackInput is what the identity provider sends to the callback.
+type OIDCCallbackInput struct {
+	State            string `query:"state"`
+	Code             string `query:"code"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
+// OIDCCallbackOutput redirects the browser back to the client.
+type OIDCCallbackOutput struct {
+	Status   int
+	Location string `header:"Location"`
+}
+
+// StatusCode returns the HTTP status code for the redirect.
+func (o *OIDCCallbackOutput) StatusCode() int {
+	return o.Status
+}
+
+// ExchangeOIDCCodeRequest is the request body for completing single sign-on.
+type ExchangeOIDCCodeRequest struct {
+	Code       string     `json:"code" validate:"required,max=128" doc:"One-time code from the callback redirect"`
+	DeviceInfo DeviceInfo `json:"device_info,omitempty" doc:"Client device info"`
+}
+
+// ExchangeOIDCCodeInput wraps the exchange request for Huma.
+type ExchangeOIDCCodeInput struct {
+	Body          ExchangeOIDCCodeRequest
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+}
+
+// === Handlers ===
+
+func (s *Server) handleGetOIDCInfo(_ context.Context, _ *OIDCInfoInput) (*OIDCInfoOutput, error) {
+	info := s.services.OIDC.Info()
+	return &OIDCInfoOutput{
+		Body: OIDCInfoResponse{
+			Enabled:     info.Enabled,
+			ButtonLabel: info.ButtonLabel,
+		},
+	}, nil
+}
+
+func (s *Server) handleStartOIDCLogin(ctx context.Context, input *StartOIDCLoginInput) (*StartOIDCLoginOutput, error) {
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	authURL, err := s.services.OIDC.StartLogin(ctx, input.Body.ReturnTo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StartOIDCLoginOutput{
+		Body: StartOIDCLoginResponse{AuthorizationURL: authURL},
+	}, nil
+}
+
+func (s *Server) handleOIDCCallback(ctx context.Context, input *OIDCCallbackInput) (*OIDCCallbackOutput, error) {
+	location, err := s.services.OIDC.HandleCallback(ctx, service.OIDCCallback{
+		State:            input.State,
+		Code:             input.Code,
+		Error:            input.Error,
+		ErrorDescription: input.ErrorDescription,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCCallbackOutput{
+		Status:   http.StatusFound,
+		Location: location,
+	}, nil
+}
+
+func (s *Server) handleExchangeOIDCCode(ctx context.Context, input *ExchangeOIDCCodeInput) (*AuthOutput, error) {
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	req := service.OIDCExchangeRequest{
+		Code: input.Body.Code,
+		DeviceInfo: auth.DeviceInfo{
+			DeviceType:      input.Body.DeviceInfo.DeviceType,
+			Platform:        input.Body.DeviceInfo.Platform,
+			PlatformVersion: input.Body.DeviceInfo.PlatformVersion,
+			ClientName:      input.Body.DeviceInfo.ClientName,
+			ClientVersion:   input.Body.DeviceInfo.ClientVersion,
+			ClientBuild:     input.Body.DeviceInfo.ClientBuild,
+			DeviceName:      input.Body.DeviceInfo.DeviceName,
+			BrowserName:     input.Body.DeviceInfo.BrowserName,
+			BrowserVersion:  input.Body.DeviceInfo.BrowserVersion,
+			DeviceModel:     input.Body.DeviceInfo.DeviceModel,
+		},
+		IPAddress: extractIP(input.XForwardedFor, input.XRealIP),
+	}
+
+	resp, err := s.services.OIDC.Exchange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthOutput{Body: s.mapAuthResponse(ctx, resp)}, nil
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/config"
+	"github.com/listenupapp/listenup-server/internal/oidc"
+	"github.com/listenupapp/listenup-server/internal/oidc/oidctest"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+func TestOIDC_LoginFlow(t *testing.T) {
+	t.Parallel()
+	ts := setupTestServer(t)
+	ts.createTestUserAndLogin(t)
+
+	// Disabled until an issuer is configured.
+	resp := ts.api.Get("/api/v1/auth/oidc")
+	require.Equal(t, http.StatusOK, resp.Code)
+	var info testEnvelope[OIDCInfoResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &info))
+	assert.False(t, info.Data.Enabled)
+
+	issuer := oidctest.NewIssuer(t, "listenup")
+	provider, err := oidc.NewProvider(oidc.Config{
+		IssuerURL:    issuer.URL,
+		ClientID:     "listenup",
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  "http://localhost:8080/api/v1/auth/oidc/callback",
+	})
+	require.NoError(t, err)
+	cfg := config.OIDCConfig{
+		ButtonLabel:      "Sign in with Homelab",
+		AutoProvision:    true,
+		AllowedRedirects: []string{"listenup://"},
+	}
+	ts.services.OIDC = service.NewOIDCService(ts.store, provider, cfg, ts.services.Auth, ts.services.Instance, ts.logger)
+
+	resp = ts.api.Get("/api/v1/auth/oidc")
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &info))
+	assert.True(t, info.Data.Enabled)
+	assert.Equal(t, "Sign in with Homelab", info.Data.ButtonLabel)
+
+	resp = ts.api.Post("/api/v1/auth/oidc/start", map[string]any{"return_to": "https://evil.example"})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = ts.api.Post("/api/v1/auth/oidc/start", map[string]any{"return_to": "listenup://oidc"})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var start testEnvelope[StartOIDCLoginResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &start))
+
+	// The user signs in at the provider, which redirects the browser to us.
+	cb := issuer.Authorize(t, start.Data.AuthorizationURL, map[string]any{
+		"sub": "sub-admin", "email": "admin@test.com", "email_verified": true,
+	})
+	resp = ts.api.Get("/api/v1/auth/oidc/callback?" + cb.Encode())
+	require.Equal(t, http.StatusFound, resp.Code, resp.Body.String())
+	location, err := url.Parse(resp.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "listenup", location.Scheme)
+	code := location.Query().Get("code")
+	require.NotEmpty(t, code, location.String())
+
+	resp = ts.api.Post("/api/v1/auth/oidc/exchange", map[string]any{
+		"code":        code,
+		"device_info": map[string]any{"device_type": "mobile", "platform": "Android"},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var authResp testEnvelope[AuthResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &authResp))
+	assert.NotEmpty(t, authResp.Data.RefreshToken)
+	assert.Equal(t, "admin@test.com", authResp.Data.User.Email, "linked to the existing account")
+
+	// The issued access token works against authenticated routes.
+	if ts.services.Profile == nil {
+		ts.services.Profile = service.NewProfileService(ts.store, nil, nil, nil, ts.logger)
+	}
+	resp = ts.api.Get("/api/v1/users/me", "Authorization: Bearer "+authResp.Data.AccessToken)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var me testEnvelope[UserResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &me))
+	assert.Equal(t, "admin@test.com", me.Data.Email)
+}
@@ -75,10 +75,11 @@ func setupPermTestServer(t *testing.T) *permTestServer {
 	sseManager := sse.NewManager(logger)
 	enricher := dto.NewEnricher(st)
 
-	sessionService := service.NewSessionService(st, tokenService, logger)
+	sessionService := service.NewSessionService(st, tokenService, sseManager, logger)
 	instanceService := service.NewInstanceService(st, logger, cfg)
-	authService := service.NewAuthService(st, tokenService, sessionService, instanceService, logger)
-	syncService := service.NewSyncService(st, logger)
+	twoFactorService := service.NewTwoFactorService(st, logger)
+	authService := service.NewAuthService(st, tokenService, sessionService, instanceService, twoFactorService, logger)
+	syncService := service.NewSyncService(st, enricher, logger)
 	sharingService := service.NewSharingService(st, logger)
 	shelfService := service.NewShelfService(st, sseManager, logger)
 	inboxService := service.NewInboxService(st, enricher, sseManager, logger)
@@ -90,13 +91,14 @@ func setupPermTestServer(t *testing.T) *permTestServer {
 	bookService := service.NewBookService(st, nil, nil, nil, nil, noopIdx, logger)
 
 	services := &Services{
-		Instance: instanceService,
-		Auth:     authService,
-		Sync:     syncService,
-		Sharing:  sharingService,
-		Shelf:    shelfService,
-		Inbox:    inboxService,
-		Book:     bookService,
+		Instance:  instanceService,
+		Auth:      authService,
+		TwoFactor: twoFactorService,
+		Sync:      syncService,
+		Sharing:   sharingService,
+		Shelf:     shelfService,
+		Inbox:     inboxService,
+		Book:      bookService,
 	}
 
 	router := chi.NewRouter()
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -20,7 +21,7 @@ func (s *Server) registerPlaybackRoutes() {
 		Method:      http.MethodPost,
 		Path:        "/api/v1/playback/prepare",
 		Summary:     "Prepare audio playback",
-		Description: "Negotiates audio format based on client capabilities. Returns stream URL for playable formats, or triggers transcoding for incompatible formats.",
+		Description: "Negotiates audio format based on client capabilities. Returns stream URL for playable formats, or triggers transcoding for incompatible formats. Includes the gain to apply for consistent loudness once the file has been analyzed.",
 		Tags:        []string{"Playback"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handlePreparePlayback)
@@ -117,6 +118,7 @@ type BookPreferencesResponse struct {
 	PlaybackSpeed             *float32  `json:"playback_speed,omitempty" doc:"Playback speed override"`
 	SkipForwardSec            *int      `json:"skip_forward_sec,omitempty" doc:"Skip forward override"`
 	HideFromContinueListening bool      `json:"hide_from_continue_listening" doc:"Hide from continue listening"`
+	PrivateListening          bool      `json:"private_listening" doc:"Keep this book out of the feed, currently listening, readers and profile"`
 	UpdatedAt                 time.Time `json:"updated_at" doc:"Last update time"`
 }
 
@@ -130,6 +132,7 @@ type UpdateBookPreferencesRequest struct {
 	PlaybackSpeed             *float32 `json:"playback_speed" validate:"omitempty,gt=0,lte=4" doc:"Playback speed override"`
 	SkipForwardSec            *int     `json:"skip_forward_sec" validate:"omitempty,gte=5,lte=300" doc:"Skip forward override"`
 	HideFromContinueListening *bool    `json:"hide_from_continue_listening" doc:"Hide from continue listening"`
+	PrivateListening          *bool    `json:"private_listening" doc:"Keep this book out of the feed, currently listening, readers and profile"`
 }
 
 // UpdateBookPreferencesInput wraps the update book preferences request for Huma.
@@ -210,6 +213,7 @@ func (s *Server) handleGetBookPreferences(ctx context.Context, input *GetBookPre
 			PlaybackSpeed:             prefs.PlaybackSpeed,
 			SkipForwardSec:            prefs.SkipForwardSec,
 			HideFromContinueListening: prefs.HideFromContinueListening,
+			PrivateListening:          prefs.PrivateListening,
 			UpdatedAt:                 prefs.UpdatedAt,
 		},
 	}, nil
@@ -225,6 +229,7 @@ func (s *Server) handleUpdateBookPreferences(ctx context.Context, input *UpdateB
 		PlaybackSpeed:             input.Body.PlaybackSpeed,
 		SkipForwardSec:            input.Body.SkipForwardSec,
 		HideFromContinueListening: input.Body.HideFromContinueListening,
+		PrivateListening:          input.Body.PrivateListening,
 	})
 	if err != nil {
 		return nil, err
@@ -236,6 +241,7 @@ func (s *Server) handleUpdateBookPreferences(ctx context.Context, input *UpdateB
 			PlaybackSpeed:             prefs.PlaybackSpeed,
 			SkipForwardSec:            prefs.SkipForwardSec,
 			HideFromContinueListening: prefs.HideFromContinueListening,
+			PrivateListening:          prefs.PrivateListening,
 			UpdatedAt:                 prefs.UpdatedAt,
 		},
 	}, nil
@@ -249,6 +255,7 @@ type PreparePlaybackRequest struct {
 	AudioFileID  string   `json:"audio_file_id" validate:"required" doc:"Audio file ID"`
 	Capabilities []string `json:"capabilities" doc:"Codecs the client can play (e.g., aac, mp3, opus)"`
 	Spatial      bool     `json:"spatial" doc:"Whether client prefers spatial audio"`
+	Normalize    bool     `json:"normalize" doc:"Stream a loudness-normalized transcode, for clients that cannot apply gain_db themselves. Ignored when transcoding is disabled."`
 }
 
 // PreparePlaybackInput wraps the prepare playback request for Huma.
@@ -261,10 +268,14 @@ type PreparePlaybackInput struct {
 type PreparePlaybackResponse struct {
 	Ready          bool   `json:"ready" doc:"True if audio is ready to stream"`
 	StreamURL      string `json:"stream_url" doc:"URL to stream the audio"`
-	Variant        string `json:"variant" doc:"Which variant: original or transcoded"`
+	Variant        string `json:"variant" doc:"Which variant: original, transcoded or normalized"`
 	Codec          string `json:"codec" doc:"Codec of the stream"`
 	TranscodeJobID string `json:"transcode_job_id,omitempty" doc:"Job ID if transcoding in progress"`
 	Progress       int    `json:"progress" doc:"Transcode progress (0-100)"`
+	// GainDB is absent until the file is analyzed, and for normalized streams.
+	GainDB *float64 `json:"gain_db,omitempty" doc:"Gain in dB to apply for consistent loudness across books"`
+	// StreamURLExpiresAt is set with StreamURL; prepare again for a fresh URL.
+	StreamURLExpiresAt *time.Time `json:"stream_url_expires_at,omitempty" doc:"When the signed stream URL stops working"`
 }
 
 // PreparePlaybackOutput wraps the prepare playback response for Huma.
@@ -290,30 +301,51 @@ func (s *Server) handlePreparePlayback(ctx context.Context, input *PreparePlayba
 		return nil, huma.Error404NotFound("audio file not found")
 	}
 
+	// Tell the client the gain once the file has been measured; until then,
+	// make sure a measurement is queued.
+	var gainDB *float64
+	if audioFile.Loudness != nil {
+		gain := audioFile.Loudness.GainDB()
+		gainDB = &gain
+	} else if err := s.services.Transcode.QueueLoudnessAnalysis(ctx, input.Body.BookID, audioFile.ID, audioFile.Path); err != nil {
+		s.logger.Warn("failed to queue loudness analysis",
+			slog.String("audio_file_id", audioFile.ID),
+			slog.Any("error", err))
+	}
+
+	// Normalizing means transcoding, so it needs the transcoder
+	normalize := input.Body.Normalize && s.services.Transcode.IsEnabled()
+
 	// Check if client can play the source format
 	sourceCodec := audioFile.Codec
 	canPlay := s.canClientPlayCodec(sourceCodec, input.Body.Capabilities)
 
-	// Build base URL for streaming from request context
-	// The actual base URL will be provided by the client in the stream URL it receives
-	baseURL := ""
-
-	if canPlay {
-		// Client can play original format - return direct stream URL
-		streamURL := baseURL + "/api/v1/audio/" + input.Body.BookID + "/" + input.Body.AudioFileID
+	if canPlay && !normalize {
+		// Client can play original format - return a signed stream URL
+		grant, streamURL, err := s.signStreamURL(ctx, userID, input.Body.BookID, input.Body.AudioFileID)
+		if err != nil {
+			return nil, err
+		}
 		return &PreparePlaybackOutput{
 			Body: PreparePlaybackResponse{
-				Ready:     true,
-				StreamURL: streamURL,
-				Variant:   "original",
-				Codec:     sourceCodec,
-				Progress:  100,
+				Ready:              true,
+				StreamURL:          streamURL,
+				Variant:            "original",
+				Codec:              sourceCodec,
+				Progress:           100,
+				GainDB:             gainDB,
+				StreamURLExpiresAt: &grant.ExpiresAt,
 			},
 		}, nil
 	}
 
-	// Client cannot play source format - need transcoding
-	variant := s.selectTranscodeVariant(input.Body.Spatial, sourceCodec)
+	// Client cannot play source format, or wants it normalized - need transcoding
+	variant := s.selectTranscodeVariant(input.Body.Spatial, normalize, sourceCodec)
+	responseVariant := "transcoded"
+	if variant == domain.TranscodeVariantNormalized {
+		responseVariant = string(domain.TranscodeVariantNormalized)
+		gainDB = nil // Already applied
+	}
 
 	// Check for existing transcode job or create one
 	job, err := s.services.Transcode.CreateJob(
@@ -322,7 +354,7 @@ func (s *Server) handlePreparePlayback(ctx context.Context, input *PreparePlayba
 		input.Body.AudioFileID,
 		audioFile.Path,
 		sourceCodec,
-		10, // High priority for user-requested playback
+		domain.TranscodePriorityPlayback,
 		variant,
 	)
 	if err != nil {
@@ -332,15 +364,20 @@ func (s *Server) handlePreparePlayback(ctx context.Context, input *PreparePlayba
 	// Build response based on job status
 	switch job.Status {
 	case domain.TranscodeStatusCompleted:
-		// Transcode ready - return HLS stream URL
-		streamURL := baseURL + "/api/v1/audio/" + input.Body.BookID + "/" + input.Body.AudioFileID + "/transcode/playlist.m3u8"
+		// Transcode ready - return a signed HLS stream URL
+		grant, streamURL, err := s.signStreamURL(ctx, userID, input.Body.BookID, input.Body.AudioFileID)
+		if err != nil {
+			return nil, err
+		}
 		return &PreparePlaybackOutput{
 			Body: PreparePlaybackResponse{
-				Ready:     true,
-				StreamURL: streamURL,
-				Variant:   "transcoded",
-				Codec:     codecAAC,
-				Progress:  100,
+				Ready:              true,
+				StreamURL:          streamURL + "/transcode/" + string(variant) + "/playlist.m3u8",
+				Variant:            responseVariant,
+				Codec:              codecAAC,
+				Progress:           100,
+				GainDB:             gainDB,
+				StreamURLExpiresAt: &grant.ExpiresAt,
 			},
 		}, nil
 
@@ -354,10 +391,11 @@ func (s *Server) handlePreparePlayback(ctx context.Context, input *PreparePlayba
 			Body: PreparePlaybackResponse{
 				Ready:          false,
 				StreamURL:      "",
-				Variant:        "transcoded",
+				Variant:        responseVariant,
 				Codec:          codecAAC,
 				TranscodeJobID: job.ID,
 				Progress:       job.Progress,
+				GainDB:         gainDB,
 			},
 		}, nil
 	}
@@ -392,7 +430,12 @@ func normalizeCodec(codec string) string {
 }
 
 // selectTranscodeVariant chooses the appropriate transcode variant.
-func (s *Server) selectTranscodeVariant(preferSpatial bool, sourceCodec string) domain.TranscodeVariant {
+// Normalized output is stereo, so it wins over a spatial preference.
+func (s *Server) selectTranscodeVariant(preferSpatial, normalize bool, sourceCodec string) domain.TranscodeVariant {
+	if normalize {
+		return domain.TranscodeVariantNormalized
+	}
+
 	// If client prefers spatial and source is a surround format, use spatial variant
 	// AC-3, E-AC-3, AC-4, TrueHD, DTS typically contain surround audio
 	if preferSpatial {
@@ -0,0 +1,522 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+// NOTE: Episode audio is registered directly on chi (not Huma), like book
+// audio in audio_handlers.go, for range request support:
+//
+//	GET /api/v1/podcasts/episodes/{episodeId}/audio - Stream a downloaded episode
+func (s *Server) registerPodcastRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listPodcasts",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/podcasts",
+		Summary:     "List podcasts",
+		Description: "Returns podcast subscriptions, optionally for one podcast library",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListPodcasts)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "subscribePodcast",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/podcasts",
+		Summary:     "Subscribe to podcast",
+		Description: "Subscribes a podcast library to an RSS or Atom feed. The feed is fetched immediately; with auto-download on, its newest episode is queued. Admin only.",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleSubscribePodcast)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getPodcast",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/podcasts/{id}",
+		Summary:     "Get podcast",
+		Description: "Returns a podcast subscription",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetPodcast)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "updatePodcast",
+		Method:      http.MethodPatch,
+		Path:        "/api/v1/podcasts/{id}",
+		Summary:     "Update podcast settings",
+		Description: "Changes auto-download and retention settings. New retention rules apply immediately. Admin only.",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleUpdatePodcast)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "unsubscribePodcast",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/podcasts/{id}",
+		Summary:     "Unsubscribe from podcast",
+		Description: "Removes a podcast with its episodes and playback state. Downloaded files are kept unless delete_files is set. Admin only.",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleUnsubscribePodcast)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "refreshPodcast",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/podcasts/{id}/refresh",
+		Summary:     "Refresh podcast",
+		Description: "Fetches the feed now instead of waiting for the hourly refresh",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRefreshPodcast)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listPodcastEpisodes",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/podcasts/{id}/episodes",
+		Summary:     "List podcast episodes",
+		Description: "Returns a podcast's episodes, newest first, with the current user's progress",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListPodcastEpisodes)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "downloadPodcastEpisode",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/podcasts/episodes/{id}/download",
+		Summary:     "Download episode",
+		Description: "Queues an episode for download into the podcast library",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleDownloadPodcastEpisode)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "recordEpisodeListening",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/podcasts/episodes/{id}/events",
+		Summary:     "Record episode listening",
+		Description: "Records a span of listening to an episode and updates the current user's progress. Repeating an event ID is a no-op.",
+		Tags:        []string{"Podcasts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRecordEpisodeEvent)
+
+	s.router.Get("/api/v1/podcasts/episodes/{episodeId}/audio", s.handleStreamEpisode)
+	s.router.Head("/api/v1/podcasts/episodes/{episodeId}/audio", s.handleStreamEpisode)
+}
+
+// === DTOs ===
+
+// PodcastResponse contains podcast data in API responses.
+type PodcastResponse struct {
+	ID              string     `json:"id" doc:"Podcast ID"`
+	LibraryID       string     `json:"library_id" doc:"Podcast library ID"`
+	FeedURL         string     `json:"feed_url" doc:"RSS or Atom feed URL"`
+	Title           string     `json:"title" doc:"Show title"`
+	Author          string     `json:"author,omitempty" doc:"Show author or host"`
+	Description     string     `json:"description,omitempty" doc:"Show description"`
+	ImageURL        string     `json:"image_url,omitempty" doc:"Show artwork URL"`
+	Link            string     `json:"link,omitempty" doc:"Show website"`
+	Language        string     `json:"language,omitempty" doc:"Feed language"`
+	Explicit        bool       `json:"explicit" doc:"Whether the feed is marked explicit"`
+	AutoDownload    bool       `json:"auto_download" doc:"New episodes are downloaded automatically"`
+	KeepEpisodes    int        `json:"keep_episodes" doc:"Downloads to keep (0 = all)"`
+	KeepDays        int        `json:"keep_days" doc:"Remove downloads older than this many days (0 = never)"`
+	LastRefreshedAt *time.Time `json:"last_refreshed_at,omitempty" doc:"Last feed refresh"`
+	LastError       string     `json:"last_error,omitempty" doc:"Why the last refresh failed"`
+	CreatedAt       time.Time  `json:"created_at" doc:"Subscribed time"`
+	UpdatedAt       time.Time  `json:"updated_at" doc:"Updated time"`
+}
+
+// EpisodeProgressResponse is the current user's progress through an episode.
+type EpisodeProgressResponse struct {
+	CurrentPositionMs int64      `json:"current_position_ms" doc:"Playback position"`
+	Progress          float64    `json:"progress" doc:"Fraction listened, 0 to 1"`
+	IsFinished        bool       `json:"is_finished" doc:"Whether the episode is finished"`
+	FinishedAt        *time.Time `json:"finished_at,omitempty" doc:"When the episode was finished"`
+	LastPlayedAt      time.Time  `json:"last_played_at" doc:"Last played time"`
+}
+
+// EpisodeResponse contains podcast episode data in API responses.
+type EpisodeResponse struct {
+	ID           string                   `json:"id" doc:"Episode ID"`
+	PodcastID    string                   `json:"podcast_id" doc:"Podcast ID"`
+	Title        string                   `json:"title" doc:"Episode title"`
+	Description  string                   `json:"description,omitempty" doc:"Show notes (may contain HTML)"`
+	PublishedAt  *time.Time               `json:"published_at,omitempty" doc:"Publication date"`
+	DurationMs   int64                    `json:"duration_ms" doc:"Duration"`
+	Season       int                      `json:"season,omitempty" doc:"Season number"`
+	Number       int                      `json:"number,omitempty" doc:"Episode number"`
+	Status       string                   `json:"status" doc:"Download status: available, queued, downloading, downloaded, or failed"`
+	FileSize     int64                    `json:"file_size,omitempty" doc:"Downloaded file size in bytes"`
+	DownloadedAt *time.Time               `json:"downloaded_at,omitempty" doc:"When the episode was downloaded"`
+	Error        string                   `json:"error,omitempty" doc:"Why the last download failed"`
+	Progress     *EpisodeProgressResponse `json:"progress,omitempty" doc:"Current user's progress (omitted if never played)"`
+}
+
+// ListPodcastsInput contains parameters for listing podcasts.
+type ListPodcastsInput struct {
+	Authorization string `header:"Authorization"`
+	LibraryID     string `query:"library_id" doc:"Only podcasts in this library"`
+}
+
+// ListPodcastsResponse contains a list of podcasts.
+type ListPodcastsResponse struct {
+	Podcasts []PodcastResponse `json:"podcasts" doc:"Podcasts, by title"`
+}
+
+// ListPodcastsOutput wraps the list podcasts response for Huma.
+type ListPodcastsOutput struct {
+	Body ListPodcastsResponse
+}
+
+// SubscribePodcastRequest is the request body for subscribing to a feed.
+type SubscribePodcastRequest struct {
+	LibraryID    string `json:"library_id" validate:"required" doc:"Podcast library to subscribe"`
+	FeedURL      string `json:"feed_url" validate:"required,max=2048" doc:"RSS or Atom feed URL"`
+	AutoDownload bool   `json:"auto_download,omitempty" doc:"Download new episodes automatically"`
+	KeepEpisodes int    `json:"keep_episodes,omitempty" minimum:"0" doc:"Downloads to keep (0 = all)"`
+	KeepDays     int    `json:"keep_days,omitempty" minimum:"0" doc:"Remove downloads older than this many days (0 = never)"`
+}
+
+// SubscribePodcastInput wraps the subscribe request for Huma.
+type SubscribePodcastInput struct {
+	Authorization string `header:"Authorization"`
+	Body          SubscribePodcastRequest
+}
+
+// PodcastIDInput contains a podcast ID path parameter.
+type PodcastIDInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Podcast ID"`
+}
+
+// UpdatePodcastRequest is the request body for changing podcast settings.
+type UpdatePodcastRequest struct {
+	AutoDownload *bool `json:"auto_download,omitempty" doc:"Download new episodes automatically"`
+	KeepEpisodes *int  `json:"keep_episodes,omitempty" minimum:"0" doc:"Downloads to keep (0 = all)"`
+	KeepDays     *int  `json:"keep_days,omitempty" minimum:"0" doc:"Remove downloads older than this many days (0 = never)"`
+}
+
+// UpdatePodcastInput wraps the update podcast request for Huma.
+type UpdatePodcastInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Podcast ID"`
+	Body          UpdatePodcastRequest
+}
+
+// UnsubscribePodcastInput contains parameters for unsubscribing.
+type UnsubscribePodcastInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Podcast ID"`
+	DeleteFiles   bool   `query:"delete_files" doc:"Also delete downloaded episodes from disk"`
+}
+
+// PodcastOutput wraps a single podcast response for Huma.
+type PodcastOutput struct {
+	Body PodcastResponse
+}
+
+// RefreshPodcastResponse contains the result of a feed refresh.
+type RefreshPodcastResponse struct {
+	Podcast     PodcastResponse `json:"podcast" doc:"Refreshed podcast"`
+	NewEpisodes int             `json:"new_episodes" doc:"Episodes added by this refresh"`
+}
+
+// RefreshPodcastOutput wraps the refresh response for Huma.
+type RefreshPodcastOutput struct {
+	Body RefreshPodcastResponse
+}
+
+// ListPodcastEpisodesResponse contains a podcast's episodes.
+type ListPodcastEpisodesResponse struct {
+	Episodes []EpisodeResponse `json:"episodes" doc:"Episodes, newest first"`
+}
+
+// ListPodcastEpisodesOutput wraps the list episodes response for Huma.
+type ListPodcastEpisodesOutput struct {
+	Body ListPodcastEpisodesResponse
+}
+
+// EpisodeIDInput contains an episode ID path parameter.
+type EpisodeIDInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Episode ID"`
+}
+
+// EpisodeOutput wraps a single episode response for Huma.
+type EpisodeOutput struct {
+	Body EpisodeResponse
+}
+
+// RecordEpisodeEventRequest is the request body for recording episode listening.
+type RecordEpisodeEventRequest struct {
+	ID              string  `json:"id,omitempty" doc:"Client-generated event ID for idempotency"`
+	StartPositionMs int64   `json:"start_position_ms" validate:"gte=0" doc:"Start position in ms"`
+	EndPositionMs   int64   `json:"end_position_ms" validate:"gte=0" doc:"End position in ms"`
+	StartedAtMs     int64   `json:"started_at" validate:"required" doc:"When playback started (epoch ms)"`
+	EndedAtMs       int64   `json:"ended_at" validate:"required" doc:"When playback ended (epoch ms)"`
+	PlaybackSpeed   float32 `json:"playback_speed" validate:"gt=0,lte=4" doc:"Playback speed"`
+	DeviceID        string  `json:"device_id" validate:"required,max=100" doc:"Device ID"`
+	DeviceName      string  `json:"device_name,omitempty" doc:"Device name"`
+}
+
+// RecordEpisodeEventInput wraps the record episode event request for Huma.
+type RecordEpisodeEventInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Episode ID"`
+	Body          RecordEpisodeEventRequest
+}
+
+// EpisodeProgressOutput wraps an episode progress response for Huma.
+type EpisodeProgressOutput struct {
+	Body EpisodeProgressResponse
+}
+
+// === Handlers ===
+
+func (s *Server) handleListPodcasts(ctx context.Context, input *ListPodcastsInput) (*ListPodcastsOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	podcasts, err := s.services.Podcast.ListPodcasts(ctx, userID, input.LibraryID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]PodcastResponse, len(podcasts))
+	for i, p := range podcasts {
+		resp[i] = toPodcastResponse(p)
+	}
+	return &ListPodcastsOutput{Body: ListPodcastsResponse{Podcasts: resp}}, nil
+}
+
+func (s *Server) handleSubscribePodcast(ctx context.Context, input *SubscribePodcastInput) (*PodcastOutput, error) {
+	if _, err := s.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	p, err := s.services.Podcast.Subscribe(ctx, service.SubscribeRequest{
+		LibraryID:    input.Body.LibraryID,
+		FeedURL:      input.Body.FeedURL,
+		AutoDownload: input.Body.AutoDownload,
+		KeepEpisodes: input.Body.KeepEpisodes,
+		KeepDays:     input.Body.KeepDays,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PodcastOutput{Body: toPodcastResponse(p)}, nil
+}
+
+func (s *Server) handleGetPodcast(ctx context.Context, input *PodcastIDInput) (*PodcastOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.services.Podcast.GetPodcast(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &PodcastOutput{Body: toPodcastResponse(p)}, nil
+}
+
+func (s *Server) handleUpdatePodcast(ctx context.Context, input *UpdatePodcastInput) (*PodcastOutput, error) {
+	if _, err := s.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	p, err := s.services.Podcast.UpdatePodcast(ctx, input.ID, service.UpdatePodcastRequest{
+		AutoDownload: input.Body.AutoDownload,
+		KeepEpisodes: input.Body.KeepEpisodes,
+		KeepDays:     input.Body.KeepDays,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PodcastOutput{Body: toPodcastResponse(p)}, nil
+}
+
+func (s *Server) handleUnsubscribePodcast(ctx context.Context, input *UnsubscribePodcastInput) (*MessageOutput, error) {
+	if _, err := s.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Podcast.Unsubscribe(ctx, input.ID, input.DeleteFiles); err != nil {
+		return nil, err
+	}
+	return &MessageOutput{Body: MessageResponse{Message: "Unsubscribed"}}, nil
+}
+
+func (s *Server) handleRefreshPodcast(ctx context.Context, input *PodcastIDInput) (*RefreshPodcastOutput, error) {
+	if _, err := s.RequireCanEdit(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := s.services.Podcast.Refresh(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &RefreshPodcastOutput{Body: RefreshPodcastResponse{
+		Podcast:     toPodcastResponse(result.Podcast),
+		NewEpisodes: result.NewEpisodes,
+	}}, nil
+}
+
+func (s *Server) handleListPodcastEpisodes(ctx context.Context, input *PodcastIDInput) (*ListPodcastEpisodesOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.services.Podcast.ListEpisodes(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	episodes := make([]EpisodeResponse, len(entries))
+	for i, e := range entries {
+		episodes[i] = toEpisodeResponse(e.Episode, e.State)
+	}
+	return &ListPodcastEpisodesOutput{Body: ListPodcastEpisodesResponse{Episodes: episodes}}, nil
+}
+
+func (s *Server) handleDownloadPodcastEpisode(ctx context.Context, input *EpisodeIDInput) (*EpisodeOutput, error) {
+	if _, err := s.RequireCanEdit(ctx); err != nil {
+		return nil, err
+	}
+
+	e, err := s.services.Podcast.QueueDownload(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &EpisodeOutput{Body: toEpisodeResponse(e, nil)}, nil
+}
+
+func (s *Server) handleRecordEpisodeEvent(ctx context.Context, input *RecordEpisodeEventInput) (*EpisodeProgressOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	episode, err := s.services.Podcast.GetEpisode(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.services.Podcast.RecordEpisodeEvent(ctx, userID, input.ID, service.RecordEpisodeEventRequest{
+		EventID:         input.Body.ID,
+		StartPositionMs: input.Body.StartPositionMs,
+		EndPositionMs:   input.Body.EndPositionMs,
+		StartedAt:       time.UnixMilli(input.Body.StartedAtMs),
+		EndedAt:         time.UnixMilli(input.Body.EndedAtMs),
+		PlaybackSpeed:   input.Body.PlaybackSpeed,
+		DeviceID:        input.Body.DeviceID,
+		DeviceName:      input.Body.DeviceName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EpisodeProgressOutput{Body: *toEpisodeProgressResponse(state, episode.DurationMs)}, nil
+}
+
+// handleStreamEpisode streams a downloaded episode with range request support.
+func (s *Server) handleStreamEpisode(w http.ResponseWriter, r *http.Request) {
+	episodeID := chi.URLParam(r, "episodeId")
+
+	userID, ok := s.authenticateAudio(w, r, episodeID, "")
+	if !ok {
+		return
+	}
+
+	episode, err := s.services.Podcast.GetEpisode(r.Context(), userID, episodeID)
+	if err != nil {
+		http.Error(w, "episode not found", http.StatusNotFound)
+		return
+	}
+	if !episode.IsDownloaded() {
+		http.Error(w, "episode is not downloaded", http.StatusNotFound)
+		return
+	}
+
+	file, err := os.Open(episode.FilePath)
+	if err != nil {
+		http.Error(w, "failed to open file", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		http.Error(w, "failed to stat file", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", getMimeType(strings.TrimPrefix(filepath.Ext(episode.FilePath), ".")))
+	http.ServeContent(w, r, episode.FilePath, fileInfo.ModTime(), file)
+}
+
+func toPodcastResponse(p *domain.Podcast) PodcastResponse {
+	return PodcastResponse{
+		ID:              p.ID,
+		LibraryID:       p.LibraryID,
+		FeedURL:         p.FeedURL,
+		Title:           p.Title,
+		Author:          p.Author,
+		Description:     p.Description,
+		ImageURL:        p.ImageURL,
+		Link:            p.Link,
+		Language:        p.Language,
+		Explicit:        p.Explicit,
+		AutoDownload:    p.AutoDownload,
+		KeepEpisodes:    p.KeepEpisodes,
+		KeepDays:        p.KeepDays,
+		LastRefreshedAt: p.LastRefreshedAt,
+		LastError:       p.LastError,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+func toEpisodeResponse(e *domain.PodcastEpisode, state *domain.PlaybackState) EpisodeResponse {
+	return EpisodeResponse{
+		ID:           e.ID,
+		PodcastID:    e.PodcastID,
+		Title:        e.Title,
+		Description:  e.Description,
+		PublishedAt:  e.PublishedAt,
+		DurationMs:   e.DurationMs,
+		Season:       e.Season,
+		Number:       e.Number,
+		Status:       string(e.Status),
+		FileSize:     e.FileSize,
+		DownloadedAt: e.DownloadedAt,
+		Error:        e.Error,
+		Progress:     toEpisodeProgressResponse(state, e.DurationMs),
+	}
+}
+
+func toEpisodeProgressResponse(state *domain.PlaybackState, durationMs int64) *EpisodeProgressResponse {
+	if state == nil {
+		return nil
+	}
+	return &EpisodeProgressResponse{
+		CurrentPositionMs: state.CurrentPositionMs,
+		Progress:          state.ComputeProgress(durationMs),
+		IsFinished:        state.IsFinished,
+		FinishedAt:        state.FinishedAt,
+		LastPlayedAt:      state.LastPlayedAt,
+	}
+}
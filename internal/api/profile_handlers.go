@@ -75,6 +75,10 @@ type ProfileResponse struct {
 	AvatarValue string `json:"avatar_value,omitempty" doc:"Avatar image path for image type"`
 	AvatarColor string `json:"avatar_color" doc:"Avatar color for auto type"`
 	Tagline     string `json:"tagline,omitempty" doc:"User's tagline (max 60 chars)"`
+
+	HideFromLeaderboard    bool `json:"hide_from_leaderboard" doc:"Left off other users' leaderboards; stats hidden on the profile"`
+	HideActivity           bool `json:"hide_activity" doc:"Activity hidden from other users' feeds, book readers and the profile"`
+	HideCurrentlyListening bool `json:"hide_currently_listening" doc:"Not shown to other users as currently listening"`
 }
 
 // ProfileOutput wraps the profile response for Huma.
@@ -91,6 +95,10 @@ type UpdateProfileInput struct {
 		FirstName   *string `json:"first_name,omitempty" maxLength:"100" doc:"User's first name"`
 		LastName    *string `json:"last_name,omitempty" maxLength:"100" doc:"User's last name"`
 		NewPassword *string `json:"new_password,omitempty" minLength:"8" doc:"New password (min 8 chars)"`
+
+		HideFromLeaderboard    *bool `json:"hide_from_leaderboard,omitempty" doc:"Leave me off other users' leaderboards"`
+		HideActivity           *bool `json:"hide_activity,omitempty" doc:"Hide my activity from other users"`
+		HideCurrentlyListening *bool `json:"hide_currently_listening,omitempty" doc:"Don't show other users what I'm listening to"`
 	}
 }
 
@@ -135,6 +143,7 @@ type FullProfileResponse struct {
 	BooksFinished     int                    `json:"books_finished" doc:"Number of books finished"`
 	CurrentStreak     int                    `json:"current_streak" doc:"Current listening streak in days"`
 	LongestStreak     int                    `json:"longest_streak" doc:"Longest listening streak in days"`
+	StatsHidden       bool                   `json:"stats_hidden" doc:"Stats are zero because the user keeps them private"`
 	IsOwnProfile      bool                   `json:"is_own_profile" doc:"Whether viewing own profile"`
 	RecentBooks       []RecentBookResponse   `json:"recent_books" doc:"Recently finished books"`
 	PublicShelves     []ShelfSummaryResponse `json:"public_shelves" doc:"User's public shelves"`
@@ -172,6 +181,10 @@ func (s *Server) handleGetMyProfile(ctx context.Context, _ *AuthenticatedInput)
 			AvatarValue: profile.AvatarValue,
 			AvatarColor: color.ForUser(user.ID),
 			Tagline:     profile.Tagline,
+
+			HideFromLeaderboard:    profile.HideFromLeaderboard,
+			HideActivity:           profile.HideActivity,
+			HideCurrentlyListening: profile.HideCurrentlyListening,
 		},
 	}, nil
 }
@@ -199,6 +212,9 @@ func (s *Server) handleUpdateMyProfile(ctx context.Context, input *UpdateProfile
 	if input.Body.NewPassword != nil {
 		req.NewPassword = input.Body.NewPassword
 	}
+	req.HideFromLeaderboard = input.Body.HideFromLeaderboard
+	req.HideActivity = input.Body.HideActivity
+	req.HideCurrentlyListening = input.Body.HideCurrentlyListening
 
 	profile, err := s.services.Profile.UpdateProfile(ctx, userID, req)
 	if err != nil {
@@ -219,6 +235,10 @@ func (s *Server) handleUpdateMyProfile(ctx context.Context, input *UpdateProfile
 			AvatarValue: profile.AvatarValue,
 			AvatarColor: color.ForUser(user.ID),
 			Tagline:     profile.Tagline,
+
+			HideFromLeaderboard:    profile.HideFromLeaderboard,
+			HideActivity:           profile.HideActivity,
+			HideCurrentlyListening: profile.HideCurrentlyListening,
 		},
 	}, nil
 }
@@ -266,6 +286,10 @@ func (s *Server) handleUploadAvatar(ctx context.Context, input *UploadAvatarInpu
 			AvatarValue: profile.AvatarValue,
 			AvatarColor: color.ForUser(user.ID),
 			Tagline:     profile.Tagline,
+
+			HideFromLeaderboard:    profile.HideFromLeaderboard,
+			HideActivity:           profile.HideActivity,
+			HideCurrentlyListening: profile.HideCurrentlyListening,
 		},
 	}, nil
 }
@@ -293,6 +317,7 @@ func (s *Server) handleGetUserProfile(ctx context.Context, input *GetUserProfile
 		BooksFinished:     fullProfile.BooksFinished,
 		CurrentStreak:     fullProfile.CurrentStreak,
 		LongestStreak:     fullProfile.LongestStreak,
+		StatsHidden:       fullProfile.StatsHidden,
 		IsOwnProfile:      fullProfile.IsOwnProfile,
 		RecentBooks:       make([]RecentBookResponse, 0, len(fullProfile.RecentBooks)),
 		PublicShelves:     make([]ShelfSummaryResponse, 0, len(fullProfile.PublicShelves)),
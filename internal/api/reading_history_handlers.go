@@ -0,0 +1,266 @@
+package api
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/backup/booktracker"
+)
+
+// maxReadingHistoryUpload caps uploaded tracker exports. Goodreads exports of
+// a few thousand books run to a couple of megabytes.
+const maxReadingHistoryUpload = 16 << 20
+
+func (s *Server) registerReadingHistoryRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "exportReadingHistory",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/listening/history/export",
+		Summary:     "Export reading history",
+		Description: "Downloads the books the current user has finished as a Goodreads or StoryGraph CSV, with dates, read counts, ratings, reviews and shelves",
+		Tags:        []string{"Listening"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleExportReadingHistory)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID:  "previewReadingHistoryImport",
+		Method:       http.MethodPost,
+		Path:         "/api/v1/listening/history/import/preview",
+		Summary:      "Preview reading history import",
+		Description:  "Parses a Goodreads or StoryGraph CSV export and matches its read books to library books by ISBN, ASIN, then title and author. Nothing is imported; confirm the matches with the import endpoint.",
+		Tags:         []string{"Listening"},
+		Security:     []map[string][]string{{"bearer": {}}},
+		MaxBodyBytes: maxReadingHistoryUpload,
+	}, s.handlePreviewReadingHistoryImport)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "importReadingHistory",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/listening/history/import",
+		Summary:     "Import reading history",
+		Description: "Marks the confirmed books finished on the dates given. Imports add no listening time, and books already finished are left alone.",
+		Tags:        []string{"Listening"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleImportReadingHistory)
+}
+
+// === DTOs ===
+
+// ExportReadingHistoryInput contains parameters for exporting reading history.
+type ExportReadingHistoryInput struct {
+	Authorization string `header:"Authorization"`
+	Format        string `query:"format" default:"goodreads" enum:"goodreads,storygraph" doc:"CSV format to export"`
+}
+
+// PreviewReadingHistoryImportInput contains an uploaded tracker export.
+type PreviewReadingHistoryImportInput struct {
+	Authorization string `header:"Authorization"`
+	ContentType   string `header:"Content-Type" doc:"text/csv"`
+	RawBody       []byte
+}
+
+// ReadingHistorySuggestionResponse is a possible library book for an unmatched row.
+type ReadingHistorySuggestionResponse struct {
+	BookID string  `json:"book_id" doc:"Book ID"`
+	Title  string  `json:"title" doc:"Book title"`
+	Author string  `json:"author,omitempty" doc:"Book author"`
+	Score  float64 `json:"score" doc:"Similarity score 0-1"`
+	Reason string  `json:"reason" doc:"Why this book is suggested"`
+}
+
+// ReadingHistoryPreviewItemResponse is a CSV row with the book it matched.
+type ReadingHistoryPreviewItemResponse struct {
+	Row         int                                `json:"row" doc:"Row number in the file, not counting the header"`
+	Title       string                             `json:"title" doc:"Title in the file"`
+	Authors     []string                           `json:"authors" doc:"Authors in the file"`
+	ISBNs       []string                           `json:"isbns,omitempty" doc:"ISBNs in the file"`
+	ASIN        string                             `json:"asin,omitempty" doc:"ASIN in the file"`
+	Rating      float64                            `json:"rating,omitempty" doc:"Star rating in the file"`
+	StartedAt   *time.Time                         `json:"started_at,omitempty" doc:"When the latest read started"`
+	FinishedAt  *time.Time                         `json:"finished_at,omitempty" doc:"When the latest read finished, or the date added when the file has no read date"`
+	Status      string                             `json:"status" enum:"matched,unmatched,already_finished,not_read" doc:"What importing the row would do"`
+	BookID      string                             `json:"book_id,omitempty" doc:"Matched book ID"`
+	BookTitle   string                             `json:"book_title,omitempty" doc:"Matched book title"`
+	Confidence  string                             `json:"confidence" doc:"Match confidence (none, weak, strong, definitive)"`
+	MatchReason string                             `json:"match_reason,omitempty" doc:"How the book was matched"`
+	Suggestions []ReadingHistorySuggestionResponse `json:"suggestions,omitempty" doc:"Possible books for unmatched rows"`
+}
+
+// ReadingHistoryPreviewResponse is what importing a tracker export would do.
+type ReadingHistoryPreviewResponse struct {
+	Format          string                              `json:"format" doc:"Detected format (goodreads, storygraph)"`
+	Matched         int                                 `json:"matched" doc:"Rows ready to import"`
+	Unmatched       int                                 `json:"unmatched" doc:"Read rows with no matching book"`
+	AlreadyFinished int                                 `json:"already_finished" doc:"Rows whose book is already finished"`
+	NotRead         int                                 `json:"not_read" doc:"Rows not on the read shelf"`
+	Items           []ReadingHistoryPreviewItemResponse `json:"items" doc:"Every row in the file"`
+}
+
+// ReadingHistoryPreviewOutput wraps the preview response for Huma.
+type ReadingHistoryPreviewOutput struct {
+	Body ReadingHistoryPreviewResponse
+}
+
+// ImportReadingHistoryItemRequest is a confirmed book to mark finished.
+type ImportReadingHistoryItemRequest struct {
+	BookID     string     `json:"book_id" minLength:"1" doc:"Book ID"`
+	StartedAt  *time.Time `json:"started_at,omitempty" doc:"When the read started, defaults to finished_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" doc:"When the read finished, defaults to now"`
+}
+
+// ImportReadingHistoryInput contains the confirmed books to import.
+type ImportReadingHistoryInput struct {
+	Authorization string `header:"Authorization"`
+	Body          struct {
+		Format string                            `json:"format" enum:"goodreads,storygraph" doc:"Format of the previewed file"`
+		Items  []ImportReadingHistoryItemRequest `json:"items" minItems:"1" maxItems:"10000" doc:"Books to mark finished"`
+	}
+}
+
+// ImportReadingHistoryResponse summarizes a reading history import.
+type ImportReadingHistoryResponse struct {
+	Imported        int `json:"imported" doc:"Books marked finished"`
+	AlreadyFinished int `json:"already_finished" doc:"Books that were already finished"`
+	Skipped         int `json:"skipped" doc:"Books that don't exist or aren't accessible"`
+}
+
+// ImportReadingHistoryOutput wraps the import response for Huma.
+type ImportReadingHistoryOutput struct {
+	Body ImportReadingHistoryResponse
+}
+
+// === Handlers ===
+
+func (s *Server) handleExportReadingHistory(ctx context.Context, input *ExportReadingHistoryInput) (*huma.StreamResponse, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := booktracker.ParseFormat(input.Format)
+	if err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
+
+	entries, err := booktracker.NewExporter(s.store, s.logger).Entries(ctx, userID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to build reading history", err)
+	}
+
+	// Write before streaming so a failure can still be reported as an error
+	var buf bytes.Buffer
+	if err := booktracker.Write(&buf, format, entries); err != nil {
+		return nil, huma.Error500InternalServerError("failed to write reading history", err)
+	}
+
+	return &huma.StreamResponse{
+		Body: func(ctx huma.Context) {
+			ctx.SetHeader("Content-Type", "text/csv; charset=utf-8")
+			ctx.SetHeader("Content-Disposition", "attachment; filename=\"listenup-"+string(format)+".csv\"")
+			if _, err := buf.WriteTo(ctx.BodyWriter()); err != nil {
+				s.logger.Error("failed to stream reading history", "error", err, "user_id", userID)
+			}
+		},
+	}, nil
+}
+
+func (s *Server) handlePreviewReadingHistoryImport(ctx context.Context, input *PreviewReadingHistoryImportInput) (*ReadingHistoryPreviewOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	format, entries, err := booktracker.Parse(bytes.NewReader(input.RawBody))
+	if err != nil {
+		return nil, huma.Error400BadRequest("failed to parse reading history: " + err.Error())
+	}
+
+	importer := booktracker.NewImporter(s.store, s.sseManager, s.logger)
+	preview, err := importer.Preview(ctx, userID, format, entries)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to preview import", err)
+	}
+
+	resp := ReadingHistoryPreviewResponse{
+		Format:          string(preview.Format),
+		Matched:         preview.Matched,
+		Unmatched:       preview.Unmatched,
+		AlreadyFinished: preview.AlreadyFinished,
+		NotRead:         preview.NotRead,
+		Items:           make([]ReadingHistoryPreviewItemResponse, 0, len(preview.Items)),
+	}
+	for _, item := range preview.Items {
+		resp.Items = append(resp.Items, toReadingHistoryPreviewItem(item))
+	}
+
+	return &ReadingHistoryPreviewOutput{Body: resp}, nil
+}
+
+func (s *Server) handleImportReadingHistory(ctx context.Context, input *ImportReadingHistoryInput) (*ImportReadingHistoryOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := booktracker.ParseFormat(input.Body.Format)
+	if err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
+
+	items := make([]booktracker.ImportItem, 0, len(input.Body.Items))
+	for _, item := range input.Body.Items {
+		items = append(items, booktracker.ImportItem{
+			BookID:     item.BookID,
+			StartedAt:  item.StartedAt,
+			FinishedAt: item.FinishedAt,
+		})
+	}
+
+	importer := booktracker.NewImporter(s.store, s.sseManager, s.logger)
+	result, err := importer.Import(ctx, userID, format, items)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to import reading history", err)
+	}
+
+	return &ImportReadingHistoryOutput{Body: ImportReadingHistoryResponse{
+		Imported:        result.Imported,
+		AlreadyFinished: result.AlreadyFinished,
+		Skipped:         result.Skipped,
+	}}, nil
+}
+
+func toReadingHistoryPreviewItem(item booktracker.PreviewItem) ReadingHistoryPreviewItemResponse {
+	resp := ReadingHistoryPreviewItemResponse{
+		Row:         item.Row,
+		Title:       item.Entry.Title,
+		Authors:     item.Entry.Authors,
+		ISBNs:       item.Entry.ISBNs,
+		ASIN:        item.Entry.ASIN,
+		Rating:      item.Entry.Rating,
+		StartedAt:   item.Entry.DateStarted,
+		FinishedAt:  cmp.Or(item.Entry.DateRead, item.Entry.DateAdded),
+		Status:      string(item.Status),
+		BookID:      item.BookID,
+		BookTitle:   item.BookTitle,
+		Confidence:  item.Confidence.String(),
+		MatchReason: item.MatchReason,
+	}
+	if resp.Authors == nil {
+		resp.Authors = []string{}
+	}
+	for _, sug := range item.Suggestions {
+		resp.Suggestions = append(resp.Suggestions, ReadingHistorySuggestionResponse{
+			BookID: sug.BookID,
+			Title:  sug.Title,
+			Author: sug.Author,
+			Score:  sug.Score,
+			Reason: sug.Reason,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,403 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+func (s *Server) registerRecapRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getRecap",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/recaps/{period}",
+		Summary:     "Get listening recap",
+		Description: "Returns the current user's year-in-review recap for a year (\"2025\") or an inclusive date range (\"2025-06-01..2025-08-31\"). Recaps are cached and rebuilt when stale.",
+		Tags:        []string{"Recaps"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetRecap)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "shareRecap",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/recaps/{period}/share",
+		Summary:     "Share listening recap",
+		Description: "Publishes the recap on a public share page and returns its URL. Sharing again returns the same URL.",
+		Tags:        []string{"Recaps"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleShareRecap)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "unshareRecap",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/recaps/{period}/share",
+		Summary:     "Stop sharing listening recap",
+		Description: "Takes the recap's public share page down",
+		Tags:        []string{"Recaps"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleUnshareRecap)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getRecapSharePage",
+		Method:      http.MethodGet,
+		Path:        "/share/recap/{id}",
+		Summary:     "Recap share page",
+		Description: "Returns HTML page with Open Graph meta tags for a shared listening recap",
+		Tags:        []string{"Web"},
+	}, s.handleGetRecapSharePage)
+}
+
+// === DTOs ===
+
+// RecapRankingResponse is an entry in one of a recap's top lists.
+type RecapRankingResponse struct {
+	ID           string `json:"id" doc:"Book, contributor, series or genre ID"`
+	Name         string `json:"name" doc:"Display name"`
+	ListenTimeMs int64  `json:"listen_time_ms" doc:"Time listened in the period"`
+}
+
+// RecapSessionResponse is the longest listening session in a recap.
+type RecapSessionResponse struct {
+	BookID       string    `json:"book_id" doc:"Book ID"`
+	BookTitle    string    `json:"book_title" doc:"Book title"`
+	StartedAt    time.Time `json:"started_at" doc:"Session start"`
+	EndedAt      time.Time `json:"ended_at" doc:"Session end"`
+	ListenTimeMs int64     `json:"listen_time_ms" doc:"Time listened in the session"`
+}
+
+// RecapBookResponse is a book highlighted in a recap.
+type RecapBookResponse struct {
+	BookID     string    `json:"book_id" doc:"Book ID"`
+	Title      string    `json:"title" doc:"Book title"`
+	AuthorName string    `json:"author_name,omitempty" doc:"Author name"`
+	ListenedAt time.Time `json:"listened_at" doc:"When it was listened to"`
+}
+
+// RecapResponse contains a listening recap.
+type RecapResponse struct {
+	Period      string    `json:"period" doc:"Recap period key"`
+	Label       string    `json:"label" doc:"Human-readable period name"`
+	StartDate   string    `json:"start_date" doc:"Period start (RFC3339)"`
+	EndDate     string    `json:"end_date" doc:"Period end, exclusive (RFC3339)"`
+	GeneratedAt time.Time `json:"generated_at" doc:"When the recap was computed"`
+	ShareURL    string    `json:"share_url,omitempty" doc:"Public share page, if shared"`
+
+	TotalListenTimeMs    int64   `json:"total_listen_time_ms" doc:"Total listen time"`
+	BooksFinished        int     `json:"books_finished" doc:"Books finished in period"`
+	BooksListened        int     `json:"books_listened" doc:"Distinct books listened to"`
+	ListeningDays        int     `json:"listening_days" doc:"Days with listening"`
+	LongestStreakDays    int     `json:"longest_streak_days" doc:"Longest streak within the period"`
+	AveragePlaybackSpeed float64 `json:"average_playback_speed" doc:"Playback speed weighted by time listened"`
+
+	TopBooks     []RecapRankingResponse `json:"top_books" doc:"Most listened books"`
+	TopAuthors   []RecapRankingResponse `json:"top_authors" doc:"Most listened authors"`
+	TopNarrators []RecapRankingResponse `json:"top_narrators" doc:"Most listened narrators"`
+	TopGenres    []RecapRankingResponse `json:"top_genres" doc:"Most listened genres"`
+	TopSeries    []RecapRankingResponse `json:"top_series" doc:"Most listened series"`
+
+	LongestSession *RecapSessionResponse   `json:"longest_session,omitempty" doc:"Longest continuous listening session"`
+	BusiestDay     *DailyListeningResponse `json:"busiest_day,omitempty" doc:"Day with the most listening"`
+	HourlyHeatmap  [][]int64               `json:"hourly_heatmap" doc:"Listen time by weekday (7 rows, Sunday first) and hour of day (24 columns)"`
+
+	FirstBook *RecapBookResponse `json:"first_book,omitempty" doc:"First book listened to in the period"`
+	LastBook  *RecapBookResponse `json:"last_book,omitempty" doc:"Most recent book listened to in the period"`
+}
+
+// RecapOutput wraps the recap response for Huma.
+type RecapOutput struct {
+	Body RecapResponse
+}
+
+// GetRecapInput contains parameters for getting a recap.
+type GetRecapInput struct {
+	Authorization string `header:"Authorization"`
+	Period        string `path:"period" doc:"Year (2025) or inclusive date range (2025-06-01..2025-08-31)"`
+	Refresh       bool   `query:"refresh" doc:"Rebuild the recap instead of using the cache"`
+}
+
+// ShareRecapInput contains parameters for sharing or unsharing a recap.
+type ShareRecapInput struct {
+	Authorization string `header:"Authorization"`
+	Period        string `path:"period" doc:"Year (2025) or inclusive date range (2025-06-01..2025-08-31)"`
+}
+
+// GetRecapSharePageInput contains parameters for the recap share page.
+type GetRecapSharePageInput struct {
+	ID string `path:"id" doc:"Recap share ID"`
+}
+
+// === Handlers ===
+
+func (s *Server) handleGetRecap(ctx context.Context, input *GetRecapInput) (*RecapOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recap, err := s.services.Recap.GetRecap(ctx, userID, input.Period, input.Refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecapOutput{Body: s.toRecapResponse(ctx, recap)}, nil
+}
+
+func (s *Server) handleShareRecap(ctx context.Context, input *ShareRecapInput) (*RecapOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recap, err := s.services.Recap.ShareRecap(ctx, userID, input.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecapOutput{Body: s.toRecapResponse(ctx, recap)}, nil
+}
+
+func (s *Server) handleUnshareRecap(ctx context.Context, input *ShareRecapInput) (*MessageOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Recap.UnshareRecap(ctx, userID, input.Period); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Recap is no longer shared"}}, nil
+}
+
+func (s *Server) handleGetRecapSharePage(ctx context.Context, input *GetRecapSharePageInput) (*HTMLOutput, error) {
+	shared, err := s.services.Recap.GetSharedRecap(ctx, input.ID)
+	if err != nil {
+		// Graceful degradation: render a user-friendly HTML error page instead of an API error.
+		return &HTMLOutput{ //nolint:nilerr // graceful HTML error page for share links
+			ContentType: "text/html; charset=utf-8",
+			Body:        renderShareErrorPage("Recap Not Found", "This recap could not be found or is no longer shared."),
+		}, nil
+	}
+
+	baseURL, err := s.shareBaseURL(ctx)
+	if err != nil {
+		// Graceful degradation: render a user-friendly HTML error page.
+		return &HTMLOutput{ //nolint:nilerr // graceful HTML error page for share links
+			ContentType: "text/html; charset=utf-8",
+			Body:        renderShareErrorPage("Error", "Could not load server config."),
+		}, nil
+	}
+
+	recap := shared.Recap
+	title := html.EscapeString(fmt.Sprintf("%s's %s in Listening", shared.DisplayName, shared.Period.Label()))
+	hours := formatRecapHours(recap.TotalListenTimeMs)
+
+	highlights := []string{hours + " listened", pluralize(recap.BooksFinished, "book", "books") + " finished"}
+	if len(recap.TopGenres) > 0 {
+		highlights = append(highlights, "top genre "+recap.TopGenres[0].Name)
+	}
+	ogDescription := html.EscapeString(strings.Join(highlights, " · "))
+
+	var ogImage string
+	if len(recap.TopBooks) > 0 {
+		ogImage = fmt.Sprintf("%s/api/v1/books/%s/cover", baseURL, recap.TopBooks[0].ID)
+	}
+
+	var rows strings.Builder
+	recapRow := func(label, value string) {
+		fmt.Fprintf(&rows, `<div class="row"><span>%s</span><strong>%s</strong></div>`,
+			html.EscapeString(label), html.EscapeString(value))
+	}
+	recapRow("Time listened", hours)
+	recapRow("Books finished", fmt.Sprint(recap.BooksFinished))
+	recapRow("Days listening", fmt.Sprint(recap.ListeningDays))
+	recapRow("Longest streak", pluralize(recap.LongestStreakDays, "day", "days"))
+	if len(recap.TopBooks) > 0 {
+		recapRow("Top book", recap.TopBooks[0].Name)
+	}
+	if len(recap.TopAuthors) > 0 {
+		recapRow("Top author", recap.TopAuthors[0].Name)
+	}
+	if len(recap.TopNarrators) > 0 {
+		recapRow("Top narrator", recap.TopNarrators[0].Name)
+	}
+	if len(recap.TopGenres) > 0 {
+		recapRow("Top genre", recap.TopGenres[0].Name)
+	}
+	if recap.BusiestDay != nil {
+		recapRow("Busiest day", recap.BusiestDay.Date.Format("Jan 2"))
+	}
+	if recap.FirstBook != nil {
+		recapRow("First book", recap.FirstBook.Title)
+	}
+	if recap.LastBook != nil {
+		recapRow("Last book", recap.LastBook.Title)
+	}
+
+	var imageTags, coverImg string
+	if ogImage != "" {
+		imageTags = fmt.Sprintf(`
+    <meta property="og:image" content="%s">
+    <meta name="twitter:image" content="%s">`, ogImage, ogImage)
+		coverImg = fmt.Sprintf(`<img class="cover" src="%s" alt="Cover art">`, ogImage)
+	}
+
+	pageHTML := fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>%s - ListenUp</title>
+    <meta property="og:type" content="website">
+    <meta property="og:title" content="%s">
+    <meta property="og:description" content="%s">%s
+    <meta name="twitter:card" content="summary_large_image">
+    <meta name="twitter:title" content="%s">
+    <meta name="twitter:description" content="%s">
+    <style>
+        *{margin:0;padding:0;box-sizing:border-box}
+        body{font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,sans-serif;background:#121212;color:#e0e0e0;min-height:100vh;display:flex;align-items:center;justify-content:center}
+        .card{max-width:400px;width:90%%;text-align:center;padding:32px 24px}
+        .cover{width:160px;height:160px;object-fit:cover;border-radius:12px;box-shadow:0 8px 32px rgba(0,0,0,.5);margin-bottom:24px}
+        h1{font-size:1.5rem;font-weight:700;color:#fff;margin-bottom:24px}
+        .row{display:flex;justify-content:space-between;gap:16px;padding:10px 0;border-bottom:1px solid #2a2a2a;font-size:.95rem;text-align:left}
+        .row span{color:#909090}
+        .row strong{color:#fff;font-weight:600;text-align:right}
+        .store{display:block;margin-top:32px;font-size:.8rem;color:#808080}
+        .store a{color:#bb86fc;text-decoration:none}
+    </style>
+</head>
+<body>
+    <div class="card">
+        %s
+        <h1>%s</h1>
+        %s
+        <p class="store">Listening with <a href="%s">ListenUp</a></p>
+    </div>
+</body>
+</html>`,
+		title, title, ogDescription, imageTags,
+		title, ogDescription,
+		coverImg, title, rows.String(), baseURL,
+	)
+
+	return &HTMLOutput{
+		ContentType: "text/html; charset=utf-8",
+		Body:        pageHTML,
+	}, nil
+}
+
+// shareBaseURL returns the server's public base URL for share links.
+func (s *Server) shareBaseURL(ctx context.Context) (string, error) {
+	instance, err := s.services.Instance.GetInstance(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL := instance.RemoteURL
+	if baseURL == "" {
+		baseURL = instance.LocalURL
+	}
+	return strings.TrimRight(baseURL, "/"), nil
+}
+
+func (s *Server) toRecapResponse(ctx context.Context, r *domain.ListeningRecap) RecapResponse {
+	resp := RecapResponse{
+		Period:               r.Period,
+		Label:                r.Period,
+		StartDate:            r.StartDate.Format(time.RFC3339),
+		EndDate:              r.EndDate.Format(time.RFC3339),
+		GeneratedAt:          r.GeneratedAt,
+		TotalListenTimeMs:    r.TotalListenTimeMs,
+		BooksFinished:        r.BooksFinished,
+		BooksListened:        r.BooksListened,
+		ListeningDays:        r.ListeningDays,
+		LongestStreakDays:    r.LongestStreakDays,
+		AveragePlaybackSpeed: r.AveragePlaybackSpeed,
+		TopBooks:             toRecapRankingResponses(r.TopBooks),
+		TopAuthors:           toRecapRankingResponses(r.TopAuthors),
+		TopNarrators:         toRecapRankingResponses(r.TopNarrators),
+		TopGenres:            toRecapRankingResponses(r.TopGenres),
+		TopSeries:            toRecapRankingResponses(r.TopSeries),
+		HourlyHeatmap:        make([][]int64, len(r.HourlyHeatmap)),
+	}
+
+	if period, err := domain.ParseRecapPeriod(r.Period, r.StartDate.Location()); err == nil {
+		resp.Label = period.Label()
+	}
+
+	if r.ShareID != "" {
+		// A missing base URL still yields a usable path for the client to resolve
+		baseURL, _ := s.shareBaseURL(ctx)
+		resp.ShareURL = baseURL + "/share/recap/" + r.ShareID
+	}
+
+	for day := range r.HourlyHeatmap {
+		resp.HourlyHeatmap[day] = r.HourlyHeatmap[day][:]
+	}
+
+	if r.LongestSession != nil {
+		resp.LongestSession = &RecapSessionResponse{
+			BookID:       r.LongestSession.BookID,
+			BookTitle:    r.LongestSession.BookTitle,
+			StartedAt:    r.LongestSession.StartedAt,
+			EndedAt:      r.LongestSession.EndedAt,
+			ListenTimeMs: r.LongestSession.ListenTimeMs,
+		}
+	}
+	if r.BusiestDay != nil {
+		resp.BusiestDay = &DailyListeningResponse{
+			Date:          r.BusiestDay.Date.Format("2006-01-02"),
+			ListenTimeMs:  r.BusiestDay.ListenTimeMs,
+			BooksListened: r.BusiestDay.BooksListened,
+		}
+	}
+	resp.FirstBook = toRecapBookResponse(r.FirstBook)
+	resp.LastBook = toRecapBookResponse(r.LastBook)
+
+	return resp
+}
+
+func toRecapRankingResponses(rankings []domain.RecapRanking) []RecapRankingResponse {
+	resp := make([]RecapRankingResponse, len(rankings))
+	for i, r := range rankings {
+		resp[i] = RecapRankingResponse{ID: r.ID, Name: r.Name, ListenTimeMs: r.ListenTimeMs}
+	}
+	return resp
+}
+
+func toRecapBookResponse(b *domain.RecapBook) *RecapBookResponse {
+	if b == nil {
+		return nil
+	}
+	return &RecapBookResponse{
+		BookID:     b.BookID,
+		Title:      b.Title,
+		AuthorName: b.AuthorName,
+		ListenedAt: b.ListenedAt,
+	}
+}
+
+// formatRecapHours formats a listening time as whole hours, or minutes when
+// under an hour.
+func formatRecapHours(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	if d < time.Hour {
+		return pluralize(int(d/time.Minute), "minute", "minutes")
+	}
+	return pluralize(int(d/time.Hour), "hour", "hours")
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+func (s *Server) registerReviewRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listBookReviews",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/books/{id}/reviews",
+		Summary:     "List book reviews",
+		Description: "Returns reviews of a book, most recently updated first, with the book's average rating",
+		Tags:        []string{"Reviews"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListBookReviews)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getMyReview",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/books/{id}/review",
+		Summary:     "Get my review",
+		Description: "Returns the current user's rating and review of a book",
+		Tags:        []string{"Reviews"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetMyReview)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "setMyReview",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/books/{id}/review",
+		Summary:     "Rate or review book",
+		Description: "Creates or replaces the current user's rating and review of a book. Ratings go from 0.5 to 5 in half-star steps.",
+		Tags:        []string{"Reviews"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleSetMyReview)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "deleteMyReview",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/books/{id}/review",
+		Summary:     "Delete my review",
+		Description: "Removes the current user's rating and review of a book",
+		Tags:        []string{"Reviews"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleDeleteMyReview)
+}
+
+// === DTOs ===
+
+// ReviewResponse contains review data in API responses.
+type ReviewResponse struct {
+	ID               string    `json:"id" doc:"Review ID"`
+	BookID           string    `json:"book_id" doc:"Book ID"`
+	UserID           string    `json:"user_id" doc:"Reviewer user ID"`
+	Rating           float64   `json:"rating" doc:"Star rating, 0.5 to 5"`
+	Text             string    `json:"text,omitempty" doc:"Written review"`
+	ContainsSpoilers bool      `json:"contains_spoilers" doc:"Whether the text contains spoilers"`
+	CreatedAt        time.Time `json:"created_at" doc:"Created time"`
+	UpdatedAt        time.Time `json:"updated_at" doc:"Updated time"`
+}
+
+// ReviewerResponse is the author of a review, for avatar display.
+type ReviewerResponse struct {
+	UserID      string `json:"user_id" doc:"User ID"`
+	DisplayName string `json:"display_name" doc:"User display name"`
+	AvatarColor string `json:"avatar_color" doc:"Generated avatar color (hex)"`
+	AvatarType  string `json:"avatar_type" doc:"Avatar type (auto or image)"`
+	AvatarValue string `json:"avatar_value,omitempty" doc:"Avatar image path (for image type)"`
+}
+
+// BookReviewResponse is a review in a book's review list.
+type BookReviewResponse struct {
+	ReviewResponse
+	Reviewer ReviewerResponse `json:"reviewer" doc:"Review author"`
+}
+
+// ListBookReviewsInput contains parameters for listing a book's reviews.
+type ListBookReviewsInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Limit         int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Max reviews to return"`
+	Offset        int    `query:"offset" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListBookReviewsResponse contains a page of reviews and the book's rating.
+type ListBookReviewsResponse struct {
+	Reviews       []BookReviewResponse `json:"reviews" doc:"Reviews, most recently updated first"`
+	AverageRating float64              `json:"average_rating" doc:"Average rating across all reviews (0 when unrated)"`
+	RatingCount   int                  `json:"rating_count" doc:"Total number of reviews"`
+}
+
+// ListBookReviewsOutput wraps the list book reviews response for Huma.
+type ListBookReviewsOutput struct {
+	Body ListBookReviewsResponse
+}
+
+// MyReviewInput contains parameters for acting on the current user's review.
+type MyReviewInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+}
+
+// SetReviewRequest is the request body for rating or reviewing a book.
+type SetReviewRequest struct {
+	Rating           float64 `json:"rating" minimum:"0.5" maximum:"5" multipleOf:"0.5" doc:"Star rating, 0.5 to 5 in half-star steps"`
+	Text             string  `json:"text,omitempty" maxLength:"10000" doc:"Written review"`
+	ContainsSpoilers bool    `json:"contains_spoilers,omitempty" doc:"Hide the text behind a spoiler warning"`
+}
+
+// SetReviewInput wraps the set review request for Huma.
+type SetReviewInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Body          SetReviewRequest
+}
+
+// ReviewOutput wraps a single review response for Huma.
+type ReviewOutput struct {
+	Body ReviewResponse
+}
+
+// === Handlers ===
+
+func (s *Server) handleListBookReviews(ctx context.Context, input *ListBookReviewsInput) (*ListBookReviewsOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, summary, err := s.services.Review.ListBookReviews(ctx, userID, input.ID, input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]BookReviewResponse, len(entries))
+	for i, e := range entries {
+		reviews[i] = BookReviewResponse{
+			ReviewResponse: toReviewResponse(e.Review),
+			Reviewer: ReviewerResponse{
+				UserID:      e.Reviewer.UserID,
+				DisplayName: e.Reviewer.DisplayName,
+				AvatarColor: e.Reviewer.AvatarColor,
+				AvatarType:  e.Reviewer.AvatarType,
+				AvatarValue: e.Reviewer.AvatarValue,
+			},
+		}
+	}
+
+	return &ListBookReviewsOutput{
+		Body: ListBookReviewsResponse{
+			Reviews:       reviews,
+			AverageRating: summary.Average,
+			RatingCount:   summary.Count,
+		},
+	}, nil
+}
+
+func (s *Server) handleGetMyReview(ctx context.Context, input *MyReviewInput) (*ReviewOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.services.Review.GetMyReview(ctx, userID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReviewOutput{Body: toReviewResponse(review)}, nil
+}
+
+func (s *Server) handleSetMyReview(ctx context.Context, input *SetReviewInput) (*ReviewOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	review, _, err := s.services.Review.SetReview(ctx, userID, input.ID, service.SetReviewRequest{
+		Rating:           input.Body.Rating,
+		Text:             input.Body.Text,
+		ContainsSpoilers: input.Body.ContainsSpoilers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReviewOutput{Body: toReviewResponse(review)}, nil
+}
+
+func (s *Server) handleDeleteMyReview(ctx context.Context, input *MyReviewInput) (*MessageOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Review.DeleteReview(ctx, userID, input.ID); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Review deleted"}}, nil
+}
+
+func toReviewResponse(r *domain.BookReview) ReviewResponse {
+	return ReviewResponse{
+		ID:               r.ID,
+		BookID:           r.BookID,
+		UserID:           r.UserID,
+		Rating:           r.Rating,
+		Text:             r.Text,
+		ContainsSpoilers: r.ContainsSpoilers,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
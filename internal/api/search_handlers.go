@@ -15,7 +15,7 @@ func (s *Server) registerSearchRoutes() {
 		Method:      http.MethodGet,
 		Path:        "/api/v1/search",
 		Summary:     "Search library",
-		Description: "Federated search across books, contributors, and series",
+		Description: "Federated search across books, contributors, series, and podcasts",
 		Tags:        []string{"Search"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleSearch)
@@ -39,31 +39,37 @@ func (s *Server) registerSearchRoutes() {
 
 // SearchInput contains parameters for searching the library.
 type SearchInput struct {
-	Authorization string `header:"Authorization"`
-	Query         string `query:"q" validate:"required,min=1,max=200" doc:"Search query"`
-	Types         string `query:"types" validate:"omitempty,max=100" doc:"Comma-separated types to search (book,contributor,series). Omit for all."`
-	Limit         int    `query:"limit" validate:"omitempty,gte=1,lte=100" doc:"Max results per type (default 10)"`
-	Offset        int    `query:"offset" validate:"omitempty,gte=0" doc:"Pagination offset (default 0)"`
-	GenreSlugs    string `query:"genres" validate:"omitempty,max=200" doc:"Comma-separated genre slugs to filter by"`
-	GenrePath     string `query:"genre_path" validate:"omitempty,max=100" doc:"Genre path prefix for hierarchical filtering (e.g. /fiction/fantasy)"`
-	Facets        bool   `query:"facets" doc:"Include facets in response"`
+	Authorization string  `header:"Authorization"`
+	Query         string  `query:"q" validate:"required,min=1,max=200" doc:"Search query"`
+	Types         string  `query:"types" validate:"omitempty,max=100" doc:"Comma-separated types to search (book,contributor,series,podcast). Omit for all."`
+	Limit         int     `query:"limit" validate:"omitempty,gte=1,lte=100" doc:"Max results per type (default 10)"`
+	Offset        int     `query:"offset" validate:"omitempty,gte=0" doc:"Pagination offset (default 0)"`
+	GenreSlugs    string  `query:"genres" validate:"omitempty,max=200" doc:"Comma-separated genre slugs to filter by"`
+	GenrePath     string  `query:"genre_path" validate:"omitempty,max=100" doc:"Genre path prefix for hierarchical filtering (e.g. /fiction/fantasy)"`
+	MinRating     float64 `query:"min_rating" validate:"omitempty,gte=0,lte=5" doc:"Only books with at least this community rating (unrated books are excluded)"`
+	LibraryID     string  `query:"library_id" doc:"Only return books in this library"`
+	Sort          string  `query:"sort" enum:"relevance,title,author,recent,duration,rating" doc:"Sort order (default relevance)"`
+	Order         string  `query:"order" enum:"asc,desc" doc:"Sort direction (default desc)"`
+	Facets        bool    `query:"facets" doc:"Include facets in response"`
 }
 
-// SearchHitResult contains a single search result (book, contributor, or series).
+// SearchHitResult contains a single search result (book, contributor, series, or podcast).
 type SearchHitResult struct {
-	ID         string            `json:"id" doc:"Entity ID"`
-	Type       string            `json:"type" doc:"Type: book, contributor, or series"`
-	Score      float64           `json:"score" doc:"Search relevance score"`
-	Name       string            `json:"name" doc:"Display name (title for books)"`
-	Subtitle   string            `json:"subtitle,omitempty" doc:"Subtitle (for books)"`
-	Author     string            `json:"author,omitempty" doc:"Author name (for books)"`
-	Narrator   string            `json:"narrator,omitempty" doc:"Narrator name (for books)"`
-	SeriesName string            `json:"series_name,omitempty" doc:"Series name (for books)"`
-	Duration   int64             `json:"duration,omitempty" doc:"Duration in ms (for books)"`
-	BookCount  int               `json:"book_count,omitempty" doc:"Number of books (for contributors/series)"`
-	GenreSlugs []string          `json:"genre_slugs,omitempty" doc:"Genre slugs (for books)"`
-	Tags       []string          `json:"tags,omitempty" doc:"Tag slugs (for books)"`
-	Highlights map[string]string `json:"highlights,omitempty" doc:"Highlighted matches"`
+	ID          string            `json:"id" doc:"Entity ID"`
+	Type        string            `json:"type" doc:"Type: book, contributor, series, or podcast"`
+	Score       float64           `json:"score" doc:"Search relevance score"`
+	Name        string            `json:"name" doc:"Display name (title for books)"`
+	Subtitle    string            `json:"subtitle,omitempty" doc:"Subtitle (for books)"`
+	Author      string            `json:"author,omitempty" doc:"Author name (for books)"`
+	Narrator    string            `json:"narrator,omitempty" doc:"Narrator name (for books)"`
+	SeriesName  string            `json:"series_name,omitempty" doc:"Series name (for books)"`
+	Duration    int64             `json:"duration,omitempty" doc:"Duration in ms (for books)"`
+	BookCount   int               `json:"book_count,omitempty" doc:"Number of books (for contributors/series)"`
+	Rating      float64           `json:"rating,omitempty" doc:"Average community rating (for books)"`
+	RatingCount int               `json:"rating_count,omitempty" doc:"Number of reviews (for books)"`
+	GenreSlugs  []string          `json:"genre_slugs,omitempty" doc:"Genre slugs (for books)"`
+	Tags        []string          `json:"tags,omitempty" doc:"Tag slugs (for books)"`
+	Highlights  map[string]string `json:"highlights,omitempty" doc:"Highlighted matches"`
 }
 
 // SearchFacets contains facet counts for filtering.
@@ -115,8 +121,12 @@ func (s *Server) handleSearch(ctx context.Context, input *SearchInput) (*SearchO
 
 	// Build search params
 	params := search.SearchParams{
-		Query: input.Query,
-		Limit: limit,
+		Query:     input.Query,
+		Limit:     limit,
+		MinRating: input.MinRating,
+		LibraryID: input.LibraryID,
+		SortBy:    input.Sort,
+		SortOrder: input.Order,
 	}
 
 	// Parse types - comma-separated string to slice
@@ -130,6 +140,8 @@ func (s *Server) handleSearch(ctx context.Context, input *SearchInput) (*SearchO
 				params.Types = append(params.Types, string(search.DocTypeContributor))
 			case "series":
 				params.Types = append(params.Types, string(search.DocTypeSeries))
+			case "podcast":
+				params.Types = append(params.Types, string(search.DocTypePodcast))
 			}
 		}
 	}
@@ -173,25 +185,32 @@ func (s *Server) handleSearch(ctx context.Context, input *SearchInput) (*SearchO
 	for i := range result.Hits {
 		hit := &result.Hits[i]
 
-		// For book results, verify user has access
-		if hit.Type == search.DocTypeBook {
+		// For book and podcast results, verify user has access
+		switch hit.Type {
+		case search.DocTypeBook:
 			if canAccess, err := s.services.Search.CanUserAccessBook(ctx, userID, hit.ID); err != nil || !canAccess {
 				continue
 			}
+		case search.DocTypePodcast:
+			if canAccess, err := s.services.Search.CanUserAccessPodcast(ctx, userID, hit.ID); err != nil || !canAccess {
+				continue
+			}
 		}
 
 		respHit := SearchHitResult{
-			ID:         hit.ID,
-			Type:       string(hit.Type),
-			Score:      hit.Score,
-			Name:       hit.Name,
-			Author:     hit.Author,
-			Narrator:   hit.Narrator,
-			SeriesName: hit.SeriesName,
-			Duration:   hit.Duration,
-			BookCount:  hit.BookCount,
-			GenreSlugs: hit.GenreSlugs,
-			Tags:       hit.Tags,
+			ID:          hit.ID,
+			Type:        string(hit.Type),
+			Score:       hit.Score,
+			Name:        hit.Name,
+			Author:      hit.Author,
+			Narrator:    hit.Narrator,
+			SeriesName:  hit.SeriesName,
+			Duration:    hit.Duration,
+			BookCount:   hit.BookCount,
+			Rating:      hit.Rating,
+			RatingCount: hit.RatingCount,
+			GenreSlugs:  hit.GenreSlugs,
+			Tags:        hit.Tags,
 		}
 		resp.Hits = append(resp.Hits, respHit)
 	}
@@ -47,6 +47,7 @@ type Server struct {
 	fileWatcher lastTicker
 	sessionJob  lastTicker
 	eventLogJob lastTicker
+	backupJob   backupReporter
 }
 
 // SetOnInstanceUpdated registers a callback invoked when instance settings change.
@@ -56,11 +57,12 @@ func (s *Server) SetOnInstanceUpdated(fn func(*domain.Instance)) {
 }
 
 // SetWorkers wires the background worker handles used by /health component checks.
-func (s *Server) SetWorkers(indexer *asyncindexer.Indexer, fileWatcher, sessionJob, eventLogJob lastTicker) {
+func (s *Server) SetWorkers(indexer *asyncindexer.Indexer, fileWatcher, sessionJob, eventLogJob lastTicker, backupJob backupReporter) {
 	s.indexer = indexer
 	s.fileWatcher = fileWatcher
 	s.sessionJob = sessionJob
 	s.eventLogJob = eventLogJob
+	s.backupJob = backupJob
 }
 
 // NewServer creates a new HTTP server with all routes configured.
@@ -203,6 +205,9 @@ func (s *Server) registerRoutes() {
 	s.registerAuthRoutes()
 	s.registerInviteRoutes()
 	s.registerUserRoutes()
+	s.registerSessionRoutes()
+	s.registerTwoFactorRoutes()
+	s.registerOIDCRoutes()
 	s.registerAdminRoutes()
 	s.registerAdminCollectionRoutes()
 	s.registerAdminInboxRoutes()
@@ -211,8 +216,10 @@ func (s *Server) registerRoutes() {
 	s.registerAdminABSRoutes()
 	s.registerAdminABSImportRoutes()
 	s.registerAdminRepairRoutes()
+	s.registerAdminTrashRoutes()
 	s.registerBookRoutes()
 	s.registerMetadataRoutes()
+	s.registerMetadataProviderRoutes()
 	s.registerSeriesRoutes()
 	s.registerContributorRoutes()
 	s.registerCollectionRoutes()
@@ -221,9 +228,17 @@ func (s *Server) registerRoutes() {
 	s.registerLibraryRoutes()
 	s.registerSyncRoutes()
 	s.registerListeningRoutes()
+	s.registerBookmarkRoutes()
+	s.registerGoalRoutes()
+	s.registerRecapRoutes()
+	s.registerReadingHistoryRoutes()
+	s.registerReviewRoutes()
+	s.registerPodcastRoutes()
+	s.registerChapterRoutes()
 	s.registerSocialRoutes()
 	s.registerProfileRoutes()
 	s.registerPlaybackRoutes()
+	s.registerStreamURLRoutes()
 	s.registerTranscodeRoutes()
 	s.registerSettingsRoutes()
 	s.registerGenreRoutes()
@@ -231,6 +246,8 @@ func (s *Server) registerRoutes() {
 	s.registerSearchRoutes()
 	s.registerCoverRoutes()
 	s.registerAudioRoutes()
+	s.registerEbookRoutes()
+	s.registerFeedRoutes()
 	s.registerBookShareRoutes()
 	s.registerWebRoutes()
 	s.registerFilesystemRoutes()
@@ -78,23 +78,30 @@ func setupTestServer(t *testing.T) *testServer {
 	enricher := dto.NewEnricher(st)
 
 	// Create services
-	sessionService := service.NewSessionService(st, tokenService, logger)
+	sessionService := service.NewSessionService(st, tokenService, sseManager, logger)
 	instanceService := service.NewInstanceService(st, logger, cfg)
-	authService := service.NewAuthService(st, tokenService, sessionService, instanceService, logger)
-	syncService := service.NewSyncService(st, logger)
+	twoFactorService := service.NewTwoFactorService(st, logger)
+	authService := service.NewAuthService(st, tokenService, sessionService, instanceService, twoFactorService, logger)
+	syncService := service.NewSyncService(st, enricher, logger)
 	shelfService := service.NewShelfService(st, sseManager, logger)
 	inboxService := service.NewInboxService(st, enricher, sseManager, logger)
 	settingsService := service.NewSettingsService(st, inboxService, logger)
 	absImportService := service.NewABSImportService(st, logger)
+	passwordService := service.NewPasswordService(st, sessionService, nil, logger, "http://localhost:8080")
+	oidcService := service.NewOIDCService(st, nil, cfg.OIDC, authService, instanceService, logger)
 
 	services := &Services{
 		Instance:  instanceService,
 		Auth:      authService,
+		TwoFactor: twoFactorService,
 		Sync:      syncService,
 		Shelf:     shelfService,
 		Settings:  settingsService,
 		Inbox:     inboxService,
 		ABSImport: absImportService,
+		Password:  passwordService,
+		Session:   sessionService,
+		OIDC:      oidcService,
 	}
 
 	// Create chi router
@@ -135,6 +142,10 @@ func setupTestServer(t *testing.T) *testServer {
 	s.registerHealthRoutes()
 	s.registerInstanceRoutes()
 	s.registerAuthRoutes()
+	s.registerUserRoutes()
+	s.registerSessionRoutes()
+	s.registerTwoFactorRoutes()
+	s.registerOIDCRoutes()
 	s.registerSyncRoutes()
 
 	// Initialize instance (required before setup can work)
@@ -20,6 +20,10 @@ type Services struct {
 	Tag            *service.TagService
 	Search         *service.SearchService
 	Invite         *service.InviteService
+	Password       *service.PasswordService  // Password change and reset codes
+	Session        *service.SessionService   // Signed-in devices
+	TwoFactor      *service.TwoFactorService // TOTP enrollment and recovery codes
+	OIDC           *service.OIDCService      // Single sign-on
 	Admin          *service.AdminService
 	Transcode      *service.TranscodeService
 	Metadata       *service.MetadataService       // Audible metadata fetching
@@ -36,6 +40,14 @@ type Services struct {
 	Contributor    *service.ContributorService    // Contributor CRUD + indexing
 	Series         *service.SeriesService         // Series CRUD + indexing
 	ABSImport      *service.ABSImportService      // Audiobookshelf import workflow
+	Bookmark       *service.BookmarkService       // Per-user bookmarks and clips
+	Goal           *service.GoalService           // Listening goals and progress
+	Recap          *service.RecapService          // Year-in-review listening recaps
+	Trash          *service.TrashService          // Admin book deletion and trash
+	Review         *service.ReviewService         // Book ratings and reviews
+	Podcast        *service.PodcastService        // Podcast subscriptions and episodes
+	Feed           *service.FeedService           // OPDS catalog, podcast feeds and feed tokens
+	StreamURL      *service.StreamURLService      // Signed audio stream URLs
 }
 
 // StorageServices groups file storage handlers used by the API server.
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+func (s *Server) registerSessionRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listMySessions",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/users/me/sessions",
+		Summary:     "List my sessions",
+		Description: "Returns the devices the authenticated user is signed in on",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListMySessions)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "revokeMySession",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/users/me/sessions/{session_id}",
+		Summary:     "Revoke a session",
+		Description: "Signs one of the authenticated user's devices out. The device receives a session.revoked event.",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRevokeMySession)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "revokeOtherSessions",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/users/me/sessions/revoke-others",
+		Summary:     "Sign out everywhere else",
+		Description: "Signs out every device except the caller's current session",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRevokeOtherSessions)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "listUserSessions",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/users/{id}/sessions",
+		Summary:     "List user sessions",
+		Description: "Returns the devices a user is signed in on",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleListUserSessions)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "revokeUserSession",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/admin/users/{id}/sessions/{session_id}",
+		Summary:     "Revoke user session",
+		Description: "Signs one of a user's devices out",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRevokeUserSession)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "revokeAllUserSessions",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/admin/users/{id}/sessions",
+		Summary:     "Revoke all user sessions",
+		Description: "Signs a user out of every device",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRevokeAllUserSessions)
+}
+
+// === DTOs ===
+
+// SessionInfoResponse describes a signed-in device in API responses.
+type SessionInfoResponse struct {
+	ID              string    `json:"id" doc:"Session ID"`
+	CreatedAt       time.Time `json:"created_at" doc:"When the device signed in"`
+	LastSeenAt      time.Time `json:"last_seen_at" doc:"Last token refresh"`
+	ExpiresAt       time.Time `json:"expires_at" doc:"When the session expires without a refresh"`
+	IPAddress       string    `json:"ip_address,omitempty" doc:"IP address of the last refresh"`
+	DeviceType      string    `json:"device_type,omitempty" doc:"Device type (mobile, tablet, desktop, web, tv)"`
+	Platform        string    `json:"platform,omitempty" doc:"Platform (iOS, Android, Windows, macOS, Linux, Web)"`
+	PlatformVersion string    `json:"platform_version,omitempty" doc:"Platform version"`
+	ClientName      string    `json:"client_name,omitempty" doc:"Client name"`
+	ClientVersion   string    `json:"client_version,omitempty" doc:"Client version"`
+	DeviceName      string    `json:"device_name,omitempty" doc:"Human-readable device name"`
+	DeviceModel     string    `json:"device_model,omitempty" doc:"Device model"`
+	BrowserName     string    `json:"browser_name,omitempty" doc:"Browser name (web clients)"`
+	BrowserVersion  string    `json:"browser_version,omitempty" doc:"Browser version (web clients)"`
+}
+
+// ListSessionsInput is the Huma input for listing one's own sessions.
+type ListSessionsInput struct {
+	Authorization string `header:"Authorization"`
+}
+
+// ListSessionsResponse contains sessions in API responses.
+type ListSessionsResponse struct {
+	Sessions []SessionInfoResponse `json:"sessions" doc:"Sessions, oldest first"`
+}
+
+// ListSessionsOutput wraps the list sessions response for Huma.
+type ListSessionsOutput struct {
+	Body ListSessionsResponse
+}
+
+// RevokeSessionInput is the Huma input for revoking one's own session.
+type RevokeSessionInput struct {
+	Authorization string `header:"Authorization"`
+	SessionID     string `path:"session_id" doc:"Session ID"`
+}
+
+// RevokeOtherSessionsRequest is the request body for signing out other devices.
+type RevokeOtherSessionsRequest struct {
+	CurrentSessionID string `json:"current_session_id" validate:"required" doc:"The caller's session ID, as returned at login; this session stays signed in"`
+}
+
+// RevokeOtherSessionsInput wraps the revoke other sessions request for Huma.
+type RevokeOtherSessionsInput struct {
+	Authorization string `header:"Authorization"`
+	Body          RevokeOtherSessionsRequest
+}
+
+// RevokeSessionsResponse reports how many sessions were revoked.
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked" doc:"Number of sessions signed out"`
+}
+
+// RevokeSessionsOutput wraps the revoke sessions response for Huma.
+type RevokeSessionsOutput struct {
+	Body RevokeSessionsResponse
+}
+
+// ListUserSessionsInput is the Huma input for listing a user's sessions.
+type ListUserSessionsInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"User ID"`
+}
+
+// RevokeUserSessionInput is the Huma input for revoking a user's session.
+type RevokeUserSessionInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"User ID"`
+	SessionID     string `path:"session_id" doc:"Session ID"`
+}
+
+// RevokeAllUserSessionsInput is the Huma input for revoking all of a user's sessions.
+type RevokeAllUserSessionsInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"User ID"`
+}
+
+// === Handlers ===
+
+func (s *Server) handleListMySessions(ctx context.Context, _ *ListSessionsInput) (*ListSessionsOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.services.Session.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListSessionsOutput{Body: ListSessionsResponse{Sessions: mapSessions(sessions)}}, nil
+}
+
+func (s *Server) handleRevokeMySession(ctx context.Context, input *RevokeSessionInput) (*MessageOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Session.RevokeSession(ctx, userID, input.SessionID, "Signed out from another device"); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Session revoked"}}, nil
+}
+
+func (s *Server) handleRevokeOtherSessions(ctx context.Context, input *RevokeOtherSessionsInput) (*RevokeSessionsOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.services.Session.RevokeOtherSessions(ctx, userID, input.Body.CurrentSessionID, "Signed out from another device")
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevokeSessionsOutput{Body: RevokeSessionsResponse{Revoked: count}}, nil
+}
+
+func (s *Server) handleListUserSessions(ctx context.Context, input *ListUserSessionsInput) (*ListSessionsOutput, error) {
+	if _, err := s.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.services.Session.AdminListUserSessions(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListSessionsOutput{Body: ListSessionsResponse{Sessions: mapSessions(sessions)}}, nil
+}
+
+func (s *Server) handleRevokeUserSession(ctx context.Context, input *RevokeUserSessionInput) (*MessageOutput, error) {
+	adminUserID, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Session.AdminRevokeSession(ctx, adminUserID, input.ID, input.SessionID); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Session revoked"}}, nil
+}
+
+func (s *Server) handleRevokeAllUserSessions(ctx context.Context, input *RevokeAllUserSessionsInput) (*RevokeSessionsOutput, error) {
+	adminUserID, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.services.Session.AdminRevokeAllSessions(ctx, adminUserID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevokeSessionsOutput{Body: RevokeSessionsResponse{Revoked: count}}, nil
+}
+
+// mapSessions converts sessions to API responses, leaving out token hashes.
+func mapSessions(sessions []*domain.Session) []SessionInfoResponse {
+	resp := make([]SessionInfoResponse, len(sessions))
+	for i, sess := range sessions {
+		resp[i] = SessionInfoResponse{
+			ID:              sess.ID,
+			CreatedAt:       sess.CreatedAt,
+			LastSeenAt:      sess.LastSeenAt,
+			ExpiresAt:       sess.ExpiresAt,
+			IPAddress:       sess.IPAddress,
+			DeviceType:      sess.DeviceType,
+			Platform:        sess.Platform,
+			PlatformVersion: sess.PlatformVersion,
+			ClientName:      sess.ClientName,
+			ClientVersion:   sess.ClientVersion,
+			DeviceName:      sess.DeviceName,
+			DeviceModel:     sess.DeviceModel,
+			BrowserName:     sess.BrowserName,
+			BrowserVersion:  sess.BrowserVersion,
+		}
+	}
+	return resp
+}
@@ -36,7 +36,7 @@ func (s *Server) registerShelfRoutes() {
 		Method:      http.MethodPost,
 		Path:        "/api/v1/shelves",
 		Summary:     "Create shelf",
-		Description: "Creates a new shelf for organizing books",
+		Description: "Creates a new shelf for organizing books. Include rules to create a smart shelf that fills itself with matching books.",
 		Tags:        []string{"Shelves"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleCreateShelf)
@@ -56,7 +56,7 @@ func (s *Server) registerShelfRoutes() {
 		Method:      http.MethodPatch,
 		Path:        "/api/v1/shelves/{id}",
 		Summary:     "Update shelf",
-		Description: "Updates shelf metadata (owner only)",
+		Description: "Updates shelf metadata, and the rules of a smart shelf (owner only)",
 		Tags:        []string{"Shelves"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleUpdateShelf)
@@ -76,7 +76,7 @@ func (s *Server) registerShelfRoutes() {
 		Method:      http.MethodPost,
 		Path:        "/api/v1/shelves/{id}/books",
 		Summary:     "Add books to shelf",
-		Description: "Adds one or more books to a shelf (owner only)",
+		Description: "Adds one or more books to a shelf (owner only). Not allowed on smart shelves.",
 		Tags:        []string{"Shelves"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleAddBooksToShelf)
@@ -86,7 +86,7 @@ func (s *Server) registerShelfRoutes() {
 		Method:      http.MethodDelete,
 		Path:        "/api/v1/shelves/{id}/books/{bookId}",
 		Summary:     "Remove book from shelf",
-		Description: "Removes a book from a shelf (owner only)",
+		Description: "Removes a book from a shelf (owner only). Not allowed on smart shelves.",
 		Tags:        []string{"Shelves"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleRemoveBookFromShelf)
@@ -106,6 +106,22 @@ type ShelfOwnerResponse struct {
 	AvatarColor string `json:"avatar_color" doc:"Owner avatar color"`
 }
 
+// ShelfRulesBody describes which books a smart shelf holds. Every rule that
+// is set must match; within a list, any one entry matching is enough.
+type ShelfRulesBody struct {
+	GenreIDs        []string `json:"genre_ids,omitempty" maxItems:"50" doc:"Books in any of these genres, including subgenres"`
+	TagSlugs        []string `json:"tag_slugs,omitempty" maxItems:"50" doc:"Books with any of these tags"`
+	ContributorIDs  []string `json:"contributor_ids,omitempty" maxItems:"50" doc:"Books credited to any of these contributors, in any role"`
+	NarratorIDs     []string `json:"narrator_ids,omitempty" maxItems:"50" doc:"Books narrated by any of these contributors"`
+	SeriesIDs       []string `json:"series_ids,omitempty" maxItems:"50" doc:"Books in any of these series"`
+	MinDurationMs   int64    `json:"min_duration_ms,omitempty" minimum:"0" doc:"Minimum book duration in milliseconds"`
+	MaxDurationMs   int64    `json:"max_duration_ms,omitempty" minimum:"0" doc:"Maximum book duration in milliseconds"`
+	MinYear         int      `json:"min_year,omitempty" minimum:"0" maximum:"9999" doc:"Earliest publish year"`
+	MaxYear         int      `json:"max_year,omitempty" minimum:"0" maximum:"9999" doc:"Latest publish year"`
+	Progress        string   `json:"progress,omitempty" enum:"not_started,in_progress,finished" doc:"The shelf owner's listening progress"`
+	AddedWithinDays int      `json:"added_within_days,omitempty" minimum:"0" maximum:"3650" doc:"Books added to the library in the last N days"`
+}
+
 // ShelfResponse contains shelf data in API responses.
 type ShelfResponse struct {
 	ID            string             `json:"id" doc:"Shelf ID"`
@@ -114,6 +130,8 @@ type ShelfResponse struct {
 	Owner         ShelfOwnerResponse `json:"owner" doc:"Shelf owner"`
 	BookCount     int                `json:"book_count" doc:"Number of books in shelf"`
 	TotalDuration int64              `json:"total_duration" doc:"Total duration in seconds"`
+	Smart         bool               `json:"smart" doc:"Whether the shelf is filled by rules"`
+	Rules         *ShelfRulesBody    `json:"rules,omitempty" doc:"Smart shelf rules"`
 	CreatedAt     time.Time          `json:"created_at" doc:"Creation time"`
 	UpdatedAt     time.Time          `json:"updated_at" doc:"Last update time"`
 }
@@ -130,8 +148,9 @@ type ListShelvesOutput struct {
 
 // CreateShelfRequest is the request body for creating a shelf.
 type CreateShelfRequest struct {
-	Name        string `json:"name" validate:"required,min=1,max=100" doc:"Shelf name"`
-	Description string `json:"description" validate:"max=500" doc:"Shelf description"`
+	Name        string          `json:"name" validate:"required,min=1,max=100" doc:"Shelf name"`
+	Description string          `json:"description" validate:"max=500" doc:"Shelf description"`
+	Rules       *ShelfRulesBody `json:"rules,omitempty" doc:"Rules for a smart shelf; omit for a regular shelf"`
 }
 
 // CreateShelfInput wraps the create shelf request for Huma.
@@ -168,6 +187,8 @@ type ShelfDetailResponse struct {
 	Owner         ShelfOwnerResponse  `json:"owner" doc:"Shelf owner"`
 	BookCount     int                 `json:"book_count" doc:"Number of accessible books"`
 	TotalDuration int64               `json:"total_duration" doc:"Total duration of accessible books"`
+	Smart         bool                `json:"smart" doc:"Whether the shelf is filled by rules"`
+	Rules         *ShelfRulesBody     `json:"rules,omitempty" doc:"Smart shelf rules"`
 	Books         []ShelfBookResponse `json:"books" doc:"Books in shelf"`
 	CreatedAt     time.Time           `json:"created_at" doc:"Creation time"`
 	UpdatedAt     time.Time           `json:"updated_at" doc:"Last update time"`
@@ -180,8 +201,9 @@ type ShelfDetailOutput struct {
 
 // UpdateShelfRequest is the request body for updating a shelf.
 type UpdateShelfRequest struct {
-	Name        string `json:"name" validate:"required,min=1,max=100" doc:"New shelf name"`
-	Description string `json:"description" validate:"max=500" doc:"New shelf description"`
+	Name        string          `json:"name" validate:"required,min=1,max=100" doc:"New shelf name"`
+	Description string          `json:"description" validate:"max=500" doc:"New shelf description"`
+	Rules       *ShelfRulesBody `json:"rules,omitempty" doc:"New rules (smart shelves only); omit to keep the current rules"`
 }
 
 // UpdateShelfInput wraps the update shelf request for Huma.
@@ -308,7 +330,7 @@ func (s *Server) handleCreateShelf(ctx context.Context, input *CreateShelfInput)
 		return nil, err
 	}
 
-	shelf, err := s.services.Shelf.CreateShelf(ctx, userID, input.Body.Name, input.Body.Description)
+	shelf, err := s.services.Shelf.CreateShelf(ctx, userID, input.Body.Name, input.Body.Description, toDomainShelfRules(input.Body.Rules))
 	if err != nil {
 		return nil, err
 	}
@@ -327,7 +349,7 @@ func (s *Server) handleGetShelf(ctx context.Context, input *GetShelfInput) (*She
 		return nil, err
 	}
 
-	shelf, err := s.services.Shelf.GetShelf(ctx, input.ID)
+	shelf, err := s.services.Shelf.GetShelf(ctx, userID, input.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -424,6 +446,8 @@ func (s *Server) handleGetShelf(ctx context.Context, input *GetShelfInput) (*She
 		Owner:         s.mapShelfOwner(owner),
 		BookCount:     len(books),
 		TotalDuration: totalDuration,
+		Smart:         shelf.IsSmart(),
+		Rules:         toShelfRulesBody(shelf.Rules),
 		Books:         books,
 		CreatedAt:     shelf.CreatedAt,
 		UpdatedAt:     shelf.UpdatedAt,
@@ -438,7 +462,7 @@ func (s *Server) handleUpdateShelf(ctx context.Context, input *UpdateShelfInput)
 		return nil, err
 	}
 
-	shelf, err := s.services.Shelf.UpdateShelf(ctx, userID, input.ID, input.Body.Name, input.Body.Description)
+	shelf, err := s.services.Shelf.UpdateShelf(ctx, userID, input.ID, input.Body.Name, input.Body.Description, toDomainShelfRules(input.Body.Rules))
 	if err != nil {
 		return nil, err
 	}
@@ -527,11 +551,53 @@ func (s *Server) mapShelfResponse(ctx context.Context, shelf *domain.Shelf, owne
 		Owner:         s.mapShelfOwner(owner),
 		BookCount:     accessibleBookCount,
 		TotalDuration: totalDuration,
+		Smart:         shelf.IsSmart(),
+		Rules:         toShelfRulesBody(shelf.Rules),
 		CreatedAt:     shelf.CreatedAt,
 		UpdatedAt:     shelf.UpdatedAt,
 	}
 }
 
+// toDomainShelfRules converts request rules to domain rules.
+func toDomainShelfRules(body *ShelfRulesBody) *domain.ShelfRules {
+	if body == nil {
+		return nil
+	}
+	return &domain.ShelfRules{
+		GenreIDs:        body.GenreIDs,
+		TagSlugs:        body.TagSlugs,
+		ContributorIDs:  body.ContributorIDs,
+		NarratorIDs:     body.NarratorIDs,
+		SeriesIDs:       body.SeriesIDs,
+		MinDurationMs:   body.MinDurationMs,
+		MaxDurationMs:   body.MaxDurationMs,
+		MinYear:         body.MinYear,
+		MaxYear:         body.MaxYear,
+		Progress:        domain.ShelfProgress(body.Progress),
+		AddedWithinDays: body.AddedWithinDays,
+	}
+}
+
+// toShelfRulesBody converts domain rules to their API form.
+func toShelfRulesBody(rules *domain.ShelfRules) *ShelfRulesBody {
+	if rules == nil {
+		return nil
+	}
+	return &ShelfRulesBody{
+		GenreIDs:        rules.GenreIDs,
+		TagSlugs:        rules.TagSlugs,
+		ContributorIDs:  rules.ContributorIDs,
+		NarratorIDs:     rules.NarratorIDs,
+		SeriesIDs:       rules.SeriesIDs,
+		MinDurationMs:   rules.MinDurationMs,
+		MaxDurationMs:   rules.MaxDurationMs,
+		MinYear:         rules.MinYear,
+		MaxYear:         rules.MaxYear,
+		Progress:        string(rules.Progress),
+		AddedWithinDays: rules.AddedWithinDays,
+	}
+}
+
 // mapShelfOwner converts a domain user to a shelf owner response.
 func (s *Server) mapShelfOwner(user *domain.User) ShelfOwnerResponse {
 	displayName := user.DisplayName
@@ -445,7 +445,7 @@ type GetActivityFeedInput struct {
 // ActivityResponse represents a single activity in API format.
 type ActivityResponse struct {
 	ID              string `json:"id" doc:"Activity ID"`
-	Type            string `json:"type" doc:"Activity type (started_book, finished_book, streak_milestone, listening_milestone, shelf_created)"`
+	Type            string `json:"type" doc:"Activity type (started_book, finished_book, streak_milestone, listening_milestone, shelf_created, reviewed_book, goal_completed)"`
 	CreatedAt       string `json:"created_at" doc:"When activity occurred (RFC3339)"`
 	UserID          string `json:"user_id" doc:"User who performed the activity"`
 	UserDisplayName string `json:"user_display_name" doc:"User display name"`
@@ -461,12 +461,15 @@ type ActivityResponse struct {
 	IsReread       bool   `json:"is_reread,omitempty" doc:"Whether this is a re-read (for started_book)"`
 
 	// Milestone activities
-	MilestoneValue int    `json:"milestone_value,omitempty" doc:"Milestone value (days or hours)"`
-	MilestoneUnit  string `json:"milestone_unit,omitempty" doc:"Milestone unit (days or hours)"`
+	MilestoneValue int    `json:"milestone_value,omitempty" doc:"Milestone value (days, hours or books)"`
+	MilestoneUnit  string `json:"milestone_unit,omitempty" doc:"Milestone unit (days, hours or books)"`
 
 	// Shelf activities
 	ShelfID   string `json:"shelf_id,omitempty" doc:"Shelf ID (for shelf activities)"`
 	ShelfName string `json:"shelf_name,omitempty" doc:"Shelf name"`
+
+	// Review activities
+	Rating float64 `json:"rating,omitempty" doc:"Star rating (for reviewed_book)"`
 }
 
 // ActivityFeedResponse contains the activity feed data.
@@ -543,6 +546,7 @@ func (s *Server) handleGetActivityFeed(ctx context.Context, input *GetActivityFe
 			MilestoneUnit:   a.MilestoneUnit,
 			ShelfID:         a.ShelfID,
 			ShelfName:       a.ShelfName,
+			Rating:          a.Rating,
 		}
 	}
 
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+func (s *Server) registerStreamURLRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "createStreamURLs",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/books/{id}/stream-urls",
+		Summary:     "Create signed stream URLs",
+		Description: "Mints short-lived URLs for streaming a book's audio without an access token. The URLs cover the whole book, or one audio file, and can be bound to the caller's IP address.",
+		Tags:        []string{"Playback"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleCreateStreamURLs)
+}
+
+// === DTOs ===
+
+// CreateStreamURLsRequest is the request body for minting stream URLs.
+type CreateStreamURLsRequest struct {
+	AudioFileID string `json:"audio_file_id,omitempty" maxLength:"100" doc:"Limit the URLs to one audio file; all files when omitted"`
+	TTLSeconds  int    `json:"ttl_seconds,omitempty" doc:"Lifetime in seconds (60-86400, default 7200)"`
+	BindIP      bool   `json:"bind_ip,omitempty" doc:"Only accept the URLs from the caller's IP address"`
+}
+
+// CreateStreamURLsInput wraps the stream URL request for Huma.
+type CreateStreamURLsInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"Book ID"`
+	Body          CreateStreamURLsRequest
+
+	clientIP string
+}
+
+// Resolve captures the caller's address for IP-bound URLs.
+func (i *CreateStreamURLsInput) Resolve(ctx huma.Context) []error {
+	i.clientIP = remoteIP(ctx.RemoteAddr())
+	return nil
+}
+
+// StreamURLResponse holds the signed URLs for one audio file.
+type StreamURLResponse struct {
+	AudioFileID  string `json:"audio_file_id" doc:"Audio file ID"`
+	URL          string `json:"url" doc:"Original audio"`
+	TranscodeURL string `json:"transcode_url" doc:"HLS playlist of the transcoded audio, once playback/prepare reports it ready"`
+}
+
+// StreamURLsResponse contains signed stream URLs in API responses.
+type StreamURLsResponse struct {
+	ExpiresAt time.Time           `json:"expires_at" doc:"When the URLs stop working"`
+	Files     []StreamURLResponse `json:"files" doc:"URLs per audio file, in playback order"`
+}
+
+// CreateStreamURLsOutput wraps the stream URL response for Huma.
+type CreateStreamURLsOutput struct {
+	Body StreamURLsResponse
+}
+
+// === Handlers ===
+
+func (s *Server) handleCreateStreamURLs(ctx context.Context, input *CreateStreamURLsInput) (*CreateStreamURLsOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientIP := ""
+	if input.Body.BindIP {
+		clientIP = input.clientIP
+	}
+
+	grant, err := s.services.StreamURL.Sign(ctx, userID, input.ID, input.Body.AudioFileID,
+		time.Duration(input.Body.TTLSeconds)*time.Second, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := StreamURLsResponse{
+		ExpiresAt: grant.ExpiresAt,
+		Files:     make([]StreamURLResponse, 0, len(grant.FileIDs)),
+	}
+	for _, fileID := range grant.FileIDs {
+		resp.Files = append(resp.Files, StreamURLResponse{
+			AudioFileID:  fileID,
+			URL:          signedAudioPath(grant.Signature, grant.BookID, fileID),
+			TranscodeURL: signedAudioPath(grant.Signature, grant.BookID, fileID) + "/transcode/playlist.m3u8",
+		})
+	}
+	return &CreateStreamURLsOutput{Body: resp}, nil
+}
+
+// signStreamURL mints the file-scoped URL handed out by playback/prepare.
+func (s *Server) signStreamURL(ctx context.Context, userID, bookID, fileID string) (*service.StreamURLGrant, string, error) {
+	grant, err := s.services.StreamURL.Sign(ctx, userID, bookID, fileID, 0, "")
+	if err != nil {
+		return nil, "", err
+	}
+	return grant, signedAudioPath(grant.Signature, bookID, fileID), nil
+}
+
+// signedAudioPath is where a signature streams a file from. The signature is
+// a path segment rather than a query parameter so that the relative segment
+// URLs in an HLS playlist carry it too.
+func signedAudioPath(signature, bookID, fileID string) string {
+	return "/api/v1/stream/" + signature + "/" + bookID + "/" + fileID
+}
+
+// remoteIP strips the port from a request's remote address. The RealIP
+// middleware has already replaced it with the forwarded client address when
+// behind a proxy.
+func remoteIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -54,6 +54,16 @@ func (s *Server) registerSyncRoutes() {
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleGetSyncSeries)
 
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getSyncBookmarks",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/sync/bookmarks",
+		Summary:     "Get bookmarks for sync",
+		Description: "Returns the current user's paginated bookmarks with optional delta sync support",
+		Tags:        []string{"Sync"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetSyncBookmarks)
+
 	huma.Register(s.api, huma.Operation{
 		OperationID: "getSyncActiveSessions",
 		Method:      http.MethodGet,
@@ -85,6 +95,7 @@ func (s *Server) registerSyncRoutes() {
 // GetSyncManifestInput contains parameters for getting the sync manifest.
 type GetSyncManifestInput struct {
 	Authorization string `header:"Authorization"`
+	LibraryID     string `query:"library_id" doc:"Only list books in this library"`
 }
 
 // SyncManifestCountsResponse contains entity counts for the sync manifest.
@@ -113,6 +124,7 @@ type GetSyncBooksInput struct {
 	Cursor        string `query:"cursor" doc:"Pagination cursor"`
 	Limit         int    `query:"limit" default:"50" minimum:"1" maximum:"500" doc:"Items per page"`
 	UpdatedAfter  string `query:"updated_after" doc:"For delta sync, only return items updated after this time (RFC3339)"`
+	LibraryID     string `query:"library_id" doc:"Only return books in this library"`
 }
 
 // SyncBooksResponse contains books for sync.
@@ -200,13 +212,13 @@ type SyncSeriesOutput struct {
 
 // === Handlers ===
 
-func (s *Server) handleGetSyncManifest(ctx context.Context, _ *GetSyncManifestInput) (*SyncManifestOutput, error) {
+func (s *Server) handleGetSyncManifest(ctx context.Context, input *GetSyncManifestInput) (*SyncManifestOutput, error) {
 	userID, err := GetUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	manifest, err := s.services.Sync.GetManifest(ctx, userID)
+	manifest, err := s.services.Sync.GetManifest(ctx, userID, input.LibraryID)
 	if err != nil {
 		return nil, err
 	}
@@ -237,8 +249,9 @@ func (s *Server) handleGetSyncBooks(ctx context.Context, input *GetSyncBooksInpu
 	}
 
 	params := store.PaginationParams{
-		Cursor: input.Cursor,
-		Limit:  limit,
+		Cursor:    input.Cursor,
+		Limit:     limit,
+		LibraryID: input.LibraryID,
 	}
 	if input.UpdatedAfter != "" {
 		t, err := time.Parse(time.RFC3339, input.UpdatedAfter)
@@ -371,6 +384,72 @@ func (s *Server) handleGetSyncSeries(ctx context.Context, input *GetSyncSeriesIn
 	}, nil
 }
 
+// === Bookmarks ===
+
+// GetSyncBookmarksInput contains parameters for getting bookmarks for sync.
+type GetSyncBookmarksInput struct {
+	Authorization string `header:"Authorization"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor"`
+	Limit         int    `query:"limit" default:"50" minimum:"1" maximum:"500" doc:"Items per page"`
+	UpdatedAfter  string `query:"updated_after" doc:"For delta sync, only return items updated after this time (RFC3339)"`
+}
+
+// SyncBookmarksResponse contains bookmarks for sync.
+type SyncBookmarksResponse struct {
+	NextCursor         string             `json:"next_cursor,omitempty" doc:"Next page cursor"`
+	Bookmarks          []BookmarkResponse `json:"bookmarks" doc:"Bookmarks"`
+	DeletedBookmarkIDs []string           `json:"deleted_bookmark_ids,omitempty" doc:"Deleted bookmark IDs (for delta sync)"`
+	HasMore            bool               `json:"has_more" doc:"Whether more pages exist"`
+}
+
+// SyncBookmarksOutput wraps the sync bookmarks response for Huma.
+type SyncBookmarksOutput struct {
+	Body SyncBookmarksResponse
+}
+
+func (s *Server) handleGetSyncBookmarks(ctx context.Context, input *GetSyncBookmarksInput) (*SyncBookmarksOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	params := store.PaginationParams{
+		Cursor: input.Cursor,
+		Limit:  limit,
+	}
+	if input.UpdatedAfter != "" {
+		t, err := time.Parse(time.RFC3339, input.UpdatedAfter)
+		if err != nil {
+			return nil, huma.Error400BadRequest("invalid updated_after format, expected RFC3339")
+		}
+		params.UpdatedAfter = t
+	}
+
+	result, err := s.services.Sync.GetBookmarksForSync(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]BookmarkResponse, len(result.Bookmarks))
+	for i, b := range result.Bookmarks {
+		resp[i] = toBookmarkResponse(b)
+	}
+
+	return &SyncBookmarksOutput{
+		Body: SyncBookmarksResponse{
+			NextCursor:         result.NextCursor,
+			Bookmarks:          resp,
+			DeletedBookmarkIDs: result.DeletedBookmarkIDs,
+			HasMore:            result.HasMore,
+		},
+	}, nil
+}
+
 // === Active Sessions ===
 
 // GetSyncActiveSessionsInput contains parameters for getting active sessions.
@@ -80,19 +80,21 @@ func setupTagTestServer(t *testing.T) *tagTestServer {
 	sseManager := sse.NewManager(logger)
 
 	// Create services.
-	sessionService := service.NewSessionService(st, tokenService, logger)
+	sessionService := service.NewSessionService(st, tokenService, sseManager, logger)
 	instanceService := service.NewInstanceService(st, logger, cfg)
-	authService := service.NewAuthService(st, tokenService, sessionService, instanceService, logger)
-	syncService := service.NewSyncService(st, logger)
+	twoFactorService := service.NewTwoFactorService(st, logger)
+	authService := service.NewAuthService(st, tokenService, sessionService, instanceService, twoFactorService, logger)
+	syncService := service.NewSyncService(st, dto.NewEnricher(st), logger)
 	tagService := service.NewTagService(st, sseManager, nil, logger) // nil search for tests
 	shelfService := service.NewShelfService(st, sseManager, logger)
 
 	services := &Services{
-		Instance: instanceService,
-		Auth:     authService,
-		Sync:     syncService,
-		Tag:      tagService,
-		Shelf:    shelfService,
+		Instance:  instanceService,
+		Auth:      authService,
+		TwoFactor: twoFactorService,
+		Sync:      syncService,
+		Tag:       tagService,
+		Shelf:     shelfService,
 	}
 
 	router := chi.NewRouter()
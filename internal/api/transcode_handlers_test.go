@@ -76,10 +76,11 @@ func setupTranscodeAPITestServer(t *testing.T) *transcodeTestServer {
 	sseManager := sse.NewManager(logger)
 	enricher := dto.NewEnricher(st)
 
-	sessionService := service.NewSessionService(st, tokenService, logger)
+	sessionService := service.NewSessionService(st, tokenService, sseManager, logger)
 	instanceService := service.NewInstanceService(st, logger, cfg)
-	authService := service.NewAuthService(st, tokenService, sessionService, instanceService, logger)
-	syncService := service.NewSyncService(st, logger)
+	twoFactorService := service.NewTwoFactorService(st, logger)
+	authService := service.NewAuthService(st, tokenService, sessionService, instanceService, twoFactorService, logger)
+	syncService := service.NewSyncService(st, enricher, logger)
 	shelfService := service.NewShelfService(st, sseManager, logger)
 	inboxService := service.NewInboxService(st, enricher, sseManager, logger)
 	settingsService := service.NewSettingsService(st, inboxService, logger)
@@ -96,6 +97,7 @@ func setupTranscodeAPITestServer(t *testing.T) *transcodeTestServer {
 	services := &Services{
 		Instance:  instanceService,
 		Auth:      authService,
+		TwoFactor: twoFactorService,
 		Sync:      syncService,
 		Shelf:     shelfService,
 		Settings:  settingsService,
@@ -0,0 +1,314 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+func (s *Server) registerTwoFactorRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "loginTwoFactor",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/login/two-factor",
+		Summary:     "Complete two-factor login",
+		Description: "Exchanges the challenge token from login and a TOTP or recovery code for access and refresh tokens",
+		Tags:        []string{"Authentication"},
+	}, s.handleLoginTwoFactor)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getTwoFactorStatus",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/users/me/two-factor",
+		Summary:     "Get two-factor status",
+		Description: "Returns whether two-factor authentication is enabled or required, and how many recovery codes are left",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleGetTwoFactorStatus)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "enrollTwoFactor",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/users/me/two-factor/enroll",
+		Summary:     "Start two-factor enrollment",
+		Description: "Creates a TOTP secret and otpauth URI to show as a QR code. Two-factor stays off until confirmed with a code.",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleEnrollTwoFactor)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "confirmTwoFactor",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/users/me/two-factor/confirm",
+		Summary:     "Confirm two-factor enrollment",
+		Description: "Turns two-factor on with a code from the authenticator and returns one-time recovery codes. The codes are shown only once.",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleConfirmTwoFactor)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "regenerateRecoveryCodes",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/users/me/two-factor/recovery-codes",
+		Summary:     "Regenerate recovery codes",
+		Description: "Replaces all recovery codes after checking a current code. The new codes are shown only once.",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleRegenerateRecoveryCodes)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "disableTwoFactor",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/users/me/two-factor/disable",
+		Summary:     "Disable two-factor",
+		Description: "Turns two-factor off after checking the password and a current code",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleDisableTwoFactor)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "resetUserTwoFactor",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/admin/users/{id}/two-factor",
+		Summary:     "Reset user two-factor",
+		Description: "Turns two-factor off for a user who lost their authenticator and recovery codes",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleResetUserTwoFactor)
+}
+
+// === DTOs ===
+
+// TwoFactorLoginRequest is the request body for the second login step.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string     `json:"challenge_token" validate:"required" doc:"Challenge token from login"`
+	Code           string     `json:"code" validate:"required,max=64" doc:"6-digit TOTP code or a recovery code"`
+	DeviceInfo     DeviceInfo `json:"device_info,omitempty" doc:"Client device info"`
+}
+
+// TwoFactorLoginInput wraps the two-factor login request for Huma.
+type TwoFactorLoginInput struct {
+	Body          TwoFactorLoginRequest
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+}
+
+// TwoFactorStatusInput is the Huma input for getting two-factor status.
+type TwoFactorStatusInput struct {
+	Authorization string `header:"Authorization"`
+}
+
+// TwoFactorStatusResponse is the API response for two-factor status.
+type TwoFactorStatusResponse struct {
+	Enabled                bool `json:"enabled" doc:"Whether two-factor authentication is on"`
+	Required               bool `json:"required" doc:"Whether the server requires it for this account"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining" doc:"Unused recovery codes"`
+}
+
+// TwoFactorStatusOutput wraps the two-factor status response for Huma.
+type TwoFactorStatusOutput struct {
+	Body TwoFactorStatusResponse
+}
+
+// EnrollTwoFactorInput is the Huma input for starting two-factor enrollment.
+type EnrollTwoFactorInput struct {
+	Authorization string `header:"Authorization"`
+}
+
+// TwoFactorEnrollmentResponse is the API response for a new TOTP secret.
+type TwoFactorEnrollmentResponse struct {
+	Secret string `json:"secret" doc:"Base32 secret for manual entry"`
+	URI    string `json:"otpauth_uri" doc:"otpauth:// URI to render as a QR code"`
+}
+
+// TwoFactorEnrollmentOutput wraps the enrollment response for Huma.
+type TwoFactorEnrollmentOutput struct {
+	Body TwoFactorEnrollmentResponse
+}
+
+// TwoFactorCodeRequest is a request body carrying a two-factor code.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,max=64" doc:"6-digit TOTP code, or a recovery code where accepted"`
+}
+
+// TwoFactorCodeInput wraps a two-factor code request for Huma.
+type TwoFactorCodeInput struct {
+	Authorization string `header:"Authorization"`
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+	Body          TwoFactorCodeRequest
+}
+
+// RecoveryCodesResponse is the API response carrying new recovery codes.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" doc:"One-time recovery codes; shown only once"`
+}
+
+// RecoveryCodesOutput wraps the recovery codes response for Huma.
+type RecoveryCodesOutput struct {
+	Body RecoveryCodesResponse
+}
+
+// DisableTwoFactorRequest is the request body for turning two-factor off.
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required,max=1024" doc:"Current password"`
+	Code     string `json:"code" validate:"required,max=64" doc:"6-digit TOTP code or a recovery code"`
+}
+
+// DisableTwoFactorInput wraps the disable request for Huma.
+type DisableTwoFactorInput struct {
+	Authorization string `header:"Authorization"`
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+	Body          DisableTwoFactorRequest
+}
+
+// ResetUserTwoFactorInput is the Huma input for an admin two-factor reset.
+type ResetUserTwoFactorInput struct {
+	Authorization string `header:"Authorization"`
+	ID            string `path:"id" doc:"User ID"`
+}
+
+// === Handlers ===
+
+func (s *Server) handleLoginTwoFactor(ctx context.Context, input *TwoFactorLoginInput) (*AuthOutput, error) {
+	// Rate limit like login: six digits are quick to guess otherwise
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	req := service.TwoFactorLoginRequest{
+		ChallengeToken: input.Body.ChallengeToken,
+		Code:           input.Body.Code,
+		DeviceInfo: auth.DeviceInfo{
+			DeviceType:      input.Body.DeviceInfo.DeviceType,
+			Platform:        input.Body.DeviceInfo.Platform,
+			PlatformVersion: input.Body.DeviceInfo.PlatformVersion,
+			ClientName:      input.Body.DeviceInfo.ClientName,
+			ClientVersion:   input.Body.DeviceInfo.ClientVersion,
+			ClientBuild:     input.Body.DeviceInfo.ClientBuild,
+			DeviceName:      input.Body.DeviceInfo.DeviceName,
+			BrowserName:     input.Body.DeviceInfo.BrowserName,
+			BrowserVersion:  input.Body.DeviceInfo.BrowserVersion,
+			DeviceModel:     input.Body.DeviceInfo.DeviceModel,
+		},
+		IPAddress: extractIP(input.XForwardedFor, input.XRealIP),
+	}
+
+	resp, err := s.services.Auth.LoginTwoFactor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthOutput{Body: s.mapAuthResponse(ctx, resp)}, nil
+}
+
+func (s *Server) handleGetTwoFactorStatus(ctx context.Context, _ *TwoFactorStatusInput) (*TwoFactorStatusOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.services.TwoFactor.GetStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorStatusOutput{
+		Body: TwoFactorStatusResponse{
+			Enabled:                status.Enabled,
+			Required:               status.Required,
+			RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+		},
+	}, nil
+}
+
+func (s *Server) handleEnrollTwoFactor(ctx context.Context, _ *EnrollTwoFactorInput) (*TwoFactorEnrollmentOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.services.TwoFactor.BeginEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollmentOutput{
+		Body: TwoFactorEnrollmentResponse{Secret: enrollment.Secret, URI: enrollment.URI},
+	}, nil
+}
+
+func (s *Server) handleConfirmTwoFactor(ctx context.Context, input *TwoFactorCodeInput) (*RecoveryCodesOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.services.TwoFactor.ConfirmEnrollment(ctx, userID, input.Body.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesOutput{Body: RecoveryCodesResponse{RecoveryCodes: codes}}, nil
+}
+
+func (s *Server) handleRegenerateRecoveryCodes(ctx context.Context, input *TwoFactorCodeInput) (*RecoveryCodesOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.services.TwoFactor.RegenerateRecoveryCodes(ctx, userID, input.Body.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesOutput{Body: RecoveryCodesResponse{RecoveryCodes: codes}}, nil
+}
+
+func (s *Server) handleDisableTwoFactor(ctx context.Context, input *DisableTwoFactorInput) (*MessageOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	err = s.services.TwoFactor.Disable(ctx, userID, service.DisableTwoFactorRequest{
+		Password: input.Body.Password,
+		Code:     input.Body.Code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Two-factor authentication disabled"}}, nil
+}
+
+func (s *Server) handleResetUserTwoFactor(ctx context.Context, input *ResetUserTwoFactorInput) (*MessageOutput, error) {
+	adminUserID, err := s.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TwoFactor.AdminDisable(ctx, adminUserID, input.ID); err != nil {
+		return nil, err
+	}
+
+	return &MessageOutput{Body: MessageResponse{Message: "Two-factor authentication reset"}}, nil
+}
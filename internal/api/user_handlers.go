@@ -6,7 +6,9 @@ import (
 
 	"github.com/danielgtaylor/huma/v2"
 
+	"github.com/listenupapp/listenup-server/internal/auth"
 	"github.com/listenupapp/listenup-server/internal/color"
+	"github.com/listenupapp/listenup-server/internal/service"
 )
 
 func (s *Server) registerUserRoutes() {
@@ -19,6 +21,16 @@ func (s *Server) registerUserRoutes() {
 		Tags:        []string{"Users"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, s.handleGetCurrentUser)
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "changePassword",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/users/me/password",
+		Summary:     "Change password",
+		Description: "Changes the authenticated user's password. Signs out every session and returns new tokens for this device.",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, s.handleChangePassword)
 }
 
 // AuthenticatedInput contains the authorization header for authenticated requests.
@@ -31,6 +43,21 @@ type UserOutput struct {
 	Body UserResponse
 }
 
+// ChangePasswordRequest is the request body for changing one's own password.
+type ChangePasswordRequest struct {
+	CurrentPassword string     `json:"current_password" validate:"required,max=1024" doc:"Current password"`
+	NewPassword     string     `json:"new_password" validate:"required,min=8,max=1024" doc:"New password"`
+	DeviceInfo      DeviceInfo `json:"device_info,omitempty" doc:"Client device info for the new session"`
+}
+
+// ChangePasswordInput wraps the change password request for Huma.
+type ChangePasswordInput struct {
+	Authorization string `header:"Authorization"`
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+	Body          ChangePasswordRequest
+}
+
 func (s *Server) handleGetCurrentUser(ctx context.Context, _ *AuthenticatedInput) (*UserOutput, error) {
 	userID, err := GetUserID(ctx)
 	if err != nil {
@@ -68,3 +95,40 @@ func (s *Server) handleGetCurrentUser(ctx context.Context, _ *AuthenticatedInput
 		},
 	}, nil
 }
+
+func (s *Server) handleChangePassword(ctx context.Context, input *ChangePasswordInput) (*AuthOutput, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rate limit like login: the current password can be guessed here too
+	if err := s.checkAuthRateLimit(input.XForwardedFor, input.XRealIP); err != nil {
+		return nil, err
+	}
+
+	req := service.ChangePasswordRequest{
+		CurrentPassword: input.Body.CurrentPassword,
+		NewPassword:     input.Body.NewPassword,
+		DeviceInfo: auth.DeviceInfo{
+			DeviceType:      input.Body.DeviceInfo.DeviceType,
+			Platform:        input.Body.DeviceInfo.Platform,
+			PlatformVersion: input.Body.DeviceInfo.PlatformVersion,
+			ClientName:      input.Body.DeviceInfo.ClientName,
+			ClientVersion:   input.Body.DeviceInfo.ClientVersion,
+			ClientBuild:     input.Body.DeviceInfo.ClientBuild,
+			DeviceName:      input.Body.DeviceInfo.DeviceName,
+			BrowserName:     input.Body.DeviceInfo.BrowserName,
+			BrowserVersion:  input.Body.DeviceInfo.BrowserVersion,
+			DeviceModel:     input.Body.DeviceInfo.DeviceModel,
+		},
+		IPAddress: extractIP(input.XForwardedFor, input.XRealIP),
+	}
+
+	resp, err := s.services.Password.ChangePassword(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthOutput{Body: s.mapAuthResponse(ctx, resp)}, nil
+}
@@ -19,6 +19,16 @@ func (s *Server) registerWebRoutes() {
 		Tags:        []string{"Web"},
 	}, s.handleGetJoinPage)
 
+	// Reset page for password reset links
+	huma.Register(s.api, huma.Operation{
+		OperationID: "getResetPage",
+		Method:      http.MethodGet,
+		Path:        "/reset/{code}",
+		Summary:     "Password reset page",
+		Description: "Returns HTML page for choosing a new password with a reset code",
+		Tags:        []string{"Web"},
+	}, s.handleGetResetPage)
+
 	// Android App Links / iOS Universal Links
 	s.router.Get("/.well-known/assetlinks.json", s.handleAssetLinks)
 	s.router.Get("/.well-known/apple-app-site-association", s.handleAppleAppSiteAssociation)
@@ -31,6 +41,11 @@ type GetJoinPageInput struct {
 	Code string `path:"code" doc:"Invite code"`
 }
 
+// GetResetPageInput contains parameters for the password reset page.
+type GetResetPageInput struct {
+	Code string `path:"code" doc:"Password reset code"`
+}
+
 // HTMLOutput represents an HTML response.
 type HTMLOutput struct {
 	ContentType string `header:"Content-Type"`
@@ -101,6 +116,93 @@ func (s *Server) handleGetJoinPage(ctx context.Context, input *GetJoinPageInput)
 	}, nil
 }
 
+func (s *Server) handleGetResetPage(ctx context.Context, input *GetResetPageInput) (*HTMLOutput, error) {
+	// Check the code is still usable - return error page HTML if not (not an error for the caller)
+	if _, err := s.services.Password.GetPasswordReset(ctx, input.Code); err != nil {
+		//nolint:nilerr // Intentional: return HTML error page, not HTTP error
+		return &HTMLOutput{
+			ContentType: "text/html; charset=utf-8",
+			Body: `<!DOCTYPE html>
+<html>
+<head>
+    <title>Invalid Reset Link</title>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, sans-serif; max-width: 400px; margin: 50px auto; padding: 20px; text-align: center; }
+        h1 { color: #d32f2f; }
+    </style>
+</head>
+<body>
+    <h1>Invalid Reset Link</h1>
+    <p>This reset link is invalid, has already been used, or has expired. Ask your server admin for a new one.</p>
+</body>
+</html>`,
+		}, nil
+	}
+
+	// Valid codes are base64url, so the code is safe to embed as-is.
+	return &HTMLOutput{
+		ContentType: "text/html; charset=utf-8",
+		Body: `<!DOCTYPE html>
+<html>
+<head>
+    <title>Reset Password - ListenUp</title>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <meta name="referrer" content="no-referrer">
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, sans-serif; max-width: 400px; margin: 50px auto; padding: 20px; text-align: center; }
+        h1 { color: #1976d2; }
+        input { display: block; width: 100%; box-sizing: border-box; padding: 10px; margin: 10px 0; border: 1px solid #ccc; border-radius: 8px; font-size: 16px; }
+        .btn { display: inline-block; background: #1976d2; color: white; padding: 12px 24px; border: none; border-radius: 8px; font-size: 16px; margin: 10px; cursor: pointer; }
+        .btn:hover { background: #1565c0; }
+        .btn:disabled { background: #90a4ae; }
+        #error { color: #d32f2f; }
+    </style>
+</head>
+<body>
+    <h1>Reset Password</h1>
+    <form id="reset-form">
+        <input type="password" id="password" placeholder="New password" minlength="8" maxlength="1024" autocomplete="new-password" required>
+        <input type="password" id="confirm" placeholder="Confirm new password" minlength="8" maxlength="1024" autocomplete="new-password" required>
+        <p id="error"></p>
+        <button type="submit" class="btn" id="submit">Set Password</button>
+    </form>
+    <p id="done" style="display:none">Your password has been changed. Sign in to the ListenUp app with your new password.</p>
+    <script>
+        document.getElementById('reset-form').addEventListener('submit', async function(e) {
+            e.preventDefault();
+            var error = document.getElementById('error');
+            var password = document.getElementById('password').value;
+            if (password !== document.getElementById('confirm').value) {
+                error.textContent = 'Passwords do not match.';
+                return;
+            }
+            error.textContent = '';
+            document.getElementById('submit').disabled = true;
+            try {
+                var resp = await fetch('/api/v1/auth/reset-password', {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ code: '` + input.Code + `', new_password: password })
+                });
+                if (resp.ok) {
+                    document.getElementById('reset-form').style.display = 'none';
+                    document.getElementById('done').style.display = 'block';
+                    return;
+                }
+                var body = await resp.json().catch(function() { return {}; });
+                error.textContent = body.message || body.error || 'Could not reset password.';
+            } catch (err) {
+                error.textContent = 'Could not reach the server.';
+            }
+            document.getElementById('submit').disabled = false;
+        });
+    </script>
+</body>
+</html>`,
+	}, nil
+}
+
 func (s *Server) handleAssetLinks(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Write([]byte(`[{
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStreamSigner(t *testing.T) *StreamSigner {
+	t.Helper()
+	signer, err := NewStreamSigner(bytes.Repeat([]byte{7}, keyBytesSize))
+	require.NoError(t, err)
+	return signer
+}
+
+func TestStreamSigner_BookScope(t *testing.T) {
+	t.Parallel()
+	signer := newTestStreamSigner(t)
+	now := time.Now()
+
+	sig, err := signer.Sign(StreamGrant{UserID: "user-1", BookID: "book-1", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	grant, err := signer.Verify(sig, "book-1", "af-1", "203.0.113.9", now)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", grant.UserID)
+	assert.Empty(t, grant.FileID)
+
+	_, err = signer.Verify(sig, "book-1", "af-2", "198.51.100.1", now)
+	require.NoError(t, err, "book scope covers every file from any address")
+
+	_, err = signer.Verify(sig, "book-2", "af-1", "", now)
+	assert.ErrorIs(t, err, ErrStreamSignatureInvalid)
+}
+
+func TestStreamSigner_FileScope(t *testing.T) {
+	t.Parallel()
+	signer := newTestStreamSigner(t)
+	now := time.Now()
+
+	sig, err := signer.Sign(StreamGrant{UserID: "user-1", BookID: "book-1", FileID: "af-1", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	_, err = signer.Verify(sig, "book-1", "af-1", "", now)
+	require.NoError(t, err)
+
+	_, err = signer.Verify(sig, "book-1", "af-2", "", now)
+	assert.ErrorIs(t, err, ErrStreamSignatureInvalid)
+}
+
+func TestStreamSigner_Expiry(t *testing.T) {
+	t.Parallel()
+	signer := newTestStreamSigner(t)
+	now := time.Now()
+
+	sig, err := signer.Sign(StreamGrant{UserID: "user-1", BookID: "book-1", ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+
+	_, err = signer.Verify(sig, "book-1", "af-1", "", now.Add(2*time.Minute))
+	assert.ErrorIs(t, err, ErrStreamSignatureExpired)
+}
+
+func TestStreamSigner_IPBinding(t *testing.T) {
+	t.Parallel()
+	signer := newTestStreamSigner(t)
+	now := time.Now()
+
+	sig, err := signer.Sign(StreamGrant{UserID: "user-1", BookID: "book-1", ClientIP: "203.0.113.9", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.NotContains(t, sig, "203.0.113.9")
+
+	grant, err := signer.Verify(sig, "book-1", "af-1", "::ffff:203.0.113.9", now)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.9", grant.ClientIP)
+
+	_, err = signer.Verify(sig, "book-1", "af-1", "203.0.113.10", now)
+	assert.ErrorIs(t, err, ErrStreamSignatureInvalid)
+
+	_, err = signer.Verify(sig, "book-1", "af-1", "", now)
+	assert.ErrorIs(t, err, ErrStreamSignatureInvalid)
+}
+
+func TestStreamSigner_Tampering(t *testing.T) {
+	t.Parallel()
+	signer := newTestStreamSigner(t)
+	now := time.Now()
+
+	sig, err := signer.Sign(StreamGrant{UserID: "user-1", BookID: "book-1", FileID: "af-1", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	payload, mac, _ := strings.Cut(sig, ".")
+
+	other, err := signer.Sign(StreamGrant{UserID: "user-1", BookID: "book-1", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	otherPayload, _, _ := strings.Cut(other, ".")
+
+	for _, bad := range []string{
+		"",
+		payload,
+		otherPayload + "." + mac, // Widening a file grant to the whole book
+		payload + "." + mac + "x",
+		"!!!." + mac,
+	} {
+		_, err := signer.Verify(bad, "book-1", "af-1", "", now)
+		assert.ErrorIs(t, err, ErrStreamSignatureInvalid, "signature %q", bad)
+	}
+
+	otherSigner, err := NewStreamSigner(bytes.Repeat([]byte{8}, keyBytesSize))
+	require.NoError(t, err)
+	_, err = otherSigner.Verify(sig, "book-1", "af-1", "", now)
+	assert.ErrorIs(t, err, ErrStreamSignatureInvalid)
+}
+
+func TestStreamSigner_RejectsSeparatorInFields(t *testing.T) {
+	t.Parallel()
+	signer := newTestStreamSigner(t)
+
+	_, err := signer.Sign(StreamGrant{UserID: "user|1", BookID: "book-1", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json/v2"
+	"errors"
 	"fmt"
 	"time"
 
@@ -18,6 +19,14 @@ const (
 	tokenIssuer   = "listenup-server"
 	tokenAudience = "listenup-client"
 
+	// challengeAudience keeps two-factor challenge tokens from being
+	// accepted as access tokens, and the other way around.
+	challengeAudience = "listenup-2fa-challenge"
+
+	// ChallengeTokenDuration is how long a user has to enter their second
+	// factor after a correct password.
+	ChallengeTokenDuration = 5 * time.Minute
+
 	// PASETO v4 symmetric key requirements.
 	keyBytesSize     = 32 // 256 bits
 	keyHexSize       = 64 // 32 bytes as hex string
@@ -144,3 +153,46 @@ func (s *TokenService) AccessTokenDuration() time.Duration {
 func (s *TokenService) RefreshTokenDuration() time.Duration {
 	return s.refreshTokenDuration
 }
+
+// GenerateChallengeToken creates a short-lived token proving that userID
+// passed the password step of a two-factor login. The challenge ID lets the
+// server count and revoke attempts against the challenge.
+func (s *TokenService) GenerateChallengeToken(userID, challengeID string) (string, error) {
+	now := time.Now()
+
+	token := paseto.NewToken()
+	token.SetIssuer(tokenIssuer)
+	token.SetSubject(userID)
+	token.SetAudience(challengeAudience)
+	token.SetIssuedAt(now)
+	token.SetNotBefore(now)
+	token.SetExpiration(now.Add(ChallengeTokenDuration))
+	token.SetJti(challengeID)
+
+	return token.V4Encrypt(s.symmetricKey, nil), nil
+}
+
+// VerifyChallengeToken verifies a two-factor challenge token and returns
+// the user ID and challenge ID it was issued for.
+func (s *TokenService) VerifyChallengeToken(tokenString string) (userID, challengeID string, err error) {
+	parser := paseto.NewParser()
+	parser.AddRule(paseto.ForAudience(challengeAudience))
+	parser.AddRule(paseto.IssuedBy(tokenIssuer))
+	parser.AddRule(paseto.NotExpired())
+	parser.AddRule(paseto.ValidAt(time.Now()))
+
+	token, err := parser.ParseV4Local(s.symmetricKey, tokenString, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid challenge token: %w", err)
+	}
+
+	userID, err = token.GetSubject()
+	if err != nil || userID == "" {
+		return "", "", errors.New("invalid challenge token: missing subject")
+	}
+	challengeID, err = token.GetJti()
+	if err != nil || challengeID == "" {
+		return "", "", errors.New("invalid challenge token: missing challenge ID")
+	}
+	return userID, challengeID, nil
+}
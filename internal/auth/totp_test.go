@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 seed from RFC 6238 appendix B, base32 encoded.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	t.Parallel()
+
+	// Appendix B lists 8-digit codes; the 6-digit code is the last six.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	step := TOTPStep(now)
+
+	prev, _ := TOTPCode(secret, step-1)
+	if got, ok := ValidateTOTP(secret, prev, now); !ok || got != step-1 {
+		t.Errorf("previous period code: got step %d, ok %v", got, ok)
+	}
+
+	old, _ := TOTPCode(secret, step-2)
+	if _, ok := ValidateTOTP(secret, old, now); ok {
+		t.Error("code from two periods ago was accepted")
+	}
+
+	current, _ := TOTPCode(secret, step)
+	spaced := current[:3] + " " + current[3:]
+	if _, ok := ValidateTOTP(secret, spaced, now); !ok {
+		t.Error("code with a space was rejected")
+	}
+	if _, ok := ValidateTOTP(secret, "12345", now); ok {
+		t.Error("short code was accepted")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	t.Parallel()
+
+	uri := TOTPURI("My Server", "ada@example.com", "JBSWY3DPEHPK3PXP")
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("parse %q: %v", uri, err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		t.Errorf("unexpected uri %q", uri)
+	}
+	if u.Path != "/My Server:ada@example.com" {
+		t.Errorf("label = %q", u.Path)
+	}
+	if u.Query().Get("secret") != "JBSWY3DPEHPK3PXP" || u.Query().Get("issuer") != "My Server" {
+		t.Errorf("query = %q", u.RawQuery)
+	}
+}
@@ -0,0 +1,556 @@
+// Package abstest runs a stub Audiobookshelf server for tests.
+package abstest
+
+import (
+	"cmp"
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Token is the API token the stub server accepts.
+const Token = "abs-test-token"
+
+// Server is a stub Audiobookshelf server backed by httptest. It serves the
+// read-only REST endpoints the live import uses, from data the test sets
+// up, in the shapes ABS 2.x returns.
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	data     Data
+	requests []string
+}
+
+// Data is everything the stub server knows about.
+type Data struct {
+	Libraries   []Library
+	Items       []Item
+	Users       []User
+	Sessions    []Session
+	Collections []Collection
+	Playlists   []Playlist
+	Podcasts    []Podcast
+}
+
+// Library is an ABS library.
+type Library struct {
+	ID        string
+	Name      string
+	MediaType string // "book" or "podcast"
+}
+
+// Item is a library item and its book.
+type Item struct {
+	ID        string // libraryItems.id
+	MediaID   string // books.id
+	LibraryID string
+	Path      string
+	Title     string
+	Author    string // comma-separated, as ABS shows them
+	Narrator  string // comma-separated, as ABS shows them
+	ASIN      string
+	ISBN      string
+	Duration  float64 // seconds
+	Tags      []string
+}
+
+// Podcast is a podcast library item and its episodes.
+type Podcast struct {
+	ID        string // libraryItems.id
+	MediaID   string // podcasts.id
+	LibraryID string
+	Path      string
+	Title     string
+	Author    string
+	FeedURL   string
+	Episodes  []Episode
+}
+
+// Episode is a podcast episode.
+type Episode struct {
+	ID           string // podcastEpisodes.id
+	GUID         string
+	Title        string
+	PublishedAt  int64 // Unix milliseconds
+	EnclosureURL string
+	Size         int64   // Sent as a string, as ABS copies it from the feed
+	Duration     float64 // seconds; 0 for an episode that isn't downloaded
+	Path         string  // Downloaded file; empty if not downloaded
+}
+
+// User is an ABS user with progress and bookmarks.
+type User struct {
+	ID        string
+	Username  string
+	Email     string
+	Type      string // "root", "admin", "user", "guest"
+	Progress  []Progress
+	Bookmarks []Bookmark
+}
+
+// Progress is a user's media progress for one book or podcast episode.
+type Progress struct {
+	LibraryItemID string
+	MediaItemID   string // books.id
+	EpisodeID     string // podcastEpisodes.id, for progress on an episode
+	Duration      float64
+	CurrentTime   float64
+	Progress      float64
+	IsFinished    bool
+	LastUpdate    int64 // Unix milliseconds
+	FinishedAt    int64 // Unix milliseconds, 0 if unfinished
+}
+
+// Bookmark is a user's bookmark in a library item.
+type Bookmark struct {
+	LibraryItemID string
+	Title         string
+	Time          float64
+	CreatedAt     int64
+}
+
+// Session is a playback session.
+type Session struct {
+	ID            string
+	UserID        string
+	LibraryID     string
+	LibraryItemID string
+	BookID        string
+	MediaType     string // defaults to "book"
+	TimeListening float64
+	StartTime     float64
+	CurrentTime   float64
+	StartedAt     int64 // Unix milliseconds
+	UpdatedAt     int64 // Unix milliseconds
+}
+
+// Collection is a library collection of items.
+type Collection struct {
+	ID          string
+	LibraryID   string
+	Name        string
+	Description string
+	ItemIDs     []string // libraryItems.id values, in order
+}
+
+// Playlist is a user's playlist of items.
+type Playlist struct {
+	ID        string
+	LibraryID string
+	UserID    string
+	Name      string
+	ItemIDs   []string // libraryItems.id values, in order
+}
+
+// NewServer starts a stub server with the given data. It is shut down when
+// the test ends.
+func NewServer(t testing.TB, data Data) *Server {
+	t.Helper()
+
+	s := &Server{data: data}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/authorize", s.handleAuthorize)
+	mux.HandleFunc("GET /api/libraries", s.handleLibraries)
+	mux.HandleFunc("GET /api/libraries/{id}/items", s.handleLibraryItems)
+	mux.HandleFunc("GET /api/items/{id}", s.handleItem)
+	mux.HandleFunc("GET /api/users", s.handleUsers)
+	mux.HandleFunc("GET /api/users/{id}", s.handleUser)
+	mux.HandleFunc("GET /api/sessions", s.handleSessions)
+	mux.HandleFunc("GET /api/collections", s.handleCollections)
+	mux.HandleFunc("GET /api/playlists", s.handlePlaylists)
+	s.server = httptest.NewServer(s.authorize(mux))
+	s.URL = s.server.URL
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+// Update changes the server's data, as activity on a running server would.
+func (s *Server) Update(fn func(d *Data)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.data)
+}
+
+// Requests returns the request URIs served so far and forgets them.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reqs := s.requests
+	s.requests = nil
+	return reqs
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.RequestURI())
+		s.mu.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleAuthorize(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The token belongs to the first root or admin user.
+	user := map[string]any{"id": "", "username": "", "type": "user"}
+	for _, u := range s.data.Users {
+		if u.Type == "root" || u.Type == "admin" {
+			user = map[string]any{"id": u.ID, "username": u.Username, "type": u.Type}
+			break
+		}
+	}
+	writeJSON(w, map[string]any{
+		"user":           user,
+		"serverSettings": map[string]any{"version": "2.17.0"},
+	})
+}
+
+func (s *Server) handleLibraries(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	libs := make([]map[string]any, len(s.data.Libraries))
+	for i, l := range s.data.Libraries {
+		libs[i] = map[string]any{
+			"id":        l.ID,
+			"name":      l.Name,
+			"mediaType": cmp.Or(l.MediaType, "book"),
+			"folders":   []map[string]any{{"id": "fol-" + l.ID, "fullPath": "/audiobooks/" + l.ID}},
+		}
+	}
+	writeJSON(w, map[string]any{"libraries": libs})
+}
+
+func (s *Server) handleLibraryItems(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []map[string]any
+	for _, item := range s.data.Items {
+		if item.LibraryID == r.PathValue("id") {
+			items = append(items, s.minifiedItem(item))
+		}
+	}
+	for _, p := range s.data.Podcasts {
+		if p.LibraryID == r.PathValue("id") {
+			items = append(items, podcastItem(p, false))
+		}
+	}
+	limit, page := pageParams(r, "limit")
+	writeJSON(w, map[string]any{
+		"results": paginate(items, limit, page),
+		"total":   len(items),
+		"limit":   limit,
+		"page":    page,
+	})
+}
+
+// handleItem serves /api/items/{id}. Only podcasts are served; the live
+// import reads books from the library listing.
+func (s *Server) handleItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.data.Podcasts, func(p Podcast) bool { return p.ID == r.PathValue("id") })
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, podcastItem(s.data.Podcasts[i], r.URL.Query().Get("expanded") == "1"))
+}
+
+func (s *Server) handleUsers(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make([]map[string]any, len(s.data.Users))
+	for i, u := range s.data.Users {
+		users[i] = map[string]any{"id": u.ID, "username": u.Username, "email": nullable(u.Email), "type": u.Type}
+	}
+	writeJSON(w, map[string]any{"users": users})
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.data.Users, func(u User) bool { return u.ID == r.PathValue("id") })
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	u := s.data.Users[i]
+
+	progress := make([]map[string]any, len(u.Progress))
+	for j, p := range u.Progress {
+		mediaItemID, mediaItemType := p.MediaItemID, "book"
+		if p.EpisodeID != "" {
+			mediaItemID, mediaItemType = p.EpisodeID, "podcastEpisode"
+		}
+		progress[j] = map[string]any{
+			"id":                        "mp-" + u.ID + "-" + mediaItemID,
+			"userId":                    u.ID,
+			"libraryItemId":             p.LibraryItemID,
+			"episodeId":                 nullable(p.EpisodeID),
+			"mediaItemId":               mediaItemID,
+			"mediaItemType":             mediaItemType,
+			"duration":                  p.Duration,
+			"progress":                  p.Progress,
+			"currentTime":               p.CurrentTime,
+			"isFinished":                p.IsFinished,
+			"hideFromContinueListening": false,
+			"ebookLocation":             nil,
+			"lastUpdate":                p.LastUpdate,
+			"startedAt":                 p.LastUpdate,
+			"finishedAt":                nullable(p.FinishedAt),
+		}
+	}
+	bookmarks := make([]map[string]any, len(u.Bookmarks))
+	for j, b := range u.Bookmarks {
+		bookmarks[j] = map[string]any{
+			"libraryItemId": b.LibraryItemID,
+			"title":         b.Title,
+			"time":          b.Time,
+			"createdAt":     b.CreatedAt,
+		}
+	}
+	writeJSON(w, map[string]any{
+		"id":            u.ID,
+		"username":      u.Username,
+		"email":         nullable(u.Email),
+		"type":          u.Type,
+		"isActive":      true,
+		"mediaProgress": progress,
+		"bookmarks":     bookmarks,
+	})
+}
+
+// handleSessions serves /api/sessions. Like ABS, it sorts by the "sort"
+// query parameter (only updatedAt is supported here) and pages with
+// itemsPerPage and a zero-based page.
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessions := slices.Clone(s.data.Sessions)
+	if r.URL.Query().Get("sort") == "updatedAt" {
+		slices.SortStableFunc(sessions, func(a, b Session) int { return cmp.Compare(a.UpdatedAt, b.UpdatedAt) })
+		if r.URL.Query().Get("desc") == "1" {
+			slices.Reverse(sessions)
+		}
+	}
+
+	out := make([]map[string]any, len(sessions))
+	for i, sess := range sessions {
+		out[i] = map[string]any{
+			"id":            sess.ID,
+			"userId":        sess.UserID,
+			"libraryId":     sess.LibraryID,
+			"libraryItemId": sess.LibraryItemID,
+			"bookId":        nullable(sess.BookID),
+			"episodeId":     nil,
+			"mediaType":     cmp.Or(sess.MediaType, "book"),
+			"mediaMetadata": map[string]any{},
+			"chapters":      []any{},
+			"displayTitle":  sess.LibraryItemID,
+			"duration":      sess.CurrentTime,
+			"playMethod":    0,
+			"deviceInfo":    map[string]any{"clientName": "Abs Web"},
+			"timeListening": sess.TimeListening,
+			"startTime":     sess.StartTime,
+			"currentTime":   sess.CurrentTime,
+			"startedAt":     sess.StartedAt,
+			"updatedAt":     sess.UpdatedAt,
+		}
+	}
+	perPage, page := pageParams(r, "itemsPerPage")
+	numPages := (len(out) + perPage - 1) / perPage
+	writeJSON(w, map[string]any{
+		"total":        len(out),
+		"numPages":     numPages,
+		"page":         page,
+		"itemsPerPage": perPage,
+		"sessions":     paginate(out, perPage, page),
+	})
+}
+
+func (s *Server) handleCollections(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	collections := make([]map[string]any, len(s.data.Collections))
+	for i, c := range s.data.Collections {
+		collections[i] = map[string]any{
+			"id":          c.ID,
+			"libraryId":   c.LibraryID,
+			"name":        c.Name,
+			"description": nullable(c.Description),
+			"books":       s.expandedItems(c.ItemIDs),
+		}
+	}
+	writeJSON(w, map[string]any{"collections": collections})
+}
+
+func (s *Server) handlePlaylists(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	playlists := make([]map[string]any, len(s.data.Playlists))
+	for i, p := range s.data.Playlists {
+		items := make([]map[string]any, 0, len(p.ItemIDs))
+		for _, item := range s.expandedItems(p.ItemIDs) {
+			items = append(items, map[string]any{
+				"libraryItemId": item["id"],
+				"episodeId":     nil,
+				"libraryItem":   item,
+			})
+		}
+		playlists[i] = map[string]any{
+			"id":        p.ID,
+			"libraryId": p.LibraryID,
+			"userId":    p.UserID,
+			"name":      p.Name,
+			"items":     items,
+		}
+	}
+	writeJSON(w, map[string]any{"playlists": playlists})
+}
+
+func (s *Server) minifiedItem(item Item) map[string]any {
+	return map[string]any{
+		"id":        item.ID,
+		"libraryId": item.LibraryID,
+		"folderId":  "fol-" + item.LibraryID,
+		"path":      item.Path,
+		"relPath":   strings.TrimPrefix(item.Path, "/audiobooks/"+item.LibraryID+"/"),
+		"isFile":    false,
+		"mediaType": "book",
+		"isMissing": false,
+		"isInvalid": false,
+		"addedAt":   1700000000000,
+		"updatedAt": 1700000000000,
+		"media": map[string]any{
+			"id": item.MediaID,
+			"metadata": map[string]any{
+				"title":         item.Title,
+				"subtitle":      nil,
+				"authorName":    item.Author,
+				"narratorName":  item.Narrator,
+				"seriesName":    "",
+				"genres":        []string{},
+				"publishedYear": nil,
+				"isbn":          nullable(item.ISBN),
+				"asin":          nullable(item.ASIN),
+				"explicit":      false,
+			},
+			"coverPath":     nil,
+			"tags":          item.Tags,
+			"numAudioFiles": 1,
+			"duration":      item.Duration,
+			"size":          1024,
+		},
+	}
+}
+
+// podcastItem returns a podcast library item. Minified items only count
+// their episodes; expanded ones list them.
+func podcastItem(p Podcast, expanded bool) map[string]any {
+	media := map[string]any{
+		"id": p.MediaID,
+		"metadata": map[string]any{
+			"title":    p.Title,
+			"author":   nullable(p.Author),
+			"feedUrl":  nullable(p.FeedURL),
+			"imageUrl": nil,
+			"explicit": false,
+			"language": nil,
+		},
+		"coverPath":            nil,
+		"tags":                 []string{},
+		"autoDownloadEpisodes": false,
+		"maxEpisodesToKeep":    0,
+		"numEpisodes":          len(p.Episodes),
+	}
+	if expanded {
+		episodes := make([]map[string]any, len(p.Episodes))
+		for i, e := range p.Episodes {
+			var audioFile any
+			if e.Path != "" {
+				audioFile = map[string]any{"duration": e.Duration, "metadata": map[string]any{"path": e.Path}}
+			}
+			episodes[i] = map[string]any{
+				"id":            e.ID,
+				"libraryItemId": p.ID,
+				"podcastId":     p.MediaID,
+				"guid":          nullable(e.GUID),
+				"title":         e.Title,
+				"season":        "",
+				"episode":       "",
+				"description":   nil,
+				"enclosure":     map[string]any{"url": e.EnclosureURL, "type": "audio/mpeg", "length": strconv.FormatInt(e.Size, 10)},
+				"publishedAt":   e.PublishedAt,
+				"audioFile":     audioFile,
+			}
+		}
+		media["episodes"] = episodes
+	}
+	return map[string]any{
+		"id":        p.ID,
+		"libraryId": p.LibraryID,
+		"path":      p.Path,
+		"mediaType": "podcast",
+		"isMissing": false,
+		"isInvalid": false,
+		"media":     media,
+	}
+}
+
+// expandedItems looks up items by libraryItems.id. Only the fields the
+// importer reads are filled in.
+func (s *Server) expandedItems(ids []string) []map[string]any {
+	items := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		for _, item := range s.data.Items {
+			if item.ID == id {
+				items = append(items, s.minifiedItem(item))
+			}
+		}
+	}
+	return items
+}
+
+func pageParams(r *http.Request, limitParam string) (limit, page int) {
+	limit, _ = strconv.Atoi(r.URL.Query().Get(limitParam))
+	if limit <= 0 {
+		limit = 10
+	}
+	page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	return limit, max(page, 0)
+}
+
+func paginate[T any](all []T, limit, page int) []T {
+	start := min(limit*page, len(all))
+	end := min(start+limit, len(all))
+	return all[start:end]
+}
+
+// nullable returns nil for zero values, as ABS sends null for unset fields.
+func nullable[T comparable](v T) any {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return v
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.MarshalWrite(w, v)
+}
@@ -15,6 +15,7 @@ const (
 	PhaseMatchingBooks    AnalysisPhase = "matching_books"
 	PhaseMatchingSessions AnalysisPhase = "matching_sessions"
 	PhaseMatchingProgress AnalysisPhase = "matching_progress"
+	PhaseMatchingLibrary  AnalysisPhase = "matching_library"
 	PhaseDone             AnalysisPhase = "done"
 )
 
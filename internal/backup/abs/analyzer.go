@@ -83,6 +83,17 @@ func (a *Analyzer) Analyze(ctx context.Context, backup *Backup) (*AnalysisResult
 	a.analyzeProgress(ctx, backup, result)
 	a.logger.Info("analyzed progress", "ready", result.ProgressReady, "pending", result.ProgressPending, "duration", time.Since(progressStart))
 
+	// 5. Preview collections, playlists, tags and bookmarks
+	a.reportProgress(PhaseMatchingLibrary, 0, 0)
+	userMap, bookMap := BuildFinalMappings(result, ImportOptions{})
+	result.Library = PreviewLibrary(backup, userMap, bookMap)
+	a.logger.Info("analyzed library data",
+		"collections_ready", result.Library.CollectionsReady,
+		"playlists_ready", result.Library.PlaylistsReady,
+		"tags_ready", result.Library.TagsReady,
+		"bookmarks_ready", result.Library.BookmarksReady,
+	)
+
 	a.logger.Info("ABS analysis complete", "total_duration", time.Since(start))
 
 	return result, nil
@@ -0,0 +1,673 @@
+package abs
+
+import (
+	"cmp"
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultClientTimeout = 60 * time.Second
+
+	// clientPageSize is how many items or sessions are requested per page.
+	clientPageSize = 500
+)
+
+// Client errors.
+var (
+	ErrInvalidServerURL = errors.New("invalid audiobookshelf server URL")
+	ErrUnauthorized     = errors.New("audiobookshelf rejected the API token")
+	ErrNotAdmin         = errors.New("audiobookshelf API token does not belong to an admin")
+)
+
+// Client reads from a running Audiobookshelf server over its REST API, as an
+// alternative to an uploaded backup. Fetch returns the same Backup that Parse
+// does, so analysis and import don't care where the data came from.
+//
+// Only read endpoints are used; nothing on the ABS server is changed.
+type Client struct {
+	http     *http.Client
+	baseURL  string
+	token    string
+	pageSize int
+}
+
+// NewClient creates a client for the ABS server at baseURL. The token is an
+// admin user's API token (ABS shows it under Settings → Users), with or
+// without its "Bearer " prefix.
+func NewClient(baseURL, token string) (*Client, error) {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidServerURL, baseURL)
+	}
+	token = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+	if token == "" {
+		return nil, ErrUnauthorized
+	}
+	return &Client{
+		http:     &http.Client{Timeout: defaultClientTimeout},
+		baseURL:  baseURL,
+		token:    token,
+		pageSize: clientPageSize,
+	}, nil
+}
+
+// BaseURL returns the normalized server URL.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// ServerInfo describes the ABS server and the user the token belongs to.
+type ServerInfo struct {
+	Version  string
+	UserID   string
+	Username string
+	UserType string
+}
+
+// Authorize checks the token and returns who it belongs to. Reading other
+// users' progress and sessions needs an admin, so other tokens are refused
+// with ErrNotAdmin.
+func (c *Client) Authorize(ctx context.Context) (*ServerInfo, error) {
+	var resp struct {
+		User struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+			Type     string `json:"type"`
+		} `json:"user"`
+		ServerSettings struct {
+			Version string `json:"version"`
+		} `json:"serverSettings"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/authorize", &resp); err != nil {
+		return nil, fmt.Errorf("authorize: %w", err)
+	}
+	info := &ServerInfo{
+		Version:  resp.ServerSettings.Version,
+		UserID:   resp.User.ID,
+		Username: resp.User.Username,
+		UserType: resp.User.Type,
+	}
+	if info.UserType != "root" && info.UserType != "admin" {
+		return info, ErrNotAdmin
+	}
+	return info, nil
+}
+
+// FetchOptions controls what Fetch reads.
+type FetchOptions struct {
+	// SessionsSince limits sessions to those updated at or after this time,
+	// for incremental re-syncs. Zero fetches every session.
+	SessionsSince time.Time
+
+	// SkipSessions leaves out listening sessions entirely, for callers that
+	// only need users, books, collections and bookmarks.
+	SkipSessions bool
+}
+
+// Fetch reads libraries, book items, podcasts with their episodes, users
+// with their progress and bookmarks, sessions, collections and the token
+// owner's playlists.
+//
+// ABS only lists a user's own playlists, so other users' playlists need a
+// backup file. Authors and series aren't fetched; analysis works from the
+// names on each item.
+func (c *Client) Fetch(ctx context.Context, opts FetchOptions) (*Backup, error) {
+	start := time.Now()
+	backup := &Backup{Path: c.baseURL}
+
+	if err := c.fetchLibraries(ctx, backup); err != nil {
+		return nil, fmt.Errorf("fetch libraries: %w", err)
+	}
+	for _, lib := range backup.BookLibraries() {
+		if err := c.fetchLibraryItems(ctx, lib.ID, backup); err != nil {
+			return nil, fmt.Errorf("fetch items for library %s: %w", lib.ID, err)
+		}
+	}
+	for _, lib := range backup.PodcastLibraries() {
+		if err := c.fetchPodcasts(ctx, lib.ID, backup); err != nil {
+			return nil, fmt.Errorf("fetch podcasts for library %s: %w", lib.ID, err)
+		}
+	}
+	if err := c.fetchUsers(ctx, backup); err != nil {
+		return nil, fmt.Errorf("fetch users: %w", err)
+	}
+	if !opts.SkipSessions {
+		if err := c.fetchSessions(ctx, opts.SessionsSince, backup); err != nil {
+			return nil, fmt.Errorf("fetch sessions: %w", err)
+		}
+	}
+	if err := c.fetchCollections(ctx, backup); err != nil {
+		return nil, fmt.Errorf("fetch collections: %w", err)
+	}
+	if err := c.fetchPlaylists(ctx, backup); err != nil {
+		return nil, fmt.Errorf("fetch playlists: %w", err)
+	}
+
+	slog.Info("fetched ABS server data",
+		"url", c.baseURL,
+		"summary", backup.Summary(),
+		"duration", time.Since(start),
+	)
+	return backup, nil
+}
+
+func (c *Client) fetchLibraries(ctx context.Context, backup *Backup) error {
+	var resp struct {
+		Libraries []Library `json:"libraries"`
+	}
+	if err := c.get(ctx, "/api/libraries", nil, &resp); err != nil {
+		return err
+	}
+	backup.Libraries = resp.Libraries
+	return nil
+}
+
+// apiItem is a library item as /api/libraries/{id}/items returns it
+// (minified: people are comma-separated names rather than references).
+type apiItem struct {
+	ID        string `json:"id"`
+	LibraryID string `json:"libraryId"`
+	FolderID  string `json:"folderId"`
+	Path      string `json:"path"`
+	RelPath   string `json:"relPath"`
+	IsFile    bool   `json:"isFile"`
+	MediaType string `json:"mediaType"`
+	IsMissing bool   `json:"isMissing"`
+	IsInvalid bool   `json:"isInvalid"`
+	AddedAt   int64  `json:"addedAt"`
+	UpdatedAt int64  `json:"updatedAt"`
+	Media     struct {
+		ID       string `json:"id"` // books.id
+		Metadata struct {
+			Title         string   `json:"title"`
+			Subtitle      string   `json:"subtitle"`
+			AuthorName    string   `json:"authorName"`
+			NarratorName  string   `json:"narratorName"`
+			Genres        []string `json:"genres"`
+			PublishedYear string   `json:"publishedYear"`
+			Publisher     string   `json:"publisher"`
+			Description   string   `json:"description"`
+			ISBN          string   `json:"isbn"`
+			ASIN          string   `json:"asin"`
+			Language      string   `json:"language"`
+			Explicit      bool     `json:"explicit"`
+			Abridged      bool     `json:"abridged"`
+		} `json:"metadata"`
+		CoverPath   string     `json:"coverPath"`
+		Tags        []string   `json:"tags"`
+		Duration    float64    `json:"duration"`
+		Size        int64      `json:"size"`
+		EbookFile   *EbookFile `json:"ebookFile"`   // Full items only
+		EbookFormat string     `json:"ebookFormat"` // Minified items only
+	} `json:"media"`
+}
+
+func (it *apiItem) toLibraryItem() LibraryItem {
+	m := it.Media.Metadata
+	item := LibraryItem{
+		ID:        it.ID,
+		MediaID:   it.Media.ID,
+		LibraryID: it.LibraryID,
+		FolderID:  it.FolderID,
+		Path:      it.Path,
+		RelPath:   it.RelPath,
+		MediaType: it.MediaType,
+		IsFile:    it.IsFile,
+		IsMissing: it.IsMissing,
+		IsInvalid: it.IsInvalid,
+		AddedAt:   it.AddedAt,
+		UpdatedAt: it.UpdatedAt,
+		Media: BookMedia{
+			Metadata: BookMetadata{
+				Title:         m.Title,
+				Subtitle:      m.Subtitle,
+				Authors:       splitNames(m.AuthorName),
+				Narrators:     splitNames(m.NarratorName),
+				Genres:        m.Genres,
+				PublishedYear: m.PublishedYear,
+				Publisher:     m.Publisher,
+				Description:   m.Description,
+				ISBN:          m.ISBN,
+				ASIN:          m.ASIN,
+				Language:      m.Language,
+				Explicit:      m.Explicit,
+				Abridged:      m.Abridged,
+			},
+			CoverPath: it.Media.CoverPath,
+			Tags:      it.Media.Tags,
+			Duration:  it.Media.Duration,
+			Size:      it.Media.Size,
+			EbookFile: it.Media.EbookFile,
+		},
+	}
+	if item.Media.EbookFile == nil && it.Media.EbookFormat != "" {
+		item.Media.EbookFile = &EbookFile{EbookFormat: it.Media.EbookFormat}
+	}
+	if item.MediaType == "" {
+		item.MediaType = mediaTypeBook
+	}
+	return item
+}
+
+// splitNames splits ABS's comma-separated display names, as the backup
+// parser does for authorNamesFirstLast.
+func splitNames(names string) []PersonRef {
+	var refs []PersonRef
+	for name := range strings.SplitSeq(names, ", ") {
+		if name = strings.TrimSpace(name); name != "" {
+			refs = append(refs, PersonRef{Name: name})
+		}
+	}
+	return refs
+}
+
+func (c *Client) fetchLibraryItems(ctx context.Context, libraryID string, backup *Backup) error {
+	for page := 0; ; page++ {
+		var resp struct {
+			Results []apiItem `json:"results"`
+			Total   int       `json:"total"`
+		}
+		query := url.Values{
+			"limit": {strconv.Itoa(c.pageSize)},
+			"page":  {strconv.Itoa(page)},
+		}
+		if err := c.get(ctx, "/api/libraries/"+url.PathEscape(libraryID)+"/items", query, &resp); err != nil {
+			return err
+		}
+		for i := range resp.Results {
+			backup.Items = append(backup.Items, resp.Results[i].toLibraryItem())
+		}
+		if len(resp.Results) < c.pageSize || (page+1)*c.pageSize >= resp.Total {
+			return nil
+		}
+	}
+}
+
+// apiPodcastItem is a podcast library item as /api/items/{id}?expanded=1
+// returns it, with every episode.
+type apiPodcastItem struct {
+	ID        string `json:"id"`
+	LibraryID string `json:"libraryId"`
+	Path      string `json:"path"`
+	Media     struct {
+		ID       string `json:"id"` // podcasts.id
+		Metadata struct {
+			Title       string `json:"title"`
+			Author      string `json:"author"`
+			Description string `json:"description"`
+			FeedURL     string `json:"feedUrl"`
+			ImageURL    string `json:"imageUrl"`
+			Language    string `json:"language"`
+			Explicit    bool   `json:"explicit"`
+		} `json:"metadata"`
+		AutoDownloadEpisodes bool         `json:"autoDownloadEpisodes"`
+		MaxEpisodesToKeep    int          `json:"maxEpisodesToKeep"`
+		Episodes             []apiEpisode `json:"episodes"`
+	} `json:"media"`
+}
+
+// apiEpisode is a podcast episode. ABS keeps the feed's strings for the
+// season, episode number and enclosure length.
+type apiEpisode struct {
+	ID          string `json:"id"`
+	GUID        string `json:"guid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Season      string `json:"season"`
+	Episode     string `json:"episode"`
+	PublishedAt int64  `json:"publishedAt"`
+	Enclosure   struct {
+		URL    string `json:"url"`
+		Type   string `json:"type"`
+		Length any    `json:"length"`
+	} `json:"enclosure"`
+	AudioFile *struct {
+		Duration float64 `json:"duration"`
+		Metadata struct {
+			Path string `json:"path"`
+		} `json:"metadata"`
+	} `json:"audioFile"`
+}
+
+func (it *apiPodcastItem) toPodcast() Podcast {
+	m := it.Media.Metadata
+	p := Podcast{
+		ID:                it.Media.ID,
+		LibraryItemID:     it.ID,
+		LibraryID:         it.LibraryID,
+		Path:              it.Path,
+		Title:             m.Title,
+		Author:            m.Author,
+		Description:       m.Description,
+		FeedURL:           m.FeedURL,
+		ImageURL:          m.ImageURL,
+		Language:          m.Language,
+		Explicit:          m.Explicit,
+		AutoDownload:      it.Media.AutoDownloadEpisodes,
+		MaxEpisodesToKeep: it.Media.MaxEpisodesToKeep,
+	}
+	for _, ae := range it.Media.Episodes {
+		e := PodcastEpisode{
+			ID:            ae.ID,
+			GUID:          ae.GUID,
+			Title:         ae.Title,
+			Description:   ae.Description,
+			PublishedAt:   ae.PublishedAt,
+			EnclosureURL:  ae.Enclosure.URL,
+			EnclosureType: ae.Enclosure.Type,
+		}
+		e.Season, _ = strconv.Atoi(strings.TrimSpace(ae.Season))
+		e.Episode, _ = strconv.Atoi(strings.TrimSpace(ae.Episode))
+		switch n := ae.Enclosure.Length.(type) {
+		case float64:
+			e.EnclosureSize = int64(n)
+		case string:
+			e.EnclosureSize, _ = strconv.ParseInt(strings.TrimSpace(n), 10, 64)
+		}
+		if ae.AudioFile != nil {
+			e.Duration = ae.AudioFile.Duration
+			e.Path = ae.AudioFile.Metadata.Path
+		}
+		p.Episodes = append(p.Episodes, e)
+	}
+	return p
+}
+
+// fetchPodcasts pages through a podcast library, then reads each podcast
+// expanded, since the list leaves out the episodes.
+func (c *Client) fetchPodcasts(ctx context.Context, libraryID string, backup *Backup) error {
+	var itemIDs []string
+	for page := 0; ; page++ {
+		var resp struct {
+			Results []struct {
+				ID string `json:"id"`
+			} `json:"results"`
+			Total int `json:"total"`
+		}
+		query := url.Values{
+			"limit": {strconv.Itoa(c.pageSize)},
+			"page":  {strconv.Itoa(page)},
+		}
+		if err := c.get(ctx, "/api/libraries/"+url.PathEscape(libraryID)+"/items", query, &resp); err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			itemIDs = append(itemIDs, r.ID)
+		}
+		if len(resp.Results) < c.pageSize || (page+1)*c.pageSize >= resp.Total {
+			break
+		}
+	}
+
+	for _, itemID := range itemIDs {
+		var item apiPodcastItem
+		if err := c.get(ctx, "/api/items/"+url.PathEscape(itemID), url.Values{"expanded": {"1"}}, &item); err != nil {
+			return fmt.Errorf("podcast %s: %w", itemID, err)
+		}
+		backup.Podcasts = append(backup.Podcasts, item.toPodcast())
+	}
+	return nil
+}
+
+// fetchUsers lists users, then reads each one for progress and bookmarks,
+// which the list doesn't always include. Both reference libraryItems.id;
+// they are resolved to books.id as the backup parser does. Progress on
+// podcast episodes is kept apart in EpisodeProgress.
+func (c *Client) fetchUsers(ctx context.Context, backup *Backup) error {
+	var list struct {
+		Users []User `json:"users"`
+	}
+	if err := c.get(ctx, "/api/users", nil, &list); err != nil {
+		return err
+	}
+
+	mediaIDs := backup.mediaIDsByItemID()
+	for _, u := range list.Users {
+		var user User
+		if err := c.get(ctx, "/api/users/"+url.PathEscape(u.ID), nil, &user); err != nil {
+			return fmt.Errorf("user %s: %w", u.ID, err)
+		}
+
+		progress := user.Progress[:0]
+		for _, mp := range user.Progress {
+			if mp.MediaItemType == mediaItemTypeEpisode {
+				mp.MediaItemID = cmp.Or(mp.EpisodeID, mp.MediaItemID)
+				user.EpisodeProgress = append(user.EpisodeProgress, mp)
+				continue
+			}
+			if !mp.IsBook() {
+				continue
+			}
+			switch {
+			case mp.MediaItemID != "":
+				mp.LibraryItemID = mp.MediaItemID
+			case mediaIDs[mp.LibraryItemID] != "":
+				mp.LibraryItemID = mediaIDs[mp.LibraryItemID]
+			}
+			progress = append(progress, mp)
+		}
+		user.Progress = progress
+
+		bookmarks := user.Bookmarks[:0]
+		for _, bm := range user.Bookmarks {
+			if bm.LibraryItemID == "" {
+				continue
+			}
+			if mediaID, ok := mediaIDs[bm.LibraryItemID]; ok {
+				bm.LibraryItemID = mediaID
+			}
+			bookmarks = append(bookmarks, bm)
+		}
+		user.Bookmarks = bookmarks
+
+		if user.Type == "" {
+			user.Type = "user"
+		}
+		backup.Users = append(backup.Users, user)
+	}
+	return nil
+}
+
+// apiSession is a playback session as /api/sessions returns it.
+type apiSession struct {
+	ID            string  `json:"id"`
+	UserID        string  `json:"userId"`
+	LibraryID     string  `json:"libraryId"`
+	LibraryItemID string  `json:"libraryItemId"`
+	BookID        string  `json:"bookId"`
+	MediaType     string  `json:"mediaType"`
+	DisplayTitle  string  `json:"displayTitle"`
+	DisplayAuthor string  `json:"displayAuthor"`
+	Duration      float64 `json:"duration"`
+	TimeListening float64 `json:"timeListening"`
+	StartTime     float64 `json:"startTime"`
+	CurrentTime   float64 `json:"currentTime"`
+	Date          string  `json:"date"`
+	DayOfWeek     string  `json:"dayOfWeek"`
+	StartedAt     int64   `json:"startedAt"`
+	UpdatedAt     int64   `json:"updatedAt"`
+}
+
+// fetchSessions pages through sessions newest first and stops at the first
+// one older than since. Session item IDs are resolved to books.id.
+func (c *Client) fetchSessions(ctx context.Context, since time.Time, backup *Backup) error {
+	mediaIDs := backup.mediaIDsByItemID()
+	sinceMs := int64(0)
+	if !since.IsZero() {
+		sinceMs = since.UnixMilli()
+	}
+
+	for page := 0; ; page++ {
+		var resp struct {
+			Sessions []apiSession `json:"sessions"`
+			NumPages int          `json:"numPages"`
+		}
+		query := url.Values{
+			"itemsPerPage": {strconv.Itoa(c.pageSize)},
+			"page":         {strconv.Itoa(page)},
+			"sort":         {"updatedAt"},
+			"desc":         {"1"},
+		}
+		if err := c.get(ctx, "/api/sessions", query, &resp); err != nil {
+			return err
+		}
+
+		for _, as := range resp.Sessions {
+			if as.UpdatedAt < sinceMs {
+				return nil
+			}
+			if as.MediaType != "" && as.MediaType != mediaTypeBook {
+				continue
+			}
+			mediaID := as.BookID
+			if mediaID == "" {
+				mediaID = mediaIDs[as.LibraryItemID]
+			}
+			if mediaID == "" {
+				mediaID = as.LibraryItemID
+			}
+			backup.Sessions = append(backup.Sessions, Session{
+				ID:            as.ID,
+				UserID:        as.UserID,
+				LibraryID:     as.LibraryID,
+				LibraryItemID: mediaID,
+				MediaType:     mediaTypeBook,
+				DisplayTitle:  as.DisplayTitle,
+				DisplayAuthor: as.DisplayAuthor,
+				Duration:      as.TimeListening, // the API's duration is the book's length
+				TimeListening: as.TimeListening,
+				StartTime:     as.StartTime,
+				CurrentTime:   as.CurrentTime,
+				Date:          as.Date,
+				DayOfWeek:     as.DayOfWeek,
+				StartedAt:     as.StartedAt,
+				UpdatedAt:     as.UpdatedAt,
+			})
+		}
+		if page+1 >= resp.NumPages || len(resp.Sessions) == 0 {
+			return nil
+		}
+	}
+}
+
+// apiItemRef is the part of an expanded library item that collections and
+// playlists need.
+type apiItemRef struct {
+	ID    string `json:"id"`
+	Media struct {
+		ID string `json:"id"`
+	} `json:"media"`
+}
+
+func (c *Client) fetchCollections(ctx context.Context, backup *Backup) error {
+	var resp struct {
+		Collections []struct {
+			ID          string       `json:"id"`
+			LibraryID   string       `json:"libraryId"`
+			Name        string       `json:"name"`
+			Description string       `json:"description"`
+			Books       []apiItemRef `json:"books"`
+		} `json:"collections"`
+	}
+	if err := c.get(ctx, "/api/collections", nil, &resp); err != nil {
+		return err
+	}
+	for _, ac := range resp.Collections {
+		col := Collection{ID: ac.ID, LibraryID: ac.LibraryID, Name: ac.Name, Description: ac.Description}
+		for _, b := range ac.Books {
+			if b.Media.ID != "" {
+				col.BookIDs = append(col.BookIDs, b.Media.ID)
+			}
+		}
+		backup.Collections = append(backup.Collections, col)
+	}
+	return nil
+}
+
+func (c *Client) fetchPlaylists(ctx context.Context, backup *Backup) error {
+	var resp struct {
+		Playlists []struct {
+			ID          string `json:"id"`
+			LibraryID   string `json:"libraryId"`
+			UserID      string `json:"userId"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Items       []struct {
+				EpisodeID   string     `json:"episodeId"`
+				LibraryItem apiItemRef `json:"libraryItem"`
+			} `json:"items"`
+		} `json:"playlists"`
+	}
+	if err := c.get(ctx, "/api/playlists", nil, &resp); err != nil {
+		return err
+	}
+	for _, ap := range resp.Playlists {
+		pl := Playlist{ID: ap.ID, LibraryID: ap.LibraryID, UserID: ap.UserID, Name: ap.Name, Description: ap.Description}
+		for _, item := range ap.Items {
+			if item.EpisodeID == "" && item.LibraryItem.Media.ID != "" {
+				pl.BookIDs = append(pl.BookIDs, item.LibraryItem.Media.ID)
+			}
+		}
+		backup.Playlists = append(backup.Playlists, pl)
+	}
+	return nil
+}
+
+// mediaIDsByItemID maps libraryItems.id to books.id for the fetched items.
+func (b *Backup) mediaIDsByItemID() map[string]string {
+	ids := make(map[string]string, len(b.Items))
+	for _, item := range b.Items {
+		if item.MediaID != "" {
+			ids[item.ID] = item.MediaID
+		}
+	}
+	return ids
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return c.do(ctx, http.MethodGet, path, v)
+}
+
+// do sends a request and decodes the JSON response into v.
+func (c *Client) do(ctx context.Context, method, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "ListenUp/1.0")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case resp.StatusCode == http.StatusForbidden:
+		return ErrNotAdmin
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+
+	if err := json.UnmarshalRead(resp.Body, v); err != nil {
+		return fmt.Errorf("%s %s: parse response: %w", method, path, err)
+	}
+	return nil
+}
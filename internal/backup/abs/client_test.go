@@ -0,0 +1,221 @@
+package abs
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/backup/abs/abstest"
+)
+
+func stubABSData() abstest.Data {
+	return abstest.Data{
+		Libraries: []abstest.Library{
+			{ID: "lib-books", Name: "Audiobooks"},
+			{ID: "lib-pods", Name: "Podcasts", MediaType: "podcast"},
+		},
+		Items: []abstest.Item{
+			{ID: "li-1", MediaID: "book-1", LibraryID: "lib-books", Path: "/audiobooks/lib-books/Dune",
+				Title: "Dune", Author: "Frank Herbert", Narrator: "Scott Brick, Orlagh Cassidy", ASIN: "B002V1OF70",
+				Duration: 75600, Tags: []string{"classics"}},
+			{ID: "li-2", MediaID: "book-2", LibraryID: "lib-books", Title: "Hyperion", Author: "Dan Simmons", Duration: 73800},
+		},
+		Podcasts: []abstest.Podcast{
+			{ID: "li-pod", MediaID: "pod-1", LibraryID: "lib-pods", Path: "/podcasts/The Show", Title: "The Show",
+				Author: "Host", FeedURL: "https://example.com/feed.xml", Episodes: []abstest.Episode{
+					{ID: "ep-1", GUID: "guid-1", Title: "Pilot", PublishedAt: 1700000000000, EnclosureURL: "https://cdn.example.com/1.mp3",
+						Size: 2048, Duration: 1800, Path: "/podcasts/The Show/Pilot.mp3"},
+					{ID: "ep-2", Title: "Second", EnclosureURL: "https://cdn.example.com/2.mp3"},
+				}},
+		},
+		Users: []abstest.User{
+			{ID: "u-root", Username: "root", Type: "root",
+				Progress: []abstest.Progress{
+					{LibraryItemID: "li-1", MediaItemID: "book-1", Duration: 75600, CurrentTime: 37800, Progress: 0.5, LastUpdate: 1700000000000},
+					{LibraryItemID: "li-pod", EpisodeID: "ep-1", Duration: 1800, CurrentTime: 1800, IsFinished: true, LastUpdate: 1700000900000},
+				},
+				Bookmarks: []abstest.Bookmark{{LibraryItemID: "li-2", Title: "Shrike", Time: 120}},
+			},
+			{ID: "u-guest", Username: "guest", Email: "guest@example.com", Type: "guest"},
+		},
+		Sessions: []abstest.Session{
+			{ID: "s-old", UserID: "u-root", LibraryItemID: "li-1", BookID: "book-1", TimeListening: 600, StartTime: 0, CurrentTime: 600,
+				StartedAt: 1700000000000, UpdatedAt: 1700000600000},
+			{ID: "s-new", UserID: "u-root", LibraryItemID: "li-2", TimeListening: 300, StartTime: 60, CurrentTime: 360,
+				StartedAt: 1700100000000, UpdatedAt: 1700100300000},
+			{ID: "s-pod", UserID: "u-root", LibraryItemID: "li-pod", MediaType: "podcast", UpdatedAt: 1700100400000},
+		},
+		Collections: []abstest.Collection{
+			{ID: "col-1", LibraryID: "lib-books", Name: "Sci-Fi", ItemIDs: []string{"li-2", "li-1"}},
+		},
+		Playlists: []abstest.Playlist{
+			{ID: "pl-1", LibraryID: "lib-books", UserID: "u-root", Name: "Next up", ItemIDs: []string{"li-1"}},
+		},
+	}
+}
+
+func newStubClient(t *testing.T, server *abstest.Server) *Client {
+	t.Helper()
+	client, err := NewClient(server.URL+"/", "Bearer "+abstest.Token)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestNewClient_Validates(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{"", "abs.local", "ftp://abs.local", "http://"} {
+		if _, err := NewClient(raw, "token"); !errors.Is(err, ErrInvalidServerURL) {
+			t.Errorf("NewClient(%q) error = %v, want ErrInvalidServerURL", raw, err)
+		}
+	}
+	if _, err := NewClient("https://abs.local", " "); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("NewClient() without token error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestClient_Authorize(t *testing.T) {
+	t.Parallel()
+	server := abstest.NewServer(t, stubABSData())
+
+	info, err := newStubClient(t, server).Authorize(t.Context())
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if info.UserID != "u-root" || info.Version == "" {
+		t.Errorf("Authorize() = %+v", info)
+	}
+
+	bad, err := NewClient(server.URL, "wrong")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bad.Authorize(t.Context()); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Authorize() with wrong token error = %v, want ErrUnauthorized", err)
+	}
+
+	// Only admins can read everyone's progress.
+	nonAdmin := abstest.NewServer(t, abstest.Data{Users: []abstest.User{{ID: "u", Type: "user"}}})
+	if _, err := newStubClient(t, nonAdmin).Authorize(t.Context()); !errors.Is(err, ErrNotAdmin) {
+		t.Errorf("Authorize() for non-admin error = %v, want ErrNotAdmin", err)
+	}
+}
+
+func TestClient_Fetch(t *testing.T) {
+	t.Parallel()
+	server := abstest.NewServer(t, stubABSData())
+
+	backup, err := newStubClient(t, server).Fetch(t.Context(), FetchOptions{})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	// Podcasts are kept apart from book items.
+	if len(backup.Libraries) != 2 || len(backup.Items) != 2 || len(backup.Podcasts) != 1 {
+		t.Fatalf("got %d libraries, %d items and %d podcasts", len(backup.Libraries), len(backup.Items), len(backup.Podcasts))
+	}
+	dune := backup.Items[0]
+	if dune.MediaID != "book-1" || !dune.IsValid() || dune.Media.Metadata.ASIN != "B002V1OF70" ||
+		dune.Media.Metadata.PrimaryAuthor() != "Frank Herbert" || len(dune.Media.Metadata.Narrators) != 2 ||
+		dune.Media.DurationMs() != 75600000 || !slices.Equal(dune.Media.Tags, []string{"classics"}) {
+		t.Errorf("unexpected item: %+v", dune)
+	}
+
+	// Progress and bookmarks are keyed by books.id, like a parsed backup.
+	if len(backup.Users) != 2 {
+		t.Fatalf("got %d users", len(backup.Users))
+	}
+	root := backup.Users[0]
+	if len(root.Progress) != 1 || root.Progress[0].LibraryItemID != "book-1" || root.Progress[0].CurrentTime != 37800 {
+		t.Errorf("unexpected progress: %+v", root.Progress)
+	}
+	if len(root.EpisodeProgress) != 1 || root.EpisodeProgress[0].MediaItemID != "ep-1" || !root.EpisodeProgress[0].IsFinished {
+		t.Errorf("unexpected episode progress: %+v", root.EpisodeProgress)
+	}
+	if len(root.Bookmarks) != 1 || root.Bookmarks[0].LibraryItemID != "book-2" {
+		t.Errorf("unexpected bookmarks: %+v", root.Bookmarks)
+	}
+	if backup.Users[1].Email != "guest@example.com" || backup.Users[1].IsImportable() {
+		t.Errorf("unexpected guest: %+v", backup.Users[1])
+	}
+
+	// Podcast sessions are dropped; item IDs resolve to books.id.
+	if len(backup.Sessions) != 2 {
+		t.Fatalf("got sessions %+v", backup.Sessions)
+	}
+	for _, s := range backup.Sessions {
+		if s.ID == "s-new" && (s.LibraryItemID != "book-2" || s.DurationMs() != 300000 || s.StartPositionMs() != 60000) {
+			t.Errorf("unexpected session: %+v", s)
+		}
+	}
+
+	if len(backup.Collections) != 1 || !slices.Equal(backup.Collections[0].BookIDs, []string{"book-2", "book-1"}) {
+		t.Errorf("unexpected collections: %+v", backup.Collections)
+	}
+	if len(backup.Playlists) != 1 || backup.Playlists[0].UserID != "u-root" {
+		t.Errorf("unexpected playlists: %+v", backup.Playlists)
+	}
+	pod := backup.Podcasts[0]
+	if pod.ID != "pod-1" || pod.FeedURL != "https://example.com/feed.xml" || pod.Path != "/podcasts/The Show" || len(pod.Episodes) != 2 {
+		t.Fatalf("unexpected podcast: %+v", pod)
+	}
+	if e := pod.Episodes[0]; e.GUID != "guid-1" || e.EnclosureSize != 2048 || e.DurationMs() != 1800000 || e.Path != "/podcasts/The Show/Pilot.mp3" {
+		t.Errorf("unexpected downloaded episode: %+v", e)
+	}
+	if e := pod.Episodes[1]; e.GUID != "" || e.Path != "" || e.EnclosureURL != "https://cdn.example.com/2.mp3" {
+		t.Errorf("unexpected episode: %+v", e)
+	}
+
+	if backup.RootUserID() != "u-root" {
+		t.Errorf("RootUserID() = %q", backup.RootUserID())
+	}
+}
+
+func TestClient_FetchSessionsSince(t *testing.T) {
+	t.Parallel()
+	server := abstest.NewServer(t, stubABSData())
+	client := newStubClient(t, server)
+	client.pageSize = 1
+
+	since := time.UnixMilli(1700050000000)
+	backup, err := client.Fetch(t.Context(), FetchOptions{SessionsSince: since})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(backup.Sessions) != 1 || backup.Sessions[0].ID != "s-new" {
+		t.Errorf("expected only the session updated since the last sync, got %+v", backup.Sessions)
+	}
+	// Newest first, so paging stops at the first older session.
+	var sessionPages, itemPages int
+	for _, req := range server.Requests() {
+		switch {
+		case strings.HasPrefix(req, "/api/sessions"):
+			sessionPages++
+			if !strings.Contains(req, "sort=updatedAt") {
+				t.Errorf("sessions requested without sorting: %s", req)
+			}
+		case strings.HasPrefix(req, "/api/libraries/lib-books/items"):
+			itemPages++
+		}
+	}
+	if sessionPages != 3 || itemPages != 2 {
+		t.Errorf("got %d session pages and %d item pages, want 3 and 2", sessionPages, itemPages)
+	}
+
+	backup, err = client.Fetch(t.Context(), FetchOptions{SkipSessions: true})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(backup.Sessions) != 0 {
+		t.Errorf("expected no sessions, got %d", len(backup.Sessions))
+	}
+	for _, req := range server.Requests() {
+		if strings.HasPrefix(req, "/api/sessions") {
+			t.Errorf("sessions requested with SkipSessions: %s", req)
+		}
+	}
+}
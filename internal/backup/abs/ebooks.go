@@ -0,0 +1,124 @@
+package abs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// importEbookPositions copies ABS ebook reading positions to the mapped
+// books' companion ebooks. ABS keeps one ebook per item; the ListenUp file
+// with the same name is used, falling back to the first of the right format.
+// A position never replaces a newer one, so importing again is harmless.
+func (im *Importer) importEbookPositions(
+	ctx context.Context,
+	backup *Backup,
+	userMap, bookMap map[string]string,
+	result *ImportResult,
+) error {
+	absEbooks := make(map[string]*EbookFile) // ABS media ID -> ebook
+	for _, item := range backup.BookItems() {
+		if item.Media.EbookFile != nil {
+			absEbooks[item.MediaID] = item.Media.EbookFile
+		}
+	}
+
+	for _, user := range backup.ImportableUsers() {
+		userID, userMapped := userMap[user.ID]
+		for _, mp := range user.Progress {
+			if mp.EbookLocation == "" {
+				continue
+			}
+			bookID, ok := bookMap[mp.LibraryItemID]
+			if !userMapped || !ok {
+				result.EbookPositionsSkipped++
+				continue
+			}
+
+			imported, err := im.importEbookPosition(ctx, userID, bookID, absEbooks[mp.LibraryItemID], mp)
+			if err != nil {
+				return err
+			}
+			if imported {
+				result.EbookPositionsImported++
+			} else {
+				result.EbookPositionsSkipped++
+			}
+		}
+	}
+	return nil
+}
+
+// importEbookPosition saves one ABS ebook position. It reports false when the
+// book has no matching ebook, the location doesn't fit it, or the user has
+// read further along since.
+func (im *Importer) importEbookPosition(ctx context.Context, userID, bookID string, absEbook *EbookFile, mp MediaProgress) (bool, error) {
+	book, err := im.store.GetBookByID(ctx, bookID)
+	if err != nil {
+		return false, nil
+	}
+	file := matchEbookFile(book.EbookFiles, absEbook)
+	if file == nil {
+		return false, nil
+	}
+	if file.Format == domain.EbookFormatEPUB && !domain.IsEPUBCFI(mp.EbookLocation) {
+		return false, nil
+	}
+
+	updatedAt := time.Now()
+	if mp.LastUpdate > 0 {
+		updatedAt = mp.LastUpdateTime()
+	}
+
+	existing, err := im.store.GetEbookPosition(ctx, userID, bookID)
+	switch {
+	case err == nil && !existing.UpdatedAt.Before(updatedAt):
+		return false, nil
+	case err != nil && !errors.Is(err, store.ErrEbookPositionNotFound):
+		return false, fmt.Errorf("get ebook position: %w", err)
+	}
+
+	position := &domain.EbookPosition{
+		UserID:      userID,
+		BookID:      bookID,
+		EbookFileID: file.ID,
+		CFI:         mp.EbookLocation,
+		Progress:    min(max(mp.EbookProgress, 0), 1),
+		UpdatedAt:   updatedAt,
+	}
+	if err := im.store.UpsertEbookPosition(ctx, position); err != nil {
+		return false, fmt.Errorf("save ebook position: %w", err)
+	}
+	if im.events != nil {
+		im.events.Emit(sse.NewEbookPositionUpdatedEvent(position))
+	}
+	return true, nil
+}
+
+// matchEbookFile picks the ListenUp ebook an ABS ebook position belongs to:
+// the file with the same name, else the first of the same format, else the
+// first file. Returns nil when the book has no ebooks.
+func matchEbookFile(files []domain.EbookFileInfo, absEbook *EbookFile) *domain.EbookFileInfo {
+	if len(files) == 0 {
+		return nil
+	}
+	if absEbook != nil {
+		for i := range files {
+			if absEbook.Metadata.Filename != "" && strings.EqualFold(files[i].Filename, absEbook.Metadata.Filename) {
+				return &files[i]
+			}
+		}
+		for i := range files {
+			if strings.EqualFold(files[i].Format, absEbook.EbookFormat) {
+				return &files[i]
+			}
+		}
+	}
+	return &files[0]
+}
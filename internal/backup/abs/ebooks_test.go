@@ -0,0 +1,117 @@
+package abs
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func (m *mockStore) GetEbookPosition(_ context.Context, userID, bookID string) (*domain.EbookPosition, error) {
+	if p, ok := m.ebookPositions[domain.EbookPositionID(userID, bookID)]; ok {
+		return p, nil
+	}
+	return nil, store.ErrEbookPositionNotFound
+}
+
+func (m *mockStore) UpsertEbookPosition(_ context.Context, p *domain.EbookPosition) error {
+	m.ebookPositions[domain.EbookPositionID(p.UserID, p.BookID)] = p
+	return nil
+}
+
+func ebookBackup() *Backup {
+	var ebook EbookFile
+	ebook.EbookFormat = "epub"
+	ebook.Metadata.Filename = "Dune.epub"
+
+	return &Backup{
+		Items: []LibraryItem{
+			{ID: "li-1", MediaID: "book-1", MediaType: mediaTypeBook, Media: BookMedia{EbookFile: &ebook}},
+			{ID: "li-2", MediaID: "book-2", MediaType: mediaTypeBook},
+		},
+		Users: []User{
+			{ID: "abs-root", Type: "root", Progress: []MediaProgress{
+				{LibraryItemID: "book-1", EbookLocation: "epubcfi(/6/10!/4/2/1:0)", EbookProgress: 0.4, LastUpdate: 1700000000000},
+				{LibraryItemID: "book-2", EbookLocation: "epubcfi(/6/2)"}, // No ebook in ListenUp
+				{LibraryItemID: "book-1", CurrentTime: 600},               // Audio only
+			}},
+			{ID: "abs-bob", Type: "user", Progress: []MediaProgress{
+				{LibraryItemID: "book-1", EbookLocation: "epubcfi(/6/4)"}, // Unmapped user
+			}},
+		},
+	}
+}
+
+func TestImportEbookPositions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ms := newMockStore()
+	ms.books = map[string]*domain.Book{
+		"lu-book-1": {
+			Syncable: domain.Syncable{ID: "lu-book-1"},
+			EbookFiles: []domain.EbookFileInfo{
+				{ID: "eb-pdf", Filename: "Dune.pdf", Format: domain.EbookFormatPDF},
+				{ID: "eb-epub", Filename: "Dune.epub", Format: domain.EbookFormatEPUB},
+			},
+		},
+		"lu-book-2": {Syncable: domain.Syncable{ID: "lu-book-2"}},
+	}
+	im := NewImporter(ms, nil, slog.New(slog.DiscardHandler))
+
+	userMap := map[string]string{"abs-root": "lu-root"}
+	bookMap := map[string]string{"book-1": "lu-book-1", "book-2": "lu-book-2"}
+	opts := ImportOptions{ImportEbookPositions: true}
+
+	result := &ImportResult{}
+	if err := im.ImportLibrary(ctx, ebookBackup(), userMap, bookMap, opts, result); err != nil {
+		t.Fatalf("ImportLibrary: %v", err)
+	}
+	if result.EbookPositionsImported != 1 || result.EbookPositionsSkipped != 2 {
+		t.Errorf("imported %d skipped %d, want 1 and 2", result.EbookPositionsImported, result.EbookPositionsSkipped)
+	}
+
+	pos := ms.ebookPositions[domain.EbookPositionID("lu-root", "lu-book-1")]
+	if pos == nil {
+		t.Fatal("expected an ebook position for lu-book-1")
+	}
+	if pos.EbookFileID != "eb-epub" || pos.CFI != "epubcfi(/6/10!/4/2/1:0)" || pos.Progress != 0.4 {
+		t.Errorf("position = %+v", pos)
+	}
+	if pos.UpdatedAt.UnixMilli() != 1700000000000 {
+		t.Errorf("updated at = %v, want the ABS last update", pos.UpdatedAt)
+	}
+
+	// A newer position read in ListenUp is kept.
+	pos.CFI = "epubcfi(/6/20)"
+	pos.UpdatedAt = pos.UpdatedAt.Add(1)
+	again := &ImportResult{}
+	if err := im.ImportLibrary(ctx, ebookBackup(), userMap, bookMap, opts, again); err != nil {
+		t.Fatalf("ImportLibrary (again): %v", err)
+	}
+	if again.EbookPositionsImported != 0 || pos.CFI != "epubcfi(/6/20)" {
+		t.Errorf("second run overwrote a newer position: %+v", again)
+	}
+}
+
+func TestMatchEbookFile(t *testing.T) {
+	t.Parallel()
+	files := []domain.EbookFileInfo{
+		{ID: "eb-1", Filename: "Book.pdf", Format: domain.EbookFormatPDF},
+		{ID: "eb-2", Filename: "Book.epub", Format: domain.EbookFormatEPUB},
+	}
+	var byFormat EbookFile
+	byFormat.EbookFormat = "epub"
+	byFormat.Metadata.Filename = "Renamed.epub"
+
+	if got := matchEbookFile(files, &byFormat); got == nil || got.ID != "eb-2" {
+		t.Errorf("match by format = %+v, want eb-2", got)
+	}
+	if got := matchEbookFile(files, nil); got == nil || got.ID != "eb-1" {
+		t.Errorf("without ABS ebook = %+v, want the first file", got)
+	}
+	if got := matchEbookFile(nil, &byFormat); got != nil {
+		t.Errorf("no files = %+v, want nil", got)
+	}
+}
@@ -44,7 +44,7 @@ func NewImporter(s store.Store, events EventEmitter, logger *slog.Logger) *Impor
 // Import executes the import using the provided mappings.
 // The mappings should come from BuildFinalMappings after admin review.
 //
-//nolint:gocyclo // Sequential phases (users, books, sessions, progress) with rollups; flatter than helpers.
+//nolint:gocyclo // Sequential phases (sessions, progress, library data) with rollups; flatter than helpers.
 func (im *Importer) Import(
 	ctx context.Context,
 	backup *Backup,
@@ -116,7 +116,12 @@ func (im *Importer) Import(
 		}
 	}
 
-	// 5. Record affected users
+	// 5. Collections, playlists, tags and bookmarks
+	if err := im.ImportLibrary(ctx, backup, userMap, bookMap, opts, result); err != nil {
+		return result, fmt.Errorf("import library data: %w", err)
+	}
+
+	// 6. Record affected users
 	for userID := range affectedUsers {
 		result.AffectedUserIDs = append(result.AffectedUserIDs, userID)
 	}
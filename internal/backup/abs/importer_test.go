@@ -14,10 +14,31 @@ type mockStore struct {
 	store.Store
 	states map[string]*domain.PlaybackState
 	books  map[string]*domain.Book // optional: set to test secondary near-complete check
+
+	// Library data (see library_test.go)
+	shelves   []*domain.Shelf
+	tags      map[string]*domain.Tag // slug -> tag
+	bookTags  map[string][]string    // book ID -> tag IDs
+	bookmarks []*domain.Bookmark
+
+	// Podcasts (see podcasts_test.go)
+	podcasts      []*domain.Podcast
+	episodes      []*domain.PodcastEpisode
+	episodeStates map[string]*domain.PlaybackState
+
+	// Ebook positions (see ebooks_test.go)
+	ebookPositions map[string]*domain.EbookPosition
 }
 
 func newMockStore() *mockStore {
-	return &mockStore{states: make(map[string]*domain.PlaybackState)}
+	return &mockStore{
+		states:   make(map[string]*domain.PlaybackState),
+		tags:     make(map[string]*domain.Tag),
+		bookTags: make(map[string][]string),
+
+		episodeStates:  make(map[string]*domain.PlaybackState),
+		ebookPositions: make(map[string]*domain.EbookPosition),
+	}
 }
 
 func (m *mockStore) GetState(_ context.Context, userID, bookID string) (*domain.PlaybackState, error) {
@@ -0,0 +1,395 @@
+package abs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/util"
+)
+
+// PreviewLibrary shows what importing collections, playlists, tags and
+// bookmarks would do under the given mappings. It is read-only, so the admin
+// review screens can recompute it after every mapping change.
+//
+// Book mappings are keyed by ABS media ID (books.id), as for sessions.
+func PreviewLibrary(backup *Backup, userMap, bookMap map[string]string) *LibraryPreview {
+	preview := &LibraryPreview{
+		Collections: []ShelfPreview{},
+		Playlists:   []ShelfPreview{},
+		Tags:        []TagPreview{},
+		Bookmarks:   []BookmarkPreview{},
+	}
+
+	rootID := backup.RootUserID()
+	for _, c := range backup.Collections {
+		p := previewShelf(c.ID, c.Name, rootID, c.BookIDs, userMap, bookMap)
+		if p.IsReady() {
+			preview.CollectionsReady++
+		}
+		preview.Collections = append(preview.Collections, p)
+	}
+
+	for _, pl := range backup.Playlists {
+		p := previewShelf(pl.ID, pl.Name, pl.UserID, pl.BookIDs, userMap, bookMap)
+		if p.IsReady() {
+			preview.PlaylistsReady++
+		}
+		preview.Playlists = append(preview.Playlists, p)
+	}
+
+	tagIndex := make(map[string]int) // slug -> index in preview.Tags
+	for _, item := range backup.BookItems() {
+		_, mapped := bookMap[item.MediaID]
+		seen := make(map[string]bool)
+		for _, raw := range item.Media.Tags {
+			slug := util.NormalizeTagSlug(raw)
+			if slug == "" || seen[slug] {
+				continue
+			}
+			seen[slug] = true
+
+			i, ok := tagIndex[slug]
+			if !ok {
+				i = len(preview.Tags)
+				tagIndex[slug] = i
+				preview.Tags = append(preview.Tags, TagPreview{Name: raw, Slug: slug})
+			}
+			preview.Tags[i].TotalBooks++
+			if mapped {
+				if preview.Tags[i].MappedBooks == 0 {
+					preview.TagsReady++
+				}
+				preview.Tags[i].MappedBooks++
+			}
+		}
+	}
+
+	for _, user := range backup.ImportableUsers() {
+		if len(user.Bookmarks) == 0 {
+			continue
+		}
+		p := BookmarkPreview{
+			ABSUserID:      user.ID,
+			ABSUsername:    user.Username,
+			ListenUpUserID: userMap[user.ID],
+			Total:          len(user.Bookmarks),
+		}
+		if p.ListenUpUserID != "" {
+			for _, bm := range user.Bookmarks {
+				if _, ok := bookMap[bm.LibraryItemID]; ok {
+					p.Ready++
+				}
+			}
+		}
+		preview.TotalBookmarks += p.Total
+		preview.BookmarksReady += p.Ready
+		preview.Bookmarks = append(preview.Bookmarks, p)
+	}
+
+	return preview
+}
+
+func previewShelf(absID, name, absUserID string, absBookIDs []string, userMap, bookMap map[string]string) ShelfPreview {
+	return ShelfPreview{
+		ABSID:          absID,
+		Name:           name,
+		ABSUserID:      absUserID,
+		ListenUpUserID: userMap[absUserID],
+		TotalBooks:     len(absBookIDs),
+		MappedBooks:    len(mapBookIDs(absBookIDs, bookMap)),
+	}
+}
+
+// mapBookIDs translates ABS book IDs to ListenUp IDs, keeping order and
+// dropping unmapped books and duplicates (two ABS items can map to one book).
+func mapBookIDs(absBookIDs []string, bookMap map[string]string) []string {
+	ids := make([]string, 0, len(absBookIDs))
+	seen := make(map[string]bool, len(absBookIDs))
+	for _, absID := range absBookIDs {
+		bookID, ok := bookMap[absID]
+		if !ok || seen[bookID] {
+			continue
+		}
+		seen[bookID] = true
+		ids = append(ids, bookID)
+	}
+	return ids
+}
+
+// ImportLibrary imports collections and playlists as shelves, item tags as
+// book tags, user bookmarks and ebook positions. It is safe to run again
+// after more users or books have been mapped: shelves are matched by owner
+// and name, bookmarks by book and position, tags are only ever added and
+// ebook positions only move to newer ones.
+func (im *Importer) ImportLibrary(
+	ctx context.Context,
+	backup *Backup,
+	userMap, bookMap map[string]string,
+	opts ImportOptions,
+	result *ImportResult,
+) error {
+	if opts.ImportShelves {
+		if err := im.importShelves(ctx, backup, userMap, bookMap, result); err != nil {
+			return fmt.Errorf("import shelves: %w", err)
+		}
+	}
+	if opts.ImportTags {
+		if err := im.importTags(ctx, backup, bookMap, result); err != nil {
+			return fmt.Errorf("import tags: %w", err)
+		}
+	}
+	if opts.ImportBookmarks {
+		if err := im.importBookmarks(ctx, backup, userMap, bookMap, result); err != nil {
+			return fmt.Errorf("import bookmarks: %w", err)
+		}
+	}
+	if opts.ImportEbookPositions {
+		if err := im.importEbookPositions(ctx, backup, userMap, bookMap, result); err != nil {
+			return fmt.Errorf("import ebook positions: %w", err)
+		}
+	}
+
+	im.logger.Info("imported ABS library data",
+		"shelves_created", result.ShelvesCreated,
+		"shelves_skipped", result.ShelvesSkipped,
+		"tags_created", result.TagsCreated,
+		"book_tags_applied", result.BookTagsApplied,
+		"bookmarks_imported", result.BookmarksImported,
+		"bookmarks_skipped", result.BookmarksSkipped,
+		"ebook_positions_imported", result.EbookPositionsImported,
+	)
+	return nil
+}
+
+// importShelves creates a shelf per collection and playlist, keeping ABS order.
+// Collections have no owner in ABS and go to the mapped root user.
+func (im *Importer) importShelves(
+	ctx context.Context,
+	backup *Backup,
+	userMap, bookMap map[string]string,
+	result *ImportResult,
+) error {
+	shelfNames := make(map[string]map[string]bool) // ListenUp user ID -> existing shelf names
+
+	create := func(name, description, absUserID string, absBookIDs []string) error {
+		ownerID, ok := userMap[absUserID]
+		bookIDs := mapBookIDs(absBookIDs, bookMap)
+		if !ok || name == "" || len(bookIDs) == 0 {
+			result.ShelvesSkipped++
+			return nil
+		}
+
+		names, ok := shelfNames[ownerID]
+		if !ok {
+			existing, err := im.store.ListShelvesByOwner(ctx, ownerID)
+			if err != nil {
+				return fmt.Errorf("list shelves for %s: %w", ownerID, err)
+			}
+			names = make(map[string]bool, len(existing))
+			for _, shelf := range existing {
+				names[shelf.Name] = true
+			}
+			shelfNames[ownerID] = names
+		}
+		if names[name] {
+			result.ShelvesSkipped++
+			return nil
+		}
+
+		shelfID, err := id.Generate("shelf")
+		if err != nil {
+			return fmt.Errorf("generate shelf ID: %w", err)
+		}
+		now := time.Now()
+		shelf := &domain.Shelf{
+			ID:          shelfID,
+			OwnerID:     ownerID,
+			Name:        name,
+			Description: description,
+			BookIDs:     bookIDs,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := im.store.CreateShelf(ctx, shelf); err != nil {
+			im.logger.Warn("failed to create shelf from ABS",
+				"name", name,
+				"owner_id", ownerID,
+				"error", err,
+			)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to create shelf %q: %v", name, err))
+			return nil
+		}
+		names[name] = true
+		result.ShelvesCreated++
+
+		if im.events != nil {
+			displayName := "Unknown"
+			if owner, err := im.store.GetUser(ctx, ownerID); err == nil {
+				displayName = owner.Name()
+			}
+			im.events.Emit(sse.NewShelfCreatedEvent(shelf, displayName, defaultShelfAvatarColor))
+		}
+		return nil
+	}
+
+	rootID := backup.RootUserID()
+	for _, c := range backup.Collections {
+		if err := create(c.Name, c.Description, rootID, c.BookIDs); err != nil {
+			return err
+		}
+	}
+	for _, p := range backup.Playlists {
+		if err := create(p.Name, p.Description, p.UserID, p.BookIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultShelfAvatarColor matches the owner color ShelfService sends.
+const defaultShelfAvatarColor = "#6B7280"
+
+// importTags applies ABS item tags to the mapped ListenUp books.
+func (im *Importer) importTags(
+	ctx context.Context,
+	backup *Backup,
+	bookMap map[string]string,
+	result *ImportResult,
+) error {
+	tags := make(map[string]*domain.Tag) // slug -> tag
+
+	for _, item := range backup.BookItems() {
+		bookID, ok := bookMap[item.MediaID]
+		if !ok || len(item.Media.Tags) == 0 {
+			continue
+		}
+
+		existing, err := im.store.GetTagIDsForBook(ctx, bookID)
+		if err != nil {
+			return fmt.Errorf("get tags for book %s: %w", bookID, err)
+		}
+		has := make(map[string]bool, len(existing))
+		for _, tagID := range existing {
+			has[tagID] = true
+		}
+
+		tagged := false
+		for _, raw := range item.Media.Tags {
+			slug := util.NormalizeTagSlug(raw)
+			if slug == "" {
+				continue
+			}
+
+			tag, ok := tags[slug]
+			if !ok {
+				var created bool
+				tag, created, err = im.store.FindOrCreateTagBySlug(ctx, slug)
+				if err != nil {
+					return fmt.Errorf("find or create tag %q: %w", slug, err)
+				}
+				tags[slug] = tag
+				if created {
+					result.TagsCreated++
+					if im.events != nil {
+						im.events.Emit(sse.NewTagCreatedEvent(tag))
+					}
+				}
+			}
+			if has[tag.ID] {
+				continue
+			}
+
+			if err := im.store.AddTagToBook(ctx, bookID, tag.ID); err != nil {
+				im.logger.Warn("failed to tag book from ABS",
+					"book_id", bookID,
+					"tag", slug,
+					"error", err,
+				)
+				continue
+			}
+			has[tag.ID] = true
+			tagged = true
+			result.BookTagsApplied++
+			if im.events != nil {
+				im.events.Emit(sse.NewBookTagAddedEvent(bookID, tag))
+			}
+		}
+		if tagged {
+			result.TaggedBookIDs = append(result.TaggedBookIDs, bookID)
+		}
+	}
+	return nil
+}
+
+// importBookmarks copies ABS bookmarks into the user's ListenUp bookmarks.
+func (im *Importer) importBookmarks(
+	ctx context.Context,
+	backup *Backup,
+	userMap, bookMap map[string]string,
+	result *ImportResult,
+) error {
+	for _, user := range backup.ImportableUsers() {
+		if len(user.Bookmarks) == 0 {
+			continue
+		}
+		userID, ok := userMap[user.ID]
+		if !ok {
+			result.BookmarksSkipped += len(user.Bookmarks)
+			continue
+		}
+
+		existing, err := im.store.ListBookmarksForUser(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("list bookmarks for %s: %w", userID, err)
+		}
+		have := make(map[string]bool, len(existing))
+		for _, b := range existing {
+			have[fmt.Sprintf("%s:%d", b.BookID, b.PositionMs)] = true
+		}
+
+		for _, bm := range user.Bookmarks {
+			bookID, ok := bookMap[bm.LibraryItemID]
+			key := fmt.Sprintf("%s:%d", bookID, bm.PositionMs())
+			if !ok || have[key] {
+				result.BookmarksSkipped++
+				continue
+			}
+
+			bookmarkID, err := id.Generate("bm")
+			if err != nil {
+				return fmt.Errorf("generate bookmark ID: %w", err)
+			}
+			bookmark := &domain.Bookmark{
+				Syncable:   domain.Syncable{ID: bookmarkID},
+				UserID:     userID,
+				BookID:     bookID,
+				PositionMs: bm.PositionMs(),
+				Title:      bm.Title,
+			}
+			bookmark.InitTimestamps()
+			if bm.CreatedAt > 0 {
+				bookmark.CreatedAt = time.UnixMilli(bm.CreatedAt)
+			}
+
+			if err := im.store.CreateBookmark(ctx, bookmark); err != nil {
+				im.logger.Warn("failed to create bookmark from ABS",
+					"user_id", userID,
+					"book_id", bookID,
+					"error", err,
+				)
+				result.BookmarksSkipped++
+				continue
+			}
+			have[key] = true
+			result.BookmarksImported++
+			if im.events != nil {
+				im.events.Emit(sse.NewBookmarkCreatedEvent(bookmark))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package abs
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+func (m *mockStore) GetUser(_ context.Context, id string) (*domain.User, error) {
+	return &domain.User{Syncable: domain.Syncable{ID: id}, DisplayName: "User " + id}, nil
+}
+
+func (m *mockStore) ListShelvesByOwner(_ context.Context, ownerID string) ([]*domain.Shelf, error) {
+	var shelves []*domain.Shelf
+	for _, s := range m.shelves {
+		if s.OwnerID == ownerID {
+			shelves = append(shelves, s)
+		}
+	}
+	return shelves, nil
+}
+
+func (m *mockStore) CreateShelf(_ context.Context, shelf *domain.Shelf) error {
+	m.shelves = append(m.shelves, shelf)
+	return nil
+}
+
+func (m *mockStore) FindOrCreateTagBySlug(_ context.Context, slug string) (*domain.Tag, bool, error) {
+	if t, ok := m.tags[slug]; ok {
+		return t, false, nil
+	}
+	t := &domain.Tag{ID: "tag-" + slug, Slug: slug}
+	m.tags[slug] = t
+	return t, true, nil
+}
+
+func (m *mockStore) GetTagIDsForBook(_ context.Context, bookID string) ([]string, error) {
+	return m.bookTags[bookID], nil
+}
+
+func (m *mockStore) AddTagToBook(_ context.Context, bookID, tagID string) error {
+	if !slices.Contains(m.bookTags[bookID], tagID) {
+		m.bookTags[bookID] = append(m.bookTags[bookID], tagID)
+	}
+	return nil
+}
+
+func (m *mockStore) ListBookmarksForUser(_ context.Context, userID string) ([]*domain.Bookmark, error) {
+	var bookmarks []*domain.Bookmark
+	for _, b := range m.bookmarks {
+		if b.UserID == userID {
+			bookmarks = append(bookmarks, b)
+		}
+	}
+	return bookmarks, nil
+}
+
+func (m *mockStore) CreateBookmark(_ context.Context, bookmark *domain.Bookmark) error {
+	m.bookmarks = append(m.bookmarks, bookmark)
+	return nil
+}
+
+// libraryBackup has a root user, a member and a guest; three books of which
+// two are mapped below; one collection and two playlists.
+func libraryBackup() *Backup {
+	book := func(mediaID string, tags ...string) LibraryItem {
+		return LibraryItem{ID: "li-" + mediaID, MediaID: mediaID, MediaType: mediaTypeBook, Media: BookMedia{Tags: tags}}
+	}
+	return &Backup{
+		Users: []User{
+			{ID: "abs-root", Username: "root", Type: "root", Bookmarks: []Bookmark{
+				{LibraryItemID: "b1", Title: "Great line", Time: 61.5},
+			}},
+			{ID: "abs-alice", Username: "alice", Type: "user", Bookmarks: []Bookmark{
+				{LibraryItemID: "b2", Title: "Chapter 3", Time: 900, CreatedAt: 1700000000000},
+				{LibraryItemID: "b3", Title: "Unmapped book", Time: 10},
+			}},
+			{ID: "abs-guest", Username: "guest", Type: "guest", Bookmarks: []Bookmark{
+				{LibraryItemID: "b1", Time: 5},
+			}},
+		},
+		Items: []LibraryItem{
+			book("b1", "Slow Burn", "favorites"),
+			book("b2", "slow_burn"),
+			book("b3", "Favorites", "DNF"),
+		},
+		Collections: []Collection{
+			{ID: "c1", Name: "Staff Picks", BookIDs: []string{"b2", "b3", "b1"}},
+		},
+		Playlists: []Playlist{
+			{ID: "p1", UserID: "abs-alice", Name: "Road Trip", BookIDs: []string{"b1", "b2"}},
+			{ID: "p2", UserID: "abs-bob", Name: "Unmapped owner", BookIDs: []string{"b1"}},
+		},
+	}
+}
+
+var (
+	libraryUserMap = map[string]string{"abs-root": "lu-root", "abs-alice": "lu-alice"}
+	libraryBookMap = map[string]string{"b1": "book-1", "b2": "book-2"}
+)
+
+func TestPreviewLibrary(t *testing.T) {
+	t.Parallel()
+
+	p := PreviewLibrary(libraryBackup(), libraryUserMap, libraryBookMap)
+
+	if len(p.Collections) != 1 || p.CollectionsReady != 1 {
+		t.Fatalf("collections = %+v, ready %d", p.Collections, p.CollectionsReady)
+	}
+	c := p.Collections[0]
+	if c.ABSUserID != "abs-root" || c.ListenUpUserID != "lu-root" || c.TotalBooks != 3 || c.MappedBooks != 2 {
+		t.Errorf("collection preview = %+v", c)
+	}
+
+	if len(p.Playlists) != 2 || p.PlaylistsReady != 1 {
+		t.Errorf("playlists = %+v, ready %d", p.Playlists, p.PlaylistsReady)
+	}
+
+	// "Slow Burn" and "slow_burn" merge; "DNF" is only on an unmapped book.
+	want := map[string][2]int{"slow-burn": {2, 2}, "favorites": {2, 1}, "dnf": {1, 0}}
+	if len(p.Tags) != len(want) {
+		t.Fatalf("tags = %+v", p.Tags)
+	}
+	for _, tag := range p.Tags {
+		if w := want[tag.Slug]; tag.TotalBooks != w[0] || tag.MappedBooks != w[1] {
+			t.Errorf("tag %s: total %d mapped %d, want %v", tag.Slug, tag.TotalBooks, tag.MappedBooks, w)
+		}
+	}
+	if p.TagsReady != 2 {
+		t.Errorf("TagsReady = %d, want 2", p.TagsReady)
+	}
+
+	// Guests aren't importable, so their bookmarks aren't counted.
+	if p.TotalBookmarks != 3 || p.BookmarksReady != 2 || len(p.Bookmarks) != 2 {
+		t.Errorf("bookmarks: total %d ready %d users %d", p.TotalBookmarks, p.BookmarksReady, len(p.Bookmarks))
+	}
+}
+
+func TestImportLibrary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ms := newMockStore()
+	im := NewImporter(ms, nil, slog.New(slog.DiscardHandler))
+	opts := DefaultImportOptions()
+
+	result := &ImportResult{}
+	if err := im.ImportLibrary(ctx, libraryBackup(), libraryUserMap, libraryBookMap, opts, result); err != nil {
+		t.Fatalf("ImportLibrary: %v", err)
+	}
+
+	if result.ShelvesCreated != 2 || result.ShelvesSkipped != 1 {
+		t.Errorf("shelves created %d skipped %d, want 2 and 1", result.ShelvesCreated, result.ShelvesSkipped)
+	}
+	shelves := map[string]*domain.Shelf{}
+	for _, s := range ms.shelves {
+		shelves[s.Name] = s
+	}
+	if s := shelves["Staff Picks"]; s == nil || s.OwnerID != "lu-root" || !slices.Equal(s.BookIDs, []string{"book-2", "book-1"}) {
+		t.Errorf("collection shelf = %+v", s)
+	}
+	if s := shelves["Road Trip"]; s == nil || s.OwnerID != "lu-alice" || !slices.Equal(s.BookIDs, []string{"book-1", "book-2"}) {
+		t.Errorf("playlist shelf = %+v", s)
+	}
+
+	if result.TagsCreated != 2 || result.BookTagsApplied != 3 {
+		t.Errorf("tags created %d applied %d, want 2 and 3", result.TagsCreated, result.BookTagsApplied)
+	}
+	if !slices.Equal(ms.bookTags["book-1"], []string{"tag-slow-burn", "tag-favorites"}) {
+		t.Errorf("book-1 tags = %v", ms.bookTags["book-1"])
+	}
+	if len(result.TaggedBookIDs) != 2 {
+		t.Errorf("TaggedBookIDs = %v", result.TaggedBookIDs)
+	}
+
+	if result.BookmarksImported != 2 || result.BookmarksSkipped != 1 {
+		t.Errorf("bookmarks imported %d skipped %d, want 2 and 1", result.BookmarksImported, result.BookmarksSkipped)
+	}
+	for _, b := range ms.bookmarks {
+		if b.UserID == "lu-alice" && (b.BookID != "book-2" || b.PositionMs != 900000 || b.Title != "Chapter 3" || b.CreatedAt.UnixMilli() != 1700000000000) {
+			t.Errorf("alice's bookmark = %+v", b)
+		}
+	}
+
+	// Running again creates nothing new.
+	again := &ImportResult{}
+	if err := im.ImportLibrary(ctx, libraryBackup(), libraryUserMap, libraryBookMap, opts, again); err != nil {
+		t.Fatalf("ImportLibrary (again): %v", err)
+	}
+	if again.ShelvesCreated != 0 || again.TagsCreated != 0 || again.BookTagsApplied != 0 || again.BookmarksImported != 0 {
+		t.Errorf("second run = %+v", again)
+	}
+	if len(ms.shelves) != 2 || len(ms.bookmarks) != 2 {
+		t.Errorf("after second run: %d shelves, %d bookmarks", len(ms.shelves), len(ms.bookmarks))
+	}
+}
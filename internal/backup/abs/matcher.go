@@ -1,9 +1,11 @@
 package abs
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -211,12 +213,7 @@ func (m *Matcher) suggestUsers(ctx context.Context, absUser *User) []UserSuggest
 
 // PreloadBooks loads all ListenUp books into memory for fast matching.
 func (m *Matcher) PreloadBooks(ctx context.Context) {
-	m.bookCache = &BookCache{
-		byASIN:  make(map[string]*CachedBook),
-		byISBN:  make(map[string]*CachedBook),
-		byPath:  make(map[string]*CachedBook),
-		byTitle: make(map[string][]*CachedBook),
-	}
+	m.bookCache = newBookCache()
 
 	// Load all books
 	books, err := m.store.ListAllBooks(ctx)
@@ -244,28 +241,58 @@ func (m *Matcher) PreloadBooks(ctx context.Context) {
 			Authors:       authorsByBook[book.ID],
 		}
 
-		m.bookCache.all = append(m.bookCache.all, cached)
+		m.bookCache.add(cached)
+	}
 
-		// Index by ASIN
-		if cached.ASIN != "" {
-			m.bookCache.byASIN[cached.ASIN] = cached
-		}
+	m.logger.Info("book cache populated", "total", len(m.bookCache.all))
+}
 
-		// Index by ISBN
-		if cached.ISBN != "" {
-			m.bookCache.byISBN[cached.ISBN] = cached
+// RestrictBooks drops every book not in allowed from the pre-loaded cache,
+// so that later matches only find books a particular user can see.
+func (m *Matcher) RestrictBooks(allowed map[string]bool) {
+	all := m.bookCache.all
+	m.bookCache = newBookCache()
+	for _, cached := range all {
+		if allowed[cached.ID] {
+			m.bookCache.add(cached)
 		}
+	}
+}
+
+// newBookCache creates an empty book cache.
+func newBookCache() *BookCache {
+	return &BookCache{
+		byASIN:  make(map[string]*CachedBook),
+		byISBN:  make(map[string]*CachedBook),
+		byPath:  make(map[string]*CachedBook),
+		byTitle: make(map[string][]*CachedBook),
+	}
+}
+
+// add indexes a book in the cache.
+func (c *BookCache) add(cached *CachedBook) {
+	c.all = append(c.all, cached)
+
+	// Index by ASIN
+	if cached.ASIN != "" {
+		c.byASIN[cached.ASIN] = cached
+	}
 
-		// Index by path
-		if cached.Path != "" {
-			m.bookCache.byPath[cached.Path] = cached
+	// Index by ISBN, both as stored and without hyphens or spaces
+	if cached.ISBN != "" {
+		c.byISBN[cached.ISBN] = cached
+		if norm := normalizeISBN(cached.ISBN); norm != "" {
+			c.byISBN[norm] = cached
 		}
+	}
 
-		// Index by normalized title (allows multiple books with same title)
-		m.bookCache.byTitle[cached.NormTitle] = append(m.bookCache.byTitle[cached.NormTitle], cached)
+	// Index by path
+	if cached.Path != "" {
+		c.byPath[cached.Path] = cached
 	}
 
-	m.logger.Info("book cache populated", "total", len(m.bookCache.all))
+	// Index by normalized title (allows multiple books with same title)
+	c.byTitle[cached.NormTitle] = append(c.byTitle[cached.NormTitle], cached)
 }
 
 // MatchBookFast matches using the pre-loaded cache (no DB calls).
@@ -387,14 +414,24 @@ func (m *Matcher) fuzzyMatchBookFast(absItem *LibraryItem) *BookMatch {
 
 // hasCommonAuthorFast checks if cached authors match ABS authors.
 func (m *Matcher) hasCommonAuthorFast(cachedAuthors []string, absAuthors []PersonRef) bool {
-	if len(absAuthors) == 0 {
+	names := make([]string, len(absAuthors))
+	for i, author := range absAuthors {
+		names[i] = author.Name
+	}
+	return hasCommonAuthorName(cachedAuthors, names)
+}
+
+// hasCommonAuthorName checks if any cached author is similar to any of the
+// given author names. An empty name list matches everything.
+func hasCommonAuthorName(cachedAuthors, names []string) bool {
+	if len(names) == 0 {
 		return true
 	}
 
 	for _, cachedAuthor := range cachedAuthors {
 		cachedNorm := normalizeString(cachedAuthor)
-		for _, absAuthor := range absAuthors {
-			if stringSimilarity(cachedNorm, normalizeString(absAuthor.Name)) >= 0.85 {
+		for _, name := range names {
+			if stringSimilarity(cachedNorm, normalizeString(name)) >= 0.85 {
 				return true
 			}
 		}
@@ -450,6 +487,99 @@ func (m *Matcher) suggestBooksFast(absItem *LibraryItem) []BookSuggestion {
 	return suggestions
 }
 
+// MatchExternalBook matches a book known only by its metadata against the
+// pre-loaded cache (no DB calls): ISBN, then ASIN, then title and author.
+// With no duration to compare, fuzzy matches rest on the title alone once
+// an author agrees. The returned match has no ABSItem.
+func (m *Matcher) MatchExternalBook(meta ExternalBook) *BookMatch {
+	match := &BookMatch{Confidence: MatchNone}
+
+	// 1. ISBN match (definitive)
+	for _, isbn := range meta.ISBNs {
+		norm := normalizeISBN(isbn)
+		if norm == "" {
+			continue
+		}
+		if cached, ok := m.bookCache.byISBN[norm]; ok {
+			match.ListenUpID = cached.ID
+			match.Confidence = MatchDefinitive
+			match.MatchReason = "ISBN match: " + isbn
+			return match
+		}
+	}
+
+	// 2. ASIN match (definitive)
+	if meta.ASIN != "" {
+		if cached, ok := m.bookCache.byASIN[meta.ASIN]; ok {
+			match.ListenUpID = cached.ID
+			match.Confidence = MatchDefinitive
+			match.MatchReason = "ASIN match: " + meta.ASIN
+			return match
+		}
+	}
+
+	normTitle := normalizeTitle(meta.Title)
+	if normTitle == "" {
+		return match
+	}
+
+	// 3. Fuzzy match by title + author, best title first
+	if m.opts.FuzzyMatchBooks {
+		var best *CachedBook
+		var bestSim float64
+		for _, candidate := range m.bookCache.all {
+			titleSim := stringSimilarity(candidate.NormTitle, normTitle)
+			if titleSim < m.opts.FuzzyThreshold || titleSim <= bestSim {
+				continue
+			}
+			if !hasCommonAuthorName(candidate.Authors, meta.Authors) {
+				continue
+			}
+			best, bestSim = candidate, titleSim
+		}
+
+		if best != nil {
+			match.ListenUpID = best.ID
+			match.Confidence = MatchStrong
+			if bestSim < 0.95 {
+				match.Confidence = MatchWeak
+			}
+			match.MatchReason = fmt.Sprintf("fuzzy match: title=%.0f%%, author match", bestSim*100)
+			return match
+		}
+	}
+
+	// 4. No match - suggest books with similar titles, best first
+	for _, candidate := range m.bookCache.all {
+		titleSim := stringSimilarity(candidate.NormTitle, normTitle)
+		if titleSim < 0.5 {
+			continue
+		}
+
+		author := ""
+		if len(candidate.Authors) > 0 {
+			author = candidate.Authors[0]
+		}
+
+		match.Suggestions = append(match.Suggestions, BookSuggestion{
+			BookID:     candidate.ID,
+			Title:      candidate.Title,
+			Author:     author,
+			DurationMs: candidate.TotalDuration,
+			Score:      titleSim,
+			Reason:     formatSuggestionReason(titleSim, 0),
+		})
+	}
+	slices.SortStableFunc(match.Suggestions, func(a, b BookSuggestion) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	if len(match.Suggestions) > 5 {
+		match.Suggestions = match.Suggestions[:5]
+	}
+
+	return match
+}
+
 // MatchBook attempts to find a ListenUp book for an ABS library item.
 func (m *Matcher) MatchBook(ctx context.Context, absItem *LibraryItem) *BookMatch {
 	match := &BookMatch{
@@ -694,6 +824,19 @@ func normalizeTitle(s string) string {
 	return strings.Join(strings.Fields(result.String()), " ")
 }
 
+// normalizeISBN strips everything but digits and the X check digit from an ISBN.
+func normalizeISBN(s string) string {
+	var result strings.Builder
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			result.WriteRune(r)
+		} else if r == 'x' || r == 'X' {
+			result.WriteRune('X')
+		}
+	}
+	return result.String()
+}
+
 // stringSimilarity calculates the similarity between two strings (0.0-1.0).
 // Uses Jaro-Winkler-like similarity optimized for book titles.
 func stringSimilarity(a, b string) float64 {
@@ -135,3 +135,59 @@ func TestMatchConfidenceShouldAutoImport(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchExternalBook(t *testing.T) {
+	t.Parallel()
+	m := &Matcher{
+		opts:      AnalysisOptions{FuzzyMatchBooks: true, FuzzyThreshold: 0.85},
+		bookCache: newBookCache(),
+	}
+	for _, b := range []*CachedBook{
+		{ID: "book-1", Title: "The Way of Kings", ISBN: "978-0-7653-2635-5", Authors: []string{"Brandon Sanderson"}},
+		{ID: "book-2", Title: "Project Hail Mary", ASIN: "B08G9PRS1K", Authors: []string{"Andy Weir"}},
+		{ID: "book-3", Title: "The Martian", Authors: []string{"Andy Weir"}},
+		{ID: "book-4", Title: "Dune", Authors: []string{"Frank Herbert"}},
+	} {
+		b.NormTitle = normalizeTitle(b.Title)
+		m.bookCache.add(b)
+	}
+
+	tests := []struct {
+		name       string
+		meta       ExternalBook
+		wantID     string
+		confidence MatchConfidence
+	}{
+		{"ISBN without hyphens", ExternalBook{ISBNs: []string{"", "9780765326355"}, Title: "Anything"}, "book-1", MatchDefinitive},
+		{"ASIN", ExternalBook{ASIN: "B08G9PRS1K"}, "book-2", MatchDefinitive},
+		{"ISBN before title", ExternalBook{ISBNs: []string{"9780765326355"}, Title: "Dune"}, "book-1", MatchDefinitive},
+		{"exact title and author", ExternalBook{Title: "Martian", Authors: []string{"Andy Weir"}}, "book-3", MatchStrong},
+		{"similar title", ExternalBook{Title: "The Way of King", Authors: []string{"Brandon Sanderson"}}, "book-1", MatchWeak},
+		{"title without authors", ExternalBook{Title: "Dune"}, "book-4", MatchStrong},
+		{"title with another author", ExternalBook{Title: "Dune", Authors: []string{"Brian Herbert Jr"}}, "", MatchNone},
+		{"unknown book", ExternalBook{Title: "Neuromancer", Authors: []string{"William Gibson"}}, "", MatchNone},
+	}
+
+	for _, tc := range tests {
+		match := m.MatchExternalBook(tc.meta)
+		if match.ListenUpID != tc.wantID || match.Confidence != tc.confidence {
+			t.Errorf("%s: MatchExternalBook() = (%q, %s), want (%q, %s)",
+				tc.name, match.ListenUpID, match.Confidence, tc.wantID, tc.confidence)
+		}
+	}
+
+	// Unmatched books get title suggestions
+	match := m.MatchExternalBook(ExternalBook{Title: "Dunes", Authors: []string{"Someone Else"}})
+	if len(match.Suggestions) == 0 || match.Suggestions[0].BookID != "book-4" {
+		t.Errorf("expected book-4 as the first suggestion, got %+v", match.Suggestions)
+	}
+
+	// Restricting the cache hides books from later matches
+	m.RestrictBooks(map[string]bool{"book-2": true})
+	if match := m.MatchExternalBook(ExternalBook{ISBNs: []string{"9780765326355"}}); match.ListenUpID != "" {
+		t.Errorf("restricted book matched: %q", match.ListenUpID)
+	}
+	if match := m.MatchExternalBook(ExternalBook{ASIN: "B08G9PRS1K"}); match.ListenUpID != "book-2" {
+		t.Errorf("allowed book not matched: %q", match.ListenUpID)
+	}
+}
@@ -10,26 +10,40 @@ import "time"
 // mediaTypeBook is ABS's media type identifier for audiobooks (vs. "podcast").
 const mediaTypeBook = "book"
 
+// mediaTypePodcast is ABS's media type identifier for podcasts.
+const mediaTypePodcast = "podcast"
+
+// mediaItemTypeEpisode is the mediaItemType of progress on a podcast episode.
+const mediaItemTypeEpisode = "podcastEpisode"
+
 // Backup represents a parsed Audiobookshelf backup.
 // ABS creates backups as .audiobookshelf files (actually tar.gz archives).
 type Backup struct {
-	Path      string
-	Users     []User
-	Libraries []Library
-	Items     []LibraryItem
-	Authors   []Author
-	Series    []Series
-	Sessions  []Session
+	Path        string
+	Users       []User
+	Libraries   []Library
+	Items       []LibraryItem
+	Authors     []Author
+	Series      []Series
+	Sessions    []Session
+	Collections []Collection
+	Playlists   []Playlist
+	Podcasts    []Podcast
 }
 
 // User represents an ABS user account.
 // ABS embeds listening progress directly in user records.
 type User struct {
-	ID       string          `json:"id"`
-	Username string          `json:"username"`
-	Email    string          `json:"email"`
-	Type     string          `json:"type"` // "root", "admin", "user", "guest"
-	Progress []MediaProgress `json:"mediaProgress"`
+	ID        string          `json:"id"`
+	Username  string          `json:"username"`
+	Email     string          `json:"email"`
+	Type      string          `json:"type"` // "root", "admin", "user", "guest"
+	Progress  []MediaProgress `json:"mediaProgress"`
+	Bookmarks []Bookmark      `json:"bookmarks"`
+
+	// EpisodeProgress is progress on podcast episodes, kept apart from the
+	// book progress above. MediaItemID is the podcastEpisodes.id.
+	EpisodeProgress []MediaProgress `json:"-"`
 }
 
 // IsImportable returns true if this user type should be imported.
@@ -38,14 +52,28 @@ func (u *User) IsImportable() bool {
 	return u.Type != "guest"
 }
 
+// Bookmark is a position a user marked in a book.
+// ABS stores these as a JSON array on the user record.
+type Bookmark struct {
+	LibraryItemID string  `json:"libraryItemId"` // Resolved to books.id by the parser, like MediaProgress
+	Title         string  `json:"title"`
+	Time          float64 `json:"time"`      // Position in seconds
+	CreatedAt     int64   `json:"createdAt"` // Unix milliseconds
+}
+
+// PositionMs returns the bookmark position in milliseconds.
+func (b *Bookmark) PositionMs() int64 {
+	return int64(b.Time * 1000)
+}
+
 // MediaProgress represents embedded listening progress in ABS user records.
 // This is the "current state" — where the user left off.
 type MediaProgress struct {
 	ID               string  `json:"id"`
 	LibraryItemID    string  `json:"libraryItemId"`
-	EpisodeID        string  `json:"episodeId,omitempty"` // For podcasts (we skip these)
+	EpisodeID        string  `json:"episodeId,omitempty"` // For podcasts; see User.EpisodeProgress
 	MediaItemID      string  `json:"mediaItemId"`
-	MediaItemType    string  `json:"mediaItemType"` // "book" or "podcast"
+	MediaItemType    string  `json:"mediaItemType"` // "book" or "podcastEpisode"
 	Duration         float64 `json:"duration"`      // Total book duration in seconds
 	CurrentTime      float64 `json:"currentTime"`   // Current position in seconds
 	Progress         float64 `json:"progress"`      // 0.0 - 1.0
@@ -54,6 +82,10 @@ type MediaProgress struct {
 	LastUpdate       int64   `json:"lastUpdate"` // Unix milliseconds
 	StartedAt        int64   `json:"startedAt"`  // Unix milliseconds
 	FinishedAt       int64   `json:"finishedAt"` // Unix milliseconds (0 if not finished)
+
+	// Ebook reading position; ABS tracks it on the same record.
+	EbookLocation string  `json:"ebookLocation,omitempty"` // EPUB CFI, or a page number for PDFs
+	EbookProgress float64 `json:"ebookProgress,omitempty"` // 0.0 - 1.0
 }
 
 // IsBook returns true if this progress is for an audiobook (not podcast).
@@ -89,6 +121,11 @@ func (l *Library) IsBookLibrary() bool {
 	return l.MediaType == mediaTypeBook
 }
 
+// IsPodcastLibrary returns true if this is a podcast library.
+func (l *Library) IsPodcastLibrary() bool {
+	return l.MediaType == mediaTypePodcast
+}
+
 // LibraryItem represents a book (or podcast) in ABS.
 // ABS calls these "library items" — we only care about books.
 type LibraryItem struct {
@@ -128,7 +165,19 @@ type BookMedia struct {
 	Chapters   []Chapter    `json:"chapters,omitempty"`
 	Duration   float64      `json:"duration"`  // Total duration in seconds
 	Size       int64        `json:"size"`      // Total size in bytes
-	EbookFile  any          `json:"ebookFile"` // We ignore ebooks
+	EbookFile  *EbookFile   `json:"ebookFile"` // Companion ebook, if any
+}
+
+// EbookFile is the ebook ABS attached to a book item.
+type EbookFile struct {
+	INO         string `json:"ino"`
+	EbookFormat string `json:"ebookFormat"` // "epub", "pdf", ...
+	Metadata    struct {
+		Filename string `json:"filename"`
+		Ext      string `json:"ext"`
+		Path     string `json:"path"`
+		Size     int64  `json:"size"`
+	} `json:"metadata"`
 }
 
 // DurationMs returns duration in milliseconds (ListenUp's format).
@@ -297,3 +346,63 @@ func (s *Session) EndPositionMs() int64 {
 func (s *Session) DurationMs() int64 {
 	return int64(s.Duration * 1000)
 }
+
+// Collection is a named, ordered set of books in an ABS library.
+// ABS collections belong to the library rather than to a user; they are
+// typically curated by the server owner.
+type Collection struct {
+	ID          string   `json:"id"`
+	LibraryID   string   `json:"libraryId"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	BookIDs     []string `json:"books"` // books.id values, in collection order
+}
+
+// Playlist is a user's ordered list of books (or podcast episodes) in ABS.
+type Playlist struct {
+	ID          string   `json:"id"`
+	LibraryID   string   `json:"libraryId"`
+	UserID      string   `json:"userId"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	BookIDs     []string `json:"items"` // books.id values, in playlist order; episodes are skipped
+}
+
+// Podcast is a podcast library item in ABS with its episodes.
+type Podcast struct {
+	ID                string           `json:"id"` // podcasts.id
+	LibraryItemID     string           `json:"libraryItemId"`
+	LibraryID         string           `json:"libraryId"`
+	Path              string           `json:"path"` // Folder holding the downloaded episodes
+	Title             string           `json:"title"`
+	Author            string           `json:"author"`
+	Description       string           `json:"description"`
+	FeedURL           string           `json:"feedUrl"`
+	ImageURL          string           `json:"imageUrl"`
+	Language          string           `json:"language"`
+	Explicit          bool             `json:"explicit"`
+	AutoDownload      bool             `json:"autoDownloadEpisodes"`
+	MaxEpisodesToKeep int              `json:"maxEpisodesToKeep"` // 0 keeps all
+	Episodes          []PodcastEpisode `json:"episodes"`
+}
+
+// PodcastEpisode is an episode ABS has downloaded (or knows of) for a podcast.
+type PodcastEpisode struct {
+	ID            string  `json:"id"` // podcastEpisodes.id, referenced by episode progress
+	GUID          string  `json:"guid"`
+	Title         string  `json:"title"`
+	Description   string  `json:"description"`
+	Season        int     `json:"season"`
+	Episode       int     `json:"episode"`
+	PublishedAt   int64   `json:"publishedAt"` // Unix milliseconds
+	EnclosureURL  string  `json:"enclosureUrl"`
+	EnclosureType string  `json:"enclosureType"`
+	EnclosureSize int64   `json:"enclosureSize"`
+	Duration      float64 `json:"duration"` // Seconds
+	Path          string  `json:"path"`     // Downloaded audio file; empty if not downloaded
+}
+
+// DurationMs returns the episode duration in milliseconds.
+func (e *PodcastEpisode) DurationMs() int64 {
+	return int64(e.Duration * 1000)
+}
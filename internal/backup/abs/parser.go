@@ -4,11 +4,13 @@ import (
 	"archive/zip"
 	"context"
 	"database/sql"
+	"encoding/json/v2"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -130,12 +132,35 @@ func Parse(ctx context.Context, path string) (*Backup, error) {
 		return nil, fmt.Errorf("parse media progress: %w", err)
 	}
 
+	if err := parseUserBookmarks(ctx, db, backup); err != nil {
+		return nil, fmt.Errorf("parse bookmarks: %w", err)
+	}
+
+	if err := parseCollections(ctx, db, backup); err != nil {
+		return nil, fmt.Errorf("parse collections: %w", err)
+	}
+
+	if err := parsePlaylists(ctx, db, backup); err != nil {
+		return nil, fmt.Errorf("parse playlists: %w", err)
+	}
+
+	if err := parsePodcasts(ctx, db, backup); err != nil {
+		return nil, fmt.Errorf("parse podcasts: %w", err)
+	}
+
+	if err := parseEpisodeProgress(ctx, db, backup); err != nil {
+		return nil, fmt.Errorf("parse episode progress: %w", err)
+	}
+
 	slog.Info("ABS backup parsed successfully",
 		"users", len(backup.Users),
 		"items", len(backup.Items),
 		"sessions", len(backup.Sessions),
 		"authors", len(backup.Authors),
 		"series", len(backup.Series),
+		"collections", len(backup.Collections),
+		"playlists", len(backup.Playlists),
+		"podcasts", len(backup.Podcasts),
 		"duration", time.Since(start),
 	)
 
@@ -177,6 +202,16 @@ func parseLibraries(ctx context.Context, db *sql.DB, backup *Backup) error {
 }
 
 func parseLibraryItems(ctx context.Context, db *sql.DB, backup *Backup) error {
+	// books.ebookFile is missing from databases that predate ebook support.
+	bookCols, err := tableColumns(ctx, db, "books")
+	if err != nil {
+		return err
+	}
+	ebookFileCol := "NULL"
+	if bookCols["ebookFile"] {
+		ebookFileCol = "b.ebookFile"
+	}
+
 	// Join libraryItems with books to get full metadata
 	// Important: li.mediaId references books.id - this is what sessions use for matching
 	query := `
@@ -198,7 +233,9 @@ func parseLibraryItems(ctx context.Context, db *sql.DB, backup *Backup) error {
 			COALESCE(b.publishedYear, ''),
 			COALESCE(b.description, ''),
 			COALESCE(b.narrators, '[]'),
-			COALESCE(li.authorNamesFirstLast, '')
+			COALESCE(b.tags, '[]'),
+			COALESCE(li.authorNamesFirstLast, ''),
+			COALESCE(` + ebookFileCol + `, '')
 		FROM libraryItems li
 		LEFT JOIN books b ON li.mediaId = b.id
 		WHERE li.mediaType = 'book' OR li.mediaType IS NULL
@@ -212,7 +249,7 @@ func parseLibraryItems(ctx context.Context, db *sql.DB, backup *Backup) error {
 
 	for rows.Next() {
 		var item LibraryItem
-		var authorNames, narratorsJSON string
+		var authorNames, narratorsJSON, tagsJSON, ebookJSON string
 
 		err := rows.Scan(
 			&item.ID,
@@ -232,7 +269,9 @@ func parseLibraryItems(ctx context.Context, db *sql.DB, backup *Backup) error {
 			&item.Media.Metadata.PublishedYear,
 			&item.Media.Metadata.Description,
 			&narratorsJSON,
+			&tagsJSON,
 			&authorNames,
+			&ebookJSON,
 		)
 		if err != nil {
 			return err
@@ -250,6 +289,8 @@ func parseLibraryItems(ctx context.Context, db *sql.DB, backup *Backup) error {
 
 		// Parse narrators JSON array
 		item.Media.Metadata.Narrators = parseNarratorsJSON(narratorsJSON)
+		item.Media.Tags = parseTagsJSON(tagsJSON)
+		item.Media.EbookFile = parseEbookFileJSON(ebookJSON)
 
 		if item.MediaType == "" {
 			item.MediaType = mediaTypeBook
@@ -282,6 +323,35 @@ func parseNarratorsJSON(jsonStr string) []PersonRef {
 	return narrators
 }
 
+// parseTagsJSON decodes the books.tags JSON array, dropping blank entries.
+// Malformed values yield no tags rather than failing the whole parse.
+func parseTagsJSON(jsonStr string) []string {
+	var raw []string
+	if err := json.Unmarshal([]byte(jsonStr), &raw); err != nil {
+		return nil
+	}
+	var tags []string
+	for _, tag := range raw {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// parseEbookFileJSON decodes the books.ebookFile JSON object. Books without
+// an ebook, and malformed values, yield nil.
+func parseEbookFileJSON(jsonStr string) *EbookFile {
+	if jsonStr == "" || jsonStr == "null" {
+		return nil
+	}
+	var ebook EbookFile
+	if err := json.Unmarshal([]byte(jsonStr), &ebook); err != nil || ebook.Metadata.Filename == "" {
+		return nil
+	}
+	return &ebook
+}
+
 func parseAuthors(ctx context.Context, db *sql.DB, backup *Backup) error {
 	rows, err := db.QueryContext(ctx, `SELECT id, COALESCE(name, ''), COALESCE(asin, ''), COALESCE(description, '') FROM authors`)
 	if err != nil {
@@ -443,6 +513,7 @@ func parseMediaProgress(ctx context.Context, db *sql.DB, backup *Backup) error {
 	// Newer ABS versions (2.17+) removed libraryItemId from mediaProgresses
 	// since mediaItemId now directly references the book/media item.
 	hasLibraryItemID := false
+	hasEbookLocation := false
 	schemaRows, err := db.QueryContext(ctx, `PRAGMA table_info(mediaProgresses)`)
 	if err == nil {
 		defer schemaRows.Close()
@@ -453,8 +524,11 @@ func parseMediaProgress(ctx context.Context, db *sql.DB, backup *Backup) error {
 			var dflt any
 			if err := schemaRows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err == nil {
 				slog.Debug("mediaProgresses column", slog.String("name", name), slog.String("type", colType))
-				if name == "libraryItemId" {
+				switch name {
+				case "libraryItemId":
 					hasLibraryItemID = true
+				case "ebookLocation":
+					hasEbookLocation = true
 				}
 			}
 		}
@@ -463,6 +537,12 @@ func parseMediaProgress(ctx context.Context, db *sql.DB, backup *Backup) error {
 		}
 	}
 
+	// Ebook positions share the record in ABS versions with ebook support.
+	ebookCols := "'', 0"
+	if hasEbookLocation {
+		ebookCols = "COALESCE(mp.ebookLocation, ''), COALESCE(mp.ebookProgress, 0)"
+	}
+
 	// Build query based on schema version.
 	// - Old schema: libraryItemId exists, may need JOIN to get mediaId
 	// - New schema: mediaItemId directly references the book
@@ -481,7 +561,8 @@ func parseMediaProgress(ctx context.Context, db *sql.DB, backup *Backup) error {
 				COALESCE(mp.hideFromContinueListening, 0),
 				COALESCE(strftime('%s', mp.updatedAt) * 1000, 0),
 				COALESCE(strftime('%s', mp.createdAt) * 1000, 0),
-				COALESCE(strftime('%s', mp.finishedAt) * 1000, 0)
+				COALESCE(strftime('%s', mp.finishedAt) * 1000, 0),
+				` + ebookCols + `
 			FROM mediaProgresses mp
 			LEFT JOIN libraryItems li ON mp.libraryItemId = li.id
 			WHERE mp.mediaItemType = 'book' OR mp.mediaItemType IS NULL
@@ -505,7 +586,8 @@ func parseMediaProgress(ctx context.Context, db *sql.DB, backup *Backup) error {
 				COALESCE(mp.hideFromContinueListening, 0),
 				COALESCE(strftime('%s', mp.updatedAt) * 1000, 0),
 				COALESCE(strftime('%s', mp.createdAt) * 1000, 0),
-				COALESCE(strftime('%s', mp.finishedAt) * 1000, 0)
+				COALESCE(strftime('%s', mp.finishedAt) * 1000, 0),
+				` + ebookCols + `
 			FROM mediaProgresses mp
 			LEFT JOIN libraryItems li ON mp.mediaItemId = li.id
 			WHERE mp.mediaItemType = 'book' OR mp.mediaItemType IS NULL
@@ -538,6 +620,8 @@ func parseMediaProgress(ctx context.Context, db *sql.DB, backup *Backup) error {
 			&p.LastUpdate,
 			&p.StartedAt,
 			&p.FinishedAt,
+			&p.EbookLocation,
+			&p.EbookProgress,
 		)
 		if err != nil {
 			return err
@@ -595,6 +679,372 @@ func parseMediaProgress(ctx context.Context, db *sql.DB, backup *Backup) error {
 	return nil
 }
 
+// tableColumns returns the column names of an ABS table, or nil if the table
+// doesn't exist. Older ABS versions lack some of the tables we read.
+func tableColumns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cols map[string]bool
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		if cols == nil {
+			cols = make(map[string]bool)
+		}
+		cols[name] = true
+	}
+	return cols, rows.Err()
+}
+
+// parseUserBookmarks reads the JSON bookmarks column of the users table.
+// Bookmarks reference libraryItems.id; they are resolved to books.id so they
+// can be mapped with the same book mappings as sessions and progress.
+func parseUserBookmarks(ctx context.Context, db *sql.DB, backup *Backup) error {
+	cols, err := tableColumns(ctx, db, "users")
+	if err != nil {
+		return err
+	}
+	if !cols["bookmarks"] {
+		return nil
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT id, bookmarks FROM users WHERE bookmarks IS NOT NULL`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	mediaIDs := make(map[string]string, len(backup.Items))
+	for _, item := range backup.Items {
+		if item.MediaID != "" {
+			mediaIDs[item.ID] = item.MediaID
+		}
+	}
+
+	bookmarkMap := make(map[string][]Bookmark)
+	for rows.Next() {
+		var userID, bookmarksJSON string
+		if err := rows.Scan(&userID, &bookmarksJSON); err != nil {
+			return err
+		}
+
+		var bookmarks []Bookmark
+		if err := json.Unmarshal([]byte(bookmarksJSON), &bookmarks); err != nil {
+			slog.Warn("skipping unreadable ABS bookmarks", "user_id", userID, "error", err)
+			continue
+		}
+		for _, bm := range bookmarks {
+			if bm.LibraryItemID == "" {
+				continue
+			}
+			if mediaID, ok := mediaIDs[bm.LibraryItemID]; ok {
+				bm.LibraryItemID = mediaID
+			}
+			bookmarkMap[userID] = append(bookmarkMap[userID], bm)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range backup.Users {
+		backup.Users[i].Bookmarks = bookmarkMap[backup.Users[i].ID]
+	}
+	return nil
+}
+
+func parseCollections(ctx context.Context, db *sql.DB, backup *Backup) error {
+	cols, err := tableColumns(ctx, db, "collections")
+	if err != nil {
+		return err
+	}
+	if cols == nil {
+		return nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, COALESCE(libraryId, ''), COALESCE(name, ''), COALESCE(description, '')
+		FROM collections
+		ORDER BY createdAt, id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	index := make(map[string]int)
+	for rows.Next() {
+		var c Collection
+		if err := rows.Scan(&c.ID, &c.LibraryID, &c.Name, &c.Description); err != nil {
+			return err
+		}
+		index[c.ID] = len(backup.Collections)
+		backup.Collections = append(backup.Collections, c)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	bookRows, err := db.QueryContext(ctx, `
+		SELECT collectionId, bookId
+		FROM collectionBooks
+		WHERE bookId IS NOT NULL
+		ORDER BY collectionId, "order"`)
+	if err != nil {
+		return err
+	}
+	defer bookRows.Close()
+
+	for bookRows.Next() {
+		var collectionID, bookID string
+		if err := bookRows.Scan(&collectionID, &bookID); err != nil {
+			return err
+		}
+		if i, ok := index[collectionID]; ok {
+			backup.Collections[i].BookIDs = append(backup.Collections[i].BookIDs, bookID)
+		}
+	}
+	return bookRows.Err()
+}
+
+func parsePlaylists(ctx context.Context, db *sql.DB, backup *Backup) error {
+	cols, err := tableColumns(ctx, db, "playlists")
+	if err != nil {
+		return err
+	}
+	if cols == nil {
+		return nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, COALESCE(libraryId, ''), COALESCE(userId, ''), COALESCE(name, ''), COALESCE(description, '')
+		FROM playlists
+		ORDER BY createdAt, id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	index := make(map[string]int)
+	for rows.Next() {
+		var p Playlist
+		if err := rows.Scan(&p.ID, &p.LibraryID, &p.UserID, &p.Name, &p.Description); err != nil {
+			return err
+		}
+		index[p.ID] = len(backup.Playlists)
+		backup.Playlists = append(backup.Playlists, p)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Podcast episodes can be mixed into playlists; only books carry over.
+	itemRows, err := db.QueryContext(ctx, `
+		SELECT playlistId, mediaItemId
+		FROM playlistMediaItems
+		WHERE mediaItemId IS NOT NULL AND (mediaItemType = 'book' OR mediaItemType IS NULL)
+		ORDER BY playlistId, "order"`)
+	if err != nil {
+		return err
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var playlistID, bookID string
+		if err := itemRows.Scan(&playlistID, &bookID); err != nil {
+			return err
+		}
+		if i, ok := index[playlistID]; ok {
+			backup.Playlists[i].BookIDs = append(backup.Playlists[i].BookIDs, bookID)
+		}
+	}
+	return itemRows.Err()
+}
+
+// parsePodcasts reads podcast library items with their episodes. The episode
+// GUID lives in the extraData JSON and the downloaded file in audioFile.
+func parsePodcasts(ctx context.Context, db *sql.DB, backup *Backup) error {
+	for _, table := range []string{"podcasts", "podcastEpisodes"} {
+		cols, err := tableColumns(ctx, db, table)
+		if err != nil {
+			return err
+		}
+		if cols == nil {
+			return nil
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT
+			p.id,
+			li.id,
+			COALESCE(li.libraryId, ''),
+			COALESCE(li.path, ''),
+			COALESCE(p.title, ''),
+			COALESCE(p.author, ''),
+			COALESCE(p.description, ''),
+			COALESCE(p.feedURL, ''),
+			COALESCE(p.imageURL, ''),
+			COALESCE(p.language, ''),
+			COALESCE(p.explicit, 0),
+			COALESCE(p.autoDownloadEpisodes, 0),
+			COALESCE(p.maxEpisodesToKeep, 0)
+		FROM libraryItems li
+		JOIN podcasts p ON li.mediaId = p.id
+		WHERE li.mediaType = 'podcast'
+		ORDER BY p.title, p.id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	index := make(map[string]int)
+	for rows.Next() {
+		var p Podcast
+		var explicit, autoDownload int
+		if err := rows.Scan(
+			&p.ID, &p.LibraryItemID, &p.LibraryID, &p.Path,
+			&p.Title, &p.Author, &p.Description, &p.FeedURL, &p.ImageURL, &p.Language,
+			&explicit, &autoDownload, &p.MaxEpisodesToKeep,
+		); err != nil {
+			return err
+		}
+		p.Explicit = explicit != 0
+		p.AutoDownload = autoDownload != 0
+		index[p.ID] = len(backup.Podcasts)
+		backup.Podcasts = append(backup.Podcasts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	episodeRows, err := db.QueryContext(ctx, `
+		SELECT
+			id,
+			podcastId,
+			COALESCE(title, ''),
+			COALESCE(description, ''),
+			COALESCE(season, ''),
+			COALESCE(episode, ''),
+			COALESCE(strftime('%s', publishedAt) * 1000, 0),
+			COALESCE(enclosureURL, ''),
+			COALESCE(enclosureType, ''),
+			COALESCE(enclosureSize, 0),
+			COALESCE(audioFile, ''),
+			COALESCE(extraData, '')
+		FROM podcastEpisodes
+		ORDER BY podcastId, publishedAt DESC, id`)
+	if err != nil {
+		return err
+	}
+	defer episodeRows.Close()
+
+	for episodeRows.Next() {
+		var e PodcastEpisode
+		var podcastID, season, number, audioFileJSON, extraDataJSON string
+		if err := episodeRows.Scan(
+			&e.ID, &podcastID, &e.Title, &e.Description, &season, &number, &e.PublishedAt,
+			&e.EnclosureURL, &e.EnclosureType, &e.EnclosureSize, &audioFileJSON, &extraDataJSON,
+		); err != nil {
+			return err
+		}
+		i, ok := index[podcastID]
+		if !ok {
+			continue
+		}
+
+		e.Season, _ = strconv.Atoi(strings.TrimSpace(season))
+		e.Episode, _ = strconv.Atoi(strings.TrimSpace(number))
+
+		var extra struct {
+			GUID string `json:"guid"`
+		}
+		if extraDataJSON != "" {
+			_ = json.Unmarshal([]byte(extraDataJSON), &extra) // GUID falls back to the enclosure URL
+		}
+		e.GUID = extra.GUID
+
+		var audio struct {
+			Duration float64 `json:"duration"`
+			Metadata struct {
+				Path string `json:"path"`
+			} `json:"metadata"`
+		}
+		if audioFileJSON != "" {
+			if err := json.Unmarshal([]byte(audioFileJSON), &audio); err != nil {
+				slog.Warn("skipping unreadable ABS episode audio file", "episode_id", e.ID, "error", err)
+			}
+		}
+		e.Duration = audio.Duration
+		e.Path = audio.Metadata.Path
+
+		backup.Podcasts[i].Episodes = append(backup.Podcasts[i].Episodes, e)
+	}
+	return episodeRows.Err()
+}
+
+// parseEpisodeProgress reads progress on podcast episodes into
+// User.EpisodeProgress. MediaItemID is the podcastEpisodes.id.
+func parseEpisodeProgress(ctx context.Context, db *sql.DB, backup *Backup) error {
+	cols, err := tableColumns(ctx, db, "mediaProgresses")
+	if err != nil {
+		return err
+	}
+	if !cols["mediaItemId"] {
+		return nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT
+			id,
+			userId,
+			mediaItemId,
+			COALESCE(duration, 0),
+			COALESCE(currentTime, 0),
+			COALESCE(isFinished, 0),
+			COALESCE(strftime('%s', updatedAt) * 1000, 0),
+			COALESCE(strftime('%s', createdAt) * 1000, 0),
+			COALESCE(strftime('%s', finishedAt) * 1000, 0)
+		FROM mediaProgresses
+		WHERE mediaItemType = 'podcastEpisode' AND mediaItemId IS NOT NULL`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	progressMap := make(map[string][]MediaProgress)
+	for rows.Next() {
+		p := MediaProgress{MediaItemType: mediaItemTypeEpisode}
+		var userID string
+		var isFinished int
+		if err := rows.Scan(
+			&p.ID, &userID, &p.MediaItemID, &p.Duration, &p.CurrentTime,
+			&isFinished, &p.LastUpdate, &p.StartedAt, &p.FinishedAt,
+		); err != nil {
+			return err
+		}
+		p.EpisodeID = p.MediaItemID
+		p.IsFinished = isFinished != 0
+		if p.Duration > 0 {
+			p.Progress = p.CurrentTime / p.Duration
+		}
+		progressMap[userID] = append(progressMap[userID], p)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range backup.Users {
+		backup.Users[i].EpisodeProgress = progressMap[backup.Users[i].ID]
+	}
+	return nil
+}
+
 // Summary returns a human-readable summary of the backup contents.
 func (b *Backup) Summary() string {
 	var users, guests int
@@ -606,12 +1056,10 @@ func (b *Backup) Summary() string {
 		}
 	}
 
-	var books, podcasts int
+	var books int
 	for _, item := range b.Items {
 		if item.IsBook() {
 			books++
-		} else {
-			podcasts++
 		}
 	}
 
@@ -625,8 +1073,8 @@ func (b *Backup) Summary() string {
 	}
 
 	return fmt.Sprintf(
-		"ABS Backup: %d users (%d guests), %d libraries, %d books, %d podcasts, %d sessions (books), %d sessions (podcasts), %d authors, %d series",
-		users, guests, len(b.Libraries), books, podcasts, bookSessions, podcastSessions, len(b.Authors), len(b.Series),
+		"ABS Backup: %d users (%d guests), %d libraries, %d books, %d podcasts, %d sessions (books), %d sessions (podcasts), %d authors, %d series, %d collections, %d playlists",
+		users, guests, len(b.Libraries), books, len(b.Podcasts), bookSessions, podcastSessions, len(b.Authors), len(b.Series), len(b.Collections), len(b.Playlists),
 	)
 }
 
@@ -663,6 +1111,17 @@ func (b *Backup) ImportableUsers() []User {
 	return users
 }
 
+// PodcastLibraries returns only podcast libraries.
+func (b *Backup) PodcastLibraries() []Library {
+	var libs []Library
+	for _, l := range b.Libraries {
+		if l.IsPodcastLibrary() {
+			libs = append(libs, l)
+		}
+	}
+	return libs
+}
+
 // BookLibraries returns only audiobook libraries (not podcast libraries).
 func (b *Backup) BookLibraries() []Library {
 	var libs []Library
@@ -673,3 +1132,14 @@ func (b *Backup) BookLibraries() []Library {
 	}
 	return libs
 }
+
+// RootUserID returns the ID of the ABS server owner, or "" if there is none.
+// Collections have no owner in ABS, so they are attributed to this user.
+func (b *Backup) RootUserID() string {
+	for _, u := range b.Users {
+		if u.Type == "root" {
+			return u.ID
+		}
+	}
+	return ""
+}
@@ -445,3 +445,183 @@ func TestParseMediaProgressLegacySchema(t *testing.T) {
 		t.Errorf("expected LibraryItemID 'media-from-join' from JOIN fallback, got '%s'", progress[0].LibraryItemID)
 	}
 }
+
+func TestParseLibraryData(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	// Without the tables (older ABS versions) there is nothing to read.
+	backup := &Backup{Users: []User{{ID: "user-1"}}}
+	for name, parse := range map[string]func() error{
+		"collections": func() error { return parseCollections(t.Context(), db, backup) },
+		"playlists":   func() error { return parsePlaylists(t.Context(), db, backup) },
+	} {
+		if err := parse(); err != nil {
+			t.Fatalf("parse %s without table: %v", name, err)
+		}
+	}
+	if backup.Collections != nil || backup.Playlists != nil {
+		t.Fatalf("expected no collections or playlists, got %+v %+v", backup.Collections, backup.Playlists)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id TEXT PRIMARY KEY, bookmarks JSON);
+		CREATE TABLE collections (id TEXT PRIMARY KEY, libraryId TEXT, name TEXT, description TEXT, createdAt TEXT);
+		CREATE TABLE collectionBooks (id TEXT PRIMARY KEY, collectionId TEXT, bookId TEXT, "order" INTEGER);
+		CREATE TABLE playlists (id TEXT PRIMARY KEY, libraryId TEXT, userId TEXT, name TEXT, description TEXT, createdAt TEXT);
+		CREATE TABLE playlistMediaItems (id TEXT PRIMARY KEY, playlistId TEXT, mediaItemId TEXT, mediaItemType TEXT, "order" INTEGER);
+
+		INSERT INTO users VALUES
+			('user-1', '[{"libraryItemId":"li-1","title":"Twist","time":125.5,"createdAt":1700000000000},{"libraryItemId":"","time":1}]'),
+			('user-2', 'not json');
+		INSERT INTO collections VALUES
+			('col-2', 'lib-1', 'Second', NULL, '2025-02-01'),
+			('col-1', 'lib-1', 'First', 'Oldest', '2025-01-01');
+		INSERT INTO collectionBooks VALUES
+			('cb-1', 'col-1', 'book-b', 2),
+			('cb-2', 'col-1', 'book-a', 1),
+			('cb-3', 'col-2', 'book-c', 1);
+		INSERT INTO playlists VALUES ('pl-1', 'lib-1', 'user-1', 'Commute', '', '2025-01-01');
+		INSERT INTO playlistMediaItems VALUES
+			('pm-1', 'pl-1', 'book-a', 'book', 1),
+			('pm-2', 'pl-1', 'episode-1', 'podcastEpisode', 2),
+			('pm-3', 'pl-1', 'book-b', 'book', 3);
+	`)
+	if err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+
+	backup = &Backup{
+		Users: []User{{ID: "user-1"}, {ID: "user-2"}},
+		Items: []LibraryItem{{ID: "li-1", MediaID: "book-a"}},
+	}
+	if err := parseUserBookmarks(t.Context(), db, backup); err != nil {
+		t.Fatalf("parseUserBookmarks failed: %v", err)
+	}
+	if err := parseCollections(t.Context(), db, backup); err != nil {
+		t.Fatalf("parseCollections failed: %v", err)
+	}
+	if err := parsePlaylists(t.Context(), db, backup); err != nil {
+		t.Fatalf("parsePlaylists failed: %v", err)
+	}
+
+	// Bookmarks resolve libraryItems.id to books.id; unreadable JSON is skipped.
+	bookmarks := backup.Users[0].Bookmarks
+	if len(bookmarks) != 1 {
+		t.Fatalf("expected 1 bookmark, got %+v", bookmarks)
+	}
+	if bm := bookmarks[0]; bm.LibraryItemID != "book-a" || bm.Title != "Twist" || bm.PositionMs() != 125500 {
+		t.Errorf("unexpected bookmark: %+v", bm)
+	}
+	if len(backup.Users[1].Bookmarks) != 0 {
+		t.Errorf("expected no bookmarks for user-2, got %+v", backup.Users[1].Bookmarks)
+	}
+
+	if len(backup.Collections) != 2 || backup.Collections[0].ID != "col-1" {
+		t.Fatalf("expected collections in creation order, got %+v", backup.Collections)
+	}
+	if got := backup.Collections[0].BookIDs; len(got) != 2 || got[0] != "book-a" || got[1] != "book-b" {
+		t.Errorf("expected collection books in order, got %v", got)
+	}
+
+	if len(backup.Playlists) != 1 {
+		t.Fatalf("expected 1 playlist, got %+v", backup.Playlists)
+	}
+	if p := backup.Playlists[0]; p.UserID != "user-1" || len(p.BookIDs) != 2 || p.BookIDs[1] != "book-b" {
+		t.Errorf("expected episodes to be dropped from playlist, got %+v", p)
+	}
+}
+
+func TestParseTagsJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{`["Fantasy", " ", "to-read"]`, 2},
+		{`[]`, 0},
+		{`not json`, 0},
+	}
+	for _, tt := range tests {
+		if got := parseTagsJSON(tt.input); len(got) != tt.want {
+			t.Errorf("parseTagsJSON(%q) = %v, want %d tags", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParsePodcasts(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	// Without the tables there is nothing to read.
+	backup := &Backup{Users: []User{{ID: "user-1"}}}
+	if err := parsePodcasts(t.Context(), db, backup); err != nil {
+		t.Fatalf("parsePodcasts without tables: %v", err)
+	}
+	if err := parseEpisodeProgress(t.Context(), db, backup); err != nil {
+		t.Fatalf("parseEpisodeProgress without table: %v", err)
+	}
+	if backup.Podcasts != nil || backup.Users[0].EpisodeProgress != nil {
+		t.Fatalf("expected no podcasts, got %+v", backup)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE libraryItems (id TEXT PRIMARY KEY, libraryId TEXT, mediaId TEXT, mediaType TEXT, path TEXT);
+		CREATE TABLE podcasts (id TEXT PRIMARY KEY, title TEXT, author TEXT, description TEXT, feedURL TEXT, imageURL TEXT,
+			language TEXT, explicit INTEGER, autoDownloadEpisodes INTEGER, maxEpisodesToKeep INTEGER);
+		CREATE TABLE podcastEpisodes (id TEXT PRIMARY KEY, podcastId TEXT, title TEXT, description TEXT, season TEXT, episode TEXT,
+			publishedAt TEXT, enclosureURL TEXT, enclosureType TEXT, enclosureSize INTEGER, audioFile JSON, extraData JSON);
+		CREATE TABLE mediaProgresses (id TEXT PRIMARY KEY, userId TEXT, mediaItemId TEXT, mediaItemType TEXT, duration REAL,
+			currentTime REAL, isFinished INTEGER, createdAt TEXT, updatedAt TEXT, finishedAt TEXT);
+
+		INSERT INTO libraryItems VALUES
+			('li-1', 'lib-pods', 'pod-1', 'podcast', '/podcasts/The Show'),
+			('li-2', 'lib-books', 'book-1', 'book', '/audiobooks/Dune');
+		INSERT INTO podcasts VALUES ('pod-1', 'The Show', 'Host', NULL, 'https://example.com/feed.xml', NULL, 'en', 0, 1, 5);
+		INSERT INTO podcastEpisodes VALUES
+			('ep-1', 'pod-1', 'Pilot', NULL, '1', '1', '2025-01-01 09:00:00', 'https://cdn.example.com/1.mp3', 'audio/mpeg', 2048,
+				'{"duration":1800.5,"metadata":{"path":"/podcasts/The Show/Pilot.mp3"}}', '{"guid":"guid-1"}'),
+			('ep-2', 'pod-1', 'Second', NULL, '', 'bonus', '2025-01-08 09:00:00', 'https://cdn.example.com/2.mp3', NULL, NULL,
+				'not json', NULL),
+			('ep-x', 'pod-gone', 'Orphan', NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL);
+		INSERT INTO mediaProgresses VALUES
+			('mp-1', 'user-1', 'ep-1', 'podcastEpisode', 1800, 900, 0, '2025-01-02', '2025-01-03', NULL),
+			('mp-2', 'user-1', 'book-1', 'book', 75600, 600, 0, '2025-01-02', '2025-01-03', NULL);
+	`)
+	if err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+
+	if err := parsePodcasts(t.Context(), db, backup); err != nil {
+		t.Fatalf("parsePodcasts failed: %v", err)
+	}
+	if err := parseEpisodeProgress(t.Context(), db, backup); err != nil {
+		t.Fatalf("parseEpisodeProgress failed: %v", err)
+	}
+
+	if len(backup.Podcasts) != 1 {
+		t.Fatalf("expected 1 podcast, got %+v", backup.Podcasts)
+	}
+	p := backup.Podcasts[0]
+	if p.LibraryItemID != "li-1" || p.Path != "/podcasts/The Show" || !p.AutoDownload || p.MaxEpisodesToKeep != 5 || len(p.Episodes) != 2 {
+		t.Fatalf("unexpected podcast: %+v", p)
+	}
+	// Newest first; the unreadable audio file leaves the episode undownloaded.
+	if e := p.Episodes[0]; e.ID != "ep-2" || e.Path != "" || e.Episode != 0 || e.GUID != "" {
+		t.Errorf("unexpected episode: %+v", e)
+	}
+	if e := p.Episodes[1]; e.GUID != "guid-1" || e.Season != 1 || e.DurationMs() != 1800500 ||
+		e.Path != "/podcasts/The Show/Pilot.mp3" || e.EnclosureSize != 2048 || e.PublishedAt == 0 {
+		t.Errorf("unexpected episode: %+v", e)
+	}
+
+	progress := backup.Users[0].EpisodeProgress
+	if len(progress) != 1 || progress[0].MediaItemID != "ep-1" || progress[0].CurrentTime != 900 || progress[0].Progress != 0.5 {
+		t.Errorf("unexpected episode progress: %+v", progress)
+	}
+}
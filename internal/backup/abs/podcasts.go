@@ -0,0 +1,253 @@
+package abs
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// PodcastImportResult summarizes an import of ABS podcasts.
+type PodcastImportResult struct {
+	PodcastsCreated  int `json:"podcasts_created"`
+	PodcastsSkipped  int `json:"podcasts_skipped"` // No feed URL, or already subscribed
+	EpisodesCreated  int `json:"episodes_created"`
+	EpisodesLinked   int `json:"episodes_linked"` // ABS downloads found on disk and kept
+	ProgressImported int `json:"progress_imported"`
+	ProgressSkipped  int `json:"progress_skipped"` // Unmapped user or unknown episode
+
+	// Podcasts that were created (for search indexing)
+	PodcastIDs []string `json:"podcast_ids"`
+
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ImportPodcasts subscribes lib, a podcast library, to every ABS podcast and
+// carries over the episodes and the mapped users' progress on them.
+//
+// Podcasts are matched to existing subscriptions by feed URL and episodes by
+// GUID, so importing again only adds what is new. Episodes ABS downloaded
+// are kept in place when their files are readable from this server;
+// otherwise they can be downloaded again from the feed.
+func (im *Importer) ImportPodcasts(
+	ctx context.Context,
+	backup *Backup,
+	lib *domain.Library,
+	userMap map[string]string, // ABS userID -> ListenUp userID
+) (*PodcastImportResult, error) {
+	if !lib.IsPodcast() || len(lib.ScanPaths) == 0 {
+		return nil, fmt.Errorf("library %s is not a podcast library with a folder", lib.ID)
+	}
+
+	result := &PodcastImportResult{}
+	episodeIDs := make(map[string]string) // ABS episode ID -> ListenUp episode ID
+
+	for _, ap := range backup.Podcasts {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if ap.FeedURL == "" {
+			result.PodcastsSkipped++
+			result.Warnings = append(result.Warnings, fmt.Sprintf("podcast %q has no feed URL", ap.Title))
+			continue
+		}
+
+		p, err := im.store.GetPodcastByFeedURL(ctx, lib.ID, ap.FeedURL)
+		switch {
+		case err == nil:
+			result.PodcastsSkipped++
+		case errors.Is(err, store.ErrPodcastNotFound):
+			if p, err = im.createPodcast(ctx, ap, lib); err != nil {
+				return result, fmt.Errorf("create podcast %q: %w", ap.Title, err)
+			}
+			result.PodcastsCreated++
+			result.PodcastIDs = append(result.PodcastIDs, p.ID)
+		default:
+			return result, fmt.Errorf("look up podcast %q: %w", ap.Title, err)
+		}
+
+		for _, ae := range ap.Episodes {
+			episodeID, created, linked, err := im.importEpisode(ctx, p.ID, ae)
+			if err != nil {
+				return result, fmt.Errorf("import episode %q: %w", ae.Title, err)
+			}
+			if episodeID == "" {
+				continue
+			}
+			episodeIDs[ae.ID] = episodeID
+			if created {
+				result.EpisodesCreated++
+			}
+			if linked {
+				result.EpisodesLinked++
+			}
+		}
+	}
+
+	for _, user := range backup.ImportableUsers() {
+		userID, ok := userMap[user.ID]
+		for _, mp := range user.EpisodeProgress {
+			episodeID, known := episodeIDs[mp.MediaItemID]
+			if !ok || !known {
+				result.ProgressSkipped++
+				continue
+			}
+			if err := im.importEpisodeProgress(ctx, userID, episodeID, mp); err != nil {
+				return result, fmt.Errorf("import episode progress: %w", err)
+			}
+			result.ProgressImported++
+		}
+	}
+
+	im.logger.Info("ABS podcasts imported",
+		"library_id", lib.ID,
+		"podcasts_created", result.PodcastsCreated,
+		"podcasts_skipped", result.PodcastsSkipped,
+		"episodes_created", result.EpisodesCreated,
+		"episodes_linked", result.EpisodesLinked,
+		"progress_imported", result.ProgressImported,
+	)
+	return result, nil
+}
+
+// createPodcast subscribes the library to an ABS podcast. Downloads stay in
+// the ABS folder when it is reachable from here, so existing files and new
+// episodes end up side by side.
+func (im *Importer) createPodcast(ctx context.Context, ap Podcast, lib *domain.Library) (*domain.Podcast, error) {
+	podcastID, err := id.Generate("podcast")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	p := &domain.Podcast{
+		ID:           podcastID,
+		LibraryID:    lib.ID,
+		FeedURL:      ap.FeedURL,
+		Title:        ap.Title,
+		Author:       ap.Author,
+		Description:  ap.Description,
+		ImageURL:     ap.ImageURL,
+		Language:     ap.Language,
+		Explicit:     ap.Explicit,
+		AutoDownload: ap.AutoDownload,
+		KeepEpisodes: ap.MaxEpisodesToKeep,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if info, err := os.Stat(ap.Path); ap.Path != "" && err == nil && info.IsDir() {
+		p.Dir = ap.Path
+	} else {
+		p.Dir = filepath.Join(lib.ScanPaths[0], p.DirName())
+	}
+
+	if err := im.store.CreatePodcast(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// importEpisode creates the ListenUp episode for an ABS episode unless the
+// podcast already has it. It returns the episode's ID, or "" for an episode
+// with neither a GUID nor an enclosure to identify it by.
+func (im *Importer) importEpisode(ctx context.Context, podcastID string, ae PodcastEpisode) (episodeID string, created, linked bool, err error) {
+	// Feeds without GUIDs are keyed by enclosure URL, as on refresh.
+	guid := cmp.Or(ae.GUID, ae.EnclosureURL)
+	if guid == "" {
+		return "", false, false, nil
+	}
+
+	existing, err := im.store.GetPodcastEpisodeByGUID(ctx, podcastID, guid)
+	if err == nil {
+		return existing.ID, false, false, nil
+	}
+	if !errors.Is(err, store.ErrEpisodeNotFound) {
+		return "", false, false, err
+	}
+
+	if episodeID, err = id.Generate("episode"); err != nil {
+		return "", false, false, err
+	}
+	now := time.Now()
+	e := &domain.PodcastEpisode{
+		ID:            episodeID,
+		PodcastID:     podcastID,
+		GUID:          guid,
+		Title:         ae.Title,
+		Description:   ae.Description,
+		DurationMs:    ae.DurationMs(),
+		Season:        ae.Season,
+		Number:        ae.Episode,
+		EnclosureURL:  ae.EnclosureURL,
+		EnclosureType: ae.EnclosureType,
+		EnclosureSize: ae.EnclosureSize,
+		Status:        domain.EpisodeStatusAvailable,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if ae.PublishedAt > 0 {
+		published := time.UnixMilli(ae.PublishedAt)
+		e.PublishedAt = &published
+	}
+	if info, err := os.Stat(ae.Path); ae.Path != "" && err == nil && info.Mode().IsRegular() {
+		e.Status = domain.EpisodeStatusDownloaded
+		e.FilePath = ae.Path
+		e.FileSize = info.Size()
+		e.DownloadedAt = &now
+		linked = true
+	}
+
+	if err := im.store.CreatePodcastEpisode(ctx, e); err != nil {
+		return "", false, false, err
+	}
+	return episodeID, true, linked, nil
+}
+
+// importEpisodeProgress applies ABS progress to a user's episode playback
+// state. Like book progress overrides, the position only moves forward and
+// finished is terminal.
+func (im *Importer) importEpisodeProgress(ctx context.Context, userID, episodeID string, mp MediaProgress) error {
+	now := time.Now()
+	state, err := im.store.GetEpisodePlaybackState(ctx, userID, episodeID)
+	switch {
+	case errors.Is(err, store.ErrProgressNotFound):
+		state = &domain.PlaybackState{
+			UserID:    userID,
+			BookID:    episodeID,
+			StartedAt: now,
+		}
+		if mp.StartedAt > 0 {
+			state.StartedAt = time.UnixMilli(mp.StartedAt)
+		}
+	case err != nil:
+		return err
+	}
+
+	if positionMs := int64(mp.CurrentTime * 1000); positionMs > state.CurrentPositionMs {
+		state.CurrentPositionMs = positionMs
+	}
+	if lastPlayed := mp.LastUpdateTime(); mp.LastUpdate > 0 && lastPlayed.After(state.LastPlayedAt) {
+		state.LastPlayedAt = lastPlayed
+	}
+	if mp.IsFinished && !state.IsFinished {
+		state.IsFinished = true
+		finishedAt := now
+		if mp.FinishedAt > 0 {
+			finishedAt = time.UnixMilli(mp.FinishedAt)
+		}
+		state.FinishedAt = &finishedAt
+	}
+	if state.LastPlayedAt.IsZero() {
+		state.LastPlayedAt = now
+	}
+	state.UpdatedAt = now
+
+	return im.store.UpsertEpisodePlaybackState(ctx, state)
+}
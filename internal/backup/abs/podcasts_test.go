@@ -0,0 +1,151 @@
+package abs
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func (m *mockStore) GetPodcastByFeedURL(_ context.Context, libraryID, feedURL string) (*domain.Podcast, error) {
+	for _, p := range m.podcasts {
+		if p.LibraryID == libraryID && p.FeedURL == feedURL {
+			return p, nil
+		}
+	}
+	return nil, store.ErrPodcastNotFound
+}
+
+func (m *mockStore) CreatePodcast(_ context.Context, p *domain.Podcast) error {
+	m.podcasts = append(m.podcasts, p)
+	return nil
+}
+
+func (m *mockStore) GetPodcastEpisodeByGUID(_ context.Context, podcastID, guid string) (*domain.PodcastEpisode, error) {
+	for _, e := range m.episodes {
+		if e.PodcastID == podcastID && e.GUID == guid {
+			return e, nil
+		}
+	}
+	return nil, store.ErrEpisodeNotFound
+}
+
+func (m *mockStore) CreatePodcastEpisode(_ context.Context, e *domain.PodcastEpisode) error {
+	m.episodes = append(m.episodes, e)
+	return nil
+}
+
+func (m *mockStore) GetEpisodePlaybackState(_ context.Context, userID, episodeID string) (*domain.PlaybackState, error) {
+	if s, ok := m.episodeStates[domain.StateID(userID, episodeID)]; ok {
+		return s, nil
+	}
+	return nil, store.ErrProgressNotFound
+}
+
+func (m *mockStore) UpsertEpisodePlaybackState(_ context.Context, state *domain.PlaybackState) error {
+	m.episodeStates[domain.StateID(state.UserID, state.BookID)] = state
+	return nil
+}
+
+// podcastBackup has one podcast whose first episode was downloaded to
+// showDir, an episode without a GUID and a podcast without a feed.
+func podcastBackup(showDir string) *Backup {
+	return &Backup{
+		Users: []User{
+			{ID: "abs-root", Type: "root", EpisodeProgress: []MediaProgress{
+				{MediaItemID: "ep-1", CurrentTime: 1800, Duration: 1800, IsFinished: true, FinishedAt: 1700000900000, LastUpdate: 1700000900000},
+				{MediaItemID: "ep-2", CurrentTime: 300, Duration: 2400, LastUpdate: 1700001000000},
+			}},
+			{ID: "abs-bob", Type: "user", EpisodeProgress: []MediaProgress{
+				{MediaItemID: "ep-1", CurrentTime: 60},
+			}},
+		},
+		Podcasts: []Podcast{
+			{ID: "pod-1", Path: showDir, Title: "The Show", Author: "Host", FeedURL: "https://example.com/feed.xml",
+				AutoDownload: true, MaxEpisodesToKeep: 3, Episodes: []PodcastEpisode{
+					{ID: "ep-1", GUID: "guid-1", Title: "Pilot", PublishedAt: 1700000000000,
+						EnclosureURL: "https://cdn.example.com/1.mp3", Duration: 1800, Path: filepath.Join(showDir, "Pilot.mp3")},
+					{ID: "ep-2", Title: "Second", EnclosureURL: "https://cdn.example.com/2.mp3", Path: "/gone/Second.mp3"},
+				}},
+			{ID: "pod-2", Title: "Local only"},
+		},
+	}
+}
+
+func TestImportPodcasts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ms := newMockStore()
+	im := NewImporter(ms, nil, slog.New(slog.DiscardHandler))
+
+	showDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(showDir, "Pilot.mp3"), []byte("audio"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	lib := &domain.Library{ID: "lib-pods", Type: domain.LibraryTypePodcast, ScanPaths: []string{t.TempDir()}}
+	userMap := map[string]string{"abs-root": "lu-root"}
+
+	result, err := im.ImportPodcasts(ctx, podcastBackup(showDir), lib, userMap)
+	if err != nil {
+		t.Fatalf("ImportPodcasts: %v", err)
+	}
+	if result.PodcastsCreated != 1 || result.PodcastsSkipped != 1 || len(result.PodcastIDs) != 1 || len(result.Warnings) != 1 {
+		t.Errorf("podcasts: %+v", result)
+	}
+	if result.EpisodesCreated != 2 || result.EpisodesLinked != 1 {
+		t.Errorf("episodes created %d linked %d, want 2 and 1", result.EpisodesCreated, result.EpisodesLinked)
+	}
+	if result.ProgressImported != 2 || result.ProgressSkipped != 1 {
+		t.Errorf("progress imported %d skipped %d, want 2 and 1", result.ProgressImported, result.ProgressSkipped)
+	}
+
+	p := ms.podcasts[0]
+	if p.Dir != showDir || !p.AutoDownload || p.KeepEpisodes != 3 || p.LibraryID != lib.ID {
+		t.Errorf("podcast = %+v", p)
+	}
+	pilot, second := ms.episodes[0], ms.episodes[1]
+	if !pilot.IsDownloaded() || pilot.FileSize != 5 || pilot.DurationMs != 1800000 || pilot.PublishedAt == nil {
+		t.Errorf("downloaded episode = %+v", pilot)
+	}
+	if second.Status != domain.EpisodeStatusAvailable || second.GUID != "https://cdn.example.com/2.mp3" {
+		t.Errorf("missing download should be available, keyed by enclosure: %+v", second)
+	}
+
+	state := ms.episodeStates[domain.StateID("lu-root", pilot.ID)]
+	if state == nil || !state.IsFinished || state.FinishedAt == nil || state.FinishedAt.UnixMilli() != 1700000900000 {
+		t.Errorf("finished episode state = %+v", state)
+	}
+	state = ms.episodeStates[domain.StateID("lu-root", second.ID)]
+	if state == nil || state.IsFinished || state.CurrentPositionMs != 300000 {
+		t.Errorf("in-progress episode state = %+v", state)
+	}
+
+	// Importing again matches the subscription and its episodes.
+	again, err := im.ImportPodcasts(ctx, podcastBackup(showDir), lib, userMap)
+	if err != nil {
+		t.Fatalf("ImportPodcasts (again): %v", err)
+	}
+	if again.PodcastsCreated != 0 || again.EpisodesCreated != 0 || again.ProgressImported != 2 {
+		t.Errorf("second run = %+v", again)
+	}
+	if len(ms.podcasts) != 1 || len(ms.episodes) != 2 {
+		t.Errorf("after second run: %d podcasts, %d episodes", len(ms.podcasts), len(ms.episodes))
+	}
+}
+
+func TestImportPodcasts_RequiresPodcastLibrary(t *testing.T) {
+	t.Parallel()
+	im := NewImporter(newMockStore(), nil, slog.New(slog.DiscardHandler))
+
+	books := &domain.Library{ID: "lib-books", ScanPaths: []string{t.TempDir()}}
+	if _, err := im.ImportPodcasts(context.Background(), podcastBackup(""), books, nil); err == nil {
+		t.Error("expected an error importing podcasts into a book library")
+	}
+	if _, err := im.ImportPodcasts(context.Background(), podcastBackup(""), &domain.Library{Type: domain.LibraryTypePodcast}, nil); err == nil {
+		t.Error("expected an error importing podcasts into a library without a folder")
+	}
+}
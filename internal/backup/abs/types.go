@@ -70,6 +70,15 @@ type BookMatch struct {
 	Suggestions []BookSuggestion // Possible matches for admin review
 }
 
+// ExternalBook is a book from outside ListenUp known only by its metadata,
+// such as a row of a book-tracking service export.
+type ExternalBook struct {
+	ISBNs   []string // ISBN-10 and/or ISBN-13, hyphens allowed
+	ASIN    string
+	Title   string
+	Authors []string
+}
+
 // BookSuggestion is a suggested ListenUp book for an unmatched ABS item.
 type BookSuggestion struct {
 	BookID     string  // ListenUp book ID
@@ -149,10 +158,61 @@ type AnalysisResult struct {
 	ProgressReady   int `json:"progress_ready"`
 	ProgressPending int `json:"progress_pending"`
 
+	// Collections, playlists, tags and bookmarks
+	Library *LibraryPreview `json:"library"`
+
 	// Issues found during analysis
 	Warnings []string `json:"warnings,omitempty"`
 }
 
+// ShelfPreview shows how an ABS collection or playlist maps to a ListenUp shelf.
+type ShelfPreview struct {
+	ABSID          string `json:"abs_id"`
+	Name           string `json:"name"`
+	ABSUserID      string `json:"abs_user_id"`                // Playlist owner, or the root user for collections
+	ListenUpUserID string `json:"listenup_user_id,omitempty"` // Shelf owner once mapped
+	TotalBooks     int    `json:"total_books"`
+	MappedBooks    int    `json:"mapped_books"`
+}
+
+// IsReady returns true if the shelf has an owner and at least one book to hold.
+func (p *ShelfPreview) IsReady() bool {
+	return p.ListenUpUserID != "" && p.MappedBooks > 0
+}
+
+// TagPreview shows an ABS tag and the ListenUp tag slug it becomes.
+// ABS tags that normalize to the same slug are merged.
+type TagPreview struct {
+	Name        string `json:"name"` // First spelling seen in ABS
+	Slug        string `json:"slug"`
+	TotalBooks  int    `json:"total_books"`
+	MappedBooks int    `json:"mapped_books"`
+}
+
+// BookmarkPreview summarizes one ABS user's bookmarks.
+type BookmarkPreview struct {
+	ABSUserID      string `json:"abs_user_id"`
+	ABSUsername    string `json:"abs_username"`
+	ListenUpUserID string `json:"listenup_user_id,omitempty"`
+	Total          int    `json:"total"`
+	Ready          int    `json:"ready"` // User and book both mapped
+}
+
+// LibraryPreview shows what an import will do with collections, playlists,
+// tags and bookmarks under a given set of user and book mappings.
+type LibraryPreview struct {
+	Collections []ShelfPreview    `json:"collections"`
+	Playlists   []ShelfPreview    `json:"playlists"`
+	Tags        []TagPreview      `json:"tags"`
+	Bookmarks   []BookmarkPreview `json:"bookmarks"`
+
+	CollectionsReady int `json:"collections_ready"`
+	PlaylistsReady   int `json:"playlists_ready"`
+	TagsReady        int `json:"tags_ready"` // Tags with at least one mapped book
+	TotalBookmarks   int `json:"total_bookmarks"`
+	BookmarksReady   int `json:"bookmarks_ready"`
+}
+
 // ImportOptions configures the import execution.
 type ImportOptions struct {
 	// UserMappings are finalized ABS user ID → ListenUp user ID mappings.
@@ -172,16 +232,36 @@ type ImportOptions struct {
 	// ImportProgress imports current progress state.
 	// Default: true
 	ImportProgress bool
+
+	// ImportShelves turns collections and playlists into shelves.
+	// Default: true
+	ImportShelves bool
+
+	// ImportTags applies ABS item tags to the mapped books.
+	// Default: true
+	ImportTags bool
+
+	// ImportBookmarks copies user bookmarks.
+	// Default: true
+	ImportBookmarks bool
+
+	// ImportEbookPositions copies where users are in companion ebooks.
+	// Default: true
+	ImportEbookPositions bool
 }
 
 // DefaultImportOptions returns sensible defaults.
 func DefaultImportOptions() ImportOptions {
 	return ImportOptions{
-		UserMappings:   make(map[string]string),
-		BookMappings:   make(map[string]string),
-		SkipUnmatched:  true,
-		ImportSessions: true,
-		ImportProgress: true,
+		UserMappings:         make(map[string]string),
+		BookMappings:         make(map[string]string),
+		SkipUnmatched:        true,
+		ImportSessions:       true,
+		ImportProgress:       true,
+		ImportShelves:        true,
+		ImportTags:           true,
+		ImportBookmarks:      true,
+		ImportEbookPositions: true,
 	}
 }
 
@@ -195,10 +275,21 @@ type ImportResult struct {
 	EventsCreated            int `json:"events_created"`             // Total ListeningEvents created
 	ReadingSessionsCreated   int `json:"reading_sessions_created"`   // BookReadingSession records for readers section
 	ProgressOverridesApplied int `json:"progress_overrides_applied"` // Authoritative MediaProgress overrides applied
+	ShelvesCreated           int `json:"shelves_created"`            // From collections and playlists
+	ShelvesSkipped           int `json:"shelves_skipped"`            // Unmapped owner, no mapped books, or already imported
+	TagsCreated              int `json:"tags_created"`               // Tags that didn't exist in ListenUp yet
+	BookTagsApplied          int `json:"book_tags_applied"`
+	BookmarksImported        int `json:"bookmarks_imported"`
+	BookmarksSkipped         int `json:"bookmarks_skipped"` // Unmapped user or book, or already imported
+	EbookPositionsImported   int `json:"ebook_positions_imported"`
+	EbookPositionsSkipped    int `json:"ebook_positions_skipped"` // Unmapped, no ebook in ListenUp, or a newer position exists
 
 	// Users whose progress was affected (for rebuild)
 	AffectedUserIDs []string `json:"affected_user_ids"`
 
+	// Books that gained tags (for search reindex)
+	TaggedBookIDs []string `json:"tagged_book_ids"`
+
 	// Duration
 	Duration time.Duration `json:"duration"`
 
@@ -0,0 +1,64 @@
+// Package booktracker moves reading history between ListenUp and
+// book-tracking services such as Goodreads and StoryGraph, using the CSV
+// formats those services import and export.
+package booktracker
+
+import (
+	"errors"
+	"time"
+)
+
+// Format is a book-tracking service's CSV layout.
+type Format string
+
+// Supported CSV formats.
+const (
+	FormatGoodreads  Format = "goodreads"
+	FormatStoryGraph Format = "storygraph"
+)
+
+// Read statuses, as Goodreads exclusive shelves and StoryGraph read statuses.
+const (
+	StatusRead             = "read"
+	StatusCurrentlyReading = "currently-reading"
+	StatusToRead           = "to-read"
+)
+
+var (
+	// ErrUnknownFormat is returned for format names and CSV headers that
+	// match no supported format.
+	ErrUnknownFormat = errors.New("unrecognized format: expected a Goodreads or StoryGraph CSV export")
+
+	// ErrEmptyFile is returned when a CSV has no header row.
+	ErrEmptyFile = errors.New("CSV file is empty")
+)
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	switch Format(name) {
+	case FormatGoodreads, FormatStoryGraph:
+		return Format(name), nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Entry is one book of a reading history, the common ground of the
+// Goodreads and StoryGraph rows.
+type Entry struct {
+	Title       string
+	Authors     []string // Primary author first
+	ISBNs       []string // ISBN-10 and/or ISBN-13
+	ASIN        string
+	Publisher   string
+	PublishYear string
+
+	Status      string     // One of the Status constants
+	Rating      float64    // 0 when unrated
+	Review      string     // Optional written review
+	Shelves     []string   // Shelves or tags, besides the read status
+	ReadCount   int        // Times finished
+	DateAdded   *time.Time // First started, when known
+	DateStarted *time.Time // Start of the latest read, when known
+	DateRead    *time.Time // End of the latest read, when known
+}
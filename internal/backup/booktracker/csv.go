@@ -0,0 +1,326 @@
+package booktracker
+
+import (
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the date format both services use in their exports.
+const dateLayout = "2006/01/02"
+
+// goodreadsHeader is the header of a Goodreads library export.
+//
+//nolint:gochecknoglobals // Static CSV layout
+var goodreadsHeader = []string{
+	"Book Id", "Title", "Author", "Author l-f", "Additional Authors", "ISBN", "ISBN13",
+	"My Rating", "Average Rating", "Publisher", "Binding", "Number of Pages", "Year Published",
+	"Original Publication Year", "Date Read", "Date Added", "Bookshelves",
+	"Bookshelves with positions", "Exclusive Shelf", "My Review", "Spoiler", "Private Notes",
+	"Read Count", "Owned Copies",
+}
+
+// storyGraphHeader is the header of a StoryGraph library export.
+//
+//nolint:gochecknoglobals // Static CSV layout
+var storyGraphHeader = []string{
+	"Title", "Authors", "Contributors", "ISBN/UID", "Format", "Read Status", "Date Added",
+	"Last Date Read", "Dates Read", "Read Count", "Moods", "Pace", "Character- or Plot-Driven?",
+	"Strong Character Development?", "Loveable Characters?", "Diverse Characters?",
+	"Flawed Characters?", "Star Rating", "Review", "Content Warnings",
+	"Content Warning Description", "Tags", "Owned?",
+}
+
+// seriesSuffixRe matches the series Goodreads appends to titles, as in
+// "The Way of Kings (The Stormlight Archive, #1)".
+var seriesSuffixRe = regexp.MustCompile(`\s*\([^()]*#\d[^()]*\)\s*$`)
+
+// Write writes entries as a CSV in the given format.
+func Write(w io.Writer, format Format, entries []Entry) error {
+	header, row := goodreadsHeader, goodreadsRow
+	if format == FormatStoryGraph {
+		header, row = storyGraphHeader, storyGraphRow
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for i := range entries {
+		if err := cw.Write(row(&entries[i])); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// goodreadsRow renders an entry as a Goodreads row. Goodreads has no ASIN
+// column but looks ASINs up from the ISBN column.
+func goodreadsRow(e *Entry) []string {
+	isbn10, isbn13 := splitISBNs(e.ISBNs)
+	if isbn10 == "" && isbn13 == "" {
+		isbn10 = e.ASIN
+	}
+
+	var author, authorLF, additional string
+	if len(e.Authors) > 0 {
+		author = e.Authors[0]
+		authorLF = lastFirst(author)
+		additional = strings.Join(e.Authors[1:], ", ")
+	}
+
+	rating := ""
+	if e.Rating > 0 {
+		rating = strconv.Itoa(int(math.Round(e.Rating)))
+	}
+
+	return []string{
+		"", e.Title, author, authorLF, additional, `="` + isbn10 + `"`, `="` + isbn13 + `"`,
+		rating, "", e.Publisher, "Audiobook", "", e.PublishYear,
+		"", formatDate(e.DateRead), formatDate(e.DateAdded), strings.Join(e.Shelves, ", "),
+		"", e.Status, e.Review, "", "",
+		strconv.Itoa(e.ReadCount), "0",
+	}
+}
+
+// storyGraphRow renders an entry as a StoryGraph row.
+func storyGraphRow(e *Entry) []string {
+	isbn10, isbn13 := splitISBNs(e.ISBNs)
+	uid := cmp.Or(isbn13, isbn10, e.ASIN)
+
+	rating := ""
+	if e.Rating > 0 {
+		rating = strconv.FormatFloat(e.Rating, 'f', -1, 64)
+	}
+
+	datesRead := formatDate(e.DateRead)
+	if e.DateStarted != nil && e.DateRead != nil {
+		datesRead = formatDate(e.DateStarted) + "-" + datesRead
+	}
+
+	return []string{
+		e.Title, strings.Join(e.Authors, ", "), "", uid, "audio", e.Status, formatDate(e.DateAdded),
+		formatDate(e.DateRead), datesRead, strconv.Itoa(e.ReadCount), "", "", "",
+		"", "", "",
+		"", rating, e.Review, "",
+		"", strings.Join(e.Shelves, ", "), "",
+	}
+}
+
+// Parse reads a Goodreads or StoryGraph CSV export, detecting which from
+// its header.
+func Parse(r io.Reader) (Format, []Entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return "", nil, ErrEmptyFile
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	var format Format
+	var parseRow func(get func(string) string) Entry
+	switch {
+	case hasColumn(columns, "Exclusive Shelf"):
+		format, parseRow = FormatGoodreads, parseGoodreadsRow
+	case hasColumn(columns, "Read Status"):
+		format, parseRow = FormatStoryGraph, parseStoryGraphRow
+	default:
+		return "", nil, ErrUnknownFormat
+	}
+
+	var entries []Entry
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("read row %d: %w", len(entries)+1, err)
+		}
+
+		get := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		entries = append(entries, parseRow(get))
+	}
+
+	return format, entries, nil
+}
+
+// parseGoodreadsRow reads a row of a Goodreads export.
+func parseGoodreadsRow(get func(string) string) Entry {
+	isbns, asin := identifiers(get("ISBN"), get("ISBN13"))
+	status := get("Exclusive Shelf")
+
+	return Entry{
+		Title:       cleanTitle(get("Title")),
+		Authors:     slices.Concat(splitList(get("Author")), splitList(get("Additional Authors"))),
+		ISBNs:       isbns,
+		ASIN:        asin,
+		Publisher:   get("Publisher"),
+		PublishYear: get("Year Published"),
+		Status:      status,
+		Rating:      parseRating(get("My Rating")),
+		Review:      get("My Review"),
+		Shelves: slices.DeleteFunc(splitList(get("Bookshelves")), func(shelf string) bool {
+			return shelf == status || shelf == StatusRead || shelf == StatusCurrentlyReading || shelf == StatusToRead
+		}),
+		ReadCount: parseCount(get("Read Count")),
+		DateAdded: parseDate(get("Date Added")),
+		DateRead:  parseDate(get("Date Read")),
+	}
+}
+
+// parseStoryGraphRow reads a row of a StoryGraph export. "Dates Read" lists
+// every read as "start-end"; the latest read's start is kept.
+func parseStoryGraphRow(get func(string) string) Entry {
+	isbns, asin := identifiers(get("ISBN/UID"))
+
+	var started *time.Time
+	if reads := splitList(get("Dates Read")); len(reads) > 0 {
+		if start, _, isRange := strings.Cut(reads[len(reads)-1], "-"); isRange {
+			started = parseDate(start)
+		}
+	}
+
+	return Entry{
+		Title:       cleanTitle(get("Title")),
+		Authors:     splitList(get("Authors")),
+		ISBNs:       isbns,
+		ASIN:        asin,
+		Status:      get("Read Status"),
+		Rating:      parseRating(get("Star Rating")),
+		Review:      get("Review"),
+		Shelves:     splitList(get("Tags")),
+		ReadCount:   parseCount(get("Read Count")),
+		DateAdded:   parseDate(get("Date Added")),
+		DateStarted: started,
+		DateRead:    parseDate(get("Last Date Read")),
+	}
+}
+
+// identifiers sorts ISBN column values into ISBNs and an ASIN. Goodreads
+// wraps them in ="…" so spreadsheets keep leading zeros.
+func identifiers(values ...string) (isbns []string, asin string) {
+	for _, v := range values {
+		v = strings.Trim(strings.TrimPrefix(v, "="), `"`)
+		switch {
+		case v == "":
+		case isASIN(v):
+			asin = v
+		default:
+			isbns = append(isbns, v)
+		}
+	}
+	return isbns, asin
+}
+
+// isASIN reports whether an identifier is an Amazon ASIN rather than an ISBN.
+func isASIN(v string) bool {
+	return len(v) == 10 && strings.HasPrefix(strings.ToUpper(v), "B0")
+}
+
+// splitISBNs picks the ISBN-10 and ISBN-13 out of a list of ISBNs.
+func splitISBNs(isbns []string) (isbn10, isbn13 string) {
+	for _, isbn := range isbns {
+		digits := strings.NewReplacer("-", "", " ", "").Replace(isbn)
+		switch len(digits) {
+		case 10:
+			isbn10 = cmp.Or(isbn10, digits)
+		case 13:
+			isbn13 = cmp.Or(isbn13, digits)
+		}
+	}
+	return isbn10, isbn13
+}
+
+// cleanTitle strips the series Goodreads appends to titles.
+func cleanTitle(title string) string {
+	return seriesSuffixRe.ReplaceAllString(title, "")
+}
+
+// lastFirst turns "Brandon Sanderson" into "Sanderson, Brandon".
+func lastFirst(name string) string {
+	i := strings.LastIndex(name, " ")
+	if i < 0 {
+		return name
+	}
+	return name[i+1:] + ", " + name[:i]
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseRating reads a star rating. Blank, zero and malformed ratings are 0.
+func parseRating(s string) float64 {
+	rating, err := strconv.ParseFloat(s, 64)
+	if err != nil || rating < 0 {
+		return 0
+	}
+	return rating
+}
+
+// parseCount reads a non-negative count. Blank and malformed counts are 0.
+func parseCount(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// parseDate reads a date as local midnight. Dashes are accepted as well as
+// slashes; blank and malformed dates are nil.
+func parseDate(s string) *time.Time {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "-", "/")
+	t, err := time.ParseInLocation(dateLayout, s, time.Local)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// formatDate renders a date in local time, or "" for nil.
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(time.Local).Format(dateLayout)
+}
+
+// hasColumn reports whether a header has the named column.
+func hasColumn(columns map[string]int, name string) bool {
+	_, ok := columns[name]
+	return ok
+}
@@ -0,0 +1,130 @@
+package booktracker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goodreadsCSV = "\ufeffBook Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating,Average Rating,Publisher,Binding,Number of Pages,Year Published,Original Publication Year,Date Read,Date Added,Bookshelves,Bookshelves with positions,Exclusive Shelf,My Review,Spoiler,Private Notes,Read Count,Owned Copies\n" +
+	`7235533,"The Way of Kings (The Stormlight Archive, #1)",Brandon Sanderson,"Sanderson, Brandon",,"=""0765326353""","=""9780765326355""",5,4.64,Tor Books,Audiobook,1007,2010,2010,2024/03/15,2024/01/02,"favorites, epic-fantasy","favorites (#3), epic-fantasy (#1)",read,Loved it,,,2,0` + "\n" +
+	`54493401,Project Hail Mary,Andy Weir,"Weir, Andy",,"=""""","=""""",0,4.52,Ballantine,Kindle Edition,496,2021,2021,,2024/05/01,to-read,to-read (#12),to-read,,,,0,0` + "\n"
+
+const storyGraphCSV = `Title,Authors,Contributors,ISBN/UID,Format,Read Status,Date Added,Last Date Read,Dates Read,Read Count,Moods,Pace,Character- or Plot-Driven?,Strong Character Development?,Loveable Characters?,Diverse Characters?,Flawed Characters?,Star Rating,Review,Content Warnings,Content Warning Description,Tags,Owned?
+Good Omens,"Terry Pratchett, Neil Gaiman",,B0031RS50E,audio,read,2023/11/20,2024/02/10,"2023/12/01-2023/12/20, 2024/01/15-2024/02/10",2,funny,fast,Plot,Yes,Yes,No,Yes,4.25,,,,"comfort-reads, buddy-read",No
+`
+
+func TestParse_Goodreads(t *testing.T) {
+	format, entries, err := Parse(strings.NewReader(goodreadsCSV))
+	require.NoError(t, err)
+	assert.Equal(t, FormatGoodreads, format)
+	require.Len(t, entries, 2)
+
+	kings := entries[0]
+	assert.Equal(t, "The Way of Kings", kings.Title, "series suffix is stripped")
+	assert.Equal(t, []string{"Brandon Sanderson"}, kings.Authors)
+	assert.Equal(t, []string{"0765326353", "9780765326355"}, kings.ISBNs)
+	assert.Empty(t, kings.ASIN)
+	assert.Equal(t, StatusRead, kings.Status)
+	assert.InDelta(t, 5.0, kings.Rating, 0)
+	assert.Equal(t, "Loved it", kings.Review)
+	assert.Equal(t, []string{"favorites", "epic-fantasy"}, kings.Shelves)
+	assert.Equal(t, 2, kings.ReadCount)
+	require.NotNil(t, kings.DateRead)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local), *kings.DateRead)
+	require.NotNil(t, kings.DateAdded)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local), *kings.DateAdded)
+
+	hailMary := entries[1]
+	assert.Equal(t, StatusToRead, hailMary.Status)
+	assert.Empty(t, hailMary.ISBNs)
+	assert.Zero(t, hailMary.Rating)
+	assert.Empty(t, hailMary.Shelves, "the status shelf isn't a shelf")
+	assert.Nil(t, hailMary.DateRead)
+}
+
+func TestParse_StoryGraph(t *testing.T) {
+	format, entries, err := Parse(strings.NewReader(storyGraphCSV))
+	require.NoError(t, err)
+	assert.Equal(t, FormatStoryGraph, format)
+	require.Len(t, entries, 1)
+
+	omens := entries[0]
+	assert.Equal(t, []string{"Terry Pratchett", "Neil Gaiman"}, omens.Authors)
+	assert.Equal(t, "B0031RS50E", omens.ASIN)
+	assert.Empty(t, omens.ISBNs)
+	assert.InDelta(t, 4.25, omens.Rating, 0)
+	assert.Equal(t, []string{"comfort-reads", "buddy-read"}, omens.Shelves)
+	require.NotNil(t, omens.DateStarted)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local), *omens.DateStarted)
+	require.NotNil(t, omens.DateRead)
+	assert.Equal(t, time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local), *omens.DateRead)
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, _, err := Parse(strings.NewReader(""))
+	require.ErrorIs(t, err, ErrEmptyFile)
+
+	_, _, err = Parse(strings.NewReader("name,email\nalice,alice@example.com\n"))
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	started := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+	finished := time.Date(2024, 6, 20, 0, 0, 0, 0, time.Local)
+	entries := []Entry{
+		{
+			Title:       "The Way of Kings",
+			Authors:     []string{"Brandon Sanderson"},
+			ISBNs:       []string{"978-0-7653-2635-5"},
+			PublishYear: "2010",
+			Status:      StatusRead,
+			Rating:      4.5,
+			Shelves:     []string{"favorites"},
+			ReadCount:   1,
+			DateAdded:   &started,
+			DateStarted: &started,
+			DateRead:    &finished,
+		},
+		{
+			Title:     "Good Omens",
+			Authors:   []string{"Terry Pratchett", "Neil Gaiman"},
+			ASIN:      "B0031RS50E",
+			Status:    StatusRead,
+			ReadCount: 1,
+			DateRead:  &finished,
+		},
+	}
+
+	for _, format := range []Format{FormatGoodreads, FormatStoryGraph} {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, format, entries))
+
+		parsed, got, err := Parse(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, format, parsed)
+		require.Len(t, got, 2)
+
+		assert.Equal(t, "The Way of Kings", got[0].Title)
+		assert.Equal(t, []string{"9780765326355"}, got[0].ISBNs)
+		assert.Equal(t, []string{"favorites"}, got[0].Shelves)
+		assert.Equal(t, 1, got[0].ReadCount)
+		require.NotNil(t, got[0].DateRead)
+		assert.Equal(t, finished, *got[0].DateRead)
+
+		assert.Equal(t, []string{"Terry Pratchett", "Neil Gaiman"}, got[1].Authors)
+		assert.Equal(t, "B0031RS50E", got[1].ASIN)
+		assert.Zero(t, got[1].Rating)
+	}
+
+	// Goodreads ratings are whole stars
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatGoodreads, entries[:1]))
+	_, got, err := Parse(&buf)
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, got[0].Rating, 0)
+}
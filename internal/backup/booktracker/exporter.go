@@ -0,0 +1,156 @@
+package booktracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/listenupapp/listenup-server/internal/util"
+)
+
+// Exporter builds a user's reading history for export.
+type Exporter struct {
+	store  store.Store
+	logger *slog.Logger
+}
+
+// NewExporter creates an exporter.
+func NewExporter(s store.Store, logger *slog.Logger) *Exporter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Exporter{
+		store:  s,
+		logger: logger,
+	}
+}
+
+// finishedBook collects a book's completed reading sessions.
+type finishedBook struct {
+	bookID    string
+	reads     int
+	firstRead time.Time  // Start of the earliest read
+	started   time.Time  // Start of the latest read
+	finished  *time.Time // End of the latest read
+}
+
+// Entries returns one entry per book the user has finished, most recently
+// finished first, with their rating, review and shelves. Books since deleted
+// from the library are left out.
+func (e *Exporter) Entries(ctx context.Context, userID string) ([]Entry, error) {
+	sessions, err := e.store.GetUserReadingSessions(ctx, userID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get reading sessions: %w", err)
+	}
+
+	byBook := make(map[string]*finishedBook)
+	for _, session := range sessions {
+		if !session.IsCompleted || session.FinishedAt == nil {
+			continue
+		}
+		fb := byBook[session.BookID]
+		if fb == nil {
+			fb = &finishedBook{bookID: session.BookID, firstRead: session.StartedAt}
+			byBook[session.BookID] = fb
+		}
+		fb.reads++
+		if session.StartedAt.Before(fb.firstRead) {
+			fb.firstRead = session.StartedAt
+		}
+		if fb.finished == nil || session.FinishedAt.After(*fb.finished) {
+			fb.started, fb.finished = session.StartedAt, session.FinishedAt
+		}
+	}
+
+	books := make([]*finishedBook, 0, len(byBook))
+	for _, fb := range byBook {
+		books = append(books, fb)
+	}
+	slices.SortFunc(books, func(a, b *finishedBook) int {
+		return b.finished.Compare(*a.finished)
+	})
+
+	shelves, err := e.shelvesByBook(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	authorsByBook, err := e.store.ListAllBookContributorNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get author names: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(books))
+	for _, fb := range books {
+		book, err := e.store.GetBookByID(ctx, fb.bookID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get book %s: %w", fb.bookID, err)
+		}
+
+		entry := Entry{
+			Title:       book.Title,
+			Authors:     authorsByBook[book.ID],
+			ASIN:        book.ASIN,
+			Publisher:   book.Publisher,
+			PublishYear: book.PublishYear,
+			Status:      StatusRead,
+			Shelves:     shelves[book.ID],
+			ReadCount:   fb.reads,
+			DateAdded:   &fb.firstRead,
+			DateStarted: &fb.started,
+			DateRead:    fb.finished,
+		}
+		if book.ISBN != "" {
+			entry.ISBNs = []string{book.ISBN}
+		}
+
+		review, err := e.store.GetReviewForUserBook(ctx, userID, book.ID)
+		switch {
+		case err == nil:
+			entry.Rating = review.Rating
+			entry.Review = review.Text
+		case !errors.Is(err, store.ErrReviewNotFound):
+			return nil, fmt.Errorf("get review for book %s: %w", book.ID, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	e.logger.Info("built reading history export",
+		"user_id", userID,
+		"books", len(entries))
+
+	return entries, nil
+}
+
+// shelvesByBook maps book IDs to the slugs of the user's shelves holding
+// them. Smart shelves are left out: their contents follow rules, not choices.
+func (e *Exporter) shelvesByBook(ctx context.Context, userID string) (map[string][]string, error) {
+	shelves, err := e.store.ListShelvesByOwner(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list shelves: %w", err)
+	}
+
+	byBook := make(map[string][]string)
+	for _, shelf := range shelves {
+		if shelf.IsSmart() {
+			continue
+		}
+		// Tracker shelves are slugs, like ListenUp tags
+		name := util.NormalizeTagSlug(shelf.Name)
+		if name == "" {
+			continue
+		}
+		for _, bookID := range shelf.BookIDs {
+			byBook[bookID] = append(byBook[bookID], name)
+		}
+	}
+	return byBook, nil
+}
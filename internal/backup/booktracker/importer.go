@@ -0,0 +1,347 @@
+package booktracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/backup/abs"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// fuzzyThreshold is the minimum title similarity for a fuzzy match.
+const fuzzyThreshold = 0.85
+
+// EventEmitter broadcasts SSE events to connected clients.
+// Small interface for dependency injection (satisfied by sse.Manager).
+type EventEmitter interface {
+	Emit(event any)
+}
+
+// ItemStatus says what importing a previewed row would do.
+type ItemStatus string
+
+// Preview item statuses.
+const (
+	ItemMatched         ItemStatus = "matched"          // Matched to a book, ready to import
+	ItemUnmatched       ItemStatus = "unmatched"        // No book found, see the suggestions
+	ItemAlreadyFinished ItemStatus = "already_finished" // Matched book is already finished
+	ItemNotRead         ItemStatus = "not_read"         // Not on the read shelf, nothing to import
+)
+
+// PreviewItem is a CSV row with the book it matched.
+type PreviewItem struct {
+	Row         int // 1-based, not counting the header
+	Entry       Entry
+	Status      ItemStatus
+	BookID      string // Matched book, empty when unmatched
+	BookTitle   string
+	Confidence  abs.MatchConfidence
+	MatchReason string
+	Suggestions []abs.BookSuggestion // Possible books for unmatched rows
+}
+
+// Preview is what importing a CSV would do, for the user to review before
+// confirming with Import.
+type Preview struct {
+	Format          Format
+	Items           []PreviewItem
+	Matched         int
+	Unmatched       int
+	AlreadyFinished int
+	NotRead         int
+}
+
+// ImportItem is a confirmed book to mark finished.
+type ImportItem struct {
+	BookID     string
+	StartedAt  *time.Time // Optional, defaults to FinishedAt
+	FinishedAt *time.Time // Optional, defaults to now
+}
+
+// ImportResult summarizes an import.
+type ImportResult struct {
+	Imported        int // Books marked finished
+	AlreadyFinished int // Books that were finished already
+	Skipped         int // Books that don't exist or the user can't access
+}
+
+// Importer marks books from a book-tracking service's export as finished.
+// Use Preview first to match rows to books.
+type Importer struct {
+	store  store.Store
+	events EventEmitter
+	logger *slog.Logger
+}
+
+// NewImporter creates an importer.
+// The events parameter is optional - if nil, SSE events won't be emitted.
+func NewImporter(s store.Store, events EventEmitter, logger *slog.Logger) *Importer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Importer{
+		store:  s,
+		events: events,
+		logger: logger,
+	}
+}
+
+// Preview matches the read rows of an export to the books the user can
+// access: by ISBN, then ASIN, then fuzzy title and author.
+func (im *Importer) Preview(ctx context.Context, userID string, format Format, entries []Entry) (*Preview, error) {
+	accessible, err := im.store.GetAccessibleBookIDSet(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get accessible books: %w", err)
+	}
+
+	matcher := abs.NewMatcher(im.store, im.logger, abs.AnalysisOptions{
+		FuzzyMatchBooks: true,
+		FuzzyThreshold:  fuzzyThreshold,
+	})
+	matcher.PreloadBooks(ctx)
+	matcher.RestrictBooks(accessible)
+
+	preview := &Preview{
+		Format: format,
+		Items:  make([]PreviewItem, 0, len(entries)),
+	}
+
+	for i, entry := range entries {
+		item := PreviewItem{Row: i + 1, Entry: entry}
+
+		if entry.Status != StatusRead {
+			item.Status = ItemNotRead
+			preview.NotRead++
+			preview.Items = append(preview.Items, item)
+			continue
+		}
+
+		match := matcher.MatchExternalBook(abs.ExternalBook{
+			ISBNs:   entry.ISBNs,
+			ASIN:    entry.ASIN,
+			Title:   entry.Title,
+			Authors: entry.Authors,
+		})
+		item.Confidence = match.Confidence
+		item.MatchReason = match.MatchReason
+		item.Suggestions = match.Suggestions
+
+		if match.ListenUpID == "" {
+			item.Status = ItemUnmatched
+			preview.Unmatched++
+			preview.Items = append(preview.Items, item)
+			continue
+		}
+
+		book, err := im.store.GetBookByID(ctx, match.ListenUpID)
+		if err != nil {
+			return nil, fmt.Errorf("get book %s: %w", match.ListenUpID, err)
+		}
+		item.BookID = book.ID
+		item.BookTitle = book.Title
+
+		state, err := im.store.GetState(ctx, userID, book.ID)
+		if err != nil && !errors.Is(err, store.ErrProgressNotFound) {
+			return nil, fmt.Errorf("get state: %w", err)
+		}
+		if state != nil && state.IsFinished {
+			item.Status = ItemAlreadyFinished
+			preview.AlreadyFinished++
+		} else {
+			item.Status = ItemMatched
+			preview.Matched++
+		}
+		preview.Items = append(preview.Items, item)
+	}
+
+	im.logger.Info("previewed reading history import",
+		"user_id", userID,
+		"format", format,
+		"rows", len(entries),
+		"matched", preview.Matched,
+		"unmatched", preview.Unmatched,
+		"already_finished", preview.AlreadyFinished)
+
+	return preview, nil
+}
+
+// Import marks the confirmed books finished for the user. Each gets an
+// import listening event, a finished playback state and a completed reading
+// session. Books already finished are left alone, so importing twice is safe.
+func (im *Importer) Import(ctx context.Context, userID string, format Format, items []ImportItem) (*ImportResult, error) {
+	result := &ImportResult{}
+
+	for _, item := range items {
+		imported, err := im.importItem(ctx, userID, format, item)
+		if err != nil {
+			return result, fmt.Errorf("import book %s: %w", item.BookID, err)
+		}
+		switch imported {
+		case ItemMatched:
+			result.Imported++
+		case ItemAlreadyFinished:
+			result.AlreadyFinished++
+		default:
+			result.Skipped++
+		}
+	}
+
+	if result.Imported > 0 {
+		if err := im.store.IncrementBooksFinishedAtomic(ctx, userID, result.Imported); err != nil {
+			im.logger.Warn("failed to update books finished after import", "user_id", userID, "error", err)
+		}
+	}
+
+	im.logger.Info("imported reading history",
+		"user_id", userID,
+		"format", format,
+		"imported", result.Imported,
+		"already_finished", result.AlreadyFinished,
+		"skipped", result.Skipped)
+
+	return result, nil
+}
+
+// importItem marks one book finished. It returns ItemMatched when the book
+// was imported, ItemAlreadyFinished when it was finished already and
+// ItemUnmatched when it was skipped.
+func (im *Importer) importItem(ctx context.Context, userID string, format Format, item ImportItem) (ItemStatus, error) {
+	canAccess, err := im.store.CanUserAccessBook(ctx, userID, item.BookID)
+	if err != nil {
+		return "", fmt.Errorf("check access: %w", err)
+	}
+	if !canAccess {
+		im.logger.Warn("skipping inaccessible book in import", "user_id", userID, "book_id", item.BookID)
+		return ItemUnmatched, nil
+	}
+
+	book, err := im.store.GetBookByID(ctx, item.BookID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ItemUnmatched, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get book: %w", err)
+	}
+
+	state, err := im.store.GetState(ctx, userID, book.ID)
+	if err != nil && !errors.Is(err, store.ErrProgressNotFound) {
+		return "", fmt.Errorf("get state: %w", err)
+	}
+	if state != nil && state.IsFinished {
+		return ItemAlreadyFinished, nil
+	}
+
+	now := time.Now()
+	finishedAt := now
+	if item.FinishedAt != nil && item.FinishedAt.Before(now) {
+		finishedAt = *item.FinishedAt
+	}
+	startedAt := finishedAt
+	if item.StartedAt != nil && item.StartedAt.Before(finishedAt) {
+		startedAt = *item.StartedAt
+	}
+
+	// The event records the finish, not listening: trackers know a book
+	// was read but not for how long, so DurationMs stays zero and imports
+	// add no listening time to stats.
+	eventID, err := id.Generate("evt")
+	if err != nil {
+		return "", fmt.Errorf("generate event ID: %w", err)
+	}
+	var startPositionMs int64
+	if state != nil {
+		startPositionMs = state.CurrentPositionMs
+	}
+	event := &domain.ListeningEvent{
+		ID:              eventID,
+		UserID:          userID,
+		BookID:          book.ID,
+		StartPositionMs: startPositionMs,
+		EndPositionMs:   book.TotalDuration,
+		StartedAt:       finishedAt,
+		EndedAt:         finishedAt,
+		PlaybackSpeed:   1.0,
+		DeviceID:        string(format) + "-import",
+		DeviceName:      formatName(format) + " Import",
+		Source:          domain.EventSourceImport,
+		CreatedAt:       now,
+	}
+	if err := im.store.CreateListeningEvent(ctx, event); err != nil {
+		return "", fmt.Errorf("create listening event: %w", err)
+	}
+
+	if state == nil {
+		state = &domain.PlaybackState{
+			UserID:       userID,
+			BookID:       book.ID,
+			StartedAt:    startedAt,
+			LastPlayedAt: finishedAt,
+		}
+	}
+	state.CurrentPositionMs = book.TotalDuration
+	state.IsFinished = true
+	state.FinishedAt = &finishedAt
+	state.UpdatedAt = now
+	if err := im.store.UpsertState(ctx, state); err != nil {
+		return "", fmt.Errorf("upsert state: %w", err)
+	}
+
+	session, err := im.completeReadingSession(ctx, userID, book.ID, startedAt, finishedAt)
+	if err != nil {
+		return "", err
+	}
+
+	if im.events != nil {
+		im.events.Emit(sse.NewProgressUpdatedEvent(userID, state, book.TotalDuration))
+		im.events.Emit(sse.NewReadingSessionUpdatedEvent(session))
+	}
+
+	return ItemMatched, nil
+}
+
+// completeReadingSession completes the user's active reading session of the
+// book at finishedAt, or records a completed one when none is active.
+func (im *Importer) completeReadingSession(ctx context.Context, userID, bookID string, startedAt, finishedAt time.Time) (*domain.BookReadingSession, error) {
+	session, err := im.store.GetActiveSession(ctx, userID, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("get active session: %w", err)
+	}
+
+	if session != nil {
+		session.MarkCompleted(1.0, session.ListenTimeMs)
+		if finishedAt.After(session.StartedAt) {
+			session.FinishedAt = &finishedAt
+		}
+		if err := im.store.UpdateReadingSession(ctx, session); err != nil {
+			return nil, fmt.Errorf("update reading session: %w", err)
+		}
+		return session, nil
+	}
+
+	sessionID, err := id.Generate("rsession")
+	if err != nil {
+		return nil, fmt.Errorf("generate session ID: %w", err)
+	}
+	session = domain.NewBookReadingSession(sessionID, userID, bookID)
+	session.StartedAt = startedAt
+	session.MarkCompleted(1.0, 0)
+	session.FinishedAt = &finishedAt
+	if err := im.store.CreateReadingSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("create reading session: %w", err)
+	}
+	return session, nil
+}
+
+// formatName returns a format's display name.
+func formatName(format Format) string {
+	if format == FormatStoryGraph {
+		return "StoryGraph"
+	}
+	return "Goodreads"
+}
@@ -0,0 +1,239 @@
+package booktracker
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStore embeds the Store interface; only methods used in tests are implemented.
+type mockStore struct {
+	store.Store
+	books      map[string]*domain.Book
+	authors    map[string][]string
+	accessible map[string]bool
+	states     map[string]*domain.PlaybackState
+	events     []*domain.ListeningEvent
+	sessions   []*domain.BookReadingSession
+	shelves    []*domain.Shelf
+	reviews    map[string]*domain.BookReview // book ID -> review
+	finished   int
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{
+		books: map[string]*domain.Book{
+			"book-1": {Syncable: domain.Syncable{ID: "book-1"}, Title: "The Way of Kings", ISBN: "9780765326355", TotalDuration: 3_600_000},
+			"book-2": {Syncable: domain.Syncable{ID: "book-2"}, Title: "Good Omens", ASIN: "B0031RS50E", TotalDuration: 3_600_000},
+			"book-3": {Syncable: domain.Syncable{ID: "book-3"}, Title: "The Martian", TotalDuration: 3_600_000},
+			"book-4": {Syncable: domain.Syncable{ID: "book-4"}, Title: "Secret Book", TotalDuration: 3_600_000},
+		},
+		authors: map[string][]string{
+			"book-1": {"Brandon Sanderson"},
+			"book-2": {"Terry Pratchett", "Neil Gaiman"},
+			"book-3": {"Andy Weir"},
+		},
+		accessible: map[string]bool{"book-1": true, "book-2": true, "book-3": true},
+		states:     make(map[string]*domain.PlaybackState),
+		reviews:    make(map[string]*domain.BookReview),
+	}
+}
+
+func (m *mockStore) ListAllBooks(_ context.Context) ([]*domain.Book, error) {
+	books := make([]*domain.Book, 0, len(m.books))
+	for _, b := range m.books {
+		books = append(books, b)
+	}
+	return books, nil
+}
+
+func (m *mockStore) ListAllBookContributorNames(_ context.Context) (map[string][]string, error) {
+	return m.authors, nil
+}
+
+func (m *mockStore) GetAccessibleBookIDSet(_ context.Context, _ string) (map[string]bool, error) {
+	return m.accessible, nil
+}
+
+func (m *mockStore) CanUserAccessBook(_ context.Context, _, bookID string) (bool, error) {
+	return m.accessible[bookID], nil
+}
+
+func (m *mockStore) GetBookByID(_ context.Context, bookID string) (*domain.Book, error) {
+	if b, ok := m.books[bookID]; ok {
+		return b, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+func (m *mockStore) GetState(_ context.Context, userID, bookID string) (*domain.PlaybackState, error) {
+	if s, ok := m.states[domain.StateID(userID, bookID)]; ok {
+		return s, nil
+	}
+	return nil, store.ErrProgressNotFound
+}
+
+func (m *mockStore) UpsertState(_ context.Context, state *domain.PlaybackState) error {
+	m.states[domain.StateID(state.UserID, state.BookID)] = state
+	return nil
+}
+
+func (m *mockStore) CreateListeningEvent(_ context.Context, event *domain.ListeningEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockStore) GetActiveSession(_ context.Context, userID, bookID string) (*domain.BookReadingSession, error) {
+	for _, s := range m.sessions {
+		if s.UserID == userID && s.BookID == bookID && s.IsActive() {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockStore) CreateReadingSession(_ context.Context, session *domain.BookReadingSession) error {
+	m.sessions = append(m.sessions, session)
+	return nil
+}
+
+func (m *mockStore) UpdateReadingSession(_ context.Context, _ *domain.BookReadingSession) error {
+	return nil
+}
+
+func (m *mockStore) GetUserReadingSessions(_ context.Context, _ string, _ int) ([]*domain.BookReadingSession, error) {
+	return m.sessions, nil
+}
+
+func (m *mockStore) IncrementBooksFinishedAtomic(_ context.Context, _ string, delta int) error {
+	m.finished += delta
+	return nil
+}
+
+func (m *mockStore) ListShelvesByOwner(_ context.Context, _ string) ([]*domain.Shelf, error) {
+	return m.shelves, nil
+}
+
+func (m *mockStore) GetReviewForUserBook(_ context.Context, _, bookID string) (*domain.BookReview, error) {
+	if r, ok := m.reviews[bookID]; ok {
+		return r, nil
+	}
+	return nil, store.ErrReviewNotFound
+}
+
+func TestImporter_PreviewAndImport(t *testing.T) {
+	ctx := context.Background()
+	s := newMockStore()
+	finishedAt := time.Now().Add(-48 * time.Hour)
+	s.states[domain.StateID("user-1", "book-3")] = &domain.PlaybackState{
+		UserID: "user-1", BookID: "book-3", IsFinished: true, FinishedAt: &finishedAt,
+	}
+	im := NewImporter(s, nil, slog.New(slog.DiscardHandler))
+
+	entries := []Entry{
+		{Title: "Kings", ISBNs: []string{"978-0-7653-2635-5"}, Status: StatusRead},
+		{Title: "Good Omens", Authors: []string{"Neil Gaiman"}, Status: StatusRead},
+		{Title: "The Martian", Authors: []string{"Andy Weir"}, Status: StatusRead},
+		{Title: "Secret Book", Status: StatusRead},
+		{Title: "The Way of Kings", Status: StatusToRead},
+	}
+
+	preview, err := im.Preview(ctx, "user-1", FormatGoodreads, entries)
+	require.NoError(t, err)
+	require.Len(t, preview.Items, 5)
+	assert.Equal(t, 2, preview.Matched)
+	assert.Equal(t, 1, preview.AlreadyFinished)
+	assert.Equal(t, 1, preview.Unmatched, "inaccessible books don't match")
+	assert.Equal(t, 1, preview.NotRead)
+
+	assert.Equal(t, "book-1", preview.Items[0].BookID)
+	assert.Equal(t, ItemMatched, preview.Items[0].Status)
+	assert.Equal(t, "book-2", preview.Items[1].BookID)
+	assert.Equal(t, ItemAlreadyFinished, preview.Items[2].Status)
+	assert.Equal(t, ItemUnmatched, preview.Items[3].Status)
+	assert.Equal(t, ItemNotRead, preview.Items[4].Status)
+
+	readAt := time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)
+	result, err := im.Import(ctx, "user-1", FormatGoodreads, []ImportItem{
+		{BookID: "book-1", FinishedAt: &readAt},
+		{BookID: "book-2"},
+		{BookID: "book-3"},
+		{BookID: "book-4"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.AlreadyFinished)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, 2, s.finished)
+
+	state := s.states[domain.StateID("user-1", "book-1")]
+	require.NotNil(t, state)
+	assert.True(t, state.IsFinished)
+	require.NotNil(t, state.FinishedAt)
+	assert.True(t, state.FinishedAt.Equal(readAt))
+
+	require.Len(t, s.events, 2)
+	assert.Equal(t, domain.EventSourceImport, s.events[0].Source)
+	assert.Zero(t, s.events[0].DurationMs, "imports add no listening time")
+	assert.Equal(t, "goodreads-import", s.events[0].DeviceID)
+
+	require.Len(t, s.sessions, 2)
+	assert.True(t, s.sessions[0].IsCompleted)
+	assert.True(t, s.sessions[0].FinishedAt.Equal(readAt))
+
+	// Importing again changes nothing
+	again, err := im.Import(ctx, "user-1", FormatGoodreads, []ImportItem{{BookID: "book-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, again.AlreadyFinished)
+	assert.Len(t, s.events, 2)
+}
+
+func TestExporter_Entries(t *testing.T) {
+	ctx := context.Background()
+	s := newMockStore()
+
+	at := func(month time.Month, day int) *time.Time {
+		t := time.Date(2024, month, day, 12, 0, 0, 0, time.Local)
+		return &t
+	}
+	s.sessions = []*domain.BookReadingSession{
+		{ID: "rs-1", UserID: "user-1", BookID: "book-1", StartedAt: *at(1, 1), FinishedAt: at(1, 20), IsCompleted: true},
+		{ID: "rs-2", UserID: "user-1", BookID: "book-1", StartedAt: *at(5, 1), FinishedAt: at(5, 10), IsCompleted: true},
+		{ID: "rs-3", UserID: "user-1", BookID: "book-2", StartedAt: *at(3, 1), FinishedAt: at(3, 2), IsCompleted: true},
+		{ID: "rs-4", UserID: "user-1", BookID: "book-3", StartedAt: *at(4, 1), FinishedAt: at(4, 2)}, // Abandoned
+		{ID: "rs-5", UserID: "user-1", BookID: "deleted", StartedAt: *at(4, 1), FinishedAt: at(4, 2), IsCompleted: true},
+	}
+	s.shelves = []*domain.Shelf{
+		{ID: "shelf-1", Name: "Epic Fantasy", BookIDs: []string{"book-1"}},
+		{ID: "shelf-2", Name: "Smart", BookIDs: []string{"book-1"}, Rules: &domain.ShelfRules{}},
+	}
+	s.reviews["book-1"] = &domain.BookReview{Rating: 4.5, Text: "Great"}
+
+	entries, err := NewExporter(s, slog.New(slog.DiscardHandler)).Entries(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	kings := entries[0]
+	assert.Equal(t, "The Way of Kings", kings.Title)
+	assert.Equal(t, []string{"Brandon Sanderson"}, kings.Authors)
+	assert.Equal(t, []string{"9780765326355"}, kings.ISBNs)
+	assert.Equal(t, StatusRead, kings.Status)
+	assert.Equal(t, 2, kings.ReadCount)
+	assert.InDelta(t, 4.5, kings.Rating, 0)
+	assert.Equal(t, "Great", kings.Review)
+	assert.Equal(t, []string{"epic-fantasy"}, kings.Shelves)
+	assert.Equal(t, at(1, 1), kings.DateAdded)
+	assert.Equal(t, at(5, 1), kings.DateStarted)
+	assert.Equal(t, at(5, 10), kings.DateRead)
+
+	omens := entries[1]
+	assert.Equal(t, "B0031RS50E", omens.ASIN)
+	assert.Equal(t, 1, omens.ReadCount)
+	assert.Zero(t, omens.Rating)
+}
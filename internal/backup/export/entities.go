@@ -274,6 +274,132 @@ func exportActivities(ctx context.Context, s store.Store, zw *zip.Writer) (int,
 	return w.Count(), nil
 }
 
+func exportBookmarks(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
+	w, err := stream.NewWriter(zw, "entities/bookmarks.jsonl")
+	if err != nil {
+		return 0, err
+	}
+
+	for bookmark, err := range s.StreamBookmarks(ctx) {
+		if err != nil {
+			return w.Count(), err
+		}
+		if err := w.Write(bookmark); err != nil {
+			return w.Count(), err
+		}
+	}
+
+	return w.Count(), nil
+}
+
+func exportBookReviews(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
+	w, err := stream.NewWriter(zw, "entities/book_reviews.jsonl")
+	if err != nil {
+		return 0, err
+	}
+
+	for review, err := range s.StreamBookReviews(ctx) {
+		if err != nil {
+			return w.Count(), err
+		}
+		if err := w.Write(review); err != nil {
+			return w.Count(), err
+		}
+	}
+
+	return w.Count(), nil
+}
+
+func exportPodcasts(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
+	w, err := stream.NewWriter(zw, "entities/podcasts.jsonl")
+	if err != nil {
+		return 0, err
+	}
+
+	for p, err := range s.StreamPodcasts(ctx) {
+		if err != nil {
+			return w.Count(), err
+		}
+		if err := w.Write(p); err != nil {
+			return w.Count(), err
+		}
+	}
+
+	return w.Count(), nil
+}
+
+func exportPodcastEpisodes(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
+	w, err := stream.NewWriter(zw, "entities/podcast_episodes.jsonl")
+	if err != nil {
+		return 0, err
+	}
+
+	for e, err := range s.StreamPodcastEpisodes(ctx) {
+		if err != nil {
+			return w.Count(), err
+		}
+		if err := w.Write(e); err != nil {
+			return w.Count(), err
+		}
+	}
+
+	return w.Count(), nil
+}
+
+func exportEpisodeProgress(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
+	w, err := stream.NewWriter(zw, "entities/episode_progress.jsonl")
+	if err != nil {
+		return 0, err
+	}
+
+	for state, err := range s.StreamEpisodePlaybackStates(ctx) {
+		if err != nil {
+			return w.Count(), err
+		}
+		if err := w.Write(state); err != nil {
+			return w.Count(), err
+		}
+	}
+
+	return w.Count(), nil
+}
+
+func exportEbookPositions(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
+	w, err := stream.NewWriter(zw, "entities/ebook_positions.jsonl")
+	if err != nil {
+		return 0, err
+	}
+
+	for pos, err := range s.StreamEbookPositions(ctx) {
+		if err != nil {
+			return w.Count(), err
+		}
+		if err := w.Write(pos); err != nil {
+			return w.Count(), err
+		}
+	}
+
+	return w.Count(), nil
+}
+
+func exportListeningGoals(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
+	w, err := stream.NewWriter(zw, "entities/listening_goals.jsonl")
+	if err != nil {
+		return 0, err
+	}
+
+	for goal, err := range s.StreamListeningGoals(ctx) {
+		if err != nil {
+			return w.Count(), err
+		}
+		if err := w.Write(goal); err != nil {
+			return w.Count(), err
+		}
+	}
+
+	return w.Count(), nil
+}
+
 func exportListeningEvents(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
 	w, err := stream.NewWriter(zw, "listening/events.jsonl")
 	if err != nil {
@@ -292,6 +418,24 @@ func exportListeningEvents(ctx context.Context, s store.Store, zw *zip.Writer) (
 	return w.Count(), nil
 }
 
+func exportEpisodeListeningEvents(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
+	w, err := stream.NewWriter(zw, "listening/episode_events.jsonl")
+	if err != nil {
+		return 0, err
+	}
+
+	for event, err := range s.StreamEpisodeListeningEvents(ctx) {
+		if err != nil {
+			return w.Count(), err
+		}
+		if err := w.Write(event); err != nil {
+			return w.Count(), err
+		}
+	}
+
+	return w.Count(), nil
+}
+
 func exportReadingSessions(ctx context.Context, s store.Store, zw *zip.Writer) (int, error) {
 	w, err := stream.NewWriter(zw, "listening/sessions.jsonl")
 	if err != nil {
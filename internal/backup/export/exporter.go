@@ -99,6 +99,13 @@ func (e *Exporter) Export(ctx context.Context, opts Options) (*Result, error) {
 		{"collection_shares", exportCollectionShares, &counts.CollectionShares},
 		{"shelves", exportShelves, &counts.Shelves},
 		{"activities", exportActivities, &counts.Activities},
+		{"bookmarks", exportBookmarks, &counts.Bookmarks},
+		{"book_reviews", exportBookReviews, &counts.BookReviews},
+		{"podcasts", exportPodcasts, &counts.Podcasts},
+		{"podcast_episodes", exportPodcastEpisodes, &counts.PodcastEpisodes},
+		{"episode_progress", exportEpisodeProgress, &counts.EpisodeProgress},
+		{"ebook_positions", exportEbookPositions, &counts.EbookPositions},
+		{"listening_goals", exportListeningGoals, &counts.ListeningGoals},
 	}
 
 	for _, step := range exportSteps {
@@ -123,6 +130,12 @@ func (e *Exporter) Export(ctx context.Context, opts Options) (*Result, error) {
 		}
 		counts.ListeningEvents = n
 
+		n, err = exportEpisodeListeningEvents(ctx, e.store, zw)
+		if err != nil {
+			return nil, fmt.Errorf("export episode listening events: %w", err)
+		}
+		counts.EpisodeEvents = n
+
 		n, err = exportReadingSessions(ctx, e.store, zw)
 		if err != nil {
 			return nil, fmt.Errorf("export reading sessions: %w", err)
@@ -384,6 +384,137 @@ func (i *Importer) importActivities(ctx context.Context, zr *zip.ReadCloser, opt
 	)
 }
 
+func (i *Importer) importBookmarks(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
+	return importEntity(ctx, i, zr, opts,
+		"entities/bookmarks.jsonl",
+		"bookmarks",
+		func(b *domain.Bookmark) string { return b.ID },
+		func(b *domain.Bookmark) bool { return b.IsDeleted() },
+		func(ctx context.Context, b *domain.Bookmark) persistOutcome {
+			return upsertWithMerge(ctx, opts, b,
+				func(ctx context.Context) (*domain.Bookmark, error) { return i.store.GetBookmark(ctx, b.ID) },
+				func(x *domain.Bookmark) time.Time { return x.UpdatedAt },
+				func(ctx context.Context, x *domain.Bookmark) error { return i.store.UpdateBookmark(ctx, x) },
+				func(ctx context.Context, x *domain.Bookmark) error { return i.store.CreateBookmark(ctx, x) },
+			)
+		},
+	)
+}
+
+func (i *Importer) importBookReviews(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
+	return importEntity(ctx, i, zr, opts,
+		"entities/book_reviews.jsonl",
+		"book_reviews",
+		func(r *domain.BookReview) string { return r.ID },
+		nil,
+		func(ctx context.Context, r *domain.BookReview) persistOutcome {
+			return upsertWithMerge(ctx, opts, r,
+				func(ctx context.Context) (*domain.BookReview, error) { return i.store.GetReview(ctx, r.ID) },
+				func(x *domain.BookReview) time.Time { return x.UpdatedAt },
+				func(ctx context.Context, x *domain.BookReview) error { return i.store.UpdateReview(ctx, x) },
+				func(ctx context.Context, x *domain.BookReview) error { return i.store.CreateReview(ctx, x) },
+			)
+		},
+	)
+}
+
+func (i *Importer) importPodcasts(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
+	return importEntity(ctx, i, zr, opts,
+		"entities/podcasts.jsonl",
+		"podcasts",
+		func(p *domain.Podcast) string { return p.ID },
+		nil,
+		func(ctx context.Context, p *domain.Podcast) persistOutcome {
+			return upsertWithMerge(ctx, opts, p,
+				func(ctx context.Context) (*domain.Podcast, error) { return i.store.GetPodcast(ctx, p.ID) },
+				func(x *domain.Podcast) time.Time { return x.UpdatedAt },
+				func(ctx context.Context, x *domain.Podcast) error { return i.store.UpdatePodcast(ctx, x) },
+				func(ctx context.Context, x *domain.Podcast) error { return i.store.CreatePodcast(ctx, x) },
+			)
+		},
+	)
+}
+
+func (i *Importer) importPodcastEpisodes(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
+	return importEntity(ctx, i, zr, opts,
+		"entities/podcast_episodes.jsonl",
+		"podcast_episodes",
+		func(e *domain.PodcastEpisode) string { return e.ID },
+		nil,
+		func(ctx context.Context, e *domain.PodcastEpisode) persistOutcome {
+			return upsertWithMerge(ctx, opts, e,
+				func(ctx context.Context) (*domain.PodcastEpisode, error) { return i.store.GetPodcastEpisode(ctx, e.ID) },
+				func(x *domain.PodcastEpisode) time.Time { return x.UpdatedAt },
+				func(ctx context.Context, x *domain.PodcastEpisode) error { return i.store.UpdatePodcastEpisode(ctx, x) },
+				func(ctx context.Context, x *domain.PodcastEpisode) error { return i.store.CreatePodcastEpisode(ctx, x) },
+			)
+		},
+	)
+}
+
+// importEpisodeProgress restores episode playback state directly. Unlike
+// book progress it isn't rebuilt from events after a restore.
+func (i *Importer) importEpisodeProgress(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
+	return importEntity(ctx, i, zr, opts,
+		"entities/episode_progress.jsonl",
+		"episode_progress",
+		func(s *domain.PlaybackState) string { return domain.StateID(s.UserID, s.BookID) },
+		nil,
+		func(ctx context.Context, s *domain.PlaybackState) persistOutcome {
+			return upsertWithMerge(ctx, opts, s,
+				func(ctx context.Context) (*domain.PlaybackState, error) {
+					return i.store.GetEpisodePlaybackState(ctx, s.UserID, s.BookID)
+				},
+				func(x *domain.PlaybackState) time.Time { return x.UpdatedAt },
+				i.store.UpsertEpisodePlaybackState,
+				i.store.UpsertEpisodePlaybackState,
+			)
+		},
+	)
+}
+
+// importEbookPositions restores ebook reading positions. They have no event
+// log to be rebuilt from, so they are backed up as they are.
+func (i *Importer) importEbookPositions(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
+	return importEntity(ctx, i, zr, opts,
+		"entities/ebook_positions.jsonl",
+		"ebook_positions",
+		func(p *domain.EbookPosition) string { return domain.EbookPositionID(p.UserID, p.BookID) },
+		nil,
+		func(ctx context.Context, p *domain.EbookPosition) persistOutcome {
+			return upsertWithMerge(ctx, opts, p,
+				func(ctx context.Context) (*domain.EbookPosition, error) {
+					return i.store.GetEbookPosition(ctx, p.UserID, p.BookID)
+				},
+				func(x *domain.EbookPosition) time.Time { return x.UpdatedAt },
+				i.store.UpsertEbookPosition,
+				i.store.UpsertEbookPosition,
+			)
+		},
+	)
+}
+
+// importListeningGoals restores listening goals. Progress is recomputed from
+// the restored events and playback states, so only the goals themselves are kept.
+func (i *Importer) importListeningGoals(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
+	return importEntity(ctx, i, zr, opts,
+		"entities/listening_goals.jsonl",
+		"listening_goals",
+		func(g *domain.ListeningGoal) string { return g.ID },
+		nil,
+		func(ctx context.Context, g *domain.ListeningGoal) persistOutcome {
+			return upsertWithMerge(ctx, opts, g,
+				func(ctx context.Context) (*domain.ListeningGoal, error) {
+					return i.store.GetListeningGoal(ctx, g.ID)
+				},
+				func(x *domain.ListeningGoal) time.Time { return x.UpdatedAt },
+				i.store.UpsertListeningGoal,
+				i.store.UpsertListeningGoal,
+			)
+		},
+	)
+}
+
 func (i *Importer) importListeningEvents(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
 	return importEntity(ctx, i, zr, opts,
 		"listening/events.jsonl",
@@ -400,6 +531,21 @@ func (i *Importer) importListeningEvents(ctx context.Context, zr *zip.ReadCloser
 	)
 }
 
+func (i *Importer) importEpisodeListeningEvents(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
+	return importEntity(ctx, i, zr, opts,
+		"listening/episode_events.jsonl",
+		"episode_events",
+		nil, // append-only, like book listening events
+		nil,
+		func(ctx context.Context, e *domain.ListeningEvent) persistOutcome {
+			if err := i.store.CreateEpisodeListeningEvent(ctx, e); err != nil {
+				return persistOutcome{skipped: true}
+			}
+			return persistOutcome{}
+		},
+	)
+}
+
 func (i *Importer) importReadingSessions(ctx context.Context, zr *zip.ReadCloser, opts RestoreOptions) (int, int, []RestoreError) {
 	return importEntity(ctx, i, zr, opts,
 		"listening/sessions.jsonl",
@@ -157,6 +157,13 @@ func (i *Importer) importEntities(ctx context.Context, zr *zip.ReadCloser, opts
 		{"collection_shares", i.importCollectionShares},
 		{"shelves", i.importShelves},
 		{"activities", i.importActivities},
+		{"bookmarks", i.importBookmarks},
+		{"book_reviews", i.importBookReviews},
+		{"podcasts", i.importPodcasts},
+		{"podcast_episodes", i.importPodcastEpisodes},
+		{"episode_progress", i.importEpisodeProgress},
+		{"ebook_positions", i.importEbookPositions},
+		{"listening_goals", i.importListeningGoals},
 	}
 
 	for _, step := range steps {
@@ -191,6 +198,17 @@ func (i *Importer) importListeningData(ctx context.Context, zr *zip.ReadCloser,
 		"skipped", skipped,
 		"errors", len(errs))
 
+	// Import episode listening events
+	imported, skipped, errs = i.importEpisodeListeningEvents(ctx, zr, opts)
+	result.Imported["episode_events"] = imported
+	result.Skipped["episode_events"] = skipped
+	result.Errors = append(result.Errors, errs...)
+
+	i.logger.Info("imported episode listening events",
+		"imported", imported,
+		"skipped", skipped,
+		"errors", len(errs))
+
 	// Import reading sessions
 	imported, skipped, errs = i.importReadingSessions(ctx, zr, opts)
 	result.Imported["reading_sessions"] = imported
@@ -40,7 +40,15 @@ type EntityCounts struct {
 	CollectionShares int `json:"collection_shares"`
 	Shelves          int `json:"shelves"`
 	Activities       int `json:"activities"`
+	Bookmarks        int `json:"bookmarks"`
+	BookReviews      int `json:"book_reviews"`
+	Podcasts         int `json:"podcasts"`
+	PodcastEpisodes  int `json:"podcast_episodes"`
+	EpisodeProgress  int `json:"episode_progress"`
+	EbookPositions   int `json:"ebook_positions"`
+	ListeningGoals   int `json:"listening_goals"`
 	ListeningEvents  int `json:"listening_events"`
 	ReadingSessions  int `json:"reading_sessions"`
+	EpisodeEvents    int `json:"episode_events"`
 	Images           int `json:"images,omitempty"`
 }
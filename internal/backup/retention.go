@@ -0,0 +1,142 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+// ScheduledPrefix is the file name prefix for backups created by the
+// scheduler. Retention only ever prunes backups with this prefix, so backups
+// an admin created by hand are never deleted automatically.
+const ScheduledPrefix = "scheduled-"
+
+// RetentionPolicy decides which scheduled backups to keep.
+type RetentionPolicy struct {
+	KeepLast    int // Always keep the N newest backups
+	KeepDaily   int // Keep the newest backup of each of the last N days
+	KeepWeekly  int // Keep the newest backup of each of the last N ISO weeks
+	KeepMonthly int // Keep the newest backup of each of the last N months
+}
+
+// RetentionPolicyFromSchedule builds a retention policy from server settings.
+func RetentionPolicyFromSchedule(schedule domain.BackupSchedule) RetentionPolicy {
+	return RetentionPolicy{
+		KeepLast:    schedule.KeepLast,
+		KeepDaily:   schedule.KeepDaily,
+		KeepWeekly:  schedule.KeepWeekly,
+		KeepMonthly: schedule.KeepMonthly,
+	}
+}
+
+// IsZero reports whether the policy keeps everything.
+func (p RetentionPolicy) IsZero() bool {
+	return p.KeepLast <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0 && p.KeepMonthly <= 0
+}
+
+// Apply splits backups into those to keep and those to prune.
+// A backup is kept if any tier selects it. Both results are newest first.
+func (p RetentionPolicy) Apply(backups []BackupInfo) (keep, prune []BackupInfo) {
+	sorted := make([]BackupInfo, len(backups))
+	copy(sorted, backups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	if p.IsZero() {
+		return sorted, nil
+	}
+
+	kept := make([]bool, len(sorted))
+	for i := 0; i < len(sorted) && i < p.KeepLast; i++ {
+		kept[i] = true
+	}
+
+	keepTier(sorted, kept, p.KeepDaily, func(t time.Time) string {
+		return t.Format("2006-01-02")
+	})
+	keepTier(sorted, kept, p.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+	keepTier(sorted, kept, p.KeepMonthly, func(t time.Time) string {
+		return t.Format("2006-01")
+	})
+
+	for i, b := range sorted {
+		if kept[i] {
+			keep = append(keep, b)
+		} else {
+			prune = append(prune, b)
+		}
+	}
+	return keep, prune
+}
+
+// keepTier marks the newest backup in each of the first n distinct periods.
+// Backups must be sorted newest first.
+func keepTier(sorted []BackupInfo, kept []bool, n int, period func(time.Time) string) {
+	if n <= 0 {
+		return
+	}
+	seen := make(map[string]bool, n)
+	for i, b := range sorted {
+		key := period(b.CreatedAt.Local())
+		if seen[key] {
+			continue
+		}
+		if len(seen) == n {
+			return
+		}
+		seen[key] = true
+		kept[i] = true
+	}
+}
+
+// ListScheduled returns backups created by the scheduler, newest first.
+func (s *BackupService) ListScheduled(ctx context.Context) ([]BackupInfo, error) {
+	all, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var scheduled []BackupInfo
+	for _, b := range all {
+		if strings.HasPrefix(b.ID, ScheduledPrefix) {
+			scheduled = append(scheduled, b)
+		}
+	}
+	return scheduled, nil
+}
+
+// Prune deletes scheduled backups that fall outside the retention policy.
+// Returns the IDs of the deleted backups.
+func (s *BackupService) Prune(ctx context.Context, policy RetentionPolicy) ([]string, error) {
+	scheduled, err := s.ListScheduled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled backups: %w", err)
+	}
+
+	_, prune := policy.Apply(scheduled)
+
+	deleted := make([]string, 0, len(prune))
+	for _, b := range prune {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		if err := s.Delete(ctx, b.ID); err != nil {
+			return deleted, fmt.Errorf("delete backup %s: %w", b.ID, err)
+		}
+		deleted = append(deleted, b.ID)
+	}
+
+	if len(deleted) > 0 {
+		s.logger.Info("pruned scheduled backups", "deleted", len(deleted), "kept", len(scheduled)-len(deleted))
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,131 @@
+package backup
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backupsAt(times ...time.Time) []BackupInfo {
+	backups := make([]BackupInfo, len(times))
+	for i, t := range times {
+		backups[i] = BackupInfo{ID: ScheduledPrefix + t.Format("2006-01-02-150405"), CreatedAt: t}
+	}
+	return backups
+}
+
+func ids(backups []BackupInfo) []string {
+	out := make([]string, len(backups))
+	for i, b := range backups {
+		out[i] = b.ID
+	}
+	return out
+}
+
+func TestRetentionPolicy_ZeroKeepsEverything(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	backups := backupsAt(now, now.Add(-time.Hour), now.Add(-48*time.Hour))
+
+	keep, prune := RetentionPolicy{}.Apply(backups)
+
+	assert.Len(t, keep, 3)
+	assert.Empty(t, prune)
+}
+
+func TestRetentionPolicy_KeepLast(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	// Deliberately out of order: Apply sorts newest first.
+	backups := backupsAt(now.Add(-2*time.Hour), now, now.Add(-time.Hour))
+
+	keep, prune := RetentionPolicy{KeepLast: 2}.Apply(backups)
+
+	assert.Equal(t, ids(backupsAt(now, now.Add(-time.Hour))), ids(keep))
+	assert.Equal(t, ids(backupsAt(now.Add(-2*time.Hour))), ids(prune))
+}
+
+func TestRetentionPolicy_DailyKeepsNewestPerDay(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	backups := backupsAt(
+		day.Add(18*time.Hour),
+		day.Add(6*time.Hour),
+		day.Add(-6*time.Hour), // Mar 9, 18:00
+		day.Add(-18*time.Hour),
+		day.Add(-30*time.Hour), // Mar 8
+	)
+
+	keep, prune := RetentionPolicy{KeepDaily: 2}.Apply(backups)
+
+	assert.Equal(t, ids(backupsAt(day.Add(18*time.Hour), day.Add(-6*time.Hour))), ids(keep))
+	assert.Len(t, prune, 3)
+}
+
+func TestRetentionPolicy_TiersCombine(t *testing.T) {
+	t.Parallel()
+	// One backup a day for 90 days.
+	start := time.Date(2026, 1, 1, 3, 0, 0, 0, time.Local)
+	var times []time.Time
+	for i := range 90 {
+		times = append(times, start.AddDate(0, 0, i))
+	}
+	backups := backupsAt(times...)
+
+	keep, prune := RetentionPolicy{KeepLast: 1, KeepDaily: 7, KeepWeekly: 4, KeepMonthly: 2}.Apply(backups)
+
+	assert.Len(t, keep, len(backups)-len(prune))
+	// The newest backup is always kept.
+	assert.Equal(t, backupsAt(times[89])[0].ID, keep[0].ID)
+
+	// Every kept backup falls within the last 7 days, is the newest of its week,
+	// or is the newest of its month.
+	newest := times[89]
+	for _, b := range keep {
+		inDaily := newest.Sub(b.CreatedAt) < 7*24*time.Hour
+		nextDay := b.CreatedAt.AddDate(0, 0, 1)
+		_, week := b.CreatedAt.ISOWeek()
+		_, nextWeek := nextDay.ISOWeek()
+		endOfWeek := week != nextWeek
+		endOfMonth := nextDay.Month() != b.CreatedAt.Month()
+		assert.True(t, inDaily || endOfWeek || endOfMonth, "unexpected keep %s", b.ID)
+	}
+
+	// Only two months are retained, so January is pruned entirely.
+	for _, b := range keep {
+		assert.NotEqual(t, time.January, b.CreatedAt.Month(), "January should be pruned: %s", b.ID)
+	}
+}
+
+func TestBackupService_PruneOnlyTouchesScheduledBackups(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	svc := NewBackupService(nil, dir, dir, "test", logger)
+
+	now := time.Now()
+	files := map[string]time.Time{
+		"backup-manual":           now.Add(-72 * time.Hour),
+		ScheduledPrefix + "old":   now.Add(-48 * time.Hour),
+		ScheduledPrefix + "older": now.Add(-96 * time.Hour),
+		ScheduledPrefix + "new":   now,
+	}
+	for name, modTime := range files {
+		path := filepath.Join(dir, name+".listenup.zip")
+		require.NoError(t, os.WriteFile(path, []byte("zip"), 0o644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	deleted, err := svc.Prune(t.Context(), RetentionPolicy{KeepLast: 1})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{ScheduledPrefix + "old", ScheduledPrefix + "older"}, deleted)
+
+	remaining, err := svc.List(t.Context())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"backup-manual", ScheduledPrefix + "new"}, ids(remaining))
+}
@@ -0,0 +1,141 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+// SettingsReader provides the server settings that hold the backup schedule.
+type SettingsReader interface {
+	GetServerSettings(ctx context.Context) (*domain.ServerSettings, error)
+}
+
+// ScheduledRun describes the outcome of one scheduled backup.
+type ScheduledRun struct {
+	StartedAt   time.Time     `json:"started_at"`
+	CompletedAt time.Time     `json:"completed_at"`
+	BackupID    string        `json:"backup_id,omitempty"`
+	Size        int64         `json:"size,omitempty"`
+	Duration    time.Duration `json:"duration"`
+	Pruned      []string      `json:"pruned,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// Succeeded reports whether the backup was written.
+// A failure to prune old backups does not count as a failed run.
+func (r *ScheduledRun) Succeeded() bool {
+	return r.BackupID != ""
+}
+
+// failedRunRetryDelay is how long the scheduler waits after a failed backup
+// before trying again. Without it a persistent failure, such as a full disk,
+// would be retried on every tick. Intervals are whole hours, so this never
+// delays a backup past its next slot.
+const failedRunRetryDelay = time.Hour
+
+// Scheduler creates backups on the interval configured in server settings
+// and prunes old scheduled backups according to the retention policy.
+type Scheduler struct {
+	backups  *BackupService
+	settings SettingsReader
+	logger   *slog.Logger
+
+	mu      sync.Mutex
+	lastRun *ScheduledRun
+}
+
+// NewScheduler creates a Scheduler.
+func NewScheduler(backups *BackupService, settings SettingsReader, logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		backups:  backups,
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// LastRun returns the most recent scheduled run, or nil if none has run
+// since the server started.
+func (s *Scheduler) LastRun() *ScheduledRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastRun == nil {
+		return nil
+	}
+	run := *s.lastRun
+	return &run
+}
+
+// RunIfDue creates a backup if scheduling is enabled and the newest scheduled
+// backup is older than the configured interval. Returns nil if no backup was due.
+//
+// The newest backup on disk is used as the reference point rather than an
+// in-memory timer, so restarting the server doesn't reset the schedule.
+// After a failed run the next attempt waits for failedRunRetryDelay.
+func (s *Scheduler) RunIfDue(ctx context.Context, now time.Time) (*ScheduledRun, error) {
+	settings, err := s.settings.GetServerSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get server settings: %w", err)
+	}
+
+	schedule := settings.Backup
+	if !schedule.Enabled {
+		return nil, nil
+	}
+
+	if last := s.LastRun(); last != nil && !last.Succeeded() && now.Sub(last.StartedAt) < failedRunRetryDelay {
+		return nil, nil
+	}
+
+	scheduled, err := s.backups.ListScheduled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled backups: %w", err)
+	}
+	if len(scheduled) > 0 && now.Sub(scheduled[0].CreatedAt) < schedule.Interval() {
+		return nil, nil
+	}
+
+	return s.Run(ctx, schedule, now), nil
+}
+
+// Run creates a scheduled backup and applies retention, regardless of the
+// interval. Errors are recorded on the returned run rather than returned.
+func (s *Scheduler) Run(ctx context.Context, schedule domain.BackupSchedule, now time.Time) *ScheduledRun {
+	run := &ScheduledRun{StartedAt: now}
+
+	backupID := ScheduledPrefix + now.Format("2006-01-02-150405")
+	result, err := s.backups.Create(ctx, BackupOptions{
+		IncludeImages: schedule.IncludeImages,
+		IncludeEvents: schedule.IncludeEvents,
+		OutputPath:    filepath.Join(s.backups.backupDir, backupID+".listenup.zip"),
+	})
+	if err != nil {
+		s.logger.Error("scheduled backup failed", "error", err)
+		run.Error = err.Error()
+	} else {
+		run.BackupID = backupID
+		run.Size = result.Size
+		run.Duration = result.Duration
+
+		// Only prune after a successful backup, so a run of failures can't
+		// eat into the backups we still have.
+		pruned, err := s.backups.Prune(ctx, RetentionPolicyFromSchedule(schedule))
+		run.Pruned = pruned
+		if err != nil {
+			s.logger.Warn("scheduled backup retention failed", "error", err)
+			run.Error = err.Error()
+		}
+	}
+	run.CompletedAt = time.Now()
+
+	s.mu.Lock()
+	s.lastRun = run
+	s.mu.Unlock()
+
+	return run
+}
@@ -0,0 +1,57 @@
+package backup_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/backup"
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+// staticSettings serves fixed server settings to the scheduler.
+type staticSettings struct {
+	settings *domain.ServerSettings
+}
+
+func (s staticSettings) GetServerSettings(context.Context) (*domain.ServerSettings, error) {
+	return s.settings, nil
+}
+
+func TestScheduler_FailedRunBacksOff(t *testing.T) {
+	testStore, backupSvc, _, _, cleanup := testSetup(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// A closed store makes every export fail.
+	require.NoError(t, testStore.Close())
+
+	settings := domain.NewServerSettings()
+	settings.Backup.Enabled = true
+	settings.Backup.IntervalHours = 24
+	scheduler := backup.NewScheduler(backupSvc, staticSettings{settings}, slog.New(slog.DiscardHandler))
+
+	start := time.Now()
+	run, err := scheduler.RunIfDue(ctx, start)
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	assert.False(t, run.Succeeded())
+	assert.NotEmpty(t, run.Error)
+
+	// The next ticks don't retry straight away.
+	for _, after := range []time.Duration{time.Minute, 30 * time.Minute, 59 * time.Minute} {
+		run, err = scheduler.RunIfDue(ctx, start.Add(after))
+		require.NoError(t, err)
+		assert.Nil(t, run, "retried after %s", after)
+	}
+
+	// Once the backoff has passed it tries again.
+	run, err = scheduler.RunIfDue(ctx, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	assert.False(t, run.Succeeded())
+}
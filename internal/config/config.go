@@ -14,14 +14,18 @@ import (
 
 // Config holds the application configuration.
 type Config struct {
-	App       AppConfig
-	Logger    LoggerConfig
-	Metadata  MetadataConfig
-	Library   LibraryConfig
-	Server    ServerConfig
-	Auth      AuthConfig
-	Transcode TranscodeConfig
-	Audible   AudibleConfig
+	App               AppConfig
+	Logger            LoggerConfig
+	Metadata          MetadataConfig
+	Library           LibraryConfig
+	Server            ServerConfig
+	Auth              AuthConfig
+	Transcode         TranscodeConfig
+	Audible           AudibleConfig
+	MetadataProviders MetadataProvidersConfig
+	SMTP              SMTPConfig
+	OIDC              OIDCConfig
+	Podcast           PodcastConfig
 }
 
 // AppConfig holds application-level configuration.
@@ -85,6 +89,60 @@ type AudibleConfig struct {
 	DefaultRegion string
 }
 
+// MetadataProvidersConfig holds credentials for the optional metadata providers.
+type MetadataProvidersConfig struct {
+	// GoogleBooksAPIKey raises the Google Books quota (optional)
+	GoogleBooksAPIKey string
+	// HardcoverAPIToken enables the Hardcover provider (disabled if empty)
+	HardcoverAPIToken string
+}
+
+// SMTPConfig holds outgoing mail settings, used to email password reset links.
+type SMTPConfig struct {
+	// Host is the SMTP relay; email is disabled when empty
+	Host string
+	// Port is the relay port (default: 587)
+	Port int
+	// Username and Password enable SMTP AUTH (optional)
+	Username string
+	Password string
+	// From is the sender address, e.g. "ListenUp <listenup@example.com>"
+	From string
+	// ImplicitTLS connects with TLS from the start, usually on port 465 (default: false, use STARTTLS)
+	ImplicitTLS bool
+}
+
+// OIDCConfig holds OpenID Connect single sign-on settings.
+type OIDCConfig struct {
+	// IssuerURL is the identity provider's issuer; SSO is disabled when empty
+	IssuerURL string
+	// ClientID and ClientSecret are registered with the provider (secret optional for public clients)
+	ClientID     string
+	ClientSecret string
+	// Scopes requested at login (default: openid, profile, email)
+	Scopes []string
+	// ButtonLabel is shown on the login screen (default: "Sign in with SSO")
+	ButtonLabel string
+	// AutoProvision creates accounts for unknown users (default: true)
+	AutoProvision bool
+	// TrustUnverifiedEmail links existing users by email even when the provider
+	// doesn't mark it verified; only for providers you control (default: false)
+	TrustUnverifiedEmail bool
+	// GroupsClaim names the claim listing the user's groups (default: "groups")
+	GroupsClaim string
+	// AdminGroups grant the admin role to members; roles are left alone when empty
+	AdminGroups []string
+	// AllowedRedirects are URL prefixes clients may return to after login,
+	// besides paths on this server (default: "listenup://")
+	AllowedRedirects []string
+}
+
+// PodcastConfig holds podcast download settings.
+type PodcastConfig struct {
+	// MaxDownloadMB is the largest episode that will be downloaded (default: 2048)
+	MaxDownloadMB int
+}
+
 // LoadConfig loads configuration from multiple sources with precedence:
 // 1. Command-line flags (highest priority).
 // 2. Environment variables.
@@ -163,6 +221,37 @@ func LoadConfig() (*Config, error) {
 		Audible: AudibleConfig{
 			DefaultRegion: getConfigValue("", "AUDIBLE_DEFAULT_REGION", "us"),
 		},
+
+		MetadataProviders: MetadataProvidersConfig{
+			GoogleBooksAPIKey: getConfigValue("", "GOOGLE_BOOKS_API_KEY", ""),
+			HardcoverAPIToken: getConfigValue("", "HARDCOVER_API_TOKEN", ""),
+		},
+
+		SMTP: SMTPConfig{
+			Host:        getConfigValue("", "SMTP_HOST", ""),
+			Port:        getIntConfigValue("", "SMTP_PORT", 587),
+			Username:    getConfigValue("", "SMTP_USERNAME", ""),
+			Password:    getConfigValue("", "SMTP_PASSWORD", ""),
+			From:        getConfigValue("", "SMTP_FROM", ""),
+			ImplicitTLS: getBoolConfigValue("", "SMTP_IMPLICIT_TLS", false),
+		},
+
+		OIDC: OIDCConfig{
+			IssuerURL:            getConfigValue("", "OIDC_ISSUER_URL", ""),
+			ClientID:             getConfigValue("", "OIDC_CLIENT_ID", ""),
+			ClientSecret:         getConfigValue("", "OIDC_CLIENT_SECRET", ""),
+			Scopes:               getListConfigValue("OIDC_SCOPES", nil),
+			ButtonLabel:          getConfigValue("", "OIDC_BUTTON_LABEL", "Sign in with SSO"),
+			AutoProvision:        getBoolConfigValue("", "OIDC_AUTO_PROVISION", true),
+			TrustUnverifiedEmail: getBoolConfigValue("", "OIDC_TRUST_UNVERIFIED_EMAIL", false),
+			GroupsClaim:          getConfigValue("", "OIDC_GROUPS_CLAIM", "groups"),
+			AdminGroups:          getListConfigValue("OIDC_ADMIN_GROUPS", nil),
+			AllowedRedirects:     getListConfigValue("OIDC_ALLOWED_REDIRECTS", []string{"listenup://"}),
+		},
+
+		Podcast: PodcastConfig{
+			MaxDownloadMB: getIntConfigValue("", "PODCAST_MAX_DOWNLOAD_MB", 2048),
+		},
 	}
 
 	// Parse auth durations.
@@ -375,6 +464,21 @@ func getIntConfigValue(flagValue, envKey string, defaultValue int) int {
 	return result
 }
 
+// getListConfigValue returns a comma-separated list from an env var, or default.
+func getListConfigValue(envKey string, defaultValue []string) []string {
+	strValue := getConfigValue("", envKey, "")
+	if strValue == "" {
+		return defaultValue
+	}
+	var result []string
+	for item := range strings.SplitSeq(strValue, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // loadEnvFile loads environment variables from a .env file.
 // Format: KEY=value (one per line, # for comments).
 func loadEnvFile(path string) error {
@@ -5,6 +5,7 @@ import (
 	"github.com/samber/do/v2"
 
 	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/backup"
 	"github.com/listenupapp/listenup-server/internal/config"
 	"github.com/listenupapp/listenup-server/internal/di/providers"
 	"github.com/listenupapp/listenup-server/internal/logger"
@@ -50,11 +51,15 @@ func NewContainer() *do.RootScope {
 
 	// Auth layer
 	do.Provide(injector, providers.ProvideTokenService)
+	do.Provide(injector, providers.ProvideStreamSigner)
+	do.Provide(injector, providers.ProvideNotifier)
 
 	// Business services
 	do.Provide(injector, providers.ProvideInstanceService)
 	do.Provide(injector, providers.ProvideSessionService)
+	do.Provide(injector, providers.ProvideTwoFactorService)
 	do.Provide(injector, providers.ProvideAuthService)
+	do.Provide(injector, providers.ProvideOIDCService)
 	do.Provide(injector, providers.ProvideBookService)
 	do.Provide(injector, providers.ProvideChapterService)
 	do.Provide(injector, providers.ProvideCollectionService)
@@ -63,13 +68,20 @@ func NewContainer() *do.RootScope {
 	do.Provide(injector, providers.ProvideReadingSessionService)
 	do.Provide(injector, providers.ProvideActivityService)
 	do.Provide(injector, providers.ProvideListeningService)
+	do.Provide(injector, providers.ProvideBookmarkService)
+	do.Provide(injector, providers.ProvideGoalService)
+	do.Provide(injector, providers.ProvideRecapService)
+	do.Provide(injector, providers.ProvideReviewService)
 	do.Provide(injector, providers.ProvideStatsService)
 	do.Provide(injector, providers.ProvideSocialService)
 	do.Provide(injector, providers.ProvideProfileService)
 	do.Provide(injector, providers.ProvideGenreService)
 	do.Provide(injector, providers.ProvideTagService)
 	do.Provide(injector, providers.ProvideInviteService)
+	do.Provide(injector, providers.ProvidePasswordService)
 	do.Provide(injector, providers.ProvideShelfService)
+	do.Provide(injector, providers.ProvideFeedService)
+	do.Provide(injector, providers.ProvideStreamURLService)
 	do.Provide(injector, providers.ProvideInboxService)
 	do.Provide(injector, providers.ProvideSettingsService)
 	do.Provide(injector, providers.ProvideAdminService)
@@ -77,12 +89,19 @@ func NewContainer() *do.RootScope {
 	do.Provide(injector, providers.ProvideContributorService)
 	do.Provide(injector, providers.ProvideSeriesService)
 	do.Provide(injector, providers.ProvideABSImportService)
+	do.Provide(injector, providers.ProvideBackupService)
+	do.Provide(injector, providers.ProvideTrashService)
+	do.Provide(injector, providers.ProvidePodcastService)
 
 	// Workers
 	do.Provide(injector, providers.ProvideTranscodeService)
 	do.Provide(injector, providers.ProvideFileWatcher)
 	do.Provide(injector, providers.ProvideSessionCleanupJob)
 	do.Provide(injector, providers.ProvideEventLogCleanupJob)
+	do.Provide(injector, providers.ProvideScheduledBackupJob)
+	do.Provide(injector, providers.ProvideTrashPurgeJob)
+	do.Provide(injector, providers.ProvideSmartShelfRefreshJob)
+	do.Provide(injector, providers.ProvidePodcastRefreshJob)
 
 	// Server
 	do.Provide(injector, providers.ProvideHTTPServer)
@@ -102,7 +121,9 @@ func NewContainer() *do.RootScope {
 //   - ProvideMDNSService initializes the server instance and (optionally)
 //     starts mDNS advertisement.
 //   - ProvideTranscodeService, ProvideFileWatcher, ProvideSessionCleanupJob,
-//     ProvideEventLogCleanupJob start background workers.
+//     ProvideEventLogCleanupJob, ProvideScheduledBackupJob,
+//     ProvideTrashPurgeJob, ProvideSmartShelfRefreshJob,
+//     ProvidePodcastRefreshJob start background workers.
 //   - ProvideGenreService seeds default genres into the database.
 //
 // If we left these to be resolved lazily on first use, `cmd/server` would
@@ -147,11 +168,15 @@ func Bootstrap(injector *do.RootScope) error {
 
 		// Auth
 		func(i *do.RootScope) { _ = do.MustInvoke[*auth.TokenService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*auth.StreamSigner](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*providers.NotifierHandle](i) },
 
 		// Business services
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.InstanceService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.SessionService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.TwoFactorService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.AuthService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.OIDCService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.BookService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.ChapterService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.CollectionService](i) },
@@ -159,22 +184,36 @@ func Bootstrap(injector *do.RootScope) error {
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.SyncService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.ReadingSessionService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.ListeningService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.BookmarkService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.GoalService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.RecapService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.ReviewService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.StatsService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.ProfileService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.GenreService](i) }, // seeds default genres
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.TagService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.InviteService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.PasswordService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.ShelfService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.FeedService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.StreamURLService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.AdminService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.ContributorService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.SeriesService](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*service.ABSImportService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*backup.BackupService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.TrashService](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*service.PodcastService](i) },
 
 		// Background workers (each starts goroutines on construction)
 		func(i *do.RootScope) { _ = do.MustInvoke[*providers.TranscodeServiceHandle](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*providers.FileWatcherHandle](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*providers.SessionCleanupJob](i) },
 		func(i *do.RootScope) { _ = do.MustInvoke[*providers.EventLogCleanupJob](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*providers.ScheduledBackupJob](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*providers.TrashPurgeJob](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*providers.SmartShelfRefreshJob](i) },
+		func(i *do.RootScope) { _ = do.MustInvoke[*providers.PodcastRefreshJob](i) },
 
 		// Server (HTTP listener + mDNS announce both spawn at construction)
 		func(i *do.RootScope) { _ = do.MustInvoke[*providers.HTTPServerHandle](i) },
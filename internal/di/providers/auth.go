@@ -42,3 +42,9 @@ func ProvideTokenService(i do.Injector) (*auth.TokenService, error) {
 	keyHex := hex.EncodeToString([]byte(authKey))
 	return auth.NewTokenService(keyHex, cfg.Auth.AccessTokenDuration, cfg.Auth.RefreshTokenDuration)
 }
+
+// ProvideStreamSigner provides the signer for audio stream URLs.
+func ProvideStreamSigner(i do.Injector) (*auth.StreamSigner, error) {
+	authKey := do.MustInvoke[AuthKey](i)
+	return auth.NewStreamSigner(authKey)
+}
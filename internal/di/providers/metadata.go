@@ -1,12 +1,18 @@
 package providers
 
 import (
+	"errors"
+
 	"github.com/samber/do/v2"
 
 	"github.com/listenupapp/listenup-server/internal/config"
 	"github.com/listenupapp/listenup-server/internal/logger"
+	"github.com/listenupapp/listenup-server/internal/metadata"
 	"github.com/listenupapp/listenup-server/internal/metadata/audible"
+	"github.com/listenupapp/listenup-server/internal/metadata/googlebooks"
+	"github.com/listenupapp/listenup-server/internal/metadata/hardcover"
 	"github.com/listenupapp/listenup-server/internal/metadata/itunes"
+	"github.com/listenupapp/listenup-server/internal/metadata/openlibrary"
 	"github.com/listenupapp/listenup-server/internal/service"
 )
 
@@ -57,15 +63,35 @@ func ProvideMetadataService(i do.Injector) (*MetadataServiceHandle, error) {
 		)
 	}
 
+	itunesHandle := do.MustInvoke[*ITunesClientHandle](i)
+
+	// Audible is always registered by the service; the rest are optional.
+	extra := []metadata.Provider{
+		openlibrary.New(log.Logger),
+		googlebooks.New(cfg.MetadataProviders.GoogleBooksAPIKey, log.Logger),
+		itunesHandle.Client,
+	}
+	hc, err := hardcover.New(cfg.MetadataProviders.HardcoverAPIToken, log.Logger)
+	switch {
+	case err == nil:
+		extra = append(extra, hc)
+	case errors.Is(err, hardcover.ErrNoToken):
+		log.Info("Hardcover provider disabled, no API token configured")
+	default:
+		return nil, err
+	}
+
 	svc := service.NewMetadataService(
 		clientHandle.Client,
 		storeHandle.Store,
 		defaultRegion,
 		log.Logger,
+		extra...,
 	)
 
 	log.Info("Metadata service initialized",
 		"default_region", defaultRegion,
+		"providers", svc.AvailableProviders(),
 	)
 
 	return &MetadataServiceHandle{MetadataService: svc}, nil
@@ -0,0 +1,43 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samber/do/v2"
+
+	"github.com/listenupapp/listenup-server/internal/config"
+	"github.com/listenupapp/listenup-server/internal/logger"
+	"github.com/listenupapp/listenup-server/internal/notify"
+)
+
+// NotifierHandle wraps the configured notifier.
+// Notifier is nil when no transport is configured.
+type NotifierHandle struct {
+	Notifier notify.Notifier
+}
+
+// ProvideNotifier provides the SMTP notifier if SMTP_HOST is set.
+func ProvideNotifier(i do.Injector) (*NotifierHandle, error) {
+	cfg := do.MustInvoke[*config.Config](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	smtpNotifier, err := notify.NewSMTP(notify.SMTPConfig{
+		Host:        cfg.SMTP.Host,
+		Port:        cfg.SMTP.Port,
+		Username:    cfg.SMTP.Username,
+		Password:    cfg.SMTP.Password,
+		From:        cfg.SMTP.From,
+		ImplicitTLS: cfg.SMTP.ImplicitTLS,
+	})
+	switch {
+	case err == nil:
+		log.Info("SMTP notifier configured", "host", cfg.SMTP.Host, "port", cfg.SMTP.Port)
+		return &NotifierHandle{Notifier: smtpNotifier}, nil
+	case errors.Is(err, notify.ErrNotConfigured):
+		log.Info("Email disabled, no SMTP host configured")
+		return &NotifierHandle{}, nil
+	default:
+		return nil, fmt.Errorf("configure smtp: %w", err)
+	}
+}
@@ -0,0 +1,53 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/do/v2"
+
+	"github.com/listenupapp/listenup-server/internal/config"
+	"github.com/listenupapp/listenup-server/internal/logger"
+	"github.com/listenupapp/listenup-server/internal/oidc"
+	"github.com/listenupapp/listenup-server/internal/service"
+)
+
+// ProvideOIDCService provides single sign-on. The service is always
+// created; it reports itself disabled when no issuer is configured.
+func ProvideOIDCService(i do.Injector) (*service.OIDCService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	authService := do.MustInvoke[*service.AuthService](i)
+	instanceService := do.MustInvoke[*service.InstanceService](i)
+	cfg := do.MustInvoke[*config.Config](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	// The provider redirects the user's browser here, so prefer the
+	// address reachable from outside the local network.
+	serverURL := "http://localhost:" + cfg.Server.Port
+	switch {
+	case cfg.Server.RemoteURL != "":
+		serverURL = strings.TrimSuffix(cfg.Server.RemoteURL, "/")
+	case cfg.Server.LocalURL != "":
+		serverURL = strings.TrimSuffix(cfg.Server.LocalURL, "/")
+	}
+
+	provider, err := oidc.NewProvider(oidc.Config{
+		IssuerURL:    cfg.OIDC.IssuerURL,
+		ClientID:     cfg.OIDC.ClientID,
+		ClientSecret: cfg.OIDC.ClientSecret,
+		RedirectURL:  serverURL + "/api/v1/auth/oidc/callback",
+		Scopes:       cfg.OIDC.Scopes,
+	})
+	switch {
+	case err == nil:
+		log.Info("Single sign-on configured", "issuer", cfg.OIDC.IssuerURL, "redirect_url", serverURL+"/api/v1/auth/oidc/callback")
+	case errors.Is(err, oidc.ErrNotConfigured):
+		log.Info("Single sign-on disabled, no OIDC issuer configured")
+		provider = nil
+	default:
+		return nil, fmt.Errorf("configure oidc: %w", err)
+	}
+
+	return service.NewOIDCService(storeHandle.Store, provider, cfg.OIDC, authService, instanceService, log.Logger), nil
+}
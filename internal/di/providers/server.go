@@ -92,6 +92,18 @@ func ProvideHTTPServer(i do.Injector) (*HTTPServerHandle, error) {
 	contributorService := do.MustInvoke[*service.ContributorService](i)
 	seriesService := do.MustInvoke[*service.SeriesService](i)
 	absImportService := do.MustInvoke[*service.ABSImportService](i)
+	bookmarkService := do.MustInvoke[*service.BookmarkService](i)
+	goalService := do.MustInvoke[*service.GoalService](i)
+	recapService := do.MustInvoke[*service.RecapService](i)
+	trashService := do.MustInvoke[*service.TrashService](i)
+	reviewService := do.MustInvoke[*service.ReviewService](i)
+	podcastService := do.MustInvoke[*service.PodcastService](i)
+	feedService := do.MustInvoke[*service.FeedService](i)
+	streamURLService := do.MustInvoke[*service.StreamURLService](i)
+	passwordService := do.MustInvoke[*service.PasswordService](i)
+	sessionService := do.MustInvoke[*service.SessionService](i)
+	twoFactorService := do.MustInvoke[*service.TwoFactorService](i)
+	oidcService := do.MustInvoke[*service.OIDCService](i)
 
 	// Wire up activity recording to reading session service
 	readingSessionService.SetActivityRecorder(activityService)
@@ -100,9 +112,16 @@ func ProvideHTTPServer(i do.Injector) (*HTTPServerHandle, error) {
 	listeningService.SetMilestoneRecorder(activityService)
 	listeningService.SetStreakCalculator(socialService)
 
+	// Wire up goal tracking to listening service
+	goalService.SetActivityRecorder(activityService)
+	listeningService.SetGoalTracker(goalService)
+
 	// Wire up activity recording to shelf service
 	shelfService.SetActivityRecorder(activityService)
 
+	// Wire up activity recording to review service
+	reviewService.SetActivityRecorder(activityService)
+
 	tokenVerifier := &sseTokenVerifier{authService: authService}
 	sseHandler := sse.NewHandler(sseHandle.Manager, log.Logger, tokenVerifier, sseHandle.GetEventLogger())
 
@@ -119,6 +138,10 @@ func ProvideHTTPServer(i do.Injector) (*HTTPServerHandle, error) {
 		Tag:            tagService,
 		Search:         searchService,
 		Invite:         inviteService,
+		Password:       passwordService,
+		Session:        sessionService,
+		TwoFactor:      twoFactorService,
+		OIDC:           oidcService,
 		Admin:          adminService,
 		Transcode:      transcodeHandle.TranscodeService,
 		Metadata:       metadataHandle.MetadataService,
@@ -135,6 +158,14 @@ func ProvideHTTPServer(i do.Injector) (*HTTPServerHandle, error) {
 		Contributor:    contributorService,
 		Series:         seriesService,
 		ABSImport:      absImportService,
+		Bookmark:       bookmarkService,
+		Goal:           goalService,
+		Recap:          recapService,
+		Trash:          trashService,
+		Review:         reviewService,
+		Podcast:        podcastService,
+		Feed:           feedService,
+		StreamURL:      streamURLService,
 	}
 
 	storage := &api.StorageServices{
@@ -146,18 +177,18 @@ func ProvideHTTPServer(i do.Injector) (*HTTPServerHandle, error) {
 
 	// Create backup services
 	dataDir := cfg.Metadata.BasePath
-	backupDir := dataDir + "/backups"
-	backupSvc := backup.NewBackupService(storeHandle.Store, backupDir, dataDir, "dev", log.Logger)
+	backupSvc := do.MustInvoke[*backup.BackupService](i)
 	restoreSvc := backup.NewRestoreService(storeHandle.Store, dataDir, log.Logger)
 
 	indexerHandle := do.MustInvoke[*AsyncIndexerHandle](i)
 	fileWatcher := do.MustInvoke[*FileWatcherHandle](i)
 	sessionJob := do.MustInvoke[*SessionCleanupJob](i)
 	eventLogJob := do.MustInvoke[*EventLogCleanupJob](i)
+	backupJob := do.MustInvoke[*ScheduledBackupJob](i)
 
 	enricher := dto.NewEnricher(storeHandle.Store)
 	handler := api.NewServer(storeHandle.Store, enricher, services, storage, sseHandler, sseHandle.Manager, registrationBroadcaster, backupSvc, restoreSvc, log.Logger)
-	handler.SetWorkers(indexerHandle.Indexer, fileWatcher, sessionJob, eventLogJob)
+	handler.SetWorkers(indexerHandle.Indexer, fileWatcher, sessionJob, eventLogJob, backupJob)
 
 	// Wire mDNS refresh callback for when instance settings change
 	mdnsHandle := do.MustInvoke[*MDNSServiceHandle](i)
@@ -2,13 +2,17 @@ package providers
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 
 	"github.com/samber/do/v2"
 
 	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/backup"
 	"github.com/listenupapp/listenup-server/internal/config"
 	"github.com/listenupapp/listenup-server/internal/dto"
 	"github.com/listenupapp/listenup-server/internal/logger"
+	"github.com/listenupapp/listenup-server/internal/podcast"
 	"github.com/listenupapp/listenup-server/internal/scanner"
 	"github.com/listenupapp/listenup-server/internal/service"
 	"github.com/listenupapp/listenup-server/internal/sse"
@@ -27,9 +31,10 @@ func ProvideInstanceService(i do.Injector) (*service.InstanceService, error) {
 func ProvideSessionService(i do.Injector) (*service.SessionService, error) {
 	storeHandle := do.MustInvoke[*StoreHandle](i)
 	tokenService := do.MustInvoke[*auth.TokenService](i)
+	sseHandle := do.MustInvoke[*SSEManagerHandle](i)
 	log := do.MustInvoke[*logger.Logger](i)
 
-	return service.NewSessionService(storeHandle.Store, tokenService, log.Logger), nil
+	return service.NewSessionService(storeHandle.Store, tokenService, sseHandle.Manager, log.Logger), nil
 }
 
 // ProvideAuthService provides the authentication service.
@@ -38,9 +43,18 @@ func ProvideAuthService(i do.Injector) (*service.AuthService, error) {
 	tokenService := do.MustInvoke[*auth.TokenService](i)
 	sessionService := do.MustInvoke[*service.SessionService](i)
 	instanceService := do.MustInvoke[*service.InstanceService](i)
+	twoFactorService := do.MustInvoke[*service.TwoFactorService](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	return service.NewAuthService(storeHandle.Store, tokenService, sessionService, instanceService, twoFactorService, log.Logger), nil
+}
+
+// ProvideTwoFactorService provides the TOTP two-factor authentication service.
+func ProvideTwoFactorService(i do.Injector) (*service.TwoFactorService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
 	log := do.MustInvoke[*logger.Logger](i)
 
-	return service.NewAuthService(storeHandle.Store, tokenService, sessionService, instanceService, log.Logger), nil
+	return service.NewTwoFactorService(storeHandle.Store, log.Logger), nil
 }
 
 // ProvideBookService provides the book service.
@@ -68,11 +82,16 @@ func ProvideBookService(i do.Injector) (*service.BookService, error) {
 func ProvideChapterService(i do.Injector) (*service.ChapterService, error) {
 	storeHandle := do.MustInvoke[*StoreHandle](i)
 	metadataHandle := do.MustInvoke[*MetadataServiceHandle](i)
+	fileScanner := do.MustInvoke[*scanner.Scanner](i)
+	sseHandle := do.MustInvoke[*SSEManagerHandle](i)
 	log := do.MustInvoke[*logger.Logger](i)
 
 	return service.NewChapterService(
 		storeHandle.Store,
 		metadataHandle.MetadataService,
+		fileScanner,
+		dto.NewEnricher(storeHandle.Store),
+		sseHandle.Manager,
 		log.Logger,
 	), nil
 }
@@ -98,7 +117,7 @@ func ProvideSyncService(i do.Injector) (*service.SyncService, error) {
 	storeHandle := do.MustInvoke[*StoreHandle](i)
 	log := do.MustInvoke[*logger.Logger](i)
 
-	return service.NewSyncService(storeHandle.Store, log.Logger), nil
+	return service.NewSyncService(storeHandle.Store, dto.NewEnricher(storeHandle.Store), log.Logger), nil
 }
 
 // ProvideReadingSessionService provides the reading session management service.
@@ -129,6 +148,32 @@ func ProvideListeningService(i do.Injector) (*service.ListeningService, error) {
 	return service.NewListeningService(storeHandle.Store, sseHandle.Manager, readingSessionService, log.Logger), nil
 }
 
+// ProvideBookmarkService provides the bookmark service.
+func ProvideBookmarkService(i do.Injector) (*service.BookmarkService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	sseHandle := do.MustInvoke[*SSEManagerHandle](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	return service.NewBookmarkService(storeHandle.Store, sseHandle.Manager, log.Logger), nil
+}
+
+// ProvideGoalService provides the listening goal service.
+func ProvideGoalService(i do.Injector) (*service.GoalService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	sseHandle := do.MustInvoke[*SSEManagerHandle](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	return service.NewGoalService(storeHandle.Store, sseHandle.Manager, log.Logger), nil
+}
+
+// ProvideRecapService provides the listening recap service.
+func ProvideRecapService(i do.Injector) (*service.RecapService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	return service.NewRecapService(storeHandle.Store, log.Logger), nil
+}
+
 // ProvideStatsService provides the listening statistics service.
 func ProvideStatsService(i do.Injector) (*service.StatsService, error) {
 	storeHandle := do.MustInvoke[*StoreHandle](i)
@@ -204,6 +249,27 @@ func ProvideInviteService(i do.Injector) (*service.InviteService, error) {
 	return service.NewInviteService(storeHandle.Store, sessionService, log.Logger, serverURL), nil
 }
 
+// ProvidePasswordService provides the password change and reset service.
+func ProvidePasswordService(i do.Injector) (*service.PasswordService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	sessionService := do.MustInvoke[*service.SessionService](i)
+	notifierHandle := do.MustInvoke[*NotifierHandle](i)
+	log := do.MustInvoke[*logger.Logger](i)
+	cfg := do.MustInvoke[*config.Config](i)
+
+	// Reset links are opened from email, so prefer the address reachable
+	// from outside the local network.
+	serverURL := "http://localhost:" + cfg.Server.Port
+	switch {
+	case cfg.Server.RemoteURL != "":
+		serverURL = strings.TrimSuffix(cfg.Server.RemoteURL, "/")
+	case cfg.Server.LocalURL != "":
+		serverURL = strings.TrimSuffix(cfg.Server.LocalURL, "/")
+	}
+
+	return service.NewPasswordService(storeHandle.Store, sessionService, notifierHandle.Notifier, log.Logger, serverURL), nil
+}
+
 // ProvideAdminService provides the admin service.
 func ProvideAdminService(i do.Injector) (*service.AdminService, error) {
 	storeHandle := do.MustInvoke[*StoreHandle](i)
@@ -223,6 +289,31 @@ func ProvideShelfService(i do.Injector) (*service.ShelfService, error) {
 	return service.NewShelfService(storeHandle.Store, sseHandle.Manager, log.Logger), nil
 }
 
+// ProvideFeedService provides the OPDS catalog and podcast feed service.
+func ProvideFeedService(i do.Injector) (*service.FeedService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	shelfService := do.MustInvoke[*service.ShelfService](i)
+	searchService := do.MustInvoke[*service.SearchService](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	return service.NewFeedService(
+		storeHandle.Store,
+		shelfService,
+		searchService,
+		dto.NewEnricher(storeHandle.Store),
+		log.Logger,
+	), nil
+}
+
+// ProvideStreamURLService provides the signed stream URL service.
+func ProvideStreamURLService(i do.Injector) (*service.StreamURLService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	signer := do.MustInvoke[*auth.StreamSigner](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	return service.NewStreamURLService(storeHandle.Store, signer, log.Logger), nil
+}
+
 // ProvideInboxService provides the inbox staging workflow service.
 func ProvideInboxService(i do.Injector) (*service.InboxService, error) {
 	storeHandle := do.MustInvoke[*StoreHandle](i)
@@ -245,10 +336,17 @@ func ProvideSettingsService(i do.Injector) (*service.SettingsService, error) {
 // ProvideLibraryService provides the library management service.
 func ProvideLibraryService(i do.Injector) (*service.LibraryService, error) {
 	storeHandle := do.MustInvoke[*StoreHandle](i)
+	indexerHandle := do.MustInvoke[*AsyncIndexerHandle](i)
 	sseHandle := do.MustInvoke[*SSEManagerHandle](i)
 	log := do.MustInvoke[*logger.Logger](i)
 
-	return service.NewLibraryService(storeHandle.Store, sseHandle.Manager, log.Logger), nil
+	return service.NewLibraryService(
+		storeHandle.Store,
+		indexerHandle.Indexer,
+		dto.NewEnricher(storeHandle.Store),
+		sseHandle.Manager,
+		log.Logger,
+	), nil
 }
 
 // ProvideContributorService provides the contributor service.
@@ -276,3 +374,55 @@ func ProvideABSImportService(i do.Injector) (*service.ABSImportService, error) {
 
 	return service.NewABSImportService(storeHandle.Store, log.Logger), nil
 }
+
+// ProvideBackupService provides the backup service.
+// Backups are written to a "backups" directory under the metadata base path.
+func ProvideBackupService(i do.Injector) (*backup.BackupService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	cfg := do.MustInvoke[*config.Config](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	dataDir := cfg.Metadata.BasePath
+	return backup.NewBackupService(storeHandle.Store, filepath.Join(dataDir, "backups"), dataDir, "dev", log.Logger), nil
+}
+
+// ProvideReviewService provides the book review service.
+func ProvideReviewService(i do.Injector) (*service.ReviewService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	indexerHandle := do.MustInvoke[*AsyncIndexerHandle](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	return service.NewReviewService(storeHandle.Store, indexerHandle.Indexer, log.Logger), nil
+}
+
+// ProvidePodcastService provides the podcast subscription service.
+func ProvidePodcastService(i do.Injector) (*service.PodcastService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	indexerHandle := do.MustInvoke[*AsyncIndexerHandle](i)
+	sseHandle := do.MustInvoke[*SSEManagerHandle](i)
+	cfg := do.MustInvoke[*config.Config](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	client := podcast.NewClient(int64(cfg.Podcast.MaxDownloadMB) << 20)
+	return service.NewPodcastService(storeHandle.Store, indexerHandle.Indexer, sseHandle.Manager, client, log.Logger), nil
+}
+
+// ProvideTrashService provides the book trash service.
+func ProvideTrashService(i do.Injector) (*service.TrashService, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	transcodeHandle := do.MustInvoke[*TranscodeServiceHandle](i)
+	indexerHandle := do.MustInvoke[*AsyncIndexerHandle](i)
+	storages := do.MustInvoke[*ImageStorages](i)
+	sseHandle := do.MustInvoke[*SSEManagerHandle](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	return service.NewTrashService(
+		storeHandle.Store,
+		transcodeHandle.TranscodeService,
+		indexerHandle.Indexer,
+		storages.Covers,
+		dto.NewEnricher(storeHandle.Store),
+		sseHandle.Manager,
+		log.Logger,
+	), nil
+}
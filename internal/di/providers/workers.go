@@ -9,11 +9,13 @@ import (
 
 	"github.com/samber/do/v2"
 
+	"github.com/listenupapp/listenup-server/internal/backup"
 	"github.com/listenupapp/listenup-server/internal/config"
 	"github.com/listenupapp/listenup-server/internal/logger"
 	"github.com/listenupapp/listenup-server/internal/processor"
 	"github.com/listenupapp/listenup-server/internal/scanner"
 	"github.com/listenupapp/listenup-server/internal/service"
+	"github.com/listenupapp/listenup-server/internal/sse"
 	"github.com/listenupapp/listenup-server/internal/watcher"
 )
 
@@ -81,6 +83,7 @@ func (h *FileWatcherHandle) LastTick() time.Time {
 func ProvideFileWatcher(i do.Injector) (*FileWatcherHandle, error) {
 	log := do.MustInvoke[*logger.Logger](i)
 	bootstrap := do.MustInvoke[*Bootstrap](i)
+	storeHandle := do.MustInvoke[*StoreHandle](i)
 	eventProcessor := do.MustInvoke[*processor.EventProcessor](i)
 
 	// If no library exists yet, return a no-op watcher
@@ -101,6 +104,14 @@ func ProvideFileWatcher(i do.Injector) (*FileWatcherHandle, error) {
 		return handle, ctx.Err() // This will be nil
 	}
 
+	// Watch every audiobook library; the event processor routes each file
+	// to the library whose scan path contains it. Podcast libraries are
+	// written by the podcast service and never scanned.
+	libraries, err := storeHandle.ListLibraries(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
 	w, err := watcher.New(log.Logger, watcher.Options{IgnoreHidden: true})
 	if err != nil {
 		return nil, err
@@ -108,14 +119,19 @@ func ProvideFileWatcher(i do.Injector) (*FileWatcherHandle, error) {
 
 	// Watch library paths (skip non-existent paths gracefully)
 	watchedPaths := 0
-	for _, scanPath := range bootstrap.Library.ScanPaths {
-		if err := w.Watch(scanPath); err != nil {
-			// Log warning but continue - path may not exist yet or may have been removed
-			log.Warn("Cannot watch scan path", "path", scanPath, "error", err)
+	for _, library := range libraries {
+		if library.IsPodcast() {
 			continue
 		}
-		log.Info("Watching scan path", "path", scanPath)
-		watchedPaths++
+		for _, scanPath := range library.ScanPaths {
+			if err := w.Watch(scanPath); err != nil {
+				// Log warning but continue - path may not exist yet or may have been removed
+				log.Warn("Cannot watch scan path", "library_id", library.ID, "path", scanPath, "error", err)
+				continue
+			}
+			log.Info("Watching scan path", "library_id", library.ID, "path", scanPath)
+			watchedPaths++
+		}
 	}
 
 	if watchedPaths == 0 {
@@ -166,7 +182,7 @@ func ProvideFileWatcher(i do.Injector) (*FileWatcherHandle, error) {
 		}
 	}()
 
-	log.Info("File watcher started", "scan_paths", len(bootstrap.Library.ScanPaths))
+	log.Info("File watcher started", "libraries", len(libraries), "scan_paths", watchedPaths)
 
 	return handle, nil
 }
@@ -320,3 +336,308 @@ func ProvideEventLogCleanupJob(i do.Injector) (*EventLogCleanupJob, error) {
 
 	return job, nil
 }
+
+var scheduledBackupExpvarOnce sync.Once
+
+// scheduledBackupCheckInterval is how often the job checks whether a backup
+// is due. The backup interval itself lives in server settings so admins can
+// change it without a restart.
+const scheduledBackupCheckInterval = 1 * time.Minute
+
+// ScheduledBackupJob creates backups on the schedule in server settings.
+type ScheduledBackupJob struct {
+	*backup.Scheduler
+	cancel       context.CancelFunc
+	lastTickUnix int64
+}
+
+// Shutdown cancels the backup loop's context and stops the job.
+func (j *ScheduledBackupJob) Shutdown() error {
+	j.cancel()
+	return nil
+}
+
+// LastTick returns the wall-clock time of the most recent loop iteration.
+func (j *ScheduledBackupJob) LastTick() time.Time {
+	return time.Unix(atomic.LoadInt64(&j.lastTickUnix), 0)
+}
+
+// ProvideScheduledBackupJob provides the scheduled backup job.
+func ProvideScheduledBackupJob(i do.Injector) (*ScheduledBackupJob, error) {
+	storeHandle := do.MustInvoke[*StoreHandle](i)
+	sseHandle := do.MustInvoke[*SSEManagerHandle](i)
+	backupSvc := do.MustInvoke[*backup.BackupService](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	job := &ScheduledBackupJob{
+		Scheduler: backup.NewScheduler(backupSvc, storeHandle.Store, log.Logger),
+		cancel:    cancel,
+	}
+	atomic.StoreInt64(&job.lastTickUnix, time.Now().Unix())
+
+	scheduledBackupExpvarOnce.Do(func() {
+		pinned := job
+		expvar.Publish("scheduled_backup_last_tick_unix", expvar.Func(func() any {
+			return atomic.LoadInt64(&pinned.lastTickUnix)
+		}))
+	})
+
+	runIfDue := func() {
+		run, err := job.RunIfDue(ctx, time.Now())
+		if err != nil {
+			log.Warn("Scheduled backup check failed", "error", err)
+			return
+		}
+		if run == nil {
+			return
+		}
+
+		data := sse.BackupEventData{
+			BackupID:    run.BackupID,
+			Size:        run.Size,
+			DurationMs:  run.Duration.Milliseconds(),
+			Pruned:      run.Pruned,
+			Error:       run.Error,
+			CompletedAt: run.CompletedAt,
+		}
+		if run.Succeeded() {
+			log.Info("Scheduled backup completed", "backup_id", run.BackupID, "pruned", len(run.Pruned))
+			sseHandle.Manager.Emit(sse.NewBackupCompletedEvent(data))
+		} else {
+			sseHandle.Manager.Emit(sse.NewBackupFailedEvent(data))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(scheduledBackupCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				atomic.StoreInt64(&job.lastTickUnix, time.Now().Unix())
+				runIfDue()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	log.Info("Scheduled backup job started")
+
+	return job, nil
+}
+
+var trashPurgeExpvarOnce sync.Once
+
+// TrashPurgeJob permanently deletes trashed books once their retention
+// period in server settings has run out.
+type TrashPurgeJob struct {
+	cancel       context.CancelFunc
+	lastTickUnix int64
+}
+
+// Shutdown cancels the purge loop's context and stops the job.
+func (j *TrashPurgeJob) Shutdown() error {
+	j.cancel()
+	return nil
+}
+
+// LastTick returns the wall-clock time of the most recent loop iteration.
+func (j *TrashPurgeJob) LastTick() time.Time {
+	return time.Unix(atomic.LoadInt64(&j.lastTickUnix), 0)
+}
+
+// ProvideTrashPurgeJob provides the periodic trash purge job.
+func ProvideTrashPurgeJob(i do.Injector) (*TrashPurgeJob, error) {
+	trashService := do.MustInvoke[*service.TrashService](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	job := &TrashPurgeJob{cancel: cancel}
+	atomic.StoreInt64(&job.lastTickUnix, time.Now().Unix())
+
+	trashPurgeExpvarOnce.Do(func() {
+		pinned := job
+		expvar.Publish("trash_purge_last_tick_unix", expvar.Func(func() any {
+			return atomic.LoadInt64(&pinned.lastTickUnix)
+		}))
+	})
+
+	purgeDue := func() {
+		if count, err := trashService.PurgeDue(ctx, time.Now()); err != nil {
+			log.Warn("Trash purge failed", "error", err)
+		} else if count > 0 {
+			log.Info("Trash purge completed", "purged", count)
+		}
+	}
+
+	go func() {
+		// Initial purge on startup.
+		purgeDue()
+
+		ticker := time.NewTicker(1 * time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				atomic.StoreInt64(&job.lastTickUnix, time.Now().Unix())
+				purgeDue()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	log.Info("Trash purge job started")
+
+	return job, nil
+}
+
+var smartShelfRefreshExpvarOnce sync.Once
+
+// SmartShelfRefreshJob re-evaluates smart shelves so owners get a
+// shelf.updated event when books start or stop matching their rules.
+type SmartShelfRefreshJob struct {
+	cancel       context.CancelFunc
+	lastTickUnix int64
+}
+
+// Shutdown cancels the refresh loop's context and stops the job.
+func (j *SmartShelfRefreshJob) Shutdown() error {
+	j.cancel()
+	return nil
+}
+
+// LastTick returns the wall-clock time of the most recent loop iteration.
+func (j *SmartShelfRefreshJob) LastTick() time.Time {
+	return time.Unix(atomic.LoadInt64(&j.lastTickUnix), 0)
+}
+
+// ProvideSmartShelfRefreshJob provides the periodic smart shelf refresh job.
+func ProvideSmartShelfRefreshJob(i do.Injector) (*SmartShelfRefreshJob, error) {
+	shelfService := do.MustInvoke[*service.ShelfService](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	job := &SmartShelfRefreshJob{cancel: cancel}
+	atomic.StoreInt64(&job.lastTickUnix, time.Now().Unix())
+
+	smartShelfRefreshExpvarOnce.Do(func() {
+		pinned := job
+		expvar.Publish("smart_shelf_refresh_last_tick_unix", expvar.Func(func() any {
+			return atomic.LoadInt64(&pinned.lastTickUnix)
+		}))
+	})
+
+	refresh := func() {
+		if count, err := shelfService.RefreshSmartShelves(ctx); err != nil {
+			log.Warn("Smart shelf refresh failed", "error", err)
+		} else if count > 0 {
+			log.Debug("Smart shelf refresh completed", "shelves", count)
+		}
+	}
+
+	go func() {
+		// Initial pass on startup seeds the membership cache.
+		refresh()
+
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				atomic.StoreInt64(&job.lastTickUnix, time.Now().Unix())
+				refresh()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	log.Info("Smart shelf refresh job started")
+
+	return job, nil
+}
+
+var podcastRefreshExpvarOnce sync.Once
+
+// PodcastRefreshJob refreshes podcast feeds, downloads queued episodes and
+// applies retention rules. Episodes queued from the API are downloaded
+// straight away rather than on the next tick.
+type PodcastRefreshJob struct {
+	cancel       context.CancelFunc
+	lastTickUnix int64
+}
+
+// Shutdown cancels the refresh loop's context and stops the job. An
+// interrupted download is re-queued on the next start.
+func (j *PodcastRefreshJob) Shutdown() error {
+	j.cancel()
+	return nil
+}
+
+// LastTick returns the wall-clock time of the most recent loop iteration.
+func (j *PodcastRefreshJob) LastTick() time.Time {
+	return time.Unix(atomic.LoadInt64(&j.lastTickUnix), 0)
+}
+
+// ProvidePodcastRefreshJob provides the periodic podcast refresh job.
+func ProvidePodcastRefreshJob(i do.Injector) (*PodcastRefreshJob, error) {
+	podcastService := do.MustInvoke[*service.PodcastService](i)
+	log := do.MustInvoke[*logger.Logger](i)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	job := &PodcastRefreshJob{cancel: cancel}
+	atomic.StoreInt64(&job.lastTickUnix, time.Now().Unix())
+
+	podcastRefreshExpvarOnce.Do(func() {
+		pinned := job
+		expvar.Publish("podcast_refresh_last_tick_unix", expvar.Func(func() any {
+			return atomic.LoadInt64(&pinned.lastTickUnix)
+		}))
+	})
+
+	refresh := func() {
+		if count, err := podcastService.RefreshAll(ctx); err != nil {
+			log.Warn("Podcast refresh failed", "error", err)
+		} else if count > 0 {
+			log.Info("Podcast refresh completed", "new_episodes", count)
+		}
+	}
+
+	go func() {
+		if err := podcastService.ResetInterruptedDownloads(ctx); err != nil {
+			log.Warn("Failed to re-queue interrupted podcast downloads", "error", err)
+		}
+
+		// Initial refresh on startup.
+		refresh()
+
+		ticker := time.NewTicker(1 * time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				atomic.StoreInt64(&job.lastTickUnix, time.Now().Unix())
+				refresh()
+			case <-podcastService.DownloadsQueued():
+				podcastService.ProcessDownloads(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	log.Info("Podcast refresh job started")
+
+	return job, nil
+}
@@ -32,12 +32,18 @@ const (
 type ABSImport struct {
 	ID          string          `json:"id"`
 	Name        string          `json:"name"`        // User-friendly name (e.g., "ABS Backup 2024")
-	BackupPath  string          `json:"backup_path"` // Original file path for reference
+	BackupPath  string          `json:"backup_path"` // Original file path for reference; empty for live imports
 	Status      ABSImportStatus `json:"status"`
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 	CompletedAt *time.Time      `json:"completed_at,omitempty"`
 
+	// Live imports read from a running ABS server instead of a backup file,
+	// and can be re-synced while both servers run side by side.
+	ServerURL    string     `json:"server_url,omitempty"`
+	APIToken     string     `json:"-"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+
 	// Summary stats (denormalized for quick display)
 	TotalUsers       int `json:"total_users"`
 	TotalBooks       int `json:"total_books"`
@@ -47,6 +53,11 @@ type ABSImport struct {
 	SessionsImported int `json:"sessions_imported"`
 }
 
+// IsLive returns true if the import reads from a running ABS server.
+func (i *ABSImport) IsLive() bool {
+	return i.ServerURL != ""
+}
+
 // ABSImportUser represents a parsed ABS user with mapping state.
 type ABSImportUser struct {
 	ImportID    string `json:"import_id"`
@@ -32,6 +32,14 @@ const (
 
 	// ActivityUserJoined is recorded when a new user is approved to join the server.
 	ActivityUserJoined ActivityType = "user_joined"
+
+	// ActivityReviewedBook is recorded when a user first rates or reviews a book.
+	// Later edits to the same review don't create new activities.
+	ActivityReviewedBook ActivityType = "reviewed_book"
+
+	// ActivityGoalCompleted is recorded when a user reaches a listening goal.
+	// Repeating goals are recorded once per period.
+	ActivityGoalCompleted ActivityType = "goal_completed"
 )
 
 // MinListeningSessionMs is the minimum duration in milliseconds for a listening session
@@ -54,7 +62,7 @@ type Activity struct {
 	UserAvatarType  string `json:"user_avatar_type"`            // "auto" or "image"
 	UserAvatarValue string `json:"user_avatar_value,omitempty"` // Image path for "image" type
 
-	// Book activities (started_book, finished_book, listening_session)
+	// Book activities (started_book, finished_book, listening_session, reviewed_book)
 	BookID         string `json:"book_id,omitempty"`
 	BookTitle      string `json:"book_title,omitempty"`
 	BookAuthorName string `json:"book_author_name,omitempty"`
@@ -64,13 +72,16 @@ type Activity struct {
 	// Listening session activities
 	DurationMs int64 `json:"duration_ms,omitempty"` // Duration listened in milliseconds
 
-	// Milestone activities (streak_milestone, listening_milestone)
+	// Milestone activities (streak_milestone, listening_milestone, goal_completed)
 	MilestoneValue int    `json:"milestone_value,omitempty"`
-	MilestoneUnit  string `json:"milestone_unit,omitempty"` // "days" or "hours"
+	MilestoneUnit  string `json:"milestone_unit,omitempty"` // "days", "hours" or "books"
 
 	// Shelf activities (shelf_created)
 	ShelfID   string `json:"shelf_id,omitempty"`
 	ShelfName string `json:"shelf_name,omitempty"`
+
+	// Review activities (reviewed_book)
+	Rating float64 `json:"rating,omitempty"` // Star rating, 0.5 to 5
 }
 
 // Milestone thresholds.
@@ -16,6 +16,7 @@ type BookSeries struct {
 // Book represents an audiobook in the library.
 type Book struct {
 	Syncable
+	LibraryID     string            `json:"library_id,omitempty"` // Library whose scan path contains the book
 	ScannedAt     time.Time         `json:"scanned_at"`
 	CoverImage    *ImageFileInfo    `json:"cover_image,omitempty"`
 	ISBN          string            `json:"isbn,omitempty"`
@@ -32,6 +33,7 @@ type Book struct {
 	Contributors  []BookContributor `json:"contributors"`
 	Series        []BookSeries      `json:"series,omitempty"` // Multiple series with sequence per series
 	AudioFiles    []AudioFileInfo   `json:"audio_files"`
+	EbookFiles    []EbookFileInfo   `json:"ebook_files,omitempty"` // Companion ebooks in the book's folder
 	Chapters      []Chapter         `json:"chapters,omitempty"`
 	TotalDuration int64             `json:"total_duration"`
 	TotalSize     int64             `json:"total_size"`
@@ -54,6 +56,9 @@ type AudioFileInfo struct {
 	Bitrate  int    `json:"bitrate,omitempty"`
 	Inode    uint64 `json:"inode"`
 	ModTime  int64  `json:"mod_time"`
+
+	// Loudness is set once the file has been analyzed.
+	Loudness *Loudness `json:"loudness,omitempty"`
 }
 
 // ImageFileInfo represents an image file (cover art).
@@ -67,6 +72,24 @@ type ImageFileInfo struct {
 	BlurHash string `json:"blur_hash,omitempty"`
 }
 
+// EbookFileInfo represents a companion ebook (EPUB or PDF) stored alongside
+// the audio files.
+type EbookFileInfo struct {
+	ID       string `json:"id"`
+	Path     string `json:"path"`
+	Filename string `json:"filename"`
+	Format   string `json:"format"` // "epub" or "pdf"
+	Size     int64  `json:"size"`
+	Inode    uint64 `json:"inode"`
+	ModTime  int64  `json:"mod_time"`
+}
+
+// Ebook formats.
+const (
+	EbookFormatEPUB = "epub"
+	EbookFormatPDF  = "pdf"
+)
+
 // Chapter represents a chapter marker within an audiobook.
 type Chapter struct {
 	Title       string `json:"title"`
@@ -128,6 +151,16 @@ func (b *Book) RemoveAudioFileByInode(inode uint64) bool {
 	return false
 }
 
+// GetEbookFileByID finds a companion ebook by its ID.
+func (b *Book) GetEbookFileByID(id string) *EbookFileInfo {
+	for i := range b.EbookFiles {
+		if b.EbookFiles[i].ID == id {
+			return &b.EbookFiles[i]
+		}
+	}
+	return nil
+}
+
 // RecalculateTotals recalculates total duration and size from audio files.
 func (b *Book) RecalculateTotals() {
 	b.TotalDuration = 0
@@ -140,9 +173,43 @@ func (b *Book) RecalculateTotals() {
 	}
 }
 
+// ChaptersForFile returns the chapters that play within an audio file, with
+// start and end times relative to the start of that file. Chapter times are
+// stored on the whole-book timeline, so a chapter spanning two files shows
+// up in both, clipped to each file's bounds. Returns nil if the file is not
+// part of the book.
+func (b *Book) ChaptersForFile(fileID string) []Chapter {
+	var offset int64
+	for _, af := range b.AudioFiles {
+		if af.ID != fileID {
+			offset += af.Duration
+			continue
+		}
+
+		fileEnd := offset + af.Duration
+		var chapters []Chapter
+		for _, ch := range b.Chapters {
+			if ch.EndTime <= offset || ch.StartTime >= fileEnd {
+				continue
+			}
+			ch.StartTime = max(ch.StartTime, offset) - offset
+			ch.EndTime = min(ch.EndTime, fileEnd) - offset
+			chapters = append(chapters, ch)
+		}
+		return chapters
+	}
+	return nil
+}
+
 // GenerateAudioFileID creates a stable ID from an inode.
 // Format: "af-{hex}" where hexx is the inode in hexadecimal notation.
 // This ensures the same file always gets the same ID, even after renames :tada:.
 func GenerateAudioFileID(inode uint64) string {
 	return fmt.Sprintf("af-%x", inode)
 }
+
+// GenerateEbookFileID creates a stable ID for a companion ebook from its inode,
+// like GenerateAudioFileID. Format: "eb-{hex}".
+func GenerateEbookFileID(inode uint64) string {
+	return fmt.Sprintf("eb-%x", inode)
+}
@@ -13,6 +13,10 @@ type BookPreferences struct {
 
 	HideFromContinueListening bool `json:"hide_from_continue_listening"`
 
+	// PrivateListening keeps the book out of everything other users see:
+	// the activity feed, currently listening, its readers and the profile.
+	PrivateListening bool `json:"private_listening"`
+
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
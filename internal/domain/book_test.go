@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBook_ChaptersForFile(t *testing.T) {
+	t.Parallel()
+
+	book := &Book{
+		AudioFiles: []AudioFileInfo{
+			{ID: "af-1", Duration: 60_000},
+			{ID: "af-2", Duration: 60_000},
+		},
+		Chapters: []Chapter{
+			{Index: 0, Title: "One", StartTime: 0, EndTime: 40_000},
+			{Index: 1, Title: "Two", StartTime: 40_000, EndTime: 90_000},
+			{Index: 2, Title: "Three", StartTime: 90_000, EndTime: 120_000},
+		},
+	}
+
+	first := book.ChaptersForFile("af-1")
+	assert.Len(t, first, 2)
+	assert.Equal(t, "Two", first[1].Title)
+	assert.Equal(t, int64(40_000), first[1].StartTime)
+	assert.Equal(t, int64(60_000), first[1].EndTime) // Clipped to the file's end
+
+	second := book.ChaptersForFile("af-2")
+	assert.Len(t, second, 2)
+	assert.Equal(t, "Two", second[0].Title)
+	assert.Equal(t, int64(0), second[0].StartTime) // Continues from the previous file
+	assert.Equal(t, int64(30_000), second[0].EndTime)
+	assert.Equal(t, int64(30_000), second[1].StartTime)
+
+	assert.Nil(t, book.ChaptersForFile("missing"))
+}
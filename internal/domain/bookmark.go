@@ -0,0 +1,32 @@
+package domain
+
+// Bookmark is a user-placed marker at a position in a book.
+// When EndPositionMs is set the bookmark describes a clip spanning
+// PositionMs..EndPositionMs rather than a single point.
+//
+// Positions are book-relative (milliseconds from the start of the book),
+// matching PlaybackState.CurrentPositionMs. AudioFileID records which file
+// the position fell in at creation time so clients can seek directly.
+type Bookmark struct {
+	Syncable
+	UserID        string `json:"user_id"`
+	BookID        string `json:"book_id"`
+	AudioFileID   string `json:"audio_file_id,omitempty"`
+	PositionMs    int64  `json:"position_ms"`
+	EndPositionMs *int64 `json:"end_position_ms,omitempty"` // Set for clips
+	Title         string `json:"title,omitempty"`
+	Note          string `json:"note,omitempty"`
+}
+
+// IsClip returns true if the bookmark spans a range rather than a single point.
+func (b *Bookmark) IsClip() bool {
+	return b.EndPositionMs != nil
+}
+
+// DurationMs returns the clip length in milliseconds, or 0 for point bookmarks.
+func (b *Bookmark) DurationMs() int64 {
+	if b.EndPositionMs == nil {
+		return 0
+	}
+	return *b.EndPositionMs - b.PositionMs
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// EbookPosition is where a user is in a book's companion ebook.
+// It is kept separately from the audio PlaybackState: the two formats have
+// no shared timeline, so clients decide how to hand off between them.
+type EbookPosition struct {
+	UserID      string `json:"user_id"`
+	BookID      string `json:"book_id"`
+	EbookFileID string `json:"ebook_file_id"`
+
+	// CFI is the EPUB Canonical Fragment Identifier of the reading position,
+	// e.g. "epubcfi(/6/14!/4/2/1:0)". PDF readers store their own locator.
+	CFI string `json:"cfi"`
+
+	// Progress is the fraction of the ebook read, from 0 to 1.
+	Progress float64 `json:"progress"`
+
+	DeviceID  string    `json:"device_id,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EbookPositionID generates composite key: "userID:ebook:bookID".
+func EbookPositionID(userID, bookID string) string {
+	return userID + ":ebook:" + bookID
+}
+
+// IsEPUBCFI reports whether s looks like an EPUB CFI: "epubcfi(" ... ")".
+func IsEPUBCFI(s string) bool {
+	return len(s) > len("epubcfi()") && strings.HasPrefix(s, "epubcfi(") && strings.HasSuffix(s, ")")
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// FeedToken lets third-party players read a user's OPDS catalog and podcast
+// feeds. Those players can only carry credentials in the URL, so instead of
+// an access token the URL holds a feed token: it only opens the read-only
+// feed routes, never expires, and can be revoked without signing the user
+// out anywhere else.
+type FeedToken struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"` // Label chosen by the user, e.g. "Overcast"
+	TokenHash  string     `json:"-"`    // SHA-256 of the token; the token itself is shown once
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"` // Updated at most once a minute
+}
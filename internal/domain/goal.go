@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// GoalMetric is what a listening goal counts.
+type GoalMetric string
+
+const (
+	// GoalMetricBooks counts books finished.
+	GoalMetricBooks GoalMetric = "books"
+	// GoalMetricHours counts hours listened.
+	GoalMetricHours GoalMetric = "hours"
+)
+
+// Valid returns true if the metric is a recognized value.
+func (m GoalMetric) Valid() bool {
+	return m == GoalMetricBooks || m == GoalMetricHours
+}
+
+// GoalPeriod is the window a goal is measured over.
+// Week, month and year goals repeat: progress always covers the current
+// calendar period, so a yearly goal starts over every January.
+type GoalPeriod string
+
+// GoalPeriod constants for goal windows.
+const (
+	GoalPeriodWeek  GoalPeriod = "week"
+	GoalPeriodMonth GoalPeriod = "month"
+	GoalPeriodYear  GoalPeriod = "year"
+	// GoalPeriodCustom is a one-off goal over a fixed date range.
+	GoalPeriodCustom GoalPeriod = "custom"
+)
+
+// Valid returns true if the period is a recognized value.
+func (p GoalPeriod) Valid() bool {
+	switch p {
+	case GoalPeriodWeek, GoalPeriodMonth, GoalPeriodYear, GoalPeriodCustom:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListeningGoal is a user's target for a period, such as 24 books this year
+// or 5 hours a week. Progress is not stored; it is computed from listening
+// events and finished playback states whenever it is needed.
+type ListeningGoal struct {
+	ID     string     `json:"id"`
+	UserID string     `json:"user_id"`
+	Metric GoalMetric `json:"metric"`
+	Period GoalPeriod `json:"period"`
+	Target int        `json:"target"` // Books, or whole hours
+
+	// Custom goals only. EndsAt is exclusive.
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+
+	// CompletedAt is when the target was last reached. For repeating goals
+	// it only counts for the current period if it falls inside its window.
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Window returns the goal's current measuring window at now.
+// Start is inclusive, end is exclusive.
+func (g *ListeningGoal) Window(now time.Time) (start, end time.Time) {
+	if g.Period == GoalPeriodCustom {
+		if g.StartsAt != nil {
+			start = *g.StartsAt
+		}
+		if g.EndsAt != nil {
+			end = *g.EndsAt
+		}
+		return start, end
+	}
+
+	start, _ = StatsPeriod(g.Period).Bounds(now)
+	switch g.Period {
+	case GoalPeriodWeek:
+		end = start.AddDate(0, 0, 7)
+	case GoalPeriodMonth:
+		end = start.AddDate(0, 1, 0)
+	default:
+		end = start.AddDate(1, 0, 0)
+	}
+	return start, end
+}
+
+// CompletedSince reports whether the goal was completed at or after t.
+func (g *ListeningGoal) CompletedSince(t time.Time) bool {
+	return g.CompletedAt != nil && !g.CompletedAt.Before(t)
+}
+
+// GoalProgress is a goal's standing in its current window.
+type GoalProgress struct {
+	Goal        *ListeningGoal `json:"goal"`
+	WindowStart time.Time      `json:"window_start"`
+	WindowEnd   time.Time      `json:"window_end"`
+	Current     float64        `json:"current"` // Books, or hours to one decimal
+	Percent     float64        `json:"percent"` // 0-100, capped
+	Completed   bool           `json:"completed"`
+}
+
+// ProgressFor builds the goal's progress from what the user did in the window.
+func (g *ListeningGoal) ProgressFor(start, end time.Time, booksFinished int, listenedMs int64) GoalProgress {
+	current := float64(booksFinished)
+	if g.Metric == GoalMetricHours {
+		current = math.Floor(float64(listenedMs)/float64(time.Hour/time.Millisecond)*10) / 10
+	}
+
+	var percent float64
+	if g.Target > 0 {
+		percent = min(current/float64(g.Target)*100, 100)
+	}
+
+	return GoalProgress{
+		Goal:        g,
+		WindowStart: start,
+		WindowEnd:   end,
+		Current:     current,
+		Percent:     math.Round(percent*10) / 10,
+		Completed:   g.Target > 0 && current >= float64(g.Target),
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListeningGoal_Window(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday
+	now := time.Date(2026, 3, 18, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period    GoalPeriod
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{GoalPeriodWeek, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 23, 0, 0, 0, 0, time.UTC)},
+		{GoalPeriodMonth, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{GoalPeriodYear, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		goal := &ListeningGoal{Period: tt.period}
+		start, end := goal.Window(now)
+		assert.Equal(t, tt.wantStart, start, "start for %s", tt.period)
+		assert.Equal(t, tt.wantEnd, end, "end for %s", tt.period)
+	}
+
+	startsAt := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	custom := &ListeningGoal{Period: GoalPeriodCustom, StartsAt: &startsAt, EndsAt: &endsAt}
+	start, end := custom.Window(now)
+	assert.Equal(t, startsAt, start)
+	assert.Equal(t, endsAt, end)
+}
+
+func TestListeningGoal_CompletedSince(t *testing.T) {
+	t.Parallel()
+
+	windowStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	goal := &ListeningGoal{Period: GoalPeriodYear}
+	assert.False(t, goal.CompletedSince(windowStart))
+
+	lastYear := windowStart.Add(-time.Hour)
+	goal.CompletedAt = &lastYear
+	assert.False(t, goal.CompletedSince(windowStart), "completion in an earlier period doesn't count")
+
+	goal.CompletedAt = &windowStart
+	assert.True(t, goal.CompletedSince(windowStart))
+}
+
+func TestListeningGoal_ProgressFor(t *testing.T) {
+	t.Parallel()
+
+	var start, end time.Time
+
+	books := &ListeningGoal{Metric: GoalMetricBooks, Target: 4}
+	p := books.ProgressFor(start, end, 1, 0)
+	assert.InDelta(t, 1, p.Current, 0.001)
+	assert.InDelta(t, 25, p.Percent, 0.001)
+	assert.False(t, p.Completed)
+
+	p = books.ProgressFor(start, end, 6, 0)
+	assert.InDelta(t, 100, p.Percent, 0.001, "percent is capped")
+	assert.True(t, p.Completed)
+
+	hours := &ListeningGoal{Metric: GoalMetricHours, Target: 5}
+	p = hours.ProgressFor(start, end, 0, (4*time.Hour + 59*time.Minute).Milliseconds())
+	assert.InDelta(t, 4.9, p.Current, 0.001, "hours are floored so the goal isn't completed early")
+	assert.InDelta(t, 98, p.Percent, 0.001)
+	assert.False(t, p.Completed)
+
+	p = hours.ProgressFor(start, end, 0, (5 * time.Hour).Milliseconds())
+	assert.True(t, p.Completed)
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 )
 
@@ -21,6 +23,19 @@ const (
 	AccessModeRestricted AccessMode = "restricted"
 )
 
+// LibraryType determines what a library holds.
+type LibraryType string
+
+const (
+	// LibraryTypeAudiobook libraries are scanned for audiobooks. This is the
+	// default for libraries created before library types existed.
+	LibraryTypeAudiobook LibraryType = "audiobook"
+
+	// LibraryTypePodcast libraries hold feed subscriptions. Their scan paths
+	// are where episodes are downloaded; the scanner never walks them.
+	LibraryTypePodcast LibraryType = "podcast"
+)
+
 // Library represents a physical audiobook collection rooted at a filesystem path.
 // A library can scan multiple filesystem paths and presents them as a single.
 // unified collection.
@@ -33,6 +48,14 @@ type Library struct {
 	ScanPaths  []string   `json:"scan_paths"`
 	SkipInbox  bool       `json:"skip_inbox"`  // If true, new books bypass Inbox and are immediately public
 	AccessMode AccessMode `json:"access_mode"` // Empty = "open" for backward compat
+	// MetadataProviders lists provider names in the order matches are tried.
+	// Empty uses the server's default order.
+	MetadataProviders []string `json:"metadata_providers,omitempty"`
+	// MetadataRegion is the Audible marketplace used for matches in this
+	// library. Empty uses the server's default region.
+	MetadataRegion string `json:"metadata_region,omitempty"`
+	// Type is what the library holds. Empty = "audiobook" for backward compat.
+	Type LibraryType `json:"type,omitempty"`
 }
 
 // AddScanPath adds a path to the library's scan paths if not already present.
@@ -51,6 +74,58 @@ func (l *Library) RemoveScanPath(path string) {
 	})
 }
 
+// ScanPathFor returns the library's deepest scan path containing path,
+// or "" if none does.
+func (l *Library) ScanPathFor(path string) string {
+	path = filepath.Clean(path)
+	var best string
+	for _, root := range l.ScanPaths {
+		root = filepath.Clean(root)
+		if pathWithin(path, root) && len(root) > len(best) {
+			best = root
+		}
+	}
+	return best
+}
+
+// ContainsPath reports whether path lies under one of the library's scan paths.
+func (l *Library) ContainsPath(path string) bool {
+	return l.ScanPathFor(path) != ""
+}
+
+// LibraryForPath returns the library whose scan path contains path. When
+// libraries have nested scan paths, the deepest match wins. Returns nil if
+// no library contains the path.
+func LibraryForPath(libraries []*Library, path string) *Library {
+	var (
+		best    *Library
+		bestLen int
+	)
+	for _, lib := range libraries {
+		if root := lib.ScanPathFor(path); len(root) > bestLen {
+			best, bestLen = lib, len(root)
+		}
+	}
+	return best
+}
+
+// ScanPathsOverlap reports whether one path contains the other.
+func ScanPathsOverlap(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	return pathWithin(a, b) || pathWithin(b, a)
+}
+
+// pathWithin reports whether path equals root or lies beneath it.
+func pathWithin(path, root string) bool {
+	if path == root {
+		return true
+	}
+	if root == string(filepath.Separator) {
+		return strings.HasPrefix(path, root)
+	}
+	return strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
 // GetAccessMode returns the effective access mode, defaulting to open.
 // This ensures backward compatibility - existing libraries without an
 // explicit access_mode behave as open.
@@ -70,3 +145,16 @@ func (l *Library) IsOpen() bool {
 func (l *Library) IsRestricted() bool {
 	return l.GetAccessMode() == AccessModeRestricted
 }
+
+// GetType returns the effective library type, defaulting to audiobook.
+func (l *Library) GetType() LibraryType {
+	if l.Type == "" {
+		return LibraryTypeAudiobook
+	}
+	return l.Type
+}
+
+// IsPodcast returns true if the library holds podcast subscriptions.
+func (l *Library) IsPodcast() bool {
+	return l.GetType() == LibraryTypePodcast
+}
@@ -68,3 +68,38 @@ func TestLibrary_IsRestricted(t *testing.T) {
 		})
 	}
 }
+
+func TestLibraryForPath(t *testing.T) {
+	t.Parallel()
+	audiobooks := &Library{ID: "audiobooks", ScanPaths: []string{"/media/audiobooks"}}
+	kids := &Library{ID: "kids", ScanPaths: []string{"/media/audiobooks/kids", "/srv/kids/"}}
+	libraries := []*Library{audiobooks, kids}
+
+	tests := []struct {
+		name string
+		path string
+		want *Library
+	}{
+		{"file under scan path", "/media/audiobooks/Dune/01.mp3", audiobooks},
+		{"scan path itself", "/media/audiobooks", audiobooks},
+		{"nested scan path wins", "/media/audiobooks/kids/Gruffalo/01.mp3", kids},
+		{"trailing slash on scan path", "/srv/kids/Matilda", kids},
+		{"sibling with shared prefix", "/media/audiobooks-old/Dune", nil},
+		{"outside every library", "/tmp/Dune", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, LibraryForPath(libraries, tt.path))
+		})
+	}
+}
+
+func TestScanPathsOverlap(t *testing.T) {
+	t.Parallel()
+	assert.True(t, ScanPathsOverlap("/media/books", "/media/books/"))
+	assert.True(t, ScanPathsOverlap("/media", "/media/books"))
+	assert.True(t, ScanPathsOverlap("/media/books", "/media"))
+	assert.False(t, ScanPathsOverlap("/media/books", "/media/books2"))
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// OIDCIdentity links an account at an OpenID provider, identified by its
+// issuer and subject, to a ListenUp user. A user can have several.
+type OIDCIdentity struct {
+	Issuer      string    `json:"issuer"`
+	Subject     string    `json:"subject"`
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email,omitempty"` // Email the provider reported when linked
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// PasswordReset is a one-time code that lets a user choose a new password
+// without knowing the old one. Admins issue codes for users who are locked
+// out; users can also have one emailed to themselves. Like an Invite, a code
+// is consumed on use and expires; unlike an Invite, only its hash is stored
+// because it grants access to an existing account.
+type PasswordReset struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	CodeHash  string     `json:"-"`                    // SHA-256 of the code; the code itself is shown once
+	CreatedBy string     `json:"created_by,omitempty"` // Admin user ID, empty when the user asked for it
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// IsUsed returns true if the code has been redeemed.
+func (r *PasswordReset) IsUsed() bool {
+	return r.UsedAt != nil
+}
+
+// IsExpired returns true if the code has passed its expiration time.
+func (r *PasswordReset) IsExpired() bool {
+	return time.Now().After(r.ExpiresAt)
+}
+
+// IsValid returns true if the code can still be redeemed.
+func (r *PasswordReset) IsValid() bool {
+	return !r.IsUsed() && !r.IsExpired()
+}
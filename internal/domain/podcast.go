@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// EpisodeStatus tracks where an episode's audio is.
+type EpisodeStatus string
+
+const (
+	// EpisodeStatusAvailable means the episode is in the feed but not downloaded.
+	EpisodeStatusAvailable EpisodeStatus = "available"
+	// EpisodeStatusQueued means the episode is waiting to be downloaded.
+	EpisodeStatusQueued EpisodeStatus = "queued"
+	// EpisodeStatusDownloading means the download is in progress.
+	EpisodeStatusDownloading EpisodeStatus = "downloading"
+	// EpisodeStatusDownloaded means the audio is in the library.
+	EpisodeStatusDownloaded EpisodeStatus = "downloaded"
+	// EpisodeStatusFailed means the last download attempt failed.
+	EpisodeStatusFailed EpisodeStatus = "failed"
+)
+
+// Podcast is a feed subscription in a podcast library.
+type Podcast struct {
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string    `json:"id"`
+	LibraryID   string    `json:"library_id"`
+	FeedURL     string    `json:"feed_url"`
+	Title       string    `json:"title"`
+	Author      string    `json:"author,omitempty"`
+	Description string    `json:"description,omitempty"`
+	ImageURL    string    `json:"image_url,omitempty"`
+	Link        string    `json:"link,omitempty"` // Show website
+	Language    string    `json:"language,omitempty"`
+	Explicit    bool      `json:"explicit,omitempty"`
+
+	// Dir is the directory episodes are downloaded into, under one of the
+	// library's scan paths.
+	Dir string `json:"dir"`
+
+	// AutoDownload queues new episodes for download as they appear in the feed.
+	AutoDownload bool `json:"auto_download"`
+	// KeepEpisodes keeps at most this many downloaded episodes. 0 keeps all.
+	KeepEpisodes int `json:"keep_episodes"`
+	// KeepDays removes downloads of episodes published more than this many
+	// days ago. 0 keeps all.
+	KeepDays int `json:"keep_days"`
+
+	// ETag and LastModified are the feed's validators from the last refresh,
+	// sent back so unchanged feeds aren't downloaded again.
+	ETag            string     `json:"etag,omitempty"`
+	LastModified    string     `json:"last_modified,omitempty"`
+	LastRefreshedAt *time.Time `json:"last_refreshed_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"` // Last refresh failure; empty after a success
+}
+
+// PodcastEpisode is one item of a podcast feed.
+type PodcastEpisode struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ID          string     `json:"id"`
+	PodcastID   string     `json:"podcast_id"`
+	GUID        string     `json:"guid"` // Feed item identity; the enclosure URL when the feed has none
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	DurationMs  int64      `json:"duration_ms"` // From the feed until downloaded, then from the file
+	Season      int        `json:"season,omitempty"`
+	Number      int        `json:"number,omitempty"`
+
+	EnclosureURL  string `json:"enclosure_url"`
+	EnclosureType string `json:"enclosure_type,omitempty"`
+	EnclosureSize int64  `json:"enclosure_size,omitempty"`
+
+	Status       EpisodeStatus `json:"status"`
+	FilePath     string        `json:"file_path,omitempty"`
+	FileSize     int64         `json:"file_size,omitempty"`
+	DownloadedAt *time.Time    `json:"downloaded_at,omitempty"`
+	Error        string        `json:"error,omitempty"` // Last download failure
+}
+
+// IsDownloaded reports whether the episode's audio is in the library.
+func (e *PodcastEpisode) IsDownloaded() bool {
+	return e.Status == EpisodeStatusDownloaded && e.FilePath != ""
+}
+
+// ReleasedAt returns when the episode was published, falling back to when
+// it was first seen for feeds without dates.
+func (e *PodcastEpisode) ReleasedAt() time.Time {
+	if e.PublishedAt != nil {
+		return *e.PublishedAt
+	}
+	return e.CreatedAt
+}
+
+// EpisodesToPrune returns the downloaded episodes that fall outside the
+// podcast's retention rules at now: those beyond the newest KeepEpisodes
+// downloads and those released more than KeepDays ago.
+func (p *Podcast) EpisodesToPrune(episodes []*PodcastEpisode, now time.Time) []*PodcastEpisode {
+	if p.KeepEpisodes <= 0 && p.KeepDays <= 0 {
+		return nil
+	}
+
+	var downloaded []*PodcastEpisode
+	for _, e := range episodes {
+		if e.IsDownloaded() {
+			downloaded = append(downloaded, e)
+		}
+	}
+	slices.SortStableFunc(downloaded, func(a, b *PodcastEpisode) int {
+		return b.ReleasedAt().Compare(a.ReleasedAt())
+	})
+
+	cutoff := now.AddDate(0, 0, -p.KeepDays)
+	var prune []*PodcastEpisode
+	for i, e := range downloaded {
+		switch {
+		case p.KeepEpisodes > 0 && i >= p.KeepEpisodes:
+			prune = append(prune, e)
+		case p.KeepDays > 0 && e.ReleasedAt().Before(cutoff):
+			prune = append(prune, e)
+		}
+	}
+	return prune
+}
+
+// DirName returns the directory name a podcast's episodes are downloaded
+// into: its title, made safe for any filesystem, with the end of its ID so
+// podcasts with the same title never share a folder.
+func (p *Podcast) DirName() string {
+	title := safeFileName(p.Title)
+	if title == "" {
+		return p.ID
+	}
+	return title + " [" + p.ID[max(0, len(p.ID)-8):] + "]"
+}
+
+// OwnsFile reports whether path lies inside the podcast's folder, and so is
+// safe to delete on its behalf.
+func (p *Podcast) OwnsFile(path string) bool {
+	if p.Dir == "" || path == "" {
+		return false
+	}
+	rel, err := filepath.Rel(p.Dir, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return false
+	}
+	return rel != "."
+}
+
+// EpisodeFileName returns the file name an episode is downloaded to: its
+// release date and title, made safe for any filesystem, with ext appended.
+func EpisodeFileName(e *PodcastEpisode, ext string) string {
+	title := cmp.Or(safeFileName(e.Title), e.ID)
+	return e.ReleasedAt().UTC().Format(time.DateOnly) + " - " + title + ext
+}
+
+// safeFileName replaces characters that are invalid in file names on common
+// filesystems, collapses whitespace and caps the length at 150 runes.
+func safeFileName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case strings.ContainsRune(`/\:*?"<>|`, r):
+			return '-'
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+	s = strings.Trim(strings.Join(strings.Fields(s), " "), ". ")
+
+	const maxLen = 150
+	if runes := []rune(s); len(runes) > maxLen {
+		s = strings.TrimSpace(string(runes[:maxLen]))
+	}
+	return s
+}
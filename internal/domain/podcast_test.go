@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLibrary_GetType(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, LibraryTypeAudiobook, (&Library{}).GetType())
+	assert.False(t, (&Library{}).IsPodcast())
+	assert.True(t, (&Library{Type: LibraryTypePodcast}).IsPodcast())
+}
+
+func TestPodcast_EpisodesToPrune(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) *time.Time {
+		ts := now.AddDate(0, 0, -n)
+		return &ts
+	}
+	episode := func(id string, published *time.Time, status EpisodeStatus) *PodcastEpisode {
+		return &PodcastEpisode{ID: id, PublishedAt: published, Status: status, FilePath: "/podcasts/" + id + ".mp3"}
+	}
+	episodes := []*PodcastEpisode{
+		episode("old", daysAgo(40), EpisodeStatusDownloaded),
+		episode("new", daysAgo(1), EpisodeStatusDownloaded),
+		episode("mid", daysAgo(10), EpisodeStatusDownloaded),
+		episode("streamed", daysAgo(0), EpisodeStatusAvailable),
+		{ID: "undated", CreatedAt: now.AddDate(0, 0, -5), Status: EpisodeStatusDownloaded, FilePath: "/podcasts/undated.mp3"},
+	}
+
+	ids := func(eps []*PodcastEpisode) []string {
+		var out []string
+		for _, e := range eps {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		podcast Podcast
+		want    []string
+	}{
+		{"no rules keeps everything", Podcast{}, nil},
+		{"keep newest two", Podcast{KeepEpisodes: 2}, []string{"mid", "old"}},
+		{"keep thirty days", Podcast{KeepDays: 30}, []string{"old"}},
+		{"both rules apply", Podcast{KeepEpisodes: 3, KeepDays: 7}, []string{"mid", "old"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ids(tt.podcast.EpisodesToPrune(episodes, now)))
+		})
+	}
+}
+
+func TestEpisodeFileName(t *testing.T) {
+	t.Parallel()
+	published := time.Date(2026, 3, 4, 23, 0, 0, 0, time.FixedZone("EST", -5*3600))
+
+	e := &PodcastEpisode{ID: "ep-1", Title: `  Q&A: "Why?" / Part 1\2 ..`, PublishedAt: &published}
+	assert.Equal(t, "2026-03-05 - Q&A- -Why-- - Part 1-2.mp3", EpisodeFileName(e, ".mp3"))
+
+	e = &PodcastEpisode{ID: "ep-2", Title: "\x00...", CreatedAt: published}
+	assert.Equal(t, "2026-03-05 - ep-2.m4a", EpisodeFileName(e, ".m4a"))
+}
+
+func TestPodcast_DirName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "AC-DC Radio [pc-1]", (&Podcast{ID: "pc-1", Title: " AC/DC  Radio "}).DirName())
+	assert.Equal(t, "pc-2", (&Podcast{ID: "pc-2", Title: "..."}).DirName())
+	assert.Equal(t, "The Show [Zx9_-K2q]", (&Podcast{ID: "podcast-V1StGXR8Zx9_-K2q", Title: "The Show"}).DirName())
+}
+
+func TestPodcast_OwnsFile(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join("library", "The Show [pc-1]")
+	p := &Podcast{ID: "pc-1", Dir: dir}
+
+	assert.True(t, p.OwnsFile(filepath.Join(dir, "2026-03-05 - Ep.mp3")))
+	assert.False(t, p.OwnsFile(dir))
+	assert.False(t, p.OwnsFile(""))
+	assert.False(t, p.OwnsFile(filepath.Join("library", "The Show [pc-2]", "2026-03-05 - Ep.mp3")))
+	assert.False(t, p.OwnsFile(filepath.Join(dir, "..", "other.mp3")))
+	assert.False(t, (&Podcast{ID: "pc-3"}).OwnsFile("ep.mp3"))
+}
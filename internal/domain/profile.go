@@ -19,8 +19,14 @@ type UserProfile struct {
 	AvatarType  AvatarType `json:"avatar_type"`
 	AvatarValue string     `json:"avatar_value"` // Image path (empty for auto)
 	Tagline     string     `json:"tagline"`      // Max 60 characters
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Social privacy. These hide the user from other users only.
+	HideFromLeaderboard    bool `json:"hide_from_leaderboard"`    // Not ranked; stats hidden on profile
+	HideActivity           bool `json:"hide_activity"`            // Not in the feed, readers lists or profile recent books
+	HideCurrentlyListening bool `json:"hide_currently_listening"` // Not shown as listening now
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // NewUserProfile creates a default profile for a user.
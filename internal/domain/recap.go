@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecapPeriod is the range a listening recap covers: a calendar year such as
+// "2025", or an inclusive date range such as "2025-06-01..2025-08-31".
+type RecapPeriod struct {
+	Key   string    // Canonical form, used as the cache key
+	Start time.Time // Inclusive, midnight local time
+	End   time.Time // Exclusive, midnight after the last day
+}
+
+// recapDateLayout is the date format used in range recap keys.
+const recapDateLayout = "2006-01-02"
+
+// MaxRecapRangeDays bounds custom recap ranges.
+const MaxRecapRangeDays = 3 * 366
+
+// ErrInvalidRecapPeriod is returned for recap periods that can't be parsed.
+var ErrInvalidRecapPeriod = errors.New(`recap period must be a year ("2025") or a date range ("2025-01-01..2025-06-30")`)
+
+// RecapPeriodForYear returns the recap period for a calendar year in loc.
+func RecapPeriodForYear(year int, loc *time.Location) RecapPeriod {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	return RecapPeriod{
+		Key:   strconv.Itoa(year),
+		Start: start,
+		End:   start.AddDate(1, 0, 0),
+	}
+}
+
+// ParseRecapPeriod parses a recap period key, with dates in loc.
+func ParseRecapPeriod(key string, loc *time.Location) (RecapPeriod, error) {
+	from, to, isRange := strings.Cut(key, "..")
+	if !isRange {
+		year, err := strconv.Atoi(key)
+		if err != nil || year < 1970 || year > 9999 {
+			return RecapPeriod{}, ErrInvalidRecapPeriod
+		}
+		return RecapPeriodForYear(year, loc), nil
+	}
+
+	start, err := time.ParseInLocation(recapDateLayout, from, loc)
+	if err != nil {
+		return RecapPeriod{}, ErrInvalidRecapPeriod
+	}
+	last, err := time.ParseInLocation(recapDateLayout, to, loc)
+	if err != nil {
+		return RecapPeriod{}, ErrInvalidRecapPeriod
+	}
+	if last.Before(start) {
+		return RecapPeriod{}, fmt.Errorf("recap period ends before it starts: %s", key)
+	}
+	end := last.AddDate(0, 0, 1)
+	if end.Sub(start) > MaxRecapRangeDays*24*time.Hour {
+		return RecapPeriod{}, fmt.Errorf("recap period is longer than %d days: %s", MaxRecapRangeDays, key)
+	}
+
+	return RecapPeriod{
+		Key:   start.Format(recapDateLayout) + ".." + last.Format(recapDateLayout),
+		Start: start,
+		End:   end,
+	}, nil
+}
+
+// IsYear reports whether the period is a whole calendar year.
+func (p RecapPeriod) IsYear() bool {
+	return !strings.Contains(p.Key, "..")
+}
+
+// Label returns a human-readable name for the period, such as "2025" or
+// "Jun 1 – Aug 31, 2025".
+func (p RecapPeriod) Label() string {
+	if p.IsYear() {
+		return p.Key
+	}
+	last := p.End.AddDate(0, 0, -1)
+	if p.Start.Year() == last.Year() {
+		return p.Start.Format("Jan 2") + " – " + last.Format("Jan 2, 2006")
+	}
+	return p.Start.Format("Jan 2, 2006") + " – " + last.Format("Jan 2, 2006")
+}
+
+// ListeningRecap is a "year in review" summary of a user's listening over a
+// period. Recaps are computed from listening events and finished playback
+// states, then cached per user and period.
+type ListeningRecap struct {
+	UserID      string    `json:"user_id"`
+	Period      string    `json:"period"` // RecapPeriod key
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"` // Exclusive
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// ShareID makes the recap readable on its public share page.
+	// Empty when the recap isn't shared. Stored in its own column.
+	ShareID string `json:"-"`
+
+	TotalListenTimeMs    int64   `json:"total_listen_time_ms"`
+	BooksFinished        int     `json:"books_finished"`
+	BooksListened        int     `json:"books_listened"` // Distinct books with any listening
+	ListeningDays        int     `json:"listening_days"`
+	LongestStreakDays    int     `json:"longest_streak_days"`
+	AveragePlaybackSpeed float64 `json:"average_playback_speed"` // Weighted by time listened
+
+	// Top five of each, most listened first
+	TopBooks     []RecapRanking `json:"top_books"`
+	TopAuthors   []RecapRanking `json:"top_authors"`
+	TopNarrators []RecapRanking `json:"top_narrators"`
+	TopGenres    []RecapRanking `json:"top_genres"`
+	TopSeries    []RecapRanking `json:"top_series"`
+
+	LongestSession *RecapSession   `json:"longest_session,omitempty"`
+	BusiestDay     *DailyListening `json:"busiest_day,omitempty"`
+
+	// HourlyHeatmap is listening time in milliseconds by weekday
+	// (Sunday first, as time.Weekday) and local hour of day.
+	HourlyHeatmap [7][24]int64 `json:"hourly_heatmap"`
+
+	FirstBook *RecapBook `json:"first_book,omitempty"` // First book listened to in the period
+	LastBook  *RecapBook `json:"last_book,omitempty"`  // Most recent book listened to in the period
+
+	// BookListenMs is listening time per book ID, kept so the share page
+	// can rank without the books the owner listens to privately.
+	BookListenMs map[string]int64 `json:"book_listen_ms,omitempty"`
+}
+
+// RecapRanking is an entry in one of a recap's top lists.
+type RecapRanking struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ListenTimeMs int64  `json:"listen_time_ms"`
+}
+
+// RecapSession is a stretch of continuous listening to one book.
+type RecapSession struct {
+	BookID       string    `json:"book_id"`
+	BookTitle    string    `json:"book_title"`
+	StartedAt    time.Time `json:"started_at"`
+	EndedAt      time.Time `json:"ended_at"`
+	ListenTimeMs int64     `json:"listen_time_ms"`
+}
+
+// RecapBook is a book highlighted in a recap.
+type RecapBook struct {
+	BookID     string    `json:"book_id"`
+	Title      string    `json:"title"`
+	AuthorName string    `json:"author_name,omitempty"`
+	ListenedAt time.Time `json:"listened_at"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecapPeriod(t *testing.T) {
+	t.Parallel()
+
+	year, err := ParseRecapPeriod("2025", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, "2025", year.Key)
+	assert.True(t, year.IsYear())
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), year.Start)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), year.End)
+	assert.Equal(t, "2025", year.Label())
+
+	summer, err := ParseRecapPeriod("2025-06-01..2025-08-31", time.UTC)
+	require.NoError(t, err)
+	assert.False(t, summer.IsYear())
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), summer.Start)
+	assert.Equal(t, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), summer.End, "end day is inclusive")
+	assert.Equal(t, "Jun 1 – Aug 31, 2025", summer.Label())
+
+	winter, err := ParseRecapPeriod("2025-12-01..2026-01-31", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, "Dec 1, 2025 – Jan 31, 2026", winter.Label())
+
+	oneDay, err := ParseRecapPeriod("2025-03-04..2025-03-04", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, oneDay.End.Sub(oneDay.Start))
+
+	for _, key := range []string{"", "25", "year", "2025-13-01..2025-12-31", "2025-06-01..", "2025-08-31..2025-06-01", "2020-01-01..2025-12-31"} {
+		_, err := ParseRecapPeriod(key, time.UTC)
+		assert.Error(t, err, "key %q should be rejected", key)
+	}
+}
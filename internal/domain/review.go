@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Review rating bounds. Ratings go in half-star steps.
+const (
+	MinReviewRating = 0.5
+	MaxReviewRating = 5.0
+)
+
+// BookReview is a user's star rating and optional written review of a book.
+// Each user has at most one review per book; setting a review again replaces it.
+type BookReview struct {
+	ID               string    `json:"id"`
+	UserID           string    `json:"user_id"`
+	BookID           string    `json:"book_id"`
+	Rating           float64   `json:"rating"`                      // 0.5 to 5 in half-star steps
+	Text             string    `json:"text,omitempty"`              // Optional written review
+	ContainsSpoilers bool      `json:"contains_spoilers,omitempty"` // Clients hide the text until tapped
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// ValidReviewRating reports whether rating is within bounds and a whole or half star.
+func ValidReviewRating(rating float64) bool {
+	if rating < MinReviewRating || rating > MaxReviewRating {
+		return false
+	}
+	return rating*2 == math.Trunc(rating*2)
+}
+
+// RatingSummary is the community rating for a book.
+type RatingSummary struct {
+	Average float64 `json:"average"` // Mean rating, 0 when Count is 0
+	Count   int     `json:"count"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidReviewRating(t *testing.T) {
+	t.Parallel()
+
+	for _, rating := range []float64{0.5, 1, 2.5, 4.5, 5} {
+		assert.True(t, ValidReviewRating(rating), "rating %v should be valid", rating)
+	}
+	for _, rating := range []float64{0, -1, 0.25, 3.3, 5.5} {
+		assert.False(t, ValidReviewRating(rating), "rating %v should be invalid", rating)
+	}
+}
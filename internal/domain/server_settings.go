@@ -2,20 +2,95 @@ package domain
 
 import "time"
 
+// Default backup schedule values, used for new installs and for settings
+// saved before scheduled backups existed.
+const (
+	DefaultBackupIntervalHours = 24
+	DefaultBackupKeepLast      = 3
+	DefaultBackupKeepDaily     = 7
+	DefaultBackupKeepWeekly    = 4
+	DefaultBackupKeepMonthly   = 6
+
+	// DefaultTrashRetentionDays is how long deleted books stay restorable.
+	DefaultTrashRetentionDays = 30
+)
+
 // ServerSettings contains server-wide configuration.
 type ServerSettings struct {
-	Name         string    `json:"name"`
-	InboxEnabled bool      `json:"inbox_enabled"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	Name         string         `json:"name"`
+	InboxEnabled bool           `json:"inbox_enabled"`
+	Backup       BackupSchedule `json:"backup"`
+	// TrashRetentionDays is how long deleted books can be restored before they
+	// are purged. Zero keeps them until an admin purges them by hand.
+	TrashRetentionDays int `json:"trash_retention_days"`
+	// RequireSignedStreamURLs stops audio being served to access tokens in
+	// the query string; players must use signed stream URLs instead.
+	RequireSignedStreamURLs bool `json:"require_signed_stream_urls"`
+	// RequireAdminTwoFactor keeps admins out of admin features until they
+	// enable two-factor authentication, and stops them turning it off.
+	RequireAdminTwoFactor bool      `json:"require_admin_two_factor"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// BackupSchedule configures automatic backups and how many of them are kept.
+//
+// Retention works in tiers: the newest KeepLast backups are always kept, and
+// on top of that the newest backup of each of the last KeepDaily days,
+// KeepWeekly ISO weeks and KeepMonthly months. When every Keep* value is zero,
+// nothing is pruned.
+type BackupSchedule struct {
+	Enabled       bool `json:"enabled"`
+	IntervalHours int  `json:"interval_hours"`
+	IncludeImages bool `json:"include_images"`
+	IncludeEvents bool `json:"include_events"`
+	KeepLast      int  `json:"keep_last"`
+	KeepDaily     int  `json:"keep_daily"`
+	KeepWeekly    int  `json:"keep_weekly"`
+	KeepMonthly   int  `json:"keep_monthly"`
+}
+
+// DefaultBackupSchedule returns a disabled schedule with sensible retention.
+func DefaultBackupSchedule() BackupSchedule {
+	return BackupSchedule{
+		Enabled:       false,
+		IntervalHours: DefaultBackupIntervalHours,
+		IncludeImages: false,
+		IncludeEvents: true,
+		KeepLast:      DefaultBackupKeepLast,
+		KeepDaily:     DefaultBackupKeepDaily,
+		KeepWeekly:    DefaultBackupKeepWeekly,
+		KeepMonthly:   DefaultBackupKeepMonthly,
+	}
+}
+
+// Interval returns the time between scheduled backups.
+// Falls back to the default interval if none is configured.
+func (b BackupSchedule) Interval() time.Duration {
+	if b.IntervalHours <= 0 {
+		return DefaultBackupIntervalHours * time.Hour
+	}
+	return time.Duration(b.IntervalHours) * time.Hour
 }
 
 // NewServerSettings creates settings with sensible defaults.
 func NewServerSettings() *ServerSettings {
 	return &ServerSettings{
-		Name:         "",
-		InboxEnabled: false,
-		UpdatedAt:    time.Now(),
+		Name:               "",
+		InboxEnabled:       false,
+		Backup:             DefaultBackupSchedule(),
+		TrashRetentionDays: DefaultTrashRetentionDays,
+		UpdatedAt:          time.Now(),
+	}
+}
+
+// TrashPurgeAt returns when a book deleted at the given time should be purged,
+// or nil if trashed books are kept until purged by hand.
+func (s *ServerSettings) TrashPurgeAt(deletedAt time.Time) *time.Time {
+	if s.TrashRetentionDays <= 0 {
+		return nil
 	}
+	purgeAt := deletedAt.AddDate(0, 0, s.TrashRetentionDays)
+	return &purgeAt
 }
 
 // GetDisplayName returns the server name or a default if empty.
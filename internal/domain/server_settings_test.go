@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,15 @@ func TestNewServerSettings_Defaults(t *testing.T) {
 	assert.Equal(t, "", settings.Name)
 	assert.False(t, settings.InboxEnabled)
 	assert.False(t, settings.UpdatedAt.IsZero())
+	assert.False(t, settings.Backup.Enabled)
+	assert.Equal(t, DefaultBackupIntervalHours, settings.Backup.IntervalHours)
+	assert.True(t, settings.Backup.IncludeEvents)
+}
+
+func TestBackupSchedule_Interval(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 6*time.Hour, BackupSchedule{IntervalHours: 6}.Interval())
+	assert.Equal(t, DefaultBackupIntervalHours*time.Hour, BackupSchedule{}.Interval())
 }
 
 func TestServerSettings_GetDisplayName(t *testing.T) {
@@ -9,6 +9,9 @@ import (
 // Unlike Collections (admin-managed access boundaries), Shelves are personal - each belongs
 // to one user. Users can create Shelves to organize their books by theme, reading progress,
 // or any other personal categorization.
+//
+// A shelf with Rules is a smart shelf: BookIDs are not stored but filled in
+// when the shelf is read, from the books that currently match the rules.
 type Shelf struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -19,6 +22,13 @@ type Shelf struct {
 	Color       string    `json:"color"`       // Reserved for future UI customization
 	Icon        string    `json:"icon"`        // Reserved for future UI customization
 	BookIDs     []string  `json:"book_ids"`    // Ordered list of book IDs (newest first)
+
+	Rules *ShelfRules `json:"rules,omitempty"` // Set for smart shelves only
+}
+
+// IsSmart reports whether the shelf is populated by rules rather than by hand.
+func (s *Shelf) IsSmart() bool {
+	return s.Rules != nil
 }
 
 // AddBook adds a book ID to the shelf, prepending it to maintain newest-first ordering.
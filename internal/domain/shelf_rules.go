@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// ShelfProgress filters a smart shelf by the owner's listening progress.
+type ShelfProgress string
+
+// Progress states a smart shelf can match on.
+const (
+	ShelfProgressAny        ShelfProgress = ""
+	ShelfProgressNotStarted ShelfProgress = "not_started"
+	ShelfProgressInProgress ShelfProgress = "in_progress"
+	ShelfProgressFinished   ShelfProgress = "finished"
+)
+
+// Valid reports whether p is a known progress state.
+func (p ShelfProgress) Valid() bool {
+	switch p {
+	case ShelfProgressAny, ShelfProgressNotStarted, ShelfProgressInProgress, ShelfProgressFinished:
+		return true
+	}
+	return false
+}
+
+// ShelfRules define a smart shelf. A smart shelf has no hand-picked books;
+// its contents are worked out from these rules every time it is read.
+//
+// Each rule that is set narrows the shelf (rules are ANDed). Within a list
+// rule, a book only has to match one entry (entries are ORed), so
+// GenreIDs ["fantasy", "sci-fi"] means "fantasy or sci-fi".
+type ShelfRules struct {
+	GenreIDs        []string      `json:"genre_ids,omitempty"`       // Genre subtrees: a genre matches its descendants too
+	TagSlugs        []string      `json:"tag_slugs,omitempty"`       // Community tags
+	ContributorIDs  []string      `json:"contributor_ids,omitempty"` // Any credited role
+	NarratorIDs     []string      `json:"narrator_ids,omitempty"`    // Contributors credited as narrator
+	SeriesIDs       []string      `json:"series_ids,omitempty"`
+	MinDurationMs   int64         `json:"min_duration_ms,omitempty"`
+	MaxDurationMs   int64         `json:"max_duration_ms,omitempty"`
+	MinYear         int           `json:"min_year,omitempty"`
+	MaxYear         int           `json:"max_year,omitempty"`
+	Progress        ShelfProgress `json:"progress,omitempty"`          // The shelf owner's progress
+	AddedWithinDays int           `json:"added_within_days,omitempty"` // Books added to the library in the last N days
+}
+
+// IsEmpty reports whether no rule is set. An empty rule set would match the
+// whole library, which is never what a smart shelf is for.
+func (r *ShelfRules) IsEmpty() bool {
+	return len(r.GenreIDs) == 0 &&
+		len(r.TagSlugs) == 0 &&
+		len(r.ContributorIDs) == 0 &&
+		len(r.NarratorIDs) == 0 &&
+		len(r.SeriesIDs) == 0 &&
+		r.MinDurationMs == 0 && r.MaxDurationMs == 0 &&
+		r.MinYear == 0 && r.MaxYear == 0 &&
+		r.Progress == ShelfProgressAny &&
+		r.AddedWithinDays == 0
+}
+
+// AddedSince returns the cutoff for the added-within rule, or the zero time
+// if the rule is not set.
+func (r *ShelfRules) AddedSince(now time.Time) time.Time {
+	if r.AddedWithinDays <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, -r.AddedWithinDays)
+}
+
+// MatchesProgress reports whether a book with the given playback state
+// (nil if never played) satisfies the progress rule.
+func (r *ShelfRules) MatchesProgress(state *PlaybackState) bool {
+	switch r.Progress {
+	case ShelfProgressNotStarted:
+		return state == nil || (!state.IsFinished && state.CurrentPositionMs == 0)
+	case ShelfProgressInProgress:
+		return state != nil && !state.IsFinished && state.CurrentPositionMs > 0
+	case ShelfProgressFinished:
+		return state != nil && state.IsFinished
+	default:
+		return true
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShelfRules_IsEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, (&ShelfRules{}).IsEmpty())
+	assert.False(t, (&ShelfRules{GenreIDs: []string{"genre-1"}}).IsEmpty())
+	assert.False(t, (&ShelfRules{MaxYear: 1990}).IsEmpty())
+	assert.False(t, (&ShelfRules{Progress: ShelfProgressFinished}).IsEmpty())
+}
+
+func TestShelfRules_AddedSince(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, (&ShelfRules{}).AddedSince(now).IsZero())
+	assert.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		(&ShelfRules{AddedWithinDays: 30}).AddedSince(now))
+}
+
+func TestShelfRules_MatchesProgress(t *testing.T) {
+	t.Parallel()
+
+	started := &PlaybackState{CurrentPositionMs: 60_000}
+	finished := &PlaybackState{CurrentPositionMs: 60_000, IsFinished: true}
+	reset := &PlaybackState{}
+
+	tests := []struct {
+		progress ShelfProgress
+		state    *PlaybackState
+		want     bool
+	}{
+		{ShelfProgressAny, finished, true},
+		{ShelfProgressNotStarted, nil, true},
+		{ShelfProgressNotStarted, reset, true},
+		{ShelfProgressNotStarted, started, false},
+		{ShelfProgressInProgress, started, true},
+		{ShelfProgressInProgress, nil, false},
+		{ShelfProgressInProgress, finished, false},
+		{ShelfProgressFinished, finished, true},
+		{ShelfProgressFinished, started, false},
+	}
+	for _, tt := range tests {
+		rules := &ShelfRules{Progress: tt.progress}
+		assert.Equal(t, tt.want, rules.MatchesProgress(tt.state), "progress=%q state=%+v", tt.progress, tt.state)
+	}
+}
+
+func TestShelfProgress_Valid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ShelfProgressAny.Valid())
+	assert.True(t, ShelfProgressInProgress.Valid())
+	assert.False(t, ShelfProgress("abandoned").Valid())
+}
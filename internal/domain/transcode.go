@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 // TranscodeStatus represents the state of a transcode job.
 type TranscodeStatus string
@@ -15,14 +18,60 @@ const (
 )
 
 // TranscodeVariant identifies the output format variant.
+// Loudness analysis shares the job queue as a variant that writes no audio.
 type TranscodeVariant string
 
 // Transcode variant constants.
 const (
-	TranscodeVariantStereo  TranscodeVariant = "stereo"  // 2-channel AAC
-	TranscodeVariantSpatial TranscodeVariant = "spatial" // 6-channel AAC (5.1)
+	TranscodeVariantStereo     TranscodeVariant = "stereo"     // 2-channel AAC
+	TranscodeVariantSpatial    TranscodeVariant = "spatial"    // 6-channel AAC (5.1)
+	TranscodeVariantNormalized TranscodeVariant = "normalized" // 2-channel AAC, loudness-normalized
+	TranscodeVariantLoudness   TranscodeVariant = "loudness"   // EBU R128 analysis only, no output
 )
 
+// IsHLS returns true if the variant produces an HLS stream.
+func (v TranscodeVariant) IsHLS() bool {
+	switch v {
+	case TranscodeVariantStereo, TranscodeVariantSpatial, TranscodeVariantNormalized:
+		return true
+	default:
+		return false
+	}
+}
+
+// Transcode job priorities. Workers take the highest first.
+const (
+	TranscodePriorityAnalysis   = 0  // Loudness analysis, when nothing else is waiting
+	TranscodePriorityBackground = 1  // Transcodes queued by the scanner
+	TranscodePriorityPlayback   = 10 // Transcodes a user is waiting on
+)
+
+// Loudness targets for normalized playback. -18 LUFS sits in the range
+// audiobook publishers master spoken word to; the peak ceiling leaves room
+// for lossy encoding.
+const (
+	LoudnessTargetLUFS   = -18.0
+	LoudnessMaxTruePeak  = -1.5
+	LoudnessSilenceFloor = -70.0 // EBU R128 absolute gate
+)
+
+// Loudness is an EBU R128 measurement of an audio file.
+type Loudness struct {
+	IntegratedLUFS float64 `json:"integrated_lufs"` // Integrated loudness
+	TruePeakDBTP   float64 `json:"true_peak_dbtp"`  // Maximum true peak
+}
+
+// GainDB returns the gain, in dB, that brings the file to LoudnessTargetLUFS
+// without pushing its true peak above LoudnessMaxTruePeak. Silent files get
+// no gain.
+func (l Loudness) GainDB() float64 {
+	if l.IntegratedLUFS <= LoudnessSilenceFloor {
+		return 0
+	}
+	gain := min(LoudnessTargetLUFS-l.IntegratedLUFS, LoudnessMaxTruePeak-l.TruePeakDBTP)
+	return math.Round(gain*10) / 10
+}
+
 // TranscodeJob represents a transcoding operation for an audio file.
 // Jobs are created when the scanner detects audio in a format that some
 // devices cannot play natively (e.g., Dolby AC-3, E-AC-3, DTS).
@@ -41,13 +90,13 @@ type TranscodeJob struct {
 	OutputCodec string `json:"output_codec"` // "aac"
 	OutputSize  int64  `json:"output_size,omitempty"`
 
-	// Output variant (stereo or spatial)
-	Variant TranscodeVariant `json:"variant"` // stereo or spatial
+	// Output variant (stereo, spatial or normalized), or loudness analysis
+	Variant TranscodeVariant `json:"variant"`
 
 	// Job state
 	Status   TranscodeStatus `json:"status"`
 	Progress int             `json:"progress"` // 0-100
-	Priority int             `json:"priority"` // Higher = more urgent (1=background, 10=user-requested)
+	Priority int             `json:"priority"` // Higher = more urgent (see the TranscodePriority constants)
 	Error    string          `json:"error,omitempty"`
 
 	// Timestamps
@@ -116,11 +165,16 @@ func (j *TranscodeJob) SetProgress(percent int) {
 
 // BumpPriority increases the job's priority for user-requested playback.
 func (j *TranscodeJob) BumpPriority() {
-	if j.Priority < 10 {
-		j.Priority = 10
+	if j.Priority < TranscodePriorityPlayback {
+		j.Priority = TranscodePriorityPlayback
 	}
 }
 
+// IsAnalysis returns true if the job measures loudness rather than transcoding.
+func (j *TranscodeJob) IsAnalysis() bool {
+	return j.Variant == TranscodeVariantLoudness
+}
+
 // MarkCancelled transitions the job to cancelled state.
 func (j *TranscodeJob) MarkCancelled() {
 	j.Status = TranscodeStatusCancelled
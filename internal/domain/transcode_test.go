@@ -42,3 +42,35 @@ func TestNeedsTranscode(t *testing.T) {
 		})
 	}
 }
+
+func TestLoudnessGainDB(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		loudness Loudness
+		expected float64
+	}{
+		{"quiet file is boosted", Loudness{IntegratedLUFS: -24, TruePeakDBTP: -10}, 6},
+		{"loud file is cut", Loudness{IntegratedLUFS: -14.2, TruePeakDBTP: -0.5}, -3.8},
+		{"boost is limited by the peak", Loudness{IntegratedLUFS: -24, TruePeakDBTP: -4}, 2.5},
+		{"peak above the ceiling is pulled down", Loudness{IntegratedLUFS: -18, TruePeakDBTP: 0.5}, -2},
+		{"on target", Loudness{IntegratedLUFS: -18, TruePeakDBTP: -6}, 0},
+		{"silence", Loudness{IntegratedLUFS: -70, TruePeakDBTP: -70}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.expected, tt.loudness.GainDB(), 0.001)
+		})
+	}
+}
+
+func TestTranscodeVariantIsHLS(t *testing.T) {
+	t.Parallel()
+	assert.True(t, TranscodeVariantStereo.IsHLS())
+	assert.True(t, TranscodeVariantSpatial.IsHLS())
+	assert.True(t, TranscodeVariantNormalized.IsHLS())
+	assert.False(t, TranscodeVariantLoudness.IsHLS())
+	assert.False(t, TranscodeVariant("bogus").IsHLS())
+}
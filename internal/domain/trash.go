@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// TrashedBook is a deleted book waiting in the trash.
+//
+// The book row itself stays soft-deleted; this record holds what is needed to
+// put the book back where it was (tags, shelves, collections) and whether its
+// files should be removed from disk when the trash is purged.
+type TrashedBook struct {
+	BookID      string     `json:"book_id"`
+	Title       string     `json:"title"`
+	Path        string     `json:"path"`
+	DeletedBy   string     `json:"deleted_by"`
+	DeletedAt   time.Time  `json:"deleted_at"`
+	PurgeAt     *time.Time `json:"purge_at,omitempty"` // nil = kept until purged by hand
+	DeleteFiles bool       `json:"delete_files"`
+
+	// Organisation at the time of deletion, restored on restore.
+	TagIDs        []string           `json:"tag_ids,omitempty"`
+	CollectionIDs []string           `json:"collection_ids,omitempty"`
+	Shelves       []TrashedShelfItem `json:"shelves,omitempty"`
+}
+
+// TrashedShelfItem records a trashed book's position on a shelf.
+type TrashedShelfItem struct {
+	ShelfID   string `json:"shelf_id"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// IsDue reports whether the trashed book should be purged at the given time.
+func (t *TrashedBook) IsDue(now time.Time) bool {
+	return t.PurgeAt != nil && !now.Before(*t.PurgeAt)
+}
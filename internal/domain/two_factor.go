@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// TwoFactor is a user's TOTP enrollment. It exists but is not yet enabled
+// between the user starting enrollment and confirming it with a first code.
+type TwoFactor struct {
+	UserID string `json:"user_id"`
+	Secret string `json:"-"` // Base32 TOTP secret; needed to check codes so it can't be hashed
+	// LastUsedStep is the TOTP time step of the last accepted code.
+	// Codes from that step or earlier are refused, so a code works once.
+	LastUsedStep int64 `json:"-"`
+	// ChallengeID is the current login challenge; earlier ones are revoked.
+	// FailedAttempts counts the wrong codes entered against it.
+	ChallengeID    string     `json:"-"`
+	FailedAttempts int        `json:"-"`
+	ConfirmedAt    *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// IsEnabled returns true once enrollment has been confirmed.
+func (t *TwoFactor) IsEnabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+// RecoveryCode is a one-time code that stands in for a TOTP code when the
+// user has lost their authenticator. Codes are hashed with argon2 like
+// passwords, since they are just as good as one.
+type RecoveryCode struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	CodeHash  string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// IsUsed returns true if the code has been redeemed.
+func (c *RecoveryCode) IsUsed() bool {
+	return c.UsedAt != nil
+}
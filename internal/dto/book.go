@@ -58,4 +58,8 @@ type Book struct {
 	SeriesName string           `json:"series_name,omitempty"` // Primary series name (first in list, for backward compat)
 	Genres     []string         `json:"genres,omitempty"`      // Resolved genre names
 	Tags       []BookTag        `json:"tags,omitempty"`        // Tags applied to this book
+
+	// Community rating across all users' reviews
+	Rating      float64 `json:"rating,omitempty"`       // Average star rating
+	RatingCount int     `json:"rating_count,omitempty"` // Number of reviews
 }
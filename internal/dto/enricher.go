@@ -17,6 +17,7 @@ type Store interface {
 	GetSeriesByIDs(ctx context.Context, ids []string) ([]*domain.Series, error)
 	GetTagsForBook(ctx context.Context, bookID string) ([]*domain.Tag, error)
 	GetTagsForBookIDs(ctx context.Context, bookIDs []string) (map[string][]*domain.Tag, error)
+	GetRatingSummaries(ctx context.Context, bookIDs []string) (map[string]domain.RatingSummary, error)
 }
 
 // Enricher denormalizes domain models for client consumption.
@@ -164,6 +165,14 @@ func (e *Enricher) EnrichBook(ctx context.Context, book *domain.Book) (*Book, er
 		}
 	}
 
+	// Enrich community rating.
+	// Rating lookup failures are non-fatal - just skip the rating.
+	if summaries, err := e.store.GetRatingSummaries(ctx, []string{book.ID}); err == nil {
+		summary := summaries[book.ID]
+		dto.Rating = summary.Average
+		dto.RatingCount = summary.Count
+	}
+
 	return dto, nil
 }
 
@@ -268,6 +277,9 @@ func (e *Enricher) EnrichBooks(ctx context.Context, books []*domain.Book) ([]*Bo
 	}
 	tagsMap, _ := e.store.GetTagsForBookIDs(ctx, bookIDs)
 
+	// Batch fetch community ratings for all books
+	ratingsMap, _ := e.store.GetRatingSummaries(ctx, bookIDs)
+
 	// Enrich each book
 	enrichedBooks := make([]*Book, len(books))
 	for i, book := range books {
@@ -359,6 +371,12 @@ func (e *Enricher) EnrichBooks(ctx context.Context, books []*domain.Book) ([]*Bo
 			}
 		}
 
+		// Enrich community rating (use pre-fetched ratings map)
+		if summary, ok := ratingsMap[book.ID]; ok {
+			dto.Rating = summary.Average
+			dto.RatingCount = summary.Count
+		}
+
 		enrichedBooks[i] = dto
 	}
 
@@ -21,12 +21,14 @@ type fakeStore struct {
 	series       map[string]*domain.Series
 	genres       map[string]*domain.Genre
 	tagsByBook   map[string][]*domain.Tag
+	ratings      map[string]domain.RatingSummary
 
 	// Error injection.
 	contributorsErr error
 	seriesErr       error
 	genresErr       error
 	tagsErr         error
+	ratingsErr      error
 
 	// Call counters.
 	getContributorsByIDsCalls int
@@ -35,6 +37,7 @@ type fakeStore struct {
 	getGenresByIDsCalls       int
 	getTagsForBookCalls       int
 	getTagsForBookIDsCalls    int
+	getRatingSummariesCalls   int
 
 	// Last argument tracking (useful for asserting batched IDs).
 	lastContributorIDs []string
@@ -49,6 +52,7 @@ func newFakeStore() *fakeStore {
 		series:       map[string]*domain.Series{},
 		genres:       map[string]*domain.Genre{},
 		tagsByBook:   map[string][]*domain.Tag{},
+		ratings:      map[string]domain.RatingSummary{},
 	}
 }
 
@@ -131,6 +135,20 @@ func (f *fakeStore) GetTagsForBookIDs(_ context.Context, bookIDs []string) (map[
 	return out, nil
 }
 
+func (f *fakeStore) GetRatingSummaries(_ context.Context, bookIDs []string) (map[string]domain.RatingSummary, error) {
+	f.getRatingSummariesCalls++
+	if f.ratingsErr != nil {
+		return nil, f.ratingsErr
+	}
+	out := make(map[string]domain.RatingSummary, len(bookIDs))
+	for _, id := range bookIDs {
+		if summary, ok := f.ratings[id]; ok {
+			out[id] = summary
+		}
+	}
+	return out, nil
+}
+
 // makeBook is a small helper to build a domain.Book for tests.
 func makeBook(id, title string, contribs []domain.BookContributor, series []domain.BookSeries, genreIDs []string) *domain.Book {
 	return &domain.Book{
@@ -371,6 +389,7 @@ func TestEnrichBooks_HappyPathBatched(t *testing.T) {
 	store.genres["g2"] = &domain.Genre{Syncable: domain.Syncable{ID: "g2"}, Name: "Mystery"}
 	store.tagsByBook["b1"] = []*domain.Tag{{ID: "t1", Slug: "epic"}}
 	store.tagsByBook["b2"] = []*domain.Tag{{ID: "t2", Slug: "noir"}}
+	store.ratings["b1"] = domain.RatingSummary{Average: 4.5, Count: 2}
 
 	books := []*domain.Book{
 		makeBook("b1", "Book 1",
@@ -413,6 +432,9 @@ func TestEnrichBooks_HappyPathBatched(t *testing.T) {
 	assert.Equal(t, []string{"Fantasy"}, got[0].Genres)
 	require.Len(t, got[0].Tags, 1)
 	assert.Equal(t, "epic", got[0].Tags[0].Slug)
+	assert.InDelta(t, 4.5, got[0].Rating, 0.001)
+	assert.Equal(t, 2, got[0].RatingCount)
+	assert.Zero(t, got[1].RatingCount, "unrated book has no rating count")
 
 	assert.Equal(t, "Alice", got[1].Author)
 	assert.Equal(t, "Carol", got[1].Narrator)
@@ -431,6 +453,8 @@ func TestEnrichBooks_HappyPathBatched(t *testing.T) {
 		"genres should be fetched once for the whole batch")
 	assert.Equal(t, 1, store.getTagsForBookIDsCalls,
 		"tags should be fetched once for the whole batch")
+	assert.Equal(t, 1, store.getRatingSummariesCalls,
+		"ratings should be fetched once for the whole batch")
 	assert.Zero(t, store.getTagsForBookCalls,
 		"per-book GetTagsForBook should not be used in batch path")
 	assert.Zero(t, store.getSeriesCalls,
@@ -508,6 +532,7 @@ func TestEnrichBooks_GenreAndTagErrorsAreNonFatal(t *testing.T) {
 	store.contributors["c1"] = &domain.Contributor{Syncable: domain.Syncable{ID: "c1"}, Name: "Alice"}
 	store.genresErr = errors.New("genre lookup failed")
 	store.tagsErr = errors.New("tag lookup failed")
+	store.ratingsErr = errors.New("rating lookup failed")
 
 	books := []*domain.Book{
 		makeBook("b1", "Book 1",
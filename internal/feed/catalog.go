@@ -0,0 +1,103 @@
+// Package feed renders the library for third-party players: OPDS 1.2 (Atom)
+// and OPDS 2.0 (JSON) catalogs, and per-book podcast RSS with Podcasting 2.0
+// chapters.
+//
+// The package only knows about formats. Callers build a format-neutral
+// Catalog or Podcast with absolute URLs filled in, and pick a writer.
+package feed
+
+import "time"
+
+// Media types used in feed links and responses.
+const (
+	MediaTypeAtomNavigation  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	MediaTypeAtomAcquisition = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	MediaTypeOPDS2           = "application/opds+json"
+	MediaTypeOpenSearch      = "application/opensearchdescription+xml"
+	MediaTypeRSS             = "application/rss+xml"
+	MediaTypeChapters        = "application/json+chapters"
+	MediaTypeJPEG            = "image/jpeg"
+)
+
+// Kind says whether a catalog page lists other pages or books.
+type Kind int
+
+// Catalog page kinds.
+const (
+	KindNavigation Kind = iota
+	KindAcquisition
+)
+
+// Catalog is one page of an OPDS catalog.
+type Catalog struct {
+	ID      string
+	Title   string
+	Updated time.Time
+	Kind    Kind
+
+	// Absolute URLs; empty ones are left out.
+	Self     string
+	Start    string
+	Up       string
+	Next     string
+	Previous string
+
+	// OpenSearch description document (OPDS 1.2).
+	SearchDescription string
+	// URI template with a {?query} expression (OPDS 2.0).
+	SearchTemplate string
+
+	// Paging info for acquisition pages.
+	TotalResults int
+	ItemsPerPage int
+
+	Navigation   []NavEntry
+	Publications []Publication
+}
+
+// NavEntry links to another catalog page.
+type NavEntry struct {
+	ID      string
+	Title   string
+	Summary string
+	Href    string
+	Kind    Kind // Kind of the page linked to
+	Count   int  // Number of books behind the link, 0 if unknown
+}
+
+// Publication is a book in an acquisition page.
+type Publication struct {
+	ID          string
+	Title       string
+	Subtitle    string
+	Description string
+	Language    string
+	Publisher   string
+	Published   string // Publish year or date as recorded
+	ISBN        string
+	Updated     time.Time
+	Authors     []string
+	Narrators   []string
+	Series      []SeriesRef
+	Subjects    []string
+	Duration    time.Duration
+
+	CoverHref string
+	FeedHref  string // Podcast RSS for the book
+	Files     []File // Audio files, in playback order
+}
+
+// SeriesRef places a publication in a series.
+type SeriesRef struct {
+	Name     string
+	Position string // As recorded: "1", "1.5", "Book Zero"
+}
+
+// File is a downloadable audio file.
+type File struct {
+	Href     string
+	Type     string
+	Title    string
+	Size     int64
+	Duration time.Duration
+}
@@ -0,0 +1,44 @@
+package feed
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"io"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+// podcastChapters is the Podcasting 2.0 JSON chapters format.
+// See https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/examples/chapters/jsonChapters.md
+type podcastChapters struct {
+	Version  string           `json:"version"`
+	Chapters []podcastChapter `json:"chapters"`
+}
+
+type podcastChapter struct {
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime,omitzero"`
+	Title     string  `json:"title,omitempty"`
+}
+
+// WriteChapters writes chapters as Podcasting 2.0 JSON. Chapter times must
+// already be relative to the start of the episode's file; see
+// domain.Book.ChaptersForFile.
+func WriteChapters(w io.Writer, chapters []domain.Chapter) error {
+	doc := podcastChapters{
+		Version:  "1.2.0",
+		Chapters: make([]podcastChapter, len(chapters)),
+	}
+	for i, c := range chapters {
+		doc.Chapters[i] = podcastChapter{
+			StartTime: float64(c.StartTime) / 1000,
+			EndTime:   float64(c.EndTime) / 1000,
+			Title:     c.Title,
+		}
+	}
+
+	if err := json.MarshalWrite(w, doc); err != nil {
+		return fmt.Errorf("encode chapters: %w", err)
+	}
+	return nil
+}
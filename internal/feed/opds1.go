@@ -0,0 +1,253 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// OPDS 1.2 link relations.
+const (
+	relAcquisition = "http://opds-spec.org/acquisition"
+	relImage       = "http://opds-spec.org/image"
+	relThumbnail   = "http://opds-spec.org/image/thumbnail"
+	relSubsection  = "subsection"
+)
+
+type atomFeed struct {
+	XMLName      xml.Name    `xml:"feed"`
+	Xmlns        string      `xml:"xmlns,attr"`
+	XmlnsDC      string      `xml:"xmlns:dc,attr"`
+	XmlnsOPDS    string      `xml:"xmlns:opds,attr"`
+	XmlnsOS      string      `xml:"xmlns:opensearch,attr"`
+	ID           string      `xml:"id"`
+	Title        string      `xml:"title"`
+	Updated      string      `xml:"updated"`
+	Author       atomPerson  `xml:"author"`
+	TotalResults int         `xml:"opensearch:totalResults,omitempty"`
+	ItemsPerPage int         `xml:"opensearch:itemsPerPage,omitempty"`
+	Links        []atomLink  `xml:"link"`
+	Entries      []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Title  string `xml:"title,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+type atomEntry struct {
+	Title        string         `xml:"title"`
+	ID           string         `xml:"id"`
+	Updated      string         `xml:"updated"`
+	Authors      []atomPerson   `xml:"author"`
+	Contributors []atomPerson   `xml:"contributor"`
+	Identifier   string         `xml:"dc:identifier,omitempty"`
+	Language     string         `xml:"dc:language,omitempty"`
+	Publisher    string         `xml:"dc:publisher,omitempty"`
+	Issued       string         `xml:"dc:issued,omitempty"`
+	Categories   []atomCategory `xml:"category"`
+	Summary      *atomText      `xml:"summary,omitempty"`
+	Content      *atomText      `xml:"content,omitempty"`
+	Links        []atomLink     `xml:"link"`
+}
+
+// WriteAtom writes c as an OPDS 1.2 Atom feed.
+func WriteAtom(w io.Writer, c *Catalog) error {
+	f := atomFeed{
+		Xmlns:        "http://www.w3.org/2005/Atom",
+		XmlnsDC:      "http://purl.org/dc/terms/",
+		XmlnsOPDS:    "http://opds-spec.org/2010/catalog",
+		XmlnsOS:      "http://a9.com/-/spec/opensearch/1.1/",
+		ID:           c.ID,
+		Title:        c.Title,
+		Updated:      atomTime(c.Updated),
+		Author:       atomPerson{Name: "ListenUp"},
+		TotalResults: c.TotalResults,
+		ItemsPerPage: c.ItemsPerPage,
+	}
+
+	self := MediaTypeAtomNavigation
+	if c.Kind == KindAcquisition {
+		self = MediaTypeAtomAcquisition
+	}
+	f.Links = appendAtomLink(f.Links, "self", c.Self, self)
+	f.Links = appendAtomLink(f.Links, "start", c.Start, MediaTypeAtomNavigation)
+	f.Links = appendAtomLink(f.Links, "up", c.Up, MediaTypeAtomNavigation)
+	f.Links = appendAtomLink(f.Links, "next", c.Next, self)
+	f.Links = appendAtomLink(f.Links, "previous", c.Previous, self)
+	f.Links = appendAtomLink(f.Links, "search", c.SearchDescription, MediaTypeOpenSearch)
+
+	for _, n := range c.Navigation {
+		linkType := MediaTypeAtomNavigation
+		if n.Kind == KindAcquisition {
+			linkType = MediaTypeAtomAcquisition
+		}
+		entry := atomEntry{
+			Title:   n.Title,
+			ID:      n.ID,
+			Updated: f.Updated,
+			Links:   []atomLink{{Rel: relSubsection, Href: n.Href, Type: linkType}},
+		}
+		summary := n.Summary
+		if summary == "" && n.Count > 0 {
+			summary = countLabel(n.Count)
+		}
+		if summary != "" {
+			entry.Content = &atomText{Type: "text", Text: summary}
+		}
+		f.Entries = append(f.Entries, entry)
+	}
+
+	for i := range c.Publications {
+		f.Entries = append(f.Entries, atomPublication(&c.Publications[i]))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return fmt.Errorf("encode atom feed: %w", err)
+	}
+	return enc.Close()
+}
+
+func atomPublication(p *Publication) atomEntry {
+	title := p.Title
+	if p.Subtitle != "" {
+		title += ": " + p.Subtitle
+	}
+
+	entry := atomEntry{
+		Title:     title,
+		ID:        p.ID,
+		Updated:   atomTime(p.Updated),
+		Language:  p.Language,
+		Publisher: p.Publisher,
+		Issued:    p.Published,
+	}
+	if p.ISBN != "" {
+		entry.Identifier = "urn:isbn:" + p.ISBN
+	}
+	for _, name := range p.Authors {
+		entry.Authors = append(entry.Authors, atomPerson{Name: name})
+	}
+	for _, name := range p.Narrators {
+		entry.Contributors = append(entry.Contributors, atomPerson{Name: name})
+	}
+	for _, subject := range p.Subjects {
+		entry.Categories = append(entry.Categories, atomCategory{Term: subject, Label: subject})
+	}
+
+	if p.Description != "" {
+		entry.Summary = &atomText{Type: "text", Text: p.Description}
+	}
+	if len(p.Series) > 0 {
+		entry.Content = &atomText{Type: "text", Text: seriesLine(p.Series)}
+	}
+
+	if p.CoverHref != "" {
+		entry.Links = append(entry.Links,
+			atomLink{Rel: relImage, Href: p.CoverHref, Type: MediaTypeJPEG},
+			atomLink{Rel: relThumbnail, Href: p.CoverHref, Type: MediaTypeJPEG},
+		)
+	}
+	if p.FeedHref != "" {
+		entry.Links = append(entry.Links, atomLink{Rel: "alternate", Href: p.FeedHref, Type: MediaTypeRSS, Title: "Podcast feed"})
+	}
+	for _, file := range p.Files {
+		entry.Links = append(entry.Links, atomLink{
+			Rel:    relAcquisition,
+			Href:   file.Href,
+			Type:   file.Type,
+			Title:  file.Title,
+			Length: file.Size,
+		})
+	}
+
+	return entry
+}
+
+// WriteOpenSearch writes an OpenSearch description pointing at an OPDS 1.2
+// search page. template must contain {searchTerms}.
+func WriteOpenSearch(w io.Writer, shortName, template string) error {
+	type osURL struct {
+		Type     string `xml:"type,attr"`
+		Template string `xml:"template,attr"`
+	}
+	doc := struct {
+		XMLName     xml.Name `xml:"OpenSearchDescription"`
+		Xmlns       string   `xml:"xmlns,attr"`
+		ShortName   string   `xml:"ShortName"`
+		Description string   `xml:"Description"`
+		URL         osURL    `xml:"Url"`
+	}{
+		Xmlns:       "http://a9.com/-/spec/opensearch/1.1/",
+		ShortName:   shortName,
+		Description: "Search the audiobook library",
+		URL:         osURL{Type: MediaTypeAtomAcquisition, Template: template},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode opensearch description: %w", err)
+	}
+	return enc.Close()
+}
+
+func appendAtomLink(links []atomLink, rel, href, linkType string) []atomLink {
+	if href == "" {
+		return links
+	}
+	return append(links, atomLink{Rel: rel, Href: href, Type: linkType})
+}
+
+func atomTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func countLabel(n int) string {
+	if n == 1 {
+		return "1 book"
+	}
+	return fmt.Sprintf("%d books", n)
+}
+
+// seriesLine renders series membership as "Mistborn #1, The Cosmere".
+func seriesLine(series []SeriesRef) string {
+	parts := make([]string, len(series))
+	for i, s := range series {
+		parts[i] = s.Name
+		if s.Position != "" {
+			parts[i] += " #" + s.Position
+		}
+	}
+	return strings.Join(parts, ", ")
+}
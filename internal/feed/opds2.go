@@ -0,0 +1,173 @@
+package feed
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type opds2Feed struct {
+	Metadata     opds2FeedMetadata  `json:"metadata"`
+	Links        []opds2Link        `json:"links"`
+	Navigation   []opds2Link        `json:"navigation,omitempty"`
+	Publications []opds2Publication `json:"publications,omitempty"`
+}
+
+type opds2FeedMetadata struct {
+	Title         string `json:"title"`
+	Modified      string `json:"modified,omitempty"`
+	NumberOfItems int    `json:"numberOfItems,omitzero"`
+	ItemsPerPage  int    `json:"itemsPerPage,omitzero"`
+}
+
+type opds2Link struct {
+	Href       string           `json:"href"`
+	Type       string           `json:"type,omitempty"`
+	Rel        string           `json:"rel,omitempty"`
+	Title      string           `json:"title,omitempty"`
+	Templated  bool             `json:"templated,omitzero"`
+	Duration   float64          `json:"duration,omitzero"` // Seconds
+	Properties *opds2Properties `json:"properties,omitempty"`
+}
+
+type opds2Properties struct {
+	NumberOfItems int `json:"numberOfItems,omitzero"`
+}
+
+type opds2Publication struct {
+	Metadata opds2PublicationMetadata `json:"metadata"`
+	Links    []opds2Link              `json:"links"`
+	Images   []opds2Link              `json:"images,omitempty"`
+}
+
+type opds2PublicationMetadata struct {
+	Type        string          `json:"@type"`
+	Identifier  string          `json:"identifier,omitempty"`
+	Title       string          `json:"title"`
+	Subtitle    string          `json:"subtitle,omitempty"`
+	Author      []opds2Named    `json:"author,omitempty"`
+	Narrator    []opds2Named    `json:"narrator,omitempty"`
+	Publisher   string          `json:"publisher,omitempty"`
+	Language    string          `json:"language,omitempty"`
+	Published   string          `json:"published,omitempty"`
+	Modified    string          `json:"modified,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Duration    float64         `json:"duration,omitzero"` // Seconds
+	Subject     []opds2Named    `json:"subject,omitempty"`
+	BelongsTo   *opds2BelongsTo `json:"belongsTo,omitempty"`
+}
+
+type opds2Named struct {
+	Name string `json:"name"`
+}
+
+type opds2BelongsTo struct {
+	Series []opds2Series `json:"series"`
+}
+
+type opds2Series struct {
+	Name     string  `json:"name"`
+	Position float64 `json:"position,omitzero"`
+}
+
+// WriteOPDS2 writes c as an OPDS 2.0 JSON feed.
+func WriteOPDS2(w io.Writer, c *Catalog) error {
+	f := opds2Feed{
+		Metadata: opds2FeedMetadata{
+			Title:         c.Title,
+			Modified:      atomTime(c.Updated),
+			NumberOfItems: c.TotalResults,
+			ItemsPerPage:  c.ItemsPerPage,
+		},
+		Links: []opds2Link{},
+	}
+
+	f.Links = appendOPDS2Link(f.Links, "self", c.Self)
+	f.Links = appendOPDS2Link(f.Links, "start", c.Start)
+	f.Links = appendOPDS2Link(f.Links, "up", c.Up)
+	f.Links = appendOPDS2Link(f.Links, "next", c.Next)
+	f.Links = appendOPDS2Link(f.Links, "previous", c.Previous)
+	if c.SearchTemplate != "" {
+		f.Links = append(f.Links, opds2Link{Rel: "search", Href: c.SearchTemplate, Type: MediaTypeOPDS2, Templated: true})
+	}
+
+	for _, n := range c.Navigation {
+		link := opds2Link{Href: n.Href, Type: MediaTypeOPDS2, Title: n.Title}
+		if n.Count > 0 {
+			link.Properties = &opds2Properties{NumberOfItems: n.Count}
+		}
+		f.Navigation = append(f.Navigation, link)
+	}
+
+	for i := range c.Publications {
+		f.Publications = append(f.Publications, opds2Pub(&c.Publications[i]))
+	}
+
+	if err := json.MarshalWrite(w, f); err != nil {
+		return fmt.Errorf("encode opds2 feed: %w", err)
+	}
+	return nil
+}
+
+func opds2Pub(p *Publication) opds2Publication {
+	md := opds2PublicationMetadata{
+		Type:        "http://schema.org/Audiobook",
+		Identifier:  p.ID,
+		Title:       p.Title,
+		Subtitle:    p.Subtitle,
+		Publisher:   p.Publisher,
+		Language:    p.Language,
+		Published:   p.Published,
+		Description: p.Description,
+		Duration:    p.Duration.Seconds(),
+	}
+	if !p.Updated.IsZero() {
+		md.Modified = atomTime(p.Updated)
+	}
+	if p.ISBN != "" {
+		md.Identifier = "urn:isbn:" + p.ISBN
+	}
+	for _, name := range p.Authors {
+		md.Author = append(md.Author, opds2Named{Name: name})
+	}
+	for _, name := range p.Narrators {
+		md.Narrator = append(md.Narrator, opds2Named{Name: name})
+	}
+	for _, subject := range p.Subjects {
+		md.Subject = append(md.Subject, opds2Named{Name: subject})
+	}
+	if len(p.Series) > 0 {
+		md.BelongsTo = &opds2BelongsTo{}
+		for _, s := range p.Series {
+			// Positions like "Book Zero" have no numeric form; leave them out.
+			pos, _ := strconv.ParseFloat(s.Position, 64)
+			md.BelongsTo.Series = append(md.BelongsTo.Series, opds2Series{Name: s.Name, Position: pos})
+		}
+	}
+
+	pub := opds2Publication{Metadata: md, Links: []opds2Link{}}
+	for _, file := range p.Files {
+		pub.Links = append(pub.Links, opds2Link{
+			Rel:      relAcquisition,
+			Href:     file.Href,
+			Type:     file.Type,
+			Title:    file.Title,
+			Duration: file.Duration.Seconds(),
+		})
+	}
+	if p.FeedHref != "" {
+		pub.Links = append(pub.Links, opds2Link{Rel: "alternate", Href: p.FeedHref, Type: MediaTypeRSS, Title: "Podcast feed"})
+	}
+	if p.CoverHref != "" {
+		pub.Images = []opds2Link{{Href: p.CoverHref, Type: MediaTypeJPEG}}
+	}
+	return pub
+}
+
+func appendOPDS2Link(links []opds2Link, rel, href string) []opds2Link {
+	if href == "" {
+		return links
+	}
+	return append(links, opds2Link{Rel: rel, Href: href, Type: MediaTypeOPDS2})
+}
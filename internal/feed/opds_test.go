@@ -0,0 +1,167 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCatalog() *Catalog {
+	return &Catalog{
+		ID:                "urn:listenup:recent",
+		Title:             "Recently Added",
+		Updated:           time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Kind:              KindAcquisition,
+		Self:              "https://example.com/feeds/t/opds/recent",
+		Start:             "https://example.com/feeds/t/opds",
+		Next:              "https://example.com/feeds/t/opds/recent?page=2",
+		SearchDescription: "https://example.com/feeds/t/opds/opensearch.xml",
+		SearchTemplate:    "https://example.com/feeds/t/opds2/search{?query}",
+		TotalResults:      51,
+		ItemsPerPage:      50,
+		Publications: []Publication{{
+			ID:        "urn:listenup:book:b1",
+			Title:     "The Way of Kings",
+			Language:  "en",
+			ISBN:      "9780765326355",
+			Authors:   []string{"Brandon Sanderson"},
+			Narrators: []string{"Michael Kramer", "Kate Reading"},
+			Series:    []SeriesRef{{Name: "The Stormlight Archive", Position: "1"}},
+			Subjects:  []string{"Fantasy"},
+			Duration:  45 * time.Hour,
+			CoverHref: "https://example.com/feeds/t/covers/b1",
+			FeedHref:  "https://example.com/feeds/t/books/b1/podcast.xml",
+			Files: []File{
+				{Href: "https://example.com/feeds/t/audio/b1/f1", Type: "audio/mp4", Title: "part1.m4b", Size: 1000, Duration: 20 * time.Hour},
+				{Href: "https://example.com/feeds/t/audio/b1/f2", Type: "audio/mp4", Title: "part2.m4b", Size: 2000, Duration: 25 * time.Hour},
+			},
+		}},
+	}
+}
+
+func TestWriteAtom_Acquisition(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteAtom(&buf, testCatalog()))
+	out := buf.String()
+
+	// Must be well-formed XML.
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), new(struct{})))
+
+	assert.True(t, strings.HasPrefix(out, "<?xml"))
+	assert.Contains(t, out, `<link rel="self" href="https://example.com/feeds/t/opds/recent" type="`+MediaTypeAtomAcquisition+`"></link>`)
+	assert.Contains(t, out, `<link rel="search" href="https://example.com/feeds/t/opds/opensearch.xml" type="application/opensearchdescription+xml"></link>`)
+	assert.Contains(t, out, `<opensearch:totalResults>51</opensearch:totalResults>`)
+	assert.Contains(t, out, `<dc:identifier>urn:isbn:9780765326355</dc:identifier>`)
+	assert.Contains(t, out, `<contributor>`)
+	assert.Contains(t, out, `<content type="text">The Stormlight Archive #1</content>`)
+	assert.Equal(t, 2, strings.Count(out, `rel="http://opds-spec.org/acquisition"`))
+	assert.Contains(t, out, `length="2000"`)
+	assert.Contains(t, out, `rel="alternate" href="https://example.com/feeds/t/books/b1/podcast.xml" type="application/rss+xml"`)
+	assert.NotContains(t, out, `rel="previous"`)
+}
+
+func TestWriteAtom_Navigation(t *testing.T) {
+	t.Parallel()
+
+	c := &Catalog{
+		ID:    "urn:listenup:root",
+		Title: "ListenUp",
+		Self:  "https://example.com/feeds/t/opds",
+		Navigation: []NavEntry{
+			{ID: "urn:listenup:series", Title: "Series", Href: "https://example.com/feeds/t/opds/series"},
+			{ID: "urn:listenup:series:s1", Title: "Mistborn", Href: "https://example.com/feeds/t/opds/series/s1", Kind: KindAcquisition, Count: 3},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteAtom(&buf, c))
+	out := buf.String()
+
+	assert.Contains(t, out, `rel="subsection" href="https://example.com/feeds/t/opds/series" type="`+MediaTypeAtomNavigation+`"`)
+	assert.Contains(t, out, `rel="subsection" href="https://example.com/feeds/t/opds/series/s1" type="`+MediaTypeAtomAcquisition+`"`)
+	assert.Contains(t, out, `<content type="text">3 books</content>`)
+}
+
+func TestWriteOPDS2(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteOPDS2(&buf, testCatalog()))
+
+	var got struct {
+		Metadata struct {
+			Title         string `json:"title"`
+			NumberOfItems int    `json:"numberOfItems"`
+		} `json:"metadata"`
+		Links []struct {
+			Rel       string `json:"rel"`
+			Href      string `json:"href"`
+			Templated bool   `json:"templated"`
+		} `json:"links"`
+		Publications []struct {
+			Metadata struct {
+				Type       string `json:"@type"`
+				Identifier string `json:"identifier"`
+				Narrator   []struct {
+					Name string `json:"name"`
+				} `json:"narrator"`
+				Duration  float64 `json:"duration"`
+				BelongsTo struct {
+					Series []struct {
+						Name     string  `json:"name"`
+						Position float64 `json:"position"`
+					} `json:"series"`
+				} `json:"belongsTo"`
+			} `json:"metadata"`
+			Links []struct {
+				Rel  string `json:"rel"`
+				Type string `json:"type"`
+			} `json:"links"`
+			Images []struct {
+				Href string `json:"href"`
+			} `json:"images"`
+		} `json:"publications"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	assert.Equal(t, "Recently Added", got.Metadata.Title)
+	assert.Equal(t, 51, got.Metadata.NumberOfItems)
+
+	var search bool
+	for _, l := range got.Links {
+		if l.Rel == "search" {
+			search = true
+			assert.True(t, l.Templated)
+		}
+	}
+	assert.True(t, search, "search link missing")
+
+	require.Len(t, got.Publications, 1)
+	pub := got.Publications[0]
+	assert.Equal(t, "http://schema.org/Audiobook", pub.Metadata.Type)
+	assert.Equal(t, "urn:isbn:9780765326355", pub.Metadata.Identifier)
+	assert.Len(t, pub.Metadata.Narrator, 2)
+	assert.InDelta(t, 45*3600, pub.Metadata.Duration, 0.001)
+	require.Len(t, pub.Metadata.BelongsTo.Series, 1)
+	assert.InDelta(t, 1, pub.Metadata.BelongsTo.Series[0].Position, 0.001)
+	require.Len(t, pub.Links, 3)
+	assert.Equal(t, relAcquisition, pub.Links[0].Rel)
+	assert.Equal(t, "alternate", pub.Links[2].Rel)
+	require.Len(t, pub.Images, 1)
+}
+
+func TestWriteOpenSearch(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteOpenSearch(&buf, "ListenUp", "https://example.com/feeds/t/opds/search?q={searchTerms}"))
+	assert.Contains(t, buf.String(), `template="https://example.com/feeds/t/opds/search?q={searchTerms}"`)
+}
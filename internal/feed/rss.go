@@ -0,0 +1,183 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Podcast is a book presented as a podcast, one episode per audio file.
+type Podcast struct {
+	Title       string
+	Author      string
+	Description string
+	Language    string
+	Link        string // Web page for the book, if any
+	FeedURL     string // This feed's own URL
+	ImageURL    string
+	Updated     time.Time
+	Episodes    []Episode
+}
+
+// Episode is one audio file of a book.
+type Episode struct {
+	GUID        string
+	Title       string
+	Description string
+	Published   time.Time
+	Number      int
+	URL         string
+	Type        string
+	Length      int64
+	Duration    time.Duration
+	ChaptersURL string // Podcasting 2.0 chapters JSON, if the file has chapters
+}
+
+type rssDoc struct {
+	XMLName      xml.Name   `xml:"rss"`
+	Version      string     `xml:"version,attr"`
+	XmlnsItunes  string     `xml:"xmlns:itunes,attr"`
+	XmlnsPodcast string     `xml:"xmlns:podcast,attr"`
+	XmlnsAtom    string     `xml:"xmlns:atom,attr"`
+	Channel      rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Description   string       `xml:"description"`
+	Language      string       `xml:"language,omitempty"`
+	LastBuildDate string       `xml:"lastBuildDate"`
+	AtomLink      *rssAtomLink `xml:"atom:link,omitempty"`
+	Image         *rssImage    `xml:"image,omitempty"`
+	ItunesAuthor  string       `xml:"itunes:author,omitempty"`
+	ItunesType    string       `xml:"itunes:type"`
+	ItunesImage   *rssHref     `xml:"itunes:image,omitempty"`
+	ItunesBlock   string       `xml:"itunes:block"`
+	Items         []rssItem    `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type rssHref struct {
+	Href string `xml:"href,attr"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type rssChapters struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title             string       `xml:"title"`
+	GUID              rssGUID      `xml:"guid"`
+	PubDate           string       `xml:"pubDate"`
+	Description       string       `xml:"description,omitempty"`
+	Enclosure         rssEnclosure `xml:"enclosure"`
+	ItunesDuration    int64        `xml:"itunes:duration,omitempty"`
+	ItunesEpisode     int          `xml:"itunes:episode,omitempty"`
+	ItunesEpisodeType string       `xml:"itunes:episodeType"`
+	Chapters          *rssChapters `xml:"podcast:chapters,omitempty"`
+}
+
+// WriteRSS writes p as a podcast RSS 2.0 feed with iTunes and Podcasting 2.0
+// tags. The feed is marked itunes:block since it carries a personal token and
+// must never end up in a public directory.
+func WriteRSS(w io.Writer, p *Podcast) error {
+	ch := rssChannel{
+		Title:         p.Title,
+		Link:          p.Link,
+		Description:   p.Description,
+		Language:      p.Language,
+		LastBuildDate: rssTime(p.Updated),
+		ItunesAuthor:  p.Author,
+		ItunesType:    "serial",
+		ItunesBlock:   "Yes",
+	}
+	if ch.Link == "" {
+		ch.Link = p.FeedURL
+	}
+	if ch.Description == "" {
+		ch.Description = p.Title
+	}
+	if p.FeedURL != "" {
+		ch.AtomLink = &rssAtomLink{Href: p.FeedURL, Rel: "self", Type: MediaTypeRSS}
+	}
+	if p.ImageURL != "" {
+		ch.Image = &rssImage{URL: p.ImageURL, Title: p.Title, Link: ch.Link}
+		ch.ItunesImage = &rssHref{Href: p.ImageURL}
+	}
+
+	for _, ep := range p.Episodes {
+		item := rssItem{
+			Title:             ep.Title,
+			GUID:              rssGUID{IsPermaLink: "false", Value: ep.GUID},
+			PubDate:           rssTime(ep.Published),
+			Description:       ep.Description,
+			Enclosure:         rssEnclosure{URL: ep.URL, Length: ep.Length, Type: ep.Type},
+			ItunesDuration:    int64(ep.Duration.Seconds()),
+			ItunesEpisode:     ep.Number,
+			ItunesEpisodeType: "full",
+		}
+		if ep.ChaptersURL != "" {
+			item.Chapters = &rssChapters{URL: ep.ChaptersURL, Type: MediaTypeChapters}
+		}
+		ch.Items = append(ch.Items, item)
+	}
+
+	doc := rssDoc{
+		Version:      "2.0",
+		XmlnsItunes:  "http://www.itunes.com/dtds/podcast-1.0.dtd",
+		XmlnsPodcast: "https://podcastindex.org/namespace/1.0",
+		XmlnsAtom:    "http://www.w3.org/2005/Atom",
+		Channel:      ch,
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode rss feed: %w", err)
+	}
+	return enc.Close()
+}
+
+func rssTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format(time.RFC1123Z)
+}
+
+// EpisodeTitle names an episode after its position, e.g. "Part 3 of 12".
+func EpisodeTitle(number, total int) string {
+	if total <= 1 {
+		return "Full book"
+	}
+	return "Part " + strconv.Itoa(number) + " of " + strconv.Itoa(total)
+}
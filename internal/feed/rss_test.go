@@ -0,0 +1,99 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+func TestWriteRSS(t *testing.T) {
+	t.Parallel()
+
+	added := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	p := &Podcast{
+		Title:    "Mistborn",
+		Author:   "Brandon Sanderson",
+		FeedURL:  "https://example.com/feeds/t/books/b1/podcast.xml",
+		ImageURL: "https://example.com/feeds/t/covers/b1",
+		Updated:  added,
+		Episodes: []Episode{
+			{
+				GUID:        "b1:f1",
+				Title:       EpisodeTitle(1, 2),
+				Published:   added,
+				Number:      1,
+				URL:         "https://example.com/feeds/t/audio/b1/f1",
+				Type:        "audio/mpeg",
+				Length:      1234,
+				Duration:    90 * time.Minute,
+				ChaptersURL: "https://example.com/feeds/t/books/b1/files/f1/chapters.json",
+			},
+			{
+				GUID:      "b1:f2",
+				Title:     EpisodeTitle(2, 2),
+				Published: added.Add(time.Minute),
+				Number:    2,
+				URL:       "https://example.com/feeds/t/audio/b1/f2",
+				Type:      "audio/mpeg",
+				Length:    5678,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteRSS(&buf, p))
+	out := buf.String()
+
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), new(struct{})))
+
+	assert.Contains(t, out, `xmlns:podcast="https://podcastindex.org/namespace/1.0"`)
+	assert.Contains(t, out, `<itunes:type>serial</itunes:type>`)
+	assert.Contains(t, out, `<itunes:block>Yes</itunes:block>`)
+	assert.Contains(t, out, `<description>Mistborn</description>`, "description falls back to the title")
+	assert.Contains(t, out, `<title>Part 1 of 2</title>`)
+	assert.Contains(t, out, `<guid isPermaLink="false">b1:f1</guid>`)
+	assert.Contains(t, out, `<enclosure url="https://example.com/feeds/t/audio/b1/f1" length="1234" type="audio/mpeg"></enclosure>`)
+	assert.Contains(t, out, `<itunes:duration>5400</itunes:duration>`)
+	assert.Contains(t, out, `<podcast:chapters url="https://example.com/feeds/t/books/b1/files/f1/chapters.json" type="application/json+chapters"></podcast:chapters>`)
+	assert.Contains(t, out, `<pubDate>Thu, 01 Oct 2026 12:01:00 +0000</pubDate>`)
+}
+
+func TestEpisodeTitle(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Full book", EpisodeTitle(1, 1))
+	assert.Equal(t, "Part 3 of 12", EpisodeTitle(3, 12))
+}
+
+func TestWriteChapters(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteChapters(&buf, []domain.Chapter{
+		{Title: "Prologue", StartTime: 0, EndTime: 61_500},
+		{Title: "Chapter 1", StartTime: 61_500, EndTime: 600_000},
+	}))
+
+	var got struct {
+		Version  string `json:"version"`
+		Chapters []struct {
+			StartTime float64 `json:"startTime"`
+			EndTime   float64 `json:"endTime"`
+			Title     string  `json:"title"`
+		} `json:"chapters"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	assert.Equal(t, "1.2.0", got.Version)
+	require.Len(t, got.Chapters, 2)
+	assert.InDelta(t, 61.5, got.Chapters[1].StartTime, 0.001)
+	assert.InDelta(t, 600, got.Chapters[1].EndTime, 0.001)
+	assert.Equal(t, "Prologue", got.Chapters[0].Title)
+}
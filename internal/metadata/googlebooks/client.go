@@ -0,0 +1,229 @@
+// Package googlebooks provides a metadata provider backed by the Google Books API.
+package googlebooks
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+const (
+	defaultBaseURL = "https://www.googleapis.com/books/v1"
+	defaultTimeout = 30 * time.Second
+
+	defaultLimit = 20
+	maxLimit     = 40 // API maximum for maxResults
+)
+
+// Client is a rate-limited Google Books API client.
+type Client struct {
+	http    *http.Client
+	baseURL string
+	apiKey  string
+	limiter *rate.Limiter
+	logger  *slog.Logger
+}
+
+var _ metadata.Provider = (*Client)(nil)
+
+// New creates a new Google Books client. The API key is optional; without
+// one, requests share Google's small anonymous quota.
+func New(apiKey string, logger *slog.Logger) *Client {
+	return &Client{
+		http:    &http.Client{Timeout: defaultTimeout},
+		baseURL: defaultBaseURL,
+		apiKey:  apiKey,
+		limiter: rate.NewLimiter(rate.Every(500*time.Millisecond), 5),
+		logger:  logger,
+	}
+}
+
+// Name implements metadata.Provider.
+func (c *Client) Name() string {
+	return metadata.ProviderGoogleBooks
+}
+
+// Search implements metadata.Provider. Results are keyed by volume ID.
+func (c *Client) Search(ctx context.Context, q metadata.SearchQuery) ([]metadata.SearchResult, error) {
+	params := url.Values{}
+	params.Set("q", searchTerms(q))
+	params.Set("printType", "books")
+	params.Set("maxResults", strconv.Itoa(clampLimit(q.Limit)))
+
+	var resp volumesResponse
+	if err := c.get(ctx, "/volumes", params, &resp); err != nil {
+		return nil, fmt.Errorf("googlebooks search: %w", err)
+	}
+
+	results := make([]metadata.SearchResult, 0, len(resp.Items))
+	for i := range resp.Items {
+		v := &resp.Items[i]
+		results = append(results, metadata.SearchResult{
+			Provider:    metadata.ProviderGoogleBooks,
+			ID:          v.ID,
+			Title:       v.Info.Title,
+			Subtitle:    v.Info.Subtitle,
+			Authors:     contributors(v.Info.Authors),
+			CoverURL:    v.Info.ImageLinks.best(),
+			PublishYear: publishYear(v.Info.PublishedDate),
+		})
+	}
+	return results, nil
+}
+
+// GetBook implements metadata.Provider. The ID is a volume ID.
+func (c *Client) GetBook(ctx context.Context, id string) (*metadata.Book, error) {
+	if id == "" || strings.ContainsAny(id, "/?#") {
+		return nil, fmt.Errorf("googlebooks get book %s: %w", id, metadata.ErrNotFound)
+	}
+
+	var v volume
+	if err := c.get(ctx, "/volumes/"+id, nil, &v); err != nil {
+		return nil, fmt.Errorf("googlebooks get book %s: %w", id, err)
+	}
+
+	book := &metadata.Book{
+		Provider:    metadata.ProviderGoogleBooks,
+		ID:          v.ID,
+		Title:       v.Info.Title,
+		Subtitle:    v.Info.Subtitle,
+		Authors:     contributors(v.Info.Authors),
+		Publisher:   v.Info.Publisher,
+		PublishYear: publishYear(v.Info.PublishedDate),
+		Description: metadata.StripHTML(v.Info.Description),
+		CoverURL:    v.Info.ImageLinks.best(),
+		Genres:      genres(v.Info.Categories),
+		Language:    v.Info.Language,
+		ISBN:        v.Info.isbn(),
+	}
+	if t, err := time.Parse("2006-01-02", v.Info.PublishedDate); err == nil {
+		book.ReleaseDate = t
+	}
+	return book, nil
+}
+
+// GetChapters implements metadata.Provider. Google Books has no chapter data.
+func (c *Client) GetChapters(context.Context, string) ([]metadata.Chapter, error) {
+	return nil, metadata.ErrNotSupported
+}
+
+// GetContributor implements metadata.Provider. Google Books only knows
+// contributors by name.
+func (c *Client) GetContributor(context.Context, string) (*metadata.ContributorProfile, error) {
+	return nil, metadata.ErrNotSupported
+}
+
+// get fetches a JSON document from the API.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if c.apiKey != "" {
+		query.Set("key", c.apiKey)
+	}
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	c.logger.Debug("google books request", "path", path)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	// Unknown volume IDs come back as 503 rather than 404.
+	case resp.StatusCode == http.StatusNotFound,
+		resp.StatusCode == http.StatusServiceUnavailable && strings.HasPrefix(path, "/volumes/"):
+		return metadata.ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if err := json.UnmarshalRead(resp.Body, v); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
+
+// searchTerms builds a query with Google's field prefixes.
+func searchTerms(q metadata.SearchQuery) string {
+	var terms []string
+	if q.Keywords != "" {
+		terms = append(terms, q.Keywords)
+	}
+	if q.Title != "" {
+		terms = append(terms, "intitle:"+q.Title)
+	}
+	if q.Author != "" {
+		terms = append(terms, "inauthor:"+q.Author)
+	}
+	if q.ISBN != "" {
+		terms = append(terms, "isbn:"+q.ISBN)
+	}
+	return strings.Join(terms, " ")
+}
+
+func contributors(names []string) []metadata.Contributor {
+	var out []metadata.Contributor
+	for _, name := range names {
+		out = append(out, metadata.Contributor{Name: name})
+	}
+	return out
+}
+
+// genres splits categories like "Fiction / Science Fiction / General" into
+// their distinct parts.
+func genres(categories []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, category := range categories {
+		for part := range strings.SplitSeq(category, "/") {
+			part = strings.TrimSpace(part)
+			if part == "" || part == "General" || seen[part] {
+				continue
+			}
+			seen[part] = true
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
+
+func publishYear(date string) string {
+	if len(date) >= 4 {
+		if _, err := strconv.Atoi(date[:4]); err == nil {
+			return date[:4]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package googlebooks
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+func newTestClient(t *testing.T, apiKey string, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New(apiKey, slog.New(slog.DiscardHandler))
+	client.http = server.Client()
+	client.baseURL = server.URL
+	return client
+}
+
+const volumeJSON = `{
+	"id": "B1hSG45JCX4C",
+	"volumeInfo": {
+		"title": "Dune",
+		"authors": ["Frank Herbert"],
+		"publisher": "Penguin",
+		"publishedDate": "2003-08-26",
+		"description": "<p>Set on the desert planet <b>Arrakis</b>&hellip;</p>",
+		"industryIdentifiers": [
+			{"type": "ISBN_10", "identifier": "0441013597"},
+			{"type": "ISBN_13", "identifier": "9780441013593"}
+		],
+		"categories": ["Fiction / Science Fiction / General"],
+		"language": "en",
+		"imageLinks": {"thumbnail": "http://books.google.com/books/content?id=B1hSG45JCX4C&edge=curl"}
+	}
+}`
+
+func TestClient_Search(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /volumes", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("q"); got != "intitle:Dune inauthor:Herbert" {
+			t.Errorf("q = %q", got)
+		}
+		if got := r.URL.Query().Get("key"); got != "secret" {
+			t.Errorf("key = %q, want secret", got)
+		}
+		_, _ = w.Write([]byte(`{"totalItems": 1, "items": [` + volumeJSON + `]}`))
+	})
+	client := newTestClient(t, "secret", mux)
+
+	results, err := client.Search(context.Background(), metadata.SearchQuery{Title: "Dune", Author: "Herbert"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].ID != "B1hSG45JCX4C" || results[0].PublishYear != "2003" {
+		t.Errorf("result = %+v", results[0])
+	}
+	if results[0].CoverURL != "https://books.google.com/books/content?id=B1hSG45JCX4C" {
+		t.Errorf("cover = %q", results[0].CoverURL)
+	}
+}
+
+func TestClient_GetBook(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /volumes/B1hSG45JCX4C", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(volumeJSON))
+	})
+	client := newTestClient(t, "", mux)
+
+	book, err := client.GetBook(context.Background(), "B1hSG45JCX4C")
+	if err != nil {
+		t.Fatalf("GetBook() error = %v", err)
+	}
+	if book.ISBN != "9780441013593" {
+		t.Errorf("isbn = %q, want the ISBN-13", book.ISBN)
+	}
+	if book.Description != "Set on the desert planet Arrakis…" {
+		t.Errorf("description = %q", book.Description)
+	}
+	if !slices.Equal(book.Genres, []string{"Fiction", "Science Fiction"}) {
+		t.Errorf("genres = %v", book.Genres)
+	}
+	if book.Year() != "2003" || book.Language != "en" {
+		t.Errorf("book = %+v", book)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, "", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	if _, err := client.GetBook(context.Background(), "missing"); !errors.Is(err, metadata.ErrNotFound) {
+		t.Errorf("GetBook() error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetContributor(context.Background(), "x"); !errors.Is(err, metadata.ErrNotSupported) {
+		t.Errorf("GetContributor() error = %v, want ErrNotSupported", err)
+	}
+}
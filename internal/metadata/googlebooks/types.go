@@ -0,0 +1,67 @@
+package googlebooks
+
+import "strings"
+
+// volumesResponse is the raw /volumes search response.
+type volumesResponse struct {
+	TotalItems int      `json:"totalItems"`
+	Items      []volume `json:"items"`
+}
+
+// volume is a single Google Books volume.
+type volume struct {
+	ID   string     `json:"id"`
+	Info volumeInfo `json:"volumeInfo"`
+}
+
+// volumeInfo holds a volume's bibliographic data.
+type volumeInfo struct {
+	Title               string               `json:"title"`
+	Subtitle            string               `json:"subtitle"`
+	Authors             []string             `json:"authors"`
+	Publisher           string               `json:"publisher"`
+	PublishedDate       string               `json:"publishedDate"` // "2005", "2005-03" or "2005-03-01"
+	Description         string               `json:"description"`
+	IndustryIdentifiers []industryIdentifier `json:"industryIdentifiers"`
+	Categories          []string             `json:"categories"`
+	Language            string               `json:"language"`
+	ImageLinks          imageLinks           `json:"imageLinks"`
+}
+
+// isbn returns the volume's ISBN-13, or its ISBN-10 when that is all it has.
+func (v *volumeInfo) isbn() string {
+	var isbn10 string
+	for _, id := range v.IndustryIdentifiers {
+		switch id.Type {
+		case "ISBN_13":
+			return id.Identifier
+		case "ISBN_10":
+			isbn10 = id.Identifier
+		}
+	}
+	return isbn10
+}
+
+type industryIdentifier struct {
+	Type       string `json:"type"`
+	Identifier string `json:"identifier"`
+}
+
+type imageLinks struct {
+	Thumbnail  string `json:"thumbnail"`
+	Small      string `json:"small"`
+	Medium     string `json:"medium"`
+	Large      string `json:"large"`
+	ExtraLarge string `json:"extraLarge"`
+}
+
+// best returns the largest image, over HTTPS and without the page-curl effect.
+func (l imageLinks) best() string {
+	for _, u := range []string{l.ExtraLarge, l.Large, l.Medium, l.Small, l.Thumbnail} {
+		if u != "" {
+			u = strings.Replace(u, "http://", "https://", 1)
+			return strings.Replace(u, "&edge=curl", "", 1)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,239 @@
+// Package hardcover provides a metadata provider backed by the Hardcover GraphQL API.
+package hardcover
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+const (
+	defaultEndpoint = "https://api.hardcover.app/v1/graphql"
+	defaultTimeout  = 30 * time.Second
+
+	defaultLimit = 20
+	maxLimit     = 50
+)
+
+// ErrNoToken is returned by New when no API token is configured. Hardcover
+// has no anonymous access.
+var ErrNoToken = errors.New("hardcover: API token required")
+
+// Client is a rate-limited Hardcover API client.
+type Client struct {
+	http     *http.Client
+	endpoint string
+	token    string
+	limiter  *rate.Limiter
+	logger   *slog.Logger
+}
+
+var _ metadata.Provider = (*Client)(nil)
+
+// New creates a new Hardcover client. The token is the one shown on the
+// account's API page, with or without its "Bearer " prefix.
+// Hardcover allows 60 requests per minute.
+func New(token string, logger *slog.Logger) (*Client, error) {
+	token = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	return &Client{
+		http:     &http.Client{Timeout: defaultTimeout},
+		endpoint: defaultEndpoint,
+		token:    token,
+		limiter:  rate.NewLimiter(rate.Every(time.Second), 5),
+		logger:   logger,
+	}, nil
+}
+
+// Name implements metadata.Provider.
+func (c *Client) Name() string {
+	return metadata.ProviderHardcover
+}
+
+const searchQuery = `query Search($query: String!, $perPage: Int!) {
+  search(query: $query, query_type: "Book", per_page: $perPage, page: 1) {
+    results
+  }
+}`
+
+// Search implements metadata.Provider. Results are keyed by Hardcover book ID.
+func (c *Client) Search(ctx context.Context, q metadata.SearchQuery) ([]metadata.SearchResult, error) {
+	// Hardcover's search is a single full-text query over titles, authors,
+	// series and ISBNs.
+	terms := []string{q.Keywords, q.Title, q.Author, q.ISBN}
+	query := strings.Join(strings.Fields(strings.Join(terms, " ")), " ")
+
+	var data struct {
+		Search struct {
+			Results searchResults `json:"results"`
+		} `json:"search"`
+	}
+	vars := map[string]any{"query": query, "perPage": clampLimit(q.Limit)}
+	if err := c.do(ctx, searchQuery, vars, &data); err != nil {
+		return nil, fmt.Errorf("hardcover search: %w", err)
+	}
+
+	hits := data.Search.Results.Hits
+	results := make([]metadata.SearchResult, 0, len(hits))
+	for i := range hits {
+		doc := &hits[i].Document
+		result := metadata.SearchResult{
+			Provider: metadata.ProviderHardcover,
+			ID:       doc.ID,
+			Title:    doc.Title,
+			Subtitle: doc.Subtitle,
+			CoverURL: doc.Image.URL,
+		}
+		for _, name := range doc.AuthorNames {
+			result.Authors = append(result.Authors, metadata.Contributor{Name: name})
+		}
+		if doc.ReleaseYear > 0 {
+			result.PublishYear = strconv.Itoa(doc.ReleaseYear)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+const bookQuery = `query Book($id: Int!) {
+  books_by_pk(id: $id) {
+    id
+    title
+    subtitle
+    description
+    release_date
+    release_year
+    image { url }
+    cached_tags
+    contributions { contribution author { id name } }
+    book_series(order_by: {position: asc}) { position series { id name } }
+    default_audio_edition { isbn_13 asin audio_seconds publisher { name } language { code2 } }
+  }
+}`
+
+// GetBook implements metadata.Provider. The ID is a Hardcover book ID.
+func (c *Client) GetBook(ctx context.Context, id string) (*metadata.Book, error) {
+	numID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("hardcover get book %s: %w", id, metadata.ErrNotFound)
+	}
+
+	var data struct {
+		Book *rawBook `json:"books_by_pk"`
+	}
+	if err := c.do(ctx, bookQuery, map[string]any{"id": numID}, &data); err != nil {
+		return nil, fmt.Errorf("hardcover get book %s: %w", id, err)
+	}
+	if data.Book == nil {
+		return nil, fmt.Errorf("hardcover get book %s: %w", id, metadata.ErrNotFound)
+	}
+
+	return data.Book.toBook(), nil
+}
+
+// GetChapters implements metadata.Provider. Hardcover has no chapter data.
+func (c *Client) GetChapters(context.Context, string) ([]metadata.Chapter, error) {
+	return nil, metadata.ErrNotSupported
+}
+
+const authorQuery = `query Author($id: Int!) {
+  authors_by_pk(id: $id) {
+    id
+    name
+    bio
+    image { url }
+  }
+}`
+
+// GetContributor implements metadata.Provider. The ID is a Hardcover author ID.
+func (c *Client) GetContributor(ctx context.Context, id string) (*metadata.ContributorProfile, error) {
+	numID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("hardcover get author %s: %w", id, metadata.ErrNotFound)
+	}
+
+	var data struct {
+		Author *rawAuthor `json:"authors_by_pk"`
+	}
+	if err := c.do(ctx, authorQuery, map[string]any{"id": numID}, &data); err != nil {
+		return nil, fmt.Errorf("hardcover get author %s: %w", id, err)
+	}
+	if data.Author == nil {
+		return nil, fmt.Errorf("hardcover get author %s: %w", id, metadata.ErrNotFound)
+	}
+
+	return &metadata.ContributorProfile{
+		Provider:  metadata.ProviderHardcover,
+		ID:        id,
+		Name:      data.Author.Name,
+		Biography: data.Author.Bio,
+		ImageURL:  data.Author.Image.URL,
+	}, nil
+}
+
+// do runs a GraphQL query and decodes its data into v.
+func (c *Client) do(ctx context.Context, query string, vars map[string]any, v any) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("User-Agent", "ListenUp/1.0")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var envelope struct {
+		Data   jsontext.Value `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.UnmarshalRead(resp.Body, &envelope); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	if len(envelope.Errors) > 0 {
+		return fmt.Errorf("graphql: %s", envelope.Errors[0].Message)
+	}
+	if err := json.Unmarshal(envelope.Data, v); err != nil {
+		return fmt.Errorf("parse data: %w", err)
+	}
+	return nil
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
@@ -0,0 +1,136 @@
+package hardcover
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+// newTestClient serves GraphQL responses keyed by operation name.
+func newTestClient(t *testing.T, responses map[string]string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var req struct {
+			Query string `json:"query"`
+		}
+		if err := json.UnmarshalRead(r.Body, &req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		for op, body := range responses {
+			if strings.HasPrefix(req.Query, "query "+op+"(") {
+				_, _ = w.Write([]byte(body))
+				return
+			}
+		}
+		t.Errorf("unexpected query %q", req.Query)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New("Bearer test-token", slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.http = server.Client()
+	client.endpoint = server.URL
+	return client
+}
+
+func TestNew_RequiresToken(t *testing.T) {
+	t.Parallel()
+
+	if _, err := New("  ", slog.New(slog.DiscardHandler)); !errors.Is(err, ErrNoToken) {
+		t.Errorf("New() error = %v, want ErrNoToken", err)
+	}
+}
+
+func TestClient_Search(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, map[string]string{
+		"Search": `{"data": {"search": {"results": {"found": 1, "hits": [{"document": {
+			"id": "312460",
+			"title": "Project Hail Mary",
+			"author_names": ["Andy Weir"],
+			"release_year": 2021,
+			"image": {"url": "https://assets.hardcover.app/phm.jpg"}
+		}}]}}}}`,
+	})
+
+	results, err := client.Search(context.Background(), metadata.SearchQuery{Title: "Project Hail Mary", Author: "Weir"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].ID != "312460" || results[0].PublishYear != "2021" || results[0].Authors[0].Name != "Andy Weir" {
+		t.Errorf("result = %+v", results[0])
+	}
+}
+
+func TestClient_GetBook(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, map[string]string{
+		"Book": `{"data": {"books_by_pk": {
+			"id": 312460,
+			"title": "Project Hail Mary",
+			"description": "A lone astronaut.",
+			"release_date": "2021-05-04",
+			"image": {"url": "https://assets.hardcover.app/phm.jpg"},
+			"cached_tags": {"Genre": [{"tag": "Science Fiction"}], "Mood": [{"tag": "funny"}]},
+			"contributions": [
+				{"contribution": null, "author": {"id": 204214, "name": "Andy Weir"}},
+				{"contribution": "Narrator", "author": {"id": 1337, "name": "Ray Porter"}}
+			],
+			"book_series": [],
+			"default_audio_edition": {"asin": "B08G9PRS1K", "audio_seconds": 58200, "publisher": {"name": "Audible Studios"}, "language": {"code2": "en"}}
+		}}}`,
+	})
+
+	book, err := client.GetBook(context.Background(), "312460")
+	if err != nil {
+		t.Fatalf("GetBook() error = %v", err)
+	}
+	if len(book.Authors) != 1 || book.Authors[0].Name != "Andy Weir" || book.Authors[0].ID != "204214" {
+		t.Errorf("authors = %+v", book.Authors)
+	}
+	if len(book.Narrators) != 1 || book.Narrators[0].Name != "Ray Porter" {
+		t.Errorf("narrators = %+v", book.Narrators)
+	}
+	if len(book.Genres) != 1 || book.Genres[0] != "Science Fiction" {
+		t.Errorf("genres = %v", book.Genres)
+	}
+	if book.ASIN != "B08G9PRS1K" || book.RuntimeMinutes != 970 || book.Year() != "2021" {
+		t.Errorf("book = %+v", book)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, map[string]string{
+		"Book":   `{"data": {"books_by_pk": null}}`,
+		"Author": `{"errors": [{"message": "invalid token"}]}`,
+	})
+
+	if _, err := client.GetBook(context.Background(), "1"); !errors.Is(err, metadata.ErrNotFound) {
+		t.Errorf("GetBook() error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetBook(context.Background(), "abc"); !errors.Is(err, metadata.ErrNotFound) {
+		t.Errorf("GetBook(non-numeric) error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetContributor(context.Background(), "1"); err == nil || !strings.Contains(err.Error(), "invalid token") {
+		t.Errorf("GetContributor() error = %v, want graphql error", err)
+	}
+}
@@ -0,0 +1,134 @@
+package hardcover
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+// searchResults is the Typesense response Hardcover returns from search.
+type searchResults struct {
+	Found int         `json:"found"`
+	Hits  []searchHit `json:"hits"`
+}
+
+type searchHit struct {
+	Document searchDocument `json:"document"`
+}
+
+type searchDocument struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Subtitle    string   `json:"subtitle"`
+	AuthorNames []string `json:"author_names"`
+	ReleaseYear int      `json:"release_year"`
+	Image       rawImage `json:"image"`
+}
+
+type rawImage struct {
+	URL string `json:"url"`
+}
+
+// rawBook is a book from books_by_pk.
+type rawBook struct {
+	ID          int                 `json:"id"`
+	Title       string              `json:"title"`
+	Subtitle    string              `json:"subtitle"`
+	Description string              `json:"description"`
+	ReleaseDate string              `json:"release_date"`
+	ReleaseYear int                 `json:"release_year"`
+	Image       rawImage            `json:"image"`
+	CachedTags  map[string][]rawTag `json:"cached_tags"`
+	Contribs    []rawContribution   `json:"contributions"`
+	BookSeries  []rawBookSeries     `json:"book_series"`
+	Audio       *rawAudioEdition    `json:"default_audio_edition"`
+}
+
+type rawTag struct {
+	Tag string `json:"tag"`
+}
+
+type rawContribution struct {
+	// Contribution is null for the primary author, otherwise a role such
+	// as "Narrator" or "Translator".
+	Contribution string    `json:"contribution"`
+	Author       rawAuthor `json:"author"`
+}
+
+type rawBookSeries struct {
+	Position float64 `json:"position"`
+	Series   struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"series"`
+}
+
+type rawAudioEdition struct {
+	ISBN13       string `json:"isbn_13"`
+	ASIN         string `json:"asin"`
+	AudioSeconds int    `json:"audio_seconds"`
+	Publisher    struct {
+		Name string `json:"name"`
+	} `json:"publisher"`
+	Language struct {
+		Code2 string `json:"code2"`
+	} `json:"language"`
+}
+
+// rawAuthor is an author from authors_by_pk or a contribution.
+type rawAuthor struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Bio   string   `json:"bio"`
+	Image rawImage `json:"image"`
+}
+
+// toBook converts a Hardcover book, preferring its default audio edition's
+// details where the edition has them.
+func (b *rawBook) toBook() *metadata.Book {
+	book := &metadata.Book{
+		Provider:    metadata.ProviderHardcover,
+		ID:          strconv.Itoa(b.ID),
+		Title:       b.Title,
+		Subtitle:    b.Subtitle,
+		Description: b.Description,
+		CoverURL:    b.Image.URL,
+	}
+	if t, err := time.Parse("2006-01-02", b.ReleaseDate); err == nil {
+		book.ReleaseDate = t
+	} else if b.ReleaseYear > 0 {
+		book.PublishYear = strconv.Itoa(b.ReleaseYear)
+	}
+
+	for _, c := range b.Contribs {
+		contributor := metadata.Contributor{ID: strconv.Itoa(c.Author.ID), Name: c.Author.Name}
+		switch c.Contribution {
+		case "", "Author":
+			book.Authors = append(book.Authors, contributor)
+		case "Narrator":
+			book.Narrators = append(book.Narrators, contributor)
+		}
+	}
+
+	for _, s := range b.BookSeries {
+		entry := metadata.SeriesEntry{ID: strconv.Itoa(s.Series.ID), Name: s.Series.Name}
+		if s.Position > 0 {
+			entry.Position = strconv.FormatFloat(s.Position, 'f', -1, 64)
+		}
+		book.Series = append(book.Series, entry)
+	}
+
+	for _, tag := range b.CachedTags["Genre"] {
+		book.Genres = append(book.Genres, tag.Tag)
+	}
+
+	if a := b.Audio; a != nil {
+		book.ISBN = a.ISBN13
+		book.ASIN = a.ASIN
+		book.RuntimeMinutes = a.AudioSeconds / 60
+		book.Publisher = a.Publisher.Name
+		book.Language = a.Language.Code2
+	}
+	return book
+}
@@ -0,0 +1,112 @@
+package itunes
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+const lookupBaseURL = "https://itunes.apple.com/lookup"
+
+var _ metadata.Provider = (*Client)(nil)
+
+// Name implements metadata.Provider.
+func (c *Client) Name() string {
+	return metadata.ProviderITunes
+}
+
+// Search implements metadata.Provider. Results are keyed by collection ID.
+// Unlike SearchAudiobooksWithDimensions it does not probe cover sizes.
+func (c *Client) Search(ctx context.Context, q metadata.SearchQuery) ([]metadata.SearchResult, error) {
+	terms := strings.Fields(strings.Join([]string{q.Keywords, q.Title, q.Author}, " "))
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	audiobooks, err := c.SearchAudiobooks(ctx, strings.Join(terms, " "))
+	if err != nil {
+		return nil, fmt.Errorf("itunes search: %w", err)
+	}
+
+	results := make([]metadata.SearchResult, 0, len(audiobooks))
+	for _, a := range audiobooks {
+		results = append(results, metadata.SearchResult{
+			Provider: metadata.ProviderITunes,
+			ID:       strconv.FormatInt(a.ID, 10),
+			Title:    a.Title,
+			Authors:  []metadata.Contributor{{Name: a.Artist}},
+			CoverURL: a.CoverURL,
+		})
+	}
+	return results, nil
+}
+
+// GetBook implements metadata.Provider. The ID is an iTunes collection ID.
+func (c *Client) GetBook(ctx context.Context, id string) (*metadata.Book, error) {
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, fmt.Errorf("itunes lookup %s: %w", id, metadata.ErrNotFound)
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lookupBaseURL+"?"+url.Values{"id": {id}}.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("lookup request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lookup failed: status %d", resp.StatusCode)
+	}
+
+	var lookup searchResponse
+	if err := json.UnmarshalRead(resp.Body, &lookup); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if len(lookup.Results) == 0 {
+		return nil, fmt.Errorf("itunes lookup %s: %w", id, metadata.ErrNotFound)
+	}
+
+	r := &lookup.Results[0]
+	artwork := r.ArtworkURL100
+	if artwork == "" {
+		artwork = r.ArtworkURL60
+	}
+	book := &metadata.Book{
+		Provider:    metadata.ProviderITunes,
+		ID:          id,
+		Title:       r.CollectionName,
+		Authors:     []metadata.Contributor{{Name: r.ArtistName}},
+		Description: metadata.StripHTML(r.Description),
+		CoverURL:    MaxCoverURL(artwork),
+	}
+	if r.PrimaryGenreName != "" {
+		book.Genres = []string{r.PrimaryGenreName}
+	}
+	if t, err := time.Parse(time.RFC3339, r.ReleaseDate); err == nil {
+		book.ReleaseDate = t
+	}
+	return book, nil
+}
+
+// GetChapters implements metadata.Provider. iTunes has no chapter data.
+func (c *Client) GetChapters(context.Context, string) ([]metadata.Chapter, error) {
+	return nil, metadata.ErrNotSupported
+}
+
+// GetContributor implements metadata.Provider. iTunes has no author biographies.
+func (c *Client) GetContributor(context.Context, string) (*metadata.ContributorProfile, error) {
+	return nil, metadata.ErrNotSupported
+}
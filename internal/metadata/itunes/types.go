@@ -30,4 +30,5 @@ type searchResult struct {
 	TrackCount       int     `json:"trackCount,omitempty"`
 	ReleaseDate      string  `json:"releaseDate,omitempty"`
 	PrimaryGenreName string  `json:"primaryGenreName,omitempty"`
+	Description      string  `json:"description,omitempty"`
 }
@@ -0,0 +1,268 @@
+// Package openlibrary provides a metadata provider backed by the Open Library API.
+package openlibrary
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+const (
+	defaultBaseURL   = "https://openlibrary.org"
+	defaultCoversURL = "https://covers.openlibrary.org"
+	defaultTimeout   = 30 * time.Second
+
+	defaultLimit = 20
+	maxLimit     = 50
+
+	// maxAuthors caps the author lookups made for one work.
+	maxAuthors = 5
+	// maxSubjects caps the subjects reported as genres; Open Library
+	// subjects are user-edited and long tails are mostly noise.
+	maxSubjects = 10
+)
+
+var (
+	workKeyPattern   = regexp.MustCompile(`^OL\d+W$`)
+	authorKeyPattern = regexp.MustCompile(`^OL\d+A$`)
+)
+
+// Client is a rate-limited Open Library API client.
+type Client struct {
+	http      *http.Client
+	baseURL   string
+	coversURL string
+	limiter   *rate.Limiter
+	logger    *slog.Logger
+}
+
+var _ metadata.Provider = (*Client)(nil)
+
+// New creates a new Open Library client.
+// Open Library asks anonymous clients to stay around one request per second.
+func New(logger *slog.Logger) *Client {
+	return &Client{
+		http:      &http.Client{Timeout: defaultTimeout},
+		baseURL:   defaultBaseURL,
+		coversURL: defaultCoversURL,
+		limiter:   rate.NewLimiter(rate.Every(time.Second), 3),
+		logger:    logger,
+	}
+}
+
+// Name implements metadata.Provider.
+func (c *Client) Name() string {
+	return metadata.ProviderOpenLibrary
+}
+
+// Search implements metadata.Provider. Results are works, keyed by their
+// work ID ("OL45804W").
+func (c *Client) Search(ctx context.Context, q metadata.SearchQuery) ([]metadata.SearchResult, error) {
+	params := url.Values{}
+	if q.Keywords != "" {
+		params.Set("q", q.Keywords)
+	}
+	if q.Title != "" {
+		params.Set("title", q.Title)
+	}
+	if q.Author != "" {
+		params.Set("author", q.Author)
+	}
+	if q.ISBN != "" {
+		params.Set("isbn", q.ISBN)
+	}
+	params.Set("limit", strconv.Itoa(clampLimit(q.Limit)))
+	params.Set("fields", "key,title,subtitle,author_name,author_key,first_publish_year,cover_i")
+
+	var resp searchResponse
+	if err := c.get(ctx, "/search.json", params, &resp); err != nil {
+		return nil, fmt.Errorf("openlibrary search: %w", err)
+	}
+
+	results := make([]metadata.SearchResult, 0, len(resp.Docs))
+	for i := range resp.Docs {
+		doc := &resp.Docs[i]
+		result := metadata.SearchResult{
+			Provider: metadata.ProviderOpenLibrary,
+			ID:       strings.TrimPrefix(doc.Key, "/works/"),
+			Title:    doc.Title,
+			Subtitle: doc.Subtitle,
+			CoverURL: c.coverURL("b", doc.CoverID),
+		}
+		for j, name := range doc.AuthorNames {
+			author := metadata.Contributor{Name: name}
+			if j < len(doc.AuthorKeys) {
+				author.ID = doc.AuthorKeys[j]
+			}
+			result.Authors = append(result.Authors, author)
+		}
+		if doc.FirstPublishYear > 0 {
+			result.PublishYear = strconv.Itoa(doc.FirstPublishYear)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// GetBook implements metadata.Provider. The ID is a work ID.
+func (c *Client) GetBook(ctx context.Context, id string) (*metadata.Book, error) {
+	if !workKeyPattern.MatchString(id) {
+		return nil, fmt.Errorf("openlibrary get book %s: %w", id, metadata.ErrNotFound)
+	}
+
+	var work rawWork
+	if err := c.get(ctx, "/works/"+id+".json", nil, &work); err != nil {
+		return nil, fmt.Errorf("openlibrary get book %s: %w", id, err)
+	}
+
+	book := &metadata.Book{
+		Provider:    metadata.ProviderOpenLibrary,
+		ID:          id,
+		Title:       work.Title,
+		Subtitle:    work.Subtitle,
+		Description: string(work.Description),
+		PublishYear: publishYear(work.FirstPublishDate),
+	}
+	for _, cover := range work.Covers {
+		if cover > 0 {
+			book.CoverURL = c.coverURL("b", cover)
+			break
+		}
+	}
+	for i, subject := range work.Subjects {
+		if i == maxSubjects {
+			break
+		}
+		book.Genres = append(book.Genres, subject)
+	}
+
+	// Works only reference their authors; names need a lookup each.
+	for i, ref := range work.Authors {
+		if i == maxAuthors {
+			break
+		}
+		key := strings.TrimPrefix(ref.Author.Key, "/authors/")
+		author, err := c.getAuthor(ctx, key)
+		if err != nil {
+			c.logger.Warn("open library author lookup failed",
+				"work", id,
+				"author", key,
+				"error", err,
+			)
+			continue
+		}
+		book.Authors = append(book.Authors, metadata.Contributor{ID: key, Name: author.Name})
+	}
+
+	return book, nil
+}
+
+// GetChapters implements metadata.Provider. Open Library has no chapter data.
+func (c *Client) GetChapters(context.Context, string) ([]metadata.Chapter, error) {
+	return nil, metadata.ErrNotSupported
+}
+
+// GetContributor implements metadata.Provider. The ID is an author ID ("OL34184A").
+func (c *Client) GetContributor(ctx context.Context, id string) (*metadata.ContributorProfile, error) {
+	author, err := c.getAuthor(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("openlibrary get author %s: %w", id, err)
+	}
+
+	profile := &metadata.ContributorProfile{
+		Provider:  metadata.ProviderOpenLibrary,
+		ID:        id,
+		Name:      author.Name,
+		Biography: string(author.Bio),
+	}
+	for _, photo := range author.Photos {
+		if photo > 0 {
+			profile.ImageURL = c.coverURL("a", photo)
+			break
+		}
+	}
+	return profile, nil
+}
+
+func (c *Client) getAuthor(ctx context.Context, id string) (*rawAuthor, error) {
+	if !authorKeyPattern.MatchString(id) {
+		return nil, metadata.ErrNotFound
+	}
+	var author rawAuthor
+	if err := c.get(ctx, "/authors/"+id+".json", nil, &author); err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+// get fetches a JSON document from the API.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "ListenUp/1.0")
+
+	c.logger.Debug("open library request", "path", path)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return metadata.ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if err := json.UnmarshalRead(resp.Body, v); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
+
+// coverURL returns the large cover ("b") or author photo ("a") for an image ID.
+func (c *Client) coverURL(kind string, id int64) string {
+	if id <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/id/%d-L.jpg", c.coversURL, kind, id)
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
+
+// publishYearPattern finds the year in free-form dates like "March 1965".
+var publishYearPattern = regexp.MustCompile(`\b\d{4}\b`)
+
+func publishYear(date string) string {
+	return publishYearPattern.FindString(date)
+}
@@ -0,0 +1,127 @@
+package openlibrary
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New(slog.New(slog.DiscardHandler))
+	client.http = server.Client()
+	client.baseURL = server.URL
+	client.coversURL = "https://covers.example"
+	return client
+}
+
+func TestClient_Search(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /search.json", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("title"); got != "Dune" {
+			t.Errorf("title = %q, want Dune", got)
+		}
+		_, _ = w.Write([]byte(`{"numFound": 1, "docs": [{
+			"key": "/works/OL893415W",
+			"title": "Dune",
+			"author_name": ["Frank Herbert"],
+			"author_key": ["OL79034A"],
+			"first_publish_year": 1965,
+			"cover_i": 11481354
+		}]}`))
+	})
+	client := newTestClient(t, mux)
+
+	results, err := client.Search(context.Background(), metadata.SearchQuery{Title: "Dune"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+
+	got := results[0]
+	if got.ID != "OL893415W" || got.Provider != metadata.ProviderOpenLibrary {
+		t.Errorf("result = %s/%s, want openlibrary/OL893415W", got.Provider, got.ID)
+	}
+	if len(got.Authors) != 1 || got.Authors[0].ID != "OL79034A" || got.Authors[0].Name != "Frank Herbert" {
+		t.Errorf("authors = %+v", got.Authors)
+	}
+	if got.PublishYear != "1965" {
+		t.Errorf("publish year = %q, want 1965", got.PublishYear)
+	}
+	if got.CoverURL != "https://covers.example/b/id/11481354-L.jpg" {
+		t.Errorf("cover = %q", got.CoverURL)
+	}
+}
+
+func TestClient_GetBook(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /works/OL893415W.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"title": "Dune",
+			"description": {"type": "/type/text", "value": "Desert planet."},
+			"covers": [-1, 11481354],
+			"subjects": ["Science fiction"],
+			"first_publish_date": "August 1965",
+			"authors": [{"author": {"key": "/authors/OL79034A"}}]
+		}`))
+	})
+	mux.HandleFunc("GET /authors/OL79034A.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"name": "Frank Herbert", "bio": "Author.", "photos": [6257539]}`))
+	})
+	client := newTestClient(t, mux)
+
+	book, err := client.GetBook(context.Background(), "OL893415W")
+	if err != nil {
+		t.Fatalf("GetBook() error = %v", err)
+	}
+	if book.Title != "Dune" || book.Description != "Desert planet." {
+		t.Errorf("book = %+v", book)
+	}
+	if book.Year() != "1965" {
+		t.Errorf("year = %q, want 1965", book.Year())
+	}
+	if book.CoverURL != "https://covers.example/b/id/11481354-L.jpg" {
+		t.Errorf("cover = %q", book.CoverURL)
+	}
+	if len(book.Authors) != 1 || book.Authors[0].Name != "Frank Herbert" {
+		t.Errorf("authors = %+v", book.Authors)
+	}
+
+	profile, err := client.GetContributor(context.Background(), "OL79034A")
+	if err != nil {
+		t.Fatalf("GetContributor() error = %v", err)
+	}
+	if profile.Biography != "Author." || profile.ImageURL != "https://covers.example/a/id/6257539-L.jpg" {
+		t.Errorf("profile = %+v", profile)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, http.NotFoundHandler())
+
+	if _, err := client.GetBook(context.Background(), "OL1W"); !errors.Is(err, metadata.ErrNotFound) {
+		t.Errorf("GetBook() error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetBook(context.Background(), "not-a-work"); !errors.Is(err, metadata.ErrNotFound) {
+		t.Errorf("GetBook(invalid) error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetChapters(context.Background(), "OL1W"); !errors.Is(err, metadata.ErrNotSupported) {
+		t.Errorf("GetChapters() error = %v, want ErrNotSupported", err)
+	}
+}
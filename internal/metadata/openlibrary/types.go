@@ -0,0 +1,67 @@
+package openlibrary
+
+import "encoding/json/v2"
+
+// searchResponse is the raw /search.json response.
+type searchResponse struct {
+	NumFound int         `json:"numFound"`
+	Docs     []searchDoc `json:"docs"`
+}
+
+// searchDoc is a single work from search.
+type searchDoc struct {
+	Key              string   `json:"key"` // "/works/OL45804W"
+	Title            string   `json:"title"`
+	Subtitle         string   `json:"subtitle"`
+	AuthorNames      []string `json:"author_name"`
+	AuthorKeys       []string `json:"author_key"`
+	FirstPublishYear int      `json:"first_publish_year"`
+	CoverID          int64    `json:"cover_i"`
+}
+
+// rawWork is the raw /works/{id}.json response.
+type rawWork struct {
+	Title            string         `json:"title"`
+	Subtitle         string         `json:"subtitle"`
+	Description      textValue      `json:"description"`
+	Covers           []int64        `json:"covers"`
+	Subjects         []string       `json:"subjects"`
+	FirstPublishDate string         `json:"first_publish_date"`
+	Authors          []rawAuthorRef `json:"authors"`
+}
+
+// rawAuthorRef links a work to an author record.
+type rawAuthorRef struct {
+	Author struct {
+		Key string `json:"key"` // "/authors/OL34184A"
+	} `json:"author"`
+}
+
+// rawAuthor is the raw /authors/{id}.json response.
+type rawAuthor struct {
+	Name   string    `json:"name"`
+	Bio    textValue `json:"bio"`
+	Photos []int64   `json:"photos"`
+}
+
+// textValue handles Open Library text fields, which are either a plain
+// string or a typed {"type": "/type/text", "value": "..."} object.
+type textValue string
+
+// UnmarshalJSON accepts both forms.
+func (t *textValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = textValue(s)
+		return nil
+	}
+
+	var typed struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	*t = textValue(typed.Value)
+	return nil
+}
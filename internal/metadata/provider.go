@@ -0,0 +1,134 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Provider names. A library's provider priority list holds these.
+const (
+	ProviderAudible     = "audible"
+	ProviderITunes      = "itunes"
+	ProviderOpenLibrary = "openlibrary"
+	ProviderGoogleBooks = "googlebooks"
+	ProviderHardcover   = "hardcover"
+)
+
+// DefaultProviderPriority is the order providers are tried in for a library
+// that has not set its own.
+var DefaultProviderPriority = []string{
+	ProviderAudible,
+	ProviderHardcover,
+	ProviderGoogleBooks,
+	ProviderOpenLibrary,
+	ProviderITunes,
+}
+
+// Provider errors.
+var (
+	// ErrNotFound means the provider has no record under the requested ID.
+	ErrNotFound = errors.New("metadata: not found")
+	// ErrNotSupported means the provider does not offer the requested kind of data.
+	ErrNotSupported = errors.New("metadata: not supported by provider")
+)
+
+// Provider is a source of book metadata.
+//
+// IDs are opaque to callers and only meaningful to the provider that issued
+// them: an ASIN for Audible, a work key for Open Library, a volume ID for
+// Google Books. Methods a provider cannot serve return ErrNotSupported.
+type Provider interface {
+	// Name returns the provider's name, one of the Provider* constants.
+	Name() string
+	Search(ctx context.Context, query SearchQuery) ([]SearchResult, error)
+	GetBook(ctx context.Context, id string) (*Book, error)
+	GetChapters(ctx context.Context, id string) ([]Chapter, error)
+	GetContributor(ctx context.Context, id string) (*ContributorProfile, error)
+}
+
+// SearchQuery describes a catalog search. Providers use whichever fields
+// their API understands and fold the rest into a keyword search.
+type SearchQuery struct {
+	Keywords string `json:"keywords,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Author   string `json:"author,omitempty"`
+	ISBN     string `json:"isbn,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+// IsEmpty reports whether the query has nothing to search for.
+func (q SearchQuery) IsEmpty() bool {
+	return q.Keywords == "" && q.Title == "" && q.Author == "" && q.ISBN == ""
+}
+
+// Contributor is an author or narrator as a provider knows them.
+type Contributor struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// SeriesEntry is a book's place in a series.
+type SeriesEntry struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Position string `json:"position,omitempty"`
+}
+
+// Book is full book metadata from a provider.
+type Book struct {
+	Provider       string        `json:"provider"`
+	ID             string        `json:"id"`
+	Title          string        `json:"title"`
+	Subtitle       string        `json:"subtitle,omitempty"`
+	Authors        []Contributor `json:"authors,omitempty"`
+	Narrators      []Contributor `json:"narrators,omitempty"`
+	Publisher      string        `json:"publisher,omitempty"`
+	ReleaseDate    time.Time     `json:"release_date,omitzero"`
+	PublishYear    string        `json:"publish_year,omitempty"`
+	RuntimeMinutes int           `json:"runtime_minutes,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	CoverURL       string        `json:"cover_url,omitempty"`
+	Series         []SeriesEntry `json:"series,omitempty"`
+	Genres         []string      `json:"genres,omitempty"`
+	Language       string        `json:"language,omitempty"`
+	ISBN           string        `json:"isbn,omitempty"`
+	ASIN           string        `json:"asin,omitempty"`
+}
+
+// Year returns the publication year, from the release date when known.
+func (b *Book) Year() string {
+	if !b.ReleaseDate.IsZero() {
+		return b.ReleaseDate.Format("2006")
+	}
+	return b.PublishYear
+}
+
+// SearchResult is a lighter-weight book for search listings.
+type SearchResult struct {
+	Provider       string        `json:"provider"`
+	ID             string        `json:"id"`
+	Title          string        `json:"title"`
+	Subtitle       string        `json:"subtitle,omitempty"`
+	Authors        []Contributor `json:"authors,omitempty"`
+	Narrators      []Contributor `json:"narrators,omitempty"`
+	CoverURL       string        `json:"cover_url,omitempty"`
+	PublishYear    string        `json:"publish_year,omitempty"`
+	RuntimeMinutes int           `json:"runtime_minutes,omitempty"`
+}
+
+// Chapter is a chapter marker.
+type Chapter struct {
+	Title      string `json:"title"`
+	StartMs    int64  `json:"start_ms"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// ContributorProfile is a provider's biography of a contributor.
+type ContributorProfile struct {
+	Provider  string `json:"provider"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Biography string `json:"biography,omitempty"`
+	ImageURL  string `json:"image_url,omitempty"`
+}
@@ -0,0 +1,25 @@
+// Package notify delivers messages to users outside the app, such as
+// password reset links. Delivery is pluggable: services depend on the
+// Notifier interface and the server wires in whichever transport is
+// configured, currently SMTP.
+package notify
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotConfigured is returned by NewSMTP when no SMTP host is set.
+var ErrNotConfigured = errors.New("notifier not configured")
+
+// Message is a plain-text notification for one recipient.
+type Message struct {
+	To      string // Recipient address
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages to users.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer accepts one connection and records the conversation.
+type fakeSMTPServer struct {
+	ln       net.Listener
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := &fakeSMTPServer{ln: ln, done: make(chan struct{})}
+	t.Cleanup(func() { ln.Close() })
+	go srv.serve()
+	return srv
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		s.commands = append(s.commands, line)
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			reply("235 authenticated")
+		case "DATA":
+			reply("354 go ahead")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			s.data = b.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTP_Send(t *testing.T) {
+	t.Parallel()
+	srv := newFakeSMTPServer(t)
+
+	n, err := NewSMTP(SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     srv.port(),
+		Username: "listenup",
+		Password: "secret",
+		From:     "ListenUp <listenup@example.com>",
+	})
+	if err != nil {
+		t.Fatalf("NewSMTP: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = n.Send(ctx, Message{
+		To:      "Ada <ada@example.com>",
+		Subject: "Reset your password",
+		Body:    "Open this link:\nhttps://listenup.example.com/reset/abc",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-srv.done
+
+	want := []string{"AUTH PLAIN", "MAIL FROM:<listenup@example.com>", "RCPT TO:<ada@example.com>", "DATA", "QUIT"}
+	for _, w := range want {
+		found := false
+		for _, c := range srv.commands {
+			if strings.HasPrefix(c, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("command %q not sent; got %v", w, srv.commands)
+		}
+	}
+	if !strings.Contains(srv.data, "Subject: Reset your password\r\n") {
+		t.Errorf("message missing subject:\n%s", srv.data)
+	}
+	if !strings.Contains(srv.data, "\r\n\r\nOpen this link:\r\nhttps://listenup.example.com/reset/abc\r\n") {
+		t.Errorf("message body not CRLF-normalised:\n%s", srv.data)
+	}
+}
+
+func TestNewSMTP_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewSMTP(SMTPConfig{}); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("empty host: got %v, want ErrNotConfigured", err)
+	}
+	if _, err := NewSMTP(SMTPConfig{Host: "smtp.example.com", From: "not an address"}); err == nil {
+		t.Error("expected error for invalid sender")
+	}
+}
+
+func TestBuildMessage_NoHeaderInjection(t *testing.T) {
+	t.Parallel()
+
+	from := &mail.Address{Name: "ListenUp", Address: "listenup@example.com"}
+	to := &mail.Address{Address: "ada@example.com"}
+	msg := string(buildMessage(from, to, "Hi\r\nBcc: eve@example.com", "body", time.Unix(0, 0)))
+
+	headers, _, _ := strings.Cut(msg, "\r\n\r\n")
+	for _, h := range strings.Split(headers, "\r\n") {
+		if strings.HasPrefix(h, "Bcc:") {
+			t.Fatalf("subject injected a header:\n%s", msg)
+		}
+	}
+	if !strings.Contains(headers, "Content-Type: text/plain; charset=utf-8") {
+		t.Errorf("missing content type:\n%s", headers)
+	}
+}
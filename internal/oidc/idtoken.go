@@ -0,0 +1,210 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json/v2"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// clockSkew is the leeway allowed on exp, nbf and iat.
+const clockSkew = time.Minute
+
+// Claims are the validated claims of an ID token.
+type Claims struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	GivenName         string
+	FamilyName        string
+	PreferredUsername string
+
+	// Raw holds every claim, for provider-specific ones like groups.
+	Raw map[string]any
+}
+
+// VerifyIDToken validates a compact-serialized ID token: signature against
+// the provider's published keys, then issuer, audience, expiry and nonce
+// (OpenID Connect Core 1.0 §3.1.3.7). Every failure wraps ErrInvalidIDToken.
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidIDToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid,omitempty"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrInvalidIDToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %w", ErrInvalidIDToken, err)
+	}
+
+	keys, err := p.signingKeys(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signingInput := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, k := range keys {
+		if k.alg != "" && k.alg != header.Alg {
+			continue
+		}
+		if verifySignature(header.Alg, k.key, signingInput, sig) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: signature not valid for any %s key", ErrInvalidIDToken, header.Alg)
+	}
+
+	var rawClaims map[string]any
+	if err := decodeSegment(parts[1], &rawClaims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %w", ErrInvalidIDToken, err)
+	}
+	c := newClaims(rawClaims)
+
+	if strings.TrimSuffix(c.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("%w: issuer %q", ErrInvalidIDToken, c.Issuer)
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+
+	aud := c.Strings("aud")
+	if !slices.Contains(aud, p.cfg.ClientID) {
+		return nil, fmt.Errorf("%w: audience %v does not include this client", ErrInvalidIDToken, aud)
+	}
+	if azp := c.String("azp"); len(aud) > 1 && azp != "" && azp != p.cfg.ClientID {
+		return nil, fmt.Errorf("%w: authorized party %q", ErrInvalidIDToken, azp)
+	}
+
+	now := p.now()
+	exp, ok := c.time("exp")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidIDToken)
+	}
+	if now.After(exp.Add(clockSkew)) {
+		return nil, fmt.Errorf("%w: expired at %s", ErrInvalidIDToken, exp.Format(time.RFC3339))
+	}
+	if nbf, ok := c.time("nbf"); ok && now.Add(clockSkew).Before(nbf) {
+		return nil, fmt.Errorf("%w: not valid before %s", ErrInvalidIDToken, nbf.Format(time.RFC3339))
+	}
+	if iat, ok := c.time("iat"); ok && now.Add(clockSkew).Before(iat) {
+		return nil, fmt.Errorf("%w: issued in the future", ErrInvalidIDToken)
+	}
+
+	if nonce != "" && subtle.ConstantTimeCompare([]byte(c.String("nonce")), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	return c, nil
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func newClaims(raw map[string]any) *Claims {
+	c := &Claims{Raw: raw}
+	c.fill()
+	return c
+}
+
+// fill copies the standard claims out of Raw.
+func (c *Claims) fill() {
+	c.Issuer = c.String("iss")
+	c.Subject = c.String("sub")
+	c.Email = c.String("email")
+	c.Name = c.String("name")
+	c.GivenName = c.String("given_name")
+	c.FamilyName = c.String("family_name")
+	c.PreferredUsername = c.String("preferred_username")
+
+	// Some providers send "true" as a string.
+	switch v := c.Raw["email_verified"].(type) {
+	case bool:
+		c.EmailVerified = v
+	case string:
+		c.EmailVerified = strings.EqualFold(v, "true")
+	}
+}
+
+// Merge adds userinfo claims that the ID token didn't carry. Claims already
+// present win, and userinfo for a different subject is ignored
+// (OpenID Connect Core 1.0 §5.3.2).
+func (c *Claims) Merge(userinfo map[string]any) {
+	if sub, _ := userinfo["sub"].(string); sub != c.Subject {
+		return
+	}
+	for k, v := range userinfo {
+		if _, ok := c.Raw[k]; !ok {
+			c.Raw[k] = v
+		}
+	}
+	c.fill()
+}
+
+// String returns a string claim, or "" if absent or not a string.
+func (c *Claims) String(name string) string {
+	s, _ := c.Raw[name].(string)
+	return s
+}
+
+// Strings returns a claim that may be a single string or an array of
+// strings, such as aud or groups. Dotted names reach into nested objects,
+// e.g. "realm_access.roles" for Keycloak.
+func (c *Claims) Strings(name string) []string {
+	var v any = c.Raw
+	for part := range strings.SplitSeq(name, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if v, ok = obj[part]; !ok {
+			// Fall back to a literal key containing dots.
+			v = c.Raw[name]
+			break
+		}
+	}
+
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// time returns a NumericDate claim.
+func (c *Claims) time(name string) (time.Time, bool) {
+	f, ok := c.Raw[name].(float64)
+	if !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)), true
+}
@@ -0,0 +1,261 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// keysTTL is how long a fetched key set is used before refetching.
+	keysTTL = time.Hour
+
+	// keysMinRefresh stops a stream of tokens with unknown key IDs from
+	// hammering the provider; a rotated key is picked up within a minute.
+	keysMinRefresh = time.Minute
+)
+
+// jwk is one JSON Web Key (RFC 7517). Only public signing keys are used.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC and OKP
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// publicKey is a parsed signing key.
+type publicKey struct {
+	kid string
+	alg string // Optional restriction from the JWK
+	key crypto.PublicKey
+}
+
+// keySet is the cached provider key set.
+type keySet struct {
+	keys      []publicKey
+	fetchedAt time.Time
+}
+
+// signingKeys returns candidate keys for a token header. The key set is
+// refetched when stale, or when the key ID is unknown and the set hasn't
+// just been fetched (the provider rotated its keys).
+func (p *Provider) signingKeys(ctx context.Context, kid string) ([]publicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	age := p.now().Sub(p.keys.fetchedAt)
+	stale := p.keys.keys == nil || age >= keysTTL
+	if !stale && kid != "" && !p.keys.has(kid) && age >= keysMinRefresh {
+		stale = true
+	}
+	if stale {
+		if err := p.fetchKeysLocked(ctx); err != nil {
+			if p.keys.keys == nil {
+				return nil, err
+			}
+			// Keep using the old set; the provider may be briefly down.
+		}
+	}
+
+	if kid == "" {
+		return p.keys.keys, nil
+	}
+	var out []publicKey
+	for _, k := range p.keys.keys {
+		if k.kid == kid {
+			out = append(out, k)
+		}
+	}
+	return out, nil
+}
+
+func (p *Provider) fetchKeysLocked(ctx context.Context) error {
+	md, err := p.discoverLocked(ctx)
+	if err != nil {
+		return err
+	}
+
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.getJSON(ctx, md.JWKSURI, "", &doc); err != nil {
+		return fmt.Errorf("fetch signing keys: %w", err)
+	}
+
+	var keys []publicKey
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			// Skip keys we can't use rather than failing the whole set.
+			continue
+		}
+		keys = append(keys, publicKey{kid: k.Kid, alg: k.Alg, key: pub})
+	}
+	if len(keys) == 0 {
+		return errors.New("fetch signing keys: provider published no usable keys")
+	}
+
+	p.keys = keySet{keys: keys, fetchedAt: p.now()}
+	return nil
+}
+
+func (s keySet) has(kid string) bool {
+	for _, k := range s.keys {
+		if k.kid == kid {
+			return true
+		}
+	}
+	return false
+}
+
+// publicKey parses the JWK into a Go public key.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("rsa exponent out of range")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("ec coordinates have the wrong length")
+		}
+		point := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("ed25519 key has the wrong length")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// verifySignature checks a JWS signature over signingInput with the given
+// algorithm and key. "none" and HMAC algorithms are never accepted: a
+// relying party shouldn't trust tokens it could have minted itself.
+func verifySignature(alg string, key crypto.PublicKey, signingInput, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	case "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return errors.New("key type does not match alg")
+		}
+		if !ed25519.Verify(pub, signingInput, sig) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+
+	h := hash.New()
+	h.Write(signingInput)
+	digest := h.Sum(nil)
+
+	switch alg[0] {
+	case 'R':
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match alg")
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, sig)
+	case 'P':
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match alg")
+		}
+		return rsa.VerifyPSS(pub, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	default: // 'E'
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match alg")
+		}
+		// JWS ECDSA signatures are r || s, each the size of the curve.
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("signature has the wrong length")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,301 @@
+// Package oidc is a small OpenID Connect relying party: discovery, the
+// authorization code flow with PKCE, and ID token validation.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout = 15 * time.Second
+
+	// discoveryTTL is how long provider metadata is trusted before it is
+	// fetched again. Signing keys follow their own schedule in keys.go.
+	discoveryTTL = time.Hour
+
+	// maxResponseSize caps what is read from the provider.
+	maxResponseSize = 1 << 20
+)
+
+// DefaultScopes are requested when Config.Scopes is empty.
+var DefaultScopes = []string{"openid", "profile", "email"}
+
+var (
+	// ErrNotConfigured is returned by NewProvider when no issuer is set.
+	ErrNotConfigured = errors.New("oidc: not configured")
+
+	// ErrInvalidIDToken wraps every ID token validation failure.
+	ErrInvalidIDToken = errors.New("oidc: invalid ID token")
+)
+
+// Config identifies this server to an OpenID provider.
+type Config struct {
+	// IssuerURL is the provider's issuer, e.g. "https://auth.example.com".
+	// Discovery reads IssuerURL + "/.well-known/openid-configuration".
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string // Empty for public clients, which rely on PKCE alone
+	RedirectURL  string // This server's callback URL, registered with the provider
+	Scopes       []string
+}
+
+// Metadata is the subset of the provider's discovery document we use.
+type Metadata struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint,omitempty"`
+	JWKSURI               string   `json:"jwks_uri"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported,omitempty"`
+}
+
+// Token is a successful token endpoint response.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in,omitempty"`
+}
+
+// Provider talks to one OpenID provider. Discovery and signing keys are
+// fetched lazily and cached, so the provider being down at startup only
+// affects SSO logins, not the server.
+type Provider struct {
+	cfg  Config
+	http *http.Client
+
+	mu        sync.Mutex
+	metadata  *Metadata
+	fetchedAt time.Time
+	keys      keySet
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+// NewProvider creates a provider client.
+// Returns ErrNotConfigured if no issuer is set.
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.IssuerURL == "" {
+		return nil, ErrNotConfigured
+	}
+	if cfg.ClientID == "" {
+		return nil, errors.New("oidc: client ID is required")
+	}
+	if cfg.RedirectURL == "" {
+		return nil, errors.New("oidc: redirect URL is required")
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = DefaultScopes
+	} else if !slices.Contains(cfg.Scopes, "openid") {
+		cfg.Scopes = append([]string{"openid"}, cfg.Scopes...)
+	}
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+
+	return &Provider{
+		cfg:  cfg,
+		http: &http.Client{Timeout: defaultTimeout},
+		now:  time.Now,
+	}, nil
+}
+
+// Issuer returns the configured issuer URL.
+func (p *Provider) Issuer() string {
+	return p.cfg.IssuerURL
+}
+
+// Discover returns the provider metadata, fetching it when the cached copy
+// is missing or stale.
+func (p *Provider) Discover(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.discoverLocked(ctx)
+}
+
+func (p *Provider) discoverLocked(ctx context.Context) (*Metadata, error) {
+	if p.metadata != nil && p.now().Sub(p.fetchedAt) < discoveryTTL {
+		return p.metadata, nil
+	}
+
+	var md Metadata
+	if err := p.getJSON(ctx, p.cfg.IssuerURL+"/.well-known/openid-configuration", "", &md); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	// The issuer must match exactly, or tokens from another tenant on the
+	// same host would validate (OpenID Connect Discovery 1.0 §4.3).
+	if strings.TrimSuffix(md.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match configured %q", md.Issuer, p.cfg.IssuerURL)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: document is missing required endpoints")
+	}
+
+	p.metadata = &md
+	p.fetchedAt = p.now()
+	return p.metadata, nil
+}
+
+// AuthCodeURL returns the URL to send the user to. state and nonce are
+// echoed back in the callback and ID token; verifier is the PKCE code
+// verifier to present later in Exchange.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	md, err := p.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.cfg.ClientID)
+	params.Set("redirect_uri", p.cfg.RedirectURL)
+	params.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", CodeChallenge(verifier))
+	params.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(md.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return md.AuthorizationEndpoint + sep + params.Encode(), nil
+}
+
+// Exchange trades an authorization code for tokens.
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	md, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", verifier)
+
+	useBasic := p.cfg.ClientSecret != "" &&
+		(len(md.TokenAuthMethods) == 0 || slices.Contains(md.TokenAuthMethods, "client_secret_basic"))
+	if !useBasic {
+		form.Set("client_id", p.cfg.ClientID)
+		if p.cfg.ClientSecret != "" {
+			form.Set("client_secret", p.cfg.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if useBasic {
+		// RFC 6749 §2.3.1: credentials are form-encoded before Basic auth.
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var oauthErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+			return nil, fmt.Errorf("token request: %s: %s", oauthErr.Error, oauthErr.Description)
+		}
+		return nil, fmt.Errorf("token request: unexpected status %d", resp.StatusCode)
+	}
+
+	var tok Token
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return nil, fmt.Errorf("decode token response: %w", err)
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+	return &tok, nil
+}
+
+// UserInfo fetches the userinfo claims for an access token. Some providers
+// only put email and groups there, not in the ID token. Returns nil if the
+// provider has no userinfo endpoint.
+func (p *Provider) UserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	md, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if md.UserinfoEndpoint == "" || accessToken == "" {
+		return nil, nil
+	}
+
+	var claims map[string]any
+	if err := p.getJSON(ctx, md.UserinfoEndpoint, accessToken, &claims); err != nil {
+		return nil, fmt.Errorf("userinfo: %w", err)
+	}
+	return claims, nil
+}
+
+// getJSON fetches and decodes a JSON document.
+func (p *Provider) getJSON(ctx context.Context, rawURL, bearer string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, rawURL)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// RandomString returns a URL-safe random string with 256 bits of entropy,
+// suitable for state, nonce and PKCE verifiers.
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge derives the S256 PKCE challenge for a verifier (RFC 7636).
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,169 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/oidc/oidctest"
+)
+
+func newTestProvider(t *testing.T) (*Provider, *oidctest.Issuer) {
+	t.Helper()
+	iss := oidctest.NewIssuer(t, "listenup")
+	p, err := NewProvider(Config{
+		IssuerURL:    iss.URL + "/",
+		ClientID:     "listenup",
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  "https://listenup.example/api/v1/auth/oidc/callback",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	return p, iss
+}
+
+func TestProvider_CodeFlow(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	p, iss := newTestProvider(t)
+
+	verifier, _ := RandomString()
+	authURL, err := p.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	u, _ := url.Parse(authURL)
+	if got := u.Query().Get("scope"); got != "openid profile email" {
+		t.Errorf("scope = %q", got)
+	}
+
+	callback := iss.Authorize(t, authURL, map[string]any{
+		"sub":            "alice-sub",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"userinfo":       map[string]any{"groups": []string{"listenup-admins", "family"}, "email": "other@example.com"},
+	})
+	if callback.Get("state") != "state-1" {
+		t.Fatalf("state = %q", callback.Get("state"))
+	}
+
+	// The wrong verifier fails PKCE at the provider.
+	if _, err := p.Exchange(ctx, callback.Get("code"), "not-the-verifier"); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("Exchange with wrong verifier: %v", err)
+	}
+
+	callback = iss.Authorize(t, authURL, map[string]any{
+		"sub":            "alice-sub",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"userinfo":       map[string]any{"groups": []string{"listenup-admins", "family"}, "email": "other@example.com"},
+	})
+	tok, err := p.Exchange(ctx, callback.Get("code"), verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if _, err := p.VerifyIDToken(ctx, tok.IDToken, "some-other-nonce"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("wrong nonce: got %v, want ErrInvalidIDToken", err)
+	}
+	claims, err := p.VerifyIDToken(ctx, tok.IDToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+	if claims.Subject != "alice-sub" || claims.Email != "alice@example.com" || !claims.EmailVerified {
+		t.Errorf("claims = %+v", claims)
+	}
+	if claims.Strings("groups") != nil {
+		t.Errorf("groups should only come from userinfo")
+	}
+
+	info, err := p.UserInfo(ctx, tok.AccessToken)
+	if err != nil {
+		t.Fatalf("UserInfo: %v", err)
+	}
+	claims.Merge(info)
+	if got := claims.Strings("groups"); !slices.Equal(got, []string{"listenup-admins", "family"}) {
+		t.Errorf("groups = %v", got)
+	}
+	if claims.Email != "alice@example.com" {
+		t.Errorf("userinfo must not override ID token claims, email = %q", claims.Email)
+	}
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	p, iss := newTestProvider(t)
+
+	valid := iss.SignIDToken(t, map[string]any{"sub": "s"})
+	if _, err := p.VerifyIDToken(ctx, valid, ""); err != nil {
+		t.Fatalf("valid token: %v", err)
+	}
+
+	parts := strings.Split(valid, ".")
+	other := strings.Split(iss.SignIDToken(t, map[string]any{"sub": "mallory"}), ".")
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"malformed", "a.b"},
+		{"tampered", parts[0] + "." + other[1] + "." + parts[2]},
+		{"alg none", "eyJhbGciOiJub25lIn0." + parts[1] + "."},
+		{"wrong audience", iss.SignIDToken(t, map[string]any{"sub": "s", "aud": "someone-else"})},
+		{"wrong issuer", iss.SignIDToken(t, map[string]any{"sub": "s", "iss": "https://evil.example"})},
+		{"expired", iss.SignIDToken(t, map[string]any{"sub": "s", "exp": time.Now().Add(-time.Hour).Unix()})},
+		{"not yet valid", iss.SignIDToken(t, map[string]any{"sub": "s", "nbf": time.Now().Add(time.Hour).Unix()})},
+		{"no subject", iss.SignIDToken(t, map[string]any{})},
+		{"foreign azp", iss.SignIDToken(t, map[string]any{"sub": "s", "aud": []string{"listenup", "x"}, "azp": "x"})},
+	}
+	for _, tt := range tests {
+		if _, err := p.VerifyIDToken(ctx, tt.token, ""); !errors.Is(err, ErrInvalidIDToken) {
+			t.Errorf("%s: got %v, want ErrInvalidIDToken", tt.name, err)
+		}
+	}
+
+	// After key rollover the new key is fetched once the cached set is a
+	// minute old; until then the unknown key ID is rejected.
+	iss.RotateKey(t)
+	rotated := iss.SignIDToken(t, map[string]any{"sub": "s"})
+	if _, err := p.VerifyIDToken(ctx, rotated, ""); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("rotated key within refresh window: got %v", err)
+	}
+	p.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if _, err := p.VerifyIDToken(ctx, rotated, ""); err != nil {
+		t.Errorf("rotated key after refresh window: %v", err)
+	}
+}
+
+func TestClaims_Strings(t *testing.T) {
+	t.Parallel()
+	c := newClaims(map[string]any{
+		"groups":       "solo",
+		"realm_access": map[string]any{"roles": []any{"admin", 7, "user"}},
+		"a.b":          []any{"literal"},
+	})
+	tests := map[string][]string{
+		"groups":             {"solo"},
+		"realm_access.roles": {"admin", "user"},
+		"a.b":                {"literal"},
+		"missing":            nil,
+		"groups.nested":      nil,
+	}
+	for name, want := range tests {
+		if got := c.Strings(name); !slices.Equal(got, want) {
+			t.Errorf("Strings(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestNewProvider_NotConfigured(t *testing.T) {
+	t.Parallel()
+	if _, err := NewProvider(Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("got %v, want ErrNotConfigured", err)
+	}
+}
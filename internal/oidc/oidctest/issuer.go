@@ -0,0 +1,302 @@
+// Package oidctest runs a fake OpenID provider for tests.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json/v2"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ClientSecret is the secret the fake issuer expects from every client.
+const ClientSecret = "test-client-secret"
+
+// Issuer is a fake OpenID provider backed by httptest. It signs RS256 ID
+// tokens, checks PKCE and client credentials at the token endpoint, and
+// serves userinfo for the access tokens it hands out.
+type Issuer struct {
+	URL      string
+	ClientID string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	key      *rsa.PrivateKey
+	kid      string
+	grants   map[string]grant          // authorization code → pending grant
+	userinfo map[string]map[string]any // access token → claims
+	failInfo bool                      // userinfo answers with a server error
+}
+
+// grant is an authorization the fake user approved.
+type grant struct {
+	claims      map[string]any
+	nonce       string
+	challenge   string
+	redirectURI string
+}
+
+// NewIssuer starts a fake issuer that accepts clientID. It is shut down
+// when the test ends.
+func NewIssuer(t testing.TB, clientID string) *Issuer {
+	t.Helper()
+
+	iss := &Issuer{
+		ClientID: clientID,
+		grants:   make(map[string]grant),
+		userinfo: make(map[string]map[string]any),
+	}
+	iss.RotateKey(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", iss.handleDiscovery)
+	mux.HandleFunc("GET /jwks", iss.handleJWKS)
+	mux.HandleFunc("POST /token", iss.handleToken)
+	mux.HandleFunc("GET /userinfo", iss.handleUserinfo)
+	iss.server = httptest.NewServer(mux)
+	iss.URL = iss.server.URL
+	t.Cleanup(iss.server.Close)
+
+	return iss
+}
+
+// RotateKey replaces the signing key, as a provider does on key rollover.
+func (i *Issuer) RotateKey(t testing.TB) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate issuer key: %v", err)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.key = key
+	i.kid = rand.Text()
+}
+
+// Authorize plays the user signing in at the provider's login page: it
+// reads the authorization URL the relying party built, remembers the
+// nonce and PKCE challenge, and returns the query the provider would send
+// to the callback (code and state). claims become the ID token's claims
+// and must include "sub"; claims under the "userinfo" key are served only
+// from the userinfo endpoint.
+func (i *Issuer) Authorize(t testing.TB, authURL string, claims map[string]any) url.Values {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization URL: %v", err)
+	}
+	q := u.Query()
+	if q.Get("client_id") != i.ClientID || q.Get("response_type") != "code" {
+		t.Fatalf("unexpected authorization request: %s", authURL)
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		t.Fatalf("authorization request without PKCE: %s", authURL)
+	}
+
+	code := rand.Text()
+	i.mu.Lock()
+	i.grants[code] = grant{
+		claims:      claims,
+		nonce:       q.Get("nonce"),
+		challenge:   q.Get("code_challenge"),
+		redirectURI: q.Get("redirect_uri"),
+	}
+	i.mu.Unlock()
+
+	return url.Values{"code": {code}, "state": {q.Get("state")}}
+}
+
+// FailUserinfo makes the userinfo endpoint answer with a server error until
+// it is called again with false, as a provider does during an outage.
+func (i *Issuer) FailUserinfo(fail bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.failInfo = fail
+}
+
+// SignIDToken returns an ID token for claims, filling in iss, aud, iat and
+// exp unless they are set.
+func (i *Issuer) SignIDToken(t testing.TB, claims map[string]any) string {
+	t.Helper()
+	token, err := i.sign(claims)
+	if err != nil {
+		t.Fatalf("sign ID token: %v", err)
+	}
+	return token
+}
+
+func (i *Issuer) sign(claims map[string]any) (string, error) {
+	now := time.Now()
+	full := map[string]any{
+		"iss": i.URL,
+		"aud": i.ClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}
+	maps.Copy(full, claims)
+	delete(full, "userinfo")
+
+	i.mu.Lock()
+	key, kid := i.key, i.kid
+	i.mu.Unlock()
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(full)
+	if err != nil {
+		return "", err
+	}
+	signingInput := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + b64(sig), nil
+}
+
+func (i *Issuer) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                i.URL,
+		"authorization_endpoint":                i.URL + "/authorize",
+		"token_endpoint":                        i.URL + "/token",
+		"userinfo_endpoint":                     i.URL + "/userinfo",
+		"jwks_uri":                              i.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+	})
+}
+
+func (i *Issuer) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	i.mu.Lock()
+	pub, kid := i.key.PublicKey, i.kid
+	i.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": kid,
+			"n":   b64(pub.N.Bytes()),
+			"e":   b64(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (i *Issuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		oauthError(w, "invalid_request", err.Error())
+		return
+	}
+
+	clientID, secret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != i.ClientID || secret != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	i.mu.Lock()
+	g, found := i.grants[code]
+	delete(i.grants, code)
+	i.mu.Unlock()
+
+	switch {
+	case r.PostForm.Get("grant_type") != "authorization_code":
+		oauthError(w, "unsupported_grant_type", "")
+		return
+	case !found:
+		oauthError(w, "invalid_grant", "unknown or used code")
+		return
+	case r.PostForm.Get("redirect_uri") != g.redirectURI:
+		oauthError(w, "invalid_grant", "redirect_uri mismatch")
+		return
+	case pkceChallenge(r.PostForm.Get("code_verifier")) != g.challenge:
+		oauthError(w, "invalid_grant", "PKCE verification failed")
+		return
+	}
+
+	claims := maps.Clone(g.claims)
+	if g.nonce != "" {
+		claims["nonce"] = g.nonce
+	}
+	accessToken := rand.Text()
+	info := map[string]any{"sub": claims["sub"]}
+	if extra, ok := g.claims["userinfo"].(map[string]any); ok {
+		maps.Copy(info, extra)
+	}
+	i.mu.Lock()
+	i.userinfo[accessToken] = info
+	i.mu.Unlock()
+
+	idToken, err := i.sign(claims)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (i *Issuer) handleUserinfo(w http.ResponseWriter, r *http.Request) {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	i.mu.Lock()
+	info, ok := i.userinfo[token]
+	fail := i.failInfo
+	i.mu.Unlock()
+	if fail {
+		http.Error(w, "userinfo unavailable", http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return b64(sum[:])
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func oauthError(w http.ResponseWriter, code, desc string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": desc})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.MarshalWrite(w, v)
+}
@@ -0,0 +1,238 @@
+package podcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFeedTimeout = 30 * time.Second
+
+	// maxFeedSize bounds how much of a feed is read. Long-running shows
+	// with full show notes reach a few megabytes.
+	maxFeedSize = 32 << 20
+
+	// DefaultMaxDownloadSize bounds a single episode download. Multi-hour
+	// episodes at high bitrates stay well under it.
+	DefaultMaxDownloadSize = 2 << 30
+
+	// downloadStallTimeout cancels a download that receives no data for
+	// this long.
+	downloadStallTimeout = 2 * time.Minute
+)
+
+// Client errors.
+var (
+	ErrInvalidURL       = errors.New("invalid feed URL")
+	ErrNotModified      = errors.New("feed not modified")
+	ErrDownloadTooLarge = errors.New("episode exceeds maximum download size")
+	ErrDownloadStalled  = errors.New("episode download stalled")
+)
+
+// Client fetches feeds and episode audio over HTTP.
+type Client struct {
+	http            *http.Client
+	feedTimeout     time.Duration
+	maxDownloadSize int64
+	stallTimeout    time.Duration
+}
+
+// NewClient creates a client that refuses episodes larger than
+// maxDownloadSize bytes (DefaultMaxDownloadSize when zero or negative).
+// Feed requests time out after 30 seconds; downloads run until the context
+// is cancelled or no data arrives for two minutes.
+func NewClient(maxDownloadSize int64) *Client {
+	if maxDownloadSize <= 0 {
+		maxDownloadSize = DefaultMaxDownloadSize
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = defaultFeedTimeout
+	transport.IdleConnTimeout = 90 * time.Second
+	return &Client{
+		http:            &http.Client{Transport: transport},
+		feedTimeout:     defaultFeedTimeout,
+		maxDownloadSize: maxDownloadSize,
+		stallTimeout:    downloadStallTimeout,
+	}
+}
+
+// ValidateURL checks that raw is an absolute http(s) URL and returns it
+// trimmed.
+func ValidateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidURL, raw)
+	}
+	return raw, nil
+}
+
+// FetchResult is a fetched feed with the validators to send next time.
+type FetchResult struct {
+	Feed         *Feed
+	ETag         string
+	LastModified string
+}
+
+// Fetch downloads and parses the feed at feedURL. When etag or
+// lastModified from a previous fetch are given, the request is conditional
+// and an unchanged feed returns ErrNotModified.
+func (c *Client) Fetch(ctx context.Context, feedURL, etag, lastModified string) (*FetchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.feedTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	req.Header.Set("User-Agent", "ListenUp/1.0")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.5")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return nil, ErrNotModified
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetch feed: unexpected status %s", resp.Status)
+	}
+
+	feed, err := Parse(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, err
+	}
+	return &FetchResult{
+		Feed:         feed,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// Download saves the audio at rawURL to dest and returns its size. The
+// body is written to a temporary file beside dest and renamed into place,
+// so dest never holds a partial download. Downloads larger than the
+// client's maximum fail with ErrDownloadTooLarge.
+func (c *Client) Download(ctx context.Context, rawURL, dest string) (int64, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stall := time.AfterFunc(c.stallTimeout, func() { cancel(ErrDownloadStalled) })
+	defer stall.Stop()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	req.Header.Set("User-Agent", "ListenUp/1.0")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("download episode: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("download episode: unexpected status %s", resp.Status)
+	}
+	if resp.ContentLength > c.maxDownloadSize {
+		return 0, fmt.Errorf("download episode: %w (%d bytes)", ErrDownloadTooLarge, resp.ContentLength)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return 0, fmt.Errorf("create episode directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.part")
+	if err != nil {
+		return 0, fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	// Read one byte past the limit to tell an oversized body from one that
+	// is exactly the maximum.
+	body := &stallReader{r: io.LimitReader(resp.Body, c.maxDownloadSize+1), timer: stall, timeout: c.stallTimeout}
+	n, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if cause := context.Cause(ctx); err != nil && errors.Is(cause, ErrDownloadStalled) {
+		err = cause
+	}
+	if err != nil {
+		return 0, fmt.Errorf("write episode: %w", err)
+	}
+	if n > c.maxDownloadSize {
+		return 0, fmt.Errorf("download episode: %w", ErrDownloadTooLarge)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return 0, fmt.Errorf("move episode into place: %w", err)
+	}
+	return n, nil
+}
+
+// stallReader pushes back a stall timer each time data arrives.
+type stallReader struct {
+	r       io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (s *stallReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if n > 0 {
+		s.timer.Reset(s.timeout)
+	}
+	return n, err
+}
+
+// audioExtensions maps enclosure MIME types to file extensions.
+var audioExtensions = map[string]string{
+	"audio/mpeg":   ".mp3",
+	"audio/mp3":    ".mp3",
+	"audio/mp4":    ".m4a",
+	"audio/x-m4a":  ".m4a",
+	"audio/m4a":    ".m4a",
+	"audio/aac":    ".aac",
+	"audio/ogg":    ".ogg",
+	"audio/opus":   ".opus",
+	"audio/flac":   ".flac",
+	"audio/x-flac": ".flac",
+	"audio/wav":    ".wav",
+	"audio/x-wav":  ".wav",
+}
+
+// Extension returns the file extension for an enclosure, preferring the
+// one in its URL path and falling back to its MIME type, then ".mp3".
+func Extension(enclosureURL, mimeType string) string {
+	if u, err := url.Parse(enclosureURL); err == nil {
+		ext := strings.ToLower(path.Ext(u.Path))
+		for _, known := range audioExtensions {
+			if ext == known {
+				return ext
+			}
+		}
+	}
+	if mt, _, err := mime.ParseMediaType(mimeType); err == nil {
+		if ext, ok := audioExtensions[mt]; ok {
+			return ext
+		}
+	}
+	return ".mp3"
+}
@@ -0,0 +1,160 @@
+package podcast
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateURL(t *testing.T) {
+	t.Parallel()
+
+	got, err := ValidateURL("  https://example.com/feed.xml ")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/feed.xml", got)
+
+	for _, raw := range []string{"", "example.com/feed", "ftp://example.com/feed", "file:///etc/passwd", "http://"} {
+		_, err := ValidateURL(raw)
+		assert.True(t, errors.Is(err, ErrInvalidURL), "ValidateURL(%q) error = %v", raw, err)
+	}
+}
+
+func TestClient_FetchConditional(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Tue, 06 Oct 2026 09:00:00 GMT")
+		_, _ = w.Write([]byte(rssFeed))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(0)
+	res, err := c.Fetch(t.Context(), srv.URL, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "The Reading Room", res.Feed.Title)
+	assert.Equal(t, `"v1"`, res.ETag)
+	assert.Equal(t, "Tue, 06 Oct 2026 09:00:00 GMT", res.LastModified)
+
+	_, err = c.Fetch(t.Context(), srv.URL, res.ETag, res.LastModified)
+	assert.ErrorIs(t, err, ErrNotModified)
+	assert.Equal(t, 2, requests)
+}
+
+func TestClient_FetchErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/html" {
+			_, _ = w.Write([]byte("<html><body>Not here</body></html>"))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(0)
+	_, err := c.Fetch(t.Context(), srv.URL+"/missing", "", "")
+	assert.ErrorContains(t, err, "404")
+
+	_, err = c.Fetch(t.Context(), srv.URL+"/html", "", "")
+	assert.ErrorIs(t, err, ErrNotFeed)
+}
+
+func TestClient_Download(t *testing.T) {
+	t.Parallel()
+
+	audio := []byte("ID3 not really audio")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ep.mp3" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(audio)
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "Show", "2026-10-06 - Episode.mp3")
+
+	c := NewClient(0)
+	n, err := c.Download(t.Context(), srv.URL+"/ep.mp3", dest)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(audio)), n)
+	got, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, audio, got)
+
+	// A failed download leaves nothing behind.
+	_, err = c.Download(t.Context(), srv.URL+"/gone.mp3", filepath.Join(dir, "Show", "gone.mp3"))
+	require.Error(t, err)
+	entries, err := os.ReadDir(filepath.Join(dir, "Show"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestClient_DownloadLimits(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/big.mp3":
+			_, _ = w.Write(make([]byte, 64))
+		case "/chunked.mp3":
+			// Flushing before the body is done hides the length.
+			for range 4 {
+				_, _ = w.Write(make([]byte, 16))
+				w.(http.Flusher).Flush()
+			}
+		case "/stalled.mp3":
+			w.Header().Set("Content-Length", "16")
+			_, _ = w.Write(make([]byte, 8))
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	c := NewClient(32)
+	c.stallTimeout = 100 * time.Millisecond
+
+	_, err := c.Download(t.Context(), srv.URL+"/big.mp3", filepath.Join(dir, "big.mp3"))
+	assert.ErrorIs(t, err, ErrDownloadTooLarge)
+
+	_, err = c.Download(t.Context(), srv.URL+"/chunked.mp3", filepath.Join(dir, "chunked.mp3"))
+	assert.ErrorIs(t, err, ErrDownloadTooLarge)
+
+	_, err = c.Download(t.Context(), srv.URL+"/stalled.mp3", filepath.Join(dir, "stalled.mp3"))
+	assert.ErrorIs(t, err, ErrDownloadStalled)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "failed downloads leave nothing behind")
+}
+
+func TestExtension(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ".m4a", Extension("https://cdn.example.com/ep.M4A?token=1", "audio/mpeg"))
+	assert.Equal(t, ".ogg", Extension("https://cdn.example.com/download?id=1", "audio/ogg; codecs=vorbis"))
+	assert.Equal(t, ".mp3", Extension("https://cdn.example.com/ep.php", ""))
+}
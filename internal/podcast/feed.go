@@ -0,0 +1,410 @@
+// Package podcast reads podcast feeds and downloads their episodes.
+//
+// It is the reading side of podcasts; internal/feed writes them.
+package podcast
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFeed is returned when a document is neither RSS nor Atom.
+var ErrNotFeed = errors.New("not an RSS or Atom feed")
+
+const nsAtom = "http://www.w3.org/2005/Atom"
+
+// Feed is a parsed podcast feed.
+type Feed struct {
+	Title       string
+	Author      string
+	Description string
+	ImageURL    string
+	Link        string // Show website
+	Language    string
+	Explicit    bool
+	Items       []Item
+}
+
+// Item is a feed entry with playable audio. Entries without an enclosure
+// are dropped by Parse.
+type Item struct {
+	GUID            string // Falls back to the enclosure URL
+	Title           string
+	Description     string
+	Published       time.Time // Zero if the feed has no usable date
+	Duration        time.Duration
+	Season          int
+	Episode         int
+	EnclosureURL    string
+	EnclosureType   string
+	EnclosureLength int64
+}
+
+// Parse reads an RSS 2.0 (with iTunes extensions) or Atom feed.
+func Parse(r io.Reader) (*Feed, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read feed: %w", err)
+	}
+
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case root.Local == "rss":
+		var doc rssDoc
+		if err := newDecoder(data).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("parse rss: %w", err)
+		}
+		return doc.Channel.feed(), nil
+	case root.Local == "feed" && root.Space == nsAtom:
+		var doc atomFeed
+		if err := newDecoder(data).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("parse atom: %w", err)
+		}
+		return doc.feed(), nil
+	default:
+		return nil, fmt.Errorf("%w: root element <%s>", ErrNotFeed, root.Local)
+	}
+}
+
+// rootElement returns the name of the document's first element.
+func rootElement(data []byte) (xml.Name, error) {
+	d := newDecoder(data)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return xml.Name{}, fmt.Errorf("%w: %w", ErrNotFeed, err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name, nil
+		}
+	}
+}
+
+func newDecoder(data []byte) *xml.Decoder {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+	d.CharsetReader = charsetReader
+	return d
+}
+
+// charsetReader handles the single-byte encodings older feeds still
+// declare. Windows-1252 is read as Latin-1, which only differs in
+// punctuation.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1", "windows-1252", "cp1252":
+		return &latin1Reader{r: bufio.NewReader(input)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+}
+
+// latin1Reader converts Latin-1 bytes to UTF-8.
+type latin1Reader struct {
+	r       *bufio.Reader
+	pending []byte
+}
+
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(l.pending) > 0 {
+			c := copy(p[n:], l.pending)
+			l.pending = l.pending[c:]
+			n += c
+			continue
+		}
+		b, err := l.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if b < 0x80 {
+			p[n] = b
+			n++
+			continue
+		}
+		l.pending = []byte(string(rune(b)))
+	}
+	return n, nil
+}
+
+// === RSS ===
+
+type rssDoc struct {
+	Channel rssChannel `xml:"channel"`
+}
+
+// Namespaced fields come before their plain counterparts: encoding/xml
+// gives an element to the first field whose name matches, and a field
+// without a namespace matches any.
+type rssChannel struct {
+	ItunesAuthor   string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+	ItunesSummary  string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	ItunesImage    rssHref    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	ItunesExplicit string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit"`
+	ItunesOwner    rssOwner   `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd owner"`
+	AtomLinks      []atomLink `xml:"http://www.w3.org/2005/Atom link"`
+	Title          string     `xml:"title"`
+	Link           string     `xml:"link"`
+	Description    string     `xml:"description"`
+	Language       string     `xml:"language"`
+	ManagingEditor string     `xml:"managingEditor"`
+	Image          rssImage   `xml:"image"`
+	Items          []rssItem  `xml:"item"`
+}
+
+type rssHref struct {
+	Href string `xml:"href,attr"`
+}
+
+type rssOwner struct {
+	Name string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd name"`
+}
+
+type rssImage struct {
+	URL string `xml:"url"`
+}
+
+type rssItem struct {
+	ItunesTitle    string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd title"`
+	ItunesSummary  string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	ItunesDuration string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	ItunesSeason   string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd season"`
+	ItunesEpisode  string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode"`
+	Title          string       `xml:"title"`
+	Description    string       `xml:"description"`
+	PubDate        string       `xml:"pubDate"`
+	GUID           string       `xml:"guid"`
+	Enclosure      rssEnclosure `xml:"enclosure"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+func (c *rssChannel) feed() *Feed {
+	f := &Feed{
+		Title:       clean(c.Title),
+		Author:      firstNonEmpty(c.ItunesAuthor, c.ItunesOwner.Name, c.ManagingEditor),
+		Description: firstNonEmpty(c.Description, c.ItunesSummary),
+		ImageURL:    firstNonEmpty(c.ItunesImage.Href, c.Image.URL),
+		Link:        clean(c.Link),
+		Language:    clean(c.Language),
+		Explicit:    parseExplicit(c.ItunesExplicit),
+	}
+	if f.Link == "" {
+		for _, l := range c.AtomLinks {
+			if l.Rel == "alternate" {
+				f.Link = l.Href
+			}
+		}
+	}
+
+	for _, it := range c.Items {
+		if strings.TrimSpace(it.Enclosure.URL) == "" {
+			continue
+		}
+		item := Item{
+			GUID:            firstNonEmpty(it.GUID, it.Enclosure.URL),
+			Title:           firstNonEmpty(it.Title, it.ItunesTitle),
+			Description:     firstNonEmpty(it.Description, it.ItunesSummary),
+			Published:       parseDate(it.PubDate),
+			Duration:        ParseDuration(it.ItunesDuration),
+			Season:          atoi(it.ItunesSeason),
+			Episode:         atoi(it.ItunesEpisode),
+			EnclosureURL:    strings.TrimSpace(it.Enclosure.URL),
+			EnclosureType:   clean(it.Enclosure.Type),
+			EnclosureLength: atoi64(it.Enclosure.Length),
+		}
+		f.Items = append(f.Items, item)
+	}
+	return f
+}
+
+// === Atom ===
+
+type atomFeed struct {
+	Title    string      `xml:"http://www.w3.org/2005/Atom title"`
+	Subtitle string      `xml:"http://www.w3.org/2005/Atom subtitle"`
+	Author   atomPerson  `xml:"http://www.w3.org/2005/Atom author"`
+	Logo     string      `xml:"http://www.w3.org/2005/Atom logo"`
+	Icon     string      `xml:"http://www.w3.org/2005/Atom icon"`
+	Links    []atomLink  `xml:"http://www.w3.org/2005/Atom link"`
+	Lang     string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Entries  []atomEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"http://www.w3.org/2005/Atom name"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type atomEntry struct {
+	ID             string     `xml:"http://www.w3.org/2005/Atom id"`
+	Title          string     `xml:"http://www.w3.org/2005/Atom title"`
+	Summary        string     `xml:"http://www.w3.org/2005/Atom summary"`
+	Content        string     `xml:"http://www.w3.org/2005/Atom content"`
+	Published      string     `xml:"http://www.w3.org/2005/Atom published"`
+	Updated        string     `xml:"http://www.w3.org/2005/Atom updated"`
+	Links          []atomLink `xml:"http://www.w3.org/2005/Atom link"`
+	ItunesDuration string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+}
+
+func (a *atomFeed) feed() *Feed {
+	f := &Feed{
+		Title:       clean(a.Title),
+		Author:      clean(a.Author.Name),
+		Description: clean(a.Subtitle),
+		ImageURL:    firstNonEmpty(a.Logo, a.Icon),
+		Language:    clean(a.Lang),
+	}
+	for _, l := range a.Links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			f.Link = l.Href
+			break
+		}
+	}
+
+	for _, e := range a.Entries {
+		var enclosure *atomLink
+		for i := range e.Links {
+			if e.Links[i].Rel == "enclosure" && e.Links[i].Href != "" {
+				enclosure = &e.Links[i]
+				break
+			}
+		}
+		if enclosure == nil {
+			continue
+		}
+		f.Items = append(f.Items, Item{
+			GUID:            firstNonEmpty(e.ID, enclosure.Href),
+			Title:           clean(e.Title),
+			Description:     firstNonEmpty(e.Summary, e.Content),
+			Published:       parseDate(firstNonEmpty(e.Published, e.Updated)),
+			Duration:        ParseDuration(e.ItunesDuration),
+			EnclosureURL:    strings.TrimSpace(enclosure.Href),
+			EnclosureType:   clean(enclosure.Type),
+			EnclosureLength: atoi64(enclosure.Length),
+		})
+	}
+	return f
+}
+
+// === Field parsing ===
+
+// dateLayouts are the pubDate formats seen in the wild, RFC 1123 first.
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.DateOnly,
+}
+
+// parseDate parses an RSS or Atom date, returning zero if no layout fits.
+func parseDate(s string) time.Time {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ParseDuration parses an itunes:duration: seconds ("3600" or "3600.5"),
+// "MM:SS" or "HH:MM:SS". Unparseable values return zero.
+func ParseDuration(s string) time.Duration {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+	if !strings.Contains(s, ":") {
+		secs, err := strconv.ParseFloat(s, 64)
+		if err != nil || secs < 0 {
+			return 0
+		}
+		return time.Duration(secs * float64(time.Second))
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0
+	}
+	var total float64
+	for _, p := range parts {
+		n, err := strconv.ParseFloat(p, 64)
+		if err != nil || n < 0 {
+			return 0
+		}
+		total = total*60 + n
+	}
+	return time.Duration(total * float64(time.Second))
+}
+
+func parseExplicit(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "yes", "true", "explicit":
+		return true
+	}
+	return false
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(s))
+	return n
+}
+
+func atoi64(s string) int64 {
+	n, _ := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	return n
+}
+
+func clean(s string) string {
+	return strings.TrimSpace(s)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
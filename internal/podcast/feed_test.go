@@ -0,0 +1,165 @@
+package podcast
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:atom="http://www.w3.org/2005/Atom">
+  <channel>
+    <atom:link href="https://example.com/feed.xml" rel="self" type="application/rss+xml"/>
+    <title>The Reading Room</title>
+    <link>https://example.com</link>
+    <description>Conversations about books.</description>
+    <language>en-us</language>
+    <itunes:author>Jane Host</itunes:author>
+    <itunes:image href="https://example.com/art.jpg"/>
+    <itunes:explicit>yes</itunes:explicit>
+    <image><url>https://example.com/small.jpg</url></image>
+    <item>
+      <title>Episode 2: Dune</title>
+      <itunes:title>Dune</itunes:title>
+      <description>&lt;p&gt;Spice &amp;amp; sand.&lt;/p&gt;</description>
+      <pubDate>Tue, 6 Oct 2026 09:00:00 GMT</pubDate>
+      <guid isPermaLink="false">rr-2</guid>
+      <enclosure url="https://cdn.example.com/rr-2.mp3" length="48000000" type="audio/mpeg"/>
+      <itunes:duration>1:02:03</itunes:duration>
+      <itunes:season>1</itunes:season>
+      <itunes:episode>2</itunes:episode>
+    </item>
+    <item>
+      <title>Trailer</title>
+      <pubDate>Mon, 28 Sep 2026 09:00:00 +0000</pubDate>
+      <enclosure url="https://cdn.example.com/trailer.m4a" type="audio/x-m4a"/>
+      <itunes:duration>95</itunes:duration>
+    </item>
+    <item>
+      <title>Blog post without audio</title>
+    </item>
+  </channel>
+</rss>`
+
+func TestParse_RSS(t *testing.T) {
+	t.Parallel()
+
+	feed, err := Parse(strings.NewReader(rssFeed))
+	require.NoError(t, err)
+
+	assert.Equal(t, "The Reading Room", feed.Title)
+	assert.Equal(t, "Jane Host", feed.Author)
+	assert.Equal(t, "https://example.com", feed.Link, "atom:link must not replace <link>")
+	assert.Equal(t, "https://example.com/art.jpg", feed.ImageURL)
+	assert.Equal(t, "en-us", feed.Language)
+	assert.True(t, feed.Explicit)
+
+	require.Len(t, feed.Items, 2, "items without an enclosure are dropped")
+	ep := feed.Items[0]
+	assert.Equal(t, "rr-2", ep.GUID)
+	assert.Equal(t, "Episode 2: Dune", ep.Title, "itunes:title must not replace <title>")
+	assert.Equal(t, "<p>Spice &amp; sand.</p>", ep.Description)
+	assert.Equal(t, time.Date(2026, 10, 6, 9, 0, 0, 0, time.UTC), ep.Published.UTC())
+	assert.Equal(t, time.Hour+2*time.Minute+3*time.Second, ep.Duration)
+	assert.Equal(t, 1, ep.Season)
+	assert.Equal(t, 2, ep.Episode)
+	assert.Equal(t, "https://cdn.example.com/rr-2.mp3", ep.EnclosureURL)
+	assert.Equal(t, "audio/mpeg", ep.EnclosureType)
+	assert.Equal(t, int64(48000000), ep.EnclosureLength)
+
+	trailer := feed.Items[1]
+	assert.Equal(t, "https://cdn.example.com/trailer.m4a", trailer.GUID, "GUID falls back to the enclosure URL")
+	assert.Equal(t, 95*time.Second, trailer.Duration)
+}
+
+func TestParse_Atom(t *testing.T) {
+	t.Parallel()
+
+	const atom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="de">
+  <title>Hörbuch Hour</title>
+  <subtitle>Weekly</subtitle>
+  <author><name>Max</name></author>
+  <logo>https://example.de/logo.png</logo>
+  <link rel="self" href="https://example.de/atom.xml"/>
+  <link rel="alternate" href="https://example.de"/>
+  <entry>
+    <id>urn:uuid:1</id>
+    <title>Folge 1</title>
+    <summary>Intro</summary>
+    <updated>2026-10-01T10:00:00+02:00</updated>
+    <link rel="alternate" href="https://example.de/1"/>
+    <link rel="enclosure" href="https://example.de/1.ogg" type="audio/ogg" length="1234"/>
+  </entry>
+</feed>`
+
+	feed, err := Parse(strings.NewReader(atom))
+	require.NoError(t, err)
+	assert.Equal(t, "Hörbuch Hour", feed.Title)
+	assert.Equal(t, "Max", feed.Author)
+	assert.Equal(t, "https://example.de", feed.Link)
+	assert.Equal(t, "de", feed.Language)
+	require.Len(t, feed.Items, 1)
+	assert.Equal(t, "urn:uuid:1", feed.Items[0].GUID)
+	assert.Equal(t, "https://example.de/1.ogg", feed.Items[0].EnclosureURL)
+	assert.Equal(t, int64(1234), feed.Items[0].EnclosureLength)
+	assert.Equal(t, time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), feed.Items[0].Published.UTC())
+}
+
+func TestParse_Latin1(t *testing.T) {
+	t.Parallel()
+
+	doc := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><rss><channel><title>Caf\xe9</title></channel></rss>"
+	feed, err := Parse(strings.NewReader(doc))
+	require.NoError(t, err)
+	assert.Equal(t, "Café", feed.Title)
+}
+
+func TestParse_NotAFeed(t *testing.T) {
+	t.Parallel()
+
+	for _, doc := range []string{"<html><body>hi</body></html>", "", "not xml"} {
+		_, err := Parse(strings.NewReader(doc))
+		assert.True(t, errors.Is(err, ErrNotFeed), "Parse(%q) error = %v", doc, err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]time.Duration{
+		"3600":     time.Hour,
+		"90.5":     90*time.Second + 500*time.Millisecond,
+		"05:30":    5*time.Minute + 30*time.Second,
+		"1:00:00":  time.Hour,
+		"":         0,
+		"abc":      0,
+		"1:2:3:4":  0,
+		"-5":       0,
+		" 12:00 ":  12 * time.Minute,
+		"00:00:07": 7 * time.Second,
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, ParseDuration(in), "ParseDuration(%q)", in)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+
+	want := time.Date(2026, 10, 6, 9, 0, 0, 0, time.UTC)
+	for _, in := range []string{
+		"Tue, 06 Oct 2026 09:00:00 +0000",
+		"Tue, 6 Oct 2026 09:00:00 GMT",
+		"Tue, 06 Oct 2026 11:00 +0200",
+		"6 Oct 2026 09:00:00 +0000",
+		"2026-10-06T09:00:00Z",
+	} {
+		assert.True(t, want.Equal(parseDate(in)), "parseDate(%q) = %v", in, parseDate(in))
+	}
+	assert.True(t, parseDate("sometime last week").IsZero())
+}
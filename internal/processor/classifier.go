@@ -18,6 +18,8 @@ const (
 	FileTypeCover
 	// FileTypeMetadata represents metadata files (.nfo, .txt, .json).
 	FileTypeMetadata
+	// FileTypeEbook represents companion ebook files (.epub, .pdf).
+	FileTypeEbook
 	// FileTypeIgnored represents files that should be ignored (.cue, .log, temp files, hidden files).
 	FileTypeIgnored
 )
@@ -31,6 +33,8 @@ func (ft FileType) String() string {
 		return "cover"
 	case FileTypeMetadata:
 		return "metadata"
+	case FileTypeEbook:
+		return "ebook"
 	case FileTypeIgnored:
 		return "ignored"
 	default:
@@ -46,6 +50,7 @@ func (ft FileType) String() string {
 //   - Audio: .mp3, .m4b, .m4a, .flac, .opus, .ogg, .aac, .wma, .wav.
 //   - Cover: .jpg, .jpeg, .png, .webp.
 //   - Metadata: .nfo, .txt, .json.
+//   - Ebook: .epub, .pdf.
 //   - Ignored: Everything else (including .cue, .log, temp files, hidden files).
 //
 // The function is case-insensitive and handles paths with various separators.
@@ -69,6 +74,11 @@ func classifyFile(path string) FileType {
 		return FileTypeCover
 	}
 
+	// Check companion ebooks.
+	if scanner.IsEbookExt(ext) {
+		return FileTypeEbook
+	}
+
 	// Check metadata files.
 	switch ext {
 	case ".nfo", ".txt", ".json":
@@ -127,6 +127,18 @@ func TestClassifyFile(t *testing.T) {
 			expected: FileTypeMetadata,
 		},
 
+		// Ebook files.
+		{
+			name:     "EPUB ebook",
+			path:     "/library/Book/Book.epub",
+			expected: FileTypeEbook,
+		},
+		{
+			name:     "PDF ebook with uppercase extension",
+			path:     "/library/Book/Book.PDF",
+			expected: FileTypeEbook,
+		},
+
 		// Ignored files.
 		{
 			name:     "CUE file (ignored)",
@@ -150,7 +162,7 @@ func TestClassifyFile(t *testing.T) {
 		},
 		{
 			name:     "Unknown extension (ignored)",
-			path:     "/library/document.pdf",
+			path:     "/library/document.docx",
 			expected: FileTypeIgnored,
 		},
 		{
@@ -191,6 +203,7 @@ func TestFileType_String(t *testing.T) {
 		{fileType: FileTypeAudio, expected: "audio"},
 		{fileType: FileTypeCover, expected: "cover"},
 		{fileType: FileTypeMetadata, expected: "metadata"},
+		{fileType: FileTypeEbook, expected: "ebook"},
 		{fileType: FileTypeIgnored, expected: "ignored"},
 	}
 
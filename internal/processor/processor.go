@@ -31,7 +31,7 @@ type BookStore interface {
 
 	// Inbox workflow methods.
 	GetServerSettings(ctx context.Context) (*domain.ServerSettings, error)
-	GetDefaultLibrary(ctx context.Context) (*domain.Library, error)
+	GetLibraryForPath(ctx context.Context, path string) (*domain.Library, error)
 	GetInboxForLibrary(ctx context.Context, libraryID string) (*domain.Collection, error)
 	AdminAddBookToCollection(ctx context.Context, bookID, collectionID string) error
 }
@@ -147,6 +147,8 @@ func (ep *EventProcessor) handleFileChange(ctx context.Context, bookFolder, file
 		return ep.handleCoverFile(ctx, bookFolder, filePath)
 	case FileTypeMetadata:
 		return ep.handleMetadataFile(ctx, bookFolder, filePath)
+	case FileTypeEbook:
+		return ep.handleEbookFile(ctx, bookFolder, filePath)
 	case FileTypeIgnored:
 		return nil // Ignored files are skipped
 	default:
@@ -185,6 +187,12 @@ func (ep *EventProcessor) handleAudioFile(ctx context.Context, bookFolder, fileP
 	existingBook, err := ep.store.GetBookByPath(ctx, item.Path)
 	if err != nil {
 		// Book doesn't exist - create new one
+		library, libErr := ep.store.GetLibraryForPath(ctx, item.Path)
+		if libErr == nil && library.IsPodcast() {
+			// Downloaded podcast episodes aren't books.
+			return nil
+		}
+
 		book, convertErr := scanner.ConvertToBook(ctx, item, ep.store)
 		if convertErr != nil {
 			ep.logger.Error("failed to convert scanned item to book",
@@ -194,6 +202,15 @@ func (ep *EventProcessor) handleAudioFile(ctx context.Context, bookFolder, fileP
 			return fmt.Errorf("convert to book: %w", convertErr)
 		}
 
+		if libErr != nil {
+			ep.logger.Warn("no library contains book folder",
+				"folder", bookFolder,
+				"error", libErr,
+			)
+		} else {
+			book.LibraryID = library.ID
+		}
+
 		if createErr := ep.store.CreateBook(ctx, book); createErr != nil {
 			ep.logger.Error("failed to create book",
 				"folder", bookFolder,
@@ -251,7 +268,7 @@ func (ep *EventProcessor) handleAudioFile(ctx context.Context, bookFolder, fileP
 		}
 
 		// Add to inbox if workflow is enabled
-		ep.addBookToInboxIfEnabled(ctx, book)
+		ep.addBookToInboxIfEnabled(ctx, book, library)
 	} else {
 		// Book exists - update it with new scan data
 		if updateErr := scanner.UpdateBookFromScan(ctx, existingBook, item, ep.store); updateErr != nil {
@@ -402,7 +419,7 @@ func (ep *EventProcessor) handleMetadataFile(ctx context.Context, bookFolder, fi
 		return nil
 	}
 
-	// Update book from scan data (handles metadata from item).
+	// Update structural data from the scan.
 	if updateErr := scanner.UpdateBookFromScan(ctx, existingBook, item, ep.store); updateErr != nil {
 		ep.logger.Error("failed to update book from scan",
 			"folder", bookFolder,
@@ -412,6 +429,18 @@ func (ep *EventProcessor) handleMetadataFile(ctx context.Context, bookFolder, fi
 		return fmt.Errorf("update book: %w", updateErr)
 	}
 
+	// Re-apply the sidecar fields. A changed metadata.json/.opf/.nfo is an
+	// explicit curation step, so those fields override what's in the database.
+	applied, applyErr := scanner.ApplySidecarsToBook(ctx, existingBook, item, ep.store)
+	if applyErr != nil {
+		ep.logger.Error("failed to apply sidecar metadata",
+			"folder", bookFolder,
+			"book_id", existingBook.ID,
+			"error", applyErr,
+		)
+		return fmt.Errorf("apply sidecar metadata: %w", applyErr)
+	}
+
 	if saveErr := ep.store.UpdateBook(ctx, existingBook); saveErr != nil {
 		ep.logger.Error("failed to save updated book",
 			"folder", bookFolder,
@@ -425,6 +454,62 @@ func (ep *EventProcessor) handleMetadataFile(ctx context.Context, bookFolder, fi
 		"book_id", existingBook.ID,
 		"title", existingBook.Title,
 		"path", existingBook.Path,
+		"sidecar_applied", applied,
+	)
+
+	return nil
+}
+
+// handleEbookFile handles added, modified or removed companion ebooks.
+// Only the book's ebook list changes; the EPUB's metadata is the weakest
+// sidecar and never overrides what is already in the database.
+func (ep *EventProcessor) handleEbookFile(ctx context.Context, bookFolder, filePath string) error {
+	ep.logger.Info("handling ebook file",
+		"folder", bookFolder,
+		"file", filePath,
+	)
+
+	item, err := ep.scanner.ScanFolder(ctx, bookFolder, scanner.ScanOptions{
+		Workers: 0, // Use default (runtime.NumCPU)
+	})
+	if err != nil {
+		ep.logger.Error("failed to scan folder",
+			"folder", bookFolder,
+			"error", err,
+		)
+		return fmt.Errorf("scan folder: %w", err)
+	}
+
+	existingBook, err := ep.store.GetBookByPath(ctx, bookFolder)
+	if err != nil {
+		ep.logger.Debug("no existing book found for folder, skipping ebook update",
+			"folder", bookFolder,
+			"error", err,
+		)
+		return nil
+	}
+
+	if updateErr := scanner.UpdateBookFromScan(ctx, existingBook, item, ep.store); updateErr != nil {
+		ep.logger.Error("failed to update book from scan",
+			"folder", bookFolder,
+			"book_id", existingBook.ID,
+			"error", updateErr,
+		)
+		return fmt.Errorf("update book: %w", updateErr)
+	}
+
+	if saveErr := ep.store.UpdateBook(ctx, existingBook); saveErr != nil {
+		ep.logger.Error("failed to save updated book",
+			"folder", bookFolder,
+			"book_id", existingBook.ID,
+			"error", saveErr,
+		)
+		return fmt.Errorf("save book: %w", saveErr)
+	}
+
+	ep.logger.Info("updated book ebooks",
+		"book_id", existingBook.ID,
+		"ebooks", len(existingBook.EbookFiles),
 	)
 
 	return nil
@@ -566,21 +651,17 @@ func (ep *EventProcessor) getFolderLock(folderPath string) *sync.Mutex {
 	return actual
 }
 
-// addBookToInboxIfEnabled adds a newly created book to the inbox if the workflow is enabled.
+// addBookToInboxIfEnabled adds a newly created book to its library's inbox if
+// the workflow is enabled and the library doesn't skip it.
 // This allows admins to review new books before they become visible to users.
-func (ep *EventProcessor) addBookToInboxIfEnabled(ctx context.Context, book *domain.Book) {
-	// Check if inbox workflow is enabled
-	settings, err := ep.store.GetServerSettings(ctx)
-	if err != nil || !settings.InboxEnabled {
+func (ep *EventProcessor) addBookToInboxIfEnabled(ctx context.Context, book *domain.Book, library *domain.Library) {
+	if library == nil || library.SkipInbox {
 		return
 	}
 
-	// Get the inbox collection for the default library
-	library, err := ep.store.GetDefaultLibrary(ctx)
-	if err != nil || library == nil {
-		ep.logger.Warn("failed to get default library for inbox",
-			"error", err,
-		)
+	// Check if inbox workflow is enabled
+	settings, err := ep.store.GetServerSettings(ctx)
+	if err != nil || !settings.InboxEnabled {
 		return
 	}
 
@@ -25,6 +25,7 @@ type mockBookStore struct {
 	books           map[string]*domain.Book // path -> book
 	contributors    map[string]*domain.Contributor
 	series          map[string]*domain.Series
+	libraries       []*domain.Library
 	createBookCalls int
 	updateBookCalls int
 }
@@ -155,7 +156,10 @@ func (m *mockBookStore) GetServerSettings(_ context.Context) (*domain.ServerSett
 	return nil, store.ErrNotFound // Inbox disabled in tests
 }
 
-func (m *mockBookStore) GetDefaultLibrary(_ context.Context) (*domain.Library, error) {
+func (m *mockBookStore) GetLibraryForPath(_ context.Context, path string) (*domain.Library, error) {
+	if lib := domain.LibraryForPath(m.libraries, path); lib != nil {
+		return lib, nil
+	}
 	return nil, store.ErrNotFound
 }
 
@@ -211,6 +215,50 @@ func TestEventProcessor_ProcessEvent_AudioFile(t *testing.T) {
 	}
 }
 
+// TestEventProcessor_ProcessEvent_AssignsLibrary tests that new books join the
+// library whose scan path contains them.
+func TestEventProcessor_ProcessEvent_AssignsLibrary(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	adultsRoot := filepath.Join(tempDir, "adults")
+	kidsRoot := filepath.Join(tempDir, "kids")
+	bookFolder := filepath.Join(kidsRoot, "Author", "Book")
+	if err := os.MkdirAll(bookFolder, 0o755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+
+	audioFile := filepath.Join(bookFolder, "chapter01.mp3")
+	if err := os.WriteFile(audioFile, []byte("fake audio data"), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	mockStore := newMockBookStore()
+	mockStore.libraries = []*domain.Library{
+		{ID: "lib-adults", ScanPaths: []string{adultsRoot}},
+		{ID: "lib-kids", ScanPaths: []string{kidsRoot}},
+	}
+	scnr := scanner.NewScanner(nil, store.NewNoopEmitter(), nil, asyncindexer.New(store.NewNoopSearchIndexer(), logger), logger)
+	processor := NewEventProcessor(scnr, mockStore, nil, nil, logger)
+
+	ctx := context.Background()
+	if err := processor.ProcessEvent(ctx, watcher.Event{Type: watcher.EventAdded, Path: audioFile}); err != nil {
+		t.Fatalf("ProcessEvent() failed: %v", err)
+	}
+
+	mockStore.mu.Lock()
+	defer mockStore.mu.Unlock()
+	book, ok := mockStore.books[bookFolder]
+	if !ok {
+		t.Fatalf("expected book at %s to be created", bookFolder)
+	}
+	if book.LibraryID != "lib-kids" {
+		t.Errorf("LibraryID = %q, want lib-kids", book.LibraryID)
+	}
+}
+
 // TestEventProcessor_ProcessEvent_CoverFile tests processing a cover file event.
 func TestEventProcessor_ProcessEvent_CoverFile(t *testing.T) {
 	t.Parallel()
@@ -199,6 +199,9 @@ func (a *Analyzer) AnalyzeItems(ctx context.Context, items []LibraryItemData) ([
 			}
 		}
 
+		// Layer sidecar files (metadata.json, .opf, .nfo, ...) over the tag data.
+		item.Metadata = applySidecarMetadata(item.Metadata, item.MetadataFiles, a.logger)
+
 		results[i] = *item
 
 		// Check for context cancellation.
@@ -212,6 +215,38 @@ func (a *Analyzer) AnalyzeItems(ctx context.Context, items []LibraryItemData) ([
 	return results, nil
 }
 
+// EmbeddedChapters re-reads the chapter markers embedded in the given audio files,
+// using the same single/multi-file rules as AnalyzeItems. Paths must be in play order.
+func (a *Analyzer) EmbeddedChapters(ctx context.Context, paths []string) ([]Chapter, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	item := LibraryItemData{AudioFiles: make([]AudioFileData, len(paths))}
+	for i, p := range paths {
+		item.AudioFiles[i] = AudioFileData{Path: p}
+	}
+
+	var (
+		metadata *audio.Metadata
+		err      error
+	)
+	if classifyItem(item) == ItemTypeMultiFile {
+		metadata, err = a.parser.ParseMultiFile(ctx, paths)
+	} else {
+		metadata, err = a.parser.Parse(ctx, paths[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	converted := convertMetadata(metadata)
+	if converted == nil {
+		return nil, nil
+	}
+	return converted.Chapters, nil
+}
+
 // classifyItem determines if an item is single-file or multi-file.
 func classifyItem(item LibraryItemData) ItemType {
 	audioCount := len(item.AudioFiles)
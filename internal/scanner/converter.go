@@ -80,6 +80,8 @@ func ConvertToBook(ctx context.Context, item *LibraryItemData, store Storer) (*d
 	// Otherwise we get a book that reads like the audio equivalent of House of Leaves.
 	sortAudioFilesByFilename(book.AudioFiles)
 
+	book.EbookFiles = convertEbookFiles(item.EbookFiles)
+
 	// Conver cover image (use first image if there are multiple).
 	if len(item.ImageFiles) > 0 {
 		img := item.ImageFiles[0]
@@ -207,6 +209,31 @@ func sortAudioFilesByFilename(files []domain.AudioFileInfo) {
 	})
 }
 
+// convertEbookFiles converts scanned companion ebooks, sorted by filename.
+// Returns nil when there are none.
+func convertEbookFiles(files []EbookFileData) []domain.EbookFileInfo {
+	if len(files) == 0 {
+		return nil
+	}
+
+	result := make([]domain.EbookFileInfo, 0, len(files))
+	for _, f := range files {
+		result = append(result, domain.EbookFileInfo{
+			ID:       domain.GenerateEbookFileID(f.Inode),
+			Path:     f.Path,
+			Filename: f.Filename,
+			Format:   strings.TrimPrefix(strings.ToLower(f.Ext), "."),
+			Size:     f.Size,
+			Inode:    f.Inode,
+			ModTime:  f.ModTime.UnixMilli(),
+		})
+	}
+	slices.SortFunc(result, func(a, b domain.EbookFileInfo) int {
+		return compareFilenames(a.Filename, b.Filename)
+	})
+	return result
+}
+
 // compareFilenames compares two filenames with natural/numeric-aware sorting.
 // Examples:
 //
@@ -493,7 +520,8 @@ func extractGenres(ctx context.Context, rawGenres []string, bookID string, store
 // UpdateBookFromScan updates an existing book with new scan data.
 // IMPORTANT: This preserves all user-editable metadata (title, description, contributors, etc.)
 // and only updates structural data that must stay in sync with the filesystem:
-//   - AudioFiles (files may have been added/removed/renamed)
+//   - AudioFiles (files may have been added/removed/renamed; loudness is kept for unchanged files)
+//   - EbookFiles (companion ebooks next to the audio)
 //   - TotalDuration, TotalSize (recalculated from audio files)
 //   - Path (if folder was moved)
 //   - Chapters (derived from audio files)
@@ -510,6 +538,14 @@ func UpdateBookFromScan(ctx context.Context, existingBook *domain.Book, item *Li
 	// Update path if the folder was moved
 	existingBook.Path = item.Path
 
+	// Loudness measurements outlive the rebuild while the file is unchanged
+	measured := make(map[string]domain.AudioFileInfo, len(existingBook.AudioFiles))
+	for _, af := range existingBook.AudioFiles {
+		if af.Loudness != nil {
+			measured[af.ID] = af
+		}
+	}
+
 	// Rebuild audio files from fresh scan
 	existingBook.AudioFiles = make([]domain.AudioFileInfo, 0, len(item.AudioFiles))
 	existingBook.TotalDuration = 0
@@ -533,6 +569,10 @@ func UpdateBookFromScan(ctx context.Context, existingBook *domain.Book, item *Li
 			existingBook.TotalDuration += audioFile.Duration
 		}
 
+		if prev, ok := measured[audioFile.ID]; ok && prev.Size == audioFile.Size && prev.ModTime == audioFile.ModTime {
+			audioFile.Loudness = prev.Loudness
+		}
+
 		existingBook.TotalSize += af.Size
 		existingBook.AudioFiles = append(existingBook.AudioFiles, audioFile)
 	}
@@ -540,6 +580,9 @@ func UpdateBookFromScan(ctx context.Context, existingBook *domain.Book, item *Li
 	// Sort audio files for consistent ordering
 	sortAudioFilesByFilename(existingBook.AudioFiles)
 
+	// Companion ebooks are files too, so they follow the disk
+	existingBook.EbookFiles = convertEbookFiles(item.EbookFiles)
+
 	// Update chapters if metadata has them (chapters are structural, derived from audio)
 	if item.Metadata != nil && len(item.Metadata.Chapters) > 0 {
 		existingBook.Chapters = convertChapters(item.Metadata.Chapters, existingBook.AudioFiles)
@@ -573,3 +616,74 @@ func UpdateBookFromScan(ctx context.Context, existingBook *domain.Book, item *Li
 
 	return nil
 }
+
+// ApplySidecarsToBook copies the fields supplied by sidecar files (those recorded in
+// item.Metadata.Sources) onto an existing book. It is used when a sidecar changes on
+// disk: unlike a rescan, which treats the database as truth, editing metadata.json or
+// an .opf is an explicit curation step for the fields it contains.
+// Fields that came only from embedded tags are left untouched.
+// Returns true if any field was applied.
+func ApplySidecarsToBook(ctx context.Context, book *domain.Book, item *LibraryItemData, store Storer) (bool, error) {
+	meta := item.Metadata
+	if meta == nil || len(meta.Sources) == 0 {
+		return false, nil
+	}
+
+	changed := false
+	setString := func(field string, target *string, value string) {
+		if _, ok := meta.Sources[field]; ok && *target != value {
+			*target = value
+			changed = true
+		}
+	}
+
+	setString("title", &book.Title, meta.Title)
+	setString("subtitle", &book.Subtitle, meta.Subtitle)
+	setString("description", &book.Description, meta.Description)
+	setString("publisher", &book.Publisher, meta.Publisher)
+	setString("publish_year", &book.PublishYear, meta.PublishYear)
+	setString("language", &book.Language, meta.Language)
+	setString("isbn", &book.ISBN, meta.ISBN)
+	setString("asin", &book.ASIN, meta.ASIN)
+
+	if _, ok := meta.Sources["abridged"]; ok && book.Abridged != meta.Abridged {
+		book.Abridged = meta.Abridged
+		changed = true
+	}
+
+	// Contributors are rebuilt from the merged metadata so tag-derived authors
+	// survive when only reader.txt changed (and vice versa).
+	_, hasAuthors := meta.Sources["authors"]
+	_, hasNarrators := meta.Sources["narrators"]
+	if hasAuthors || hasNarrators {
+		contributors, err := extractContributors(ctx, meta, store)
+		if err != nil {
+			return changed, fmt.Errorf("extract contributors: %w", err)
+		}
+		book.Contributors = contributors
+		changed = true
+	}
+
+	if _, ok := meta.Sources["series"]; ok {
+		bookSeries, err := extractSeries(ctx, meta, store)
+		if err != nil {
+			return changed, fmt.Errorf("extract series: %w", err)
+		}
+		book.Series = bookSeries
+		changed = true
+	}
+
+	if _, ok := meta.Sources["genres"]; ok {
+		genreIDs, _ := extractGenres(ctx, meta.Genres, book.ID, store)
+		if len(genreIDs) > 0 {
+			book.GenreIDs = genreIDs
+			changed = true
+		}
+	}
+
+	if changed {
+		book.UpdatedAt = time.Now()
+	}
+
+	return changed, nil
+}
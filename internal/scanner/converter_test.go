@@ -496,6 +496,35 @@ func TestConvertToBook_MultipleCoverImages(t *testing.T) {
 	assert.Equal(t, "cover.jpg", book.CoverImage.Filename) // Only first image used
 }
 
+// TestConvertToBook_EbookFiles tests that companion ebooks are attached in filename order.
+func TestConvertToBook_EbookFiles(t *testing.T) {
+	t.Parallel()
+	item := &LibraryItemData{
+		Path: "/audiobooks/test",
+		AudioFiles: []AudioFileData{
+			{Path: "/audiobooks/test/file.mp3", Filename: "file.mp3", Ext: ".mp3", Size: 1000, Inode: 1001},
+		},
+		EbookFiles: []EbookFileData{
+			{Path: "/audiobooks/test/Book.pdf", Filename: "Book.pdf", Ext: ".PDF", Size: 300, Inode: 3002},
+			{Path: "/audiobooks/test/Book.epub", Filename: "Book.epub", Ext: ".epub", Size: 200, Inode: 3001},
+		},
+	}
+
+	ctx := context.Background()
+	book, err := ConvertToBook(ctx, item, newMockStore())
+	require.NoError(t, err)
+	require.Len(t, book.EbookFiles, 2)
+	assert.Equal(t, domain.EbookFormatEPUB, book.EbookFiles[0].Format)
+	assert.Equal(t, domain.GenerateEbookFileID(3001), book.EbookFiles[0].ID)
+	assert.Equal(t, domain.EbookFormatPDF, book.EbookFiles[1].Format)
+	assert.Equal(t, int64(1000), book.TotalSize, "ebooks don't count towards the audio size")
+
+	// Removing the ebooks from disk drops them on rescan.
+	item.EbookFiles = nil
+	require.NoError(t, UpdateBookFromScan(ctx, book, item, newMockStore()))
+	assert.Empty(t, book.EbookFiles)
+}
+
 // TestConvertToBook_FileExtensions tests various file extension handling.
 func TestConvertToBook_FileExtensions(t *testing.T) {
 	t.Parallel()
@@ -876,6 +905,36 @@ func TestUpdateBookFromScan_PreservesID(t *testing.T) {
 	assert.Equal(t, "User's Title", existingBook.Title)
 }
 
+// TestUpdateBookFromScan_KeepsLoudness tests that a rescan keeps the loudness
+// of unchanged audio files and drops it for files that changed.
+func TestUpdateBookFromScan_KeepsLoudness(t *testing.T) {
+	t.Parallel()
+	modTime := time.UnixMilli(1700000000000)
+	loudness := &domain.Loudness{IntegratedLUFS: -20, TruePeakDBTP: -3}
+	existingBook := &domain.Book{
+		Syncable: domain.Syncable{ID: "book-loudness"},
+		AudioFiles: []domain.AudioFileInfo{
+			{ID: domain.GenerateAudioFileID(3001), Size: 1000, ModTime: modTime.UnixMilli(), Loudness: loudness},
+			{ID: domain.GenerateAudioFileID(3002), Size: 1000, ModTime: modTime.UnixMilli(), Loudness: loudness},
+		},
+	}
+
+	newItem := &LibraryItemData{
+		Path: "/test",
+		AudioFiles: []AudioFileData{
+			{Path: "/test/01.mp3", Filename: "01.mp3", Ext: ".mp3", Size: 1000, Inode: 3001, ModTime: modTime},
+			{Path: "/test/02.mp3", Filename: "02.mp3", Ext: ".mp3", Size: 1200, Inode: 3002, ModTime: modTime.Add(time.Minute)},
+		},
+	}
+
+	err := UpdateBookFromScan(context.Background(), existingBook, newItem, newMockStore())
+	require.NoError(t, err)
+
+	require.Len(t, existingBook.AudioFiles, 2)
+	assert.Equal(t, loudness, existingBook.AudioFiles[0].Loudness)
+	assert.Nil(t, existingBook.AudioFiles[1].Loudness, "changed file must be measured again")
+}
+
 // TestConvertToBook_AudioFileWithoutMetadata tests audio file without metadata.
 func TestConvertToBook_AudioFileWithoutMetadata(t *testing.T) {
 	t.Parallel()
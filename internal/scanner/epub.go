@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"path"
+)
+
+// epubContainer is META-INF/container.xml, which points at the package document.
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// epubPackageMediaType is the media type of an EPUB package document.
+const epubPackageMediaType = "application/oebps-package+xml"
+
+// parseEPUB reads the OPF package document out of a companion EPUB.
+//
+// The ISBN and ASIN are dropped: they identify the ebook edition, and matching
+// the audiobook against them would pull in the wrong product.
+func parseEPUB(filePath string) (*BookMetadata, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("open epub: %w", err)
+	}
+	defer zr.Close()
+
+	data, err := readZipEntry(&zr.Reader, "META-INF/container.xml")
+	if err != nil {
+		return nil, err
+	}
+	var container epubContainer
+	if err := xml.Unmarshal(data, &container); err != nil {
+		return nil, fmt.Errorf("parse epub container: %w", err)
+	}
+
+	var rootfile string
+	for _, rf := range container.Rootfiles {
+		if rf.MediaType == "" || rf.MediaType == epubPackageMediaType {
+			rootfile = rf.FullPath
+			break
+		}
+	}
+	if rootfile == "" {
+		return nil, fmt.Errorf("epub has no package document")
+	}
+
+	if data, err = readZipEntry(&zr.Reader, path.Clean(rootfile)); err != nil {
+		return nil, err
+	}
+	meta, err := parseOPF(data)
+	if err != nil {
+		return nil, err
+	}
+	meta.ISBN = ""
+	meta.ASIN = ""
+	return meta, nil
+}
+
+// readZipEntry reads one file out of a zip archive, capped at maxSidecarSize.
+func readZipEntry(zr *zip.Reader, name string) ([]byte, error) {
+	f, err := zr.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	defer f.Close()
+
+	data, err := readLimited(f, maxSidecarSize)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	return data, nil
+}
@@ -40,6 +40,12 @@ var (
 		".gif":  true,
 		".bmp":  true,
 	}
+
+	// Companion ebooks that sit next to the audio ("whispersync" pairs).
+	ebookExtensions = map[string]bool{
+		".epub": true,
+		".pdf":  true,
+	}
 )
 
 // TranscodeQueuer is an interface for queueing transcode jobs.
@@ -48,6 +54,10 @@ type TranscodeQueuer interface {
 	// QueueTranscode queues a transcode job for an audio file if needed.
 	// Returns nil if no transcode is needed or if transcoding is disabled.
 	QueueTranscode(ctx context.Context, bookID, audioFileID, sourcePath, sourceCodec string) error
+
+	// QueueLoudnessAnalysis queues a loudness measurement for an audio file.
+	// Returns nil if transcoding is disabled.
+	QueueLoudnessAnalysis(ctx context.Context, bookID, audioFileID, sourcePath string) error
 }
 
 // NoopTranscodeQueuer is a no-op implementation for when transcoding is disabled.
@@ -58,6 +68,11 @@ func (NoopTranscodeQueuer) QueueTranscode(context.Context, string, string, strin
 	return nil
 }
 
+// QueueLoudnessAnalysis is a no-op.
+func (NoopTranscodeQueuer) QueueLoudnessAnalysis(context.Context, string, string, string) error {
+	return nil
+}
+
 // Scanner orchestrates the library scanning process.
 type Scanner struct {
 	store           store.Store
@@ -268,7 +283,7 @@ func (s *Scanner) buildLibraryItems(ctx context.Context, grouped map[string][]Wa
 		}
 
 		// Classify files by type.
-		audioFiles, imageFiles, metadataFiles := s.classifyFiles(itemFiles)
+		audioFiles, imageFiles, ebookFiles, metadataFiles := s.classifyFiles(itemFiles)
 
 		// Skip items with no audio files.
 		if len(audioFiles) == 0 {
@@ -293,7 +308,7 @@ func (s *Scanner) buildLibraryItems(ctx context.Context, grouped map[string][]Wa
 		}
 
 		// Build item.
-		item := s.buildItemData(itemPath, itemFiles, analyzed, imageFiles, metadataFiles)
+		item := s.buildItemData(itemPath, itemFiles, analyzed, imageFiles, ebookFiles, metadataFiles)
 		items = append(items, item)
 		tracker.Increment(itemPath)
 	}
@@ -302,13 +317,17 @@ func (s *Scanner) buildLibraryItems(ctx context.Context, grouped map[string][]Wa
 	return items, nil
 }
 
-// classifyFiles separates files into audio, image, and metadata categories.
+// classifyFiles separates files into audio, image, ebook, and metadata categories.
 // Uses a single-pass algorithm for optimal performance.
-func (s *Scanner) classifyFiles(itemFiles []WalkResult) ([]AudioFileData, []ImageFileData, []MetadataFileData) {
+//
+// An EPUB is both an ebook and a metadata source: its package document is
+// listed with the metadata files so applySidecarMetadata can read it.
+func (s *Scanner) classifyFiles(itemFiles []WalkResult) ([]AudioFileData, []ImageFileData, []EbookFileData, []MetadataFileData) {
 	// Preallocate with reasonable capacities based on expected ratios.
 	audioFiles := make([]AudioFileData, 0, len(itemFiles)/3)
 	imageFiles := make([]ImageFileData, 0, len(itemFiles)/10)
 	metadataFiles := make([]MetadataFileData, 0, len(itemFiles)/10)
+	var ebookFiles []EbookFileData
 
 	for _, f := range itemFiles {
 		ext := strings.ToLower(filepath.Ext(f.Path))
@@ -341,6 +360,32 @@ func (s *Scanner) classifyFiles(itemFiles []WalkResult) ([]AudioFileData, []Imag
 			continue
 		}
 
+		// Check companion ebooks.
+		if IsEbookExt(ext) {
+			ebookFiles = append(ebookFiles, EbookFileData{
+				Path:     f.Path,
+				RelPath:  f.RelPath,
+				Filename: filepath.Base(f.Path),
+				Ext:      ext,
+				Size:     f.Size,
+				ModTime:  time.UnixMilli(f.ModTime),
+				Inode:    f.Inode,
+			})
+			if ext == ".epub" {
+				metadataFiles = append(metadataFiles, MetadataFileData{
+					Path:     f.Path,
+					RelPath:  f.RelPath,
+					Filename: filepath.Base(f.Path),
+					Ext:      ext,
+					Type:     MetadataTypeEPUB,
+					Size:     f.Size,
+					ModTime:  time.UnixMilli(f.ModTime),
+					Inode:    f.Inode,
+				})
+			}
+			continue
+		}
+
 		// Check metadata (least common).
 		if metadataType := classifyMetadataFile(f.Path); metadataType != MetadataTypeUnknown {
 			metadataFiles = append(metadataFiles, MetadataFileData{
@@ -356,15 +401,16 @@ func (s *Scanner) classifyFiles(itemFiles []WalkResult) ([]AudioFileData, []Imag
 		}
 	}
 
-	return audioFiles, imageFiles, metadataFiles
+	return audioFiles, imageFiles, ebookFiles, metadataFiles
 }
 
 // buildItemData constructs a LibraryItemData from classified files.
-func (s *Scanner) buildItemData(itemPath string, itemFiles []WalkResult, audioFiles []AudioFileData, imageFiles []ImageFileData, metadataFiles []MetadataFileData) *LibraryItemData {
+func (s *Scanner) buildItemData(itemPath string, itemFiles []WalkResult, audioFiles []AudioFileData, imageFiles []ImageFileData, ebookFiles []EbookFileData, metadataFiles []MetadataFileData) *LibraryItemData {
 	item := &LibraryItemData{
 		Path:          itemPath,
 		AudioFiles:    audioFiles,
 		ImageFiles:    imageFiles,
+		EbookFiles:    ebookFiles,
 		MetadataFiles: metadataFiles,
 	}
 
@@ -372,6 +418,7 @@ func (s *Scanner) buildItemData(itemPath string, itemFiles []WalkResult, audioFi
 	if len(audioFiles) > 0 && audioFiles[0].Metadata != nil {
 		item.Metadata = buildBookMetadata(audioFiles[0].Metadata)
 	}
+	item.Metadata = applySidecarMetadata(item.Metadata, metadataFiles, s.logger)
 
 	// Determine if this is a file or directory.
 	if len(itemFiles) == 1 && itemPath == itemFiles[0].Path {
@@ -403,22 +450,29 @@ func (s *Scanner) saveToDatabase(ctx context.Context, items []*LibraryItemData,
 	tracker.SetPhase(PhaseApplying)
 	s.logger.Info("saving books to database", "count", len(items))
 
+	// Each book joins the library being scanned, or failing that the
+	// library whose scan path contains it.
+	libraries, err := s.store.ListLibraries(ctx)
+	if err != nil {
+		return fmt.Errorf("list libraries: %w", err)
+	}
+	libraryFor := func(path string) *domain.Library {
+		for _, lib := range libraries {
+			if lib.ID == opts.LibraryID {
+				return lib
+			}
+		}
+		return domain.LibraryForPath(libraries, path)
+	}
+
 	// Check if inbox workflow is enabled
 	var inboxEnabled bool
-	var inboxCollection *domain.Collection
 	settings, err := s.store.GetServerSettings(ctx)
 	if err == nil && settings.InboxEnabled {
 		inboxEnabled = true
-		// Get the inbox collection for the default library
-		library, err := s.store.GetDefaultLibrary(ctx)
-		if err == nil && library != nil {
-			inboxCollection, _ = s.store.GetInboxForLibrary(ctx, library.ID)
-		}
-	}
-
-	if inboxEnabled {
 		s.logger.Info("inbox workflow enabled - new books will be staged")
 	}
+	inboxes := make(map[string]*domain.Collection) // library ID -> inbox
 
 	batchWriter := s.store.NewBatchWriter(100)
 
@@ -447,6 +501,9 @@ func (s *Scanner) saveToDatabase(ctx context.Context, items []*LibraryItemData,
 			result.Errors++
 			continue
 		}
+		if lib := libraryFor(item.Path); lib != nil {
+			book.LibraryID = lib.ID
+		}
 
 		// Extract and process cover art.
 		// Priority: 1) embedded artwork, 2) external cover image files
@@ -534,8 +591,9 @@ func (s *Scanner) saveToDatabase(ctx context.Context, items []*LibraryItemData,
 	// Post-flush operations: inbox, transcodes, SSE events.
 	// These require the book rows to exist in the database.
 	for _, book := range pendingBooks {
-		// Add to inbox if workflow is enabled
-		if inboxEnabled && inboxCollection != nil {
+		// Add to the library's inbox if the workflow is enabled and the
+		// library doesn't skip it.
+		if inboxCollection := s.inboxFor(ctx, inboxEnabled, libraryFor(book.Path), inboxes); inboxCollection != nil {
 			if err := s.store.AdminAddBookToCollection(ctx, book.ID, inboxCollection.ID); err != nil {
 				s.logger.Warn("failed to add book to inbox",
 					"book_id", book.ID,
@@ -552,7 +610,7 @@ func (s *Scanner) saveToDatabase(ctx context.Context, items []*LibraryItemData,
 			}
 		}
 
-		// Queue transcodes for audio files with problematic codecs.
+		// Queue transcodes for audio files with problematic codecs, and loudness analyses.
 		s.queueTranscodesForBook(ctx, book)
 	}
 
@@ -566,6 +624,24 @@ func (s *Scanner) saveToDatabase(ctx context.Context, items []*LibraryItemData,
 	return nil
 }
 
+// inboxFor returns the inbox collection new books in lib are staged in, or
+// nil if they go straight to the library. Lookups are cached in inboxes.
+func (s *Scanner) inboxFor(ctx context.Context, inboxEnabled bool, lib *domain.Library, inboxes map[string]*domain.Collection) *domain.Collection {
+	if !inboxEnabled || lib == nil || lib.SkipInbox {
+		return nil
+	}
+	if inbox, ok := inboxes[lib.ID]; ok {
+		return inbox
+	}
+	inbox, err := s.store.GetInboxForLibrary(ctx, lib.ID)
+	if err != nil {
+		s.logger.Warn("failed to get inbox collection", "library_id", lib.ID, "error", err)
+		inbox = nil
+	}
+	inboxes[lib.ID] = inbox
+	return inbox
+}
+
 // ExtractCoverArt extracts embedded cover art from an audio file and processes it.
 // Returns CoverInfo containing the hash, size, and format, or nil if no cover found.
 func (s *Scanner) ExtractCoverArt(ctx context.Context, audioFilePath, bookID string) (*images.CoverInfo, error) {
@@ -627,59 +703,12 @@ func (s *Scanner) ScanFolder(ctx context.Context, folderPath string, opts ScanOp
 	s.logger.Info("grouping complete", "path", itemPath, "files", len(itemFiles))
 
 	// Phase 3: Extract and classify files.
-	var audioFiles []AudioFileData
-	var imageFiles []ImageFileData
-	var metadataFiles []MetadataFileData
-
-	for _, f := range itemFiles {
-		ext := strings.ToLower(filepath.Ext(f.Path))
-
-		// Check if it's an audio file.
-		if IsAudioExt(ext) {
-			audioFiles = append(audioFiles, AudioFileData{
-				Path:     f.Path,
-				RelPath:  f.RelPath,
-				Filename: filepath.Base(f.Path),
-				Ext:      ext,
-				Size:     f.Size,
-				ModTime:  time.UnixMilli(f.ModTime),
-				Inode:    f.Inode,
-			})
-			continue
-		}
-
-		// Check if it's an image file.
-		if isImageExt(ext) {
-			imageFiles = append(imageFiles, ImageFileData{
-				Path:     f.Path,
-				RelPath:  f.RelPath,
-				Filename: filepath.Base(f.Path),
-				Ext:      ext,
-				Size:     f.Size,
-				ModTime:  time.UnixMilli(f.ModTime),
-				Inode:    f.Inode,
-			})
-			continue
-		}
-
-		// Check if it's a metadata file.
-		if metadataType := classifyMetadataFile(f.Path); metadataType != MetadataTypeUnknown {
-			metadataFiles = append(metadataFiles, MetadataFileData{
-				Path:     f.Path,
-				RelPath:  f.RelPath,
-				Filename: filepath.Base(f.Path),
-				Ext:      ext,
-				Type:     metadataType,
-				Size:     f.Size,
-				ModTime:  time.UnixMilli(f.ModTime),
-				Inode:    f.Inode,
-			})
-		}
-	}
+	audioFiles, imageFiles, ebookFiles, metadataFiles := s.classifyFiles(itemFiles)
 
 	s.logger.Info("files classified",
 		"audio", len(audioFiles),
 		"images", len(imageFiles),
+		"ebooks", len(ebookFiles),
 		"metadata", len(metadataFiles),
 	)
 
@@ -704,6 +733,7 @@ func (s *Scanner) ScanFolder(ctx context.Context, folderPath string, opts ScanOp
 		Path:          itemPath,
 		AudioFiles:    analyzed,
 		ImageFiles:    imageFiles,
+		EbookFiles:    ebookFiles,
 		MetadataFiles: metadataFiles,
 	}
 
@@ -713,6 +743,9 @@ func (s *Scanner) ScanFolder(ctx context.Context, folderPath string, opts ScanOp
 		item.Metadata = buildBookMetadata(analyzed[0].Metadata)
 	}
 
+	// Layer sidecar files over the tag data (see sidecar.go for precedence).
+	item.Metadata = applySidecarMetadata(item.Metadata, metadataFiles, s.logger)
+
 	// Determine if this is a file or directory.
 	if len(itemFiles) == 1 && itemPath == itemFiles[0].Path {
 		// Single file (e.g., single M4B in library root).
@@ -831,11 +864,33 @@ func (s *Scanner) RepairBookRelationships(ctx context.Context) (int, error) {
 	return repaired, nil
 }
 
+// ReadEmbeddedChapters re-reads the chapter markers embedded in a book's audio
+// files and maps them onto the book's audio layout. Used to undo manual chapter edits.
+func (s *Scanner) ReadEmbeddedChapters(ctx context.Context, book *domain.Book) ([]domain.Chapter, error) {
+	paths := make([]string, len(book.AudioFiles))
+	for i, af := range book.AudioFiles {
+		paths[i] = af.Path
+	}
+
+	embedded, err := s.analyzer.EmbeddedChapters(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertChapters(embedded, book.AudioFiles), nil
+}
+
 // isImageExt checks if a file extension is for an image file.
 func isImageExt(ext string) bool {
 	return imageExtensions[ext]
 }
 
+// IsEbookExt checks if a file extension is for a companion ebook.
+// Exported for the event processor's file classifier, like IsAudioExt.
+func IsEbookExt(ext string) bool {
+	return ebookExtensions[ext]
+}
+
 // classifyMetadataFile determines the type of metadata file.
 func classifyMetadataFile(path string) MetadataFileType {
 	filename := strings.ToLower(filepath.Base(path))
@@ -869,9 +924,20 @@ func IsAudioExt(ext string) bool {
 	return audioExtensions[ext]
 }
 
-// queueTranscodesForBook checks each audio file in a book and queues transcodes if needed.
+// queueTranscodesForBook checks each audio file in a book and queues transcodes if needed,
+// along with a loudness analysis for files that haven't been measured.
 func (s *Scanner) queueTranscodesForBook(ctx context.Context, book *domain.Book) {
 	for _, af := range book.AudioFiles {
+		if af.Loudness == nil {
+			if err := s.transcodeQueuer.QueueLoudnessAnalysis(ctx, book.ID, af.ID, af.Path); err != nil {
+				s.logger.Warn("failed to queue loudness analysis",
+					"book_id", book.ID,
+					"audio_file_id", af.ID,
+					"error", err,
+				)
+			}
+		}
+
 		// Check if this codec needs transcoding.
 		if !domain.NeedsTranscode(af.Codec) {
 			continue
@@ -0,0 +1,710 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json/v2"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/listenupapp/listenup-server/internal/normalize"
+)
+
+// Sidecar metadata files.
+//
+// Curated libraries (Calibre, Audiobookshelf, rip tools) keep metadata in files
+// next to the audio. Each file type is parsed into a partial BookMetadata and
+// merged on top of the embedded tag data in this order, lowest to highest:
+//
+//  1. Embedded audio tags
+//  2. Companion .epub (package metadata, minus edition identifiers)
+//  3. .nfo
+//  4. desc.txt / reader.txt (description and narrators only)
+//  5. .opf
+//  6. metadata.abs
+//  7. metadata.json
+//
+// A higher source only replaces a field when it actually supplies a value, so a
+// sparse sidecar never blanks out tag data. Chapters always come from the audio
+// because they have to line up with the files on disk.
+
+// sidecarPrecedence ranks metadata file types; higher values win.
+var sidecarPrecedence = map[MetadataFileType]int{
+	MetadataTypeEPUB:   0,
+	MetadataTypeNFO:    1,
+	MetadataTypeDesc:   2,
+	MetadataTypeReader: 2,
+	MetadataTypeOPF:    3,
+	MetadataTypeABS:    4,
+	MetadataTypeJSON:   5,
+}
+
+// maxSidecarSize caps how much of a sidecar file we read.
+// Real sidecars are a few KB; anything larger is not what we're looking for.
+const maxSidecarSize = 1 << 20
+
+// sidecarParser parses the raw contents of a sidecar file.
+type sidecarParser func(data []byte) (*BookMetadata, error)
+
+// sidecarParsers maps each metadata file type to its parser.
+var sidecarParsers = map[MetadataFileType]sidecarParser{
+	MetadataTypeJSON:   parseMetadataJSON,
+	MetadataTypeABS:    parseMetadataABS,
+	MetadataTypeOPF:    parseOPF,
+	MetadataTypeNFO:    parseNFO,
+	MetadataTypeDesc:   parseDescTxt,
+	MetadataTypeReader: parseReaderTxt,
+}
+
+// applySidecarMetadata parses the item's sidecar files and merges them over base
+// in precedence order. base may be nil (e.g. when tag parsing failed).
+// Files that can't be read or parsed are logged and skipped.
+func applySidecarMetadata(base *BookMetadata, files []MetadataFileData, logger *slog.Logger) *BookMetadata {
+	if len(files) == 0 {
+		return base
+	}
+
+	ordered := slices.Clone(files)
+	slices.SortStableFunc(ordered, func(a, b MetadataFileData) int {
+		if c := cmp.Compare(sidecarPrecedence[a.Type], sidecarPrecedence[b.Type]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Filename, b.Filename)
+	})
+
+	result := base
+	for _, f := range ordered {
+		sidecar, err := ParseSidecarFile(f.Path, f.Type)
+		if err != nil {
+			if logger != nil {
+				logger.Warn("failed to parse metadata file", "path", f.Path, "type", f.Type, "error", err)
+			}
+			continue
+		}
+		if sidecar == nil {
+			continue
+		}
+		if result == nil {
+			result = &BookMetadata{}
+		}
+		mergeBookMetadata(result, sidecar, f.Type)
+	}
+
+	return result
+}
+
+// ParseSidecarFile reads and parses a single sidecar metadata file.
+// Returns nil metadata for unknown file types.
+func ParseSidecarFile(path string, fileType MetadataFileType) (*BookMetadata, error) {
+	if fileType == MetadataTypeEPUB {
+		return parseEPUB(path)
+	}
+
+	parse, ok := sidecarParsers[fileType]
+	if !ok {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := readLimited(f, maxSidecarSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Strip a UTF-8 BOM and replace invalid bytes; NFO files in particular
+	// are often written in legacy code pages.
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	data = bytes.ToValidUTF8(data, []byte("\uFFFD"))
+
+	return parse(data)
+}
+
+// readLimited reads at most limit bytes and errors if the file is larger.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("file exceeds %d bytes", limit)
+	}
+	return data, nil
+}
+
+// mergeBookMetadata copies every non-empty field from src into dst and records
+// the source in dst.Sources. Abridged can only be switched on, since sidecars
+// have no way to say "unknown".
+func mergeBookMetadata(dst, src *BookMetadata, source MetadataFileType) {
+	if dst.Sources == nil {
+		dst.Sources = make(map[string]string)
+	}
+
+	setString := func(field string, target *string, value string) {
+		value = strings.TrimSpace(sanitizeString(value))
+		if value == "" {
+			return
+		}
+		*target = value
+		dst.Sources[field] = string(source)
+	}
+	setList := func(field string, target *[]string, values []string) {
+		cleaned := cleanList(values)
+		if len(cleaned) == 0 {
+			return
+		}
+		*target = cleaned
+		dst.Sources[field] = string(source)
+	}
+
+	setString("title", &dst.Title, src.Title)
+	setString("subtitle", &dst.Subtitle, src.Subtitle)
+	setString("description", &dst.Description, htmlToMarkdown(src.Description))
+	setString("publisher", &dst.Publisher, src.Publisher)
+	setString("publish_year", &dst.PublishYear, extractYear(src.PublishYear))
+	setString("language", &dst.Language, normalize.LanguageCode(src.Language))
+	setString("isbn", &dst.ISBN, src.ISBN)
+	setString("asin", &dst.ASIN, src.ASIN)
+
+	setList("authors", &dst.Authors, src.Authors)
+	setList("narrators", &dst.Narrators, src.Narrators)
+	setList("genres", &dst.Genres, src.Genres)
+	setList("tags", &dst.Tags, src.Tags)
+
+	var series []SeriesInfo
+	for _, s := range src.Series {
+		name := strings.TrimSpace(sanitizeString(s.Name))
+		if name == "" {
+			continue
+		}
+		series = append(series, SeriesInfo{
+			Name:     name,
+			Sequence: strings.TrimSpace(sanitizeString(s.Sequence)),
+		})
+	}
+	if len(series) > 0 {
+		dst.Series = series
+		dst.Sources["series"] = string(source)
+	}
+
+	if src.Abridged && !dst.Abridged {
+		dst.Abridged = true
+		dst.Sources["abridged"] = string(source)
+	}
+}
+
+// cleanList trims, sanitizes and de-duplicates a list, dropping empty entries.
+func cleanList(values []string) []string {
+	var result []string
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(sanitizeString(v))
+		key := strings.ToLower(v)
+		if v == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// yearPattern matches a four-digit year anywhere in a date string.
+var yearPattern = regexp.MustCompile(`\b(1[5-9]\d{2}|2\d{3})\b`)
+
+// extractYear pulls the year out of values like "2010", "2010-07-01T00:00:00+00:00"
+// or "Copyright 2010 Tor Books".
+func extractYear(s string) string {
+	return yearPattern.FindString(s)
+}
+
+// splitList splits a delimited list on commas and semicolons, dropping empty entries.
+func splitList(s string) []string {
+	var result []string
+	for part := range strings.FieldsFuncSeq(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// parseSeriesString parses "Mistborn #1", "Mistborn, Book 1" or plain "Mistborn".
+func parseSeriesString(s string) SeriesInfo {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndex(s, "#"); i > 0 {
+		return SeriesInfo{
+			Name:     strings.TrimSpace(s[:i]),
+			Sequence: strings.TrimSpace(s[i+1:]),
+		}
+	}
+	if m := seriesBookPattern.FindStringSubmatch(s); m != nil {
+		return SeriesInfo{Name: strings.TrimSpace(m[1]), Sequence: m[2]}
+	}
+	return SeriesInfo{Name: s}
+}
+
+// seriesBookPattern matches "Name, Book 3" and "Name Book 3.5".
+var seriesBookPattern = regexp.MustCompile(`(?i)^(.+?),?\s+(?:book|vol\.?|volume)\s+(\d+(?:\.\d+)?)$`)
+
+// formatSequence turns Calibre's float series index ("1.0") into "1".
+func formatSequence(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// --- metadata.json (Audiobookshelf) ---
+
+// absMetadataJSON mirrors the metadata.json file Audiobookshelf writes into each item folder.
+type absMetadataJSON struct {
+	Title         string   `json:"title"`
+	Subtitle      string   `json:"subtitle"`
+	Description   string   `json:"description"`
+	Publisher     string   `json:"publisher"`
+	PublishedYear string   `json:"publishedYear"`
+	Language      string   `json:"language"`
+	ISBN          string   `json:"isbn"`
+	ASIN          string   `json:"asin"`
+	Authors       []string `json:"authors"`
+	Narrators     []string `json:"narrators"`
+	Series        []string `json:"series"`
+	Genres        []string `json:"genres"`
+	Tags          []string `json:"tags"`
+	Abridged      bool     `json:"abridged"`
+}
+
+// parseMetadataJSON parses an Audiobookshelf metadata.json file.
+func parseMetadataJSON(data []byte) (*BookMetadata, error) {
+	var raw absMetadataJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse metadata.json: %w", err)
+	}
+
+	meta := &BookMetadata{
+		Title:       raw.Title,
+		Subtitle:    raw.Subtitle,
+		Description: raw.Description,
+		Publisher:   raw.Publisher,
+		PublishYear: raw.PublishedYear,
+		Language:    raw.Language,
+		ISBN:        raw.ISBN,
+		ASIN:        raw.ASIN,
+		Authors:     raw.Authors,
+		Narrators:   raw.Narrators,
+		Genres:      raw.Genres,
+		Tags:        raw.Tags,
+		Abridged:    raw.Abridged,
+	}
+	for _, s := range raw.Series {
+		meta.Series = append(meta.Series, parseSeriesString(s))
+	}
+
+	return meta, nil
+}
+
+// --- metadata.abs (Audiobookshelf legacy) ---
+
+// parseMetadataABS parses the legacy ";ABMETADATA" key=value format.
+// A [DESCRIPTION] section holds a multi-line description; other sections
+// (e.g. [CHAPTER]) are ignored.
+func parseMetadataABS(data []byte) (*BookMetadata, error) {
+	meta := &BookMetadata{}
+
+	var (
+		section     string
+		description []string
+	)
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), maxSidecarSize)
+	for sc.Scan() {
+		line := sc.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section = strings.ToUpper(strings.Trim(trimmed, "[]"))
+			continue
+		}
+
+		if section == "DESCRIPTION" {
+			description = append(description, line)
+			continue
+		}
+		if section != "" || trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "title":
+			meta.Title = value
+		case "subtitle":
+			meta.Subtitle = value
+		case "authors", "author":
+			meta.Authors = append(meta.Authors, value)
+		case "narrators", "narrator":
+			meta.Narrators = append(meta.Narrators, value)
+		case "series":
+			meta.Series = append(meta.Series, parseSeriesString(value))
+		case "publishedyear", "year":
+			meta.PublishYear = value
+		case "publisher":
+			meta.Publisher = value
+		case "description":
+			meta.Description = value
+		case "isbn":
+			meta.ISBN = value
+		case "asin":
+			meta.ASIN = value
+		case "language":
+			meta.Language = value
+		case "genres", "genre":
+			meta.Genres = append(meta.Genres, splitList(value)...)
+		case "tags":
+			meta.Tags = append(meta.Tags, splitList(value)...)
+		case "abridged":
+			meta.Abridged = value == "1" || strings.EqualFold(value, "true")
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("parse metadata.abs: %w", err)
+	}
+
+	if desc := strings.TrimSpace(strings.Join(description, "\n")); desc != "" {
+		meta.Description = desc
+	}
+
+	return meta, nil
+}
+
+// --- OPF (Calibre / EPUB package) ---
+
+// opfPackage is the subset of an OPF package document we care about.
+// encoding/xml matches on local names, so dc: and opf: prefixes need no special handling.
+type opfPackage struct {
+	Metadata opfMetadata `xml:"metadata"`
+}
+
+type opfMetadata struct {
+	Description string          `xml:"description"`
+	Publisher   string          `xml:"publisher"`
+	Titles      []opfElement    `xml:"title"`
+	Creators    []opfElement    `xml:"creator"`
+	Dates       []string        `xml:"date"`
+	Languages   []string        `xml:"language"`
+	Identifiers []opfIdentifier `xml:"identifier"`
+	Subjects    []string        `xml:"subject"`
+	Metas       []opfMeta       `xml:"meta"`
+}
+
+type opfElement struct {
+	ID    string `xml:"id,attr"`
+	Role  string `xml:"role,attr"`
+	Value string `xml:",chardata"`
+}
+
+type opfIdentifier struct {
+	Scheme string `xml:"scheme,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type opfMeta struct {
+	Name     string `xml:"name,attr"`
+	Content  string `xml:"content,attr"`
+	Property string `xml:"property,attr"`
+	Refines  string `xml:"refines,attr"`
+	ID       string `xml:"id,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// marcRoles maps MARC relator codes used in OPF files to contributor role suffixes
+// understood by parseContributorString. An empty suffix means author.
+var marcRoles = map[string]string{
+	"aut": "",
+	"nrt": "narrator",
+	"trl": "translator",
+	"edt": "editor",
+	"ill": "illustrator",
+	"aui": "introduction",
+	"aft": "afterword",
+	"pro": "producer",
+	"adp": "adapter",
+}
+
+// parseOPF parses a Calibre or EPUB OPF package document.
+// Handles both EPUB2 attributes (opf:role, calibre:series) and EPUB3
+// refinements (<meta refines="#id" property="role">).
+func parseOPF(data []byte) (*BookMetadata, error) {
+	var pkg opfPackage
+	if err := xml.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("parse opf: %w", err)
+	}
+	md := pkg.Metadata
+
+	// Collect EPUB3 refinements keyed by the refined element ID.
+	refines := make(map[string]map[string]string)
+	for _, m := range md.Metas {
+		if m.Refines == "" || m.Property == "" {
+			continue
+		}
+		target := strings.TrimPrefix(m.Refines, "#")
+		if refines[target] == nil {
+			refines[target] = make(map[string]string)
+		}
+		refines[target][m.Property] = strings.TrimSpace(m.Value)
+	}
+
+	meta := &BookMetadata{
+		Description: md.Description,
+		Publisher:   md.Publisher,
+	}
+
+	for _, t := range md.Titles {
+		value := strings.TrimSpace(t.Value)
+		if value == "" {
+			continue
+		}
+		switch refines[t.ID]["title-type"] {
+		case "subtitle":
+			meta.Subtitle = value
+		case "", "main":
+			if meta.Title == "" {
+				meta.Title = value
+			}
+		}
+	}
+
+	for _, c := range md.Creators {
+		name := strings.TrimSpace(c.Value)
+		if name == "" {
+			continue
+		}
+		code := strings.ToLower(c.Role)
+		if code == "" {
+			code = strings.ToLower(refines[c.ID]["role"])
+		}
+		if code == "" {
+			code = "aut"
+		}
+		role, ok := marcRoles[code]
+		switch {
+		case !ok:
+			continue // bkp (book producer) and friends aren't contributors
+		case role == "narrator":
+			meta.Narrators = append(meta.Narrators, name)
+		case role == "":
+			meta.Authors = append(meta.Authors, name)
+		default:
+			meta.Authors = append(meta.Authors, name+" - "+role)
+		}
+	}
+
+	if len(md.Dates) > 0 {
+		meta.PublishYear = md.Dates[0]
+	}
+	if len(md.Languages) > 0 {
+		meta.Language = md.Languages[0]
+	}
+
+	for _, ident := range md.Identifiers {
+		value := strings.TrimSpace(ident.Value)
+		scheme := strings.ToUpper(ident.Scheme)
+		lower := strings.ToLower(value)
+		switch {
+		case scheme == "ISBN":
+			meta.ISBN = value
+		case strings.HasPrefix(lower, "urn:isbn:"):
+			meta.ISBN = value[len("urn:isbn:"):]
+		case scheme == "ASIN", scheme == "AMAZON", scheme == "MOBI-ASIN", scheme == "AUDIBLE":
+			meta.ASIN = value
+		}
+	}
+
+	for _, subject := range md.Subjects {
+		meta.Genres = append(meta.Genres, splitList(subject)...)
+	}
+
+	// Calibre series.
+	var calibreSeries, calibreIndex string
+	for _, m := range md.Metas {
+		switch m.Name {
+		case "calibre:series":
+			calibreSeries = m.Content
+		case "calibre:series_index":
+			calibreIndex = m.Content
+		}
+	}
+	if strings.TrimSpace(calibreSeries) != "" {
+		meta.Series = append(meta.Series, SeriesInfo{
+			Name:     strings.TrimSpace(calibreSeries),
+			Sequence: formatSequence(calibreIndex),
+		})
+	}
+
+	// EPUB3 collections.
+	for _, m := range md.Metas {
+		if m.Property != "belongs-to-collection" || m.Refines != "" {
+			continue
+		}
+		name := strings.TrimSpace(m.Value)
+		if name == "" || strings.EqualFold(name, calibreSeries) {
+			continue
+		}
+		if ct := refines[m.ID]["collection-type"]; ct != "" && ct != "series" {
+			continue
+		}
+		meta.Series = append(meta.Series, SeriesInfo{
+			Name:     name,
+			Sequence: formatSequence(refines[m.ID]["group-position"]),
+		})
+	}
+
+	return meta, nil
+}
+
+// --- NFO (rip tool info files) ---
+
+// nfoDescriptionKeys start a free-form description that runs to the end of the file
+// when they have no inline value.
+var nfoDescriptionKeys = map[string]bool{
+	"book synopsis":    true,
+	"synopsis":         true,
+	"description":      true,
+	"summary":          true,
+	"book description": true,
+}
+
+// parseNFO parses the "Key: Value" text format written by common audiobook
+// ripping tools, e.g.
+//
+//	Title..............: The Way of Kings
+//	Author.............: Brandon Sanderson
+//	Read By............: Michael Kramer, Kate Reading
+//
+//	Book Synopsis:
+//	Roshar is a world of stone and storms...
+func parseNFO(data []byte) (*BookMetadata, error) {
+	meta := &BookMetadata{}
+
+	var (
+		seriesName     string
+		seriesSequence string
+		description    []string
+		inDescription  bool
+	)
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), maxSidecarSize)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+
+		if inDescription {
+			description = append(description, line)
+			continue
+		}
+
+		rawKey, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(strings.Trim(rawKey, " \t."))
+		value = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(value), "."))
+
+		if nfoDescriptionKeys[key] {
+			if value == "" {
+				inDescription = true
+			} else {
+				meta.Description = value
+			}
+			continue
+		}
+		if value == "" {
+			continue
+		}
+
+		switch key {
+		case "title", "book title":
+			meta.Title = value
+		case "subtitle":
+			meta.Subtitle = value
+		case "author", "authors", "written by":
+			meta.Authors = append(meta.Authors, value)
+		case "narrator", "narrators", "read by", "reader":
+			meta.Narrators = append(meta.Narrators, value)
+		case "series", "series name":
+			seriesName = value
+		case "series position", "series number", "series #", "position in series", "book number":
+			seriesSequence = value
+		case "genre", "genres":
+			meta.Genres = append(meta.Genres, splitList(value)...)
+		case "publisher":
+			meta.Publisher = value
+		case "release date", "copyright", "year", "published", "original release":
+			if meta.PublishYear == "" {
+				meta.PublishYear = value
+			}
+		case "language":
+			meta.Language = value
+		case "isbn":
+			meta.ISBN = value
+		case "asin":
+			meta.ASIN = value
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("parse nfo: %w", err)
+	}
+
+	if desc := strings.TrimSpace(strings.Join(description, "\n")); desc != "" {
+		meta.Description = desc
+	}
+	if seriesName != "" {
+		series := parseSeriesString(seriesName)
+		if seriesSequence != "" {
+			series.Sequence = seriesSequence
+		}
+		meta.Series = []SeriesInfo{series}
+	}
+
+	return meta, nil
+}
+
+// --- desc.txt / reader.txt ---
+
+// parseDescTxt treats the whole file as the book description.
+func parseDescTxt(data []byte) (*BookMetadata, error) {
+	return &BookMetadata{Description: strings.TrimSpace(string(data))}, nil
+}
+
+// parseReaderTxt reads narrator names, one per line or semicolon separated.
+// Comma-separated names are split later by parseContributorString.
+func parseReaderTxt(data []byte) (*BookMetadata, error) {
+	meta := &BookMetadata{}
+	for line := range strings.Lines(string(data)) {
+		for _, name := range strings.Split(line, ";") {
+			if name = strings.TrimSpace(name); name != "" {
+				meta.Narrators = append(meta.Narrators, name)
+			}
+		}
+	}
+	return meta, nil
+}
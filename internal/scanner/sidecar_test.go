@@ -0,0 +1,347 @@
+package scanner
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOPF = `<?xml version="1.0" encoding="utf-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:title>The Way of Kings</dc:title>
+    <dc:creator opf:role="aut" opf:file-as="Sanderson, Brandon">Brandon Sanderson</dc:creator>
+    <dc:creator opf:role="nrt">Michael Kramer</dc:creator>
+    <dc:creator opf:role="bkp">calibre (7.0.0)</dc:creator>
+    <dc:description>&lt;p&gt;Roshar is a world of stone and storms.&lt;/p&gt;</dc:description>
+    <dc:publisher>Macmillan Audio</dc:publisher>
+    <dc:date>2010-08-31T00:00:00+00:00</dc:date>
+    <dc:language>eng</dc:language>
+    <dc:identifier opf:scheme="ISBN">9781427209993</dc:identifier>
+    <dc:identifier opf:scheme="AMAZON">B003ZWFO7E</dc:identifier>
+    <dc:subject>Fantasy</dc:subject>
+    <dc:subject>Epic</dc:subject>
+    <meta name="calibre:series" content="The Stormlight Archive"/>
+    <meta name="calibre:series_index" content="1.0"/>
+  </metadata>
+</package>`
+
+func TestParseOPF(t *testing.T) {
+	t.Parallel()
+
+	meta, err := parseOPF([]byte(testOPF))
+	require.NoError(t, err)
+
+	assert.Equal(t, "The Way of Kings", meta.Title)
+	assert.Equal(t, []string{"Brandon Sanderson"}, meta.Authors)
+	assert.Equal(t, []string{"Michael Kramer"}, meta.Narrators)
+	assert.Equal(t, "Macmillan Audio", meta.Publisher)
+	assert.Equal(t, "9781427209993", meta.ISBN)
+	assert.Equal(t, "B003ZWFO7E", meta.ASIN)
+	assert.Equal(t, []string{"Fantasy", "Epic"}, meta.Genres)
+	require.Len(t, meta.Series, 1)
+	assert.Equal(t, SeriesInfo{Name: "The Stormlight Archive", Sequence: "1"}, meta.Series[0])
+}
+
+func TestParseOPF_EPUB3Refinements(t *testing.T) {
+	t.Parallel()
+
+	data := `<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title id="t1">Project Hail Mary</dc:title>
+    <dc:title id="t2">A Novel</dc:title>
+    <meta refines="#t2" property="title-type">subtitle</meta>
+    <dc:creator id="c1">Andy Weir</dc:creator>
+    <dc:creator id="c2">Ray Porter</dc:creator>
+    <meta refines="#c2" property="role" scheme="marc:relators">nrt</meta>
+    <dc:identifier>urn:isbn:9780593395561</dc:identifier>
+    <meta property="belongs-to-collection" id="s1">Standalone</meta>
+    <meta refines="#s1" property="group-position">2</meta>
+  </metadata>
+</package>`
+
+	meta, err := parseOPF([]byte(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Project Hail Mary", meta.Title)
+	assert.Equal(t, "A Novel", meta.Subtitle)
+	assert.Equal(t, []string{"Andy Weir"}, meta.Authors)
+	assert.Equal(t, []string{"Ray Porter"}, meta.Narrators)
+	assert.Equal(t, "9780593395561", meta.ISBN)
+	assert.Equal(t, []SeriesInfo{{Name: "Standalone", Sequence: "2"}}, meta.Series)
+}
+
+// writeTestEPUB writes a minimal EPUB whose package document is opf.
+func writeTestEPUB(t *testing.T, path, opf string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	entries := []struct{ name, body string }{
+		{"mimetype", "application/epub+zip"},
+		{"META-INF/container.xml", `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>`},
+		{"OEBPS/content.opf", opf},
+	}
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(e.body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func TestParseEPUB(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "The Way of Kings.epub")
+	writeTestEPUB(t, path, testOPF)
+
+	meta, err := ParseSidecarFile(path, MetadataTypeEPUB)
+	require.NoError(t, err)
+
+	assert.Equal(t, "The Way of Kings", meta.Title)
+	assert.Equal(t, []string{"Brandon Sanderson"}, meta.Authors)
+	require.Len(t, meta.Series, 1)
+	assert.Equal(t, "The Stormlight Archive", meta.Series[0].Name)
+	assert.Empty(t, meta.ISBN, "the ebook's ISBN is not the audiobook's")
+	assert.Empty(t, meta.ASIN)
+
+	// An EPUB is the weakest sidecar: any other file overrides it.
+	nfo := filepath.Join(filepath.Dir(path), "book.nfo")
+	require.NoError(t, os.WriteFile(nfo, []byte("Title: NFO Title\n"), 0o644))
+	merged := applySidecarMetadata(nil, []MetadataFileData{
+		{Path: nfo, Filename: "book.nfo", Type: MetadataTypeNFO},
+		{Path: path, Filename: "The Way of Kings.epub", Type: MetadataTypeEPUB},
+	}, nil)
+	assert.Equal(t, "NFO Title", merged.Title)
+	assert.Equal(t, string(MetadataTypeEPUB), merged.Sources["authors"])
+
+	_, err = ParseSidecarFile(nfo, MetadataTypeEPUB)
+	assert.Error(t, err, "not a zip")
+}
+
+func TestParseMetadataJSON(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+  "title": "Mistborn",
+  "subtitle": null,
+  "authors": ["Brandon Sanderson"],
+  "narrators": ["Michael Kramer"],
+  "series": ["Mistborn #1", "The Cosmere"],
+  "genres": ["Fantasy"],
+  "publishedYear": "2006",
+  "publisher": "Tor",
+  "description": "For a thousand years the ash fell.",
+  "asin": "B002UZMLXM",
+  "language": "English",
+  "abridged": false,
+  "chapters": [{"id": 0, "start": 0, "end": 10, "title": "Prologue"}]
+}`
+
+	meta, err := parseMetadataJSON([]byte(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Mistborn", meta.Title)
+	assert.Empty(t, meta.Subtitle)
+	assert.Equal(t, []string{"Brandon Sanderson"}, meta.Authors)
+	assert.Equal(t, []SeriesInfo{
+		{Name: "Mistborn", Sequence: "1"},
+		{Name: "The Cosmere"},
+	}, meta.Series)
+	assert.Equal(t, "2006", meta.PublishYear)
+	assert.Equal(t, "B002UZMLXM", meta.ASIN)
+}
+
+func TestParseMetadataABS(t *testing.T) {
+	t.Parallel()
+
+	data := `;ABMETADATA1
+#audiobookshelf v2.3.0
+
+title=Elantris
+authors=Brandon Sanderson
+narrators=Jack Garrett
+series=Elantris #1
+publishedYear=2005
+genres=Fantasy, Epic Fantasy
+
+[DESCRIPTION]
+Elantris was the capital of Arelon.
+It was beautiful.
+
+[CHAPTER]
+start=0
+title=Chapter 1
+`
+
+	meta, err := parseMetadataABS([]byte(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Elantris", meta.Title)
+	assert.Equal(t, []string{"Brandon Sanderson"}, meta.Authors)
+	assert.Equal(t, []string{"Jack Garrett"}, meta.Narrators)
+	assert.Equal(t, []SeriesInfo{{Name: "Elantris", Sequence: "1"}}, meta.Series)
+	assert.Equal(t, []string{"Fantasy", "Epic Fantasy"}, meta.Genres)
+	assert.Equal(t, "Elantris was the capital of Arelon.\nIt was beautiful.", meta.Description)
+}
+
+func TestParseNFO(t *testing.T) {
+	t.Parallel()
+
+	data := "General Information\r\n" +
+		"===================\r\n" +
+		"Title..............: Leviathan Wakes\r\n" +
+		"Author.............: James S. A. Corey\r\n" +
+		"Read By............: Jefferson Mays\r\n" +
+		"Series Name........: The Expanse\r\n" +
+		"Series Position....: 1\r\n" +
+		"Copyright..........: 2011\r\n" +
+		"Genre..............: Science Fiction\r\n" +
+		"\r\n" +
+		"Book Synopsis:\r\n" +
+		"Humanity has colonized the solar system.\r\n" +
+		"Time: the future.\r\n"
+
+	meta, err := parseNFO([]byte(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Leviathan Wakes", meta.Title)
+	assert.Equal(t, []string{"James S. A. Corey"}, meta.Authors)
+	assert.Equal(t, []string{"Jefferson Mays"}, meta.Narrators)
+	assert.Equal(t, []SeriesInfo{{Name: "The Expanse", Sequence: "1"}}, meta.Series)
+	assert.Equal(t, "2011", meta.PublishYear)
+	assert.Equal(t, "Humanity has colonized the solar system.\nTime: the future.", meta.Description)
+}
+
+func TestParseSeriesString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected SeriesInfo
+	}{
+		{"Mistborn #1", SeriesInfo{Name: "Mistborn", Sequence: "1"}},
+		{"The Expanse #4.5", SeriesInfo{Name: "The Expanse", Sequence: "4.5"}},
+		{"Discworld, Book 12", SeriesInfo{Name: "Discworld", Sequence: "12"}},
+		{"Standalone", SeriesInfo{Name: "Standalone"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, parseSeriesString(tt.input))
+		})
+	}
+}
+
+func TestApplySidecarMetadata_Precedence(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	write := func(name, content string) MetadataFileData {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return MetadataFileData{Path: path, Filename: name, Type: classifyMetadataFile(path)}
+	}
+
+	files := []MetadataFileData{
+		write("metadata.json", `{"title": "JSON Title", "narrators": []}`),
+		write("book.opf", testOPF),
+		write("desc.txt", "Description from desc.txt"),
+		write("reader.txt", "Kate Reading\nMichael Kramer\n"),
+	}
+
+	base := &BookMetadata{
+		Title:     "Tag Title",
+		Authors:   []string{"Tag Author"},
+		Narrators: []string{"Tag Narrator"},
+		Language:  "en",
+	}
+
+	meta := applySidecarMetadata(base, files, nil)
+
+	// metadata.json beats the OPF for title.
+	assert.Equal(t, "JSON Title", meta.Title)
+	assert.Equal(t, string(MetadataTypeJSON), meta.Sources["title"])
+
+	// The OPF beats reader.txt for narrators; the empty JSON list doesn't clear them.
+	assert.Equal(t, []string{"Michael Kramer"}, meta.Narrators)
+	assert.Equal(t, string(MetadataTypeOPF), meta.Sources["narrators"])
+
+	// The OPF description (HTML) beats desc.txt and is converted to markdown.
+	assert.Equal(t, "Roshar is a world of stone and storms.", meta.Description)
+
+	// OPF-only fields are filled in.
+	assert.Equal(t, []string{"Brandon Sanderson"}, meta.Authors)
+	assert.Equal(t, "2010", meta.PublishYear)
+	assert.Equal(t, "Macmillan Audio", meta.Publisher)
+}
+
+func TestApplySidecarMetadata_NoTagMetadata(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "desc.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Just a description"), 0o644))
+
+	meta := applySidecarMetadata(nil, []MetadataFileData{{Path: path, Filename: "desc.txt", Type: MetadataTypeDesc}}, nil)
+
+	require.NotNil(t, meta)
+	assert.Equal(t, "Just a description", meta.Description)
+}
+
+func TestApplySidecarMetadata_SkipsBrokenFiles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "metadata.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+
+	base := &BookMetadata{Title: "Tag Title"}
+	meta := applySidecarMetadata(base, []MetadataFileData{{Path: path, Filename: "metadata.json", Type: MetadataTypeJSON}}, nil)
+
+	assert.Equal(t, "Tag Title", meta.Title)
+}
+
+func TestApplySidecarsToBook(t *testing.T) {
+	t.Parallel()
+	store := newMockStore()
+
+	book := &domain.Book{
+		Title:       "Old Title",
+		Description: "Edited by the user",
+		Publisher:   "Tag Publisher",
+	}
+	item := &LibraryItemData{
+		Metadata: &BookMetadata{
+			Title:       "Sidecar Title",
+			Description: "From tags",
+			Publisher:   "Tag Publisher",
+			Narrators:   []string{"Kate Reading"},
+			Sources: map[string]string{
+				"title":     string(MetadataTypeJSON),
+				"narrators": string(MetadataTypeReader),
+			},
+		},
+	}
+
+	changed, err := ApplySidecarsToBook(context.Background(), book, item, store)
+	require.NoError(t, err)
+
+	assert.True(t, changed)
+	assert.Equal(t, "Sidecar Title", book.Title)
+	assert.Equal(t, "Edited by the user", book.Description, "tag-derived fields must not overwrite the database")
+	require.Len(t, book.Contributors, 1)
+	assert.Equal(t, []domain.ContributorRole{domain.RoleNarrator}, book.Contributors[0].Roles)
+}
@@ -25,6 +25,7 @@ type LibraryItemData struct {
 	RelPath       string
 	AudioFiles    []AudioFileData
 	ImageFiles    []ImageFileData
+	EbookFiles    []EbookFileData
 	MetadataFiles []MetadataFileData
 	Inode         uint64
 	Size          int64
@@ -88,6 +89,17 @@ type ImageFileData struct {
 	Inode    uint64
 }
 
+// EbookFileData represents a discovered companion ebook (.epub or .pdf).
+type EbookFileData struct {
+	ModTime  time.Time
+	Path     string
+	RelPath  string
+	Filename string
+	Ext      string
+	Size     int64
+	Inode    uint64
+}
+
 // MetadataFileData represents a discovered metadata file.
 // (metadata.json, metadata.abs, .opf, .nfo, desc.txt, reader.txt).
 type MetadataFileData struct {
@@ -116,6 +128,8 @@ const (
 	MetadataTypeNFO MetadataFileType = "nfo"
 	// MetadataTypeDesc represents description text files.
 	MetadataTypeDesc MetadataFileType = "desc.txt"
+	// MetadataTypeEPUB represents the package document inside a companion EPUB.
+	MetadataTypeEPUB MetadataFileType = "epub"
 	// MetadataTypeReader represents reader text files.
 	MetadataTypeReader MetadataFileType = "reader.txt"
 	// MetadataTypeUnknown represents unknown metadata file types.
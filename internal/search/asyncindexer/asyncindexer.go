@@ -44,6 +44,8 @@ const (
 	OpDeleteContributor
 	OpIndexSeries
 	OpDeleteSeries
+	OpIndexPodcast
+	OpDeletePodcast
 )
 
 // Job is a sum-type-style envelope for any operation submitted to the indexer.
@@ -54,6 +56,7 @@ type Job struct {
 	Book        *domain.Book
 	Contributor *domain.Contributor
 	Series      *domain.Series
+	Podcast     *domain.Podcast
 }
 
 // Indexer runs SearchIndexer operations off the request path on a dedicated
@@ -163,6 +166,16 @@ func (a *Indexer) SubmitDeleteSeries(id string) {
 	a.Submit(Job{Op: OpDeleteSeries, ID: id})
 }
 
+// SubmitIndexPodcast enqueues a non-blocking search index update for a podcast.
+func (a *Indexer) SubmitIndexPodcast(p *domain.Podcast) {
+	a.Submit(Job{Op: OpIndexPodcast, Podcast: p})
+}
+
+// SubmitDeletePodcast enqueues a non-blocking search index removal for a podcast.
+func (a *Indexer) SubmitDeletePodcast(id string) {
+	a.Submit(Job{Op: OpDeletePodcast, ID: id})
+}
+
 // Shutdown stops accepting new work, drains in-flight jobs, and waits for
 // workers to exit. If ctx is canceled or expires before workers finish,
 // it returns ctx.Err.
@@ -244,6 +257,12 @@ func (a *Indexer) execute(job Job) {
 		}
 	case OpDeleteSeries:
 		err = a.indexer.DeleteSeries(ctx, job.ID)
+	case OpIndexPodcast:
+		if job.Podcast != nil {
+			err = a.indexer.IndexPodcast(ctx, job.Podcast)
+		}
+	case OpDeletePodcast:
+		err = a.indexer.DeletePodcast(ctx, job.ID)
 	default:
 		a.logger.Warn("unknown index job op", "op", job.Op)
 		return
@@ -283,6 +302,8 @@ func (a *Indexer) jobIdentifier(job Job) string {
 		return job.Contributor.ID
 	case job.Series != nil:
 		return job.Series.ID
+	case job.Podcast != nil:
+		return job.Podcast.ID
 	default:
 		return job.ID
 	}
@@ -18,6 +18,8 @@ func (noopSearchIndexer) IndexContributor(_ context.Context, _ *domain.Contribut
 func (noopSearchIndexer) DeleteContributor(_ context.Context, _ string) error             { return nil }
 func (noopSearchIndexer) IndexSeries(_ context.Context, _ *domain.Series) error           { return nil }
 func (noopSearchIndexer) DeleteSeries(_ context.Context, _ string) error                  { return nil }
+func (noopSearchIndexer) IndexPodcast(_ context.Context, _ *domain.Podcast) error         { return nil }
+func (noopSearchIndexer) DeletePodcast(_ context.Context, _ string) error                 { return nil }
 
 func TestIndexer_LastTickAdvances(t *testing.T) {
 	t.Parallel()
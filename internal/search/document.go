@@ -1,9 +1,10 @@
 // Package search provides full-text search functionality using Bleve.
-// It enables federated search across books, contributors, and series with
+// It enables federated search across books, contributors, series and podcasts with
 // faceted filtering, fuzzy matching, and hierarchical genre traversal.
 package search
 
 import (
+	"cmp"
 	"strconv"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
@@ -17,6 +18,7 @@ const (
 	DocTypeBook        DocType = "book"
 	DocTypeContributor DocType = "contributor"
 	DocTypeSeries      DocType = "series"
+	DocTypePodcast     DocType = "podcast"
 )
 
 // SearchDocument is the unified document structure for the Bleve index.
@@ -50,17 +52,28 @@ type SearchDocument struct {
 	// Genre slugs for exact matching
 	GenreSlugs []string `json:"genre_slugs,omitempty"`
 
+	// Library the book or podcast belongs to
+	LibraryID string `json:"library_id,omitempty"`
+
 	// Tags - community-applied content descriptors
 	Tags []string `json:"tags,omitempty"`
 
 	// Contributor-specific fields
 	Biography string `json:"biography,omitempty"`
 
+	// Search language the text fields are stemmed in (see searchLanguages).
+	// Empty means English.
+	Language string `json:"language,omitempty"`
+
 	// Numeric fields for range queries and sorting
 	Duration    int64 `json:"duration,omitempty"`     // Milliseconds (books only)
 	PublishYear int   `json:"publish_year,omitempty"` // (books only)
 	BookCount   int   `json:"book_count,omitempty"`   // (contributors/series only)
 
+	// Community rating from user reviews (books only)
+	Rating      float64 `json:"rating,omitempty"`       // Average stars, 0 when unrated
+	RatingCount int     `json:"rating_count,omitempty"` // Number of reviews
+
 	// Timestamps for sorting
 	CreatedAt int64 `json:"created_at"` // Unix millis
 	UpdatedAt int64 `json:"updated_at"` // Unix millis
@@ -107,6 +120,9 @@ func (d *SearchDocument) ToMap() map[string]any {
 	if len(d.GenreSlugs) > 0 {
 		m["genre_slugs"] = d.GenreSlugs
 	}
+	if d.LibraryID != "" {
+		m["library_id"] = d.LibraryID
+	}
 	if len(d.Tags) > 0 {
 		m["tags"] = d.Tags
 	}
@@ -119,6 +135,18 @@ func (d *SearchDocument) ToMap() map[string]any {
 	if d.BookCount > 0 {
 		m["book_count"] = d.BookCount
 	}
+	if d.RatingCount > 0 {
+		m["rating"] = d.Rating
+		m["rating_count"] = d.RatingCount
+	}
+
+	// Copy text fields into their language-analyzed fields
+	language := cmp.Or(d.Language, defaultLanguage)
+	for _, field := range languageFields {
+		if text, ok := m[field].(string); ok && text != "" {
+			m[languageField(field, language)] = text
+		}
+	}
 
 	return m
 }
@@ -143,7 +171,9 @@ func BookToSearchDocument(
 		SeriesName:  seriesName,
 		GenrePaths:  genrePaths,
 		GenreSlugs:  genreSlugs,
+		LibraryID:   book.LibraryID,
 		Duration:    book.TotalDuration,
+		Language:    documentLanguage(book.Language, book.Description),
 		CreatedAt:   book.CreatedAt.UnixMilli(),
 		UpdatedAt:   book.UpdatedAt.UnixMilli(),
 	}
@@ -166,11 +196,27 @@ func ContributorToSearchDocument(c *domain.Contributor, bookCount int) *SearchDo
 		Name:      c.Name,
 		Biography: c.Biography,
 		BookCount: bookCount,
+		Language:  documentLanguage("", c.Biography),
 		CreatedAt: c.CreatedAt.UnixMilli(),
 		UpdatedAt: c.UpdatedAt.UnixMilli(),
 	}
 }
 
+// PodcastToSearchDocument converts a domain Podcast to a SearchDocument.
+func PodcastToSearchDocument(p *domain.Podcast) *SearchDocument {
+	return &SearchDocument{
+		ID:          p.ID,
+		Type:        DocTypePodcast,
+		Name:        p.Title,
+		Description: p.Description,
+		Author:      p.Author,
+		LibraryID:   p.LibraryID,
+		Language:    documentLanguage(p.Language, p.Description),
+		CreatedAt:   p.CreatedAt.UnixMilli(),
+		UpdatedAt:   p.UpdatedAt.UnixMilli(),
+	}
+}
+
 // SeriesToSearchDocument converts a domain Series to a SearchDocument.
 func SeriesToSearchDocument(s *domain.Series) *SearchDocument {
 	return &SearchDocument{
@@ -179,6 +225,7 @@ func SeriesToSearchDocument(s *domain.Series) *SearchDocument {
 		Name:        s.Name,
 		Description: s.Description,
 		BookCount:   0, // BookCount must be provided by caller since TotalBooks was removed from Series
+		Language:    documentLanguage("", s.Description),
 		CreatedAt:   s.CreatedAt.UnixMilli(),
 		UpdatedAt:   s.UpdatedAt.UnixMilli(),
 	}
@@ -0,0 +1,164 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/analysis/lang/de"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/lang/es"
+	"github.com/blevesearch/bleve/v2/analysis/lang/fr"
+	"github.com/blevesearch/bleve/v2/analysis/lang/it"
+	"github.com/blevesearch/bleve/v2/analysis/lang/nl"
+	"github.com/blevesearch/bleve/v2/analysis/lang/pt"
+	"github.com/blevesearch/bleve/v2/analysis/lang/ru"
+	"github.com/blevesearch/bleve/v2/analysis/lang/sv"
+
+	"github.com/listenupapp/listenup-server/internal/normalize"
+)
+
+// defaultLanguage is used when a document's language is unknown and can't be detected.
+const defaultLanguage = "en"
+
+// searchLanguages are the languages with their own analyzer, in mapping and
+// query order. Chinese, Japanese and Korean share the CJK bigram analyzer.
+//
+//nolint:gochecknoglobals // Static lookup table
+var searchLanguages = []string{"en", "de", "es", "fr", "it", "nl", "pt", "ru", "sv", "cjk"}
+
+// languageAnalyzers maps search languages to their Bleve analyzer.
+//
+//nolint:gochecknoglobals // Static lookup table
+var languageAnalyzers = map[string]string{
+	"en":  en.AnalyzerName,
+	"de":  de.AnalyzerName,
+	"es":  es.AnalyzerName,
+	"fr":  fr.AnalyzerName,
+	"it":  it.AnalyzerName,
+	"nl":  nl.AnalyzerName,
+	"pt":  pt.AnalyzerName,
+	"ru":  ru.AnalyzerName,
+	"sv":  sv.AnalyzerName,
+	"cjk": cjk.AnalyzerName,
+}
+
+// languageFields are the text fields indexed a second time with the
+// document's language analyzer, as "<field>_<language>" (e.g. "name_de").
+// The base field keeps language-neutral tokens for prefix, fuzzy and
+// highlighting across every language.
+//
+//nolint:gochecknoglobals // Static lookup table
+var languageFields = []string{"name", "subtitle", "description", "author", "series_name", "biography"}
+
+// languageField returns the name of a field's copy for a search language.
+func languageField(field, language string) string {
+	return field + "_" + language
+}
+
+// documentLanguage picks the search language for a document: the declared
+// language when it has an analyzer, otherwise the language detected from
+// sample text (usually the description), otherwise English.
+func documentLanguage(declared, sample string) string {
+	if lang := searchLanguage(normalize.LanguageCode(declared)); lang != "" {
+		return lang
+	}
+	if lang := detectLanguage(sample); lang != "" {
+		return lang
+	}
+	return defaultLanguage
+}
+
+// searchLanguage maps an ISO 639-1 code to its search language, or "" when
+// the language has no analyzer.
+func searchLanguage(code string) string {
+	switch code {
+	case "ja", "zh", "ko":
+		return "cjk"
+	}
+	if _, ok := languageAnalyzers[code]; ok {
+		return code
+	}
+	return ""
+}
+
+// detectSampleRunes caps how much text detection looks at.
+const detectSampleRunes = 2000
+
+// languageStopwords are common, fairly distinctive short words per language.
+// Latin-script text is attributed to the language with the most hits.
+//
+//nolint:gochecknoglobals // Static lookup table
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "that", "with", "his", "her", "was", "he", "she", "this", "from"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "sich", "ein", "eine", "auf", "für", "dem", "den", "wird"},
+	"es": {"el", "los", "las", "y", "del", "una", "por", "con", "para", "su", "sus", "como", "pero", "es"},
+	"fr": {"le", "la", "les", "et", "est", "une", "dans", "des", "du", "pour", "qui", "pas", "sur", "avec", "il", "elle"},
+	"it": {"il", "gli", "della", "di", "che", "è", "non", "per", "una", "sono", "nel", "alla", "anche"},
+	"nl": {"de", "het", "een", "en", "van", "dat", "niet", "op", "met", "voor", "zijn", "ook", "hij", "zij"},
+	"pt": {"o", "os", "as", "e", "do", "da", "dos", "das", "não", "uma", "com", "em", "seu", "sua"},
+	"sv": {"och", "att", "det", "som", "är", "på", "för", "med", "inte", "han", "hon", "av", "den"},
+}
+
+// detectLanguage guesses the search language of text. Scripts decide CJK and
+// Russian; Latin-script languages are told apart by stopword counts. Returns
+// "" when the text is too short or ambiguous to tell.
+func detectLanguage(text string) string {
+	var letters, cjkRunes, cyrillic int
+	var sample strings.Builder
+	n := 0
+	for _, r := range text {
+		if n == detectSampleRunes {
+			break
+		}
+		n++
+		sample.WriteRune(r)
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			cjkRunes++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+	// CJK text often mixes in Latin names and numbers
+	if cjkRunes*5 >= letters {
+		return "cjk"
+	}
+	if cyrillic*2 > letters {
+		return "ru"
+	}
+
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(sample.String()), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		for lang, stopwords := range languageStopwords {
+			if slices.Contains(stopwords, word) {
+				counts[lang]++
+			}
+		}
+	}
+
+	best, bestCount, tied := "", 0, false
+	for _, lang := range searchLanguages {
+		switch c := counts[lang]; {
+		case c > bestCount:
+			best, bestCount, tied = lang, c, false
+		case c == bestCount && c > 0:
+			tied = true
+		}
+	}
+	if bestCount < 2 || tied {
+		return ""
+	}
+	return best
+}
@@ -1,27 +1,42 @@
 package search
 
 import (
+	"fmt"
+
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
 	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
 	"github.com/blevesearch/bleve/v2/analysis/analyzer/simple"
-	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
 )
 
+// textAnalyzerName is the language-neutral analyzer for base text fields:
+// Unicode word segmentation and lowercasing, no stemming or stop words.
+const textAnalyzerName = "text"
+
 // buildIndexMapping creates the Bleve index mapping for search documents.
 //
 // The mapping is designed with these priorities:
-//  1. Fast full-text search on names/titles with English stemming
+//  1. Fast full-text search on names/titles, stemmed in the document's language
 //  2. Boosted relevance for author/narrator matches
 //  3. Exact keyword matching for type and genre filters
-//  4. Numeric range queries for duration and year
+//  4. Numeric range queries for duration, year and community rating
 //  5. Term vectors enabled on key fields for highlighting
 func buildIndexMapping() mapping.IndexMapping {
 	// Create the index mapping
 	indexMapping := bleve.NewIndexMapping()
 
-	// Use English analyzer as default for text fields
-	indexMapping.DefaultAnalyzer = en.AnalyzerName
+	// Language-neutral analyzer as default for text fields
+	if err := indexMapping.AddCustomAnalyzer(textAnalyzerName, map[string]any{
+		"type":          custom.Name,
+		"tokenizer":     unicode.Name,
+		"token_filters": []any{lowercase.Name},
+	}); err != nil {
+		panic(fmt.Sprintf("register %s analyzer: %v", textAnalyzerName, err))
+	}
+	indexMapping.DefaultAnalyzer = textAnalyzerName
 
 	// Create document mapping
 	docMapping := bleve.NewDocumentMapping()
@@ -30,50 +45,61 @@ func buildIndexMapping() mapping.IndexMapping {
 
 	// Name field - primary search target, boosted
 	nameFieldMapping := bleve.NewTextFieldMapping()
-	nameFieldMapping.Analyzer = en.AnalyzerName
+	nameFieldMapping.Analyzer = textAnalyzerName
 	nameFieldMapping.Store = true
 	nameFieldMapping.IncludeTermVectors = true // For highlighting
 	docMapping.AddFieldMappingsAt("name", nameFieldMapping)
 
 	// Subtitle - searchable text
 	subtitleFieldMapping := bleve.NewTextFieldMapping()
-	subtitleFieldMapping.Analyzer = en.AnalyzerName
+	subtitleFieldMapping.Analyzer = textAnalyzerName
 	subtitleFieldMapping.Store = true
 	docMapping.AddFieldMappingsAt("subtitle", subtitleFieldMapping)
 
 	// Description - searchable but not stored (too large)
 	descFieldMapping := bleve.NewTextFieldMapping()
-	descFieldMapping.Analyzer = en.AnalyzerName
+	descFieldMapping.Analyzer = textAnalyzerName
 	descFieldMapping.Store = false
 	docMapping.AddFieldMappingsAt("description", descFieldMapping)
 
 	// Author - searchable, important for book search
 	authorFieldMapping := bleve.NewTextFieldMapping()
-	authorFieldMapping.Analyzer = en.AnalyzerName
+	authorFieldMapping.Analyzer = textAnalyzerName
 	authorFieldMapping.Store = true
 	authorFieldMapping.IncludeTermVectors = true // For highlighting
 	docMapping.AddFieldMappingsAt("author", authorFieldMapping)
 
 	// Narrator - searchable
 	narratorFieldMapping := bleve.NewTextFieldMapping()
-	narratorFieldMapping.Analyzer = en.AnalyzerName
+	narratorFieldMapping.Analyzer = textAnalyzerName
 	narratorFieldMapping.Store = true
 	narratorFieldMapping.IncludeTermVectors = true // For highlighting
 	docMapping.AddFieldMappingsAt("narrator", narratorFieldMapping)
 
 	// Series name - searchable
 	seriesFieldMapping := bleve.NewTextFieldMapping()
-	seriesFieldMapping.Analyzer = en.AnalyzerName
+	seriesFieldMapping.Analyzer = textAnalyzerName
 	seriesFieldMapping.Store = true
 	seriesFieldMapping.IncludeTermVectors = true // For highlighting
 	docMapping.AddFieldMappingsAt("series_name", seriesFieldMapping)
 
 	// Biography - searchable for contributors
 	bioFieldMapping := bleve.NewTextFieldMapping()
-	bioFieldMapping.Analyzer = en.AnalyzerName
+	bioFieldMapping.Analyzer = textAnalyzerName
 	bioFieldMapping.Store = false
 	docMapping.AddFieldMappingsAt("biography", bioFieldMapping)
 
+	// Language copies of the text fields (name_de, description_fr, ...).
+	// Each document fills only the copies for its own language.
+	for _, field := range languageFields {
+		for _, lang := range searchLanguages {
+			langFieldMapping := bleve.NewTextFieldMapping()
+			langFieldMapping.Analyzer = languageAnalyzers[lang]
+			langFieldMapping.Store = false
+			docMapping.AddFieldMappingsAt(languageField(field, lang), langFieldMapping)
+		}
+	}
+
 	// Publisher - searchable with simple analyzer (no stemming)
 	publisherFieldMapping := bleve.NewTextFieldMapping()
 	publisherFieldMapping.Analyzer = simple.Name
@@ -104,6 +130,11 @@ func buildIndexMapping() mapping.IndexMapping {
 	genreSlugsFieldMapping.Store = true // Store for retrieval in search results
 	docMapping.AddFieldMappingsAt("genre_slugs", genreSlugsFieldMapping)
 
+	// Library ID - for scoping book results to one library
+	libraryIDFieldMapping := bleve.NewTextFieldMapping()
+	libraryIDFieldMapping.Analyzer = keyword.Name
+	docMapping.AddFieldMappingsAt("library_id", libraryIDFieldMapping)
+
 	// Tags - community-applied content descriptors
 	// Keyword analyzer keeps compound slugs intact (e.g., "slow-burn")
 	tagsFieldMapping := bleve.NewTextFieldMapping()
@@ -129,6 +160,15 @@ func buildIndexMapping() mapping.IndexMapping {
 	bookCountFieldMapping.Store = true
 	docMapping.AddFieldMappingsAt("book_count", bookCountFieldMapping)
 
+	// Community rating and review count - for filtering and sorting books
+	ratingFieldMapping := bleve.NewNumericFieldMapping()
+	ratingFieldMapping.Store = true
+	docMapping.AddFieldMappingsAt("rating", ratingFieldMapping)
+
+	ratingCountFieldMapping := bleve.NewNumericFieldMapping()
+	ratingCountFieldMapping.Store = true
+	docMapping.AddFieldMappingsAt("rating_count", ratingCountFieldMapping)
+
 	// Timestamps - for sorting by recency
 	createdAtFieldMapping := bleve.NewNumericFieldMapping()
 	createdAtFieldMapping.Store = true
@@ -22,13 +22,15 @@ type SearchParams struct {
 	MaxDuration int64    // Maximum duration in ms (books only)
 	MinYear     int      // Minimum publish year
 	MaxYear     int      // Maximum publish year
+	MinRating   float64  // Minimum community rating (books only; excludes unrated books)
+	LibraryID   string   // Restrict books and podcasts to one library; contributors and series are shared
 
 	// Pagination
 	Limit  int
 	Offset int
 
 	// Sorting
-	SortBy    string // "relevance", "title", "author", "recent", "duration"
+	SortBy    string // "relevance", "title", "author", "recent", "duration", "rating"
 	SortOrder string // "asc", "desc"
 
 	// Options
@@ -61,19 +63,21 @@ type SearchResult struct {
 
 // SearchHit represents a single search result.
 type SearchHit struct {
-	ID         string            `json:"id"`
-	Type       DocType           `json:"type"`
-	Score      float64           `json:"score"`
-	Name       string            `json:"name"`
-	Subtitle   string            `json:"subtitle,omitempty"`
-	Author     string            `json:"author,omitempty"`
-	Narrator   string            `json:"narrator,omitempty"`
-	SeriesName string            `json:"series_name,omitempty"`
-	Duration   int64             `json:"duration,omitempty"`
-	BookCount  int               `json:"book_count,omitempty"`
-	GenreSlugs []string          `json:"genre_slugs,omitempty"`
-	Tags       []string          `json:"tags,omitempty"`
-	Highlights map[string]string `json:"highlights,omitempty"`
+	ID          string            `json:"id"`
+	Type        DocType           `json:"type"`
+	Score       float64           `json:"score"`
+	Name        string            `json:"name"`
+	Subtitle    string            `json:"subtitle,omitempty"`
+	Author      string            `json:"author,omitempty"`
+	Narrator    string            `json:"narrator,omitempty"`
+	SeriesName  string            `json:"series_name,omitempty"`
+	Duration    int64             `json:"duration,omitempty"`
+	BookCount   int               `json:"book_count,omitempty"`
+	Rating      float64           `json:"rating,omitempty"`
+	RatingCount int               `json:"rating_count,omitempty"`
+	GenreSlugs  []string          `json:"genre_slugs,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Highlights  map[string]string `json:"highlights,omitempty"`
 }
 
 // SearchFacets contains facet counts.
@@ -121,7 +125,7 @@ func (s *SearchIndex) Search(ctx context.Context, params SearchParams) (*SearchR
 	// Request stored fields
 	searchRequest.Fields = []string{
 		"id", "type", "name", "subtitle", "author", "narrator",
-		"series_name", "duration", "book_count",
+		"series_name", "duration", "book_count", "rating", "rating_count",
 	}
 
 	// Execute search
@@ -169,6 +173,12 @@ func (s *SearchIndex) Search(ctx context.Context, params SearchParams) (*SearchR
 		if bc, ok := hit.Fields["book_count"].(float64); ok {
 			searchHit.BookCount = int(bc)
 		}
+		if r, ok := hit.Fields["rating"].(float64); ok {
+			searchHit.Rating = r
+		}
+		if rc, ok := hit.Fields["rating_count"].(float64); ok {
+			searchHit.RatingCount = int(rc)
+		}
 
 		// Extract genre slugs (stored as array)
 		if gs, ok := hit.Fields["genre_slugs"].([]any); ok {
@@ -229,20 +239,23 @@ func buildSearchQuery(params SearchParams) query.Query {
 	// We DON'T search author/narrator fields on books because that returns
 	// every book by "Peter" when you search for "Peter", when you really
 	// want Peter Pan (the book) or Peter Smith (the contributor).
+	//
+	// Titles and series names are matched in every search language, since the
+	// query's language is unknown; each document only fills the fields for
+	// its own language. The language-neutral base field adds exact word
+	// matches in languages without an analyzer.
 	if params.Query != "" {
 		textQueries := []query.Query{}
 
 		// Name/title match with highest boost
+		textQueries = append(textQueries, languageMatchQueries(params.Query, "name", 3.0)...)
 		nameMatch := bleve.NewMatchQuery(params.Query)
 		nameMatch.SetField("name")
-		nameMatch.SetBoost(3.0)
+		nameMatch.SetBoost(1.0)
 		textQueries = append(textQueries, nameMatch)
 
 		// Series name match (for books in a series)
-		seriesMatch := bleve.NewMatchQuery(params.Query)
-		seriesMatch.SetField("series_name")
-		seriesMatch.SetBoost(1.5)
-		textQueries = append(textQueries, seriesMatch)
+		textQueries = append(textQueries, languageMatchQueries(params.Query, "series_name", 1.5)...)
 
 		// Add fuzzy matching for typo tolerance on name
 		fuzzyQuery := bleve.NewFuzzyQuery(params.Query)
@@ -274,6 +287,27 @@ func buildSearchQuery(params SearchParams) query.Query {
 		queries = append(queries, bleve.NewDisjunctionQuery(typeQueries...))
 	}
 
+	// Library filter: books and podcasts must belong to the library.
+	// Contributors and series are not tied to a library and always pass.
+	if params.LibraryID != "" {
+		bookType := bleve.NewTermQuery(string(DocTypeBook))
+		bookType.SetField("type")
+		podcastType := bleve.NewTermQuery(string(DocTypePodcast))
+		podcastType.SetField("type")
+		library := bleve.NewTermQuery(params.LibraryID)
+		library.SetField("library_id")
+		contributorType := bleve.NewTermQuery(string(DocTypeContributor))
+		contributorType.SetField("type")
+		seriesType := bleve.NewTermQuery(string(DocTypeSeries))
+		seriesType.SetField("type")
+		queries = append(queries, bleve.NewDisjunctionQuery(
+			bleve.NewConjunctionQuery(bookType, library),
+			bleve.NewConjunctionQuery(podcastType, library),
+			contributorType,
+			seriesType,
+		))
+	}
+
 	// Genre slug filter (exact match, OR across slugs)
 	if len(params.GenreSlugs) > 0 {
 		genreQueries := make([]query.Query, len(params.GenreSlugs))
@@ -316,6 +350,14 @@ func buildSearchQuery(params SearchParams) query.Query {
 		queries = append(queries, rangeQuery)
 	}
 
+	// Community rating filter (unrated books have no rating field, so never match)
+	if params.MinRating > 0 {
+		minVal := params.MinRating
+		rangeQuery := bleve.NewNumericRangeQuery(&minVal, nil)
+		rangeQuery.SetField("rating")
+		queries = append(queries, rangeQuery)
+	}
+
 	// Combine all queries with AND
 	if len(queries) == 0 {
 		return bleve.NewMatchAllQuery()
@@ -326,6 +368,19 @@ func buildSearchQuery(params SearchParams) query.Query {
 	return bleve.NewConjunctionQuery(queries...)
 }
 
+// languageMatchQueries matches text against each language copy of a field,
+// analyzing it with that language's analyzer.
+func languageMatchQueries(text, field string, boost float64) []query.Query {
+	queries := make([]query.Query, 0, len(searchLanguages))
+	for _, lang := range searchLanguages {
+		match := bleve.NewMatchQuery(text)
+		match.SetField(languageField(field, lang))
+		match.SetBoost(boost)
+		queries = append(queries, match)
+	}
+	return queries
+}
+
 // addSorting configures sort order.
 func addSorting(req *bleve.SearchRequest, params SearchParams) {
 	switch params.SortBy {
@@ -353,6 +408,13 @@ func addSorting(req *bleve.SearchRequest, params SearchParams) {
 		} else {
 			req.SortBy([]string{"-duration"})
 		}
+	case "rating":
+		// Ties broken by review count so well-reviewed books beat a single 5-star
+		if params.SortOrder == "asc" {
+			req.SortBy([]string{"rating", "-rating_count"})
+		} else {
+			req.SortBy([]string{"-rating", "-rating_count"})
+		}
 	default:
 		// Relevance (score) is default - Bleve handles this
 		req.SortBy([]string{"-_score"})
@@ -260,6 +260,140 @@ func TestSearchIndex_Search_Duration(t *testing.T) {
 	assert.Equal(t, "book-2", result.Hits[0].ID)
 }
 
+func TestSearchIndex_Search_Rating(t *testing.T) {
+	index, cleanup := setupTestIndex(t)
+	defer cleanup()
+
+	docs := []*SearchDocument{
+		{ID: "book-1", Type: DocTypeBook, Name: "Loved Book", Rating: 4.5, RatingCount: 12},
+		{ID: "book-2", Type: DocTypeBook, Name: "One Fan Book", Rating: 5, RatingCount: 1},
+		{ID: "book-3", Type: DocTypeBook, Name: "Mixed Book", Rating: 2.5, RatingCount: 4},
+		{ID: "book-4", Type: DocTypeBook, Name: "Unrated Book"},
+	}
+
+	err := index.IndexDocuments(docs)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Filter by minimum rating excludes low-rated and unrated books
+	result, err := index.Search(ctx, SearchParams{
+		MinRating: 4,
+		SortBy:    "rating",
+		SortOrder: "desc",
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), result.Total)
+	assert.Equal(t, "book-2", result.Hits[0].ID)
+	assert.Equal(t, "book-1", result.Hits[1].ID)
+	assert.InDelta(t, 4.5, result.Hits[1].Rating, 0.001)
+	assert.Equal(t, 12, result.Hits[1].RatingCount)
+}
+
+func TestSearchIndex_Search_Library(t *testing.T) {
+	index, cleanup := setupTestIndex(t)
+	defer cleanup()
+
+	docs := []*SearchDocument{
+		{ID: "book-1", Type: DocTypeBook, Name: "Dragon Rider", LibraryID: "lib-kids"},
+		{ID: "book-2", Type: DocTypeBook, Name: "Dragon Reborn", LibraryID: "lib-adults"},
+		{ID: "contrib-1", Type: DocTypeContributor, Name: "Dragon Expert"},
+	}
+
+	err := index.IndexDocuments(docs)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Books from other libraries are excluded; contributors are shared.
+	result, err := index.Search(ctx, SearchParams{
+		Query:     "dragon",
+		LibraryID: "lib-kids",
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	ids := make([]string, len(result.Hits))
+	for i, hit := range result.Hits {
+		ids[i] = hit.ID
+	}
+	assert.ElementsMatch(t, []string{"book-1", "contrib-1"}, ids)
+}
+
+func TestSearchIndex_Search_Language(t *testing.T) {
+	index, cleanup := setupTestIndex(t)
+	defer cleanup()
+
+	docs := []*SearchDocument{
+		{ID: "book-de", Type: DocTypeBook, Name: "Grimms Märchen", Language: "de"},
+		{ID: "book-ja", Type: DocTypeBook, Name: "ノルウェイの森", Language: "cjk"},
+		{ID: "book-en", Type: DocTypeBook, Name: "Fairy Tales"},
+	}
+
+	err := index.IndexDocuments(docs)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// German normalization folds umlauts, so the unaccented spelling matches
+	result, err := index.Search(ctx, SearchParams{Query: "Marchen", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Hits, 1)
+	assert.Equal(t, "book-de", result.Hits[0].ID)
+
+	// CJK titles match on the CJK analyzer
+	result, err = index.Search(ctx, SearchParams{Query: "ノルウェイ", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Hits, 1)
+	assert.Equal(t, "book-ja", result.Hits[0].ID)
+}
+
+func TestSearchDocument_ToMap_Language(t *testing.T) {
+	t.Parallel()
+	doc := &SearchDocument{ID: "book-1", Type: DocTypeBook, Name: "Le Petit Prince", Description: "Un conte", Language: "fr"}
+	m := doc.ToMap()
+	assert.Equal(t, "Le Petit Prince", m["name_fr"])
+	assert.Equal(t, "Un conte", m["description_fr"])
+	assert.NotContains(t, m, "name_en")
+	assert.NotContains(t, m, "author_fr", "empty fields get no language copy")
+
+	// Documents without a language are English
+	m = (&SearchDocument{ID: "book-2", Type: DocTypeBook, Name: "The Little Prince"}).ToMap()
+	assert.Equal(t, "The Little Prince", m["name_en"])
+}
+
+func TestDocumentLanguage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		declared string
+		sample   string
+		want     string
+	}{
+		{"declared code", "de", "", "de"},
+		{"declared locale", "fr-CA", "", "fr"},
+		{"declared name", "Spanish", "", "es"},
+		{"japanese shares cjk", "jpn", "", "cjk"},
+		{"declared wins over sample", "it", "The story of a boy and his dog.", "it"},
+		{"unsupported falls back to sample", "pl", "Die Geschichte eines Jungen und seines Hundes, der nicht mit ihm spricht.", "de"},
+		{"detect german", "", "Die Geschichte eines Jungen und seines Hundes, der nicht mit ihm spricht.", "de"},
+		{"detect french", "", "Un jeune prince quitte sa planète et voyage dans les étoiles pour trouver des amis qui sont avec lui.", "fr"},
+		{"detect spanish", "", "La historia de los Buendía y del pueblo de Macondo, contada por uno de sus descendientes con humor.", "es"},
+		{"detect english", "", "The story of a boy and his dog, who travel from the city to the sea.", "en"},
+		{"detect japanese", "", "僕は三十七歳で、そのときボーイング747のシートに座っていた。", "cjk"},
+		{"detect russian", "", "Все счастливые семьи похожи друг на друга, каждая несчастливая семья несчастлива по-своему.", "ru"},
+		{"too short defaults to english", "", "Dune", "en"},
+		{"empty defaults to english", "", "", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, documentLanguage(tt.declared, tt.sample))
+		})
+	}
+}
+
 func TestSearchIndex_Rebuild(t *testing.T) {
 	index, cleanup := setupTestIndex(t)
 	defer cleanup()
@@ -352,6 +486,7 @@ func TestBookToSearchDocument(t *testing.T) {
 	assert.Equal(t, 2023, doc.PublishYear)
 	assert.Equal(t, []string{"/fiction/fantasy"}, doc.GenrePaths)
 	assert.Equal(t, []string{"fantasy"}, doc.GenreSlugs)
+	assert.Equal(t, "en", doc.Language)
 }
 
 func TestContributorToSearchDocument(t *testing.T) {
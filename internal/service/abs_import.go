@@ -184,6 +184,16 @@ func (s *ABSImportService) CreateABSImportProgress(ctx context.Context, progress
 	return s.store.CreateABSImportProgress(ctx, progress)
 }
 
+// GetABSImportProgress returns a staged media progress row.
+func (s *ABSImportService) GetABSImportProgress(ctx context.Context, importID, absUserID, absMediaID string) (*domain.ABSImportProgress, error) {
+	return s.store.GetABSImportProgress(ctx, importID, absUserID, absMediaID)
+}
+
+// UpdateABSImportProgress refreshes a staged media progress row after a re-sync.
+func (s *ABSImportService) UpdateABSImportProgress(ctx context.Context, progress *domain.ABSImportProgress) error {
+	return s.store.UpdateABSImportProgress(ctx, progress)
+}
+
 // ListABSImportProgressForUser returns all media progress entries for an ABS user.
 func (s *ABSImportService) ListABSImportProgressForUser(ctx context.Context, importID, absUserID string) ([]*domain.ABSImportProgress, error) {
 	return s.store.ListABSImportProgressForUser(ctx, importID, absUserID)
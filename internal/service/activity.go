@@ -89,7 +89,7 @@ func (s *ActivityService) RecordBookStarted(ctx context.Context, userID, bookID
 		return fmt.Errorf("create activity: %w", err)
 	}
 
-	s.broadcastActivity(activity)
+	s.broadcastActivity(ctx, activity)
 
 	action := "started"
 	if isReread {
@@ -143,7 +143,7 @@ func (s *ActivityService) RecordBookFinished(ctx context.Context, userID, bookID
 		return fmt.Errorf("create activity: %w", err)
 	}
 
-	s.broadcastActivity(activity)
+	s.broadcastActivity(ctx, activity)
 
 	s.logger.Info("activity recorded",
 		"type", activity.Type,
@@ -154,6 +154,58 @@ func (s *ActivityService) RecordBookFinished(ctx context.Context, userID, bookID
 	return nil
 }
 
+// RecordBookReviewed creates an activity when a user reviews a book.
+// Only the first review of a book is recorded; edits don't repeat it in the feed.
+func (s *ActivityService) RecordBookReviewed(ctx context.Context, userID, bookID string, rating float64) error {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	book, err := s.store.GetBookByID(ctx, bookID)
+	if err != nil {
+		return fmt.Errorf("get book: %w", err)
+	}
+
+	activityID, err := id.Generate("act")
+	if err != nil {
+		return fmt.Errorf("generate activity ID: %w", err)
+	}
+
+	avatarType, avatarValue := s.getUserAvatarInfo(ctx, userID)
+
+	activity := &domain.Activity{
+		ID:              activityID,
+		UserID:          userID,
+		Type:            domain.ActivityReviewedBook,
+		CreatedAt:       time.Now(),
+		UserDisplayName: user.Name(),
+		UserAvatarColor: color.ForUser(userID),
+		UserAvatarType:  avatarType,
+		UserAvatarValue: avatarValue,
+		BookID:          bookID,
+		BookTitle:       book.Title,
+		BookAuthorName:  getAuthorName(ctx, s.store, book),
+		BookCoverPath:   getBookCoverPath(book),
+		Rating:          rating,
+	}
+
+	if err := s.store.CreateActivity(ctx, activity); err != nil {
+		return fmt.Errorf("create activity: %w", err)
+	}
+
+	s.broadcastActivity(ctx, activity)
+
+	s.logger.Info("activity recorded",
+		"type", activity.Type,
+		"user_id", userID,
+		"book_id", bookID,
+		"rating", rating,
+	)
+
+	return nil
+}
+
 // RecordStreakMilestone creates an activity when a user hits a streak milestone.
 func (s *ActivityService) RecordStreakMilestone(ctx context.Context, userID string, days int) error {
 	if !domain.IsStreakMilestone(days) {
@@ -189,7 +241,7 @@ func (s *ActivityService) RecordStreakMilestone(ctx context.Context, userID stri
 		return fmt.Errorf("create activity: %w", err)
 	}
 
-	s.broadcastActivity(activity)
+	s.broadcastActivity(ctx, activity)
 
 	s.logger.Info("streak milestone recorded",
 		"user_id", userID,
@@ -230,7 +282,7 @@ func (s *ActivityService) RecordListeningMilestone(ctx context.Context, userID s
 		return fmt.Errorf("create activity: %w", err)
 	}
 
-	s.broadcastActivity(activity)
+	s.broadcastActivity(ctx, activity)
 
 	s.logger.Info("listening milestone recorded",
 		"user_id", userID,
@@ -240,6 +292,47 @@ func (s *ActivityService) RecordListeningMilestone(ctx context.Context, userID s
 	return nil
 }
 
+// RecordGoalCompleted creates an activity when a user reaches a listening goal.
+func (s *ActivityService) RecordGoalCompleted(ctx context.Context, userID string, goal *domain.ListeningGoal) error {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	activityID, err := id.Generate("act")
+	if err != nil {
+		return fmt.Errorf("generate activity ID: %w", err)
+	}
+
+	avatarType, avatarValue := s.getUserAvatarInfo(ctx, userID)
+
+	activity := &domain.Activity{
+		ID:              activityID,
+		UserID:          userID,
+		Type:            domain.ActivityGoalCompleted,
+		CreatedAt:       time.Now(),
+		UserDisplayName: user.Name(),
+		UserAvatarColor: color.ForUser(userID),
+		UserAvatarType:  avatarType,
+		UserAvatarValue: avatarValue,
+		MilestoneValue:  goal.Target,
+		MilestoneUnit:   string(goal.Metric),
+	}
+
+	if err := s.store.CreateActivity(ctx, activity); err != nil {
+		return fmt.Errorf("create activity: %w", err)
+	}
+
+	s.broadcastActivity(ctx, activity)
+
+	s.logger.Info("goal completion recorded",
+		"user_id", userID,
+		"goal_id", goal.ID,
+	)
+
+	return nil
+}
+
 // RecordShelfCreated creates an activity when a user creates a shelf.
 func (s *ActivityService) RecordShelfCreated(ctx context.Context, userID string, shelf *domain.Shelf) error {
 	user, err := s.store.GetUser(ctx, userID)
@@ -271,7 +364,7 @@ func (s *ActivityService) RecordShelfCreated(ctx context.Context, userID string,
 		return fmt.Errorf("create activity: %w", err)
 	}
 
-	s.broadcastActivity(activity)
+	s.broadcastActivity(ctx, activity)
 
 	s.logger.Info("shelf created activity recorded",
 		"user_id", userID,
@@ -344,7 +437,7 @@ func (s *ActivityService) RecordListeningSession(ctx context.Context, userID, bo
 		return fmt.Errorf("create activity: %w", err)
 	}
 
-	s.broadcastActivity(activity)
+	s.broadcastActivity(ctx, activity)
 
 	s.logger.Info("listening session activity recorded",
 		"user_id", userID,
@@ -384,7 +477,7 @@ func (s *ActivityService) RecordUserJoined(ctx context.Context, userID string) e
 		return fmt.Errorf("create activity: %w", err)
 	}
 
-	s.broadcastActivity(activity)
+	s.broadcastActivity(ctx, activity)
 
 	s.logger.Info("user joined activity recorded",
 		"user_id", userID,
@@ -394,7 +487,8 @@ func (s *ActivityService) RecordUserJoined(ctx context.Context, userID string) e
 }
 
 // GetFeed retrieves the global activity feed with ACL filtering.
-// Only returns activities for books the viewing user can access.
+// Only returns activities for books the viewing user can access, and leaves
+// out other users' activity they have made private.
 // beforeID is optional — when provided with before, enables deterministic cursor pagination.
 func (s *ActivityService) GetFeed(ctx context.Context, viewingUserID string, limit int, before *time.Time, beforeID string) ([]*domain.Activity, error) {
 	// Overfetch to account for ACL filtering
@@ -405,13 +499,19 @@ func (s *ActivityService) GetFeed(ctx context.Context, viewingUserID string, lim
 		return nil, fmt.Errorf("fetching activities: %w", err)
 	}
 
-	// Filter activities based on book access
+	// Filter activities based on privacy settings and book access
+	privacy := newSocialPrivacy(s.store)
 	filtered := make([]*domain.Activity, 0, limit)
 	for _, activity := range activities {
 		if len(filtered) >= limit {
 			break
 		}
 
+		// Users always see their own activity, others only what they share
+		if activity.UserID != viewingUserID && privacy.hidesActivity(ctx, activity.UserID, activity.BookID) {
+			continue
+		}
+
 		// Non-book activities are always visible
 		if activity.BookID == "" {
 			filtered = append(filtered, activity)
@@ -452,10 +552,15 @@ func (s *ActivityService) GetBookActivities(ctx context.Context, bookID string,
 }
 
 // broadcastActivity sends the activity to all connected SSE clients.
-func (s *ActivityService) broadcastActivity(activity *domain.Activity) {
+// Activity the user keeps private is stored for their own feed but never
+// broadcast, since the event goes to every user.
+func (s *ActivityService) broadcastActivity(ctx context.Context, activity *domain.Activity) {
 	if s.sseManager == nil {
 		return
 	}
+	if newSocialPrivacy(s.store).hidesActivity(ctx, activity.UserID, activity.BookID) {
+		return
+	}
 	s.sseManager.Emit(sse.NewActivityEvent(activity))
 }
 
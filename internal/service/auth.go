@@ -43,6 +43,7 @@ type AuthService struct {
 	tokenService    *auth.TokenService
 	sessionService  *SessionService
 	instanceService *InstanceService
+	twoFactor       *TwoFactorService
 	logger          *slog.Logger
 }
 
@@ -52,6 +53,7 @@ func NewAuthService(
 	tokenService *auth.TokenService,
 	sessionService *SessionService,
 	instanceService *InstanceService,
+	twoFactor *TwoFactorService,
 	logger *slog.Logger,
 ) *AuthService {
 	return &AuthService{
@@ -59,6 +61,7 @@ func NewAuthService(
 		tokenService:    tokenService,
 		sessionService:  sessionService,
 		instanceService: instanceService,
+		twoFactor:       twoFactor,
 		logger:          logger,
 	}
 }
@@ -79,6 +82,14 @@ type LoginRequest struct {
 	IPAddress  string          `json:"-"` // Extracted from request by handler
 }
 
+// TwoFactorLoginRequest completes a login that returned a two-factor challenge.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string          `json:"challenge_token" validate:"required"`
+	Code           string          `json:"code" validate:"required,max=64"` // TOTP or recovery code
+	DeviceInfo     auth.DeviceInfo `json:"device_info"`
+	IPAddress      string          `json:"-"` // Extracted from request by handler
+}
+
 // RefreshRequest contains the refresh token and updated device info.
 type RefreshRequest struct {
 	RefreshToken string          `json:"refresh_token" validate:"required"`
@@ -104,6 +115,16 @@ type RegisterResponse struct {
 type AuthResponse struct {
 	User *domain.User `json:"user"`
 	SessionResponse
+
+	// TwoFactorChallenge is set instead of tokens and user when the password
+	// was right but the user must still enter a second factor.
+	TwoFactorChallenge *TwoFactorChallenge `json:"two_factor_challenge,omitempty"`
+}
+
+// TwoFactorChallenge is the first half of a two-factor login.
+type TwoFactorChallenge struct {
+	Token     string `json:"challenge_token"`
+	ExpiresIn int    `json:"expires_in"` // Seconds until the challenge expires
 }
 
 // Setup creates the first user (root) and completes initial server configuration.
@@ -292,11 +313,78 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*AuthRespons
 		return nil, domainerrors.InvalidCredentials("invalid email or password")
 	}
 
+	return s.LoginVerified(ctx, user, req.DeviceInfo, req.IPAddress)
+}
+
+// LoginVerified logs in a user whose identity has already been checked,
+// by password or by a single sign-on provider. Pending users are refused,
+// and users with two-factor on get a challenge instead of tokens.
+func (s *AuthService) LoginVerified(ctx context.Context, user *domain.User, deviceInfo auth.DeviceInfo, ipAddress string) (*AuthResponse, error) {
 	// Check if user is pending approval
 	if user.IsPending() {
 		return nil, domainerrors.Forbidden("your account is pending admin approval")
 	}
 
+	// With two-factor on, the first factor only earns a challenge
+	enabled, err := s.twoFactor.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challengeID, err := s.twoFactor.StartChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		token, err := s.tokenService.GenerateChallengeToken(user.ID, challengeID)
+		if err != nil {
+			return nil, fmt.Errorf("generate challenge token: %w", err)
+		}
+		return &AuthResponse{
+			TwoFactorChallenge: &TwoFactorChallenge{
+				Token:     token,
+				ExpiresIn: int(auth.ChallengeTokenDuration.Seconds()),
+			},
+		}, nil
+	}
+
+	return s.completeLogin(ctx, user, deviceInfo, ipAddress)
+}
+
+// LoginTwoFactor completes a login with the challenge token from Login and
+// a TOTP or recovery code.
+func (s *AuthService) LoginTwoFactor(ctx context.Context, req TwoFactorLoginRequest) (*AuthResponse, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+	if !req.DeviceInfo.IsValid() {
+		return nil, domainerrors.Validation("device_info is required (device_type and platform)")
+	}
+
+	userID, challengeID, err := s.tokenService.VerifyChallengeToken(req.ChallengeToken)
+	if err != nil {
+		return nil, domainerrors.TokenExpired("login challenge is invalid or expired, sign in again").WithCause(err)
+	}
+
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, domainerrors.InvalidCredentials("invalid email or password")
+		}
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+	if user.IsPending() {
+		return nil, domainerrors.Forbidden("your account is pending admin approval")
+	}
+
+	if err := s.twoFactor.VerifyChallengeCode(ctx, user.ID, challengeID, req.Code); err != nil {
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, user, req.DeviceInfo, req.IPAddress)
+}
+
+// completeLogin records the login and starts a session.
+func (s *AuthService) completeLogin(ctx context.Context, user *domain.User, deviceInfo auth.DeviceInfo, ipAddress string) (*AuthResponse, error) {
 	// Update last login
 	user.LastLoginAt = time.Now()
 	user.Touch()
@@ -311,7 +399,7 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*AuthRespons
 	}
 
 	// Create session
-	sessionResp, err := s.sessionService.CreateSession(ctx, user, req.DeviceInfo, req.IPAddress)
+	sessionResp, err := s.sessionService.CreateSession(ctx, user, deviceInfo, ipAddress)
 	if err != nil {
 		return nil, fmt.Errorf("create session: %w", err)
 	}
@@ -319,7 +407,7 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*AuthRespons
 	if s.logger != nil {
 		s.logger.Info("User logged in",
 			"user_id", user.ID,
-			"device", req.DeviceInfo.Platform,
+			"device", deviceInfo.Platform,
 		)
 	}
 
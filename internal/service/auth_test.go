@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/hex"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
@@ -59,13 +60,14 @@ func setupAuthTest(t *testing.T) (*AuthService, *InstanceService, *auth.TokenSer
 	require.NoError(t, err)
 
 	// Create session service
-	sessionService := NewSessionService(s, tokenService, nil)
+	sessionService := NewSessionService(s, tokenService, nil, nil)
 
 	// Create instance service
 	instanceService := NewInstanceService(s, nil, cfg)
 
 	// Create auth service
-	authService := NewAuthService(s, tokenService, sessionService, instanceService, nil)
+	twoFactorService := NewTwoFactorService(s, slog.New(slog.DiscardHandler))
+	authService := NewAuthService(s, tokenService, sessionService, instanceService, twoFactorService, nil)
 
 	// Cleanup function
 	cleanup := func() {
@@ -13,8 +13,10 @@ import (
 	"time"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
 	"github.com/listenupapp/listenup-server/internal/genre"
 	"github.com/listenupapp/listenup-server/internal/media/images"
+	"github.com/listenupapp/listenup-server/internal/metadata"
 	"github.com/listenupapp/listenup-server/internal/metadata/audible"
 	"github.com/listenupapp/listenup-server/internal/scanner"
 	"github.com/listenupapp/listenup-server/internal/search/asyncindexer"
@@ -23,14 +25,23 @@ import (
 
 // ApplyMatchOptions contains the user's field selections for a match operation.
 type ApplyMatchOptions struct {
-	Fields    MatchFields
-	Authors   []string // Audible ASINs
-	Narrators []string // Audible ASINs
+	Fields MatchFields
+	// Authors and Narrators select contributors by provider ID (the ASIN for
+	// Audible), or by name for providers that have no contributor IDs.
+	Authors   []string
+	Narrators []string
 	Series    []SeriesMatchEntry
 	Genres    []string
 	CoverURL  string // Explicit cover URL (overrides Audible if provided)
 }
 
+// selectsAny reports whether any metadata was selected to apply.
+func (o ApplyMatchOptions) selectsAny() bool {
+	f := o.Fields
+	return f.Title || f.Subtitle || f.Description || f.Publisher || f.ReleaseDate || f.Language || f.Cover ||
+		len(o.Authors) > 0 || len(o.Narrators) > 0 || len(o.Series) > 0 || len(o.Genres) > 0
+}
+
 // ApplyMatchResult contains the book and cover download result.
 type ApplyMatchResult struct {
 	Book        *domain.Book
@@ -46,11 +57,12 @@ type MatchFields struct {
 	ReleaseDate bool
 	Language    bool
 	Cover       bool
+	ASIN        bool // Store the provider's ASIN on its own
 }
 
 // SeriesMatchEntry specifies a series match with granular control.
 type SeriesMatchEntry struct {
-	ASIN          string
+	ID            string // Provider series ID (the ASIN for Audible), or the name when it has none
 	ApplyName     bool
 	ApplySequence bool
 }
@@ -124,6 +136,7 @@ func (s *BookService) ListBooks(ctx context.Context, userID string, params store
 	if err != nil {
 		return nil, fmt.Errorf("get accessible books: %w", err)
 	}
+	accessibleBooks = booksInLibrary(accessibleBooks, params.LibraryID)
 
 	// Apply cursor-based pagination manually
 	total := len(accessibleBooks)
@@ -288,6 +301,10 @@ func (s *BookService) TriggerScan(ctx context.Context, libraryID string, opts sc
 		return nil, fmt.Errorf("get library: %w", err)
 	}
 
+	if library.IsPodcast() {
+		return nil, domainerrors.Validation("podcast libraries are not scanned; refresh their podcasts instead")
+	}
+
 	if len(library.ScanPaths) == 0 {
 		return nil, errors.New("library has no scan paths configured")
 	}
@@ -309,6 +326,14 @@ func (s *BookService) TriggerScan(ctx context.Context, libraryID string, opts sc
 	for _, scanPath := range library.ScanPaths {
 		s.logger.Info("scanning path", "library_id", libraryID, "path", scanPath)
 
+		// Claim books already under this path, e.g. after the path moved
+		// here from another library.
+		moved, err := s.store.AssignBooksToLibrary(ctx, libraryID, scanPath)
+		if err != nil {
+			s.logger.Error("failed to assign books to library", "path", scanPath, "error", err)
+		}
+		s.reindexBooks(ctx, moved)
+
 		result, err := s.scanner.Scan(ctx, scanPath, opts)
 		if err != nil {
 			s.logger.Error("scan failed for path", "path", scanPath, "error", err)
@@ -338,6 +363,19 @@ func (s *BookService) TriggerScan(ctx context.Context, libraryID string, opts sc
 	return aggregated, nil
 }
 
+// reindexBooks queues search index updates for books whose indexed fields
+// changed outside the normal update path.
+func (s *BookService) reindexBooks(ctx context.Context, bookIDs []string) {
+	for _, bookID := range bookIDs {
+		book, err := s.store.GetBookByID(ctx, bookID)
+		if err != nil {
+			s.logger.Warn("failed to load book for reindex", "book_id", bookID, "error", err)
+			continue
+		}
+		s.indexer.SubmitIndexBook(book)
+	}
+}
+
 // ScanFolder scans a specific folder and returns the book data without saving.
 // Useful for previewing what would be scanned.
 func (s *BookService) ScanFolder(ctx context.Context, folderPath string) (*domain.Book, error) {
@@ -361,6 +399,27 @@ func (s *BookService) RepairBookRelationships(ctx context.Context) (int, error)
 	return s.scanner.RepairBookRelationships(ctx)
 }
 
+// matchRegion returns the Audible region to match a book in: the requested
+// region if valid, else the book's library region, else nil for the server
+// default.
+func (s *BookService) matchRegion(ctx context.Context, book *domain.Book, region string) *audible.Region {
+	if r := audible.Region(region); r.Valid() {
+		return &r
+	}
+	if book.LibraryID == "" {
+		return nil
+	}
+	lib, err := s.store.GetLibrary(ctx, book.LibraryID)
+	if err != nil {
+		s.logger.Warn("failed to get library for metadata region", "library_id", book.LibraryID, "error", err)
+		return nil
+	}
+	if r := audible.Region(lib.MetadataRegion); r.Valid() {
+		return &r
+	}
+	return nil
+}
+
 // ApplyMatch applies selected Audible metadata to a local book.
 func (s *BookService) ApplyMatch(
 	ctx context.Context,
@@ -373,14 +432,7 @@ func (s *BookService) ApplyMatch(
 		return nil, err
 	}
 
-	// Parse region
-	var audibleRegion *audible.Region
-	if region != "" {
-		r := audible.Region(region)
-		if r.Valid() {
-			audibleRegion = &r
-		}
-	}
+	audibleRegion := s.matchRegion(ctx, book, region)
 
 	// Fetch metadata from Audible
 	audibleBook, err := s.metadataService.GetBook(ctx, audibleRegion, asin)
@@ -439,13 +491,15 @@ func (s *BookService) ApplyMatch(
 		book.AudibleRegion = string(*audibleRegion)
 	}
 
+	match := metadataFromAudible(asin, audibleBook)
+
 	// Apply contributors using smart merge:
 	// - If authors selected: replace existing authors with selected Audible authors
 	// - If no authors selected: preserve existing authors
 	// - Same logic for narrators
 	// - Always preserve other roles (editor, translator, etc.)
 	if len(opts.Authors) > 0 || len(opts.Narrators) > 0 {
-		contributors, err := s.mergeContributors(ctx, book.Contributors, audibleBook, opts.Authors, opts.Narrators)
+		contributors, err := s.mergeContributors(ctx, book.Contributors, match, opts.Authors, opts.Narrators)
 		if err != nil {
 			return nil, fmt.Errorf("merge contributors: %w", err)
 		}
@@ -454,7 +508,7 @@ func (s *BookService) ApplyMatch(
 
 	// Apply series
 	if len(opts.Series) > 0 {
-		seriesLinks, err := s.resolveSeries(ctx, audibleBook.Series, opts.Series)
+		seriesLinks, err := s.resolveSeries(ctx, match, opts.Series)
 		if err != nil {
 			return nil, fmt.Errorf("resolve series: %w", err)
 		}
@@ -523,14 +577,7 @@ func (s *BookService) ApplyMatchWithCoverResult(
 		return nil, err
 	}
 
-	// Parse region
-	var audibleRegion *audible.Region
-	if region != "" {
-		r := audible.Region(region)
-		if r.Valid() {
-			audibleRegion = &r
-		}
-	}
+	audibleRegion := s.matchRegion(ctx, book, region)
 
 	// Fetch metadata from Audible
 	audibleBook, err := s.metadataService.GetBook(ctx, audibleRegion, asin)
@@ -569,9 +616,11 @@ func (s *BookService) ApplyMatchWithCoverResult(
 		book.AudibleRegion = string(*audibleRegion)
 	}
 
+	match := metadataFromAudible(asin, audibleBook)
+
 	// Apply contributors
 	if len(opts.Authors) > 0 || len(opts.Narrators) > 0 {
-		contributors, err := s.mergeContributors(ctx, book.Contributors, audibleBook, opts.Authors, opts.Narrators)
+		contributors, err := s.mergeContributors(ctx, book.Contributors, match, opts.Authors, opts.Narrators)
 		if err != nil {
 			return nil, fmt.Errorf("merge contributors: %w", err)
 		}
@@ -580,7 +629,7 @@ func (s *BookService) ApplyMatchWithCoverResult(
 
 	// Apply series
 	if len(opts.Series) > 0 {
-		seriesLinks, err := s.resolveSeries(ctx, audibleBook.Series, opts.Series)
+		seriesLinks, err := s.resolveSeries(ctx, match, opts.Series)
 		if err != nil {
 			return nil, fmt.Errorf("resolve series: %w", err)
 		}
@@ -600,30 +649,7 @@ func (s *BookService) ApplyMatchWithCoverResult(
 
 	// Apply cover with detailed result
 	if opts.Fields.Cover {
-		// Use explicit URL if provided, otherwise use Audible's cover
-		coverURL := opts.CoverURL
-		if coverURL == "" {
-			coverURL = audibleBook.CoverURL
-		}
-
-		if coverURL != "" && s.coverService != nil {
-			coverResult := s.coverService.DownloadCover(ctx, bookID, coverURL)
-			result.CoverResult = coverResult
-
-			if coverResult.Applied {
-				// Update book's CoverImage metadata
-				book.CoverImage = &domain.ImageFileInfo{
-					Path:     book.ID + ".jpg",
-					Filename: "cover.jpg",
-					Format:   "image/jpeg",
-				}
-			}
-		} else if coverURL == "" {
-			result.CoverResult = &CoverDownloadResult{
-				Applied: false,
-				Error:   "no cover URL available",
-			}
-		}
+		result.CoverResult = s.applyMatchCover(ctx, book, opts.CoverURL, audibleBook.CoverURL)
 	}
 
 	// Update book
@@ -642,18 +668,148 @@ func (s *BookService) ApplyMatchWithCoverResult(
 	return result, nil
 }
 
-// mergeContributors performs a smart merge of Audible contributors with existing book contributors.
+// ApplyProviderMatch applies metadata from any configured provider and
+// returns detailed cover download results. id is the provider's book ID.
+// Contributors and series are matched by name unless the provider is Audible.
+func (s *BookService) ApplyProviderMatch(
+	ctx context.Context,
+	userID, bookID, provider, id string,
+	opts ApplyMatchOptions,
+) (*ApplyMatchResult, error) {
+	// Get book with ACL check
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := s.metadataService.GetProviderBook(ctx, provider, id)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s metadata: %w", provider, err)
+	}
+	if match.Title == "" && len(match.Authors) == 0 && len(match.Narrators) == 0 {
+		return nil, domainerrors.NotFoundf("%s returned empty metadata for %s", provider, id)
+	}
+
+	// Apply simple fields
+	if opts.Fields.Title {
+		book.Title = match.Title
+	}
+	if opts.Fields.Subtitle {
+		book.Subtitle = match.Subtitle
+	}
+	if opts.Fields.Description {
+		book.Description = match.Description
+	}
+	if opts.Fields.Publisher && match.Publisher != "" {
+		book.Publisher = match.Publisher
+	}
+	if opts.Fields.ReleaseDate && match.Year() != "" {
+		book.PublishYear = match.Year()
+	}
+	if opts.Fields.Language && match.Language != "" {
+		book.Language = match.Language
+	}
+
+	// Keep identifiers the provider knows, so later refreshes and other
+	// providers can find the book again. Applying nothing changes nothing.
+	if opts.selectsAny() || opts.Fields.ASIN {
+		if match.ASIN != "" {
+			book.ASIN = match.ASIN
+		}
+		if match.ISBN != "" && book.ISBN == "" {
+			book.ISBN = match.ISBN
+		}
+	}
+
+	// Apply contributors
+	if len(opts.Authors) > 0 || len(opts.Narrators) > 0 {
+		contributors, err := s.mergeContributors(ctx, book.Contributors, match, opts.Authors, opts.Narrators)
+		if err != nil {
+			return nil, fmt.Errorf("merge contributors: %w", err)
+		}
+		book.Contributors = contributors
+	}
+
+	// Apply series
+	if len(opts.Series) > 0 {
+		seriesLinks, err := s.resolveSeries(ctx, match, opts.Series)
+		if err != nil {
+			return nil, fmt.Errorf("resolve series: %w", err)
+		}
+		book.Series = seriesLinks
+	}
+
+	// Apply genres
+	if len(opts.Genres) > 0 {
+		genreIDs, err := s.resolveGenres(ctx, opts.Genres)
+		if err != nil {
+			return nil, fmt.Errorf("resolve genres: %w", err)
+		}
+		book.GenreIDs = genreIDs
+	}
+
+	result := &ApplyMatchResult{Book: book}
+	if opts.Fields.Cover {
+		result.CoverResult = s.applyMatchCover(ctx, book, opts.CoverURL, match.CoverURL)
+	}
+
+	// Update book
+	if err := s.store.UpdateBook(ctx, book); err != nil {
+		return nil, fmt.Errorf("update book: %w", err)
+	}
+	s.indexer.SubmitIndexBook(book)
+
+	s.logger.Info("Applied provider match",
+		"book_id", bookID,
+		"provider", provider,
+		"provider_id", id,
+		"cover_applied", result.CoverResult != nil && result.CoverResult.Applied,
+	)
+
+	return result, nil
+}
+
+// applyMatchCover downloads the explicitly chosen cover, or the match's own,
+// and points the book at it when it was applied.
+func (s *BookService) applyMatchCover(ctx context.Context, book *domain.Book, explicitURL, matchURL string) *CoverDownloadResult {
+	coverURL := explicitURL
+	if coverURL == "" {
+		coverURL = matchURL
+	}
+	if coverURL == "" {
+		return &CoverDownloadResult{
+			Applied: false,
+			Error:   "no cover URL available",
+		}
+	}
+	if s.coverService == nil {
+		return nil
+	}
+
+	coverResult := s.coverService.DownloadCover(ctx, book.ID, coverURL)
+	if coverResult.Applied {
+		// Update book's CoverImage metadata
+		book.CoverImage = &domain.ImageFileInfo{
+			Path:     book.ID + ".jpg",
+			Filename: "cover.jpg",
+			Format:   "image/jpeg",
+		}
+	}
+	return coverResult
+}
+
+// mergeContributors performs a smart merge of matched contributors with existing book contributors.
 // Rules:
-//   - If authorASINs is non-empty: replace all existing authors with resolved Audible authors
-//   - If authorASINs is empty: preserve all existing authors
+//   - If authorIDs is non-empty: replace all existing authors with the selected matched authors
+//   - If authorIDs is empty: preserve all existing authors
 //   - Same logic applies to narrators
 //   - Other roles (editor, translator, etc.) are always preserved
 //   - Deduplicates by contributor ID to avoid duplicates
 func (s *BookService) mergeContributors(
 	ctx context.Context,
 	existing []domain.BookContributor,
-	audibleBook *audible.Book,
-	authorASINs, narratorASINs []string,
+	match *metadata.Book,
+	authorIDs, narratorIDs []string,
 ) ([]domain.BookContributor, error) {
 	var result []domain.BookContributor
 	seen := make(map[string]int) // maps contributor ID to index in result for role merging
@@ -677,17 +833,10 @@ func (s *BookService) mergeContributors(
 	}
 
 	// Step 1: Handle authors
-	if len(authorASINs) > 0 {
-		// Replace: resolve selected Audible authors
-		selectedAuthors := make(map[string]bool)
-		for _, asin := range authorASINs {
-			selectedAuthors[asin] = true
-		}
-		for _, author := range audibleBook.Authors {
-			if !selectedAuthors[author.ASIN] {
-				continue
-			}
-			contributor, err := s.resolveContributor(ctx, author)
+	if len(authorIDs) > 0 {
+		// Replace: resolve selected matched authors
+		for _, author := range selectContributors(match.Authors, authorIDs) {
+			contributor, err := s.resolveContributor(ctx, match.Provider, author)
 			if err != nil {
 				return nil, err
 			}
@@ -703,17 +852,10 @@ func (s *BookService) mergeContributors(
 	}
 
 	// Step 2: Handle narrators
-	if len(narratorASINs) > 0 {
-		// Replace: resolve selected Audible narrators
-		selectedNarrators := make(map[string]bool)
-		for _, asin := range narratorASINs {
-			selectedNarrators[asin] = true
-		}
-		for _, narrator := range audibleBook.Narrators {
-			if !selectedNarrators[narrator.ASIN] {
-				continue
-			}
-			contributor, err := s.resolveContributor(ctx, narrator)
+	if len(narratorIDs) > 0 {
+		// Replace: resolve selected matched narrators
+		for _, narrator := range selectContributors(match.Narrators, narratorIDs) {
+			contributor, err := s.resolveContributor(ctx, match.Provider, narrator)
 			if err != nil {
 				return nil, err
 			}
@@ -740,11 +882,33 @@ func (s *BookService) mergeContributors(
 	return result, nil
 }
 
-// resolveContributor resolves a single Audible contributor.
-func (s *BookService) resolveContributor(ctx context.Context, audibleContrib audible.Contributor) (*domain.Contributor, error) {
+// selectContributors returns the contributors whose provider ID, or name
+// when they have none, was selected.
+func selectContributors(contributors []metadata.Contributor, selected []string) []metadata.Contributor {
+	var out []metadata.Contributor
+	for _, c := range contributors {
+		key := c.ID
+		if key == "" {
+			key = c.Name
+		}
+		if slices.Contains(selected, key) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// resolveContributor resolves a single matched contributor. Only Audible IDs
+// are ASINs; contributors from other providers are matched by name.
+func (s *BookService) resolveContributor(ctx context.Context, provider string, matched metadata.Contributor) (*domain.Contributor, error) {
+	var asin string
+	if provider == metadata.ProviderAudible {
+		asin = matched.ID
+	}
+
 	// 1. Try ASIN lookup first
-	if audibleContrib.ASIN != "" {
-		existing, err := s.store.GetContributorByASIN(ctx, audibleContrib.ASIN)
+	if asin != "" {
+		existing, err := s.store.GetContributorByASIN(ctx, asin)
 		if err == nil {
 			return existing, nil
 		}
@@ -754,19 +918,19 @@ func (s *BookService) resolveContributor(ctx context.Context, audibleContrib aud
 	}
 
 	// 2. Fall back to name matching
-	existing, err := s.store.GetOrCreateContributorByName(ctx, audibleContrib.Name)
+	existing, err := s.store.GetOrCreateContributorByName(ctx, matched.Name)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. Enrich with ASIN if found by name and missing ASIN
-	if existing.ASIN == "" && audibleContrib.ASIN != "" {
-		existing.ASIN = audibleContrib.ASIN
+	if existing.ASIN == "" && asin != "" {
+		existing.ASIN = asin
 		if err := s.store.UpdateContributor(ctx, existing); err != nil {
 			s.logger.Warn("Failed to enrich contributor with ASIN",
 				"error", err,
 				"contributor_id", existing.ID,
-				"asin", audibleContrib.ASIN,
+				"asin", asin,
 			)
 			// Continue without enrichment
 		} else {
@@ -777,10 +941,10 @@ func (s *BookService) resolveContributor(ctx context.Context, audibleContrib aud
 	return existing, nil
 }
 
-// resolveSeries resolves Audible series to local entities.
+// resolveSeries resolves matched series to local entities.
 func (s *BookService) resolveSeries(
 	ctx context.Context,
-	audibleSeries []audible.SeriesEntry,
+	match *metadata.Book,
 	selections []SeriesMatchEntry,
 ) ([]domain.BookSeries, error) {
 	var bookSeries []domain.BookSeries
@@ -788,17 +952,21 @@ func (s *BookService) resolveSeries(
 	// Build lookup map for selections
 	selectionMap := make(map[string]SeriesMatchEntry)
 	for _, sel := range selections {
-		selectionMap[sel.ASIN] = sel
+		selectionMap[sel.ID] = sel
 	}
 
-	for _, as := range audibleSeries {
-		sel, selected := selectionMap[as.ASIN]
+	for _, ms := range match.Series {
+		key := ms.ID
+		if key == "" {
+			key = ms.Name
+		}
+		sel, selected := selectionMap[key]
 		if !selected {
 			continue
 		}
 
 		// Resolve series entity
-		series, err := s.resolveSingleSeries(ctx, as)
+		series, err := s.resolveSingleSeries(ctx, match.Provider, ms)
 		if err != nil {
 			return nil, err
 		}
@@ -809,7 +977,7 @@ func (s *BookService) resolveSeries(
 
 		// Apply sequence only if selected
 		if sel.ApplySequence {
-			bs.Sequence = as.Position
+			bs.Sequence = ms.Position
 		}
 
 		bookSeries = append(bookSeries, bs)
@@ -818,11 +986,17 @@ func (s *BookService) resolveSeries(
 	return bookSeries, nil
 }
 
-// resolveSingleSeries resolves a single Audible series.
-func (s *BookService) resolveSingleSeries(ctx context.Context, audibleSeries audible.SeriesEntry) (*domain.Series, error) {
+// resolveSingleSeries resolves a single matched series. As with
+// contributors, only Audible series IDs are ASINs.
+func (s *BookService) resolveSingleSeries(ctx context.Context, provider string, matched metadata.SeriesEntry) (*domain.Series, error) {
+	var asin string
+	if provider == metadata.ProviderAudible {
+		asin = matched.ID
+	}
+
 	// 1. Try ASIN lookup first
-	if audibleSeries.ASIN != "" {
-		existing, err := s.store.GetSeriesByASIN(ctx, audibleSeries.ASIN)
+	if asin != "" {
+		existing, err := s.store.GetSeriesByASIN(ctx, asin)
 		if err == nil {
 			return existing, nil
 		}
@@ -832,19 +1006,19 @@ func (s *BookService) resolveSingleSeries(ctx context.Context, audibleSeries aud
 	}
 
 	// 2. Fall back to name matching
-	existing, err := s.store.GetOrCreateSeriesByName(ctx, audibleSeries.Name)
+	existing, err := s.store.GetOrCreateSeriesByName(ctx, matched.Name)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. Enrich with ASIN if found by name and missing ASIN
-	if existing.ASIN == "" && audibleSeries.ASIN != "" {
-		existing.ASIN = audibleSeries.ASIN
+	if existing.ASIN == "" && asin != "" {
+		existing.ASIN = asin
 		if err := s.store.UpdateSeries(ctx, existing); err != nil {
 			s.logger.Warn("Failed to enrich series with ASIN",
 				"error", err,
 				"series_id", existing.ID,
-				"asin", audibleSeries.ASIN,
+				"asin", asin,
 			)
 		} else {
 			s.indexer.SubmitIndexSeries(existing)
@@ -1,11 +1,21 @@
 package service
 
 import (
+	"context"
+	"log/slog"
+	"path/filepath"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/metadata"
+	"github.com/listenupapp/listenup-server/internal/search/asyncindexer"
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestMergeContributors_PreservesAuthorsWhenOnlyNarratorsSelected tests that
@@ -156,3 +166,58 @@ func mergeContributorsLogic(
 
 	return result
 }
+
+func TestApplyProviderMatch_SelectedFieldsOnly(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	logger := slog.New(slog.DiscardHandler)
+	hardcover := &fakeProvider{
+		name: metadata.ProviderHardcover,
+		book: &metadata.Book{Provider: metadata.ProviderHardcover, ID: "312", Title: "Dune", ASIN: "B002V1OF70", ISBN: "9780441013593"},
+	}
+	metadataService := NewMetadataService(nil, s, "us", logger, hardcover)
+	svc := NewBookService(s, nil, metadataService, nil, nil, asyncindexer.New(store.NewNoopSearchIndexer(), logger), logger)
+
+	user := createTestUserWithPermissions(t, s, "editor@example.com", false)
+	now := time.Now()
+	require.NoError(t, s.CreateBook(ctx, &domain.Book{
+		Syncable: domain.Syncable{ID: "book-1", CreatedAt: now, UpdatedAt: now},
+		Title:    "dune (unabridged)",
+		Path:     "/media/books/Dune",
+	}))
+
+	// Nothing selected leaves the book alone, identifiers included.
+	result, err := svc.ApplyProviderMatch(ctx, user.ID, "book-1", metadata.ProviderHardcover, "312", ApplyMatchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "dune (unabridged)", result.Book.Title)
+	assert.Empty(t, result.Book.ASIN)
+	assert.Empty(t, result.Book.ISBN)
+
+	// The ASIN can be asked for on its own.
+	result, err = svc.ApplyProviderMatch(ctx, user.ID, "book-1", metadata.ProviderHardcover, "312", ApplyMatchOptions{
+		Fields: MatchFields{ASIN: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dune (unabridged)", result.Book.Title)
+	assert.Equal(t, "B002V1OF70", result.Book.ASIN)
+
+	// Selected fields bring the identifiers along.
+	result, err = svc.ApplyProviderMatch(ctx, user.ID, "book-1", metadata.ProviderHardcover, "312", ApplyMatchOptions{
+		Fields: MatchFields{Title: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", result.Book.Title)
+	assert.Equal(t, "9780441013593", result.Book.ISBN)
+
+	// A provider with nothing to say is a not found, not a server error.
+	hardcover.book = &metadata.Book{Provider: metadata.ProviderHardcover, ID: "999"}
+	_, err = svc.ApplyProviderMatch(ctx, user.ID, "book-1", metadata.ProviderHardcover, "999", ApplyMatchOptions{
+		Fields: MatchFields{Title: true},
+	})
+	assert.ErrorIs(t, err, domainerrors.NotFound(""))
+}
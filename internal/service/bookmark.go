@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// bookmarkServiceStore is the narrow store interface BookmarkService depends on.
+type bookmarkServiceStore interface {
+	store.BookStore
+	store.BookmarkStore
+	GetAccessibleBookIDSet(ctx context.Context, userID string) (map[string]bool, error)
+}
+
+// BookmarkService manages per-user bookmarks and clips.
+type BookmarkService struct {
+	store  bookmarkServiceStore
+	events store.EventEmitter
+	logger *slog.Logger
+}
+
+// NewBookmarkService creates a new bookmark service.
+func NewBookmarkService(store bookmarkServiceStore, events store.EventEmitter, logger *slog.Logger) *BookmarkService {
+	return &BookmarkService{
+		store:  store,
+		events: events,
+		logger: logger,
+	}
+}
+
+// CreateBookmarkRequest contains the data for creating a bookmark or clip.
+type CreateBookmarkRequest struct {
+	ID            string `json:"id"` // Client-provided ID for offline creation (optional)
+	AudioFileID   string `json:"audio_file_id"`
+	PositionMs    int64  `json:"position_ms" validate:"gte=0"`
+	EndPositionMs *int64 `json:"end_position_ms" validate:"omitempty,gte=0"`
+	Title         string `json:"title" validate:"max=200"`
+	Note          string `json:"note" validate:"max=10000"`
+}
+
+// UpdateBookmarkRequest contains the fields that can be updated on a bookmark.
+// Nil fields are left unchanged. ClearEndPosition turns a clip back into a point bookmark.
+type UpdateBookmarkRequest struct {
+	AudioFileID      *string `json:"audio_file_id"`
+	PositionMs       *int64  `json:"position_ms" validate:"omitempty,gte=0"`
+	EndPositionMs    *int64  `json:"end_position_ms" validate:"omitempty,gte=0"`
+	ClearEndPosition bool    `json:"clear_end_position"`
+	Title            *string `json:"title" validate:"omitempty,max=200"`
+	Note             *string `json:"note" validate:"omitempty,max=10000"`
+}
+
+// ListBookmarks returns the user's bookmarks for a book, ordered by position.
+func (s *BookmarkService) ListBookmarks(ctx context.Context, userID, bookID string) ([]*domain.Bookmark, error) {
+	if _, err := s.store.GetBook(ctx, bookID, userID); err != nil {
+		return nil, err
+	}
+	return s.store.ListBookmarksForUserBook(ctx, userID, bookID)
+}
+
+// ListAllBookmarks returns the user's bookmarks across every book they can
+// still access, oldest first.
+func (s *BookmarkService) ListAllBookmarks(ctx context.Context, userID string) ([]*domain.Bookmark, error) {
+	bookmarks, err := s.store.ListBookmarksForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	accessible, err := s.store.GetAccessibleBookIDSet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*domain.Bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if accessible[b.BookID] {
+			visible = append(visible, b)
+		}
+	}
+	return visible, nil
+}
+
+// GetBookmark returns a single bookmark owned by the user on the given book.
+func (s *BookmarkService) GetBookmark(ctx context.Context, userID, bookID, bookmarkID string) (*domain.Bookmark, error) {
+	return s.getOwnedBookmark(ctx, userID, bookID, bookmarkID)
+}
+
+// CreateBookmark creates a bookmark (or clip, when EndPositionMs is set) on a book.
+// If the request carries a client-generated ID that already exists for this user,
+// the existing bookmark is returned so offline retries are idempotent.
+func (s *BookmarkService) CreateBookmark(ctx context.Context, userID, bookID string, req CreateBookmarkRequest) (*domain.Bookmark, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBookmarkRange(book, req.AudioFileID, req.PositionMs, req.EndPositionMs); err != nil {
+		return nil, err
+	}
+
+	bookmarkID := req.ID
+	if bookmarkID != "" {
+		existing, err := s.store.GetBookmark(ctx, bookmarkID)
+		if err == nil && existing != nil {
+			if existing.UserID != userID || existing.BookID != bookID {
+				return nil, domainerrors.Conflict("bookmark ID already in use")
+			}
+			return existing, nil
+		}
+	} else {
+		bookmarkID, err = id.Generate("bm")
+		if err != nil {
+			return nil, fmt.Errorf("generate bookmark ID: %w", err)
+		}
+	}
+
+	bookmark := &domain.Bookmark{
+		Syncable:      domain.Syncable{ID: bookmarkID},
+		UserID:        userID,
+		BookID:        bookID,
+		AudioFileID:   req.AudioFileID,
+		PositionMs:    req.PositionMs,
+		EndPositionMs: req.EndPositionMs,
+		Title:         req.Title,
+		Note:          req.Note,
+	}
+	bookmark.InitTimestamps()
+
+	if err := s.store.CreateBookmark(ctx, bookmark); err != nil {
+		// A deleted bookmark still holds its ID, and a sync client may
+		// replay a create for it.
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return nil, domainerrors.Conflict("bookmark ID already in use")
+		}
+		return nil, fmt.Errorf("create bookmark: %w", err)
+	}
+
+	s.logger.Info("bookmark created",
+		"bookmark_id", bookmark.ID,
+		"user_id", userID,
+		"book_id", bookID,
+		"clip", bookmark.IsClip(),
+	)
+
+	s.events.Emit(sse.NewBookmarkCreatedEvent(bookmark))
+
+	return bookmark, nil
+}
+
+// UpdateBookmark applies a partial update to a bookmark owned by the user.
+func (s *BookmarkService) UpdateBookmark(ctx context.Context, userID, bookID, bookmarkID string, req UpdateBookmarkRequest) (*domain.Bookmark, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	bookmark, err := s.getOwnedBookmark(ctx, userID, bookID, bookmarkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AudioFileID != nil {
+		bookmark.AudioFileID = *req.AudioFileID
+	}
+	if req.PositionMs != nil {
+		bookmark.PositionMs = *req.PositionMs
+	}
+	if req.ClearEndPosition {
+		bookmark.EndPositionMs = nil
+	} else if req.EndPositionMs != nil {
+		end := *req.EndPositionMs
+		bookmark.EndPositionMs = &end
+	}
+	if req.Title != nil {
+		bookmark.Title = *req.Title
+	}
+	if req.Note != nil {
+		bookmark.Note = *req.Note
+	}
+
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBookmarkRange(book, bookmark.AudioFileID, bookmark.PositionMs, bookmark.EndPositionMs); err != nil {
+		return nil, err
+	}
+
+	bookmark.Touch()
+
+	if err := s.store.UpdateBookmark(ctx, bookmark); err != nil {
+		return nil, fmt.Errorf("update bookmark: %w", err)
+	}
+
+	s.logger.Info("bookmark updated",
+		"bookmark_id", bookmark.ID,
+		"user_id", userID,
+		"book_id", bookID,
+	)
+
+	s.events.Emit(sse.NewBookmarkUpdatedEvent(bookmark))
+
+	return bookmark, nil
+}
+
+// DeleteBookmark soft-deletes a bookmark owned by the user.
+func (s *BookmarkService) DeleteBookmark(ctx context.Context, userID, bookID, bookmarkID string) error {
+	if _, err := s.getOwnedBookmark(ctx, userID, bookID, bookmarkID); err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteBookmark(ctx, bookmarkID); err != nil {
+		return fmt.Errorf("delete bookmark: %w", err)
+	}
+
+	s.logger.Info("bookmark deleted",
+		"bookmark_id", bookmarkID,
+		"user_id", userID,
+		"book_id", bookID,
+	)
+
+	s.events.Emit(sse.NewBookmarkDeletedEvent(userID, bookID, bookmarkID, time.Now()))
+
+	return nil
+}
+
+// getOwnedBookmark loads a bookmark and verifies it belongs to the user and book.
+// Bookmarks owned by other users are reported as not found so IDs don't leak.
+func (s *BookmarkService) getOwnedBookmark(ctx context.Context, userID, bookID, bookmarkID string) (*domain.Bookmark, error) {
+	bookmark, err := s.store.GetBookmark(ctx, bookmarkID)
+	if err != nil {
+		return nil, err
+	}
+	if bookmark.UserID != userID || bookmark.BookID != bookID {
+		return nil, store.ErrBookmarkNotFound
+	}
+	return bookmark, nil
+}
+
+// validateBookmarkRange checks that the positions fall inside the book and
+// that the referenced audio file (if any) belongs to it.
+func validateBookmarkRange(book *domain.Book, audioFileID string, positionMs int64, endPositionMs *int64) error {
+	if audioFileID != "" && book.GetAudioFileByID(audioFileID) == nil {
+		return domainerrors.Validationf("audio file %s does not belong to this book", audioFileID)
+	}
+	if endPositionMs != nil && *endPositionMs <= positionMs {
+		return domainerrors.Validation("clip end position must be after start position")
+	}
+	if book.TotalDuration > 0 {
+		if positionMs > book.TotalDuration {
+			return domainerrors.Validation("position is past the end of the book")
+		}
+		if endPositionMs != nil && *endPositionMs > book.TotalDuration {
+			return domainerrors.Validation("clip end position is past the end of the book")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+func TestBookmarkService_ReuseDeletedID(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	svc := NewBookmarkService(s, store.NewNoopEmitter(), slog.New(slog.DiscardHandler))
+	user := createTestUserWithPermissions(t, s, "listener@example.com", false)
+	now := time.Now()
+	require.NoError(t, s.CreateBook(ctx, &domain.Book{
+		Syncable: domain.Syncable{ID: "book-1", CreatedAt: now, UpdatedAt: now},
+		Title:    "Dune",
+		Path:     "/media/books/Dune",
+	}))
+
+	req := CreateBookmarkRequest{ID: "bm-offline-1", PositionMs: 60000, Title: "Arrakis"}
+	created, err := svc.CreateBookmark(ctx, user.ID, "book-1", req)
+	require.NoError(t, err)
+
+	// Replaying the create returns the same bookmark.
+	again, err := svc.CreateBookmark(ctx, user.ID, "book-1", req)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, again.ID)
+
+	// Once deleted, its ID can't be reused.
+	require.NoError(t, svc.DeleteBookmark(ctx, user.ID, "book-1", created.ID))
+	_, err = svc.CreateBookmark(ctx, user.ID, "book-1", req)
+	assert.ErrorIs(t, err, domainerrors.Conflict(""))
+}
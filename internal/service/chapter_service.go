@@ -1,14 +1,20 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/listenupapp/listenup-server/internal/chapters"
 	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/dto"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
 	"github.com/listenupapp/listenup-server/internal/metadata/audible"
+	"github.com/listenupapp/listenup-server/internal/sse"
 	"github.com/listenupapp/listenup-server/internal/store"
 )
 
@@ -20,10 +26,19 @@ type chapterServiceStore interface {
 	store.BookStore
 }
 
-// ChapterService handles chapter name alignment operations.
+// EmbeddedChapterReader re-reads the chapter markers embedded in a book's audio files.
+// Implemented by scanner.Scanner.
+type EmbeddedChapterReader interface {
+	ReadEmbeddedChapters(ctx context.Context, book *domain.Book) ([]domain.Chapter, error)
+}
+
+// ChapterService handles chapter name alignment and manual chapter editing.
 type ChapterService struct {
 	store           chapterServiceStore
 	metadataService *MetadataService
+	embedded        EmbeddedChapterReader
+	enricher        *dto.Enricher
+	events          store.EventEmitter
 	logger          *slog.Logger
 }
 
@@ -31,15 +46,43 @@ type ChapterService struct {
 func NewChapterService(
 	store chapterServiceStore,
 	metadataService *MetadataService,
+	embedded EmbeddedChapterReader,
+	enricher *dto.Enricher,
+	events store.EventEmitter,
 	logger *slog.Logger,
 ) *ChapterService {
 	return &ChapterService{
 		store:           store,
 		metadataService: metadataService,
+		embedded:        embedded,
+		enricher:        enricher,
+		events:          events,
 		logger:          logger,
 	}
 }
 
+// ChapterInput describes one chapter in a full chapter list replacement.
+type ChapterInput struct {
+	Title     string `json:"title" validate:"required,max=500"`
+	StartTime int64  `json:"start_time" validate:"gte=0"`
+}
+
+// UpdateChapterRequest renames a chapter and/or moves its start boundary.
+// Moving the start also moves the end of the previous chapter.
+type UpdateChapterRequest struct {
+	Title     *string `json:"title" validate:"omitempty,min=1,max=500"`
+	StartTime *int64  `json:"start_time" validate:"omitempty,gte=0"`
+}
+
+// GetChapters returns a book's chapters along with a generic-name analysis.
+func (s *ChapterService) GetChapters(ctx context.Context, userID, bookID string) ([]domain.Chapter, chapters.AnalysisResult, error) {
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, chapters.AnalysisResult{}, err
+	}
+	return book.Chapters, chapters.AnalyzeChapters(convertDomainChapters(book.Chapters)), nil
+}
+
 // SuggestChapterNames analyzes a book and returns alignment suggestions.
 func (s *ChapterService) SuggestChapterNames(
 	ctx context.Context,
@@ -133,8 +176,8 @@ func (s *ChapterService) ApplyChapterNames(
 	}
 
 	// Save book
-	if err := s.store.UpdateBook(ctx, book); err != nil {
-		return nil, fmt.Errorf("save book: %w", err)
+	if err := s.saveChapters(ctx, book); err != nil {
+		return nil, err
 	}
 
 	s.logger.Info("Applied chapter names",
@@ -145,6 +188,231 @@ func (s *ChapterService) ApplyChapterNames(
 	return book, nil
 }
 
+// ReplaceChapters replaces a book's chapter list. Chapters are sorted by start
+// time; end times and audio files are derived from the following chapter and
+// the book's audio layout.
+func (s *ChapterService) ReplaceChapters(ctx context.Context, userID, bookID string, inputs []ChapterInput) (*domain.Book, error) {
+	if len(inputs) == 0 {
+		return nil, domainerrors.Validation("at least one chapter is required")
+	}
+	for _, in := range inputs {
+		if err := validate.Struct(in); err != nil {
+			return nil, formatValidationError(err)
+		}
+	}
+
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	edited := make([]domain.Chapter, len(inputs))
+	for i, in := range inputs {
+		edited[i] = domain.Chapter{Title: strings.TrimSpace(in.Title), StartTime: in.StartTime}
+	}
+	slices.SortStableFunc(edited, func(a, b domain.Chapter) int {
+		return cmp.Compare(a.StartTime, b.StartTime)
+	})
+	if err := validateChapterStarts(book, edited); err != nil {
+		return nil, err
+	}
+
+	book.Chapters = edited
+	if err := s.saveChapters(ctx, book); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("replaced chapters", "book_id", bookID, "count", len(edited))
+	return book, nil
+}
+
+// UpdateChapter renames a chapter and/or shifts its start boundary.
+// The new start must stay strictly between the neighbouring chapters' starts.
+func (s *ChapterService) UpdateChapter(ctx context.Context, userID, bookID string, index int, req UpdateChapterRequest) (*domain.Book, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	book, err := s.getBookChapter(ctx, userID, bookID, index)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		book.Chapters[index].Title = strings.TrimSpace(*req.Title)
+	}
+	if req.StartTime != nil {
+		start := *req.StartTime
+		if index > 0 && start <= book.Chapters[index-1].StartTime {
+			return nil, domainerrors.Validation("chapter start must be after the previous chapter's start")
+		}
+		if index < len(book.Chapters)-1 && start >= book.Chapters[index+1].StartTime {
+			return nil, domainerrors.Validation("chapter start must be before the next chapter's start")
+		}
+		if book.TotalDuration > 0 && start >= book.TotalDuration {
+			return nil, domainerrors.Validation("chapter start is past the end of the book")
+		}
+		book.Chapters[index].StartTime = start
+	}
+
+	if err := s.saveChapters(ctx, book); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("updated chapter", "book_id", bookID, "index", index)
+	return book, nil
+}
+
+// SplitChapter splits a chapter in two at the given book-relative position.
+// The new chapter gets title, or the original chapter's title if empty.
+func (s *ChapterService) SplitChapter(ctx context.Context, userID, bookID string, index int, atMs int64, title string) (*domain.Book, error) {
+	book, err := s.getBookChapter(ctx, userID, bookID, index)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := book.Chapters[index]
+	if atMs <= ch.StartTime || atMs >= ch.EndTime {
+		return nil, domainerrors.Validationf("split position must be inside the chapter (%d-%d ms)", ch.StartTime, ch.EndTime)
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = ch.Title
+	}
+	if len(title) > 500 {
+		return nil, domainerrors.Validation("title must be at most 500 characters")
+	}
+
+	book.Chapters = slices.Insert(book.Chapters, index+1, domain.Chapter{Title: title, StartTime: atMs})
+	if err := s.saveChapters(ctx, book); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("split chapter", "book_id", bookID, "index", index, "at_ms", atMs)
+	return book, nil
+}
+
+// MergeChapters merges a chapter with the one that follows it.
+// The merged chapter keeps the first chapter's title.
+func (s *ChapterService) MergeChapters(ctx context.Context, userID, bookID string, index int) (*domain.Book, error) {
+	book, err := s.getBookChapter(ctx, userID, bookID, index)
+	if err != nil {
+		return nil, err
+	}
+	if index == len(book.Chapters)-1 {
+		return nil, domainerrors.Validation("the last chapter has no following chapter to merge with")
+	}
+
+	book.Chapters = slices.Delete(book.Chapters, index+1, index+2)
+	if err := s.saveChapters(ctx, book); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("merged chapters", "book_id", bookID, "index", index)
+	return book, nil
+}
+
+// ResetChapters discards edits and restores the chapters embedded in the audio files.
+func (s *ChapterService) ResetChapters(ctx context.Context, userID, bookID string) (*domain.Book, error) {
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, err := s.embedded.ReadEmbeddedChapters(ctx, book)
+	if err != nil {
+		return nil, fmt.Errorf("read embedded chapters: %w", err)
+	}
+
+	book.Chapters = embedded
+	if err := s.saveChapters(ctx, book); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("reset chapters to embedded", "book_id", bookID, "count", len(embedded))
+	return book, nil
+}
+
+// getBookChapter loads a book (with ACL check) and validates the chapter index.
+func (s *ChapterService) getBookChapter(ctx context.Context, userID, bookID string, index int) (*domain.Book, error) {
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(book.Chapters) {
+		return nil, domainerrors.NotFoundf("chapter %d not found", index)
+	}
+	return book, nil
+}
+
+// saveChapters normalizes the chapter list, persists the book and emits book.updated.
+func (s *ChapterService) saveChapters(ctx context.Context, book *domain.Book) error {
+	normalizeChapters(book)
+	book.Touch()
+
+	if err := s.store.UpdateBook(ctx, book); err != nil {
+		return fmt.Errorf("save book: %w", err)
+	}
+
+	if s.enricher != nil && s.events != nil {
+		enriched, err := s.enricher.EnrichBook(ctx, book)
+		if err != nil {
+			s.logger.Warn("failed to enrich book for SSE event", "book_id", book.ID, "error", err)
+			return nil
+		}
+		s.events.Emit(sse.NewBookUpdatedEvent(enriched))
+	}
+
+	return nil
+}
+
+// validateChapterStarts checks sorted chapter starts are unique and inside the book.
+func validateChapterStarts(book *domain.Book, sorted []domain.Chapter) error {
+	for i, ch := range sorted {
+		if i > 0 && ch.StartTime == sorted[i-1].StartTime {
+			return domainerrors.Validationf("two chapters start at %d ms", ch.StartTime)
+		}
+		if book.TotalDuration > 0 && ch.StartTime >= book.TotalDuration {
+			return domainerrors.Validationf("chapter %q starts past the end of the book", ch.Title)
+		}
+	}
+	return nil
+}
+
+// normalizeChapters re-derives indexes, end times and audio file IDs after an edit.
+// Each chapter ends where the next begins; the last ends at the book's duration.
+func normalizeChapters(book *domain.Book) {
+	for i := range book.Chapters {
+		ch := &book.Chapters[i]
+		ch.Index = i
+		switch {
+		case i < len(book.Chapters)-1:
+			ch.EndTime = book.Chapters[i+1].StartTime
+		case book.TotalDuration > 0:
+			ch.EndTime = book.TotalDuration
+		case ch.EndTime < ch.StartTime:
+			ch.EndTime = ch.StartTime
+		}
+		ch.AudioFileID = audioFileAt(book.AudioFiles, ch.StartTime)
+	}
+}
+
+// audioFileAt returns the ID of the audio file containing the book-relative position.
+func audioFileAt(files []domain.AudioFileInfo, positionMs int64) string {
+	if len(files) == 0 {
+		return ""
+	}
+	var offset int64
+	for _, af := range files {
+		offset += af.Duration
+		if positionMs < offset {
+			return af.ID
+		}
+	}
+	return files[len(files)-1].ID
+}
+
 func convertDomainChapters(dc []domain.Chapter) []chapters.Chapter {
 	result := make([]chapters.Chapter, len(dc))
 	for i, c := range dc {
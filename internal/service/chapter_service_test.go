@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChapterTestBook() *domain.Book {
+	return &domain.Book{
+		TotalDuration: 30000,
+		AudioFiles: []domain.AudioFileInfo{
+			{ID: "af-1", Duration: 10000},
+			{ID: "af-2", Duration: 20000},
+		},
+	}
+}
+
+// TestNormalizeChapters_DerivesEndTimesAndFiles tests that indexes, end times
+// and audio file IDs are recomputed from chapter starts.
+func TestNormalizeChapters_DerivesEndTimesAndFiles(t *testing.T) {
+	t.Parallel()
+	book := newChapterTestBook()
+	book.Chapters = []domain.Chapter{
+		{Title: "Opening", StartTime: 0, Index: 7},
+		{Title: "Middle", StartTime: 12000},
+		{Title: "End", StartTime: 25000, EndTime: 1},
+	}
+
+	normalizeChapters(book)
+
+	require.Len(t, book.Chapters, 3)
+	assert.Equal(t, domain.Chapter{Title: "Opening", Index: 0, StartTime: 0, EndTime: 12000, AudioFileID: "af-1"}, book.Chapters[0])
+	assert.Equal(t, domain.Chapter{Title: "Middle", Index: 1, StartTime: 12000, EndTime: 25000, AudioFileID: "af-2"}, book.Chapters[1])
+	assert.Equal(t, domain.Chapter{Title: "End", Index: 2, StartTime: 25000, EndTime: 30000, AudioFileID: "af-2"}, book.Chapters[2])
+}
+
+// TestValidateChapterStarts tests duplicate and out-of-range start detection.
+func TestValidateChapterStarts(t *testing.T) {
+	t.Parallel()
+	book := newChapterTestBook()
+
+	tests := []struct {
+		name     string
+		chapters []domain.Chapter
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			chapters: []domain.Chapter{{StartTime: 0}, {StartTime: 5000}},
+		},
+		{
+			name:     "duplicate start",
+			chapters: []domain.Chapter{{StartTime: 0}, {StartTime: 5000}, {StartTime: 5000}},
+			wantErr:  true,
+		},
+		{
+			name:     "past the end",
+			chapters: []domain.Chapter{{StartTime: 0}, {StartTime: 30000}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateChapterStarts(book, tt.chapters)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,612 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/dto"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/search"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+const (
+	feedTokenSize          = 32
+	maxFeedTokensPerUser   = 20
+	maxFeedTokenNameLength = 100
+
+	// How often LastUsedAt is written. Podcast apps poll feeds constantly;
+	// a write per request would be wasted.
+	feedTokenTouchInterval = time.Minute
+)
+
+// feedServiceStore is the narrow store interface FeedService depends on.
+type feedServiceStore interface {
+	store.FeedTokenStore
+	store.UserStore
+	store.BookStore
+	store.LibraryStore
+	store.CollectionStore
+	store.SeriesStore
+	store.ContributorStore
+	store.GenreStore
+}
+
+// FeedGroup is a library, series, contributor, genre or shelf as listed in
+// a feed catalog.
+type FeedGroup struct {
+	ID          string
+	Name        string
+	Description string
+	BookCount   int
+}
+
+// FeedService backs the OPDS catalog and podcast feeds: it manages the
+// per-user feed tokens those clients authenticate with, and answers the
+// catalog's browse queries. Every listing is limited to the books the user
+// can access.
+type FeedService struct {
+	store    feedServiceStore
+	shelves  *ShelfService
+	search   *SearchService
+	enricher *dto.Enricher
+	logger   *slog.Logger
+}
+
+// NewFeedService creates a new feed service.
+func NewFeedService(
+	store feedServiceStore,
+	shelves *ShelfService,
+	search *SearchService,
+	enricher *dto.Enricher,
+	logger *slog.Logger,
+) *FeedService {
+	return &FeedService{
+		store:    store,
+		shelves:  shelves,
+		search:   search,
+		enricher: enricher,
+		logger:   logger,
+	}
+}
+
+// CreateFeedToken creates a feed token for the user. The plaintext token is
+// returned once; only its hash is stored.
+func (s *FeedService) CreateFeedToken(ctx context.Context, userID, name string) (*domain.FeedToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", domainerrors.Validation("name is required")
+	}
+	if len(name) > maxFeedTokenNameLength {
+		return nil, "", domainerrors.Validationf("name must be at most %d characters", maxFeedTokenNameLength)
+	}
+
+	existing, err := s.store.ListFeedTokensForUser(ctx, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("list feed tokens: %w", err)
+	}
+	if len(existing) >= maxFeedTokensPerUser {
+		return nil, "", domainerrors.Validationf("at most %d feed tokens allowed; revoke one first", maxFeedTokensPerUser)
+	}
+
+	b := make([]byte, feedTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("generate feed token: %w", err)
+	}
+	plaintext := base64.RawURLEncoding.EncodeToString(b)
+
+	tokenID, err := id.Generate("feedtoken")
+	if err != nil {
+		return nil, "", fmt.Errorf("generate feed token ID: %w", err)
+	}
+
+	token := &domain.FeedToken{
+		ID:        tokenID,
+		UserID:    userID,
+		Name:      name,
+		TokenHash: auth.HashRefreshToken(plaintext),
+		CreatedAt: time.Now(),
+	}
+	if err := s.store.CreateFeedToken(ctx, token); err != nil {
+		return nil, "", fmt.Errorf("create feed token: %w", err)
+	}
+
+	s.logger.Info("feed token created",
+		"user_id", userID,
+		"token_id", token.ID,
+	)
+
+	return token, plaintext, nil
+}
+
+// ListFeedTokens returns the user's feed tokens, newest first.
+func (s *FeedService) ListFeedTokens(ctx context.Context, userID string) ([]*domain.FeedToken, error) {
+	return s.store.ListFeedTokensForUser(ctx, userID)
+}
+
+// RevokeFeedToken deletes one of the user's feed tokens. Feeds using it stop
+// working immediately.
+func (s *FeedService) RevokeFeedToken(ctx context.Context, userID, tokenID string) error {
+	token, err := s.store.GetFeedToken(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if token.UserID != userID {
+		return domainerrors.Forbidden("feed token belongs to another user")
+	}
+
+	if err := s.store.DeleteFeedToken(ctx, tokenID); err != nil {
+		return fmt.Errorf("delete feed token: %w", err)
+	}
+
+	s.logger.Info("feed token revoked",
+		"user_id", userID,
+		"token_id", tokenID,
+	)
+	return nil
+}
+
+// AuthenticateFeedToken resolves a plaintext feed token to its user.
+func (s *FeedService) AuthenticateFeedToken(ctx context.Context, plaintext string) (*domain.User, error) {
+	if plaintext == "" {
+		return nil, domainerrors.Unauthorized("feed token required")
+	}
+
+	token, err := s.store.GetFeedTokenByHash(ctx, auth.HashRefreshToken(plaintext))
+	if errors.Is(err, store.ErrFeedTokenNotFound) {
+		return nil, domainerrors.Unauthorized("invalid feed token")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get feed token: %w", err)
+	}
+
+	user, err := s.store.GetUser(ctx, token.UserID)
+	if errors.Is(err, store.ErrUserNotFound) {
+		return nil, domainerrors.Unauthorized("invalid feed token")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if !user.IsActive() {
+		return nil, domainerrors.Unauthorized("account is not active")
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= feedTokenTouchInterval {
+		if err := s.store.TouchFeedToken(ctx, token.ID, now); err != nil {
+			s.logger.Warn("failed to record feed token use",
+				"token_id", token.ID,
+				"error", err,
+			)
+		}
+	}
+
+	return user, nil
+}
+
+// CanAccessBook reports whether the user can access a book.
+func (s *FeedService) CanAccessBook(ctx context.Context, userID, bookID string) (bool, error) {
+	return s.store.CanUserAccessBook(ctx, userID, bookID)
+}
+
+// Book returns a single book if the user can access it.
+func (s *FeedService) Book(ctx context.Context, userID, bookID string) (*dto.Book, error) {
+	ok, err := s.store.CanUserAccessBook(ctx, userID, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("check book access: %w", err)
+	}
+	if !ok {
+		return nil, store.ErrBookNotFound
+	}
+
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.enricher.EnrichBook(ctx, book)
+}
+
+// Books returns every book the user can access, by title.
+func (s *FeedService) Books(ctx context.Context, userID string) ([]*dto.Book, error) {
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get books: %w", err)
+	}
+	sortBooksByTitle(books)
+	return s.enricher.EnrichBooks(ctx, books)
+}
+
+// RecentBooks returns the user's most recently added books.
+func (s *FeedService) RecentBooks(ctx context.Context, userID string, limit int) ([]*dto.Book, error) {
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get books: %w", err)
+	}
+	slices.SortFunc(books, func(a, b *domain.Book) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	if len(books) > limit {
+		books = books[:limit]
+	}
+	return s.enricher.EnrichBooks(ctx, books)
+}
+
+// Libraries lists the libraries holding books the user can access.
+func (s *FeedService) Libraries(ctx context.Context, userID string) ([]FeedGroup, error) {
+	libraries, err := s.store.ListLibraries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list libraries: %w", err)
+	}
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get books: %w", err)
+	}
+
+	var groups []FeedGroup
+	for _, lib := range libraries {
+		count := len(booksInLibrary(books, lib.ID))
+		if count == 0 {
+			continue
+		}
+		groups = append(groups, FeedGroup{ID: lib.ID, Name: lib.Name, BookCount: count})
+	}
+	sortGroupsByName(groups)
+	return groups, nil
+}
+
+// LibraryBooks returns the user's books in one library, by title.
+func (s *FeedService) LibraryBooks(ctx context.Context, userID, libraryID string) (*FeedGroup, []*dto.Book, error) {
+	lib, err := s.store.GetLibrary(ctx, libraryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get books: %w", err)
+	}
+
+	books = booksInLibrary(books, lib.ID)
+	sortBooksByTitle(books)
+	enriched, err := s.enricher.EnrichBooks(ctx, books)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &FeedGroup{ID: lib.ID, Name: lib.Name, BookCount: len(books)}, enriched, nil
+}
+
+// Series lists the series with books the user can access.
+func (s *FeedService) Series(ctx context.Context, userID string) ([]FeedGroup, error) {
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get books: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, b := range books {
+		for _, bs := range b.Series {
+			counts[bs.SeriesID]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	series, err := s.store.GetSeriesByIDs(ctx, slices.Collect(maps.Keys(counts)))
+	if err != nil {
+		return nil, fmt.Errorf("get series: %w", err)
+	}
+
+	groups := make([]FeedGroup, 0, len(series))
+	for _, se := range series {
+		groups = append(groups, FeedGroup{ID: se.ID, Name: se.Name, Description: se.Description, BookCount: counts[se.ID]})
+	}
+	sortGroupsByName(groups)
+	return groups, nil
+}
+
+// SeriesBooks returns the user's books in a series, in series order.
+func (s *FeedService) SeriesBooks(ctx context.Context, userID, seriesID string) (*FeedGroup, []*dto.Book, error) {
+	series, err := s.store.GetSeries(ctx, seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get books: %w", err)
+	}
+
+	sequence := make(map[string]string)
+	books = slices.DeleteFunc(books, func(b *domain.Book) bool {
+		for _, bs := range b.Series {
+			if bs.SeriesID == seriesID {
+				sequence[b.ID] = bs.Sequence
+				return false
+			}
+		}
+		return true
+	})
+	slices.SortStableFunc(books, func(a, b *domain.Book) int {
+		return compareSequence(sequence[a.ID], sequence[b.ID])
+	})
+
+	enriched, err := s.enricher.EnrichBooks(ctx, books)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &FeedGroup{ID: series.ID, Name: series.Name, Description: series.Description, BookCount: len(books)}, enriched, nil
+}
+
+// Contributors lists the authors, narrators and other contributors of books
+// the user can access.
+func (s *FeedService) Contributors(ctx context.Context, userID string) ([]FeedGroup, error) {
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get books: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, b := range books {
+		for _, bc := range b.Contributors {
+			counts[bc.ContributorID]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	contributors, err := s.store.GetContributorsByIDs(ctx, slices.Collect(maps.Keys(counts)))
+	if err != nil {
+		return nil, fmt.Errorf("get contributors: %w", err)
+	}
+
+	groups := make([]FeedGroup, 0, len(contributors))
+	for _, c := range contributors {
+		groups = append(groups, FeedGroup{ID: c.ID, Name: c.Name, BookCount: counts[c.ID]})
+	}
+	sortGroupsByName(groups)
+	return groups, nil
+}
+
+// ContributorBooks returns the user's books credited to a contributor, by title.
+func (s *FeedService) ContributorBooks(ctx context.Context, userID, contributorID string) (*FeedGroup, []*dto.Book, error) {
+	contributor, err := s.store.GetContributor(ctx, contributorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get books: %w", err)
+	}
+
+	books = slices.DeleteFunc(books, func(b *domain.Book) bool {
+		return !slices.ContainsFunc(b.Contributors, func(bc domain.BookContributor) bool {
+			return bc.ContributorID == contributorID
+		})
+	})
+	sortBooksByTitle(books)
+
+	enriched, err := s.enricher.EnrichBooks(ctx, books)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &FeedGroup{ID: contributor.ID, Name: contributor.Name, BookCount: len(books)}, enriched, nil
+}
+
+// Genres lists genres with books the user can access, in tree order. A book
+// counts towards its genres and all their ancestors.
+func (s *FeedService) Genres(ctx context.Context, userID string) ([]FeedGroup, error) {
+	genres, bookGenres, _, err := s.genreTree(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, genreIDs := range bookGenres {
+		for genreID := range genreIDs {
+			counts[genreID]++
+		}
+	}
+
+	slices.SortFunc(genres, func(a, b *domain.Genre) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	var groups []FeedGroup
+	for _, g := range genres {
+		if counts[g.ID] == 0 {
+			continue
+		}
+		groups = append(groups, FeedGroup{ID: g.ID, Name: g.Name, Description: g.Description, BookCount: counts[g.ID]})
+	}
+	return groups, nil
+}
+
+// GenreBooks returns the user's books in a genre or any of its descendants,
+// by title.
+func (s *FeedService) GenreBooks(ctx context.Context, userID, genreID string) (*FeedGroup, []*dto.Book, error) {
+	genre, err := s.store.GetGenre(ctx, genreID)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, bookGenres, books, err := s.genreTree(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	books = slices.DeleteFunc(books, func(b *domain.Book) bool {
+		return !bookGenres[b.ID][genreID]
+	})
+	sortBooksByTitle(books)
+
+	enriched, err := s.enricher.EnrichBooks(ctx, books)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &FeedGroup{ID: genre.ID, Name: genre.Name, Description: genre.Description, BookCount: len(books)}, enriched, nil
+}
+
+// genreTree loads the user's books and, for each, the set of genres it
+// belongs to including ancestors.
+func (s *FeedService) genreTree(ctx context.Context, userID string) ([]*domain.Genre, map[string]map[string]bool, []*domain.Book, error) {
+	books, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get books: %w", err)
+	}
+	genres, err := s.store.ListGenres(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("list genres: %w", err)
+	}
+
+	bookIDs := make([]string, len(books))
+	for i, b := range books {
+		bookIDs[i] = b.ID
+	}
+	direct, err := s.store.GetGenreIDsByBookIDs(ctx, bookIDs)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get book genres: %w", err)
+	}
+
+	byID := make(map[string]*domain.Genre, len(genres))
+	for _, g := range genres {
+		byID[g.ID] = g
+	}
+
+	bookGenres := make(map[string]map[string]bool, len(direct))
+	for bookID, genreIDs := range direct {
+		set := make(map[string]bool)
+		for _, genreID := range genreIDs {
+			// Walk up to the root; the depth bound guards against a cycle.
+			for g, depth := byID[genreID], 0; g != nil && depth <= len(genres); g, depth = byID[g.ParentID], depth+1 {
+				set[g.ID] = true
+			}
+		}
+		bookGenres[bookID] = set
+	}
+
+	return genres, bookGenres, books, nil
+}
+
+// Shelves lists the user's own shelves, smart ones included.
+func (s *FeedService) Shelves(ctx context.Context, userID string) ([]FeedGroup, error) {
+	shelves, err := s.shelves.ListMyShelves(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]FeedGroup, 0, len(shelves))
+	for _, shelf := range shelves {
+		groups = append(groups, FeedGroup{ID: shelf.ID, Name: shelf.Name, Description: shelf.Description, BookCount: len(shelf.BookIDs)})
+	}
+	sortGroupsByName(groups)
+	return groups, nil
+}
+
+// ShelfBooks returns the books on one of the user's shelves, in shelf order,
+// limited to those the user can still access.
+func (s *FeedService) ShelfBooks(ctx context.Context, userID, shelfID string) (*FeedGroup, []*dto.Book, error) {
+	shelf, err := s.shelves.GetShelf(ctx, userID, shelfID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if shelf.OwnerID != userID {
+		return nil, nil, store.ErrShelfNotFound
+	}
+
+	books, err := s.booksByID(ctx, userID, shelf.BookIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &FeedGroup{ID: shelf.ID, Name: shelf.Name, Description: shelf.Description, BookCount: len(books)}, books, nil
+}
+
+// Search returns the user's books matching query, best match first.
+func (s *FeedService) Search(ctx context.Context, userID, query string, limit int) ([]*dto.Book, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	result, err := s.search.Search(ctx, search.SearchParams{
+		Query:     query,
+		Types:     []string{string(search.DocTypeBook)},
+		Limit:     limit,
+		SortBy:    "relevance",
+		SortOrder: "desc",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	ids := make([]string, len(result.Hits))
+	for i, hit := range result.Hits {
+		ids[i] = hit.ID
+	}
+	return s.booksByID(ctx, userID, ids)
+}
+
+// booksByID loads and enriches the accessible books among ids, keeping the
+// order of ids.
+func (s *FeedService) booksByID(ctx context.Context, userID string, ids []string) ([]*dto.Book, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	all, err := s.store.GetBooksForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get books: %w", err)
+	}
+	byID := make(map[string]*domain.Book, len(all))
+	for _, b := range all {
+		byID[b.ID] = b
+	}
+
+	books := make([]*domain.Book, 0, len(ids))
+	for _, bookID := range ids {
+		if b, ok := byID[bookID]; ok {
+			books = append(books, b)
+		}
+	}
+	return s.enricher.EnrichBooks(ctx, books)
+}
+
+func sortBooksByTitle(books []*domain.Book) {
+	slices.SortFunc(books, func(a, b *domain.Book) int {
+		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	})
+}
+
+func sortGroupsByName(groups []FeedGroup) {
+	slices.SortFunc(groups, func(a, b FeedGroup) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+}
+
+// compareSequence orders series positions numerically where possible;
+// positions like "Book Zero" sort after the numbered ones.
+func compareSequence(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(fa, fb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return cmp.Compare(a, b)
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+func TestFeedService_TokenLifecycle(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	feeds := NewFeedService(s, nil, nil, nil, slog.New(slog.DiscardHandler))
+	alice := createTestUserWithPermissions(t, s, "alice@example.com", true)
+	bob := createTestUserWithPermissions(t, s, "bob@example.com", true)
+
+	_, _, err = feeds.CreateFeedToken(ctx, alice.ID, "   ")
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+
+	token, plaintext, err := feeds.CreateFeedToken(ctx, alice.ID, " Overcast ")
+	require.NoError(t, err)
+	assert.Equal(t, "Overcast", token.Name)
+	assert.NotEmpty(t, plaintext)
+	assert.NotEqual(t, plaintext, token.TokenHash, "only the hash is stored")
+
+	user, err := feeds.AuthenticateFeedToken(ctx, plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, user.ID)
+
+	tokens, err := feeds.ListFeedTokens(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.NotNil(t, tokens[0].LastUsedAt, "authentication records use")
+
+	_, err = feeds.AuthenticateFeedToken(ctx, plaintext+"x")
+	assert.ErrorIs(t, err, domainerrors.Unauthorized(""))
+
+	err = feeds.RevokeFeedToken(ctx, bob.ID, token.ID)
+	assert.ErrorIs(t, err, domainerrors.Forbidden(""))
+
+	require.NoError(t, feeds.RevokeFeedToken(ctx, alice.ID, token.ID))
+	_, err = feeds.AuthenticateFeedToken(ctx, plaintext)
+	assert.ErrorIs(t, err, domainerrors.Unauthorized(""))
+}
+
+func TestCompareSequence(t *testing.T) {
+	t.Parallel()
+
+	assert.Negative(t, compareSequence("2", "10"))
+	assert.Negative(t, compareSequence("1", "1.5"))
+	assert.Negative(t, compareSequence("3", "Book Zero"))
+	assert.Positive(t, compareSequence("", "1"))
+	assert.Zero(t, compareSequence("1.0", "1"))
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// GoalActivityRecorder records goal completions in the activity feed.
+// This avoids a circular dependency between GoalService and ActivityService.
+type GoalActivityRecorder interface {
+	RecordGoalCompleted(ctx context.Context, userID string, goal *domain.ListeningGoal) error
+}
+
+// goalServiceStore is the narrow store interface GoalService depends on.
+type goalServiceStore interface {
+	store.GoalStore
+	GetEventsForUserInRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.ListeningEvent, error)
+	GetStateFinishedInRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.PlaybackState, error)
+}
+
+// GoalService manages listening goals and tracks progress toward them.
+type GoalService struct {
+	store            goalServiceStore
+	events           store.EventEmitter
+	activityRecorder GoalActivityRecorder
+	logger           *slog.Logger
+}
+
+// NewGoalService creates a new goal service.
+func NewGoalService(store goalServiceStore, events store.EventEmitter, logger *slog.Logger) *GoalService {
+	return &GoalService{
+		store:  store,
+		events: events,
+		logger: logger,
+	}
+}
+
+// SetActivityRecorder sets the recorder for goal completion activities.
+// This is set after construction to avoid circular dependencies.
+func (s *GoalService) SetActivityRecorder(recorder GoalActivityRecorder) {
+	s.activityRecorder = recorder
+}
+
+// maxGoalTarget bounds goal targets to something a person could reach.
+const maxGoalTarget = 10000
+
+// CreateGoalRequest contains the data for creating a listening goal.
+type CreateGoalRequest struct {
+	Metric   domain.GoalMetric `json:"metric" validate:"required"`
+	Period   domain.GoalPeriod `json:"period" validate:"required"`
+	Target   int               `json:"target" validate:"gte=1,lte=10000"`
+	StartsAt *time.Time        `json:"starts_at"` // Custom goals only
+	EndsAt   *time.Time        `json:"ends_at"`   // Custom goals only
+}
+
+// UpdateGoalRequest contains the fields that can be updated on a goal.
+// Nil fields are left unchanged; the range can only be set on custom goals.
+type UpdateGoalRequest struct {
+	Target   *int       `json:"target" validate:"omitempty,gte=1,lte=10000"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// ListGoals returns the user's goals with their current progress, oldest first.
+func (s *GoalService) ListGoals(ctx context.Context, userID string) ([]domain.GoalProgress, error) {
+	goals, err := s.store.ListListeningGoals(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list goals: %w", err)
+	}
+
+	now := time.Now()
+	result := make([]domain.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		progress, err := s.progress(ctx, goal, now)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, progress)
+	}
+	return result, nil
+}
+
+// GetGoal returns one of the user's goals with its current progress.
+func (s *GoalService) GetGoal(ctx context.Context, userID, goalID string) (*domain.GoalProgress, error) {
+	goal, err := s.getOwnedGoal(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.progress(ctx, goal, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// CreateGoal creates a listening goal. A goal that is already met when it is
+// set is marked complete without announcing it in the activity feed.
+func (s *GoalService) CreateGoal(ctx context.Context, userID string, req CreateGoalRequest) (*domain.GoalProgress, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	goalID, err := id.Generate("goal")
+	if err != nil {
+		return nil, fmt.Errorf("generate goal ID: %w", err)
+	}
+
+	now := time.Now()
+	goal := &domain.ListeningGoal{
+		ID:        goalID,
+		UserID:    userID,
+		Metric:    req.Metric,
+		Period:    req.Period,
+		Target:    req.Target,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := validateGoal(goal); err != nil {
+		return nil, err
+	}
+
+	progress, err := s.save(ctx, goal, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("goal created",
+		"goal_id", goal.ID,
+		"user_id", userID,
+		"metric", goal.Metric,
+		"period", goal.Period,
+		"target", goal.Target,
+	)
+
+	return progress, nil
+}
+
+// UpdateGoal applies a partial update to one of the user's goals.
+func (s *GoalService) UpdateGoal(ctx context.Context, userID, goalID string, req UpdateGoalRequest) (*domain.GoalProgress, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	goal, err := s.getOwnedGoal(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Target != nil {
+		goal.Target = *req.Target
+	}
+	if req.StartsAt != nil {
+		goal.StartsAt = req.StartsAt
+	}
+	if req.EndsAt != nil {
+		goal.EndsAt = req.EndsAt
+	}
+	if err := validateGoal(goal); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	goal.UpdatedAt = now
+
+	progress, err := s.save(ctx, goal, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("goal updated", "goal_id", goal.ID, "user_id", userID)
+
+	return progress, nil
+}
+
+// DeleteGoal deletes one of the user's goals.
+func (s *GoalService) DeleteGoal(ctx context.Context, userID, goalID string) error {
+	if _, err := s.getOwnedGoal(ctx, userID, goalID); err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteListeningGoal(ctx, goalID); err != nil {
+		return fmt.Errorf("delete goal: %w", err)
+	}
+
+	s.logger.Info("goal deleted", "goal_id", goalID, "user_id", userID)
+
+	s.events.Emit(sse.NewGoalDeletedEvent(userID, goalID))
+
+	return nil
+}
+
+// CheckGoals recomputes progress on the user's goals after their listening
+// changed. Goals reached for the first time this period are marked complete
+// and announced in the activity feed. Every goal still running is pushed to
+// the user's devices. Errors are logged rather than returned, since callers
+// are recording listening and must not fail because of goals.
+func (s *GoalService) CheckGoals(ctx context.Context, userID string) {
+	goals, err := s.store.ListListeningGoals(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to list goals", "user_id", userID, "error", err)
+		return
+	}
+
+	now := time.Now()
+	for _, goal := range goals {
+		start, end := goal.Window(now)
+		if now.Before(start) || !now.Before(end) {
+			continue // Custom goal not started or already over
+		}
+
+		wasCompleted := goal.CompletedSince(start)
+		progress, err := s.save(ctx, goal, now)
+		if err != nil {
+			s.logger.Warn("failed to update goal", "goal_id", goal.ID, "user_id", userID, "error", err)
+			continue
+		}
+
+		if progress.Completed && !wasCompleted {
+			s.logger.Info("goal completed", "goal_id", goal.ID, "user_id", userID)
+			if s.activityRecorder != nil {
+				if err := s.activityRecorder.RecordGoalCompleted(ctx, userID, goal); err != nil {
+					s.logger.Warn("failed to record goal completion", "goal_id", goal.ID, "user_id", userID, "error", err)
+				}
+			}
+		}
+	}
+}
+
+// save recomputes the goal's progress, keeps CompletedAt in step with it,
+// stores the goal and pushes the result to the user's devices.
+func (s *GoalService) save(ctx context.Context, goal *domain.ListeningGoal, now time.Time) (*domain.GoalProgress, error) {
+	progress, err := s.progress(ctx, goal, now)
+	if err != nil {
+		return nil, err
+	}
+
+	switch completedThisWindow := goal.CompletedSince(progress.WindowStart); {
+	case progress.Completed && !completedThisWindow:
+		goal.CompletedAt = &now
+	case !progress.Completed && completedThisWindow:
+		goal.CompletedAt = nil // Target was raised or finishes were undone
+	}
+
+	if err := s.store.UpsertListeningGoal(ctx, goal); err != nil {
+		return nil, fmt.Errorf("save goal: %w", err)
+	}
+
+	s.events.Emit(sse.NewGoalUpdatedEvent(progress))
+
+	return &progress, nil
+}
+
+// progress computes the goal's standing in its window at now.
+func (s *GoalService) progress(ctx context.Context, goal *domain.ListeningGoal, now time.Time) (domain.GoalProgress, error) {
+	start, end := goal.Window(now)
+
+	var booksFinished int
+	var listenedMs int64
+	switch goal.Metric {
+	case domain.GoalMetricBooks:
+		finished, err := s.store.GetStateFinishedInRange(ctx, goal.UserID, start, end)
+		if err != nil {
+			return domain.GoalProgress{}, fmt.Errorf("get finished books: %w", err)
+		}
+		booksFinished = len(finished)
+	case domain.GoalMetricHours:
+		events, err := s.store.GetEventsForUserInRange(ctx, goal.UserID, start, end)
+		if err != nil {
+			return domain.GoalProgress{}, fmt.Errorf("get listening events: %w", err)
+		}
+		const maxReasonableDurationMs = 24 * 60 * 60 * 1000
+		for _, e := range events {
+			if e.DurationMs > 0 && e.DurationMs <= maxReasonableDurationMs {
+				listenedMs += e.DurationMs
+			}
+		}
+	}
+
+	return goal.ProgressFor(start, end, booksFinished, listenedMs), nil
+}
+
+// getOwnedGoal loads a goal and verifies it belongs to the user.
+// Goals owned by other users are reported as not found so IDs don't leak.
+func (s *GoalService) getOwnedGoal(ctx context.Context, userID, goalID string) (*domain.ListeningGoal, error) {
+	goal, err := s.store.GetListeningGoal(ctx, goalID)
+	if err != nil {
+		return nil, err
+	}
+	if goal.UserID != userID {
+		return nil, store.ErrGoalNotFound
+	}
+	return goal, nil
+}
+
+// validateGoal checks the metric, period and range of a goal.
+func validateGoal(goal *domain.ListeningGoal) error {
+	if !goal.Metric.Valid() {
+		return domainerrors.Validationf("unknown goal metric %q", goal.Metric)
+	}
+	if !goal.Period.Valid() {
+		return domainerrors.Validationf("unknown goal period %q", goal.Period)
+	}
+	if goal.Target < 1 || goal.Target > maxGoalTarget {
+		return domainerrors.Validationf("target must be between 1 and %d", maxGoalTarget)
+	}
+
+	if goal.Period != domain.GoalPeriodCustom {
+		if goal.StartsAt != nil || goal.EndsAt != nil {
+			return domainerrors.Validation("only custom goals take a date range")
+		}
+		return nil
+	}
+	if goal.StartsAt == nil || goal.EndsAt == nil {
+		return domainerrors.Validation("custom goals need starts_at and ends_at")
+	}
+	if !goal.EndsAt.After(*goal.StartsAt) {
+		return domainerrors.Validation("ends_at must be after starts_at")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGoalActivityRecorder records which goals were announced as completed.
+type fakeGoalActivityRecorder struct {
+	completed []string
+}
+
+func (r *fakeGoalActivityRecorder) RecordGoalCompleted(_ context.Context, _ string, goal *domain.ListeningGoal) error {
+	r.completed = append(r.completed, goal.ID)
+	return nil
+}
+
+func setupTestGoalService(t *testing.T) (*GoalService, *fakeGoalActivityRecorder, store.Store) {
+	t.Helper()
+
+	testStore, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { testStore.Close() })
+
+	recorder := &fakeGoalActivityRecorder{}
+	svc := NewGoalService(testStore, store.NewNoopEmitter(), slog.New(slog.DiscardHandler))
+	svc.SetActivityRecorder(recorder)
+
+	createTestUserForSocial(t, testStore, "user-1", "User One", domain.RoleMember)
+	createTestUserForSocial(t, testStore, "user-2", "User Two", domain.RoleMember)
+
+	return svc, recorder, testStore
+}
+
+func TestGoalService_CreateGoal_Validation(t *testing.T) {
+	svc, _, _ := setupTestGoalService(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	later := now.Add(24 * time.Hour)
+
+	tests := []struct {
+		name string
+		req  CreateGoalRequest
+	}{
+		{"unknown metric", CreateGoalRequest{Metric: "pages", Period: domain.GoalPeriodYear, Target: 10}},
+		{"unknown period", CreateGoalRequest{Metric: domain.GoalMetricBooks, Period: "decade", Target: 10}},
+		{"zero target", CreateGoalRequest{Metric: domain.GoalMetricBooks, Period: domain.GoalPeriodYear}},
+		{"range on yearly goal", CreateGoalRequest{Metric: domain.GoalMetricBooks, Period: domain.GoalPeriodYear, Target: 10, StartsAt: &now, EndsAt: &later}},
+		{"custom without range", CreateGoalRequest{Metric: domain.GoalMetricHours, Period: domain.GoalPeriodCustom, Target: 10}},
+		{"custom range reversed", CreateGoalRequest{Metric: domain.GoalMetricHours, Period: domain.GoalPeriodCustom, Target: 10, StartsAt: &later, EndsAt: &now}},
+	}
+	for _, tt := range tests {
+		_, err := svc.CreateGoal(ctx, "user-1", tt.req)
+		assert.ErrorIs(t, err, domainerrors.ErrValidation, tt.name)
+	}
+}
+
+func TestGoalService_CheckGoals_RecordsCompletionOnce(t *testing.T) {
+	svc, recorder, s := setupTestGoalService(t)
+	ctx := context.Background()
+
+	hours, err := svc.CreateGoal(ctx, "user-1", CreateGoalRequest{
+		Metric: domain.GoalMetricHours, Period: domain.GoalPeriodWeek, Target: 1,
+	})
+	require.NoError(t, err)
+	books, err := svc.CreateGoal(ctx, "user-1", CreateGoalRequest{
+		Metric: domain.GoalMetricBooks, Period: domain.GoalPeriodYear, Target: 2,
+	})
+	require.NoError(t, err)
+	assert.False(t, hours.Completed)
+	assert.Zero(t, books.Current)
+
+	createTestBookForSocial(t, s, "book-1", "Book One")
+	now := time.Now()
+	require.NoError(t, s.CreateListeningEvent(ctx, domain.NewListeningEvent(
+		"evt-1", "user-1", "book-1", 0, time.Hour.Milliseconds(),
+		now.Add(-2*time.Minute), now.Add(-time.Minute), 1.0, "device-1", "Phone",
+	)))
+	finishedAt := now.Add(-time.Minute)
+	require.NoError(t, s.UpsertState(ctx, &domain.PlaybackState{
+		UserID: "user-1", BookID: "book-1", IsFinished: true, FinishedAt: &finishedAt,
+		StartedAt: now.Add(-time.Hour), LastPlayedAt: now, UpdatedAt: now,
+	}))
+
+	svc.CheckGoals(ctx, "user-1")
+	svc.CheckGoals(ctx, "user-1")
+
+	assert.Equal(t, []string{hours.Goal.ID}, recorder.completed, "hours goal is announced once")
+
+	progress, err := svc.GetGoal(ctx, "user-1", books.Goal.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, progress.Current, 0.001)
+	assert.InDelta(t, 50, progress.Percent, 0.001)
+
+	// Raising the target past the current progress reopens the goal
+	raised := 2
+	progress, err = svc.UpdateGoal(ctx, "user-1", hours.Goal.ID, UpdateGoalRequest{Target: &raised})
+	require.NoError(t, err)
+	assert.False(t, progress.Completed)
+	assert.Nil(t, progress.Goal.CompletedAt)
+}
+
+func TestGoalService_OtherUsersGoalsAreNotFound(t *testing.T) {
+	svc, _, _ := setupTestGoalService(t)
+	ctx := context.Background()
+
+	created, err := svc.CreateGoal(ctx, "user-1", CreateGoalRequest{
+		Metric: domain.GoalMetricBooks, Period: domain.GoalPeriodYear, Target: 12,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.GetGoal(ctx, "user-2", created.Goal.ID)
+	assert.ErrorIs(t, err, store.ErrGoalNotFound)
+	assert.ErrorIs(t, svc.DeleteGoal(ctx, "user-2", created.Goal.ID), store.ErrGoalNotFound)
+
+	goals, err := svc.ListGoals(ctx, "user-2")
+	require.NoError(t, err)
+	assert.Empty(t, goals)
+
+	require.NoError(t, svc.DeleteGoal(ctx, "user-1", created.Goal.ID))
+	goals, err = svc.ListGoals(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, goals)
+}
@@ -45,34 +45,59 @@ type ReleaseResult struct {
 	ToCollections int `json:"to_collections"`
 }
 
-// ListBooks returns all books in the Inbox.
+// inboxes returns the Inbox collection of every library.
+func (s *InboxService) inboxes(ctx context.Context) ([]*domain.Collection, error) {
+	libraries, err := s.store.ListLibraries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list libraries: %w", err)
+	}
+
+	inboxes := make([]*domain.Collection, 0, len(libraries))
+	for _, library := range libraries {
+		inbox, err := s.store.GetInboxForLibrary(ctx, library.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get inbox for library %s: %w", library.ID, err)
+		}
+		inboxes = append(inboxes, inbox)
+	}
+	return inboxes, nil
+}
+
+// inboxContaining returns the inbox holding bookID, or nil.
+func inboxContaining(inboxes []*domain.Collection, bookID string) *domain.Collection {
+	for _, inbox := range inboxes {
+		if inbox.ContainsBook(bookID) {
+			return inbox
+		}
+	}
+	return nil
+}
+
+// ListBooks returns all books in the Inbox of every library.
 func (s *InboxService) ListBooks(ctx context.Context) ([]*domain.Book, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	library, err := s.store.GetDefaultLibrary(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get default library: %w", err)
-	}
-
-	inbox, err := s.store.GetInboxForLibrary(ctx, library.ID)
+	inboxes, err := s.inboxes(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("get inbox: %w", err)
+		return nil, err
 	}
 
-	// Get all books in the inbox collection
+	// Get all books in the inbox collections
 	var books []*domain.Book
-	for _, bookID := range inbox.BookIDs {
-		book, err := s.store.GetBookByID(ctx, bookID)
-		if err != nil {
-			s.logger.Warn("failed to get inbox book",
-				"book_id", bookID,
-				"error", err,
-			)
-			continue
+	for _, inbox := range inboxes {
+		for _, bookID := range inbox.BookIDs {
+			book, err := s.store.GetBookByID(ctx, bookID)
+			if err != nil {
+				s.logger.Warn("failed to get inbox book",
+					"book_id", bookID,
+					"error", err,
+				)
+				continue
+			}
+			books = append(books, book)
 		}
-		books = append(books, book)
 	}
 
 	return books, nil
@@ -85,14 +110,9 @@ func (s *InboxService) ReleaseBooks(ctx context.Context, bookIDs []string) (*Rel
 		return nil, err
 	}
 
-	library, err := s.store.GetDefaultLibrary(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get default library: %w", err)
-	}
-
-	inbox, err := s.store.GetInboxForLibrary(ctx, library.ID)
+	inboxes, err := s.inboxes(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("get inbox: %w", err)
+		return nil, err
 	}
 
 	result := &ReleaseResult{}
@@ -107,6 +127,12 @@ func (s *InboxService) ReleaseBooks(ctx context.Context, bookIDs []string) (*Rel
 			continue
 		}
 
+		inbox := inboxContaining(inboxes, bookID)
+		if inbox == nil {
+			s.logger.Warn("book is not in any inbox", "book_id", bookID)
+			continue
+		}
+
 		// Track how many collections this book is going to
 		stagedCount := len(book.StagedCollectionIDs)
 
@@ -244,32 +270,26 @@ func (s *InboxService) UnstageCollection(ctx context.Context, bookID, collection
 	return nil
 }
 
-// isBookInInbox checks if a book is in the Inbox collection.
+// isBookInInbox checks if a book is in any library's Inbox collection.
 func (s *InboxService) isBookInInbox(ctx context.Context, bookID string) bool {
-	library, err := s.store.GetDefaultLibrary(ctx)
-	if err != nil {
-		return false
-	}
-
-	inbox, err := s.store.GetInboxForLibrary(ctx, library.ID)
+	inboxes, err := s.inboxes(ctx)
 	if err != nil {
 		return false
 	}
 
-	return inbox.ContainsBook(bookID)
+	return inboxContaining(inboxes, bookID) != nil
 }
 
-// GetInboxCount returns the number of books in the Inbox.
+// GetInboxCount returns the number of books in the Inbox of every library.
 func (s *InboxService) GetInboxCount(ctx context.Context) (int, error) {
-	library, err := s.store.GetDefaultLibrary(ctx)
+	inboxes, err := s.inboxes(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("get default library: %w", err)
+		return 0, err
 	}
 
-	inbox, err := s.store.GetInboxForLibrary(ctx, library.ID)
-	if err != nil {
-		return 0, fmt.Errorf("get inbox: %w", err)
+	count := 0
+	for _, inbox := range inboxes {
+		count += len(inbox.BookIDs)
 	}
-
-	return len(inbox.BookIDs), nil
+	return count, nil
 }
@@ -9,6 +9,11 @@ import (
 	"time"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/dto"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/metadata/audible"
+	"github.com/listenupapp/listenup-server/internal/search/asyncindexer"
 	"github.com/listenupapp/listenup-server/internal/sse"
 	"github.com/listenupapp/listenup-server/internal/store"
 )
@@ -18,19 +23,31 @@ type libraryServiceStore interface {
 	store.LibraryStore
 	store.UserStore
 	store.BookStore
+	store.CollectionStore
+	store.PodcastStore
 }
 
 // LibraryService orchestrates library operations.
 type LibraryService struct {
 	store      libraryServiceStore
+	indexer    *asyncindexer.Indexer
+	enricher   *dto.Enricher
 	sseManager *sse.Manager
 	logger     *slog.Logger
 }
 
 // NewLibraryService creates a new library service.
-func NewLibraryService(libraries libraryServiceStore, sseManager *sse.Manager, logger *slog.Logger) *LibraryService {
+func NewLibraryService(
+	libraries libraryServiceStore,
+	indexer *asyncindexer.Indexer,
+	enricher *dto.Enricher,
+	sseManager *sse.Manager,
+	logger *slog.Logger,
+) *LibraryService {
 	return &LibraryService{
 		store:      libraries,
+		indexer:    indexer,
+		enricher:   enricher,
 		sseManager: sseManager,
 		logger:     logger,
 	}
@@ -119,13 +136,19 @@ func (s *LibraryService) SetupLibrary(ctx context.Context, input SetupLibraryInp
 	return lib, nil
 }
 
-// AddScanPath adds a scan path to an existing library.
+// AddScanPath adds a scan path to an existing library. Books already
+// scanned under the path move into the library.
+// Returns a conflict error if the path overlaps another library's scan path.
 func (s *LibraryService) AddScanPath(ctx context.Context, libraryID, cleanPath string) (*domain.Library, error) {
 	lib, err := s.store.GetLibrary(ctx, libraryID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.checkScanPathsFree(ctx, libraryID, []string{cleanPath}); err != nil {
+		return nil, err
+	}
+
 	lib.AddScanPath(cleanPath)
 	lib.UpdatedAt = time.Now()
 
@@ -133,9 +156,209 @@ func (s *LibraryService) AddScanPath(ctx context.Context, libraryID, cleanPath s
 		return nil, fmt.Errorf("update library: %w", err)
 	}
 
+	moved, err := s.store.AssignBooksToLibrary(ctx, libraryID, cleanPath)
+	if err != nil {
+		return nil, fmt.Errorf("assign books to library: %w", err)
+	}
+	if len(moved) > 0 {
+		s.logger.Info("moved books into library", "library_id", libraryID, "path", cleanPath, "count", len(moved))
+	}
+	s.publishMovedBooks(ctx, moved)
+
 	return lib, nil
 }
 
+// publishMovedBooks reindexes books that changed library and tells clients
+// about them, since their library is part of both the index and the book.
+func (s *LibraryService) publishMovedBooks(ctx context.Context, bookIDs []string) {
+	for _, bookID := range bookIDs {
+		book, err := s.store.GetBookByID(ctx, bookID)
+		if err != nil {
+			s.logger.Warn("failed to load moved book", "book_id", bookID, "error", err)
+			continue
+		}
+		if s.indexer != nil {
+			s.indexer.SubmitIndexBook(book)
+		}
+		if s.enricher != nil && s.sseManager != nil {
+			enriched, err := s.enricher.EnrichBook(ctx, book)
+			if err != nil {
+				s.logger.Warn("failed to enrich book for SSE event", "book_id", book.ID, "error", err)
+				continue
+			}
+			s.sseManager.Emit(sse.NewBookUpdatedEvent(enriched))
+		}
+	}
+}
+
+// checkScanPathsFree returns a conflict error if any path overlaps a scan
+// path of a library other than libraryID. Each book must belong to exactly
+// one library, so library trees may not nest.
+func (s *LibraryService) checkScanPathsFree(ctx context.Context, libraryID string, paths []string) error {
+	libraries, err := s.store.ListLibraries(ctx)
+	if err != nil {
+		return fmt.Errorf("list libraries: %w", err)
+	}
+	for _, other := range libraries {
+		if other.ID == libraryID {
+			continue
+		}
+		for _, existing := range other.ScanPaths {
+			for _, path := range paths {
+				if domain.ScanPathsOverlap(path, existing) {
+					return domainerrors.Conflictf("path %s overlaps %s in library %q", path, existing, other.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CreateLibraryInput is the input for creating an additional library.
+type CreateLibraryInput struct {
+	OwnerID        string
+	Name           string
+	ScanPaths      []string // At least one path required.
+	SkipInbox      bool
+	AccessMode     string
+	MetadataRegion string
+	Type           string // audiobook (default) or podcast
+}
+
+// CreateLibrary creates a library with its inbox collection. Scan paths
+// must not overlap any other library's.
+func (s *LibraryService) CreateLibrary(ctx context.Context, input CreateLibraryInput) (*domain.Library, error) {
+	if len(input.ScanPaths) == 0 {
+		return nil, domainerrors.Validation("at least one scan path is required")
+	}
+	if err := validateMetadataRegion(input.MetadataRegion); err != nil {
+		return nil, err
+	}
+	libType := domain.LibraryType(input.Type)
+	if libType != "" && libType != domain.LibraryTypeAudiobook && libType != domain.LibraryTypePodcast {
+		return nil, domainerrors.Validationf("unknown library type %q", input.Type)
+	}
+	if err := s.checkScanPathsFree(ctx, "", input.ScanPaths); err != nil {
+		return nil, err
+	}
+
+	libraryID, err := id.Generate("lib")
+	if err != nil {
+		return nil, fmt.Errorf("generate library ID: %w", err)
+	}
+	inboxID, err := id.Generate("coll")
+	if err != nil {
+		return nil, fmt.Errorf("generate collection ID: %w", err)
+	}
+
+	now := time.Now()
+	lib := &domain.Library{
+		ID:             libraryID,
+		OwnerID:        input.OwnerID,
+		Name:           input.Name,
+		SkipInbox:      input.SkipInbox,
+		AccessMode:     domain.AccessMode(input.AccessMode),
+		MetadataRegion: input.MetadataRegion,
+		Type:           libType,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	for _, p := range input.ScanPaths {
+		lib.AddScanPath(p)
+	}
+
+	if err := s.store.CreateLibrary(ctx, lib); err != nil {
+		return nil, fmt.Errorf("create library: %w", err)
+	}
+
+	inbox := &domain.Collection{
+		ID:        inboxID,
+		LibraryID: lib.ID,
+		OwnerID:   input.OwnerID,
+		Name:      "Inbox",
+		IsInbox:   true,
+		BookIDs:   []string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.CreateCollection(ctx, inbox); err != nil {
+		if delErr := s.store.DeleteLibrary(ctx, lib.ID); delErr != nil {
+			s.logger.Error("failed to clean up library", "library_id", lib.ID, "error", delErr)
+		}
+		return nil, fmt.Errorf("create inbox collection: %w", err)
+	}
+
+	// Podcast libraries hold downloaded episodes, never scanned books.
+	if !lib.IsPodcast() {
+		for _, p := range lib.ScanPaths {
+			moved, err := s.store.AssignBooksToLibrary(ctx, lib.ID, p)
+			if err != nil {
+				return nil, fmt.Errorf("assign books to library: %w", err)
+			}
+			s.publishMovedBooks(ctx, moved)
+		}
+	}
+
+	s.logger.Info("library created",
+		"library_id", lib.ID,
+		"type", lib.GetType(),
+		"name", lib.Name,
+		"scan_paths", len(lib.ScanPaths),
+	)
+
+	return lib, nil
+}
+
+// DeleteLibrary deletes a library and its collections. The library must be
+// empty and must not be the last one; remove its scan paths from disk and
+// rescan, or move them to another library, first.
+func (s *LibraryService) DeleteLibrary(ctx context.Context, libraryID string) error {
+	lib, err := s.store.GetLibrary(ctx, libraryID)
+	if err != nil {
+		return err
+	}
+
+	libraries, err := s.store.ListLibraries(ctx)
+	if err != nil {
+		return fmt.Errorf("list libraries: %w", err)
+	}
+	if len(libraries) <= 1 {
+		return domainerrors.Conflict("cannot delete the last library")
+	}
+
+	stats, err := s.store.GetLibraryStats(ctx, libraryID)
+	if err != nil {
+		return fmt.Errorf("get library stats: %w", err)
+	}
+	if stats.BookCount > 0 {
+		return domainerrors.Conflictf("library still has %d books", stats.BookCount)
+	}
+	if lib.IsPodcast() {
+		podcasts, err := s.store.ListPodcastsForLibrary(ctx, libraryID)
+		if err != nil {
+			return fmt.Errorf("list podcasts: %w", err)
+		}
+		if len(podcasts) > 0 {
+			return domainerrors.Conflictf("library still has %d podcasts", len(podcasts))
+		}
+	}
+
+	if err := s.store.DeleteLibrary(ctx, libraryID); err != nil {
+		return fmt.Errorf("delete library: %w", err)
+	}
+
+	s.logger.Info("library deleted", "library_id", libraryID)
+	return nil
+}
+
+// GetLibraryStats returns book totals for a library.
+func (s *LibraryService) GetLibraryStats(ctx context.Context, libraryID string) (*store.LibraryStats, error) {
+	if _, err := s.store.GetLibrary(ctx, libraryID); err != nil {
+		return nil, err
+	}
+	return s.store.GetLibraryStats(ctx, libraryID)
+}
+
 // RemoveScanPath removes a scan path from an existing library.
 func (s *LibraryService) RemoveScanPath(ctx context.Context, libraryID, cleanPath string) (*domain.Library, error) {
 	lib, err := s.store.GetLibrary(ctx, libraryID)
@@ -153,9 +376,18 @@ func (s *LibraryService) RemoveScanPath(ctx context.Context, libraryID, cleanPat
 	return lib, nil
 }
 
+// LibraryUpdate holds the library settings to change. Nil fields are left as-is.
+type LibraryUpdate struct {
+	Name              *string
+	AccessMode        *string
+	SkipInbox         *bool
+	MetadataRegion    *string
+	MetadataProviders *[]string
+}
+
 // UpdateLibrary updates a library's settings.
 // Only admins can update libraries (enforced at API layer).
-func (s *LibraryService) UpdateLibrary(ctx context.Context, libraryID string, name *string, accessMode *string) (*domain.Library, error) {
+func (s *LibraryService) UpdateLibrary(ctx context.Context, libraryID string, update LibraryUpdate) (*domain.Library, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -171,16 +403,31 @@ func (s *LibraryService) UpdateLibrary(ctx context.Context, libraryID string, na
 	accessModeChanged := false
 
 	// Update fields if provided
-	if name != nil {
-		lib.Name = *name
+	if update.Name != nil {
+		lib.Name = *update.Name
 	}
-	if accessMode != nil {
-		newMode := domain.AccessMode(*accessMode)
+	if update.AccessMode != nil {
+		newMode := domain.AccessMode(*update.AccessMode)
 		if newMode != oldAccessMode {
 			lib.AccessMode = newMode
 			accessModeChanged = true
 		}
 	}
+	if update.SkipInbox != nil {
+		lib.SkipInbox = *update.SkipInbox
+	}
+	if update.MetadataRegion != nil {
+		if err := validateMetadataRegion(*update.MetadataRegion); err != nil {
+			return nil, err
+		}
+		lib.MetadataRegion = *update.MetadataRegion
+	}
+	if update.MetadataProviders != nil {
+		if err := validateProviderPriority(*update.MetadataProviders); err != nil {
+			return nil, err
+		}
+		lib.MetadataProviders = *update.MetadataProviders
+	}
 
 	// Save changes
 	lib.UpdatedAt = time.Now()
@@ -208,3 +455,27 @@ func (s *LibraryService) UpdateLibrary(ctx context.Context, libraryID string, na
 
 	return lib, nil
 }
+
+// validateMetadataRegion checks a library's Audible region. Empty means the
+// server default.
+func validateMetadataRegion(region string) error {
+	if region != "" && !audible.Region(region).Valid() {
+		return domainerrors.Validationf("unknown metadata region %q", region)
+	}
+	return nil
+}
+
+// booksInLibrary returns the books belonging to libraryID, or all books if
+// libraryID is empty. The input slice is not modified.
+func booksInLibrary(books []*domain.Book, libraryID string) []*domain.Book {
+	if libraryID == "" {
+		return books
+	}
+	var filtered []*domain.Book
+	for _, b := range books {
+		if b.LibraryID == libraryID {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/search/asyncindexer"
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+func TestLibraryService_CreateAndDelete(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	owner := createTestUserWithPermissions(t, s, "owner@example.com", true)
+	logger := slog.New(slog.DiscardHandler)
+	indexer := asyncindexer.New(store.NewNoopSearchIndexer(), logger)
+	svc := NewLibraryService(s, indexer, nil, nil, logger)
+
+	adults, err := svc.CreateLibrary(ctx, CreateLibraryInput{
+		OwnerID:   owner.ID,
+		Name:      "Adults",
+		ScanPaths: []string{"/media/adults"},
+	})
+	require.NoError(t, err)
+
+	// A book scanned before the library existed is claimed by it.
+	now := time.Now()
+	book := &domain.Book{
+		Syncable: domain.Syncable{ID: "book-1", CreatedAt: now, UpdatedAt: now},
+		Title:    "Gruffalo",
+		Path:     "/media/kids/Gruffalo",
+	}
+	require.NoError(t, s.CreateBook(ctx, book))
+
+	kids, err := svc.CreateLibrary(ctx, CreateLibraryInput{
+		OwnerID:        owner.ID,
+		Name:           "Kids",
+		ScanPaths:      []string{"/media/kids"},
+		SkipInbox:      true,
+		MetadataRegion: "uk",
+	})
+	require.NoError(t, err)
+	assert.True(t, kids.SkipInbox)
+
+	inbox, err := s.GetInboxForLibrary(ctx, kids.ID)
+	require.NoError(t, err)
+	assert.True(t, inbox.IsInbox)
+
+	got, err := s.GetBookByID(ctx, "book-1")
+	require.NoError(t, err)
+	assert.Equal(t, kids.ID, got.LibraryID)
+	assert.Equal(t, 1, indexer.QueueDepth(), "claimed book is reindexed")
+
+	// Adding a scan path claims and reindexes the books under it too.
+	book = &domain.Book{
+		Syncable: domain.Syncable{ID: "book-2", CreatedAt: now, UpdatedAt: now},
+		Title:    "Dune",
+		Path:     "/media/scifi/Dune",
+	}
+	require.NoError(t, s.CreateBook(ctx, book))
+	_, err = svc.AddScanPath(ctx, adults.ID, "/media/scifi")
+	require.NoError(t, err)
+	got, err = s.GetBookByID(ctx, "book-2")
+	require.NoError(t, err)
+	assert.Equal(t, adults.ID, got.LibraryID)
+	assert.Equal(t, 2, indexer.QueueDepth())
+	require.NoError(t, s.DeleteBook(ctx, "book-2"))
+
+	stats, err := svc.GetLibraryStats(ctx, kids.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.BookCount)
+
+	// Overlapping trees and unknown regions are rejected.
+	_, err = svc.CreateLibrary(ctx, CreateLibraryInput{OwnerID: owner.ID, Name: "Nested", ScanPaths: []string{"/media/kids/picture-books"}})
+	assert.ErrorIs(t, err, domainerrors.Conflict(""))
+	_, err = svc.AddScanPath(ctx, adults.ID, "/media")
+	assert.ErrorIs(t, err, domainerrors.Conflict(""))
+	_, err = svc.CreateLibrary(ctx, CreateLibraryInput{OwnerID: owner.ID, Name: "Mars", ScanPaths: []string{"/media/mars"}, MetadataRegion: "mars"})
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+
+	// A library with books can't be deleted; an empty one can.
+	assert.ErrorIs(t, svc.DeleteLibrary(ctx, kids.ID), domainerrors.Conflict(""))
+	require.NoError(t, svc.DeleteLibrary(ctx, adults.ID))
+
+	// The last library can't be deleted.
+	require.NoError(t, s.DeleteBook(ctx, "book-1"))
+	assert.ErrorIs(t, svc.DeleteLibrary(ctx, kids.ID), domainerrors.Conflict(""))
+}
+
+func TestBooksInLibrary(t *testing.T) {
+	t.Parallel()
+
+	books := []*domain.Book{
+		{Syncable: domain.Syncable{ID: "b1"}, LibraryID: "adults"},
+		{Syncable: domain.Syncable{ID: "b2"}, LibraryID: "kids"},
+		{Syncable: domain.Syncable{ID: "b3"}, LibraryID: "adults"},
+	}
+
+	assert.Len(t, booksInLibrary(books, ""), 3)
+	kids := booksInLibrary(books, "kids")
+	if assert.Len(t, kids, 1) {
+		assert.Equal(t, "b2", kids[0].ID)
+	}
+	assert.Empty(t, booksInLibrary(books, "missing"))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/listenupapp/listenup-server/internal/color"
 	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
 	"github.com/listenupapp/listenup-server/internal/id"
 	"github.com/listenupapp/listenup-server/internal/sse"
 	"github.com/listenupapp/listenup-server/internal/store"
@@ -27,6 +28,12 @@ type StreakCalculator interface {
 	CalculateUserStreak(ctx context.Context, userID string) int
 }
 
+// GoalTracker recomputes a user's listening goals after their listening changes.
+// This avoids a circular dependency between ListeningService and GoalService.
+type GoalTracker interface {
+	CheckGoals(ctx context.Context, userID string)
+}
+
 // listeningServiceStore is the narrow store interface ListeningService depends on.
 type listeningServiceStore interface {
 	store.BookStore
@@ -44,6 +51,7 @@ type ListeningService struct {
 	logger                *slog.Logger
 	milestoneRecorder     MilestoneRecorder
 	streakCalculator      StreakCalculator
+	goalTracker           GoalTracker
 }
 
 // NewListeningService creates a new listening service.
@@ -68,6 +76,12 @@ func (s *ListeningService) SetStreakCalculator(calculator StreakCalculator) {
 	s.streakCalculator = calculator
 }
 
+// SetGoalTracker sets the tracker that keeps listening goals up to date.
+// This is set after construction to avoid circular dependencies.
+func (s *ListeningService) SetGoalTracker(tracker GoalTracker) {
+	s.goalTracker = tracker
+}
+
 // RecordEventRequest contains the data for recording a listening event.
 type RecordEventRequest struct {
 	EventID         string    `json:"event_id"` // Client-provided ID for idempotency
@@ -229,6 +243,9 @@ func (s *ListeningService) RecordEvent(ctx context.Context, userID string, req R
 	// Check for milestone crossings (non-blocking)
 	s.checkMilestones(ctx, userID, progress.TotalListenTimeMs)
 
+	// Update listening goal progress (non-blocking)
+	s.checkGoals(ctx, userID)
+
 	// Broadcast updated user stats for leaderboard caching (non-blocking)
 	s.broadcastUserStatsUpdate(ctx, userID)
 
@@ -241,6 +258,13 @@ func (s *ListeningService) RecordEvent(ctx context.Context, userID string, req R
 	}, nil
 }
 
+// checkGoals updates the user's listening goals if a goal tracker is set.
+func (s *ListeningService) checkGoals(ctx context.Context, userID string) {
+	if s.goalTracker != nil {
+		s.goalTracker.CheckGoals(ctx, userID)
+	}
+}
+
 // checkMilestones checks for and records any milestone crossings.
 // This is non-blocking and logs errors instead of returning them.
 func (s *ListeningService) checkMilestones(ctx context.Context, userID string, newTotalListenTimeMs int64) {
@@ -487,6 +511,7 @@ type UpdateBookPreferencesRequest struct {
 	PlaybackSpeed             *float32 `json:"playback_speed" validate:"omitempty,gt=0,lte=4"`
 	SkipForwardSec            *int     `json:"skip_forward_sec" validate:"omitempty,gte=5,lte=300"`
 	HideFromContinueListening *bool    `json:"hide_from_continue_listening"`
+	PrivateListening          *bool    `json:"private_listening"`
 }
 
 // UpdateBookPreferences updates per-book preferences.
@@ -517,6 +542,9 @@ func (s *ListeningService) UpdateBookPreferences(ctx context.Context, userID, bo
 	if req.HideFromContinueListening != nil {
 		prefs.HideFromContinueListening = *req.HideFromContinueListening
 	}
+	if req.PrivateListening != nil {
+		prefs.PrivateListening = *req.PrivateListening
+	}
 
 	prefs.UpdatedAt = time.Now()
 
@@ -527,6 +555,76 @@ func (s *ListeningService) UpdateBookPreferences(ctx context.Context, userID, bo
 	return prefs, nil
 }
 
+// GetEbookPosition returns the user's reading position in a book's companion ebook.
+func (s *ListeningService) GetEbookPosition(ctx context.Context, userID, bookID string) (*domain.EbookPosition, error) {
+	if _, err := s.accessibleBook(ctx, userID, bookID); err != nil {
+		return nil, err
+	}
+	return s.store.GetEbookPosition(ctx, userID, bookID)
+}
+
+// GetEbookPositions returns all of the user's ebook positions, most recent first.
+func (s *ListeningService) GetEbookPositions(ctx context.Context, userID string) ([]*domain.EbookPosition, error) {
+	return s.store.GetEbookPositionsForUser(ctx, userID)
+}
+
+// UpdateEbookPositionRequest records where the user is in a companion ebook.
+type UpdateEbookPositionRequest struct {
+	EbookFileID string  `json:"ebook_file_id" validate:"required"`
+	CFI         string  `json:"cfi" validate:"required,max=2048"`
+	Progress    float64 `json:"progress" validate:"gte=0,lte=1"`
+	DeviceID    string  `json:"device_id" validate:"max=255"`
+}
+
+// UpdateEbookPosition saves the user's reading position and tells their other devices.
+// EPUB positions must be CFIs; PDF readers may use any locator.
+func (s *ListeningService) UpdateEbookPosition(ctx context.Context, userID, bookID string, req UpdateEbookPositionRequest) (*domain.EbookPosition, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	book, err := s.accessibleBook(ctx, userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	file := book.GetEbookFileByID(req.EbookFileID)
+	if file == nil {
+		return nil, domainerrors.Validation("ebook file not found in this book")
+	}
+	if file.Format == domain.EbookFormatEPUB && !domain.IsEPUBCFI(req.CFI) {
+		return nil, domainerrors.Validation("cfi must be an EPUB CFI, e.g. epubcfi(/6/4!/4/2/1:0)")
+	}
+
+	position := &domain.EbookPosition{
+		UserID:      userID,
+		BookID:      bookID,
+		EbookFileID: file.ID,
+		CFI:         req.CFI,
+		Progress:    req.Progress,
+		DeviceID:    req.DeviceID,
+		UpdatedAt:   time.Now(),
+	}
+	if err := s.store.UpsertEbookPosition(ctx, position); err != nil {
+		return nil, fmt.Errorf("save ebook position: %w", err)
+	}
+
+	s.events.Emit(sse.NewEbookPositionUpdatedEvent(position))
+
+	return position, nil
+}
+
+// accessibleBook loads a book the user can see, or reports it as not found.
+func (s *ListeningService) accessibleBook(ctx context.Context, userID, bookID string) (*domain.Book, error) {
+	ok, err := s.store.CanUserAccessBook(ctx, userID, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("check book access: %w", err)
+	}
+	if !ok {
+		return nil, store.ErrBookNotFound
+	}
+	return s.store.GetBook(ctx, bookID, userID)
+}
+
 // UserStats contains listening statistics for a user.
 type UserStats struct {
 	TotalListenTimeMs int64 `json:"total_listen_time_ms"`
@@ -655,6 +753,8 @@ func (s *ListeningService) MarkComplete(ctx context.Context, userID, bookID stri
 	// Emit SSE event
 	s.events.Emit(sse.NewProgressUpdatedEvent(userID, state, book.TotalDuration))
 
+	s.checkGoals(ctx, userID)
+
 	return state, nil
 }
 
@@ -696,6 +796,8 @@ func (s *ListeningService) DiscardProgress(ctx context.Context, userID, bookID s
 	// Emit SSE event
 	s.events.Emit(sse.NewProgressDeletedEvent(userID, bookID))
 
+	s.checkGoals(ctx, userID)
+
 	return nil
 }
 
@@ -754,6 +856,8 @@ func (s *ListeningService) RestartBook(ctx context.Context, userID, bookID strin
 	// Emit SSE event
 	s.events.Emit(sse.NewProgressUpdatedEvent(userID, state, book.TotalDuration))
 
+	s.checkGoals(ctx, userID)
+
 	return state, nil
 }
 
@@ -796,6 +900,10 @@ func (s *ListeningService) broadcastUserStatsUpdate(ctx context.Context, userID
 
 	// Get user profile for avatar info
 	profile, _ := s.store.GetUserProfile(ctx, userID)
+	if profile != nil && profile.HideFromLeaderboard {
+		// The event feeds everyone's leaderboard cache
+		return
+	}
 	avatarType := string(domain.AvatarTypeAuto)
 	avatarValue := ""
 	if profile != nil {
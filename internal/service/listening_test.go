@@ -293,6 +293,66 @@ func TestBookPreferences_CRUD(t *testing.T) {
 	assert.True(t, prefs.HideFromContinueListening)
 }
 
+func TestEbookPosition(t *testing.T) {
+	svc, testStore, cleanup := setupTestListening(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ensureTestUserForListening(t, testStore, "user-123")
+	createTestBookForListening(t, testStore, "book-456", 3600000)
+
+	book, err := testStore.GetBookByID(ctx, "book-456")
+	require.NoError(t, err)
+	book.EbookFiles = []domain.EbookFileInfo{
+		{ID: "eb-1", Path: "/test/book-456/Book.epub", Filename: "Book.epub", Format: domain.EbookFormatEPUB},
+		{ID: "eb-2", Path: "/test/book-456/Book.pdf", Filename: "Book.pdf", Format: domain.EbookFormatPDF},
+	}
+	require.NoError(t, testStore.UpdateBook(ctx, book))
+
+	_, err = svc.GetEbookPosition(ctx, "user-123", "book-456")
+	assert.ErrorIs(t, err, store.ErrEbookPositionNotFound)
+
+	pos, err := svc.UpdateEbookPosition(ctx, "user-123", "book-456", UpdateEbookPositionRequest{
+		EbookFileID: "eb-1",
+		CFI:         "epubcfi(/6/14!/4/2/1:0)",
+		Progress:    0.25,
+		DeviceID:    "tablet",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "eb-1", pos.EbookFileID)
+
+	got, err := svc.GetEbookPosition(ctx, "user-123", "book-456")
+	require.NoError(t, err)
+	assert.Equal(t, "epubcfi(/6/14!/4/2/1:0)", got.CFI)
+	assert.InDelta(t, 0.25, got.Progress, 0.0001)
+	assert.Equal(t, "tablet", got.DeviceID)
+
+	// PDFs keep whatever locator the reader uses.
+	_, err = svc.UpdateEbookPosition(ctx, "user-123", "book-456", UpdateEbookPositionRequest{
+		EbookFileID: "eb-2", CFI: "page=42", Progress: 0.5,
+	})
+	require.NoError(t, err)
+	all, err := svc.GetEbookPositions(ctx, "user-123")
+	require.NoError(t, err)
+	require.Len(t, all, 1, "one position per book")
+	assert.Equal(t, "eb-2", all[0].EbookFileID)
+
+	invalid := []UpdateEbookPositionRequest{
+		{EbookFileID: "eb-1", CFI: "/6/14!/4/2/1:0", Progress: 0.5},      // not a CFI
+		{EbookFileID: "eb-missing", CFI: "epubcfi(/6/2)", Progress: 0.5}, // not this book's file
+		{EbookFileID: "eb-1", CFI: "epubcfi(/6/2)", Progress: 1.5},       // out of range
+	}
+	for _, req := range invalid {
+		_, err := svc.UpdateEbookPosition(ctx, "user-123", "book-456", req)
+		assert.Error(t, err, "%+v", req)
+	}
+
+	_, err = svc.UpdateEbookPosition(ctx, "user-123", "book-missing", UpdateEbookPositionRequest{
+		EbookFileID: "eb-1", CFI: "epubcfi(/6/2)",
+	})
+	assert.ErrorIs(t, err, store.ErrBookNotFound)
+}
+
 func TestRecordEvent_Idempotency(t *testing.T) {
 	svc, testStore, cleanup := setupTestListening(t)
 	defer cleanup()
@@ -4,6 +4,8 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/metadata"
 	"github.com/listenupapp/listenup-server/internal/metadata/audible"
 	"github.com/listenupapp/listenup-server/internal/store"
 )
@@ -11,29 +13,45 @@ import (
 // metadataServiceStore is the narrow store interface MetadataService depends on.
 type metadataServiceStore interface {
 	store.MetadataCacheStore
+	store.ProviderCacheStore
+	GetLibrary(ctx context.Context, id string) (*domain.Library, error)
+	GetDefaultLibrary(ctx context.Context) (*domain.Library, error)
 }
 
 // MetadataService orchestrates metadata fetching with caching.
+//
+// Audible is always available through the region-aware methods below. It and
+// any other providers passed to NewMetadataService are also reachable by name
+// through the metadata.Provider methods in metadata_providers.go.
 type MetadataService struct {
 	client        *audible.Client
 	store         metadataServiceStore
 	defaultRegion audible.Region
+	providers     map[string]metadata.Provider
 	logger        *slog.Logger
 }
 
-// NewMetadataService creates a new metadata service.
+// NewMetadataService creates a new metadata service. Providers other than
+// Audible are wrapped with the provider response cache.
 func NewMetadataService(
 	client *audible.Client,
 	store metadataServiceStore,
 	defaultRegion audible.Region,
 	logger *slog.Logger,
+	providers ...metadata.Provider,
 ) *MetadataService {
-	return &MetadataService{
+	s := &MetadataService{
 		client:        client,
 		store:         store,
 		defaultRegion: defaultRegion,
+		providers:     make(map[string]metadata.Provider, len(providers)+1),
 		logger:        logger,
 	}
+	s.providers[metadata.ProviderAudible] = &audibleProvider{svc: s}
+	for _, p := range providers {
+		s.providers[p.Name()] = &cachedProvider{Provider: p, store: store, logger: logger}
+	}
+	return s
 }
 
 // Search searches the Audible catalog with caching.
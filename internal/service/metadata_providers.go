@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/metadata"
+	"github.com/listenupapp/listenup-server/internal/metadata/audible"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// ProviderSearchResult holds search results and the provider that returned them.
+type ProviderSearchResult struct {
+	Provider string
+	Results  []metadata.SearchResult
+}
+
+// AvailableProviders returns the configured providers in the default order.
+func (s *MetadataService) AvailableProviders() []string {
+	var names []string
+	for _, name := range metadata.DefaultProviderPriority {
+		if _, ok := s.providers[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Provider returns a configured provider by name.
+func (s *MetadataService) Provider(name string) (metadata.Provider, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return nil, domainerrors.Validationf("metadata provider %q is unknown or not configured", name)
+	}
+	return p, nil
+}
+
+// ProviderPriority returns the configured providers in the order a library
+// tries them. An empty libraryID means the default library.
+func (s *MetadataService) ProviderPriority(ctx context.Context, libraryID string) ([]string, error) {
+	var (
+		lib *domain.Library
+		err error
+	)
+	if libraryID == "" {
+		lib, err = s.store.GetDefaultLibrary(ctx)
+		if errors.Is(err, store.ErrNotFound) {
+			return s.AvailableProviders(), nil
+		}
+	} else {
+		lib, err = s.store.GetLibrary(ctx, libraryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lib.MetadataProviders) == 0 {
+		return s.AvailableProviders(), nil
+	}
+	var names []string
+	for _, name := range lib.MetadataProviders {
+		if _, ok := s.providers[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// SearchProviders searches a library's providers in priority order and
+// returns the results of the first one that finds anything.
+func (s *MetadataService) SearchProviders(ctx context.Context, libraryID string, query metadata.SearchQuery) (*ProviderSearchResult, error) {
+	if query.IsEmpty() {
+		return nil, domainerrors.Validation("search query is empty")
+	}
+
+	order, err := s.ProviderPriority(ctx, libraryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	failed := 0
+	for _, name := range order {
+		results, err := s.providers[name].Search(ctx, query)
+		if err != nil {
+			s.logger.Warn("metadata provider search failed, trying next",
+				"provider", name,
+				"error", err,
+			)
+			lastErr = err
+			failed++
+			continue
+		}
+		if len(results) > 0 {
+			return &ProviderSearchResult{Provider: name, Results: results}, nil
+		}
+	}
+
+	// Only an error if no provider could be asked at all.
+	if failed > 0 && failed == len(order) {
+		return nil, fmt.Errorf("search metadata providers: %w", lastErr)
+	}
+	return &ProviderSearchResult{}, nil
+}
+
+// SearchProvider searches one provider.
+func (s *MetadataService) SearchProvider(ctx context.Context, provider string, query metadata.SearchQuery) ([]metadata.SearchResult, error) {
+	if query.IsEmpty() {
+		return nil, domainerrors.Validation("search query is empty")
+	}
+	p, err := s.Provider(provider)
+	if err != nil {
+		return nil, err
+	}
+	results, err := p.Search(ctx, query)
+	return results, providerError(provider, "search", err)
+}
+
+// GetProviderBook fetches book metadata from one provider.
+func (s *MetadataService) GetProviderBook(ctx context.Context, provider, id string) (*metadata.Book, error) {
+	p, err := s.Provider(provider)
+	if err != nil {
+		return nil, err
+	}
+	book, err := p.GetBook(ctx, id)
+	return book, providerError(provider, "book", err)
+}
+
+// GetProviderChapters fetches chapters from one provider.
+func (s *MetadataService) GetProviderChapters(ctx context.Context, provider, id string) ([]metadata.Chapter, error) {
+	p, err := s.Provider(provider)
+	if err != nil {
+		return nil, err
+	}
+	chapters, err := p.GetChapters(ctx, id)
+	return chapters, providerError(provider, "chapters", err)
+}
+
+// GetProviderContributor fetches a contributor profile from one provider.
+func (s *MetadataService) GetProviderContributor(ctx context.Context, provider, id string) (*metadata.ContributorProfile, error) {
+	p, err := s.Provider(provider)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := p.GetContributor(ctx, id)
+	return profile, providerError(provider, "contributor profiles", err)
+}
+
+// providerError turns the provider sentinels into domain errors.
+func providerError(provider, what string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, metadata.ErrNotSupported):
+		return domainerrors.Validationf("%s does not provide %s", provider, what)
+	case errors.Is(err, metadata.ErrNotFound):
+		return domainerrors.NotFound(err.Error())
+	}
+	return err
+}
+
+// validateProviderPriority checks a library's provider list. Providers that
+// are known but not configured are allowed, so a list can name Hardcover
+// before its token is set.
+func validateProviderPriority(names []string) error {
+	for i, name := range names {
+		if !slices.Contains(metadata.DefaultProviderPriority, name) {
+			return domainerrors.Validationf("unknown metadata provider %q", name)
+		}
+		if slices.Contains(names[:i], name) {
+			return domainerrors.Validationf("metadata provider %q is listed twice", name)
+		}
+	}
+	return nil
+}
+
+// audibleProvider exposes the cached, region-aware Audible lookups as a
+// metadata.Provider. It uses the default region with fallback to US.
+type audibleProvider struct {
+	svc *MetadataService
+}
+
+func (p *audibleProvider) Name() string {
+	return metadata.ProviderAudible
+}
+
+func (p *audibleProvider) Search(ctx context.Context, q metadata.SearchQuery) ([]metadata.SearchResult, error) {
+	keywords := q.Keywords
+	if keywords == "" {
+		keywords = q.ISBN
+	}
+	results, _, err := p.svc.SearchWithFallback(ctx, audible.SearchParams{
+		Keywords: keywords,
+		Title:    q.Title,
+		Author:   q.Author,
+		Limit:    q.Limit,
+	})
+	if err != nil {
+		return nil, audibleError(err)
+	}
+
+	out := make([]metadata.SearchResult, 0, len(results))
+	for i := range results {
+		r := &results[i]
+		result := metadata.SearchResult{
+			Provider:       metadata.ProviderAudible,
+			ID:             r.ASIN,
+			Title:          r.Title,
+			Subtitle:       r.Subtitle,
+			Authors:        contributorsFromAudible(r.Authors),
+			Narrators:      contributorsFromAudible(r.Narrators),
+			CoverURL:       r.CoverURL,
+			RuntimeMinutes: r.RuntimeMinutes,
+		}
+		if !r.ReleaseDate.IsZero() {
+			result.PublishYear = strconv.Itoa(r.ReleaseDate.Year())
+		}
+		out = append(out, result)
+	}
+	return out, nil
+}
+
+func (p *audibleProvider) GetBook(ctx context.Context, id string) (*metadata.Book, error) {
+	book, err := p.svc.GetBook(ctx, nil, id)
+	if err != nil {
+		return nil, audibleError(err)
+	}
+	return metadataFromAudible(id, book), nil
+}
+
+func (p *audibleProvider) GetChapters(ctx context.Context, id string) ([]metadata.Chapter, error) {
+	chapters, err := p.svc.GetChapters(ctx, nil, id)
+	if err != nil {
+		return nil, audibleError(err)
+	}
+	out := make([]metadata.Chapter, len(chapters))
+	for i, ch := range chapters {
+		out[i] = metadata.Chapter(ch)
+	}
+	return out, nil
+}
+
+func (p *audibleProvider) GetContributor(ctx context.Context, id string) (*metadata.ContributorProfile, error) {
+	profile, _, err := p.svc.GetContributorProfileWithFallback(ctx, id)
+	if err != nil {
+		return nil, audibleError(err)
+	}
+	return &metadata.ContributorProfile{
+		Provider:  metadata.ProviderAudible,
+		ID:        profile.ASIN,
+		Name:      profile.Name,
+		Biography: profile.Biography,
+		ImageURL:  profile.ImageURL,
+	}, nil
+}
+
+// audibleError marks Audible's not-found errors with metadata.ErrNotFound.
+func audibleError(err error) error {
+	if errors.Is(err, audible.ErrNotFound) || errors.Is(err, audible.ErrContributorNotFound) || errors.Is(err, audible.ErrInvalidASIN) {
+		return fmt.Errorf("%w: %w", metadata.ErrNotFound, err)
+	}
+	return err
+}
+
+// metadataFromAudible converts an Audible book to the provider-neutral form.
+func metadataFromAudible(asin string, b *audible.Book) *metadata.Book {
+	book := &metadata.Book{
+		Provider:       metadata.ProviderAudible,
+		ID:             asin,
+		Title:          b.Title,
+		Subtitle:       b.Subtitle,
+		Authors:        contributorsFromAudible(b.Authors),
+		Narrators:      contributorsFromAudible(b.Narrators),
+		Publisher:      b.Publisher,
+		ReleaseDate:    b.ReleaseDate,
+		RuntimeMinutes: b.RuntimeMinutes,
+		Description:    b.Description,
+		CoverURL:       b.CoverURL,
+		Genres:         b.Genres,
+		Language:       b.Language,
+		ASIN:           asin,
+	}
+	for _, se := range b.Series {
+		book.Series = append(book.Series, metadata.SeriesEntry{ID: se.ASIN, Name: se.Name, Position: se.Position})
+	}
+	return book
+}
+
+func contributorsFromAudible(contributors []audible.Contributor) []metadata.Contributor {
+	out := make([]metadata.Contributor, 0, len(contributors))
+	for _, c := range contributors {
+		out = append(out, metadata.Contributor{ID: c.ASIN, Name: c.Name})
+	}
+	return out
+}
+
+// cachedProvider wraps a provider with the provider response cache. Cache
+// failures are logged and fall through to the provider.
+type cachedProvider struct {
+	metadata.Provider
+	store  store.ProviderCacheStore
+	logger *slog.Logger
+}
+
+func (p *cachedProvider) Search(ctx context.Context, q metadata.SearchQuery) ([]metadata.SearchResult, error) {
+	name := p.Name()
+	key := fmt.Sprintf("k=%s|t=%s|a=%s|i=%s|n=%d", q.Keywords, q.Title, q.Author, q.ISBN, q.Limit)
+
+	cached, err := p.store.GetCachedProviderSearch(ctx, name, key)
+	if err != nil {
+		p.logger.Warn("provider search cache lookup failed", "provider", name, "error", err)
+	}
+	if cached != nil {
+		return cached.Results, nil
+	}
+
+	results, err := p.Provider.Search(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.store.SetCachedProviderSearch(ctx, name, key, results); err != nil {
+		p.logger.Warn("failed to cache provider search", "provider", name, "error", err)
+	}
+	return results, nil
+}
+
+func (p *cachedProvider) GetBook(ctx context.Context, id string) (*metadata.Book, error) {
+	name := p.Name()
+
+	cached, err := p.store.GetCachedProviderBook(ctx, name, id)
+	if err != nil {
+		p.logger.Warn("provider book cache lookup failed", "provider", name, "id", id, "error", err)
+	}
+	if cached != nil {
+		return cached.Book, nil
+	}
+
+	book, err := p.Provider.GetBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.store.SetCachedProviderBook(ctx, name, id, book); err != nil {
+		p.logger.Warn("failed to cache provider book", "provider", name, "id", id, "error", err)
+	}
+	return book, nil
+}
+
+func (p *cachedProvider) GetContributor(ctx context.Context, id string) (*metadata.ContributorProfile, error) {
+	name := p.Name()
+
+	cached, err := p.store.GetCachedProviderContributor(ctx, name, id)
+	if err != nil {
+		p.logger.Warn("provider contributor cache lookup failed", "provider", name, "id", id, "error", err)
+	}
+	if cached != nil {
+		return cached.Profile, nil
+	}
+
+	profile, err := p.Provider.GetContributor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.store.SetCachedProviderContributor(ctx, name, id, profile); err != nil {
+		p.logger.Warn("failed to cache provider contributor", "provider", name, "id", id, "error", err)
+	}
+	return profile, nil
+}
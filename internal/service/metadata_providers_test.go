@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/metadata"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+// fakeProvider returns canned results and counts calls.
+type fakeProvider struct {
+	name    string
+	results []metadata.SearchResult
+	book    *metadata.Book // returned by GetBook when set
+	err     error
+	calls   int
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) Search(context.Context, metadata.SearchQuery) ([]metadata.SearchResult, error) {
+	p.calls++
+	return p.results, p.err
+}
+
+func (p *fakeProvider) GetBook(_ context.Context, id string) (*metadata.Book, error) {
+	p.calls++
+	if p.book != nil {
+		return p.book, nil
+	}
+	return &metadata.Book{Provider: p.name, ID: id, Title: "Dune"}, nil
+}
+
+func (p *fakeProvider) GetChapters(context.Context, string) ([]metadata.Chapter, error) {
+	return nil, metadata.ErrNotSupported
+}
+
+func (p *fakeProvider) GetContributor(context.Context, string) (*metadata.ContributorProfile, error) {
+	return nil, metadata.ErrNotFound
+}
+
+func TestMetadataService_SearchProvidersPriority(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	openLibrary := &fakeProvider{name: metadata.ProviderOpenLibrary}
+	google := &fakeProvider{name: metadata.ProviderGoogleBooks, err: errors.New("quota exceeded")}
+	hardcover := &fakeProvider{
+		name:    metadata.ProviderHardcover,
+		results: []metadata.SearchResult{{Provider: metadata.ProviderHardcover, ID: "312", Title: "Dune"}},
+	}
+	svc := NewMetadataService(nil, s, "us", slog.New(slog.DiscardHandler), openLibrary, google, hardcover)
+
+	assert.Equal(t,
+		[]string{metadata.ProviderAudible, metadata.ProviderHardcover, metadata.ProviderGoogleBooks, metadata.ProviderOpenLibrary},
+		svc.AvailableProviders())
+
+	owner := createTestUserWithPermissions(t, s, "owner@example.com", true)
+	now := time.Now()
+	lib := &domain.Library{
+		ID:                "lib-1",
+		OwnerID:           owner.ID,
+		Name:              "Books",
+		ScanPaths:         []string{"/media/books"},
+		AccessMode:        domain.AccessModeOpen,
+		MetadataProviders: []string{metadata.ProviderOpenLibrary, metadata.ProviderGoogleBooks, metadata.ProviderHardcover},
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	require.NoError(t, s.CreateLibrary(ctx, lib))
+
+	// Empty results and errors both fall through to the next provider.
+	found, err := svc.SearchProviders(ctx, lib.ID, metadata.SearchQuery{Title: "Dune"})
+	require.NoError(t, err)
+	assert.Equal(t, metadata.ProviderHardcover, found.Provider)
+	require.Len(t, found.Results, 1)
+	assert.Equal(t, "312", found.Results[0].ID)
+	assert.Equal(t, 1, openLibrary.calls)
+	assert.Equal(t, 1, google.calls)
+
+	// A repeat search is answered from the cache.
+	_, err = svc.SearchProviders(ctx, lib.ID, metadata.SearchQuery{Title: "Dune"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, hardcover.calls)
+
+	_, err = svc.SearchProviders(ctx, lib.ID, metadata.SearchQuery{})
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+}
+
+func TestMetadataService_ProviderErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	openLibrary := &fakeProvider{name: metadata.ProviderOpenLibrary}
+	svc := NewMetadataService(nil, s, "us", slog.New(slog.DiscardHandler), openLibrary)
+
+	_, err = svc.GetProviderBook(ctx, metadata.ProviderHardcover, "1")
+	assert.ErrorIs(t, err, domainerrors.Validation(""), "unconfigured provider")
+
+	_, err = svc.GetProviderChapters(ctx, metadata.ProviderOpenLibrary, "OL1W")
+	assert.ErrorIs(t, err, domainerrors.Validation(""), "unsupported lookup")
+
+	_, err = svc.GetProviderContributor(ctx, metadata.ProviderOpenLibrary, "OL1A")
+	assert.ErrorIs(t, err, domainerrors.NotFound(""))
+
+	book, err := svc.GetProviderBook(ctx, metadata.ProviderOpenLibrary, "OL1W")
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", book.Title)
+}
+
+func TestValidateProviderPriority(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateProviderPriority(nil))
+	require.NoError(t, validateProviderPriority([]string{metadata.ProviderHardcover, metadata.ProviderAudible}))
+	assert.ErrorIs(t, validateProviderPriority([]string{"goodreads"}), domainerrors.Validation(""))
+	assert.ErrorIs(t, validateProviderPriority([]string{metadata.ProviderAudible, metadata.ProviderAudible}), domainerrors.Validation(""))
+}
@@ -0,0 +1,582 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/config"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/oidc"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+const (
+	// oidcLoginTTL is how long the user has to finish signing in at the
+	// provider after starting a login.
+	oidcLoginTTL = 10 * time.Minute
+
+	// oidcGrantTTL is how long the client has to redeem the one-time code
+	// it was sent back with.
+	oidcGrantTTL = time.Minute
+)
+
+// Error codes sent back to the client's return URL when a single sign-on
+// login fails after the provider redirected back to us.
+const (
+	OIDCErrorAccessDenied  = "access_denied"  // The user or provider declined
+	OIDCErrorNotRegistered = "not_registered" // No account and auto-provisioning is off
+	OIDCErrorPending       = "account_pending"
+	OIDCErrorFailed        = "login_failed"
+)
+
+// oidcServiceStore is the narrow store surface OIDCService needs.
+type oidcServiceStore interface {
+	store.UserStore
+	store.OIDCIdentityStore
+}
+
+// oidcLogin is a login waiting for the provider to redirect back.
+type oidcLogin struct {
+	nonce     string
+	verifier  string
+	returnTo  string
+	expiresAt time.Time
+}
+
+// oidcGrant is a finished login waiting for the client to collect tokens.
+type oidcGrant struct {
+	userID    string
+	expiresAt time.Time
+}
+
+// OIDCService handles single sign-on through an OpenID Connect provider.
+//
+// The flow is: the client asks StartLogin for the provider URL and opens
+// it in a browser; the provider redirects to our callback, which resolves
+// the provider account to a user and sends the browser to the client's
+// return URL with a one-time code; the client trades that code for the
+// usual tokens with Exchange. Tokens never appear in a URL.
+//
+// Pending logins and codes are kept in memory; a restart only means users
+// mid-login have to start again.
+type OIDCService struct {
+	store    oidcServiceStore
+	provider *oidc.Provider // nil when single sign-on is off
+	cfg      config.OIDCConfig
+	auth     *AuthService
+	instance *InstanceService
+	logger   *slog.Logger
+
+	mu     sync.Mutex
+	logins map[string]*oidcLogin // state → login
+	grants map[string]*oidcGrant // one-time code → grant
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+// NewOIDCService creates the single sign-on service. provider may be nil,
+// in which case every call but Enabled reports single sign-on as off.
+func NewOIDCService(
+	s oidcServiceStore,
+	provider *oidc.Provider,
+	cfg config.OIDCConfig,
+	authService *AuthService,
+	instanceService *InstanceService,
+	logger *slog.Logger,
+) *OIDCService {
+	return &OIDCService{
+		store:    s,
+		provider: provider,
+		cfg:      cfg,
+		auth:     authService,
+		instance: instanceService,
+		logger:   logger,
+		logins:   make(map[string]*oidcLogin),
+		grants:   make(map[string]*oidcGrant),
+		now:      time.Now,
+	}
+}
+
+// OIDCInfo tells clients whether to offer single sign-on.
+type OIDCInfo struct {
+	Enabled     bool
+	ButtonLabel string
+}
+
+// OIDCCallback is what the provider sends to the callback URL.
+type OIDCCallback struct {
+	State            string
+	Code             string
+	Error            string
+	ErrorDescription string
+}
+
+// OIDCExchangeRequest trades the one-time code from the callback for tokens.
+type OIDCExchangeRequest struct {
+	Code       string          `json:"code" validate:"required,max=128"`
+	DeviceInfo auth.DeviceInfo `json:"device_info"`
+	IPAddress  string          `json:"-"` // Extracted from request by handler
+}
+
+// Enabled reports whether single sign-on is configured.
+func (s *OIDCService) Enabled() bool {
+	return s.provider != nil
+}
+
+// Info returns what the login screen needs to show a single sign-on button.
+func (s *OIDCService) Info() OIDCInfo {
+	if !s.Enabled() {
+		return OIDCInfo{}
+	}
+	return OIDCInfo{Enabled: true, ButtonLabel: s.cfg.ButtonLabel}
+}
+
+// StartLogin begins a login and returns the provider URL to open.
+// returnTo is where the browser goes afterwards: a path on this server or
+// a URL under one of the configured allowed prefixes, such as the app's
+// custom scheme.
+func (s *OIDCService) StartLogin(ctx context.Context, returnTo string) (string, error) {
+	if !s.Enabled() {
+		return "", domainerrors.NotFound("single sign-on is not configured")
+	}
+	if !s.allowedReturn(returnTo) {
+		return "", domainerrors.Validation("return_to is not an allowed redirect")
+	}
+	if setup, err := s.instance.IsSetupRequired(ctx); err != nil {
+		return "", err
+	} else if setup {
+		return "", domainerrors.Forbidden("finish server setup before using single sign-on")
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return "", fmt.Errorf("generate state: %w", err)
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		return "", fmt.Errorf("generate verifier: %w", err)
+	}
+
+	authURL, err := s.provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", domainerrors.Internal("identity provider is unavailable").WithCause(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+	s.logins[state] = &oidcLogin{
+		nonce:     nonce,
+		verifier:  verifier,
+		returnTo:  returnTo,
+		expiresAt: s.now().Add(oidcLoginTTL),
+	}
+
+	return authURL, nil
+}
+
+// HandleCallback finishes the provider side of a login and returns where
+// to send the browser: the client's return URL with either a one-time
+// "code" or an "error" (one of the OIDCError codes) and
+// "error_description". An error is only returned when the state is
+// unknown, since then there is nowhere safe to redirect to.
+func (s *OIDCService) HandleCallback(ctx context.Context, cb OIDCCallback) (string, error) {
+	if !s.Enabled() {
+		return "", domainerrors.NotFound("single sign-on is not configured")
+	}
+
+	s.mu.Lock()
+	login, ok := s.logins[cb.State]
+	delete(s.logins, cb.State)
+	s.mu.Unlock()
+	if !ok || s.now().After(login.expiresAt) {
+		return "", domainerrors.Validation("login expired or was already used, start again")
+	}
+
+	if cb.Error != "" {
+		desc := cb.ErrorDescription
+		if desc == "" {
+			desc = "the identity provider declined the login"
+		}
+		return withQuery(login.returnTo, "error", OIDCErrorAccessDenied, "error_description", desc), nil
+	}
+
+	user, err := s.authenticate(ctx, login, cb.Code)
+	if err != nil {
+		code := OIDCErrorFailed
+		desc := "single sign-on failed"
+		var domainErr *domainerrors.Error
+		if errors.As(err, &domainErr) {
+			switch domainErr.Code {
+			case domainerrors.CodeForbidden:
+				code, desc = OIDCErrorNotRegistered, domainErr.Message
+			case domainerrors.CodeInternal:
+				// Keep the generic description
+			default:
+				desc = domainErr.Message
+			}
+		}
+		if s.logger != nil {
+			s.logger.Warn("Single sign-on failed", "error", err)
+		}
+		return withQuery(login.returnTo, "error", code, "error_description", desc), nil
+	}
+
+	if user.IsPending() {
+		return withQuery(login.returnTo, "error", OIDCErrorPending,
+			"error_description", "your account is pending admin approval"), nil
+	}
+
+	code, err := oidc.RandomString()
+	if err != nil {
+		return "", fmt.Errorf("generate login code: %w", err)
+	}
+	s.mu.Lock()
+	s.grants[code] = &oidcGrant{userID: user.ID, expiresAt: s.now().Add(oidcGrantTTL)}
+	s.mu.Unlock()
+
+	return withQuery(login.returnTo, "code", code), nil
+}
+
+// Exchange trades the one-time code from HandleCallback for an access and
+// refresh token pair, or a two-factor challenge if the user has it on.
+func (s *OIDCService) Exchange(ctx context.Context, req OIDCExchangeRequest) (*AuthResponse, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+	if !req.DeviceInfo.IsValid() {
+		return nil, domainerrors.Validation("device_info is required (device_type and platform)")
+	}
+
+	s.mu.Lock()
+	grant, ok := s.grants[req.Code]
+	delete(s.grants, req.Code)
+	s.mu.Unlock()
+	if !ok || s.now().After(grant.expiresAt) {
+		return nil, domainerrors.InvalidCredentials("login code is invalid or expired")
+	}
+
+	user, err := s.store.GetUser(ctx, grant.userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, domainerrors.InvalidCredentials("login code is invalid or expired")
+		}
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+
+	return s.auth.LoginVerified(ctx, user, req.DeviceInfo, req.IPAddress)
+}
+
+// authenticate exchanges the code, validates the ID token and resolves the
+// provider account to a user.
+func (s *OIDCService) authenticate(ctx context.Context, login *oidcLogin, code string) (*domain.User, error) {
+	if code == "" {
+		return nil, domainerrors.Validation("identity provider sent no authorization code")
+	}
+
+	tok, err := s.provider.Exchange(ctx, code, login.verifier)
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+	claims, err := s.provider.VerifyIDToken(ctx, tok.IDToken, login.nonce)
+	if err != nil {
+		return nil, fmt.Errorf("verify ID token: %w", err)
+	}
+
+	// Fill in email and groups from userinfo when the ID token is lean.
+	// Groups are only known when the ID token carries them or userinfo
+	// answered; a failed fetch must not read as leaving every group.
+	groupsKnown := claims.Strings(s.cfg.GroupsClaim) != nil
+	if claims.Email == "" || (len(s.cfg.AdminGroups) > 0 && !groupsKnown) {
+		info, err := s.provider.UserInfo(ctx, tok.AccessToken)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Warn("Failed to fetch userinfo", "error", err)
+			}
+		} else if info != nil {
+			claims.Merge(info)
+			groupsKnown = true
+		}
+	}
+
+	user, err := s.resolveUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !groupsKnown {
+		if s.logger != nil && len(s.cfg.AdminGroups) > 0 {
+			s.logger.Warn("No group information from identity provider, leaving role unchanged", "user_id", user.ID)
+		}
+	} else if err := s.syncRole(ctx, user, claims); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// resolveUser finds the user for a provider account: an existing link
+// first, then an existing user with the same email (which gets linked),
+// and finally a new account if auto-provisioning is on.
+func (s *OIDCService) resolveUser(ctx context.Context, claims *oidc.Claims) (*domain.User, error) {
+	issuer := s.provider.Issuer()
+	now := s.now()
+
+	ident, err := s.store.GetOIDCIdentity(ctx, issuer, claims.Subject)
+	switch {
+	case err == nil:
+		user, err := s.store.GetUser(ctx, ident.UserID)
+		if err != nil {
+			if errors.Is(err, store.ErrUserNotFound) {
+				return nil, domainerrors.Forbidden("the linked account no longer exists")
+			}
+			return nil, fmt.Errorf("lookup user: %w", err)
+		}
+		if err := s.store.TouchOIDCIdentity(ctx, issuer, claims.Subject, now); err != nil && s.logger != nil {
+			s.logger.Warn("Failed to record single sign-on login", "user_id", user.ID, "error", err)
+		}
+		return user, nil
+	case !errors.Is(err, store.ErrOIDCIdentityNotFound):
+		return nil, fmt.Errorf("lookup identity: %w", err)
+	}
+
+	if claims.Email == "" {
+		return nil, domainerrors.Validation("identity provider did not share an email address")
+	}
+
+	user, err := s.store.GetUserByEmail(ctx, claims.Email)
+	switch {
+	case err == nil:
+		// Linking on an unverified email would let anyone who can set
+		// their email at the provider take over the matching account.
+		if !claims.EmailVerified && !s.cfg.TrustUnverifiedEmail {
+			return nil, domainerrors.Forbidden("identity provider has not verified this email address")
+		}
+		if err := s.link(ctx, user, claims, "Linked single sign-on account by email"); err != nil {
+			return nil, err
+		}
+		return user, nil
+	case !errors.Is(err, store.ErrUserNotFound):
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+
+	if !s.cfg.AutoProvision {
+		return nil, domainerrors.Forbidden("there is no ListenUp account for this login, ask an admin for an invite")
+	}
+	return s.provision(ctx, claims)
+}
+
+// provision creates a user for a provider account. With open registration
+// the account is active right away; otherwise it waits for an admin.
+func (s *OIDCService) provision(ctx context.Context, claims *oidc.Claims) (*domain.User, error) {
+	instance, err := s.instance.GetInstance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
+
+	userID, err := id.Generate("user")
+	if err != nil {
+		return nil, fmt.Errorf("generate user ID: %w", err)
+	}
+
+	status := domain.UserStatusPending
+	if instance.OpenRegistration {
+		status = domain.UserStatusActive
+	}
+
+	user := &domain.User{
+		Syncable: domain.Syncable{
+			ID: userID,
+		},
+		Email:       claims.Email,
+		Role:        domain.RoleMember,
+		Status:      status,
+		FirstName:   claims.GivenName,
+		LastName:    claims.FamilyName,
+		DisplayName: oidcDisplayName(claims),
+		Permissions: domain.DefaultPermissions(),
+	}
+	if s.inAdminGroup(claims) {
+		user.Role = domain.RoleAdmin
+	}
+	user.InitTimestamps()
+
+	if err := s.store.CreateUser(ctx, user); err != nil {
+		if errors.Is(err, store.ErrEmailExists) {
+			return nil, domainerrors.AlreadyExists("email already in use")
+		}
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	if err := s.link(ctx, user, claims, "Provisioned user from single sign-on"); err != nil {
+		return nil, err
+	}
+
+	if user.IsPending() {
+		s.store.BroadcastUserPending(user)
+	}
+	return user, nil
+}
+
+// link records that a provider account belongs to user.
+func (s *OIDCService) link(ctx context.Context, user *domain.User, claims *oidc.Claims, msg string) error {
+	now := s.now()
+	ident := &domain.OIDCIdentity{
+		Issuer:      s.provider.Issuer(),
+		Subject:     claims.Subject,
+		UserID:      user.ID,
+		Email:       claims.Email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}
+	if err := s.store.CreateOIDCIdentity(ctx, ident); err != nil {
+		if errors.Is(err, store.ErrOIDCIdentityExists) {
+			return domainerrors.Conflict("single sign-on account is already linked, try again")
+		}
+		return fmt.Errorf("link identity: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Info(msg,
+			"user_id", user.ID,
+			"issuer", ident.Issuer,
+			"email", claims.Email,
+		)
+	}
+	return nil
+}
+
+// syncRole makes the user's role follow admin group membership. Roles are
+// left alone when no admin groups are configured, and the root user is
+// never demoted. Callers only sync when the provider sent group data.
+func (s *OIDCService) syncRole(ctx context.Context, user *domain.User, claims *oidc.Claims) error {
+	if len(s.cfg.AdminGroups) == 0 || user.IsRoot {
+		return nil
+	}
+
+	want := domain.RoleMember
+	if s.inAdminGroup(claims) {
+		want = domain.RoleAdmin
+	}
+	if user.Role == want {
+		return nil
+	}
+
+	user.Role = want
+	user.Touch()
+	if err := s.store.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("update role: %w", err)
+	}
+	if s.logger != nil {
+		s.logger.Info("Updated role from single sign-on groups", "user_id", user.ID, "role", want)
+	}
+	return nil
+}
+
+func (s *OIDCService) inAdminGroup(claims *oidc.Claims) bool {
+	for _, g := range claims.Strings(s.cfg.GroupsClaim) {
+		if slices.Contains(s.cfg.AdminGroups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedReturn reports whether the browser may be sent to returnTo after
+// login. Anything else would make the callback an open redirect that hands
+// out login codes.
+func (s *OIDCService) allowedReturn(returnTo string) bool {
+	if returnTo == "" {
+		return false
+	}
+	// Local paths, but not protocol-relative "//host" or "/\host".
+	if returnTo[0] == '/' {
+		return len(returnTo) == 1 || (returnTo[1] != '/' && returnTo[1] != '\\')
+	}
+	for _, prefix := range s.cfg.AllowedRedirects {
+		if prefix != "" && matchesRedirect(returnTo, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRedirect reports whether returnTo falls under an allowed redirect
+// prefix. Web prefixes must match scheme and host exactly, with the path
+// matched on a "/" boundary, so "https://app.example.com" doesn't also allow
+// "https://app.example.com.evil.net" or "https://app.example.com@evil.net".
+// App schemes such as "listenup://" are matched as plain prefixes.
+func matchesRedirect(returnTo, prefix string) bool {
+	allowed, err := url.Parse(prefix)
+	if err != nil {
+		return false
+	}
+	if allowed.Scheme != "http" && allowed.Scheme != "https" {
+		return strings.HasPrefix(returnTo, prefix)
+	}
+
+	target, err := url.Parse(returnTo)
+	if err != nil || target.User != nil || target.Opaque != "" {
+		return false
+	}
+	if !strings.EqualFold(target.Scheme, allowed.Scheme) || !strings.EqualFold(target.Host, allowed.Host) {
+		return false
+	}
+
+	base := strings.TrimSuffix(allowed.Path, "/")
+	return base == "" || target.Path == base || strings.HasPrefix(target.Path, base+"/")
+}
+
+// pruneLocked drops expired logins and codes. Called with s.mu held.
+func (s *OIDCService) pruneLocked() {
+	now := s.now()
+	for k, l := range s.logins {
+		if now.After(l.expiresAt) {
+			delete(s.logins, k)
+		}
+	}
+	for k, g := range s.grants {
+		if now.After(g.expiresAt) {
+			delete(s.grants, k)
+		}
+	}
+}
+
+// oidcDisplayName picks the friendliest name the provider shared.
+func oidcDisplayName(claims *oidc.Claims) string {
+	switch {
+	case claims.Name != "":
+		return claims.Name
+	case claims.GivenName != "" || claims.FamilyName != "":
+		return strings.TrimSpace(claims.GivenName + " " + claims.FamilyName)
+	case claims.PreferredUsername != "":
+		return claims.PreferredUsername
+	}
+	local, _, _ := strings.Cut(claims.Email, "@")
+	return local
+}
+
+// withQuery appends query parameters (key, value pairs) to a URL.
+func withQuery(rawURL string, kv ...string) string {
+	params := url.Values{}
+	for i := 0; i+1 < len(kv); i += 2 {
+		params.Set(kv[i], kv[i+1])
+	}
+	sep := "?"
+	if strings.Contains(rawURL, "?") {
+		sep = "&"
+	}
+	return rawURL + sep + params.Encode()
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/config"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/oidc"
+	"github.com/listenupapp/listenup-server/internal/oidc/oidctest"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+type oidcTest struct {
+	svc      *OIDCService
+	twoFA    *TwoFactorService
+	instance *InstanceService
+	issuer   *oidctest.Issuer
+	store    *sqlite.Store
+}
+
+func setupOIDCTest(t *testing.T, cfg config.OIDCConfig) *oidcTest {
+	t.Helper()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	key, err := auth.LoadOrGenerateKey(t.TempDir())
+	require.NoError(t, err)
+	tokenService, err := auth.NewTokenService(hex.EncodeToString(key), 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	logger := slog.New(slog.DiscardHandler)
+	sessions := NewSessionService(s, tokenService, nil, logger)
+	instance := NewInstanceService(s, logger, &config.Config{})
+	twoFactor := NewTwoFactorService(s, logger)
+	authService := NewAuthService(s, tokenService, sessions, instance, twoFactor, logger)
+
+	_, err = instance.InitializeInstance(ctx)
+	require.NoError(t, err)
+	root := createTestUserWithPermissions(t, s, "root@example.com", true)
+	require.NoError(t, instance.SetRootUser(ctx, root.ID))
+
+	issuer := oidctest.NewIssuer(t, "listenup")
+	provider, err := oidc.NewProvider(oidc.Config{
+		IssuerURL:    issuer.URL,
+		ClientID:     "listenup",
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  "http://localhost:8080/api/v1/auth/oidc/callback",
+	})
+	require.NoError(t, err)
+
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	if cfg.AllowedRedirects == nil {
+		cfg.AllowedRedirects = []string{"listenup://"}
+	}
+
+	return &oidcTest{
+		svc:      NewOIDCService(s, provider, cfg, authService, instance, logger),
+		twoFA:    twoFactor,
+		instance: instance,
+		issuer:   issuer,
+		store:    s,
+	}
+}
+
+// login runs a browser round trip through the fake provider and returns
+// the query the client's return URL was called with.
+func (o *oidcTest) login(t *testing.T, claims map[string]any) url.Values {
+	t.Helper()
+	ctx := context.Background()
+
+	authURL, err := o.svc.StartLogin(ctx, "listenup://oidc")
+	require.NoError(t, err)
+	cb := o.issuer.Authorize(t, authURL, claims)
+
+	location, err := o.svc.HandleCallback(ctx, OIDCCallback{State: cb.Get("state"), Code: cb.Get("code")})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(location, "listenup://oidc?"), location)
+
+	u, err := url.Parse(location)
+	require.NoError(t, err)
+	return u.Query()
+}
+
+func (o *oidcTest) exchange(t *testing.T, code string) (*AuthResponse, error) {
+	t.Helper()
+	return o.svc.Exchange(context.Background(), OIDCExchangeRequest{
+		Code:       code,
+		DeviceInfo: auth.DeviceInfo{DeviceType: "mobile", Platform: "iOS"},
+	})
+}
+
+func TestOIDC_ProvisionAndLogin(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	o := setupOIDCTest(t, config.OIDCConfig{AutoProvision: true, AdminGroups: []string{"listenup-admins"}})
+
+	// Registration is closed, so a new account waits for approval.
+	q := o.login(t, map[string]any{
+		"sub": "sub-alice", "email": "alice@example.com", "email_verified": true,
+		"given_name": "Alice", "family_name": "Liddell",
+	})
+	assert.Equal(t, OIDCErrorPending, q.Get("error"))
+	assert.Empty(t, q.Get("code"))
+
+	alice, err := o.store.GetUserByEmail(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.True(t, alice.IsPending())
+	assert.Equal(t, "Alice Liddell", alice.DisplayName)
+	assert.Empty(t, alice.PasswordHash)
+	assert.Equal(t, domain.RoleMember, alice.Role)
+
+	// With open registration, new accounts are active, and groups map to admin.
+	require.NoError(t, o.instance.SetOpenRegistration(ctx, true))
+	q = o.login(t, map[string]any{
+		"sub": "sub-bob", "email": "bob@example.com", "email_verified": true,
+		"preferred_username": "bob", "groups": []string{"family", "listenup-admins"},
+	})
+	require.NotEmpty(t, q.Get("code"), q.Encode())
+
+	resp, err := o.exchange(t, q.Get("code"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.Equal(t, "bob@example.com", resp.User.Email)
+	assert.Equal(t, "bob", resp.User.DisplayName)
+	assert.Equal(t, domain.RoleAdmin, resp.User.Role)
+	assert.True(t, resp.User.IsActive())
+
+	// The code works once.
+	_, err = o.exchange(t, q.Get("code"))
+	assert.ErrorIs(t, err, domainerrors.InvalidCredentials(""))
+
+	// Leaving the group demotes on the next login, found by subject even
+	// though the email changed at the provider.
+	q = o.login(t, map[string]any{"sub": "sub-bob", "email": "robert@example.com", "email_verified": true, "groups": "family"})
+	resp, err = o.exchange(t, q.Get("code"))
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", resp.User.Email)
+	assert.Equal(t, domain.RoleMember, resp.User.Role)
+}
+
+func TestOIDC_RoleKeptWhenUserinfoFails(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	o := setupOIDCTest(t, config.OIDCConfig{AutoProvision: true, AdminGroups: []string{"listenup-admins"}})
+	require.NoError(t, o.instance.SetOpenRegistration(ctx, true))
+
+	// Groups from userinfo grant admin.
+	q := o.login(t, map[string]any{
+		"sub": "sub-erin", "email": "erin@example.com", "email_verified": true,
+		"userinfo": map[string]any{"groups": []string{"listenup-admins"}},
+	})
+	resp, err := o.exchange(t, q.Get("code"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, resp.User.Role)
+
+	// Without userinfo there are no groups to go on, so the role stays.
+	o.issuer.FailUserinfo(true)
+	q = o.login(t, map[string]any{
+		"sub": "sub-erin", "email": "erin@example.com", "email_verified": true,
+		"userinfo": map[string]any{"groups": []string{"family"}},
+	})
+	resp, err = o.exchange(t, q.Get("code"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, resp.User.Role)
+
+	// Once userinfo answers again, leaving the group demotes.
+	o.issuer.FailUserinfo(false)
+	q = o.login(t, map[string]any{
+		"sub": "sub-erin", "email": "erin@example.com", "email_verified": true,
+		"userinfo": map[string]any{"groups": []string{"family"}},
+	})
+	resp, err = o.exchange(t, q.Get("code"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleMember, resp.User.Role)
+}
+
+func TestOIDC_LinkExistingUser(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	o := setupOIDCTest(t, config.OIDCConfig{AutoProvision: false})
+
+	user := createTestUserWithPermissions(t, o.store, "carol@example.com", true)
+
+	// An unverified email can't claim an existing account.
+	q := o.login(t, map[string]any{"sub": "sub-carol", "email": "carol@example.com", "email_verified": false})
+	assert.Equal(t, OIDCErrorNotRegistered, q.Get("error"))
+
+	// A verified one links it; the email can come from userinfo.
+	q = o.login(t, map[string]any{
+		"sub":      "sub-carol",
+		"userinfo": map[string]any{"email": "carol@example.com", "email_verified": "true"},
+	})
+	resp, err := o.exchange(t, q.Get("code"))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, resp.User.ID)
+
+	idents, err := o.store.ListOIDCIdentities(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, idents, 1)
+	assert.Equal(t, o.issuer.URL, idents[0].Issuer)
+	assert.Equal(t, "sub-carol", idents[0].Subject)
+
+	// Unknown users aren't created with auto-provisioning off.
+	q = o.login(t, map[string]any{"sub": "sub-dave", "email": "dave@example.com", "email_verified": true})
+	assert.Equal(t, OIDCErrorNotRegistered, q.Get("error"))
+	_, err = o.store.GetUserByEmail(ctx, "dave@example.com")
+	assert.Error(t, err)
+
+	// Users with two-factor on still get a challenge.
+	enrollUser(t, o.twoFA, user.ID)
+	q = o.login(t, map[string]any{"sub": "sub-carol"})
+	resp, err = o.exchange(t, q.Get("code"))
+	require.NoError(t, err)
+	require.NotNil(t, resp.TwoFactorChallenge)
+	assert.Empty(t, resp.AccessToken)
+}
+
+func TestOIDC_StateAndRedirects(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	o := setupOIDCTest(t, config.OIDCConfig{AutoProvision: true})
+
+	for _, returnTo := range []string{"", "https://evil.example/steal", "//evil.example", `/\evil.example`, "javascript:alert(1)"} {
+		_, err := o.svc.StartLogin(ctx, returnTo)
+		assert.ErrorIs(t, err, domainerrors.Validation(""), "return_to %q", returnTo)
+	}
+
+	authURL, err := o.svc.StartLogin(ctx, "/web/login?next=library")
+	require.NoError(t, err)
+	cb := o.issuer.Authorize(t, authURL, map[string]any{"sub": "sub-erin", "email": "erin@example.com"})
+
+	_, err = o.svc.HandleCallback(ctx, OIDCCallback{State: "forged", Code: cb.Get("code")})
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+
+	// The provider reporting an error is passed on to the client.
+	location, err := o.svc.HandleCallback(ctx, OIDCCallback{State: cb.Get("state"), Error: "access_denied"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(location, "/web/login?next=library&error="+OIDCErrorAccessDenied), location)
+
+	// The state was used up by that callback.
+	_, err = o.svc.HandleCallback(ctx, OIDCCallback{State: cb.Get("state"), Code: cb.Get("code")})
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+
+	// Disabled service.
+	off := NewOIDCService(o.store, nil, config.OIDCConfig{}, nil, o.instance, nil)
+	assert.False(t, off.Info().Enabled)
+	_, err = off.StartLogin(ctx, "listenup://oidc")
+	assert.ErrorIs(t, err, domainerrors.NotFound(""))
+}
+
+func TestOIDC_AllowedReturn(t *testing.T) {
+	t.Parallel()
+	svc := &OIDCService{cfg: config.OIDCConfig{
+		AllowedRedirects: []string{"listenup://", "https://app.example.com", "https://web.example.com/listenup/"},
+	}}
+
+	for returnTo, want := range map[string]bool{
+		"/web/login":                               true,
+		"listenup://oidc":                          true,
+		"https://app.example.com":                  true,
+		"https://app.example.com/cb?x=1":           true,
+		"https://web.example.com/listenup":         true,
+		"https://web.example.com/listenup/cb":      true,
+		"https://app.example.com.evil.net/cb":      false,
+		"https://app.example.com@evil.net/":        false,
+		"https://user@app.example.com/cb":          false,
+		"http://app.example.com/cb":                false,
+		"https://app.example.com:8443/cb":          false,
+		"https://web.example.com/listenup-evil/cb": false,
+		"https://web.example.com/other":            false,
+		"//app.example.com/cb":                     false,
+		"https:app.example.com/cb":                 false,
+	} {
+		assert.Equal(t, want, svc.allowedReturn(returnTo), "return_to %q", returnTo)
+	}
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/notify"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+const (
+	// resetCodeSize is the number of random bytes in a reset code (256 bits).
+	resetCodeSize = 32
+	// defaultAdminResetExpiry is how long an admin-issued code stays valid.
+	defaultAdminResetExpiry = 24 * time.Hour
+	// emailedResetExpiry is how long a self-service emailed code stays valid.
+	emailedResetExpiry = time.Hour
+)
+
+// passwordServiceStore is the narrow store surface PasswordService needs.
+type passwordServiceStore interface {
+	store.UserStore
+	store.PasswordResetStore
+	store.InstanceStore
+}
+
+// PasswordService handles password changes and one-time reset codes.
+type PasswordService struct {
+	store          passwordServiceStore
+	sessionService *SessionService
+	notifier       notify.Notifier // nil when email is not configured
+	logger         *slog.Logger
+	serverURL      string // Base URL for reset links
+}
+
+// NewPasswordService creates a new password service. notifier may be nil,
+// which disables emailed reset links.
+func NewPasswordService(
+	s passwordServiceStore,
+	sessionService *SessionService,
+	notifier notify.Notifier,
+	logger *slog.Logger,
+	serverURL string,
+) *PasswordService {
+	return &PasswordService{
+		store:          s,
+		sessionService: sessionService,
+		notifier:       notifier,
+		logger:         logger,
+		serverURL:      serverURL,
+	}
+}
+
+// ChangePasswordRequest contains the data needed to change one's own password.
+type ChangePasswordRequest struct {
+	CurrentPassword string          `json:"current_password" validate:"required"`
+	NewPassword     string          `json:"new_password" validate:"required,min=8,max=1024"`
+	DeviceInfo      auth.DeviceInfo `json:"device_info"`
+	IPAddress       string          `json:"-"` // Extracted from request by handler
+}
+
+// AdminPasswordResetRequest contains the options for an admin-issued reset.
+type AdminPasswordResetRequest struct {
+	SendEmail      bool // Email the link to the user as well as returning it
+	ExpiresInHours int  // 0 = use default (24 hours)
+}
+
+// PasswordResetResponse is returned after issuing a reset code.
+type PasswordResetResponse struct {
+	Reset   *domain.PasswordReset
+	Code    string // Shown once; only its hash is stored
+	URL     string // Full reset page URL
+	Emailed bool
+}
+
+// ResetPasswordRequest contains the data needed to redeem a reset code.
+type ResetPasswordRequest struct {
+	Code        string `json:"code" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=1024"`
+}
+
+// EmailEnabled reports whether reset links can be emailed.
+func (s *PasswordService) EmailEnabled() bool {
+	return s.notifier != nil
+}
+
+// ChangePassword verifies the current password and sets a new one. Every
+// session of the user is revoked, and a fresh session is returned for the
+// device that made the change so it stays signed in.
+func (s *PasswordService) ChangePassword(ctx context.Context, userID string, req ChangePasswordRequest) (*AuthResponse, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, domainerrors.NotFound("user not found")
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	valid, err := auth.VerifyPassword(user.PasswordHash, req.CurrentPassword)
+	if err != nil {
+		return nil, fmt.Errorf("verify password: %w", err)
+	}
+	if !valid {
+		return nil, domainerrors.InvalidCredentials("current password is incorrect")
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return nil, domainerrors.Validation("new password must be different from the current password")
+	}
+
+	if err := s.setPassword(ctx, user, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	sessionResp, err := s.sessionService.CreateSession(ctx, user, req.DeviceInfo, req.IPAddress)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+
+	s.logger.Info("password changed", "user_id", userID)
+
+	return &AuthResponse{
+		User:            user,
+		SessionResponse: *sessionResp,
+	}, nil
+}
+
+// CreatePasswordReset issues a one-time reset code for a user, replacing
+// any earlier unused code. Only the root user can reset the root password.
+func (s *PasswordService) CreatePasswordReset(ctx context.Context, adminUserID, targetUserID string, req AdminPasswordResetRequest) (*PasswordResetResponse, error) {
+	admin, err := s.store.GetUser(ctx, adminUserID)
+	if err != nil {
+		return nil, fmt.Errorf("get admin: %w", err)
+	}
+	user, err := s.store.GetUser(ctx, targetUserID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, domainerrors.NotFound("user not found")
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if user.IsRoot && !admin.IsRoot {
+		return nil, domainerrors.Forbidden("only the root user can reset the root password")
+	}
+	if req.SendEmail && s.notifier == nil {
+		return nil, domainerrors.Validation("email is not configured on this server")
+	}
+
+	expiresIn := defaultAdminResetExpiry
+	if req.ExpiresInHours > 0 {
+		expiresIn = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	reset, code, err := s.issueReset(ctx, user, adminUserID, expiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &PasswordResetResponse{
+		Reset: reset,
+		Code:  code,
+		URL:   s.resetURL(code),
+	}
+	if req.SendEmail {
+		if err := s.sendResetEmail(ctx, user, resp.URL, expiresIn); err != nil {
+			return nil, err
+		}
+		resp.Emailed = true
+	}
+
+	s.logger.Info("password reset issued",
+		"admin_id", adminUserID,
+		"user_id", targetUserID,
+		"emailed", resp.Emailed,
+	)
+
+	return resp, nil
+}
+
+// RequestPasswordReset emails a reset link to the account with this address.
+// It succeeds whether or not the account exists so callers can't probe for
+// registered addresses.
+func (s *PasswordService) RequestPasswordReset(ctx context.Context, email string) error {
+	if s.notifier == nil {
+		return domainerrors.Validation("password recovery by email is not enabled on this server")
+	}
+
+	user, err := s.store.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			s.logger.Debug("password reset requested for unknown email")
+			return nil
+		}
+		return fmt.Errorf("lookup user: %w", err)
+	}
+	if user.IsPending() {
+		return nil
+	}
+
+	_, code, err := s.issueReset(ctx, user, "", emailedResetExpiry)
+	if err != nil {
+		return err
+	}
+	if err := s.sendResetEmail(ctx, user, s.resetURL(code), emailedResetExpiry); err != nil {
+		// Don't reveal the account exists through a different response.
+		s.logger.Error("failed to email password reset", "user_id", user.ID, "error", err)
+		return nil
+	}
+
+	s.logger.Info("password reset emailed", "user_id", user.ID)
+	return nil
+}
+
+// GetPasswordReset returns the reset for a code if it can still be redeemed.
+func (s *PasswordService) GetPasswordReset(ctx context.Context, code string) (*domain.PasswordReset, error) {
+	reset, err := s.store.GetPasswordResetByHash(ctx, auth.HashRefreshToken(code))
+	if err != nil {
+		if errors.Is(err, store.ErrPasswordResetNotFound) {
+			return nil, domainerrors.NotFound("reset link is invalid or has expired")
+		}
+		return nil, fmt.Errorf("get password reset: %w", err)
+	}
+	if !reset.IsValid() {
+		return nil, domainerrors.NotFound("reset link is invalid or has expired")
+	}
+	return reset, nil
+}
+
+// ResetPassword redeems a reset code and sets a new password. Every session
+// of the user is revoked; they sign in again with the new password.
+func (s *PasswordService) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
+	if err := validate.Struct(req); err != nil {
+		return formatValidationError(err)
+	}
+
+	reset, err := s.GetPasswordReset(ctx, req.Code)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.store.GetUser(ctx, reset.UserID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return domainerrors.NotFound("reset link is invalid or has expired")
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	// Consume the code before changing anything so two concurrent requests
+	// can't both redeem it.
+	if err := s.store.UsePasswordReset(ctx, reset.ID, time.Now()); err != nil {
+		if errors.Is(err, store.ErrPasswordResetNotFound) {
+			return domainerrors.NotFound("reset link is invalid or has expired")
+		}
+		return fmt.Errorf("use password reset: %w", err)
+	}
+
+	if err := s.setPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+
+	s.logger.Info("password reset redeemed", "user_id", user.ID, "reset_id", reset.ID)
+	return nil
+}
+
+// setPassword stores a new password hash and revokes every session and
+// outstanding reset code of the user.
+func (s *PasswordService) setPassword(ctx context.Context, user *domain.User, password string) error {
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+
+	user.PasswordHash = hash
+	user.Touch()
+	if err := s.store.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("save user: %w", err)
+	}
+
+	if _, err := s.sessionService.RevokeAllSessions(ctx, user.ID, "Password changed"); err != nil {
+		// Don't fail the change — sessions will expire naturally
+		s.logger.Error("failed to invalidate sessions after password change", "user_id", user.ID, "error", err)
+	}
+	if err := s.store.DeletePasswordResetsForUser(ctx, user.ID); err != nil {
+		s.logger.Warn("failed to delete password reset codes", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// issueReset creates a reset code for a user, replacing any earlier one.
+func (s *PasswordService) issueReset(ctx context.Context, user *domain.User, createdBy string, expiresIn time.Duration) (*domain.PasswordReset, string, error) {
+	if err := s.store.DeletePasswordResetsForUser(ctx, user.ID); err != nil {
+		return nil, "", fmt.Errorf("delete old password resets: %w", err)
+	}
+
+	b := make([]byte, resetCodeSize)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("generate reset code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(b)
+
+	resetID, err := id.Generate("pwreset")
+	if err != nil {
+		return nil, "", fmt.Errorf("generate password reset ID: %w", err)
+	}
+
+	now := time.Now()
+	reset := &domain.PasswordReset{
+		ID:        resetID,
+		UserID:    user.ID,
+		CodeHash:  auth.HashRefreshToken(code),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: now.Add(expiresIn),
+	}
+	if err := s.store.CreatePasswordReset(ctx, reset); err != nil {
+		return nil, "", fmt.Errorf("create password reset: %w", err)
+	}
+	return reset, code, nil
+}
+
+// sendResetEmail emails a reset link to the user.
+func (s *PasswordService) sendResetEmail(ctx context.Context, user *domain.User, url string, expiresIn time.Duration) error {
+	serverName := "ListenUp Server"
+	if instance, err := s.store.GetInstance(ctx); err == nil && instance.Name != "" {
+		serverName = instance.Name
+	}
+
+	body := fmt.Sprintf(`Hi %s,
+
+A password reset was requested for your account on %s.
+Open this link to choose a new password:
+
+%s
+
+The link works once and expires in %s. If you didn't ask for this,
+you can ignore this email; your password hasn't changed.
+`, user.Name(), serverName, url, formatHours(expiresIn))
+
+	err := s.notifier.Send(ctx, notify.Message{
+		To:      user.Email,
+		Subject: "Reset your " + serverName + " password",
+		Body:    body,
+	})
+	if err != nil {
+		return fmt.Errorf("send reset email: %w", err)
+	}
+	return nil
+}
+
+// formatHours renders a whole number of hours for the reset email.
+func formatHours(d time.Duration) string {
+	if h := int(d.Hours()); h != 1 {
+		return fmt.Sprintf("%d hours", h)
+	}
+	return "1 hour"
+}
+
+// resetURL returns the full reset page URL for a code.
+func (s *PasswordService) resetURL(code string) string {
+	return s.serverURL + "/reset/" + code
+}
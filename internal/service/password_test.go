@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/notify"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+// recordingNotifier keeps sent messages instead of delivering them.
+type recordingNotifier struct {
+	sent []notify.Message
+}
+
+func (n *recordingNotifier) Send(_ context.Context, msg notify.Message) error {
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+func setupPasswordTest(t *testing.T, notifier notify.Notifier) (*PasswordService, *sqlite.Store) {
+	t.Helper()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	key, err := auth.LoadOrGenerateKey(t.TempDir())
+	require.NoError(t, err)
+	tokenService, err := auth.NewTokenService(hex.EncodeToString(key), 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	logger := slog.New(slog.DiscardHandler)
+	sessions := NewSessionService(s, tokenService, nil, logger)
+	return NewPasswordService(s, sessions, notifier, logger, "https://listen.example.com"), s
+}
+
+func TestPasswordService_AdminReset(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	notifier := &recordingNotifier{}
+	svc, s := setupPasswordTest(t, notifier)
+
+	admin := createTestUserWithPermissions(t, s, "admin@example.com", true)
+	user := createTestUserWithPermissions(t, s, "user@example.com", true)
+
+	first, err := svc.CreatePasswordReset(ctx, admin.ID, user.ID, AdminPasswordResetRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://listen.example.com/reset/"+first.Code, first.URL)
+	assert.False(t, first.Emailed)
+
+	// A second code replaces the first, and is emailed on request.
+	second, err := svc.CreatePasswordReset(ctx, admin.ID, user.ID, AdminPasswordResetRequest{SendEmail: true, ExpiresInHours: 2})
+	require.NoError(t, err)
+	assert.True(t, second.Emailed)
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, "user@example.com", notifier.sent[0].To)
+	assert.Contains(t, notifier.sent[0].Body, second.URL)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), second.Reset.ExpiresAt, time.Minute)
+
+	_, err = svc.GetPasswordReset(ctx, first.Code)
+	assert.ErrorIs(t, err, domainerrors.NotFound(""))
+
+	// The code works once.
+	req := ResetPasswordRequest{Code: second.Code, NewPassword: "NewPassword456!"}
+	require.NoError(t, svc.ResetPassword(ctx, req))
+	assert.ErrorIs(t, svc.ResetPassword(ctx, req), domainerrors.NotFound(""))
+
+	updated, err := s.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	ok, err := auth.VerifyPassword(updated.PasswordHash, "NewPassword456!")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestPasswordService_ResetRules(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc, s := setupPasswordTest(t, nil)
+
+	admin := createTestUserWithPermissions(t, s, "admin@example.com", true)
+	root := createTestUserWithPermissions(t, s, "root@example.com", true)
+	root.IsRoot = true
+	require.NoError(t, s.UpdateUser(ctx, root))
+
+	_, err := svc.CreatePasswordReset(ctx, admin.ID, root.ID, AdminPasswordResetRequest{})
+	assert.ErrorIs(t, err, domainerrors.Forbidden(""), "non-root admin resetting root")
+
+	_, err = svc.CreatePasswordReset(ctx, root.ID, admin.ID, AdminPasswordResetRequest{SendEmail: true})
+	assert.ErrorIs(t, err, domainerrors.Validation(""), "email requested without a notifier")
+
+	assert.ErrorIs(t, svc.RequestPasswordReset(ctx, "admin@example.com"), domainerrors.Validation(""))
+}
+
+func TestPasswordService_RequestPasswordReset(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	notifier := &recordingNotifier{}
+	svc, s := setupPasswordTest(t, notifier)
+	createTestUserWithPermissions(t, s, "user@example.com", true)
+
+	// Unknown addresses succeed silently.
+	require.NoError(t, svc.RequestPasswordReset(ctx, "nobody@example.com"))
+	assert.Empty(t, notifier.sent)
+
+	require.NoError(t, svc.RequestPasswordReset(ctx, "user@example.com"))
+	require.Len(t, notifier.sent, 1)
+	assert.Contains(t, notifier.sent[0].Body, "https://listen.example.com/reset/")
+	assert.Contains(t, notifier.sent[0].Body, "expires in 1 hour")
+}
@@ -0,0 +1,819 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/podcast"
+	"github.com/listenupapp/listenup-server/internal/scanner/audio"
+	"github.com/listenupapp/listenup-server/internal/search/asyncindexer"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// podcastServiceStore is the narrow store interface PodcastService depends on.
+type podcastServiceStore interface {
+	store.LibraryStore
+	store.PodcastStore
+}
+
+// PodcastService manages feed subscriptions in podcast libraries, the
+// episode download queue and per-episode playback state.
+type PodcastService struct {
+	store   podcastServiceStore
+	indexer *asyncindexer.Indexer
+	events  store.EventEmitter
+	client  *podcast.Client
+	parser  audio.Parser
+	logger  *slog.Logger
+
+	// queued is signalled when an episode is queued for download so the
+	// refresh worker can start on it without waiting for the next tick.
+	queued chan struct{}
+}
+
+// NewPodcastService creates a new podcast service.
+func NewPodcastService(store podcastServiceStore, indexer *asyncindexer.Indexer, events store.EventEmitter, client *podcast.Client, logger *slog.Logger) *PodcastService {
+	return &PodcastService{
+		store:   store,
+		indexer: indexer,
+		events:  events,
+		client:  client,
+		parser:  audio.NewNativeParser(),
+		logger:  logger,
+		queued:  make(chan struct{}, 1),
+	}
+}
+
+// DownloadsQueued returns a channel that receives when episodes are queued
+// for download outside a refresh.
+func (s *PodcastService) DownloadsQueued() <-chan struct{} {
+	return s.queued
+}
+
+// SubscribeRequest contains the data for subscribing a library to a feed.
+type SubscribeRequest struct {
+	LibraryID    string `json:"library_id" validate:"required"`
+	FeedURL      string `json:"feed_url" validate:"required,max=2048"`
+	AutoDownload bool   `json:"auto_download"`
+	KeepEpisodes int    `json:"keep_episodes" validate:"gte=0"`
+	KeepDays     int    `json:"keep_days" validate:"gte=0"`
+}
+
+// UpdatePodcastRequest contains the subscription settings to change.
+// Nil fields are left unchanged.
+type UpdatePodcastRequest struct {
+	AutoDownload *bool `json:"auto_download,omitempty"`
+	KeepEpisodes *int  `json:"keep_episodes,omitempty" validate:"omitempty,gte=0"`
+	KeepDays     *int  `json:"keep_days,omitempty" validate:"omitempty,gte=0"`
+}
+
+// RecordEpisodeEventRequest contains the data for recording listening to an episode.
+type RecordEpisodeEventRequest struct {
+	EventID         string    `json:"event_id"` // Client-provided ID for idempotency
+	StartPositionMs int64     `json:"start_position_ms" validate:"gte=0"`
+	EndPositionMs   int64     `json:"end_position_ms" validate:"gtfield=StartPositionMs"`
+	StartedAt       time.Time `json:"started_at" validate:"required"`
+	EndedAt         time.Time `json:"ended_at" validate:"required"`
+	PlaybackSpeed   float32   `json:"playback_speed" validate:"gt=0,lte=4"`
+	DeviceID        string    `json:"device_id" validate:"required"`
+	DeviceName      string    `json:"device_name"`
+}
+
+// EpisodeWithState is an episode together with the requesting user's
+// playback state, which is nil if they haven't played it.
+type EpisodeWithState struct {
+	Episode *domain.PodcastEpisode
+	State   *domain.PlaybackState
+}
+
+// RefreshResult reports the outcome of refreshing one feed.
+type RefreshResult struct {
+	Podcast     *domain.Podcast
+	NewEpisodes int
+}
+
+// Subscribe adds a feed to a podcast library. The feed is fetched straight
+// away so the podcast arrives with its episodes; with auto-download on, only
+// the newest episode is queued rather than the whole back catalogue.
+func (s *PodcastService) Subscribe(ctx context.Context, req SubscribeRequest) (*domain.Podcast, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	lib, err := s.store.GetLibrary(ctx, req.LibraryID)
+	if err != nil {
+		return nil, err
+	}
+	if !lib.IsPodcast() {
+		return nil, domainerrors.Validation("library is not a podcast library")
+	}
+	if len(lib.ScanPaths) == 0 {
+		return nil, domainerrors.Validation("podcast library has no folder to download into")
+	}
+
+	feedURL, err := podcast.ValidateURL(req.FeedURL)
+	if err != nil {
+		return nil, domainerrors.Validation("feed URL must be an http or https URL")
+	}
+	if _, err := s.store.GetPodcastByFeedURL(ctx, lib.ID, feedURL); err == nil {
+		return nil, domainerrors.Conflict("library is already subscribed to this feed")
+	} else if !errors.Is(err, store.ErrPodcastNotFound) {
+		return nil, fmt.Errorf("check existing subscription: %w", err)
+	}
+
+	res, err := s.client.Fetch(ctx, feedURL, "", "")
+	if err != nil {
+		if errors.Is(err, podcast.ErrNotFeed) {
+			return nil, domainerrors.Validation("URL is not an RSS or Atom feed")
+		}
+		return nil, domainerrors.Validationf("could not fetch feed: %v", err)
+	}
+
+	podcastID, err := id.Generate("podcast")
+	if err != nil {
+		return nil, fmt.Errorf("generate podcast ID: %w", err)
+	}
+
+	now := time.Now()
+	p := &domain.Podcast{
+		ID:              podcastID,
+		LibraryID:       lib.ID,
+		FeedURL:         feedURL,
+		AutoDownload:    req.AutoDownload,
+		KeepEpisodes:    req.KeepEpisodes,
+		KeepDays:        req.KeepDays,
+		ETag:            res.ETag,
+		LastModified:    res.LastModified,
+		LastRefreshedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	applyFeed(p, res.Feed)
+	p.Dir = filepath.Join(lib.ScanPaths[0], p.DirName())
+
+	if err := s.store.CreatePodcast(ctx, p); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return nil, domainerrors.Conflict("library is already subscribed to this feed")
+		}
+		return nil, fmt.Errorf("create podcast: %w", err)
+	}
+
+	// Only the newest episode is worth downloading on subscribe.
+	items := slices.Clone(res.Feed.Items)
+	slices.SortStableFunc(items, func(a, b podcast.Item) int {
+		return b.Published.Compare(a.Published)
+	})
+	added, err := s.syncEpisodes(ctx, p, items, min(1, len(items)))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("subscribed to podcast",
+		"podcast_id", p.ID,
+		"library_id", lib.ID,
+		"title", p.Title,
+		"episodes", added,
+	)
+
+	s.indexer.SubmitIndexPodcast(p)
+	s.events.Emit(sse.NewPodcastUpdatedEvent(p))
+	return p, nil
+}
+
+// PublishImported indexes podcasts created outside the service, by an ABS
+// import, and tells clients about them.
+func (s *PodcastService) PublishImported(ctx context.Context, podcastIDs []string) {
+	for _, podcastID := range podcastIDs {
+		p, err := s.store.GetPodcast(ctx, podcastID)
+		if err != nil {
+			s.logger.Warn("imported podcast not found", "podcast_id", podcastID, "error", err)
+			continue
+		}
+		s.indexer.SubmitIndexPodcast(p)
+		s.events.Emit(sse.NewPodcastUpdatedEvent(p))
+	}
+}
+
+// GetPodcast returns a podcast the user can access. Podcasts in libraries
+// the user can't access are reported as not found.
+func (s *PodcastService) GetPodcast(ctx context.Context, userID, podcastID string) (*domain.Podcast, error) {
+	p, err := s.store.GetPodcast(ctx, podcastID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireAccess(ctx, userID, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPodcasts returns the podcasts the user can access in a library, or in
+// every library when libraryID is empty.
+func (s *PodcastService) ListPodcasts(ctx context.Context, userID, libraryID string) ([]*domain.Podcast, error) {
+	var podcasts []*domain.Podcast
+	var err error
+	if libraryID == "" {
+		podcasts, err = s.store.ListPodcasts(ctx)
+	} else {
+		podcasts, err = s.store.ListPodcastsForLibrary(ctx, libraryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	access := make(map[string]bool)
+	result := make([]*domain.Podcast, 0, len(podcasts))
+	for _, p := range podcasts {
+		ok, seen := access[p.LibraryID]
+		if !seen {
+			if ok, err = s.canAccess(ctx, userID, p); err != nil {
+				return nil, err
+			}
+			access[p.LibraryID] = ok
+		}
+		if ok {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// canAccess reports whether the user can access a podcast's library.
+func (s *PodcastService) canAccess(ctx context.Context, userID string, p *domain.Podcast) (bool, error) {
+	ok, err := s.store.CanUserAccessLibrary(ctx, userID, p.LibraryID)
+	if err != nil {
+		return false, fmt.Errorf("check library access: %w", err)
+	}
+	return ok, nil
+}
+
+// requireAccess returns ErrPodcastNotFound when the user can't access a
+// podcast's library, so its existence isn't revealed.
+func (s *PodcastService) requireAccess(ctx context.Context, userID string, p *domain.Podcast) error {
+	ok, err := s.canAccess(ctx, userID, p)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return store.ErrPodcastNotFound
+	}
+	return nil
+}
+
+// UpdatePodcast changes a subscription's download settings and applies the
+// new retention rules straight away.
+func (s *PodcastService) UpdatePodcast(ctx context.Context, podcastID string, req UpdatePodcastRequest) (*domain.Podcast, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	p, err := s.store.GetPodcast(ctx, podcastID)
+	if err != nil {
+		return nil, err
+	}
+	if req.AutoDownload != nil {
+		p.AutoDownload = *req.AutoDownload
+	}
+	if req.KeepEpisodes != nil {
+		p.KeepEpisodes = *req.KeepEpisodes
+	}
+	if req.KeepDays != nil {
+		p.KeepDays = *req.KeepDays
+	}
+	p.UpdatedAt = time.Now()
+
+	if err := s.store.UpdatePodcast(ctx, p); err != nil {
+		return nil, fmt.Errorf("update podcast: %w", err)
+	}
+	if _, err := s.ApplyRetention(ctx, p); err != nil {
+		s.logger.Warn("failed to apply podcast retention", "podcast_id", p.ID, "error", err)
+	}
+
+	s.events.Emit(sse.NewPodcastUpdatedEvent(p))
+	return p, nil
+}
+
+// Unsubscribe removes a podcast with its episodes and playback state. When
+// deleteFiles is set the downloaded episodes are removed from disk too, and
+// the podcast's folder if that leaves it empty.
+func (s *PodcastService) Unsubscribe(ctx context.Context, podcastID string, deleteFiles bool) error {
+	p, err := s.store.GetPodcast(ctx, podcastID)
+	if err != nil {
+		return err
+	}
+
+	var episodes []*domain.PodcastEpisode
+	if deleteFiles {
+		episodes, err = s.store.ListPodcastEpisodes(ctx, podcastID)
+		if err != nil {
+			return fmt.Errorf("list episodes: %w", err)
+		}
+	}
+
+	if err := s.store.DeletePodcast(ctx, podcastID); err != nil {
+		return err
+	}
+
+	for _, e := range episodes {
+		if e.FilePath == "" {
+			continue
+		}
+		if !p.OwnsFile(e.FilePath) {
+			s.logger.Warn("kept episode file outside podcast folder", "path", e.FilePath, "podcast_dir", p.Dir)
+			continue
+		}
+		if err := os.Remove(e.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warn("failed to remove episode file", "path", e.FilePath, "error", err)
+		}
+	}
+	if deleteFiles {
+		// os.Remove refuses non-empty directories, so anything the user
+		// put there themselves survives.
+		_ = os.Remove(p.Dir)
+	}
+
+	s.logger.Info("unsubscribed from podcast", "podcast_id", p.ID, "title", p.Title, "files_deleted", deleteFiles)
+
+	s.indexer.SubmitDeletePodcast(p.ID)
+	s.events.Emit(sse.NewPodcastDeletedEvent(p.ID))
+	return nil
+}
+
+// ListEpisodes returns a podcast's episodes, newest first, with the user's
+// playback state for each.
+func (s *PodcastService) ListEpisodes(ctx context.Context, userID, podcastID string) ([]EpisodeWithState, error) {
+	if _, err := s.GetPodcast(ctx, userID, podcastID); err != nil {
+		return nil, err
+	}
+
+	episodes, err := s.store.ListPodcastEpisodes(ctx, podcastID)
+	if err != nil {
+		return nil, fmt.Errorf("list episodes: %w", err)
+	}
+	states, err := s.store.ListEpisodePlaybackStates(ctx, userID, podcastID)
+	if err != nil {
+		return nil, fmt.Errorf("list episode playback states: %w", err)
+	}
+
+	byEpisode := make(map[string]*domain.PlaybackState, len(states))
+	for _, st := range states {
+		byEpisode[st.BookID] = st
+	}
+
+	result := make([]EpisodeWithState, len(episodes))
+	for i, e := range episodes {
+		result[i] = EpisodeWithState{Episode: e, State: byEpisode[e.ID]}
+	}
+	return result, nil
+}
+
+// GetEpisode returns an episode of a podcast the user can access. Episodes
+// the user can't access are reported as not found.
+func (s *PodcastService) GetEpisode(ctx context.Context, userID, episodeID string) (*domain.PodcastEpisode, error) {
+	e, err := s.store.GetPodcastEpisode(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetPodcast(ctx, userID, e.PodcastID); err != nil {
+		if errors.Is(err, store.ErrPodcastNotFound) {
+			return nil, store.ErrEpisodeNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+// QueueDownload queues an episode for download. Episodes that are already
+// downloaded or queued are returned unchanged.
+func (s *PodcastService) QueueDownload(ctx context.Context, episodeID string) (*domain.PodcastEpisode, error) {
+	e, err := s.store.GetPodcastEpisode(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Status {
+	case domain.EpisodeStatusQueued, domain.EpisodeStatusDownloading:
+		return e, nil
+	case domain.EpisodeStatusDownloaded:
+		if e.IsDownloaded() {
+			return e, nil
+		}
+	}
+
+	e.Status = domain.EpisodeStatusQueued
+	e.Error = ""
+	e.UpdatedAt = time.Now()
+	if err := s.store.UpdatePodcastEpisode(ctx, e); err != nil {
+		return nil, fmt.Errorf("queue episode: %w", err)
+	}
+
+	s.events.Emit(sse.NewPodcastEpisodeUpdatedEvent(e))
+	s.signalQueued()
+	return e, nil
+}
+
+// Refresh fetches a podcast's feed and adds any new episodes.
+func (s *PodcastService) Refresh(ctx context.Context, podcastID string) (*RefreshResult, error) {
+	p, err := s.store.GetPodcast(ctx, podcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := s.refresh(ctx, p)
+	if err != nil {
+		return nil, domainerrors.Validationf("could not refresh feed: %v", err)
+	}
+	if added > 0 {
+		s.signalQueued()
+	}
+	return &RefreshResult{Podcast: p, NewEpisodes: added}, nil
+}
+
+// RefreshAll refreshes every subscription, downloads what that queued and
+// applies retention rules. A failing feed is recorded on its podcast and
+// doesn't stop the others. Returns the number of new episodes.
+func (s *PodcastService) RefreshAll(ctx context.Context) (int, error) {
+	podcasts, err := s.store.ListPodcasts(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list podcasts: %w", err)
+	}
+
+	total := 0
+	for _, p := range podcasts {
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+		added, err := s.refresh(ctx, p)
+		if err != nil {
+			s.logger.Warn("podcast refresh failed", "podcast_id", p.ID, "feed_url", p.FeedURL, "error", err)
+			continue
+		}
+		total += added
+	}
+
+	s.ProcessDownloads(ctx)
+
+	for _, p := range podcasts {
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+		if _, err := s.ApplyRetention(ctx, p); err != nil {
+			s.logger.Warn("failed to apply podcast retention", "podcast_id", p.ID, "error", err)
+		}
+	}
+	return total, nil
+}
+
+// refresh fetches p's feed, conditionally on the validators from the last
+// refresh, and syncs its episodes. The outcome, success or failure, is
+// saved on p.
+func (s *PodcastService) refresh(ctx context.Context, p *domain.Podcast) (int, error) {
+	now := time.Now()
+	p.LastRefreshedAt = &now
+	p.UpdatedAt = now
+
+	res, err := s.client.Fetch(ctx, p.FeedURL, p.ETag, p.LastModified)
+	switch {
+	case errors.Is(err, podcast.ErrNotModified):
+		p.LastError = ""
+		if err := s.store.UpdatePodcast(ctx, p); err != nil {
+			return 0, fmt.Errorf("update podcast: %w", err)
+		}
+		return 0, nil
+
+	case err != nil:
+		if ctx.Err() != nil {
+			return 0, err
+		}
+		p.LastError = err.Error()
+		if updateErr := s.store.UpdatePodcast(ctx, p); updateErr != nil {
+			s.logger.Warn("failed to record podcast refresh error", "podcast_id", p.ID, "error", updateErr)
+		}
+		return 0, err
+	}
+
+	applyFeed(p, res.Feed)
+	p.ETag = res.ETag
+	p.LastModified = res.LastModified
+	p.LastError = ""
+
+	queue := 0
+	if p.AutoDownload {
+		queue = len(res.Feed.Items)
+	}
+	added, err := s.syncEpisodes(ctx, p, res.Feed.Items, queue)
+	if err != nil {
+		return added, err
+	}
+
+	if err := s.store.UpdatePodcast(ctx, p); err != nil {
+		return added, fmt.Errorf("update podcast: %w", err)
+	}
+	s.indexer.SubmitIndexPodcast(p)
+
+	if added > 0 {
+		s.logger.Info("new podcast episodes", "podcast_id", p.ID, "title", p.Title, "count", added)
+		s.events.Emit(sse.NewPodcastUpdatedEvent(p))
+	}
+	return added, nil
+}
+
+// syncEpisodes creates episodes for feed items not seen before and updates
+// the feed-owned fields of known ones. New episodes among the first queue
+// items are queued for download when the podcast auto-downloads.
+func (s *PodcastService) syncEpisodes(ctx context.Context, p *domain.Podcast, items []podcast.Item, queue int) (int, error) {
+	added := 0
+	for i, item := range items {
+		existing, err := s.store.GetPodcastEpisodeByGUID(ctx, p.ID, item.GUID)
+		switch {
+		case err == nil:
+			if applyItem(existing, item) {
+				existing.UpdatedAt = time.Now()
+				if err := s.store.UpdatePodcastEpisode(ctx, existing); err != nil {
+					return added, fmt.Errorf("update episode: %w", err)
+				}
+			}
+			continue
+		case !errors.Is(err, store.ErrEpisodeNotFound):
+			return added, fmt.Errorf("get episode: %w", err)
+		}
+
+		episodeID, err := id.Generate("episode")
+		if err != nil {
+			return added, fmt.Errorf("generate episode ID: %w", err)
+		}
+		now := time.Now()
+		e := &domain.PodcastEpisode{
+			ID:        episodeID,
+			PodcastID: p.ID,
+			GUID:      item.GUID,
+			Status:    domain.EpisodeStatusAvailable,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		applyItem(e, item)
+		if p.AutoDownload && i < queue {
+			e.Status = domain.EpisodeStatusQueued
+		}
+
+		if err := s.store.CreatePodcastEpisode(ctx, e); err != nil {
+			// A feed listing the same GUID twice keeps the first.
+			if errors.Is(err, store.ErrAlreadyExists) {
+				continue
+			}
+			return added, fmt.Errorf("create episode: %w", err)
+		}
+		added++
+	}
+	return added, nil
+}
+
+// ResetInterruptedDownloads re-queues episodes left downloading by a
+// shutdown or crash.
+func (s *PodcastService) ResetInterruptedDownloads(ctx context.Context) error {
+	episodes, err := s.store.ListPodcastEpisodesByStatus(ctx, domain.EpisodeStatusDownloading)
+	if err != nil {
+		return fmt.Errorf("list downloading episodes: %w", err)
+	}
+	for _, e := range episodes {
+		e.Status = domain.EpisodeStatusQueued
+		e.UpdatedAt = time.Now()
+		if err := s.store.UpdatePodcastEpisode(ctx, e); err != nil {
+			return fmt.Errorf("re-queue episode: %w", err)
+		}
+	}
+	return nil
+}
+
+// ProcessDownloads downloads queued episodes one at a time until the queue
+// is empty or ctx is cancelled. Returns the number downloaded.
+func (s *PodcastService) ProcessDownloads(ctx context.Context) int {
+	downloaded := 0
+	failed := make(map[string]bool)
+	for ctx.Err() == nil {
+		queued, err := s.store.ListPodcastEpisodesByStatus(ctx, domain.EpisodeStatusQueued)
+		if err != nil {
+			s.logger.Warn("failed to list queued episodes", "error", err)
+			return downloaded
+		}
+		queued = slices.DeleteFunc(queued, func(e *domain.PodcastEpisode) bool { return failed[e.ID] })
+		if len(queued) == 0 {
+			return downloaded
+		}
+
+		for _, e := range queued {
+			if ctx.Err() != nil {
+				break
+			}
+			if err := s.download(ctx, e); err != nil {
+				failed[e.ID] = true
+				continue
+			}
+			downloaded++
+		}
+	}
+	return downloaded
+}
+
+// download fetches one episode's audio into its podcast's folder.
+func (s *PodcastService) download(ctx context.Context, e *domain.PodcastEpisode) error {
+	p, err := s.store.GetPodcast(ctx, e.PodcastID)
+	if err != nil {
+		return err
+	}
+
+	s.setEpisodeStatus(ctx, e, domain.EpisodeStatusDownloading, "")
+
+	dest := filepath.Join(p.Dir, domain.EpisodeFileName(e, podcast.Extension(e.EnclosureURL, e.EnclosureType)))
+	size, err := s.client.Download(ctx, e.EnclosureURL, dest)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; pick it up again next time.
+			s.setEpisodeStatus(context.WithoutCancel(ctx), e, domain.EpisodeStatusQueued, "")
+			return err
+		}
+		s.logger.Warn("episode download failed", "episode_id", e.ID, "url", e.EnclosureURL, "error", err)
+		s.setEpisodeStatus(ctx, e, domain.EpisodeStatusFailed, err.Error())
+		return err
+	}
+
+	// The file knows its length better than the feed does.
+	if meta, err := s.parser.Parse(ctx, dest); err == nil && meta.Duration > 0 {
+		e.DurationMs = meta.Duration.Milliseconds()
+	}
+
+	now := time.Now()
+	e.FilePath = dest
+	e.FileSize = size
+	e.DownloadedAt = &now
+	s.setEpisodeStatus(ctx, e, domain.EpisodeStatusDownloaded, "")
+
+	s.logger.Info("downloaded podcast episode", "episode_id", e.ID, "podcast_id", p.ID, "path", dest, "size", size)
+	return nil
+}
+
+// setEpisodeStatus saves an episode's download status and tells clients.
+func (s *PodcastService) setEpisodeStatus(ctx context.Context, e *domain.PodcastEpisode, status domain.EpisodeStatus, errMsg string) {
+	e.Status = status
+	e.Error = errMsg
+	e.UpdatedAt = time.Now()
+	if err := s.store.UpdatePodcastEpisode(ctx, e); err != nil {
+		s.logger.Warn("failed to update episode status", "episode_id", e.ID, "status", status, "error", err)
+		return
+	}
+	s.events.Emit(sse.NewPodcastEpisodeUpdatedEvent(e))
+}
+
+// ApplyRetention removes the downloads that fall outside the podcast's
+// keep rules. The episodes stay listed and can be downloaded again.
+// Returns the number of downloads removed.
+func (s *PodcastService) ApplyRetention(ctx context.Context, p *domain.Podcast) (int, error) {
+	episodes, err := s.store.ListPodcastEpisodes(ctx, p.ID)
+	if err != nil {
+		return 0, fmt.Errorf("list episodes: %w", err)
+	}
+
+	removed := 0
+	for _, e := range p.EpisodesToPrune(episodes, time.Now()) {
+		if !p.OwnsFile(e.FilePath) {
+			s.logger.Warn("kept episode file outside podcast folder", "path", e.FilePath, "podcast_dir", p.Dir)
+			continue
+		}
+		if err := os.Remove(e.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warn("failed to remove episode file", "path", e.FilePath, "error", err)
+			continue
+		}
+		e.FilePath = ""
+		e.FileSize = 0
+		e.DownloadedAt = nil
+		s.setEpisodeStatus(ctx, e, domain.EpisodeStatusAvailable, "")
+		removed++
+	}
+
+	if removed > 0 {
+		s.logger.Info("removed old podcast episodes", "podcast_id", p.ID, "count", removed)
+	}
+	return removed, nil
+}
+
+// RecordEpisodeEvent records a listening event for an episode and updates
+// the user's playback state for it. A repeated EventID returns the current
+// state without recording anything.
+func (s *PodcastService) RecordEpisodeEvent(ctx context.Context, userID, episodeID string, req RecordEpisodeEventRequest) (*domain.PlaybackState, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, formatValidationError(err)
+	}
+
+	e, err := s.GetEpisode(ctx, userID, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID := req.EventID
+	if eventID == "" {
+		if eventID, err = id.Generate("evt"); err != nil {
+			return nil, fmt.Errorf("generate event ID: %w", err)
+		}
+	}
+
+	event := domain.NewListeningEvent(
+		eventID,
+		userID,
+		e.ID,
+		req.StartPositionMs,
+		req.EndPositionMs,
+		req.StartedAt,
+		req.EndedAt,
+		req.PlaybackSpeed,
+		req.DeviceID,
+		req.DeviceName,
+	)
+	if err := s.store.CreateEpisodeListeningEvent(ctx, event); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return s.store.GetEpisodePlaybackState(ctx, userID, e.ID)
+		}
+		return nil, fmt.Errorf("store event: %w", err)
+	}
+
+	state, err := s.store.GetEpisodePlaybackState(ctx, userID, e.ID)
+	switch {
+	case err == nil:
+		state.UpdateFromEvent(event, e.DurationMs)
+	case errors.Is(err, store.ErrProgressNotFound):
+		state = domain.NewPlaybackState(event, e.DurationMs)
+	default:
+		return nil, fmt.Errorf("get episode state: %w", err)
+	}
+
+	if err := s.store.UpsertEpisodePlaybackState(ctx, state); err != nil {
+		return nil, fmt.Errorf("store episode state: %w", err)
+	}
+
+	s.events.Emit(sse.NewEpisodeProgressEvent(userID, e, state))
+	return state, nil
+}
+
+// signalQueued wakes the download worker without blocking.
+func (s *PodcastService) signalQueued() {
+	select {
+	case s.queued <- struct{}{}:
+	default:
+	}
+}
+
+// applyFeed copies feed-level metadata onto p, keeping existing values the
+// feed leaves out.
+func applyFeed(p *domain.Podcast, f *podcast.Feed) {
+	p.Title = cmp.Or(f.Title, p.Title, p.FeedURL)
+	p.Author = cmp.Or(f.Author, p.Author)
+	p.Description = cmp.Or(f.Description, p.Description)
+	p.ImageURL = cmp.Or(f.ImageURL, p.ImageURL)
+	p.Link = cmp.Or(f.Link, p.Link)
+	p.Language = cmp.Or(f.Language, p.Language)
+	p.Explicit = f.Explicit
+}
+
+// applyItem copies a feed item onto an episode and reports whether anything
+// changed. The duration of a downloaded episode comes from its file and
+// isn't overwritten.
+func applyItem(e *domain.PodcastEpisode, item podcast.Item) bool {
+	before := *e
+
+	e.Title = cmp.Or(item.Title, e.Title)
+	e.Description = item.Description
+	if !item.Published.IsZero() {
+		published := item.Published
+		e.PublishedAt = &published
+	}
+	if !e.IsDownloaded() && item.Duration > 0 {
+		e.DurationMs = item.Duration.Milliseconds()
+	}
+	e.Season = item.Season
+	e.Number = item.Episode
+	e.EnclosureURL = item.EnclosureURL
+	e.EnclosureType = item.EnclosureType
+	e.EnclosureSize = item.EnclosureLength
+
+	samePublished := (before.PublishedAt == nil) == (e.PublishedAt == nil) &&
+		(e.PublishedAt == nil || before.PublishedAt.Equal(*e.PublishedAt))
+	return before.Title != e.Title ||
+		before.Description != e.Description ||
+		!samePublished ||
+		before.DurationMs != e.DurationMs ||
+		before.Season != e.Season ||
+		before.Number != e.Number ||
+		before.EnclosureURL != e.EnclosureURL ||
+		before.EnclosureType != e.EnclosureType ||
+		before.EnclosureSize != e.EnclosureSize
+}
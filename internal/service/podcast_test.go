@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/podcast"
+	"github.com/listenupapp/listenup-server/internal/search/asyncindexer"
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFeedServer serves a feed of the episodes in items and their audio.
+// Episode "ep-N" is published N days into October 2026. Storing new items
+// changes the feed.
+type testFeedServer struct {
+	*httptest.Server
+	items atomic.Value // []string of episode slugs
+}
+
+func newTestFeedServer(t *testing.T, items ...string) *testFeedServer {
+	t.Helper()
+	fs := &testFeedServer{}
+	fs.items.Store(items)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed.xml", func(w http.ResponseWriter, _ *http.Request) {
+		body := `<rss><channel><title>Test Show</title><itunes:author xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">Host</itunes:author>`
+		base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+		slugs := fs.items.Load().([]string)
+		for _, slug := range slugs {
+			var n int
+			_, _ = fmt.Sscanf(slug, "ep-%d", &n)
+			published := base.AddDate(0, 0, n).Format(time.RFC1123Z)
+			body += fmt.Sprintf(`<item><title>%s</title><guid>%s</guid><pubDate>%s</pubDate>`+
+				`<enclosure url="%s/audio/%s.mp3" type="audio/mpeg"/></item>`, slug, slug, published, fs.URL, slug)
+		}
+		_, _ = w.Write([]byte(body + `</channel></rss>`))
+	})
+	mux.HandleFunc("/audio/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("not really audio"))
+	})
+	mux.HandleFunc("/page.html", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<html><body>hello</body></html>"))
+	})
+
+	fs.Server = httptest.NewServer(mux)
+	t.Cleanup(fs.Close)
+	return fs
+}
+
+func setupPodcastTest(t *testing.T) (*PodcastService, *sqlite.Store, *domain.Library) {
+	t.Helper()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	owner := createTestUserWithPermissions(t, s, "owner@example.com", true)
+	now := time.Now()
+	lib := &domain.Library{
+		ID:        "lib-podcasts",
+		OwnerID:   owner.ID,
+		Name:      "Podcasts",
+		ScanPaths: []string{t.TempDir()},
+		Type:      domain.LibraryTypePodcast,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	require.NoError(t, s.CreateLibrary(context.Background(), lib))
+
+	logger := slog.New(slog.DiscardHandler)
+	indexer := asyncindexer.New(store.NewNoopSearchIndexer(), logger)
+	svc := NewPodcastService(s, indexer, store.NewNoopEmitter(), podcast.NewClient(0), logger)
+	return svc, s, lib
+}
+
+func TestPodcastService_Subscribe(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, s, lib := setupPodcastTest(t)
+	feed := newTestFeedServer(t, "ep-3", "ep-2", "ep-1")
+
+	p, err := svc.Subscribe(ctx, SubscribeRequest{LibraryID: lib.ID, FeedURL: feed.URL + "/feed.xml", AutoDownload: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Test Show", p.Title)
+	assert.Equal(t, "Host", p.Author)
+	assert.Equal(t, filepath.Join(lib.ScanPaths[0], p.DirName()), p.Dir)
+	assert.True(t, strings.HasPrefix(filepath.Base(p.Dir), "Test Show ["), p.Dir)
+
+	episodes, err := s.ListPodcastEpisodes(ctx, p.ID)
+	require.NoError(t, err)
+	require.Len(t, episodes, 3)
+	assert.Equal(t, "ep-3", episodes[0].GUID)
+	assert.Equal(t, domain.EpisodeStatusQueued, episodes[0].Status, "newest episode is queued")
+	assert.Equal(t, domain.EpisodeStatusAvailable, episodes[1].Status, "back catalogue is not")
+
+	_, err = svc.Subscribe(ctx, SubscribeRequest{LibraryID: lib.ID, FeedURL: feed.URL + "/feed.xml"})
+	assert.ErrorIs(t, err, domainerrors.Conflict(""))
+
+	_, err = svc.Subscribe(ctx, SubscribeRequest{LibraryID: lib.ID, FeedURL: feed.URL + "/page.html"})
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+
+	_, err = svc.Subscribe(ctx, SubscribeRequest{LibraryID: lib.ID, FeedURL: "file:///etc/passwd"})
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+}
+
+func TestPodcastService_SubscribeRequiresPodcastLibrary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, s, lib := setupPodcastTest(t)
+	feed := newTestFeedServer(t, "ep-1")
+
+	books := &domain.Library{
+		ID:        "lib-books",
+		OwnerID:   lib.OwnerID,
+		Name:      "Books",
+		ScanPaths: []string{t.TempDir()},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, s.CreateLibrary(ctx, books))
+
+	_, err := svc.Subscribe(ctx, SubscribeRequest{LibraryID: books.ID, FeedURL: feed.URL + "/feed.xml"})
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+}
+
+func TestPodcastService_SameTitleFolders(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, s, lib := setupPodcastTest(t)
+
+	// Both feeds are titled "Test Show".
+	var podcasts []*domain.Podcast
+	for range 2 {
+		feed := newTestFeedServer(t, "ep-1")
+		p, err := svc.Subscribe(ctx, SubscribeRequest{LibraryID: lib.ID, FeedURL: feed.URL + "/feed.xml", AutoDownload: true})
+		require.NoError(t, err)
+		podcasts = append(podcasts, p)
+	}
+	assert.NotEqual(t, podcasts[0].Dir, podcasts[1].Dir)
+	assert.Equal(t, 2, svc.ProcessDownloads(ctx))
+
+	// Unsubscribing from one leaves the other's downloads alone.
+	require.NoError(t, svc.Unsubscribe(ctx, podcasts[0].ID, true))
+	assert.NoDirExists(t, podcasts[0].Dir)
+	episodes, err := s.ListPodcastEpisodes(ctx, podcasts[1].ID)
+	require.NoError(t, err)
+	require.Len(t, episodes, 1)
+	assert.FileExists(t, episodes[0].FilePath)
+}
+
+func TestPodcastService_RefreshDownloadAndRetention(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, s, lib := setupPodcastTest(t)
+	feed := newTestFeedServer(t, "ep-1")
+
+	p, err := svc.Subscribe(ctx, SubscribeRequest{
+		LibraryID:    lib.ID,
+		FeedURL:      feed.URL + "/feed.xml",
+		AutoDownload: true,
+		KeepEpisodes: 2,
+	})
+	require.NoError(t, err)
+
+	// Two new episodes appear; the refresh queues and downloads both,
+	// then retention drops the oldest of the three downloads.
+	feed.items.Store([]string{"ep-3", "ep-2", "ep-1"})
+	added, err := svc.RefreshAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	episodes, err := s.ListPodcastEpisodes(ctx, p.ID)
+	require.NoError(t, err)
+	require.Len(t, episodes, 3)
+	for _, e := range episodes[:2] {
+		assert.True(t, e.IsDownloaded(), "%s should be downloaded", e.GUID)
+		assert.FileExists(t, e.FilePath)
+		assert.Equal(t, p.Dir, filepath.Dir(e.FilePath))
+	}
+	assert.Equal(t, domain.EpisodeStatusAvailable, episodes[2].Status, "oldest download is pruned")
+	entries, err := os.ReadDir(p.Dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// A manual download of the pruned episode goes through the queue.
+	queued, err := svc.QueueDownload(ctx, episodes[2].ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EpisodeStatusQueued, queued.Status)
+	select {
+	case <-svc.DownloadsQueued():
+	default:
+		t.Fatal("queueing a download should wake the worker")
+	}
+	assert.Equal(t, 1, svc.ProcessDownloads(ctx))
+
+	// Unsubscribing with files removes the downloads and the folder.
+	require.NoError(t, svc.Unsubscribe(ctx, p.ID, true))
+	assert.NoDirExists(t, p.Dir)
+	_, err = s.GetPodcast(ctx, p.ID)
+	assert.ErrorIs(t, err, store.ErrPodcastNotFound)
+}
+
+func TestPodcastService_RecordEpisodeEvent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, s, lib := setupPodcastTest(t)
+	feed := newTestFeedServer(t, "ep-1")
+	user := createTestUserWithPermissions(t, s, "listener@example.com", false)
+
+	p, err := svc.Subscribe(ctx, SubscribeRequest{LibraryID: lib.ID, FeedURL: feed.URL + "/feed.xml"})
+	require.NoError(t, err)
+	episodes, err := svc.ListEpisodes(ctx, user.ID, p.ID)
+	require.NoError(t, err)
+	require.Len(t, episodes, 1)
+	assert.Nil(t, episodes[0].State)
+
+	ep := episodes[0].Episode
+	ep.DurationMs = 600000
+	require.NoError(t, s.UpdatePodcastEpisode(ctx, ep))
+
+	start := time.Now().Add(-5 * time.Minute)
+	req := RecordEpisodeEventRequest{
+		EventID:         "evt-ep-1",
+		StartPositionMs: 0,
+		EndPositionMs:   300000,
+		StartedAt:       start,
+		EndedAt:         start.Add(5 * time.Minute),
+		PlaybackSpeed:   1,
+		DeviceID:        "phone",
+	}
+	state, err := svc.RecordEpisodeEvent(ctx, user.ID, ep.ID, req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300000), state.CurrentPositionMs)
+	assert.False(t, state.IsFinished)
+
+	// The same event again changes nothing.
+	again, err := svc.RecordEpisodeEvent(ctx, user.ID, ep.ID, req)
+	require.NoError(t, err)
+	assert.Equal(t, state.TotalListenTimeMs, again.TotalListenTimeMs)
+
+	req.EventID = "evt-ep-2"
+	req.StartPositionMs, req.EndPositionMs = 300000, 600000
+	state, err = svc.RecordEpisodeEvent(ctx, user.ID, ep.ID, req)
+	require.NoError(t, err)
+	assert.True(t, state.IsFinished)
+
+	episodes, err = svc.ListEpisodes(ctx, user.ID, p.ID)
+	require.NoError(t, err)
+	require.NotNil(t, episodes[0].State)
+	assert.True(t, episodes[0].State.IsFinished)
+}
+
+func TestPodcastService_RestrictedLibrary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, s, lib := setupPodcastTest(t)
+	feed := newTestFeedServer(t, "ep-1")
+	user := createTestUserWithPermissions(t, s, "listener@example.com", false)
+
+	p, err := svc.Subscribe(ctx, SubscribeRequest{LibraryID: lib.ID, FeedURL: feed.URL + "/feed.xml"})
+	require.NoError(t, err)
+	episodes, err := s.ListPodcastEpisodes(ctx, p.ID)
+	require.NoError(t, err)
+	require.Len(t, episodes, 1)
+	ep := episodes[0]
+
+	lib.AccessMode = domain.AccessModeRestricted
+	require.NoError(t, s.UpdateLibrary(ctx, lib))
+
+	// The library owner still sees everything.
+	podcasts, err := svc.ListPodcasts(ctx, lib.OwnerID, "")
+	require.NoError(t, err)
+	assert.Len(t, podcasts, 1)
+	_, err = svc.GetEpisode(ctx, lib.OwnerID, ep.ID)
+	require.NoError(t, err)
+
+	// Other users don't, and can't tell the podcast exists.
+	podcasts, err = svc.ListPodcasts(ctx, user.ID, "")
+	require.NoError(t, err)
+	assert.Empty(t, podcasts)
+	podcasts, err = svc.ListPodcasts(ctx, user.ID, lib.ID)
+	require.NoError(t, err)
+	assert.Empty(t, podcasts)
+	_, err = svc.GetPodcast(ctx, user.ID, p.ID)
+	require.ErrorIs(t, err, store.ErrPodcastNotFound)
+	_, err = svc.ListEpisodes(ctx, user.ID, p.ID)
+	require.ErrorIs(t, err, store.ErrPodcastNotFound)
+	_, err = svc.GetEpisode(ctx, user.ID, ep.ID)
+	require.ErrorIs(t, err, store.ErrEpisodeNotFound)
+	start := time.Now().Add(-time.Minute)
+	_, err = svc.RecordEpisodeEvent(ctx, user.ID, ep.ID, RecordEpisodeEventRequest{
+		EndPositionMs: 60000,
+		StartedAt:     start,
+		EndedAt:       start.Add(time.Minute),
+		PlaybackSpeed: 1,
+		DeviceID:      "phone",
+	})
+	require.ErrorIs(t, err, store.ErrEpisodeNotFound)
+
+	// Sharing a collection in the library grants access.
+	now := time.Now()
+	coll := &domain.Collection{ID: "coll-shows", LibraryID: lib.ID, OwnerID: lib.OwnerID, Name: "Shows", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, s.CreateCollection(ctx, coll))
+	require.NoError(t, s.CreateShare(ctx, &domain.CollectionShare{
+		CollectionID:     coll.ID,
+		SharedWithUserID: user.ID,
+		SharedByUserID:   lib.OwnerID,
+		Permission:       domain.PermissionRead,
+	}))
+	podcasts, err = svc.ListPodcasts(ctx, user.ID, "")
+	require.NoError(t, err)
+	assert.Len(t, podcasts, 1)
+	_, err = svc.GetEpisode(ctx, user.ID, ep.ID)
+	require.NoError(t, err)
+}
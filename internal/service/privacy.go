@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// privacyStore is the store surface the social privacy checks read.
+type privacyStore interface {
+	GetUserProfile(ctx context.Context, userID string) (*domain.UserProfile, error)
+	GetBookPreferences(ctx context.Context, userID, bookID string) (*domain.BookPreferences, error)
+}
+
+// socialPrivacy answers what other users may see of someone's listening.
+// Lookups are cached, so create one per request: feeds and reader lists
+// keep asking about the same users and books.
+//
+// A lookup that fails for any reason other than "not set" counts as hidden,
+// so a storage error never leaks listening a user asked to keep private.
+type socialPrivacy struct {
+	store    privacyStore
+	profiles map[string]*domain.UserProfile // nil entry: lookup failed
+	private  map[string]bool                // BookPreferencesID -> private listening
+}
+
+func newSocialPrivacy(st privacyStore) *socialPrivacy {
+	return &socialPrivacy{
+		store:    st,
+		profiles: make(map[string]*domain.UserProfile),
+		private:  make(map[string]bool),
+	}
+}
+
+// profile returns the user's profile, a default one if they never saved it,
+// or nil if it couldn't be read.
+func (p *socialPrivacy) profile(ctx context.Context, userID string) *domain.UserProfile {
+	if profile, ok := p.profiles[userID]; ok {
+		return profile
+	}
+
+	profile, err := p.store.GetUserProfile(ctx, userID)
+	if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrProfileNotFound) {
+		profile, err = domain.NewUserProfile(userID), nil
+	}
+	if err != nil {
+		profile = nil
+	}
+	p.profiles[userID] = profile
+	return profile
+}
+
+// isPrivateListening reports whether the user listens to the book privately.
+func (p *socialPrivacy) isPrivateListening(ctx context.Context, userID, bookID string) bool {
+	key := domain.BookPreferencesID(userID, bookID)
+	if private, ok := p.private[key]; ok {
+		return private
+	}
+
+	prefs, err := p.store.GetBookPreferences(ctx, userID, bookID)
+	private := true
+	switch {
+	case err == nil:
+		private = prefs.PrivateListening
+	case errors.Is(err, store.ErrBookPreferencesNotFound):
+		private = false
+	}
+	p.private[key] = private
+	return private
+}
+
+// hidesActivity reports whether an activity by the user about the book
+// (empty for activities without one) must be kept from other users.
+func (p *socialPrivacy) hidesActivity(ctx context.Context, userID, bookID string) bool {
+	profile := p.profile(ctx, userID)
+	if profile == nil || profile.HideActivity {
+		return true
+	}
+	return bookID != "" && p.isPrivateListening(ctx, userID, bookID)
+}
+
+// hidesCurrentlyListening reports whether other users may not see that the
+// user is listening to the book right now.
+func (p *socialPrivacy) hidesCurrentlyListening(ctx context.Context, userID, bookID string) bool {
+	profile := p.profile(ctx, userID)
+	if profile == nil || profile.HideCurrentlyListening {
+		return true
+	}
+	return p.isPrivateListening(ctx, userID, bookID)
+}
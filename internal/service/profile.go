@@ -96,6 +96,11 @@ type UpdateProfileRequest struct {
 	FirstName   *string
 	LastName    *string
 	NewPassword *string
+
+	// Social privacy settings
+	HideFromLeaderboard    *bool
+	HideActivity           *bool
+	HideCurrentlyListening *bool
 }
 
 // UpdateProfile updates a user's profile settings.
@@ -131,6 +136,16 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, userID string, req U
 		profile.Tagline = tagline
 	}
 
+	if req.HideFromLeaderboard != nil {
+		profile.HideFromLeaderboard = *req.HideFromLeaderboard
+	}
+	if req.HideActivity != nil {
+		profile.HideActivity = *req.HideActivity
+	}
+	if req.HideCurrentlyListening != nil {
+		profile.HideCurrentlyListening = *req.HideCurrentlyListening
+	}
+
 	// Handle firstName, lastName, and password changes
 	if req.FirstName != nil || req.LastName != nil || req.NewPassword != nil {
 		if err := s.updateUserDetails(ctx, userID, req); err != nil {
@@ -270,6 +285,7 @@ type FullUserProfile struct {
 	BooksFinished     int   `json:"books_finished"`
 	CurrentStreak     int   `json:"current_streak"`
 	LongestStreak     int   `json:"longest_streak"`
+	StatsHidden       bool  `json:"stats_hidden"` // Zeroed because the user hides from the leaderboard
 
 	// Recent activity (filtered by viewer's ACL)
 	RecentBooks   []RecentBookSummary `json:"recent_books"`
@@ -296,7 +312,8 @@ type ShelfSummary struct {
 }
 
 // GetFullProfile returns a complete profile for display.
-// viewingUserID is used for ACL filtering on recent books.
+// viewingUserID is used for ACL filtering on recent books, and for
+// other viewers the profile user's privacy settings are applied.
 func (s *ProfileService) GetFullProfile(ctx context.Context, profileUserID, viewingUserID string) (*FullUserProfile, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -314,17 +331,27 @@ func (s *ProfileService) GetFullProfile(ctx context.Context, profileUserID, view
 		return nil, fmt.Errorf("get profile: %w", err)
 	}
 
+	isOwnProfile := profileUserID == viewingUserID
+	hideStats := !isOwnProfile && profile.HideFromLeaderboard
+	hideActivity := !isOwnProfile && profile.HideActivity
+
 	// Get all-time stats
-	stats, err := s.stats.GetUserStats(ctx, profileUserID, domain.StatsPeriodAllTime)
-	if err != nil {
-		return nil, fmt.Errorf("get stats: %w", err)
+	stats := &domain.UserStatsDetailed{}
+	if !hideStats {
+		stats, err = s.stats.GetUserStats(ctx, profileUserID, domain.StatsPeriodAllTime)
+		if err != nil {
+			return nil, fmt.Errorf("get stats: %w", err)
+		}
 	}
 
 	// Get recent finished books (filtered by viewer's access)
-	recentBooks, err := s.getRecentBooksFiltered(ctx, profileUserID, viewingUserID, 5)
-	if err != nil {
-		s.logger.Warn("failed to get recent books", "error", err)
-		recentBooks = []RecentBookSummary{}
+	recentBooks := []RecentBookSummary{}
+	if !hideActivity {
+		recentBooks, err = s.getRecentBooksFiltered(ctx, profileUserID, viewingUserID, 5)
+		if err != nil {
+			s.logger.Warn("failed to get recent books", "error", err)
+			recentBooks = []RecentBookSummary{}
+		}
 	}
 
 	// Get public shelves
@@ -345,14 +372,16 @@ func (s *ProfileService) GetFullProfile(ctx context.Context, profileUserID, view
 		BooksFinished:     stats.BooksFinished,
 		CurrentStreak:     stats.CurrentStreakDays,
 		LongestStreak:     stats.LongestStreakDays,
+		StatsHidden:       hideStats,
 		RecentBooks:       recentBooks,
 		PublicShelves:     shelves,
-		IsOwnProfile:      profileUserID == viewingUserID,
+		IsOwnProfile:      isOwnProfile,
 	}, nil
 }
 
 // getRecentBooksFiltered returns the profile user's recently finished books,
-// filtered to only books the viewing user can access.
+// filtered to only books the viewing user can access. Books the profile user
+// listens to privately are left out for other viewers.
 func (s *ProfileService) getRecentBooksFiltered(ctx context.Context, profileUserID, viewingUserID string, limit int) ([]RecentBookSummary, error) {
 	// Get profile user's finished state
 	finishedProgress, err := s.store.GetStateFinishedInRange(ctx, profileUserID, time.Time{}, time.Now())
@@ -376,11 +405,15 @@ func (s *ProfileService) getRecentBooksFiltered(ctx context.Context, profileUser
 	// Filter and build response
 	var result []RecentBookSummary
 	seenBooks := make(map[string]bool)
+	privacy := newSocialPrivacy(s.store)
 
 	for _, progress := range finishedProgress {
 		if !accessibleSet[progress.BookID] {
 			continue
 		}
+		if profileUserID != viewingUserID && privacy.isPrivateListening(ctx, profileUserID, progress.BookID) {
+			continue
+		}
 		if seenBooks[progress.BookID] {
 			continue
 		}
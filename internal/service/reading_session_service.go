@@ -362,6 +362,14 @@ func (s *ReadingSessionService) GetBookReaders(ctx context.Context, bookID, view
 	viewerSessions := sessionsByUser[viewingUserID]
 	delete(sessionsByUser, viewingUserID) // Remove from other readers
 
+	// Remove readers who keep their activity or this book private
+	privacy := newSocialPrivacy(s.store)
+	for userID := range sessionsByUser {
+		if privacy.hidesActivity(ctx, userID, bookID) {
+			delete(sessionsByUser, userID)
+		}
+	}
+
 	// Build response
 	response := &BookReadersResponse{
 		YourSessions:     buildSessionSummaries(viewerSessions),
@@ -394,17 +402,15 @@ func (s *ReadingSessionService) GetBookReaders(ctx context.Context, bookID, view
 			continue
 		}
 
-		// Get profile for avatar info (optional - defaults to auto if not found)
-		var profile *domain.UserProfile
-		if p, err := s.store.GetUserProfile(ctx, userID); err == nil {
-			profile = p
-		}
+		// Profile for avatar info, already loaded by the privacy check
+		profile := privacy.profile(ctx, userID)
+		hideListening := profile.HideCurrentlyListening
 
 		// Get current progress from PlaybackState if user is actively reading
 		var currentProgress float64
 		hasActiveSession := false
 		for _, session := range sessions {
-			if session.IsActive() {
+			if session.IsActive() && !hideListening {
 				hasActiveSession = true
 				break
 			}
@@ -416,6 +422,9 @@ func (s *ReadingSessionService) GetBookReaders(ctx context.Context, bookID, view
 		}
 
 		summary := buildReaderSummary(user, profile, sessions, currentProgress)
+		if hideListening {
+			summary.IsCurrentlyReading = false
+		}
 		response.OtherReaders = append(response.OtherReaders, summary)
 
 		// Count completions
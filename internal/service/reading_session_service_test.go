@@ -278,6 +278,41 @@ func TestGetBookReaders(t *testing.T) {
 	assert.Equal(t, 1, bobSummary.CompletionCount)
 }
 
+func TestGetBookReaders_RespectsPrivacy(t *testing.T) {
+	svc, s, cleanup := setupTestReadingSession(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	viewer := createTestUserForSession(t, s, "user-1", "alice@example.com", "Alice")
+	createTestUserForSession(t, s, "user-2", "bob@example.com", "Bob")
+	createTestUserForSession(t, s, "user-3", "carol@example.com", "Carol")
+	createTestBookForSession(t, s, "book-1", 3600000)
+
+	// Bob and Carol are both reading the book right now
+	require.NoError(t, s.CreateReadingSession(ctx, domain.NewBookReadingSession("session-2", "user-2", "book-1")))
+	require.NoError(t, s.CreateReadingSession(ctx, domain.NewBookReadingSession("session-3", "user-3", "book-1")))
+
+	// Bob hides what he's listening to now; Carol reads this book privately
+	profile := domain.NewUserProfile("user-2")
+	profile.HideCurrentlyListening = true
+	require.NoError(t, s.SaveUserProfile(ctx, profile))
+
+	prefs := domain.NewBookPreferences("user-3", "book-1")
+	prefs.PrivateListening = true
+	require.NoError(t, s.UpsertBookPreferences(ctx, prefs))
+
+	response, err := svc.GetBookReaders(ctx, "book-1", viewer.ID, 10)
+	require.NoError(t, err)
+
+	require.Len(t, response.OtherReaders, 1)
+	assert.Equal(t, 1, response.TotalReaders)
+	bob := response.OtherReaders[0]
+	assert.Equal(t, "user-2", bob.UserID)
+	assert.False(t, bob.IsCurrentlyReading)
+	assert.Zero(t, bob.CurrentProgress)
+}
+
 func TestGetUserReadingHistory(t *testing.T) {
 	svc, s, cleanup := setupTestReadingSession(t)
 	defer cleanup()
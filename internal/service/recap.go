@@ -0,0 +1,561 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// recapServiceStore is the narrow store interface RecapService depends on:
+// the recap cache, listening history, and the book metadata recaps group by.
+type recapServiceStore interface {
+	store.RecapStore
+	privacyStore
+	GetUser(ctx context.Context, id string) (*domain.User, error)
+	GetEventsForUserInRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.ListeningEvent, error)
+	GetStateFinishedInRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.PlaybackState, error)
+	GetBook(ctx context.Context, id string, userID string) (*domain.Book, error)
+	GetContributorsByBookIDs(ctx context.Context, bookIDs []string) (map[string][]domain.BookContributor, error)
+	GetSeriesByBookIDs(ctx context.Context, bookIDs []string) (map[string][]domain.BookSeries, error)
+	GetContributorsByIDs(ctx context.Context, ids []string) ([]*domain.Contributor, error)
+	GetSeriesByIDs(ctx context.Context, ids []string) ([]*domain.Series, error)
+	GetGenresByIDs(ctx context.Context, ids []string) ([]*domain.Genre, error)
+}
+
+// RecapService builds "year in review" listening recaps and manages their
+// public share pages.
+type RecapService struct {
+	store  recapServiceStore
+	logger *slog.Logger
+}
+
+// NewRecapService creates a new recap service.
+func NewRecapService(store recapServiceStore, logger *slog.Logger) *RecapService {
+	return &RecapService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+const (
+	// recapTopN is the length of each of a recap's top lists.
+	recapTopN = 5
+
+	// recapSessionGap is the longest pause that still counts as one
+	// listening session when events of the same book are merged.
+	recapSessionGap = 10 * time.Minute
+
+	// Cached recaps are rebuilt after these ages. A period that is still
+	// running changes with every listen; a finished one only changes when
+	// history is imported or edited.
+	recapOngoingTTL  = time.Hour
+	recapFinishedTTL = 24 * time.Hour
+)
+
+// GetRecap returns the user's recap for a period, from the cache when it is
+// fresh. refresh forces a rebuild.
+func (s *RecapService) GetRecap(ctx context.Context, userID, periodKey string, refresh bool) (*domain.ListeningRecap, error) {
+	period, err := domain.ParseRecapPeriod(periodKey, time.Local)
+	if err != nil {
+		return nil, domainerrors.Validation(err.Error())
+	}
+	return s.recap(ctx, userID, period, refresh)
+}
+
+// ShareRecap makes the user's recap for a period readable on its public
+// share page. Sharing an already shared recap keeps its share ID.
+func (s *RecapService) ShareRecap(ctx context.Context, userID, periodKey string) (*domain.ListeningRecap, error) {
+	period, err := domain.ParseRecapPeriod(periodKey, time.Local)
+	if err != nil {
+		return nil, domainerrors.Validation(err.Error())
+	}
+
+	recap, err := s.recap(ctx, userID, period, false)
+	if err != nil {
+		return nil, err
+	}
+	if recap.ShareID != "" {
+		return recap, nil
+	}
+
+	shareID, err := id.Generate("recap")
+	if err != nil {
+		return nil, fmt.Errorf("generate share ID: %w", err)
+	}
+	// A concurrent share may have won; its ID is kept.
+	if recap.ShareID, err = s.store.ShareListeningRecap(ctx, userID, period.Key, shareID); err != nil {
+		return nil, fmt.Errorf("share recap: %w", err)
+	}
+
+	s.logger.Info("recap shared", "user_id", userID, "period", period.Key)
+
+	return recap, nil
+}
+
+// UnshareRecap takes the user's recap for a period off its public share page.
+func (s *RecapService) UnshareRecap(ctx context.Context, userID, periodKey string) error {
+	period, err := domain.ParseRecapPeriod(periodKey, time.Local)
+	if err != nil {
+		return domainerrors.Validation(err.Error())
+	}
+
+	recap, err := s.store.GetListeningRecap(ctx, userID, period.Key)
+	if err != nil {
+		return err
+	}
+	if recap.ShareID == "" {
+		return nil
+	}
+
+	if err := s.store.UnshareListeningRecap(ctx, userID, period.Key); err != nil {
+		return fmt.Errorf("unshare recap: %w", err)
+	}
+
+	s.logger.Info("recap unshared", "user_id", userID, "period", period.Key)
+
+	return nil
+}
+
+// SharedRecap is a recap as shown on its public share page.
+type SharedRecap struct {
+	Recap       *domain.ListeningRecap
+	Period      domain.RecapPeriod
+	DisplayName string
+}
+
+// GetSharedRecap returns the recap shared under shareID. Books the owner
+// listens to privately are left out of the rankings and book highlights.
+func (s *RecapService) GetSharedRecap(ctx context.Context, shareID string) (*SharedRecap, error) {
+	cached, err := s.store.GetListeningRecapByShareID(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := domain.ParseRecapPeriod(cached.Period, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("parse cached recap period: %w", err)
+	}
+
+	recap, err := s.recap(ctx, cached.UserID, period, false)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUser(ctx, recap.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	public, err := s.publicRecap(ctx, recap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SharedRecap{
+		Recap:       public,
+		Period:      period,
+		DisplayName: user.Name(),
+	}, nil
+}
+
+// recap returns the cached recap for the period, rebuilding and caching it
+// when missing, stale or refresh is set. Saving a rebuild leaves the stored
+// share ID alone; the cached one is only carried over for the caller.
+func (s *RecapService) recap(ctx context.Context, userID string, period domain.RecapPeriod, refresh bool) (*domain.ListeningRecap, error) {
+	cached, err := s.store.GetListeningRecap(ctx, userID, period.Key)
+	if err != nil && !errors.Is(err, store.ErrRecapNotFound) {
+		return nil, fmt.Errorf("get cached recap: %w", err)
+	}
+
+	now := time.Now()
+	if cached != nil && !refresh && recapIsFresh(cached, now) {
+		return cached, nil
+	}
+
+	recap, err := s.buildRecap(ctx, userID, period, now)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		recap.ShareID = cached.ShareID
+	}
+
+	if err := s.store.SaveListeningRecap(ctx, recap); err != nil {
+		return nil, fmt.Errorf("save recap: %w", err)
+	}
+
+	return recap, nil
+}
+
+// recapIsFresh reports whether a cached recap can still be served at now.
+// Recaps cached without per-book listening time can't be shared without
+// private books, so they are rebuilt.
+func recapIsFresh(recap *domain.ListeningRecap, now time.Time) bool {
+	if recap.BooksListened > 0 && recap.BookListenMs == nil {
+		return false
+	}
+	ttl := recapOngoingTTL
+	if !recap.GeneratedAt.Before(recap.EndDate) {
+		ttl = recapFinishedTTL
+	}
+	return now.Sub(recap.GeneratedAt) < ttl
+}
+
+// buildRecap computes the user's recap for the period from their listening
+// events, finished books and the metadata of the books they listened to.
+func (s *RecapService) buildRecap(ctx context.Context, userID string, period domain.RecapPeriod, now time.Time) (*domain.ListeningRecap, error) {
+	allEvents, err := s.store.GetEventsForUserInRange(ctx, userID, period.Start, period.End)
+	if err != nil {
+		return nil, fmt.Errorf("get listening events: %w", err)
+	}
+	finished, err := s.store.GetStateFinishedInRange(ctx, userID, period.Start, period.End)
+	if err != nil {
+		return nil, fmt.Errorf("get finished books: %w", err)
+	}
+
+	recap := &domain.ListeningRecap{
+		UserID:       userID,
+		Period:       period.Key,
+		StartDate:    period.Start,
+		EndDate:      period.End,
+		GeneratedAt:  now,
+		TopBooks:     []domain.RecapRanking{},
+		TopAuthors:   []domain.RecapRanking{},
+		TopNarrators: []domain.RecapRanking{},
+		TopGenres:    []domain.RecapRanking{},
+		TopSeries:    []domain.RecapRanking{},
+	}
+
+	// Range queries include their end; the period doesn't.
+	for _, state := range finished {
+		if state.FinishedAt != nil && state.FinishedAt.Before(period.End) {
+			recap.BooksFinished++
+		}
+	}
+
+	// Skip events that end on the boundary or have implausible durations
+	const maxReasonableDurationMs = 24 * 60 * 60 * 1000
+	events := make([]*domain.ListeningEvent, 0, len(allEvents))
+	for _, e := range allEvents {
+		if e.EndedAt.Before(period.End) && e.DurationMs > 0 && e.DurationMs <= maxReasonableDurationMs {
+			events = append(events, e)
+		}
+	}
+	slices.SortFunc(events, func(a, b *domain.ListeningEvent) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	bookMs := make(map[string]int64)
+	dailyMap := make(map[string]*domain.DailyListening)
+	booksPerDay := make(map[string]map[string]bool)
+	var speedWeighted float64
+	var speedMs int64
+	var session, longest *domain.RecapSession
+
+	for _, e := range events {
+		recap.TotalListenTimeMs += e.DurationMs
+		bookMs[e.BookID] += e.DurationMs
+
+		if e.PlaybackSpeed > 0 {
+			speedWeighted += float64(e.PlaybackSpeed) * float64(e.DurationMs)
+			speedMs += e.DurationMs
+		}
+
+		started := e.StartedAt.In(time.Local)
+		recap.HourlyHeatmap[started.Weekday()][started.Hour()] += e.DurationMs
+
+		// Daily aggregation, matching StatsService
+		ended := e.EndedAt.In(time.Local)
+		dateKey := ended.Format("2006-01-02")
+		if dailyMap[dateKey] == nil {
+			dailyMap[dateKey] = &domain.DailyListening{
+				Date: time.Date(ended.Year(), ended.Month(), ended.Day(), 0, 0, 0, 0, time.Local),
+			}
+			booksPerDay[dateKey] = make(map[string]bool)
+		}
+		dailyMap[dateKey].ListenTimeMs += e.DurationMs
+		booksPerDay[dateKey][e.BookID] = true
+
+		// Merge events of the same book into sessions
+		if session != nil && session.BookID == e.BookID && !e.StartedAt.After(session.EndedAt.Add(recapSessionGap)) {
+			session.ListenTimeMs += e.DurationMs
+			if e.EndedAt.After(session.EndedAt) {
+				session.EndedAt = e.EndedAt
+			}
+		} else {
+			session = &domain.RecapSession{
+				BookID:       e.BookID,
+				StartedAt:    e.StartedAt,
+				EndedAt:      e.EndedAt,
+				ListenTimeMs: e.DurationMs,
+			}
+		}
+		if longest == nil || session.ListenTimeMs > longest.ListenTimeMs {
+			longest = session
+		}
+	}
+
+	recap.BooksListened = len(bookMs)
+	recap.BookListenMs = bookMs
+	if speedMs > 0 {
+		recap.AveragePlaybackSpeed = math.Round(speedWeighted/float64(speedMs)*100) / 100
+	}
+
+	var qualifyingDates []string
+	for dateKey, daily := range dailyMap {
+		daily.BooksListened = len(booksPerDay[dateKey])
+		if recap.BusiestDay == nil || daily.ListenTimeMs > recap.BusiestDay.ListenTimeMs ||
+			(daily.ListenTimeMs == recap.BusiestDay.ListenTimeMs && daily.Date.Before(recap.BusiestDay.Date)) {
+			recap.BusiestDay = daily
+		}
+		if daily.ListenTimeMs >= minListenMs {
+			qualifyingDates = append(qualifyingDates, dateKey)
+		}
+	}
+	recap.ListeningDays = len(qualifyingDates)
+	recap.LongestStreakDays = longestStreak(qualifyingDates)
+
+	books := make(map[string]*domain.Book, len(bookMs))
+	for bookID := range bookMs {
+		// Books since deleted or no longer accessible count toward totals only
+		if book, err := s.store.GetBook(ctx, bookID, userID); err == nil {
+			books[bookID] = book
+		}
+	}
+
+	if err := s.rankMetadata(ctx, recap, books, bookMs); err != nil {
+		return nil, err
+	}
+
+	if longest != nil {
+		if book := books[longest.BookID]; book != nil {
+			longest.BookTitle = book.Title
+		}
+		recap.LongestSession = longest
+	}
+
+	for _, e := range events {
+		if book := books[e.BookID]; book != nil {
+			recap.FirstBook = s.recapBook(ctx, book, e.StartedAt)
+			break
+		}
+	}
+	for _, e := range slices.Backward(events) {
+		if book := books[e.BookID]; book != nil {
+			recap.LastBook = s.recapBook(ctx, book, e.EndedAt)
+			break
+		}
+	}
+
+	s.logger.Debug("built listening recap",
+		"user_id", userID,
+		"period", period.Key,
+		"event_count", len(events),
+		"total_listen_time_ms", recap.TotalListenTimeMs,
+	)
+
+	return recap, nil
+}
+
+// rankMetadata fills the recap's top books, authors, narrators, series and
+// genres. A book's listening time counts fully toward each of its authors,
+// narrators, series and genres.
+func (s *RecapService) rankMetadata(ctx context.Context, recap *domain.ListeningRecap, books map[string]*domain.Book, bookMs map[string]int64) error {
+	// GetBook does not load contributor and series links.
+	bookIDs := slices.Collect(maps.Keys(books))
+	contribMap, err := s.store.GetContributorsByBookIDs(ctx, bookIDs)
+	if err != nil {
+		return fmt.Errorf("load book contributors: %w", err)
+	}
+	seriesMap, err := s.store.GetSeriesByBookIDs(ctx, bookIDs)
+	if err != nil {
+		return fmt.Errorf("load book series: %w", err)
+	}
+
+	bookNames := make(map[string]string, len(books))
+	authorMs := make(map[string]int64)
+	narratorMs := make(map[string]int64)
+	seriesMs := make(map[string]int64)
+	genreMs := make(map[string]int64)
+
+	for bookID, book := range books {
+		ms := bookMs[bookID]
+		bookNames[bookID] = book.Title
+		for _, c := range contribMap[bookID] {
+			if slices.Contains(c.Roles, domain.RoleAuthor) {
+				authorMs[c.ContributorID] += ms
+			}
+			if slices.Contains(c.Roles, domain.RoleNarrator) {
+				narratorMs[c.ContributorID] += ms
+			}
+		}
+		for _, bs := range seriesMap[bookID] {
+			seriesMs[bs.SeriesID] += ms
+		}
+		for _, genreID := range book.GenreIDs {
+			genreMs[genreID] += ms
+		}
+	}
+
+	contributorNames := make(map[string]string)
+	if ids := slices.Concat(slices.Collect(maps.Keys(authorMs)), slices.Collect(maps.Keys(narratorMs))); len(ids) > 0 {
+		contributors, err := s.store.GetContributorsByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("get contributors: %w", err)
+		}
+		for _, c := range contributors {
+			contributorNames[c.ID] = c.Name
+		}
+	}
+
+	seriesNames := make(map[string]string)
+	if ids := slices.Collect(maps.Keys(seriesMs)); len(ids) > 0 {
+		series, err := s.store.GetSeriesByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("get series: %w", err)
+		}
+		for _, sr := range series {
+			seriesNames[sr.ID] = sr.Name
+		}
+	}
+
+	genreNames := make(map[string]string)
+	if ids := slices.Collect(maps.Keys(genreMs)); len(ids) > 0 {
+		genres, err := s.store.GetGenresByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("get genres: %w", err)
+		}
+		for _, g := range genres {
+			genreNames[g.ID] = g.Name
+		}
+	}
+
+	recap.TopBooks = topRankings(bookMs, bookNames)
+	recap.TopAuthors = topRankings(authorMs, contributorNames)
+	recap.TopNarrators = topRankings(narratorMs, contributorNames)
+	recap.TopSeries = topRankings(seriesMs, seriesNames)
+	recap.TopGenres = topRankings(genreMs, genreNames)
+	return nil
+}
+
+// recapBook builds a recap highlight for a book listened to at the given time.
+func (s *RecapService) recapBook(ctx context.Context, book *domain.Book, listenedAt time.Time) *domain.RecapBook {
+	return &domain.RecapBook{
+		BookID:     book.ID,
+		Title:      book.Title,
+		AuthorName: getAuthorName(ctx, s.store, book),
+		ListenedAt: listenedAt,
+	}
+}
+
+// topRankings returns the recapTopN named entries with the most listening
+// time, most first. Entries without a name (deleted since) are left out.
+func topRankings(ms map[string]int64, names map[string]string) []domain.RecapRanking {
+	rankings := make([]domain.RecapRanking, 0, len(ms))
+	for entryID, listened := range ms {
+		name := names[entryID]
+		if name == "" {
+			continue
+		}
+		rankings = append(rankings, domain.RecapRanking{ID: entryID, Name: name, ListenTimeMs: listened})
+	}
+
+	slices.SortFunc(rankings, func(a, b domain.RecapRanking) int {
+		if c := cmp.Compare(b.ListenTimeMs, a.ListenTimeMs); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	if len(rankings) > recapTopN {
+		rankings = rankings[:recapTopN]
+	}
+	return rankings
+}
+
+// longestStreak returns the longest run of consecutive dates, given as
+// unordered "2006-01-02" strings.
+func longestStreak(dates []string) int {
+	if len(dates) == 0 {
+		return 0
+	}
+	slices.Sort(dates)
+
+	longest, run := 1, 1
+	for i := 1; i < len(dates); i++ {
+		curr, _ := time.Parse("2006-01-02", dates[i])
+		prev, _ := time.Parse("2006-01-02", dates[i-1])
+		if prev.AddDate(0, 0, 1).Equal(curr) {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 1
+		}
+	}
+	return longest
+}
+
+// publicRecap returns a copy of the recap for its share page. Books the owner
+// listens to privately are left out, and the top lists are ranked again
+// without their listening time. Totals still count them.
+func (s *RecapService) publicRecap(ctx context.Context, recap *domain.ListeningRecap) (*domain.ListeningRecap, error) {
+	privacy := newSocialPrivacy(s.store)
+	public := withoutPrivateBooks(ctx, privacy, recap)
+
+	publicMs := make(map[string]int64, len(recap.BookListenMs))
+	for bookID, ms := range recap.BookListenMs {
+		if !privacy.isPrivateListening(ctx, recap.UserID, bookID) {
+			publicMs[bookID] = ms
+		}
+	}
+	if len(publicMs) == len(recap.BookListenMs) {
+		return public, nil
+	}
+
+	books := make(map[string]*domain.Book, len(publicMs))
+	for bookID := range publicMs {
+		if book, err := s.store.GetBook(ctx, bookID, recap.UserID); err == nil {
+			books[bookID] = book
+		}
+	}
+	if err := s.rankMetadata(ctx, public, books, publicMs); err != nil {
+		return nil, err
+	}
+	public.BookListenMs = publicMs
+	return public, nil
+}
+
+// withoutPrivateBooks returns a copy of the recap without the books the
+// owner listens to privately in its book highlights.
+func withoutPrivateBooks(ctx context.Context, privacy *socialPrivacy, recap *domain.ListeningRecap) *domain.ListeningRecap {
+	private := func(bookID string) bool {
+		return privacy.isPrivateListening(ctx, recap.UserID, bookID)
+	}
+
+	public := *recap
+	public.TopBooks = slices.DeleteFunc(slices.Clone(recap.TopBooks), func(r domain.RecapRanking) bool {
+		return private(r.ID)
+	})
+	if public.FirstBook != nil && private(public.FirstBook.BookID) {
+		public.FirstBook = nil
+	}
+	if public.LastBook != nil && private(public.LastBook.BookID) {
+		public.LastBook = nil
+	}
+	if public.LongestSession != nil && private(public.LongestSession.BookID) {
+		session := *public.LongestSession
+		session.BookID, session.BookTitle = "", ""
+		public.LongestSession = &session
+	}
+	return &public
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestRecapService(t *testing.T) (*RecapService, store.Store) {
+	t.Helper()
+
+	testStore, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { testStore.Close() })
+
+	svc := NewRecapService(testStore, slog.New(slog.DiscardHandler))
+
+	createTestUserForSocial(t, testStore, "user-1", "User One", domain.RoleMember)
+	createTestRecapBook(t, testStore, 1, "Book One")
+	createTestRecapBook(t, testStore, 2, "Book Two")
+
+	return svc, testStore
+}
+
+// createTestRecapBook creates book-<n> with its own path, since book paths are unique.
+func createTestRecapBook(t *testing.T, s store.Store, n int, title string) {
+	t.Helper()
+	id := fmt.Sprintf("book-%d", n)
+	book := &domain.Book{
+		Syncable:      domain.Syncable{ID: id},
+		Title:         title,
+		Path:          "/media/books/" + id,
+		TotalDuration: 3600000,
+	}
+	book.InitTimestamps()
+	require.NoError(t, s.CreateBook(context.Background(), book))
+}
+
+// recordRecapEvent stores a listening event covering minutes of book time.
+func recordRecapEvent(t *testing.T, s store.Store, eventID, bookID string, startedAt time.Time, minutes int, speed float32) {
+	t.Helper()
+	durationMs := (time.Duration(minutes) * time.Minute).Milliseconds()
+	event := domain.NewListeningEvent(eventID, "user-1", bookID, 0, durationMs,
+		startedAt, startedAt.Add(time.Duration(minutes)*time.Minute), speed, "device-1", "Phone")
+	require.NoError(t, s.CreateListeningEvent(context.Background(), event))
+}
+
+func TestRecapService_GetRecap(t *testing.T) {
+	svc, s := setupTestRecapService(t)
+	ctx := context.Background()
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.March, day, hour, minute, 0, 0, time.Local)
+	}
+
+	// Tuesday evening: two events of book-1 five minutes apart form one session
+	recordRecapEvent(t, s, "evt-1", "book-1", at(4, 20, 0), 30, 1.0)
+	recordRecapEvent(t, s, "evt-2", "book-1", at(4, 20, 35), 30, 1.0)
+	recordRecapEvent(t, s, "evt-3", "book-2", at(5, 8, 0), 20, 2.0)
+	recordRecapEvent(t, s, "evt-4", "book-1", at(7, 9, 0), 10, 1.0)
+	// Outside the period
+	recordRecapEvent(t, s, "evt-5", "book-2", time.Date(2024, time.December, 31, 12, 0, 0, 0, time.Local), 60, 1.0)
+
+	finishedAt := at(7, 9, 10)
+	require.NoError(t, s.UpsertState(ctx, &domain.PlaybackState{
+		UserID: "user-1", BookID: "book-1", IsFinished: true, FinishedAt: &finishedAt,
+		StartedAt: at(4, 20, 0), LastPlayedAt: finishedAt, UpdatedAt: finishedAt,
+	}))
+
+	recap, err := svc.GetRecap(ctx, "user-1", "2025", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, (90 * time.Minute).Milliseconds(), recap.TotalListenTimeMs)
+	assert.Equal(t, 1, recap.BooksFinished)
+	assert.Equal(t, 2, recap.BooksListened)
+	assert.Equal(t, 3, recap.ListeningDays)
+	assert.Equal(t, 2, recap.LongestStreakDays)
+	assert.InDelta(t, 1.22, recap.AveragePlaybackSpeed, 0.001)
+
+	require.Len(t, recap.TopBooks, 2)
+	assert.Equal(t, "book-1", recap.TopBooks[0].ID)
+	assert.Equal(t, (70 * time.Minute).Milliseconds(), recap.TopBooks[0].ListenTimeMs)
+
+	require.NotNil(t, recap.LongestSession)
+	assert.Equal(t, "Book One", recap.LongestSession.BookTitle)
+	assert.Equal(t, (60 * time.Minute).Milliseconds(), recap.LongestSession.ListenTimeMs)
+
+	require.NotNil(t, recap.BusiestDay)
+	assert.Equal(t, 4, recap.BusiestDay.Date.Day())
+	assert.Equal(t, (60 * time.Minute).Milliseconds(), recap.HourlyHeatmap[time.Tuesday][20])
+
+	require.NotNil(t, recap.FirstBook)
+	assert.Equal(t, "book-1", recap.FirstBook.BookID)
+	require.NotNil(t, recap.LastBook)
+	assert.Equal(t, "book-1", recap.LastBook.BookID)
+	assert.True(t, recap.LastBook.ListenedAt.Equal(at(7, 9, 10)))
+
+	// A finished period is served from the cache until refreshed
+	cached, err := svc.GetRecap(ctx, "user-1", "2025", false)
+	require.NoError(t, err)
+	assert.True(t, cached.GeneratedAt.Equal(recap.GeneratedAt))
+
+	refreshed, err := svc.GetRecap(ctx, "user-1", "2025", true)
+	require.NoError(t, err)
+	assert.True(t, refreshed.GeneratedAt.After(recap.GeneratedAt))
+
+	_, err = svc.GetRecap(ctx, "user-1", "last-year", false)
+	assert.ErrorIs(t, err, domainerrors.ErrValidation)
+}
+
+func TestRecapService_Sharing(t *testing.T) {
+	svc, s := setupTestRecapService(t)
+	ctx := context.Background()
+
+	_, err := s.SetBookContributors(ctx, "book-1", []store.ContributorInput{{Name: "Private Author", Roles: []domain.ContributorRole{domain.RoleAuthor}}})
+	require.NoError(t, err)
+	_, err = s.SetBookContributors(ctx, "book-2", []store.ContributorInput{{Name: "Public Author", Roles: []domain.ContributorRole{domain.RoleAuthor}}})
+	require.NoError(t, err)
+	recordRecapEvent(t, s, "evt-1", "book-1", time.Date(2025, time.May, 1, 20, 0, 0, 0, time.Local), 90, 1.0)
+	recordRecapEvent(t, s, "evt-2", "book-2", time.Date(2025, time.May, 2, 20, 0, 0, 0, time.Local), 30, 1.0)
+
+	shared, err := svc.ShareRecap(ctx, "user-1", "2025")
+	require.NoError(t, err)
+	require.NotEmpty(t, shared.ShareID)
+	require.Len(t, shared.TopAuthors, 2)
+
+	again, err := svc.ShareRecap(ctx, "user-1", "2025")
+	require.NoError(t, err)
+	assert.Equal(t, shared.ShareID, again.ShareID, "sharing twice keeps the link")
+
+	// Rebuilding the recap keeps it shared
+	_, err = svc.GetRecap(ctx, "user-1", "2025", true)
+	require.NoError(t, err)
+
+	prefs := domain.NewBookPreferences("user-1", "book-1")
+	prefs.PrivateListening = true
+	require.NoError(t, s.UpsertBookPreferences(ctx, prefs))
+
+	page, err := svc.GetSharedRecap(ctx, shared.ShareID)
+	require.NoError(t, err)
+	assert.Equal(t, "User One", page.DisplayName)
+	assert.Equal(t, "2025", page.Period.Label())
+	assert.Equal(t, (120 * time.Minute).Milliseconds(), page.Recap.TotalListenTimeMs, "totals still count private books")
+	require.Len(t, page.Recap.TopBooks, 1)
+	assert.Equal(t, "book-2", page.Recap.TopBooks[0].ID)
+	require.Len(t, page.Recap.TopAuthors, 1, "private books don't count toward rankings")
+	assert.Equal(t, "Public Author", page.Recap.TopAuthors[0].Name)
+	assert.Nil(t, page.Recap.FirstBook)
+	require.NotNil(t, page.Recap.LastBook)
+	assert.Equal(t, "book-2", page.Recap.LastBook.BookID)
+	require.NotNil(t, page.Recap.LongestSession)
+	assert.Empty(t, page.Recap.LongestSession.BookTitle)
+
+	require.NoError(t, svc.UnshareRecap(ctx, "user-1", "2025"))
+	_, err = svc.GetSharedRecap(ctx, shared.ShareID)
+	assert.ErrorIs(t, err, store.ErrRecapNotFound)
+
+	// Rebuilding after unsharing doesn't bring the link back
+	_, err = svc.GetRecap(ctx, "user-1", "2025", true)
+	require.NoError(t, err)
+	_, err = svc.GetSharedRecap(ctx, shared.ShareID)
+	assert.ErrorIs(t, err, store.ErrRecapNotFound)
+}
+
+func TestLongestStreak(t *testing.T) {
+	assert.Equal(t, 0, longestStreak(nil))
+	assert.Equal(t, 1, longestStreak([]string{"2025-01-05"}))
+	assert.Equal(t, 3, longestStreak([]string{"2025-03-02", "2025-01-31", "2025-02-28", "2025-03-01", "2025-02-01"}))
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/color"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/search/asyncindexer"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// ReviewActivityRecorder records review-related activities.
+// This avoids a circular dependency between ReviewService and ActivityService.
+type ReviewActivityRecorder interface {
+	RecordBookReviewed(ctx context.Context, userID, bookID string, rating float64) error
+}
+
+// reviewServiceStore is the narrow store interface ReviewService depends on.
+type reviewServiceStore interface {
+	store.BookStore
+	store.ReviewStore
+	store.UserStore
+}
+
+// ReviewService manages per-user book ratings and reviews.
+type ReviewService struct {
+	store            reviewServiceStore
+	indexer          *asyncindexer.Indexer
+	logger           *slog.Logger
+	activityRecorder ReviewActivityRecorder
+}
+
+// NewReviewService creates a new review service.
+func NewReviewService(store reviewServiceStore, indexer *asyncindexer.Indexer, logger *slog.Logger) *ReviewService {
+	return &ReviewService{
+		store:   store,
+		indexer: indexer,
+		logger:  logger,
+	}
+}
+
+// SetActivityRecorder sets the activity recorder for recording social activities.
+// This is set after construction to avoid circular dependencies.
+func (s *ReviewService) SetActivityRecorder(recorder ReviewActivityRecorder) {
+	s.activityRecorder = recorder
+}
+
+// SetReviewRequest contains the data for rating and reviewing a book.
+type SetReviewRequest struct {
+	Rating           float64 `json:"rating"`
+	Text             string  `json:"text" validate:"max=10000"`
+	ContainsSpoilers bool    `json:"contains_spoilers"`
+}
+
+// ReviewEntry is a review together with its author's display info.
+type ReviewEntry struct {
+	Review   *domain.BookReview
+	Reviewer ReaderInfo
+}
+
+// SetReview creates or replaces the user's review of a book.
+// Returns the review and whether it was newly created.
+func (s *ReviewService) SetReview(ctx context.Context, userID, bookID string, req SetReviewRequest) (*domain.BookReview, bool, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, false, formatValidationError(err)
+	}
+	if !domain.ValidReviewRating(req.Rating) {
+		return nil, false, domainerrors.Validationf("rating must be between %.1f and %.1f in half-star steps",
+			domain.MinReviewRating, domain.MaxReviewRating)
+	}
+
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	review, err := s.store.GetReviewForUserBook(ctx, userID, bookID)
+	switch {
+	case err == nil:
+		review.Rating = req.Rating
+		review.Text = req.Text
+		review.ContainsSpoilers = req.ContainsSpoilers
+		review.UpdatedAt = now
+		if err := s.store.UpdateReview(ctx, review); err != nil {
+			return nil, false, fmt.Errorf("update review: %w", err)
+		}
+		s.reindex(book)
+		return review, false, nil
+
+	case !errors.Is(err, store.ErrReviewNotFound):
+		return nil, false, fmt.Errorf("get review: %w", err)
+	}
+
+	reviewID, err := id.Generate("review")
+	if err != nil {
+		return nil, false, fmt.Errorf("generate review ID: %w", err)
+	}
+
+	review = &domain.BookReview{
+		ID:               reviewID,
+		UserID:           userID,
+		BookID:           bookID,
+		Rating:           req.Rating,
+		Text:             req.Text,
+		ContainsSpoilers: req.ContainsSpoilers,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := s.store.CreateReview(ctx, review); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return nil, false, domainerrors.Conflict("review was created concurrently, try again")
+		}
+		return nil, false, fmt.Errorf("create review: %w", err)
+	}
+	s.reindex(book)
+
+	s.logger.Info("book reviewed",
+		"review_id", reviewID,
+		"user_id", userID,
+		"book_id", bookID,
+		"rating", req.Rating,
+	)
+
+	// Record activity for social feed
+	if s.activityRecorder != nil {
+		if err := s.activityRecorder.RecordBookReviewed(ctx, userID, bookID, req.Rating); err != nil {
+			s.logger.Warn("failed to record book reviewed activity",
+				"review_id", reviewID,
+				"user_id", userID,
+				"error", err,
+			)
+		}
+	}
+
+	return review, true, nil
+}
+
+// GetMyReview returns the user's review of a book.
+func (s *ReviewService) GetMyReview(ctx context.Context, userID, bookID string) (*domain.BookReview, error) {
+	if _, err := s.store.GetBook(ctx, bookID, userID); err != nil {
+		return nil, err
+	}
+	return s.store.GetReviewForUserBook(ctx, userID, bookID)
+}
+
+// DeleteReview removes the user's review of a book.
+func (s *ReviewService) DeleteReview(ctx context.Context, userID, bookID string) error {
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return err
+	}
+
+	review, err := s.store.GetReviewForUserBook(ctx, userID, bookID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteReview(ctx, review.ID); err != nil {
+		return err
+	}
+	s.reindex(book)
+
+	s.logger.Info("review deleted", "review_id", review.ID, "user_id", userID, "book_id", bookID)
+	return nil
+}
+
+// ListBookReviews returns a page of reviews for a book along with its
+// community rating. The viewing user must be able to access the book.
+func (s *ReviewService) ListBookReviews(ctx context.Context, userID, bookID string, limit, offset int) ([]ReviewEntry, domain.RatingSummary, error) {
+	if _, err := s.store.GetBook(ctx, bookID, userID); err != nil {
+		return nil, domain.RatingSummary{}, err
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	offset = max(offset, 0)
+
+	reviews, err := s.store.ListReviewsForBook(ctx, bookID, limit, offset)
+	if err != nil {
+		return nil, domain.RatingSummary{}, fmt.Errorf("list reviews: %w", err)
+	}
+
+	summary, err := s.store.GetRatingSummary(ctx, bookID)
+	if err != nil {
+		return nil, domain.RatingSummary{}, fmt.Errorf("get rating summary: %w", err)
+	}
+
+	userIDs := make([]string, len(reviews))
+	for i, r := range reviews {
+		userIDs[i] = r.UserID
+	}
+	users, err := s.store.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, domain.RatingSummary{}, fmt.Errorf("get reviewers: %w", err)
+	}
+	usersByID := make(map[string]*domain.User, len(users))
+	for _, u := range users {
+		usersByID[u.ID] = u
+	}
+	profiles, err := s.store.GetUserProfilesByIDs(ctx, userIDs)
+	if err != nil {
+		// Profiles only carry avatars - fall back to auto avatars
+		s.logger.Debug("failed to get reviewer profiles", "book_id", bookID, "error", err)
+		profiles = nil
+	}
+
+	entries := make([]ReviewEntry, 0, len(reviews))
+	for _, r := range reviews {
+		reviewer := ReaderInfo{
+			UserID:      r.UserID,
+			AvatarColor: color.ForUser(r.UserID),
+			AvatarType:  string(domain.AvatarTypeAuto),
+		}
+		if u, ok := usersByID[r.UserID]; ok {
+			reviewer.DisplayName = u.Name()
+		}
+		if profile, ok := profiles[r.UserID]; ok && profile != nil {
+			reviewer.AvatarType = string(profile.AvatarType)
+			reviewer.AvatarValue = profile.AvatarValue
+		}
+		entries = append(entries, ReviewEntry{Review: r, Reviewer: reviewer})
+	}
+
+	return entries, summary, nil
+}
+
+// reindex refreshes the book's community rating in the search index.
+func (s *ReviewService) reindex(book *domain.Book) {
+	if s.indexer != nil {
+		s.indexer.SubmitIndexBook(book)
+	}
+}
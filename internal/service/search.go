@@ -20,6 +20,9 @@ type searchServiceStore interface {
 	store.TagStore
 	store.CollectionStore
 	store.UserStore
+	store.ReviewStore
+	store.LibraryStore
+	store.PodcastStore
 }
 
 // SearchService provides search functionality across the library.
@@ -97,6 +100,18 @@ func (s *SearchService) IndexSeries(ctx context.Context, series *domain.Series)
 	return nil
 }
 
+// IndexPodcast indexes a single podcast.
+func (s *SearchService) IndexPodcast(_ context.Context, p *domain.Podcast) error {
+	doc := search.PodcastToSearchDocument(p)
+
+	if err := s.index.IndexDocument(doc); err != nil {
+		return fmt.Errorf("index podcast: %w", err)
+	}
+
+	s.logger.Debug("indexed podcast", "id", p.ID, "title", p.Title)
+	return nil
+}
+
 // DeleteBook removes a book from the index.
 func (s *SearchService) DeleteBook(_ context.Context, bookID string) error {
 	return s.index.DeleteDocument(bookID)
@@ -112,6 +127,11 @@ func (s *SearchService) DeleteSeries(_ context.Context, seriesID string) error {
 	return s.index.DeleteDocument(seriesID)
 }
 
+// DeletePodcast removes a podcast from the index.
+func (s *SearchService) DeletePodcast(_ context.Context, podcastID string) error {
+	return s.index.DeleteDocument(podcastID)
+}
+
 // UpdateBookTags updates just the tags field of a book in the index.
 // This re-indexes the entire book document with updated tags.
 func (s *SearchService) UpdateBookTags(ctx context.Context, bookID string, tagSlugs []string) error {
@@ -141,6 +161,16 @@ func (s *SearchService) CanUserAccessBook(ctx context.Context, userID, bookID st
 	return s.store.CanUserAccessBook(ctx, userID, bookID)
 }
 
+// CanUserAccessPodcast reports whether userID may see podcastID, which
+// depends on access to the podcast's library.
+func (s *SearchService) CanUserAccessPodcast(ctx context.Context, userID, podcastID string) (bool, error) {
+	p, err := s.store.GetPodcast(ctx, podcastID)
+	if err != nil {
+		return false, err
+	}
+	return s.store.CanUserAccessLibrary(ctx, userID, p.LibraryID)
+}
+
 // GetUser returns the domain user by ID. Used for admin checks in search handlers.
 func (s *SearchService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
 	return s.store.GetUser(ctx, userID)
@@ -268,6 +298,24 @@ func (s *SearchService) ReindexAll(ctx context.Context) error {
 	}
 	s.logger.Info("indexed series", "count", len(seriesDocs))
 
+	// Index all podcasts
+	podcasts, err := s.store.ListPodcasts(ctx)
+	if err != nil {
+		return fmt.Errorf("list podcasts: %w", err)
+	}
+
+	podcastDocs := make([]*search.SearchDocument, 0, len(podcasts))
+	for _, p := range podcasts {
+		podcastDocs = append(podcastDocs, search.PodcastToSearchDocument(p))
+	}
+
+	if len(podcastDocs) > 0 {
+		if err := s.index.IndexDocuments(podcastDocs); err != nil {
+			return fmt.Errorf("index podcasts: %w", err)
+		}
+	}
+	s.logger.Info("indexed podcasts", "count", len(podcastDocs))
+
 	total, _ := s.index.DocumentCount()
 	s.logger.Info("full reindex complete", "total_documents", total)
 
@@ -337,6 +385,12 @@ func (s *SearchService) buildBookDocument(ctx context.Context, book *domain.Book
 	)
 	doc.Tags = tagSlugs
 
+	// Community rating for sorting and filtering
+	if summary, err := s.store.GetRatingSummary(ctx, book.ID); err == nil {
+		doc.Rating = summary.Average
+		doc.RatingCount = summary.Count
+	}
+
 	return doc, nil
 }
 
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/listenupapp/listenup-server/internal/domain"
 	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
 	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/sse"
 	"github.com/listenupapp/listenup-server/internal/store"
 )
 
@@ -23,6 +25,7 @@ type sessionServiceStore interface {
 type SessionService struct {
 	store        sessionServiceStore
 	tokenService *auth.TokenService
+	sseManager   *sse.Manager // Optional; nil disables session.revoked events
 	logger       *slog.Logger
 }
 
@@ -30,11 +33,13 @@ type SessionService struct {
 func NewSessionService(
 	store sessionServiceStore,
 	tokenService *auth.TokenService,
+	sseManager *sse.Manager,
 	logger *slog.Logger,
 ) *SessionService {
 	return &SessionService{
 		store:        store,
 		tokenService: tokenService,
+		sseManager:   sseManager,
 		logger:       logger,
 	}
 }
@@ -178,6 +183,90 @@ func (s *SessionService) ListUserSessions(ctx context.Context, userID string) ([
 	return sessions, nil
 }
 
+// RevokeSession ends one of a user's sessions and tells its client to sign out.
+// Returns NotFound if the session doesn't exist or belongs to someone else.
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID, reason string) error {
+	session, err := s.store.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return domainerrors.NotFound("session not found")
+		}
+		return fmt.Errorf("get session: %w", err)
+	}
+	if session.UserID != userID {
+		return domainerrors.NotFound("session not found")
+	}
+
+	if err := s.store.DeleteSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	s.emitRevoked(userID, []string{sessionID}, reason)
+
+	if s.logger != nil {
+		s.logger.Info("Session revoked", "user_id", userID, "session_id", sessionID)
+	}
+	return nil
+}
+
+// RevokeOtherSessions ends every session of a user except keepSessionID,
+// which must be one of theirs. Returns the number of sessions revoked.
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, userID, keepSessionID, reason string) (int, error) {
+	sessions, err := s.store.ListUserSessions(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("list user sessions: %w", err)
+	}
+
+	kept := false
+	for _, session := range sessions {
+		if session.ID == keepSessionID {
+			kept = true
+			break
+		}
+	}
+	if !kept {
+		// Guard against signing out the caller too because of a stale ID
+		return 0, domainerrors.Validation("current session not found")
+	}
+
+	return s.revokeSessions(ctx, userID, sessions, keepSessionID, reason)
+}
+
+// RevokeAllSessions ends every session of a user.
+// Returns the number of sessions revoked.
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userID, reason string) (int, error) {
+	sessions, err := s.store.ListUserSessions(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("list user sessions: %w", err)
+	}
+	return s.revokeSessions(ctx, userID, sessions, "", reason)
+}
+
+// AdminListUserSessions returns all sessions for any user.
+func (s *SessionService) AdminListUserSessions(ctx context.Context, targetUserID string) ([]*domain.Session, error) {
+	if _, err := s.getUser(ctx, targetUserID); err != nil {
+		return nil, err
+	}
+	return s.ListUserSessions(ctx, targetUserID)
+}
+
+// AdminRevokeSession ends one session of any user.
+// Only the root user can revoke the root user's sessions.
+func (s *SessionService) AdminRevokeSession(ctx context.Context, adminUserID, targetUserID, sessionID string) error {
+	if err := s.checkCanManageSessions(ctx, adminUserID, targetUserID); err != nil {
+		return err
+	}
+	return s.RevokeSession(ctx, targetUserID, sessionID, "Signed out by administrator")
+}
+
+// AdminRevokeAllSessions signs a user out of every device.
+// Only the root user can revoke the root user's sessions.
+func (s *SessionService) AdminRevokeAllSessions(ctx context.Context, adminUserID, targetUserID string) (int, error) {
+	if err := s.checkCanManageSessions(ctx, adminUserID, targetUserID); err != nil {
+		return 0, err
+	}
+	return s.RevokeAllSessions(ctx, targetUserID, "Signed out by administrator")
+}
+
 // DeleteExpiredSessions removes all expired sessions.
 // This should be run periodically as a cleanup job.
 func (s *SessionService) DeleteExpiredSessions(ctx context.Context) (int, error) {
@@ -193,6 +282,65 @@ func (s *SessionService) DeleteExpiredSessions(ctx context.Context) (int, error)
 	return count, nil
 }
 
+// revokeSessions deletes the given sessions, skipping keepSessionID, and
+// emits a single session.revoked event for the ones that went away.
+func (s *SessionService) revokeSessions(ctx context.Context, userID string, sessions []*domain.Session, keepSessionID, reason string) (int, error) {
+	revoked := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if session.ID == keepSessionID {
+			continue
+		}
+		if err := s.store.DeleteSession(ctx, session.ID); err != nil {
+			s.emitRevoked(userID, revoked, reason)
+			return len(revoked), fmt.Errorf("delete session %s: %w", session.ID, err)
+		}
+		revoked = append(revoked, session.ID)
+	}
+	s.emitRevoked(userID, revoked, reason)
+
+	if s.logger != nil && len(revoked) > 0 {
+		s.logger.Info("Sessions revoked", "user_id", userID, "count", len(revoked))
+	}
+	return len(revoked), nil
+}
+
+// emitRevoked notifies the user's clients that sessions were revoked.
+func (s *SessionService) emitRevoked(userID string, sessionIDs []string, reason string) {
+	if s.sseManager == nil || len(sessionIDs) == 0 {
+		return
+	}
+	s.sseManager.Emit(sse.NewSessionRevokedEvent(userID, sessionIDs, reason))
+}
+
+// checkCanManageSessions verifies the target user exists and that a
+// non-root admin isn't touching the root user's sessions.
+func (s *SessionService) checkCanManageSessions(ctx context.Context, adminUserID, targetUserID string) error {
+	admin, err := s.store.GetUser(ctx, adminUserID)
+	if err != nil {
+		return fmt.Errorf("get admin: %w", err)
+	}
+	user, err := s.getUser(ctx, targetUserID)
+	if err != nil {
+		return err
+	}
+	if user.IsRoot && !admin.IsRoot {
+		return domainerrors.Forbidden("only the root user can manage the root user's sessions")
+	}
+	return nil
+}
+
+// getUser loads a user, mapping a missing user to NotFound.
+func (s *SessionService) getUser(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, domainerrors.NotFound("user not found")
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return user, nil
+}
+
 // SessionResponse contains session tokens and metadata.
 type SessionResponse struct {
 	AccessToken  string `json:"access_token"`
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+func setupSessionTest(t *testing.T) (*SessionService, *sqlite.Store, *sse.Manager) {
+	t.Helper()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	key, err := auth.LoadOrGenerateKey(t.TempDir())
+	require.NoError(t, err)
+	tokenService, err := auth.NewTokenService(hex.EncodeToString(key), 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	logger := slog.New(slog.DiscardHandler)
+	manager := sse.NewManager(logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go manager.Start(ctx)
+
+	return NewSessionService(s, tokenService, manager, logger), s, manager
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc, s, manager := setupSessionTest(t)
+	user := createTestUserWithPermissions(t, s, "user@example.com", true)
+	other := createTestUserWithPermissions(t, s, "other@example.com", true)
+
+	device := auth.DeviceInfo{DeviceType: "mobile", Platform: "iOS"}
+	phone, err := svc.CreateSession(ctx, user, device, "10.0.0.1")
+	require.NoError(t, err)
+	tablet, err := svc.CreateSession(ctx, user, device, "10.0.0.2")
+	require.NoError(t, err)
+	laptop, err := svc.CreateSession(ctx, user, device, "10.0.0.3")
+	require.NoError(t, err)
+
+	client, err := manager.Connect(user.ID, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { manager.Disconnect(client.ID) })
+
+	// Someone else's session looks like a missing one.
+	assert.ErrorIs(t, svc.RevokeSession(ctx, other.ID, tablet.SessionID, ""), domainerrors.NotFound(""))
+
+	require.NoError(t, svc.RevokeSession(ctx, user.ID, tablet.SessionID, "Signed out"))
+	evt := nextEvent(t, client, sse.EventSessionRevoked)
+	assert.Equal(t, []string{tablet.SessionID}, evt.Data.(sse.SessionRevokedEventData).SessionIDs)
+
+	// A stale current session ID doesn't sign the caller out too.
+	_, err = svc.RevokeOtherSessions(ctx, user.ID, tablet.SessionID, "")
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+
+	count, err := svc.RevokeOtherSessions(ctx, user.ID, phone.SessionID, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	evt = nextEvent(t, client, sse.EventSessionRevoked)
+	assert.Equal(t, []string{laptop.SessionID}, evt.Data.(sse.SessionRevokedEventData).SessionIDs)
+
+	sessions, err := svc.ListUserSessions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, phone.SessionID, sessions[0].ID)
+}
+
+func TestSessionService_AdminRules(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc, s, _ := setupSessionTest(t)
+	admin := createTestUserWithPermissions(t, s, "admin@example.com", true)
+	root := createTestUserWithPermissions(t, s, "root@example.com", true)
+	root.IsRoot = true
+	require.NoError(t, s.UpdateUser(ctx, root))
+
+	_, err := svc.CreateSession(ctx, root, auth.DeviceInfo{DeviceType: "web", Platform: "Web"}, "")
+	require.NoError(t, err)
+
+	_, err = svc.AdminListUserSessions(ctx, "user_missing")
+	assert.ErrorIs(t, err, domainerrors.NotFound(""))
+
+	_, err = svc.AdminRevokeAllSessions(ctx, admin.ID, root.ID)
+	assert.ErrorIs(t, err, domainerrors.Forbidden(""), "non-root admin revoking root")
+
+	count, err := svc.AdminRevokeAllSessions(ctx, root.ID, root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+// nextEvent waits for the next event of the given type delivered to a client.
+func nextEvent(t *testing.T, client *sse.Client, eventType sse.EventType) sse.Event {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case evt := <-client.EventChan:
+			if evt.Type == eventType {
+				return evt
+			}
+		case <-timeout:
+			t.Fatalf("no %s event received", eventType)
+			return sse.Event{}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
 	"github.com/listenupapp/listenup-server/internal/store"
 )
 
@@ -35,6 +36,76 @@ func (s *SettingsService) GetServerSettings(ctx context.Context) (*domain.Server
 type SettingsUpdate struct {
 	Name         *string
 	InboxEnabled *bool
+	Backup       *BackupScheduleUpdate
+
+	// TrashRetentionDays sets how long deleted books stay restorable (0 = forever).
+	TrashRetentionDays *int
+
+	// RequireSignedStreamURLs rejects access tokens in audio URL query strings.
+	RequireSignedStreamURLs *bool
+
+	// RequireAdminTwoFactor makes two-factor authentication mandatory for admins.
+	RequireAdminTwoFactor *bool
+}
+
+// BackupScheduleUpdate contains backup schedule fields that can be updated.
+// Nil fields are left unchanged.
+type BackupScheduleUpdate struct {
+	Enabled       *bool
+	IntervalHours *int
+	IncludeImages *bool
+	IncludeEvents *bool
+	KeepLast      *int
+	KeepDaily     *int
+	KeepWeekly    *int
+	KeepMonthly   *int
+}
+
+// Limits for the backup schedule.
+const (
+	maxBackupIntervalHours = 24 * 30
+	maxBackupKeep          = 1000
+	maxTrashRetentionDays  = 365
+)
+
+// validate checks the update against the backup schedule limits.
+func (u *BackupScheduleUpdate) validate() error {
+	if u.IntervalHours != nil && (*u.IntervalHours < 1 || *u.IntervalHours > maxBackupIntervalHours) {
+		return domainerrors.Validationf("backup interval must be between 1 and %d hours", maxBackupIntervalHours)
+	}
+	keeps := []struct {
+		name  string
+		value *int
+	}{
+		{"keep_last", u.KeepLast},
+		{"keep_daily", u.KeepDaily},
+		{"keep_weekly", u.KeepWeekly},
+		{"keep_monthly", u.KeepMonthly},
+	}
+	for _, k := range keeps {
+		if k.value != nil && (*k.value < 0 || *k.value > maxBackupKeep) {
+			return domainerrors.Validationf("%s must be between 0 and %d", k.name, maxBackupKeep)
+		}
+	}
+	return nil
+}
+
+// apply copies the set fields onto the schedule.
+func (u *BackupScheduleUpdate) apply(schedule *domain.BackupSchedule) {
+	setIfNotNil(&schedule.Enabled, u.Enabled)
+	setIfNotNil(&schedule.IntervalHours, u.IntervalHours)
+	setIfNotNil(&schedule.IncludeImages, u.IncludeImages)
+	setIfNotNil(&schedule.IncludeEvents, u.IncludeEvents)
+	setIfNotNil(&schedule.KeepLast, u.KeepLast)
+	setIfNotNil(&schedule.KeepDaily, u.KeepDaily)
+	setIfNotNil(&schedule.KeepWeekly, u.KeepWeekly)
+	setIfNotNil(&schedule.KeepMonthly, u.KeepMonthly)
+}
+
+func setIfNotNil[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
 }
 
 // UpdateServerSettings updates server-wide settings.
@@ -44,6 +115,15 @@ func (s *SettingsService) UpdateServerSettings(ctx context.Context, update *Sett
 		return nil, err
 	}
 
+	if update.Backup != nil {
+		if err := update.Backup.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if d := update.TrashRetentionDays; d != nil && (*d < 0 || *d > maxTrashRetentionDays) {
+		return nil, domainerrors.Validationf("trash retention must be between 0 and %d days", maxTrashRetentionDays)
+	}
+
 	current, err := s.store.GetServerSettings(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get current settings: %w", err)
@@ -86,6 +166,12 @@ func (s *SettingsService) UpdateServerSettings(ctx context.Context, update *Sett
 	if update.InboxEnabled != nil {
 		current.InboxEnabled = *update.InboxEnabled
 	}
+	if update.Backup != nil {
+		update.Backup.apply(&current.Backup)
+	}
+	setIfNotNil(&current.TrashRetentionDays, update.TrashRetentionDays)
+	setIfNotNil(&current.RequireSignedStreamURLs, update.RequireSignedStreamURLs)
+	setIfNotNil(&current.RequireAdminTwoFactor, update.RequireAdminTwoFactor)
 	current.UpdatedAt = time.Now()
 
 	if err := s.store.UpdateServerSettings(ctx, current); err != nil {
@@ -95,6 +181,9 @@ func (s *SettingsService) UpdateServerSettings(ctx context.Context, update *Sett
 	s.logger.Info("server settings updated",
 		"name", current.Name,
 		"inbox_enabled", current.InboxEnabled,
+		"backup_enabled", current.Backup.Enabled,
+		"require_signed_stream_urls", current.RequireSignedStreamURLs,
+		"require_admin_two_factor", current.RequireAdminTwoFactor,
 	)
 
 	return current, nil
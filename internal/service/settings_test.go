@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestBackupScheduleUpdate_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		update  BackupScheduleUpdate
+		wantErr bool
+	}{
+		{"empty", BackupScheduleUpdate{}, false},
+		{"valid", BackupScheduleUpdate{IntervalHours: intPtr(6), KeepLast: intPtr(0), KeepMonthly: intPtr(12)}, false},
+		{"zero interval", BackupScheduleUpdate{IntervalHours: intPtr(0)}, true},
+		{"interval too long", BackupScheduleUpdate{IntervalHours: intPtr(maxBackupIntervalHours + 1)}, true},
+		{"negative keep", BackupScheduleUpdate{KeepDaily: intPtr(-1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.update.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBackupScheduleUpdate_ApplyLeavesUnsetFields(t *testing.T) {
+	t.Parallel()
+	schedule := domain.DefaultBackupSchedule()
+	enabled := true
+
+	(&BackupScheduleUpdate{Enabled: &enabled, KeepLast: intPtr(10)}).apply(&schedule)
+
+	assert.True(t, schedule.Enabled)
+	assert.Equal(t, 10, schedule.KeepLast)
+	assert.Equal(t, domain.DefaultBackupIntervalHours, schedule.IntervalHours)
+	assert.Equal(t, domain.DefaultBackupKeepDaily, schedule.KeepDaily)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
@@ -25,6 +26,11 @@ type shelfServiceStore interface {
 	store.UserStore
 	store.CollectionStore
 	store.BookStore
+	store.GenreStore
+	store.TagStore
+	store.ContributorStore
+	store.SeriesStore
+	store.ListeningStore
 }
 
 // ShelfService orchestrates shelf operations with ownership enforcement and SSE events.
@@ -33,6 +39,11 @@ type ShelfService struct {
 	sseManager       *sse.Manager
 	logger           *slog.Logger
 	activityRecorder ShelfActivityRecorder
+
+	// Last known membership of each smart shelf (owner's view), used to
+	// emit shelf.updated when rule matches change.
+	membershipMu sync.Mutex
+	membership   map[string]uint64
 }
 
 // NewShelfService creates a new shelf service.
@@ -41,6 +52,7 @@ func NewShelfService(store shelfServiceStore, sseManager *sse.Manager, logger *s
 		store:      store,
 		sseManager: sseManager,
 		logger:     logger,
+		membership: make(map[string]uint64),
 	}
 }
 
@@ -51,7 +63,9 @@ func (s *ShelfService) SetActivityRecorder(recorder ShelfActivityRecorder) {
 }
 
 // CreateShelf creates a new shelf for the user.
-func (s *ShelfService) CreateShelf(ctx context.Context, ownerID, name, description string) (*domain.Shelf, error) {
+// Passing rules creates a smart shelf, whose books are matched by the rules
+// instead of added by hand.
+func (s *ShelfService) CreateShelf(ctx context.Context, ownerID, name, description string, rules *domain.ShelfRules) (*domain.Shelf, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -60,6 +74,11 @@ func (s *ShelfService) CreateShelf(ctx context.Context, ownerID, name, descripti
 	if name == "" {
 		return nil, domainerrors.Validation("shelf name cannot be empty")
 	}
+	if rules != nil {
+		if err := validateShelfRules(rules); err != nil {
+			return nil, err
+		}
+	}
 
 	// Generate shelf ID
 	shelfID, err := id.Generate("shelf")
@@ -74,6 +93,7 @@ func (s *ShelfService) CreateShelf(ctx context.Context, ownerID, name, descripti
 		Name:        name,
 		Description: description,
 		BookIDs:     []string{},
+		Rules:       rules,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -82,10 +102,15 @@ func (s *ShelfService) CreateShelf(ctx context.Context, ownerID, name, descripti
 		return nil, fmt.Errorf("create shelf: %w", err)
 	}
 
+	if err := s.resolveShelf(ctx, shelf, ownerID); err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("shelf created",
 		"shelf_id", shelfID,
 		"owner_id", ownerID,
 		"name", name,
+		"smart", shelf.IsSmart(),
 	)
 
 	// Emit SSE event
@@ -107,14 +132,23 @@ func (s *ShelfService) CreateShelf(ctx context.Context, ownerID, name, descripti
 	return shelf, nil
 }
 
-// GetShelf retrieves a shelf by ID.
-func (s *ShelfService) GetShelf(ctx context.Context, id string) (*domain.Shelf, error) {
-	return s.store.GetShelf(ctx, id)
+// GetShelf retrieves a shelf by ID. A smart shelf's books are the ones
+// matching its rules that viewerID can access.
+func (s *ShelfService) GetShelf(ctx context.Context, viewerID, id string) (*domain.Shelf, error) {
+	shelf, err := s.store.GetShelf(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveShelf(ctx, shelf, viewerID); err != nil {
+		return nil, err
+	}
+	return shelf, nil
 }
 
-// UpdateShelf updates shelf metadata.
-// Requires ownership.
-func (s *ShelfService) UpdateShelf(ctx context.Context, userID, shelfID, name, description string) (*domain.Shelf, error) {
+// UpdateShelf updates shelf metadata, and for smart shelves, the rules.
+// Nil rules leave the current rules unchanged. A manual shelf cannot be
+// given rules. Requires ownership.
+func (s *ShelfService) UpdateShelf(ctx context.Context, userID, shelfID, name, description string, rules *domain.ShelfRules) (*domain.Shelf, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -133,6 +167,15 @@ func (s *ShelfService) UpdateShelf(ctx context.Context, userID, shelfID, name, d
 	if name == "" {
 		return nil, domainerrors.Validation("shelf name cannot be empty")
 	}
+	if rules != nil {
+		if !shelf.IsSmart() {
+			return nil, domainerrors.Validation("rules can only be set on smart shelves")
+		}
+		if err := validateShelfRules(rules); err != nil {
+			return nil, err
+		}
+		shelf.Rules = rules
+	}
 
 	// Update fields
 	shelf.Name = name
@@ -143,6 +186,13 @@ func (s *ShelfService) UpdateShelf(ctx context.Context, userID, shelfID, name, d
 		return nil, fmt.Errorf("update shelf: %w", err)
 	}
 
+	// The update event below already carries the new membership; re-seed the
+	// cache instead of emitting a second event for it.
+	s.forgetMembership(shelfID)
+	if err := s.resolveShelf(ctx, shelf, userID); err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("shelf updated",
 		"shelf_id", shelfID,
 		"user_id", userID,
@@ -179,6 +229,7 @@ func (s *ShelfService) DeleteShelf(ctx context.Context, userID, shelfID string)
 	if err := s.store.DeleteShelf(ctx, shelfID); err != nil {
 		return fmt.Errorf("delete shelf: %w", err)
 	}
+	s.forgetMembership(shelfID)
 
 	s.logger.Info("shelf deleted",
 		"shelf_id", shelfID,
@@ -193,7 +244,16 @@ func (s *ShelfService) DeleteShelf(ctx context.Context, userID, shelfID string)
 
 // ListMyShelves returns all shelves owned by the user.
 func (s *ShelfService) ListMyShelves(ctx context.Context, ownerID string) ([]*domain.Shelf, error) {
-	return s.store.ListShelvesByOwner(ctx, ownerID)
+	shelves, err := s.store.ListShelvesByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, shelf := range shelves {
+		if err := s.resolveShelf(ctx, shelf, ownerID); err != nil {
+			return nil, err
+		}
+	}
+	return shelves, nil
 }
 
 // ListDiscoverShelves returns shelves from other users that contain books the requesting user can access.
@@ -211,12 +271,36 @@ func (s *ShelfService) ListDiscoverShelves(ctx context.Context, userID string) (
 
 	result := make(map[string][]*domain.Shelf)
 
+	// Smart shelves are evaluated against the viewer's library.
+	var accessible map[string]bool
+
 	for _, shelf := range allShelves {
 		// Skip user's own shelves
 		if shelf.OwnerID == userID {
 			continue
 		}
 
+		if shelf.IsSmart() {
+			if accessible == nil {
+				accessible, err = s.store.GetAccessibleBookIDSet(ctx, userID)
+				if err != nil {
+					return nil, fmt.Errorf("get accessible books: %w", err)
+				}
+			}
+			shelf.BookIDs, err = s.evaluateRules(ctx, shelf, accessible)
+			if err != nil {
+				s.logger.Warn("failed to evaluate smart shelf",
+					"shelf_id", shelf.ID,
+					"error", err,
+				)
+				continue
+			}
+			if len(shelf.BookIDs) > 0 {
+				result[shelf.OwnerID] = append(result[shelf.OwnerID], shelf)
+			}
+			continue
+		}
+
 		// Check if user can access at least one book in the shelf
 		canSeeAnyBook := false
 		for _, bookID := range shelf.BookIDs {
@@ -260,6 +344,10 @@ func (s *ShelfService) AddBookToShelf(ctx context.Context, userID, shelfID, book
 		return domainerrors.Forbidden("you do not own this shelf")
 	}
 
+	if shelf.IsSmart() {
+		return domainerrors.Validation("books cannot be added to a smart shelf by hand; edit its rules instead")
+	}
+
 	// Check if book already in shelf (no-op if so)
 	if shelf.ContainsBook(bookID) {
 		return nil
@@ -305,6 +393,10 @@ func (s *ShelfService) RemoveBookFromShelf(ctx context.Context, userID, shelfID,
 		return domainerrors.Forbidden("you do not own this shelf")
 	}
 
+	if shelf.IsSmart() {
+		return domainerrors.Validation("books cannot be removed from a smart shelf by hand; edit its rules instead")
+	}
+
 	// Check if book in shelf (no-op if not)
 	if !shelf.ContainsBook(bookID) {
 		return nil
@@ -353,13 +445,34 @@ func (s *ShelfService) GetShelvesForBook(ctx context.Context, userID, bookID str
 		}
 	}
 
+	// Smart shelves have no stored books; check the user's against their rules.
+	owned, err := s.store.ListShelvesByOwner(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list shelves: %w", err)
+	}
+	for _, shelf := range owned {
+		if !shelf.IsSmart() {
+			continue
+		}
+		if err := s.resolveShelf(ctx, shelf, userID); err != nil {
+			s.logger.Warn("failed to evaluate smart shelf",
+				"shelf_id", shelf.ID,
+				"error", err,
+			)
+			continue
+		}
+		if shelf.ContainsBook(bookID) {
+			userShelves = append(userShelves, shelf)
+		}
+	}
+
 	return userShelves, nil
 }
 
 // CreateDefaultShelf creates a default "To Read" shelf for a new user.
 // This is a best-effort operation that logs but doesn't fail registration.
 func (s *ShelfService) CreateDefaultShelf(ctx context.Context, userID string) error {
-	_, err := s.CreateShelf(ctx, userID, "To Read", "")
+	_, err := s.CreateShelf(ctx, userID, "To Read", "", nil)
 	if err != nil {
 		s.logger.Warn("failed to create default shelf for user",
 			"user_id", userID,
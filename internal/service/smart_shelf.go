@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// Limits on smart shelf rules, to keep evaluation cheap.
+const (
+	maxShelfRuleEntries = 50
+	maxAddedWithinDays  = 3650
+	minShelfRuleYear    = 1000
+	maxShelfRuleYear    = 9999
+)
+
+// validateShelfRules checks a smart shelf's rules before they are saved.
+func validateShelfRules(rules *domain.ShelfRules) error {
+	if rules.IsEmpty() {
+		return domainerrors.Validation("a smart shelf needs at least one rule")
+	}
+
+	lists := map[string][]string{
+		"genre_ids":       rules.GenreIDs,
+		"tag_slugs":       rules.TagSlugs,
+		"contributor_ids": rules.ContributorIDs,
+		"narrator_ids":    rules.NarratorIDs,
+		"series_ids":      rules.SeriesIDs,
+	}
+	for name, ids := range lists {
+		if len(ids) > maxShelfRuleEntries {
+			return domainerrors.Validationf("%s: at most %d entries allowed", name, maxShelfRuleEntries)
+		}
+		if slices.Contains(ids, "") {
+			return domainerrors.Validationf("%s: entries cannot be empty", name)
+		}
+	}
+
+	if rules.MinDurationMs < 0 || rules.MaxDurationMs < 0 {
+		return domainerrors.Validation("duration bounds cannot be negative")
+	}
+	if rules.MaxDurationMs > 0 && rules.MinDurationMs > rules.MaxDurationMs {
+		return domainerrors.Validation("min_duration_ms cannot be greater than max_duration_ms")
+	}
+
+	for _, year := range []int{rules.MinYear, rules.MaxYear} {
+		if year != 0 && (year < minShelfRuleYear || year > maxShelfRuleYear) {
+			return domainerrors.Validationf("publish year %d is out of range", year)
+		}
+	}
+	if rules.MaxYear > 0 && rules.MinYear > rules.MaxYear {
+		return domainerrors.Validation("min_year cannot be greater than max_year")
+	}
+
+	if !rules.Progress.Valid() {
+		return domainerrors.Validationf("unknown progress %q", rules.Progress)
+	}
+
+	if rules.AddedWithinDays < 0 || rules.AddedWithinDays > maxAddedWithinDays {
+		return domainerrors.Validationf("added_within_days must be between 0 and %d", maxAddedWithinDays)
+	}
+
+	return nil
+}
+
+// evaluateRules returns the books matching a smart shelf's rules, most
+// recently added first, limited to the accessible set. Progress is always
+// the shelf owner's, whoever is looking at the shelf.
+func (s *ShelfService) evaluateRules(ctx context.Context, shelf *domain.Shelf, accessible map[string]bool) ([]string, error) {
+	rules := shelf.Rules
+
+	candidates, err := s.store.FilterBookIDs(ctx, store.BookFilter{
+		MinDurationMs: rules.MinDurationMs,
+		MaxDurationMs: rules.MaxDurationMs,
+		MinYear:       rules.MinYear,
+		MaxYear:       rules.MaxYear,
+		CreatedAfter:  rules.AddedSince(time.Now()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("filter books: %w", err)
+	}
+
+	bookIDs := candidates[:0]
+	for _, bookID := range candidates {
+		if accessible[bookID] {
+			bookIDs = append(bookIDs, bookID)
+		}
+	}
+
+	listRules := []struct {
+		ids     []string
+		resolve func(ctx context.Context, id string) ([]string, error)
+	}{
+		{rules.GenreIDs, s.store.GetBookIDsForGenreTree},
+		{rules.TagSlugs, s.bookIDsForTagSlug},
+		{rules.ContributorIDs, s.store.GetBookIDsByContributor},
+		{rules.NarratorIDs, s.bookIDsForNarrator},
+		{rules.SeriesIDs, s.store.GetBookIDsBySeries},
+	}
+	for _, rule := range listRules {
+		if len(rule.ids) == 0 || len(bookIDs) == 0 {
+			continue
+		}
+		matches := make(map[string]bool)
+		for _, id := range rule.ids {
+			ids, err := rule.resolve(ctx, id)
+			if errors.Is(err, store.ErrNotFound) {
+				// The genre, tag, etc. was deleted after the rule was saved.
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("resolve rule %s: %w", id, err)
+			}
+			for _, bookID := range ids {
+				matches[bookID] = true
+			}
+		}
+		bookIDs = slices.DeleteFunc(bookIDs, func(bookID string) bool { return !matches[bookID] })
+	}
+
+	if rules.Progress != domain.ShelfProgressAny && len(bookIDs) > 0 {
+		states, err := s.store.GetStateForUser(ctx, shelf.OwnerID)
+		if err != nil {
+			return nil, fmt.Errorf("get playback state: %w", err)
+		}
+		stateByBook := make(map[string]*domain.PlaybackState, len(states))
+		for _, state := range states {
+			stateByBook[state.BookID] = state
+		}
+		bookIDs = slices.DeleteFunc(bookIDs, func(bookID string) bool {
+			return !rules.MatchesProgress(stateByBook[bookID])
+		})
+	}
+
+	return bookIDs, nil
+}
+
+func (s *ShelfService) bookIDsForTagSlug(ctx context.Context, slug string) ([]string, error) {
+	tag, err := s.store.GetTagBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetBookIDsForTag(ctx, tag.ID)
+}
+
+func (s *ShelfService) bookIDsForNarrator(ctx context.Context, contributorID string) ([]string, error) {
+	books, err := s.store.GetBooksByContributorRole(ctx, contributorID, domain.RoleNarrator)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(books))
+	for i, b := range books {
+		ids[i] = b.ID
+	}
+	return ids, nil
+}
+
+// resolveShelf fills in a smart shelf's BookIDs as seen by viewerID.
+// Manual shelves are left untouched.
+func (s *ShelfService) resolveShelf(ctx context.Context, shelf *domain.Shelf, viewerID string) error {
+	if !shelf.IsSmart() {
+		return nil
+	}
+
+	accessible, err := s.store.GetAccessibleBookIDSet(ctx, viewerID)
+	if err != nil {
+		return fmt.Errorf("get accessible books: %w", err)
+	}
+
+	shelf.BookIDs, err = s.evaluateRules(ctx, shelf, accessible)
+	if err != nil {
+		return fmt.Errorf("evaluate shelf %s: %w", shelf.ID, err)
+	}
+
+	if viewerID == shelf.OwnerID {
+		s.noteMembership(ctx, shelf)
+	}
+	return nil
+}
+
+// noteMembership remembers the owner's view of a smart shelf and emits
+// shelf.updated when it differs from the last one seen. The first sighting
+// after startup only seeds the cache.
+func (s *ShelfService) noteMembership(ctx context.Context, shelf *domain.Shelf) {
+	h := fnv.New64a()
+	for _, bookID := range shelf.BookIDs {
+		h.Write([]byte(bookID))
+		h.Write([]byte{0})
+	}
+	fingerprint := h.Sum64()
+
+	s.membershipMu.Lock()
+	previous, seen := s.membership[shelf.ID]
+	s.membership[shelf.ID] = fingerprint
+	s.membershipMu.Unlock()
+
+	if !seen || previous == fingerprint {
+		return
+	}
+
+	s.logger.Debug("smart shelf membership changed",
+		"shelf_id", shelf.ID,
+		"book_count", len(shelf.BookIDs),
+	)
+
+	owner, _ := s.store.GetUser(ctx, shelf.OwnerID)
+	displayName, avatarColor := s.getOwnerInfo(owner)
+	s.sseManager.Emit(sse.NewShelfUpdatedEvent(shelf, displayName, avatarColor))
+}
+
+// forgetMembership drops a deleted shelf from the membership cache.
+func (s *ShelfService) forgetMembership(shelfID string) {
+	s.membershipMu.Lock()
+	delete(s.membership, shelfID)
+	s.membershipMu.Unlock()
+}
+
+// RefreshSmartShelves re-evaluates every smart shelf for its owner and emits
+// shelf.updated for those whose books changed since the last evaluation.
+// Books come and go from smart shelves without anyone touching the shelf
+// (a scan adds a book, a listener finishes one), so this runs periodically.
+func (s *ShelfService) RefreshSmartShelves(ctx context.Context) (int, error) {
+	shelves, err := s.store.ListAllShelves(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list all shelves: %w", err)
+	}
+
+	accessibleByOwner := make(map[string]map[string]bool)
+	refreshed := 0
+	for _, shelf := range shelves {
+		if !shelf.IsSmart() {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return refreshed, err
+		}
+
+		accessible, ok := accessibleByOwner[shelf.OwnerID]
+		if !ok {
+			accessible, err = s.store.GetAccessibleBookIDSet(ctx, shelf.OwnerID)
+			if err != nil {
+				s.logger.Warn("failed to get accessible books for smart shelf owner",
+					"owner_id", shelf.OwnerID,
+					"error", err,
+				)
+				continue
+			}
+			accessibleByOwner[shelf.OwnerID] = accessible
+		}
+
+		shelf.BookIDs, err = s.evaluateRules(ctx, shelf, accessible)
+		if err != nil {
+			s.logger.Warn("failed to evaluate smart shelf",
+				"shelf_id", shelf.ID,
+				"error", err,
+			)
+			continue
+		}
+		s.noteMembership(ctx, shelf)
+		refreshed++
+	}
+
+	return refreshed, nil
+}
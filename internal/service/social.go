@@ -111,18 +111,24 @@ func (s *SocialService) GetLeaderboard(
 		}
 	}
 
-	// Batch fetch all user profiles (avoids N+1 per-user lookups)
+	// Batch fetch all user profiles (avoids N+1 per-user lookups).
+	// Privacy settings live on the profile, so a failure here is fatal
+	// rather than ranking users who asked not to be.
 	allUserIDs := make([]string, len(users))
 	for i, u := range users {
 		allUserIDs[i] = u.ID
 	}
 	profilesByID, err := s.store.GetUserProfilesByIDs(ctx, allUserIDs)
 	if err != nil {
-		s.logger.Warn("failed to batch fetch profiles, falling back to per-user", "error", err)
-		profilesByID = make(map[string]*domain.UserProfile)
+		return nil, fmt.Errorf("fetching profiles: %w", err)
 	}
 
 	for _, user := range users {
+		// Users who opted out are left off everyone else's leaderboard and community totals
+		if profile := profilesByID[user.ID]; profile != nil && profile.HideFromLeaderboard && user.ID != viewingUserID {
+			continue
+		}
+
 		var totalTimeMs int64
 		var booksFinished int
 
@@ -316,7 +322,7 @@ func (s *SocialService) GetLeaderboard(
 		Category:               category,
 		Period:                 period,
 		Entries:                entries,
-		TotalUsers:             len(users),
+		TotalUsers:             len(stats),
 		CommunityTotalTimeMs:   communityTotalTimeMs,
 		CommunityTotalBooks:    communityTotalBooks,
 		CommunityAverageStreak: avgStreak,
@@ -489,8 +495,9 @@ func (s *SocialService) GetCurrentlyListening(ctx context.Context, viewingUserID
 		readers []ReaderInfo
 	}
 	bookReadersMap := make(map[string]*bookReaders)
+	privacy := newSocialPrivacy(s.store)
 
-	var excludedSelf, excludedACL int
+	var excludedSelf, excludedACL, excludedPrivate int
 	for _, session := range activeSessions {
 		// Exclude viewing user's sessions
 		if session.UserID == viewingUserID {
@@ -504,6 +511,11 @@ func (s *SocialService) GetCurrentlyListening(ctx context.Context, viewingUserID
 			s.logger.Debug("excluding ACL session", "session_id", session.ID, "book_id", session.BookID, "user_id", session.UserID)
 			continue
 		}
+		// Privacy filter
+		if privacy.hidesCurrentlyListening(ctx, session.UserID, session.BookID) {
+			excludedPrivate++
+			continue
+		}
 		s.logger.Debug("including session", "session_id", session.ID, "book_id", session.BookID, "user_id", session.UserID)
 
 		// Get or create entry
@@ -520,14 +532,10 @@ func (s *SocialService) GetCurrentlyListening(ctx context.Context, viewingUserID
 			continue
 		}
 
-		// Get profile for avatar info (optional - may not exist)
-		avatarType := string(domain.AvatarTypeAuto)
-		avatarValue := ""
-		profile, err := s.store.GetUserProfile(ctx, session.UserID)
-		if err == nil && profile != nil {
-			avatarType = string(profile.AvatarType)
-			avatarValue = profile.AvatarValue
-		}
+		// Avatar info comes from the profile the privacy check already loaded
+		profile := privacy.profile(ctx, session.UserID)
+		avatarType := string(profile.AvatarType)
+		avatarValue := profile.AvatarValue
 
 		br.readers = append(br.readers, ReaderInfo{
 			UserID:      user.ID,
@@ -573,6 +581,7 @@ func (s *SocialService) GetCurrentlyListening(ctx context.Context, viewingUserID
 	s.logger.Info("GetCurrentlyListening result",
 		"excluded_self", excludedSelf,
 		"excluded_acl", excludedACL,
+		"excluded_private", excludedPrivate,
 		"result_books", len(books))
 
 	return books, nil
@@ -173,3 +173,61 @@ func TestSocialService_GetCurrentlyListening_ExcludesCompletedSessions(t *testin
 	require.NoError(t, err)
 	assert.Empty(t, result, "Completed sessions should not appear")
 }
+
+func TestSocialService_GetCurrentlyListening_RespectsPrivacy(t *testing.T) {
+	svc, s, cleanup := setupTestSocialService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	createTestUserForSocial(t, s, "user-1", "User One", domain.RoleAdmin)
+	createTestUserForSocial(t, s, "user-2", "User Two", domain.RoleMember)
+	createTestUserForSocial(t, s, "user-3", "User Three", domain.RoleMember)
+	createTestUserForSocial(t, s, "user-4", "User Four", domain.RoleMember)
+	createTestBookForSocial(t, s, "book-1", "Book One")
+
+	for _, userID := range []string{"user-2", "user-3", "user-4"} {
+		session := domain.NewBookReadingSession("session-"+userID, userID, "book-1")
+		require.NoError(t, s.CreateReadingSession(ctx, session))
+	}
+
+	// user-2 hides what they're listening to, user-3 listens to book-1 privately
+	profile := domain.NewUserProfile("user-2")
+	profile.HideCurrentlyListening = true
+	require.NoError(t, s.SaveUserProfile(ctx, profile))
+
+	prefs := domain.NewBookPreferences("user-3", "book-1")
+	prefs.PrivateListening = true
+	require.NoError(t, s.UpsertBookPreferences(ctx, prefs))
+
+	result, err := svc.GetCurrentlyListening(ctx, "user-1", 10)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, 1, result[0].TotalReaderCount)
+	assert.Equal(t, "user-4", result[0].Readers[0].UserID)
+}
+
+func TestSocialService_GetLeaderboard_HidesOptedOutUsers(t *testing.T) {
+	svc, s, cleanup := setupTestSocialService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	createTestUserForSocial(t, s, "user-1", "User One", domain.RoleAdmin)
+	createTestUserForSocial(t, s, "user-2", "User Two", domain.RoleMember)
+
+	profile := domain.NewUserProfile("user-2")
+	profile.HideFromLeaderboard = true
+	require.NoError(t, s.SaveUserProfile(ctx, profile))
+
+	board, err := svc.GetLeaderboard(ctx, "user-1", domain.StatsPeriodWeek, domain.LeaderboardCategoryTime, 10)
+	require.NoError(t, err)
+	require.Len(t, board.Entries, 1)
+	assert.Equal(t, "user-1", board.Entries[0].UserID)
+	assert.Equal(t, 1, board.TotalUsers)
+
+	// Users still see themselves
+	board, err = svc.GetLeaderboard(ctx, "user-2", domain.StatsPeriodWeek, domain.LeaderboardCategoryTime, 10)
+	require.NoError(t, err)
+	assert.Len(t, board.Entries, 2)
+}
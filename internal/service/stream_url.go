@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// Stream URL lifetimes. Players re-request the URL on every seek, so a URL
+// has to outlive a listening session; clients call prepare again when one
+// runs out.
+const (
+	DefaultStreamURLTTL = 2 * time.Hour
+	MinStreamURLTTL     = time.Minute
+	MaxStreamURLTTL     = 24 * time.Hour
+)
+
+// streamURLStore is the narrow store interface StreamURLService depends on.
+type streamURLStore interface {
+	store.BookStore
+	store.CollectionStore
+	store.SettingsStore
+}
+
+// StreamURLGrant is a signature covering some of a book's audio files.
+type StreamURLGrant struct {
+	Signature string
+	BookID    string
+	FileIDs   []string // Files the signature covers, in playback order
+	ExpiresAt time.Time
+}
+
+// StreamURLService mints and checks the signed, short-lived URLs audio is
+// streamed from, so players never hold the user's access token.
+type StreamURLService struct {
+	store  streamURLStore
+	signer *auth.StreamSigner
+	logger *slog.Logger
+}
+
+// NewStreamURLService creates a new stream URL service.
+func NewStreamURLService(store streamURLStore, signer *auth.StreamSigner, logger *slog.Logger) *StreamURLService {
+	return &StreamURLService{
+		store:  store,
+		signer: signer,
+		logger: logger,
+	}
+}
+
+// Sign mints a signature for one of the user's books, or for one audio file
+// of it when fileID is set. A zero ttl uses DefaultStreamURLTTL. A non-empty
+// clientIP binds the signature to that address.
+func (s *StreamURLService) Sign(ctx context.Context, userID, bookID, fileID string, ttl time.Duration, clientIP string) (*StreamURLGrant, error) {
+	if ttl == 0 {
+		ttl = DefaultStreamURLTTL
+	}
+	if ttl < MinStreamURLTTL || ttl > MaxStreamURLTTL {
+		return nil, domainerrors.Validationf("ttl must be between %d and %d seconds",
+			int(MinStreamURLTTL.Seconds()), int(MaxStreamURLTTL.Seconds()))
+	}
+
+	ok, err := s.store.CanUserAccessBook(ctx, userID, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("check book access: %w", err)
+	}
+	if !ok {
+		return nil, store.ErrBookNotFound
+	}
+	book, err := s.store.GetBook(ctx, bookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileIDs []string
+	if fileID != "" {
+		if book.GetAudioFileByID(fileID) == nil {
+			return nil, domainerrors.NotFound("audio file not found")
+		}
+		fileIDs = []string{fileID}
+	} else {
+		for _, af := range book.AudioFiles {
+			fileIDs = append(fileIDs, af.ID)
+		}
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	signature, err := s.signer.Sign(auth.StreamGrant{
+		UserID:    userID,
+		BookID:    bookID,
+		FileID:    fileID,
+		ClientIP:  clientIP,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign stream url: %w", err)
+	}
+
+	return &StreamURLGrant{
+		Signature: signature,
+		BookID:    bookID,
+		FileIDs:   fileIDs,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Verify checks a signature presented for one audio file and returns the
+// user it was minted for.
+func (s *StreamURLService) Verify(signature, bookID, fileID, clientIP string) (string, error) {
+	grant, err := s.signer.Verify(signature, bookID, fileID, clientIP, time.Now())
+	if errors.Is(err, auth.ErrStreamSignatureExpired) {
+		return "", domainerrors.TokenExpired("stream url expired")
+	}
+	if err != nil {
+		return "", domainerrors.Unauthorized("invalid stream url")
+	}
+	return grant.UserID, nil
+}
+
+// QueryTokensAllowed reports whether audio may still be fetched with an
+// access token in the query string. Admins turn this off once every client
+// uses signed stream URLs.
+func (s *StreamURLService) QueryTokensAllowed(ctx context.Context) (bool, error) {
+	settings, err := s.store.GetServerSettings(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get server settings: %w", err)
+	}
+	return !settings.RequireSignedStreamURLs, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/store"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+func TestStreamURLService_SignAndVerify(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	signer, err := auth.NewStreamSigner(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	streams := NewStreamURLService(s, signer, slog.New(slog.DiscardHandler))
+
+	alice := createTestUserWithPermissions(t, s, "alice@example.com", true)
+	book := &domain.Book{
+		Syncable: domain.Syncable{ID: "book-stream"},
+		Title:    "Streamed",
+		Path:     "/test/book-stream",
+		AudioFiles: []domain.AudioFileInfo{
+			{ID: "af-1", Path: "/test/book-stream/01.mp3"},
+			{ID: "af-2", Path: "/test/book-stream/02.mp3"},
+		},
+	}
+	book.InitTimestamps()
+	require.NoError(t, s.CreateBook(ctx, book))
+
+	grant, err := streams.Sign(ctx, alice.ID, book.ID, "", 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"af-1", "af-2"}, grant.FileIDs)
+	assert.WithinDuration(t, time.Now().Add(DefaultStreamURLTTL), grant.ExpiresAt, time.Minute)
+
+	userID, err := streams.Verify(grant.Signature, book.ID, "af-2", "")
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, userID)
+
+	fileGrant, err := streams.Sign(ctx, alice.ID, book.ID, "af-1", 10*time.Minute, "192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"af-1"}, fileGrant.FileIDs)
+
+	_, err = streams.Verify(fileGrant.Signature, book.ID, "af-2", "192.0.2.1")
+	assert.ErrorIs(t, err, domainerrors.Unauthorized(""))
+	_, err = streams.Verify(fileGrant.Signature, book.ID, "af-1", "192.0.2.2")
+	assert.ErrorIs(t, err, domainerrors.Unauthorized(""))
+
+	_, err = streams.Sign(ctx, alice.ID, book.ID, "af-missing", 0, "")
+	assert.ErrorIs(t, err, domainerrors.NotFound(""))
+
+	_, err = streams.Sign(ctx, alice.ID, "book-missing", "", 0, "")
+	assert.ErrorIs(t, err, store.ErrBookNotFound)
+
+	_, err = streams.Sign(ctx, alice.ID, book.ID, "", MaxStreamURLTTL+time.Second, "")
+	assert.ErrorIs(t, err, domainerrors.Validation(""))
+}
+
+func TestStreamURLService_QueryTokensAllowed(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	signer, err := auth.NewStreamSigner(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	streams := NewStreamURLService(s, signer, slog.New(slog.DiscardHandler))
+
+	allowed, err := streams.QueryTokensAllowed(ctx)
+	require.NoError(t, err)
+	assert.True(t, allowed, "query tokens keep working until an admin opts in")
+
+	settings, err := s.GetServerSettings(ctx)
+	require.NoError(t, err)
+	settings.RequireSignedStreamURLs = true
+	require.NoError(t, s.UpdateServerSettings(ctx, settings))
+
+	allowed, err = streams.QueryTokensAllowed(ctx)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
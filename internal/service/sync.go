@@ -37,6 +37,7 @@ type syncServiceStore interface {
 	store.GenreStore
 	store.TagStore
 	store.ListeningStore
+	store.BookmarkStore
 	store.UserStore
 }
 
@@ -48,10 +49,10 @@ type SyncService struct {
 }
 
 // NewSyncService creates a new sync service.
-func NewSyncService(store syncServiceStore, logger *slog.Logger) *SyncService {
+func NewSyncService(store syncServiceStore, enricher *dto.Enricher, logger *slog.Logger) *SyncService {
 	return &SyncService{
 		store:    store,
-		enricher: dto.NewEnricher(store),
+		enricher: enricher,
 		logger:   logger,
 	}
 }
@@ -59,12 +60,14 @@ func NewSyncService(store syncServiceStore, logger *slog.Logger) *SyncService {
 // GetManifest returns the library manifest for sync.
 // This provides a high-level overview of the library state including.
 // the current checkpoint and counts of various entities.
-func (s *SyncService) GetManifest(ctx context.Context, userID string) (*ManifestResponse, error) {
+// If libraryID is set, only that library's books are listed.
+func (s *SyncService) GetManifest(ctx context.Context, userID, libraryID string) (*ManifestResponse, error) {
 	// Get book IDs filtered by user access.
 	accessibleBooks, err := s.store.GetBooksForUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	accessibleBooks = booksInLibrary(accessibleBooks, libraryID)
 
 	// Extract just the IDs from accessible books.
 	bookIDs := make([]string, len(accessibleBooks))
@@ -169,6 +172,8 @@ func (s *SyncService) GetBooksForSync(ctx context.Context, userID string, params
 		}
 	}
 
+	books = booksInLibrary(books, params.LibraryID)
+
 	// Apply cursor-based pagination manually (in-memory)
 	// This applies to both full sync and delta sync results
 	total := len(books)
@@ -461,6 +466,110 @@ func (s *SyncService) GetSeriesForSync(ctx context.Context, userID string, param
 	return response, nil
 }
 
+// BookmarksResponse represents paginated bookmarks for sync.
+type BookmarksResponse struct {
+	NextCursor         string             `json:"next_cursor,omitempty"`
+	Bookmarks          []*domain.Bookmark `json:"bookmarks"`
+	DeletedBookmarkIDs []string           `json:"deleted_bookmark_ids,omitempty"`
+	HasMore            bool               `json:"has_more"`
+}
+
+// GetBookmarksForSync returns the user's paginated bookmarks for sync.
+// Supports both full sync and delta sync (bookmarks updated after timestamp).
+// Bookmarks on books the user can no longer access are omitted.
+func (s *SyncService) GetBookmarksForSync(ctx context.Context, userID string, params store.PaginationParams) (*BookmarksResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	params.Validate()
+
+	var bookmarks []*domain.Bookmark
+	var deletedBookmarkIDs []string
+	var err error
+
+	// DELTA SYNC: If UpdatedAfter is set, fetch only changed items.
+	if !params.UpdatedAfter.IsZero() {
+		bookmarks, err = s.store.GetBookmarksForUserUpdatedAfter(ctx, userID, params.UpdatedAfter)
+		if err != nil {
+			return nil, err
+		}
+
+		deletedBookmarkIDs, err = s.store.GetBookmarksDeletedAfter(ctx, userID, params.UpdatedAfter)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		bookmarks, err = s.store.ListBookmarksForUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	accessibleBookIDs, err := s.store.GetAccessibleBookIDSet(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get accessible books: %w", err)
+	}
+	filtered := make([]*domain.Bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if accessibleBookIDs[b.BookID] {
+			filtered = append(filtered, b)
+		}
+	}
+	bookmarks = filtered
+
+	// Apply manual pagination
+	total := len(bookmarks)
+	startIdx := 0
+	if params.Cursor != "" {
+		decoded, err := store.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Sscanf(decoded, "%d", &startIdx); err != nil {
+			return nil, err
+		}
+	}
+
+	if startIdx >= total && len(deletedBookmarkIDs) == 0 {
+		return &BookmarksResponse{
+			Bookmarks: []*domain.Bookmark{},
+			HasMore:   false,
+		}, nil
+	}
+
+	startIdx = min(startIdx, total)
+	endIdx := min(startIdx+params.Limit, total)
+
+	pageItems := bookmarks[startIdx:endIdx]
+	hasMore := endIdx < total
+
+	response := &BookmarksResponse{
+		Bookmarks:          pageItems,
+		DeletedBookmarkIDs: deletedBookmarkIDs,
+		HasMore:            hasMore,
+	}
+
+	// Only send deleted IDs on the first page to avoid duplication
+	if startIdx > 0 {
+		response.DeletedBookmarkIDs = nil
+	}
+
+	if hasMore {
+		response.NextCursor = store.EncodeCursor(strconv.Itoa(endIdx))
+	}
+
+	s.logger.Info("bookmarks fetched for sync",
+		"user_id", userID,
+		"delta_sync", !params.UpdatedAfter.IsZero(),
+		"count", len(pageItems),
+		"deleted_count", len(deletedBookmarkIDs),
+		"has_more", hasMore,
+	)
+
+	return response, nil
+}
+
 // SessionWithUser bundles a reading session with its associated user and profile.
 // Used as the return type for GetActiveSessionsForUser and GetReadingSessionsForUser
 // so callers do not need to perform secondary lookups against the store.
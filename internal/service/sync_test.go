@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/dto"
 	"github.com/listenupapp/listenup-server/internal/store"
 	"github.com/listenupapp/listenup-server/internal/store/sqlite"
 	"github.com/stretchr/testify/assert"
@@ -35,7 +36,7 @@ func setupTestSync(t *testing.T) (*SyncService, store.Store, func()) {
 
 	// Create sync service.
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
-	syncService := NewSyncService(testStore, logger)
+	syncService := NewSyncService(testStore, dto.NewEnricher(testStore), logger)
 
 	// Return cleanup function.
 	cleanup := func() {
@@ -94,7 +95,7 @@ func TestGetManifest_WithMultipleBooks(t *testing.T) {
 	require.NoError(t, testStore.CreateBook(ctx, book3))
 
 	// Get manifest.
-	manifest, err := syncService.GetManifest(ctx, "test-user")
+	manifest, err := syncService.GetManifest(ctx, "test-user", "")
 	require.NoError(t, err)
 	require.NotNil(t, manifest)
 
@@ -125,7 +126,7 @@ func TestGetManifest_EmptyLibrary(t *testing.T) {
 
 	ctx := context.Background()
 
-	manifest, err := syncService.GetManifest(ctx, "test-user")
+	manifest, err := syncService.GetManifest(ctx, "test-user", "")
 	require.NoError(t, err)
 	require.NotNil(t, manifest)
 
@@ -152,7 +153,7 @@ func TestGetManifest_SingleBook(t *testing.T) {
 	book := createSyncTestBook("book-solo", bookTime)
 	require.NoError(t, testStore.CreateBook(ctx, book))
 
-	manifest, err := syncService.GetManifest(ctx, "test-user")
+	manifest, err := syncService.GetManifest(ctx, "test-user", "")
 	require.NoError(t, err)
 	require.NotNil(t, manifest)
 
@@ -185,7 +186,7 @@ func TestGetManifest_CheckpointOrdering(t *testing.T) {
 	require.NoError(t, testStore.CreateBook(ctx, newestBook))
 	require.NoError(t, testStore.CreateBook(ctx, oldBook))
 
-	manifest, err := syncService.GetManifest(ctx, "test-user")
+	manifest, err := syncService.GetManifest(ctx, "test-user", "")
 	require.NoError(t, err)
 
 	// Verify checkpoint is from the newest book, not insertion order.
@@ -201,7 +202,7 @@ func TestNewSyncService(t *testing.T) {
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	service := NewSyncService(testStore, logger)
+	service := NewSyncService(testStore, dto.NewEnricher(testStore), logger)
 
 	assert.NotNil(t, service)
 	assert.Equal(t, testStore, service.store)
@@ -220,7 +221,7 @@ func TestManifestResponse_Structure(t *testing.T) {
 	book := createSyncTestBook("book-001", now)
 	require.NoError(t, testStore.CreateBook(ctx, book))
 
-	manifest, err := syncService.GetManifest(ctx, "test-user")
+	manifest, err := syncService.GetManifest(ctx, "test-user", "")
 	require.NoError(t, err)
 
 	// Verify all fields are populated.
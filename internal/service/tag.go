@@ -221,6 +221,12 @@ func (s *TagService) GetBooksForTag(ctx context.Context, userID, slug string) ([
 }
 
 // reindexBookTags triggers search re-indexing for a book's tags.
+// ReindexBookTags refreshes a book's tags in the search index after they
+// were changed outside this service, e.g. by an Audiobookshelf import.
+func (s *TagService) ReindexBookTags(ctx context.Context, bookID string) {
+	s.reindexBookTags(ctx, bookID)
+}
+
 func (s *TagService) reindexBookTags(ctx context.Context, bookID string) {
 	if s.search == nil {
 		return
@@ -2,6 +2,7 @@ package service
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -29,6 +31,7 @@ import (
 // transcodeServiceStore is the narrow store interface TranscodeService depends on.
 type transcodeServiceStore interface {
 	store.TranscodeStore
+	SetAudioFileLoudness(ctx context.Context, bookID, audioFileID string, loudness domain.Loudness) error
 }
 
 // TranscodeService manages audio transcoding operations.
@@ -247,6 +250,62 @@ func (s *TranscodeService) CreateJob(
 	return job, nil
 }
 
+// CreateAnalysisJob queues a loudness analysis of an audio file. Analysis jobs
+// share the worker pool with transcodes at domain.TranscodePriorityAnalysis.
+// An existing job is returned while it is queued or running, or once it has
+// finished for the file as it is now.
+func (s *TranscodeService) CreateAnalysisJob(ctx context.Context, bookID, audioFileID, sourcePath string) (*domain.TranscodeJob, error) {
+	// Analysis reads the whole file once; a content hash would read it again.
+	fingerprint, err := statFingerprint(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("stat source file: %w", err)
+	}
+
+	existing, err := s.store.GetTranscodeJobByAudioFileAndVariant(ctx, audioFileID, domain.TranscodeVariantLoudness)
+	switch {
+	case err == nil:
+		if existing.IsActive() || (existing.Status != domain.TranscodeStatusCancelled && existing.SourceHash == fingerprint) {
+			return existing, nil
+		}
+		if err := s.store.DeleteTranscodeJob(ctx, existing.ID); err != nil {
+			return nil, fmt.Errorf("delete stale analysis job: %w", err)
+		}
+	case !errors.Is(err, store.ErrNotFound):
+		return nil, fmt.Errorf("check existing analysis job: %w", err)
+	}
+
+	jobID, err := id.Generate("tj")
+	if err != nil {
+		return nil, fmt.Errorf("generate job id: %w", err)
+	}
+
+	job := &domain.TranscodeJob{
+		ID:          jobID,
+		BookID:      bookID,
+		AudioFileID: audioFileID,
+		SourcePath:  sourcePath,
+		SourceHash:  fingerprint,
+		Variant:     domain.TranscodeVariantLoudness,
+		Status:      domain.TranscodeStatusPending,
+		Priority:    domain.TranscodePriorityAnalysis,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.store.CreateTranscodeJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("create analysis job: %w", err)
+	}
+
+	s.logger.Debug("created loudness analysis job",
+		slog.String("job_id", job.ID),
+		slog.String("book_id", bookID),
+		slog.String("audio_file_id", audioFileID),
+	)
+
+	s.NotifyNewJob()
+
+	return job, nil
+}
+
 // BumpPriority increases a job's priority for user-requested playback.
 func (s *TranscodeService) BumpPriority(ctx context.Context, jobID string) error {
 	job, err := s.store.GetTranscodeJob(ctx, jobID)
@@ -468,16 +527,18 @@ func (s *TranscodeService) processNextJob(workerID int) {
 		s.activeJobCancels.Delete(job.ID)
 	}()
 
+	if job.IsAnalysis() {
+		s.runAnalysis(ctx, jobCtx, job)
+		return
+	}
+
 	// Execute transcode
 	outputPath, err := s.executeTranscode(jobCtx, job)
 	if err != nil {
 		// If the job was cancelled, mark it cancelled rather than failed.
-		if jobCtx.Err() != nil {
-			job, storeErr := s.store.GetTranscodeJob(ctx, job.ID)
-			if storeErr == nil && job.Status == domain.TranscodeStatusCancelled {
-				// Already marked by CancelJob; nothing more to do.
-				return
-			}
+		if s.wasCancelled(ctx, jobCtx, job.ID) {
+			// Already marked by CancelJob; nothing more to do.
+			return
 		}
 		s.handleTranscodeError(ctx, job, err)
 		return
@@ -507,6 +568,144 @@ func (s *TranscodeService) processNextJob(workerID int) {
 	s.emitter.Emit(sse.NewTranscodeCompleteEvent(job.ID, job.BookID, job.AudioFileID))
 }
 
+// wasCancelled reports whether a job stopped because CancelJob cancelled it.
+func (s *TranscodeService) wasCancelled(ctx, jobCtx context.Context, jobID string) bool {
+	if jobCtx.Err() == nil {
+		return false
+	}
+	job, err := s.store.GetTranscodeJob(ctx, jobID)
+	return err == nil && job.Status == domain.TranscodeStatusCancelled
+}
+
+// runAnalysis measures a job's source and records the loudness on its audio
+// file. Clients never wait on analysis, so it emits no events.
+func (s *TranscodeService) runAnalysis(ctx, jobCtx context.Context, job *domain.TranscodeJob) {
+	loudness, err := s.executeAnalysis(jobCtx, job)
+	if err == nil {
+		err = s.store.SetAudioFileLoudness(ctx, job.BookID, job.AudioFileID, loudness)
+	}
+	if err != nil {
+		if s.wasCancelled(ctx, jobCtx, job.ID) {
+			return
+		}
+		s.logger.Warn("loudness analysis failed",
+			slog.String("job_id", job.ID),
+			slog.String("audio_file_id", job.AudioFileID),
+			slog.Any("error", err),
+		)
+		job.MarkFailed(err.Error())
+		if updateErr := s.store.UpdateTranscodeJob(ctx, job); updateErr != nil {
+			s.logger.Error("failed to update failed job", slog.Any("error", updateErr))
+		}
+		return
+	}
+
+	job.MarkCompleted("", 0)
+	if err := s.store.UpdateTranscodeJob(ctx, job); err != nil {
+		s.logger.Error("failed to update completed job", slog.Any("error", err))
+		return
+	}
+
+	s.logger.Info("loudness analysis completed",
+		slog.String("job_id", job.ID),
+		slog.String("audio_file_id", job.AudioFileID),
+		slog.Float64("integrated_lufs", loudness.IntegratedLUFS),
+		slog.Float64("true_peak_dbtp", loudness.TruePeakDBTP),
+	)
+}
+
+// executeAnalysis runs ffmpeg's EBU R128 filter over the job's source.
+func (s *TranscodeService) executeAnalysis(ctx context.Context, job *domain.TranscodeJob) (domain.Loudness, error) {
+	args := s.buildAnalysisArgs(job.SourcePath)
+
+	s.logger.Debug("executing ffmpeg",
+		slog.String("job_id", job.ID),
+		slog.Any("args", args),
+	)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.ffmpegPath, args...) //nolint:gosec // ffmpegPath is validated at service init
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return domain.Loudness{}, fmt.Errorf("ffmpeg failed: %w", err)
+	}
+
+	return parseEBUR128Summary(&stderr)
+}
+
+// buildAnalysisArgs constructs the ffmpeg command arguments for a loudness
+// measurement. Only the summary is logged; per-frame output is suppressed.
+func (s *TranscodeService) buildAnalysisArgs(input string) []string {
+	return []string{
+		"-hide_banner",
+		"-nostats",
+		"-i", input, // Input file
+		"-vn",                                     // No video
+		"-af", "ebur128=peak=true:framelog=quiet", // Integrated loudness and true peak
+		"-f", "null", // Decode only
+		"-",
+	}
+}
+
+// parseEBUR128Summary reads the integrated loudness and true peak from the
+// summary the ebur128 filter logs when its input ends:
+//
+//	Integrated loudness:
+//	  I:         -19.5 LUFS
+//	  Threshold: -29.8 LUFS
+//	...
+//	True peak:
+//	  Peak:       -0.4 dBFS
+func parseEBUR128Summary(r io.Reader) (domain.Loudness, error) {
+	var (
+		loudness        domain.Loudness
+		inSummary       bool
+		haveI, havePeak bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasSuffix(strings.TrimSpace(line), "Summary:") {
+			inSummary = true
+			continue
+		}
+		if !inSummary {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "I:":
+			loudness.IntegratedLUFS, haveI = parseLoudnessLevel(fields[1])
+		case "Peak:":
+			loudness.TruePeakDBTP, havePeak = parseLoudnessLevel(fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return domain.Loudness{}, fmt.Errorf("read ffmpeg output: %w", err)
+	}
+
+	if !haveI || !havePeak {
+		return domain.Loudness{}, errors.New("ffmpeg output has no EBU R128 summary")
+	}
+	return loudness, nil
+}
+
+// parseLoudnessLevel parses a level from the ebur128 summary. Digital silence
+// is reported as -inf and is clamped to the EBU R128 absolute gate.
+func parseLoudnessLevel(field string) (float64, bool) {
+	level, err := strconv.ParseFloat(field, 64)
+	if err != nil || math.IsNaN(level) {
+		return 0, false
+	}
+	return max(level, domain.LoudnessSilenceFloor), true
+}
+
 // executeTranscode runs ffmpeg and returns the output path.
 func (s *TranscodeService) executeTranscode(ctx context.Context, job *domain.TranscodeJob) (string, error) {
 	// Check if FFmpeg can decode this codec before attempting transcode.
@@ -607,6 +806,11 @@ func (s *TranscodeService) buildFFmpegArgs(input, outputDir string, bitrate, cha
 		if bitrate < 384000 {
 			bitrate = 384000
 		}
+	case domain.TranscodeVariantNormalized:
+		channels = 2
+		if bitrate > 128000 {
+			bitrate = 128000
+		}
 	}
 
 	playlistPath := filepath.Join(outputDir, "playlist.m3u8")
@@ -615,7 +819,16 @@ func (s *TranscodeService) buildFFmpegArgs(input, outputDir string, bitrate, cha
 	args := []string{
 		"-y",        // Overwrite output
 		"-i", input, // Input file
-		"-vn",         // No video
+		"-vn", // No video
+	}
+
+	// Single-pass loudnorm, for clients that can't apply the gain themselves
+	if variant == domain.TranscodeVariantNormalized {
+		args = append(args, "-af", fmt.Sprintf("loudnorm=I=%g:TP=%g:LRA=11",
+			domain.LoudnessTargetLUFS, domain.LoudnessMaxTruePeak))
+	}
+
+	args = append(args,
 		"-c:a", "aac", // AAC codec
 		"-b:a", strconv.Itoa(bitrate), // Bitrate based on variant
 		"-ac", strconv.Itoa(channels), // Channels based on variant
@@ -627,7 +840,7 @@ func (s *TranscodeService) buildFFmpegArgs(input, outputDir string, bitrate, cha
 		"-hls_segment_type", "mpegts", // MPEG-TS segments
 		"-hls_segment_filename", segmentPattern,
 		playlistPath,
-	}
+	)
 
 	return args
 }
@@ -852,6 +1065,15 @@ func (s *TranscodeService) hashFile(path string) (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// statFingerprint identifies a version of a file by its size and modification time.
+func statFingerprint(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("stat:%d:%d", info.Size(), info.ModTime().UnixNano()), nil
+}
+
 // DeleteTranscodesForBook removes all transcoded files and jobs for a book.
 func (s *TranscodeService) DeleteTranscodesForBook(ctx context.Context, bookID string) error {
 	// Delete jobs from store
@@ -895,7 +1117,18 @@ func (s *TranscodeService) QueueTranscode(ctx context.Context, bookID, audioFile
 		return nil
 	}
 
-	// Create job with background priority (1) and spatial variant
-	_, err := s.CreateJob(ctx, bookID, audioFileID, sourcePath, sourceCodec, 1, domain.TranscodeVariantSpatial)
+	// Create job with background priority and spatial variant
+	_, err := s.CreateJob(ctx, bookID, audioFileID, sourcePath, sourceCodec, domain.TranscodePriorityBackground, domain.TranscodeVariantSpatial)
+	return err
+}
+
+// QueueLoudnessAnalysis implements scanner.TranscodeQueuer.
+// It queues a loudness analysis for an audio file if transcoding is enabled.
+func (s *TranscodeService) QueueLoudnessAnalysis(ctx context.Context, bookID, audioFileID, sourcePath string) error {
+	if !s.IsEnabled() {
+		return nil
+	}
+
+	_, err := s.CreateAnalysisJob(ctx, bookID, audioFileID, sourcePath)
 	return err
 }
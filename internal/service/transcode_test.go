@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -534,4 +537,111 @@ func Test_buildFFmpegArgs_Variants(t *testing.T) {
 		assert.Equal(t, "6", args[channelsIdx])
 		assert.Equal(t, "384000", args[bitrateIdx])
 	})
+
+	t.Run("normalized variant applies loudnorm in stereo", func(t *testing.T) {
+		args := service.buildFFmpegArgs("/test/input.m4a", outputDir, 256000, 6, domain.TranscodeVariantNormalized)
+
+		filterIdx := slices.Index(args, "-af")
+		require.NotEqual(t, -1, filterIdx, "audio filter arg not found")
+		assert.Equal(t, "loudnorm=I=-18:TP=-1.5:LRA=11", args[filterIdx+1])
+		assert.Equal(t, "2", args[slices.Index(args, "-ac")+1])
+		assert.Equal(t, "128000", args[slices.Index(args, "-b:a")+1])
+	})
+
+	t.Run("other variants have no audio filter", func(t *testing.T) {
+		args := service.buildFFmpegArgs("/test/input.m4a", outputDir, 128000, 2, domain.TranscodeVariantStereo)
+		assert.NotContains(t, args, "-af")
+	})
+}
+
+func Test_parseEBUR128Summary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads integrated loudness and true peak", func(t *testing.T) {
+		t.Parallel()
+		output := `Input #0, mp3, from 'chapter01.mp3':
+  Duration: 00:42:10.03, start: 0.025057, bitrate: 64 kb/s
+[Parsed_ebur128_0 @ 0x55d0c6a0c0c0] Summary:
+
+  Integrated loudness:
+    I:         -21.4 LUFS
+    Threshold: -31.7 LUFS
+
+  Loudness range:
+    LRA:         5.2 LU
+    Threshold: -41.9 LUFS
+    LRA low:   -24.6 LUFS
+    LRA high:  -19.4 LUFS
+
+  True peak:
+    Peak:       -2.3 dBFS
+`
+		loudness, err := parseEBUR128Summary(strings.NewReader(output))
+		require.NoError(t, err)
+		assert.InDelta(t, -21.4, loudness.IntegratedLUFS, 0.001)
+		assert.InDelta(t, -2.3, loudness.TruePeakDBTP, 0.001)
+	})
+
+	t.Run("clamps digital silence to the absolute gate", func(t *testing.T) {
+		t.Parallel()
+		output := "[Parsed_ebur128_0 @ 0x1] Summary:\n  Integrated loudness:\n    I:         -70.0 LUFS\n  True peak:\n    Peak:       -inf dBFS\n"
+		loudness, err := parseEBUR128Summary(strings.NewReader(output))
+		require.NoError(t, err)
+		assert.InDelta(t, domain.LoudnessSilenceFloor, loudness.TruePeakDBTP, 0.001)
+		assert.Zero(t, loudness.GainDB())
+	})
+
+	t.Run("errors without a summary", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseEBUR128Summary(strings.NewReader("chapter01.mp3: Invalid data found when processing input\n"))
+		assert.Error(t, err)
+	})
+}
+
+func Test_CreateAnalysisJob(t *testing.T) {
+	service, rawStore, tmpDir, cleanup := setupTranscodeTest(t)
+	defer cleanup()
+	service.logger = slog.New(slog.DiscardHandler)
+
+	ctx := context.Background()
+	sourcePath := filepath.Join(tmpDir, "source.mp3")
+	require.NoError(t, os.WriteFile(sourcePath, []byte("audio"), 0644))
+	ensureTestBookWithAudioFile(t, rawStore, "book_loud", "af_loud")
+
+	job, err := service.CreateAnalysisJob(ctx, "book_loud", "af_loud", sourcePath)
+	require.NoError(t, err)
+	assert.True(t, job.IsAnalysis())
+	assert.Equal(t, domain.TranscodePriorityAnalysis, job.Priority)
+	assert.Equal(t, domain.TranscodeStatusPending, job.Status)
+
+	t.Run("pending job is reused", func(t *testing.T) {
+		again, err := service.CreateAnalysisJob(ctx, "book_loud", "af_loud", sourcePath)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, again.ID)
+	})
+
+	t.Run("finished job is reused until the file changes", func(t *testing.T) {
+		job.MarkCompleted("", 0)
+		require.NoError(t, rawStore.UpdateTranscodeJob(ctx, job))
+
+		again, err := service.CreateAnalysisJob(ctx, "book_loud", "af_loud", sourcePath)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, again.ID)
+
+		require.NoError(t, os.WriteFile(sourcePath, []byte("re-encoded audio"), 0644))
+		fresh, err := service.CreateAnalysisJob(ctx, "book_loud", "af_loud", sourcePath)
+		require.NoError(t, err)
+		assert.NotEqual(t, job.ID, fresh.ID)
+		assert.Equal(t, domain.TranscodeStatusPending, fresh.Status)
+	})
+
+	t.Run("analysis is not served as a transcode", func(t *testing.T) {
+		_, err := rawStore.GetTranscodeJobByAudioFile(ctx, "af_loud")
+		assert.ErrorIs(t, err, store.ErrNotFound)
+
+		stereo := createTestTranscodeJobWithVariant(t, rawStore, "book_loud", "af_loud", domain.TranscodeStatusRunning, domain.TranscodeVariantStereo)
+		got, err := rawStore.GetTranscodeJobByAudioFile(ctx, "af_loud")
+		require.NoError(t, err)
+		assert.Equal(t, stereo.ID, got.ID)
+	})
 }
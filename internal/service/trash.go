@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/dto"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/media/images"
+	"github.com/listenupapp/listenup-server/internal/search/asyncindexer"
+	"github.com/listenupapp/listenup-server/internal/sse"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// trashServiceStore is the narrow store interface TrashService depends on.
+type trashServiceStore interface {
+	store.TrashStore
+	GetBookByID(ctx context.Context, id string) (*domain.Book, error)
+	ListLibraries(ctx context.Context) ([]*domain.Library, error)
+	GetServerSettings(ctx context.Context) (*domain.ServerSettings, error)
+	CleanupTagsForDeletedBook(ctx context.Context, bookID string) error
+	GetShelf(ctx context.Context, id string) (*domain.Shelf, error)
+	AdminGetCollection(ctx context.Context, id string) (*domain.Collection, error)
+}
+
+// TranscodeCleaner removes cached transcodes for a book.
+// Implemented by TranscodeService.
+type TranscodeCleaner interface {
+	DeleteTranscodesForBook(ctx context.Context, bookID string) error
+}
+
+// TrashService handles admin book deletion, the trash, and restoring or
+// purging trashed books.
+//
+// Deleting a book never removes its row: the book is soft-deleted and stays
+// that way after purge, so listening history keeps its references and the
+// scanner doesn't pick the folder up again as a new book.
+type TrashService struct {
+	store      trashServiceStore
+	transcodes TranscodeCleaner
+	indexer    *asyncindexer.Indexer
+	covers     *images.Storage
+	enricher   *dto.Enricher
+	events     store.EventEmitter
+	logger     *slog.Logger
+}
+
+// NewTrashService creates a new trash service.
+func NewTrashService(
+	store trashServiceStore,
+	transcodes TranscodeCleaner,
+	indexer *asyncindexer.Indexer,
+	covers *images.Storage,
+	enricher *dto.Enricher,
+	events store.EventEmitter,
+	logger *slog.Logger,
+) *TrashService {
+	return &TrashService{
+		store:      store,
+		transcodes: transcodes,
+		indexer:    indexer,
+		covers:     covers,
+		enricher:   enricher,
+		events:     events,
+		logger:     logger,
+	}
+}
+
+// DeleteBook moves a book to the trash.
+//
+// With deleteFiles set, the book's folder is removed from disk when the book is
+// purged; otherwise the files are left alone and only the library entry goes.
+// The book disappears from search, shelves and collections immediately and
+// its transcodes are dropped, since they can be regenerated after a restore.
+func (s *TrashService) DeleteBook(ctx context.Context, adminID, bookID string, deleteFiles bool) (*domain.TrashedBook, error) {
+	book, err := s.store.GetBookByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.store.GetServerSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get server settings: %w", err)
+	}
+
+	now := time.Now()
+	entry := &domain.TrashedBook{
+		BookID:      book.ID,
+		Title:       book.Title,
+		Path:        book.Path,
+		DeletedBy:   adminID,
+		DeletedAt:   now,
+		PurgeAt:     settings.TrashPurgeAt(now),
+		DeleteFiles: deleteFiles,
+	}
+	if err := s.store.TrashBook(ctx, entry); err != nil {
+		return nil, fmt.Errorf("trash book: %w", err)
+	}
+
+	if err := s.store.CleanupTagsForDeletedBook(ctx, bookID); err != nil {
+		s.logger.Warn("failed to clean up tags for trashed book", "book_id", bookID, "error", err)
+	}
+	if s.transcodes != nil {
+		if err := s.transcodes.DeleteTranscodesForBook(ctx, bookID); err != nil {
+			s.logger.Warn("failed to delete transcodes for trashed book", "book_id", bookID, "error", err)
+		}
+	}
+	if s.indexer != nil {
+		s.indexer.SubmitDeleteBook(bookID)
+	}
+
+	s.emitDetached(ctx, entry)
+	s.events.Emit(sse.NewBookDeletedEvent(bookID, now))
+
+	s.logger.Info("book moved to trash",
+		"book_id", bookID,
+		"title", book.Title,
+		"deleted_by", adminID,
+		"delete_files", deleteFiles,
+	)
+
+	return entry, nil
+}
+
+// ListTrash returns every book in the trash, most recently deleted first.
+func (s *TrashService) ListTrash(ctx context.Context) ([]*domain.TrashedBook, error) {
+	return s.store.ListTrashedBooks(ctx)
+}
+
+// RestoreBook takes a book out of the trash and puts it back on the shelves
+// and in the collections it was removed from, where those still exist.
+func (s *TrashService) RestoreBook(ctx context.Context, bookID string) (*domain.Book, error) {
+	if err := s.store.RestoreTrashedBook(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	book, err := s.store.GetBookByID(ctx, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("get restored book: %w", err)
+	}
+
+	if s.indexer != nil {
+		s.indexer.SubmitIndexBook(book)
+	}
+
+	enriched, err := s.enricher.EnrichBook(ctx, book)
+	if err != nil {
+		s.logger.Warn("failed to enrich restored book for SSE event", "book_id", bookID, "error", err)
+		enriched = &dto.Book{Book: book}
+	}
+	event := sse.NewBookCreatedEvent(enriched)
+	event.BookID = bookID
+	s.events.Emit(event)
+
+	s.logger.Info("book restored from trash", "book_id", bookID, "title", book.Title)
+
+	return book, nil
+}
+
+// PurgeBook permanently deletes a trashed book: its cover goes, its folder is
+// removed if the admin asked for that, and it can no longer be restored.
+func (s *TrashService) PurgeBook(ctx context.Context, bookID string) error {
+	entry, err := s.store.GetTrashedBook(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	return s.purge(ctx, entry)
+}
+
+// PurgeDue purges every trashed book whose retention period has run out.
+// Returns the number of books purged. A book that fails to purge is logged
+// and left in the trash to be retried on the next run.
+func (s *TrashService) PurgeDue(ctx context.Context, now time.Time) (int, error) {
+	entries, err := s.store.ListTrashedBooksDue(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("list trashed books due: %w", err)
+	}
+
+	purged := 0
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+		if err := s.purge(ctx, entry); err != nil {
+			s.logger.Error("failed to purge trashed book", "book_id", entry.BookID, "error", err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (s *TrashService) purge(ctx context.Context, entry *domain.TrashedBook) error {
+	if entry.DeleteFiles {
+		libraries, err := s.store.ListLibraries(ctx)
+		if err != nil {
+			return fmt.Errorf("list libraries: %w", err)
+		}
+		var scanPaths []string
+		for _, lib := range libraries {
+			scanPaths = append(scanPaths, lib.ScanPaths...)
+		}
+		if err := removeBookFiles(entry.Path, scanPaths); err != nil {
+			return fmt.Errorf("remove book files: %w", err)
+		}
+	}
+
+	if s.covers != nil {
+		if err := s.covers.Delete(entry.BookID); err != nil {
+			s.logger.Warn("failed to delete cover for purged book", "book_id", entry.BookID, "error", err)
+		}
+	}
+
+	if err := s.store.DeleteTrashedBook(ctx, entry.BookID); err != nil {
+		return err
+	}
+
+	s.logger.Info("trashed book purged",
+		"book_id", entry.BookID,
+		"title", entry.Title,
+		"files_deleted", entry.DeleteFiles,
+	)
+	return nil
+}
+
+// emitDetached notifies clients that a trashed book left its shelves and collections.
+func (s *TrashService) emitDetached(ctx context.Context, entry *domain.TrashedBook) {
+	for _, item := range entry.Shelves {
+		shelf, err := s.store.GetShelf(ctx, item.ShelfID)
+		if err != nil {
+			continue
+		}
+		s.events.Emit(sse.NewShelfBookRemovedEvent(shelf, entry.BookID))
+	}
+	for _, collectionID := range entry.CollectionIDs {
+		coll, err := s.store.AdminGetCollection(ctx, collectionID)
+		if err != nil {
+			continue
+		}
+		s.events.Emit(sse.NewCollectionBookRemovedEvent(coll.ID, coll.Name, entry.BookID))
+	}
+}
+
+// removeBookFiles deletes a book's files from disk. The path must sit strictly
+// inside one of the library scan paths, so a bad path can never take a scan
+// root (or anything outside the library) with it. A path that is already gone
+// is not an error.
+func removeBookFiles(path string, scanPaths []string) error {
+	if path == "" {
+		return domainerrors.Validation("book has no path")
+	}
+	clean := filepath.Clean(path)
+
+	inside := false
+	for _, root := range scanPaths {
+		rel, err := filepath.Rel(filepath.Clean(root), clean)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		inside = true
+		break
+	}
+	if !inside {
+		return domainerrors.Validationf("refusing to delete %s: not inside a library scan path", clean)
+	}
+
+	if err := os.RemoveAll(clean); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRemoveBookFiles_StaysInsideScanPaths tests that book folders are only
+// deleted when they sit below a library scan path.
+func TestRemoveBookFiles_StaysInsideScanPaths(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	library := filepath.Join(root, "library")
+	bookDir := filepath.Join(library, "Author", "Book")
+	outside := filepath.Join(root, "elsewhere")
+	require.NoError(t, os.MkdirAll(bookDir, 0o755))
+	require.NoError(t, os.MkdirAll(outside, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(bookDir, "01.mp3"), []byte("audio"), 0o644))
+
+	scanPaths := []string{library}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "empty path", path: "", wantErr: true},
+		{name: "scan root", path: library, wantErr: true},
+		{name: "scan root with trailing slash", path: library + string(filepath.Separator), wantErr: true},
+		{name: "outside library", path: outside, wantErr: true},
+		{name: "escapes library", path: filepath.Join(library, "..", "elsewhere"), wantErr: true},
+		{name: "already gone", path: filepath.Join(library, "Missing")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := removeBookFiles(tt.path, scanPaths)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// Run after the subtests so the refusals above are checked against a full tree.
+	t.Cleanup(func() {
+		assert.DirExists(t, library)
+		assert.DirExists(t, outside)
+	})
+}
+
+// TestRemoveBookFiles_DeletesBookFolder tests that a book folder inside a
+// scan path is removed along with its files.
+func TestRemoveBookFiles_DeletesBookFolder(t *testing.T) {
+	t.Parallel()
+	library := t.TempDir()
+	bookDir := filepath.Join(library, "Author", "Book")
+	require.NoError(t, os.MkdirAll(bookDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(bookDir, "01.mp3"), []byte("audio"), 0o644))
+
+	require.NoError(t, removeBookFiles(bookDir, []string{library}))
+
+	assert.NoDirExists(t, bookDir)
+	assert.DirExists(t, filepath.Join(library, "Author"))
+}
@@ -0,0 +1,500 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/id"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes a user gets at a time.
+	recoveryCodeCount = 10
+	// recoveryCodeBytes is the random bytes per recovery code (80 bits),
+	// which base32-encode to 16 characters.
+	recoveryCodeBytes = 10
+
+	// maxTwoFactorAttempts is how many wrong codes a login challenge takes
+	// before it is revoked.
+	maxTwoFactorAttempts = 5
+
+	// defaultTOTPIssuer labels the account in authenticator apps when the
+	// server has no name.
+	defaultTOTPIssuer = "ListenUp"
+)
+
+// recoveryCodeEncoding is lowercase-friendly base32 for recovery codes.
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// errInvalidTwoFactorCode is returned for any wrong, reused or malformed code.
+var errInvalidTwoFactorCode = domainerrors.InvalidCredentials("invalid two-factor code")
+
+// errChallengeRevoked is returned for a login challenge that was replaced by a
+// newer one or took too many wrong codes.
+var errChallengeRevoked = domainerrors.TokenExpired("login challenge is invalid or expired, sign in again")
+
+// twoFactorServiceStore is the narrow store surface TwoFactorService needs.
+type twoFactorServiceStore interface {
+	store.UserStore
+	store.TwoFactorStore
+	store.SettingsStore
+}
+
+// TwoFactorService handles TOTP enrollment, code verification and
+// recovery codes.
+type TwoFactorService struct {
+	store  twoFactorServiceStore
+	logger *slog.Logger
+}
+
+// NewTwoFactorService creates a new two-factor authentication service.
+func NewTwoFactorService(s twoFactorServiceStore, logger *slog.Logger) *TwoFactorService {
+	return &TwoFactorService{
+		store:  s,
+		logger: logger,
+	}
+}
+
+// TwoFactorStatus describes a user's two-factor setup.
+type TwoFactorStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+	// Required is true when the user is an admin and the server makes
+	// two-factor authentication mandatory for admins.
+	Required bool
+}
+
+// TwoFactorEnrollment is the secret a user adds to their authenticator.
+type TwoFactorEnrollment struct {
+	Secret string // Base32, for manual entry
+	URI    string // otpauth:// URI, for QR codes
+}
+
+// DisableTwoFactorRequest contains what a user must prove to turn 2FA off.
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required,max=1024"`
+	Code     string `json:"code" validate:"required,max=64"`
+}
+
+// GetStatus returns a user's two-factor status.
+func (s *TwoFactorService) GetStatus(ctx context.Context, userID string) (*TwoFactorStatus, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TwoFactorStatus{}
+	status.Required, err = s.IsRequired(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	tf, err := s.store.GetTwoFactor(ctx, userID)
+	if errors.Is(err, store.ErrTwoFactorNotFound) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get two-factor: %w", err)
+	}
+	status.Enabled = tf.IsEnabled()
+	if !status.Enabled {
+		return status, nil
+	}
+
+	codes, err := s.store.ListRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list recovery codes: %w", err)
+	}
+	for _, c := range codes {
+		if !c.IsUsed() {
+			status.RecoveryCodesRemaining++
+		}
+	}
+	return status, nil
+}
+
+// BeginEnrollment creates a new TOTP secret for a user. Two-factor stays
+// off until ConfirmEnrollment is called with a code from it. Starting again
+// replaces an unconfirmed secret.
+func (s *TwoFactorService) BeginEnrollment(ctx context.Context, userID string) (*TwoFactorEnrollment, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.GetTwoFactor(ctx, userID)
+	if err != nil && !errors.Is(err, store.ErrTwoFactorNotFound) {
+		return nil, fmt.Errorf("get two-factor: %w", err)
+	}
+	if existing != nil && existing.IsEnabled() {
+		return nil, domainerrors.Conflict("two-factor authentication is already enabled")
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.store.SaveTwoFactor(ctx, &domain.TwoFactor{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		return nil, fmt.Errorf("save two-factor: %w", err)
+	}
+
+	return &TwoFactorEnrollment{
+		Secret: secret,
+		URI:    auth.TOTPURI(s.issuer(ctx), user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment turns two-factor on once the user proves their
+// authenticator works. Returns the recovery codes, which are shown once.
+func (s *TwoFactorService) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	tf, err := s.store.GetTwoFactor(ctx, userID)
+	if errors.Is(err, store.ErrTwoFactorNotFound) {
+		return nil, domainerrors.Validation("start two-factor enrollment first")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get two-factor: %w", err)
+	}
+	if tf.IsEnabled() {
+		return nil, domainerrors.Conflict("two-factor authentication is already enabled")
+	}
+
+	step, ok := auth.ValidateTOTP(tf.Secret, code, time.Now())
+	if !ok {
+		return nil, errInvalidTwoFactorCode
+	}
+
+	now := time.Now()
+	tf.ConfirmedAt = &now
+	tf.LastUsedStep = step
+	tf.UpdatedAt = now
+	if err := s.store.SaveTwoFactor(ctx, tf); err != nil {
+		return nil, fmt.Errorf("save two-factor: %w", err)
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Two-factor authentication enabled", "user_id", userID)
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes replaces a user's recovery codes after checking
+// a current code. Returns the new codes, which are shown once.
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// Disable turns two-factor off for the user after checking their password
+// and a current code. Admins can't turn it off while it is mandatory.
+func (s *TwoFactorService) Disable(ctx context.Context, userID string, req DisableTwoFactorRequest) error {
+	if err := validate.Struct(req); err != nil {
+		return formatValidationError(err)
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	required, err := s.IsRequired(ctx, user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return domainerrors.Forbidden("two-factor authentication is required for admins on this server")
+	}
+
+	valid, err := auth.VerifyPassword(user.PasswordHash, req.Password)
+	if err != nil {
+		return fmt.Errorf("verify password: %w", err)
+	}
+	if !valid {
+		return domainerrors.InvalidCredentials("current password is incorrect")
+	}
+	if err := s.VerifyCode(ctx, userID, req.Code); err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteTwoFactor(ctx, userID); err != nil {
+		return fmt.Errorf("delete two-factor: %w", err)
+	}
+	s.logger.Info("Two-factor authentication disabled", "user_id", userID)
+	return nil
+}
+
+// AdminDisable turns two-factor off for a user who lost their
+// authenticator and recovery codes. Only the root user can do this for root.
+func (s *TwoFactorService) AdminDisable(ctx context.Context, adminUserID, targetUserID string) error {
+	admin, err := s.store.GetUser(ctx, adminUserID)
+	if err != nil {
+		return fmt.Errorf("get admin: %w", err)
+	}
+	user, err := s.getUser(ctx, targetUserID)
+	if err != nil {
+		return err
+	}
+	if user.IsRoot && !admin.IsRoot {
+		return domainerrors.Forbidden("only the root user can reset the root user's two-factor authentication")
+	}
+
+	if err := s.store.DeleteTwoFactor(ctx, targetUserID); err != nil {
+		return fmt.Errorf("delete two-factor: %w", err)
+	}
+	s.logger.Info("Two-factor authentication reset by admin",
+		"admin_id", adminUserID,
+		"user_id", targetUserID,
+	)
+	return nil
+}
+
+// IsEnabled reports whether a user has confirmed two-factor enrollment.
+func (s *TwoFactorService) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	tf, err := s.store.GetTwoFactor(ctx, userID)
+	if errors.Is(err, store.ErrTwoFactorNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get two-factor: %w", err)
+	}
+	return tf.IsEnabled(), nil
+}
+
+// IsRequired reports whether the server makes two-factor mandatory for this user.
+func (s *TwoFactorService) IsRequired(ctx context.Context, user *domain.User) (bool, error) {
+	if !user.IsAdmin() {
+		return false, nil
+	}
+	settings, err := s.store.GetServerSettings(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get server settings: %w", err)
+	}
+	return settings.RequireAdminTwoFactor, nil
+}
+
+// CheckAdminAccess returns Forbidden if two-factor is mandatory for admins
+// and this admin hasn't enrolled yet. They can still use the app, and
+// enroll, but not the admin endpoints.
+func (s *TwoFactorService) CheckAdminAccess(ctx context.Context, user *domain.User) error {
+	required, err := s.IsRequired(ctx, user)
+	if err != nil || !required {
+		return err
+	}
+	enabled, err := s.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return domainerrors.Forbidden("enable two-factor authentication to use admin features")
+	}
+	return nil
+}
+
+// StartChallenge begins a two-factor login for a user who passed the
+// password step and returns the challenge ID. Any earlier challenge for the
+// user is revoked.
+func (s *TwoFactorService) StartChallenge(ctx context.Context, userID string) (string, error) {
+	challengeID, err := id.Generate("challenge")
+	if err != nil {
+		return "", fmt.Errorf("generate challenge ID: %w", err)
+	}
+	if err := s.store.StartTwoFactorChallenge(ctx, userID, challengeID); err != nil {
+		return "", fmt.Errorf("start challenge: %w", err)
+	}
+	return challengeID, nil
+}
+
+// VerifyChallengeCode checks a code entered for a login challenge. Wrong
+// codes are counted against the challenge on the server, so changing client
+// address doesn't reset them; after maxTwoFactorAttempts the challenge is
+// revoked and the user must sign in with their password again.
+func (s *TwoFactorService) VerifyChallengeCode(ctx context.Context, userID, challengeID, code string) error {
+	tf, err := s.store.GetTwoFactor(ctx, userID)
+	if err != nil && !errors.Is(err, store.ErrTwoFactorNotFound) {
+		return fmt.Errorf("get two-factor: %w", err)
+	}
+	if tf == nil || tf.ChallengeID != challengeID || tf.FailedAttempts >= maxTwoFactorAttempts {
+		return errChallengeRevoked
+	}
+
+	err = s.VerifyCode(ctx, userID, code)
+	if !errors.Is(err, errInvalidTwoFactorCode) {
+		return err
+	}
+
+	attempts, recordErr := s.store.RecordTwoFactorFailure(ctx, userID, challengeID)
+	if errors.Is(recordErr, store.ErrTwoFactorNotFound) {
+		// Revoked meanwhile
+		return errChallengeRevoked
+	}
+	if recordErr != nil {
+		return fmt.Errorf("record failed attempt: %w", recordErr)
+	}
+	if attempts >= maxTwoFactorAttempts {
+		if err := s.store.RevokeTwoFactorChallenge(ctx, userID, challengeID); err != nil {
+			return fmt.Errorf("revoke challenge: %w", err)
+		}
+		s.logger.Warn("Two-factor challenge revoked after too many invalid codes", "user_id", userID)
+		return domainerrors.TokenExpired("too many invalid codes, sign in again")
+	}
+	return err
+}
+
+// VerifyCode checks a TOTP code or an unused recovery code for a user with
+// two-factor enabled. Each code is accepted only once.
+func (s *TwoFactorService) VerifyCode(ctx context.Context, userID, code string) error {
+	tf, err := s.store.GetTwoFactor(ctx, userID)
+	if errors.Is(err, store.ErrTwoFactorNotFound) {
+		return domainerrors.Validation("two-factor authentication is not enabled")
+	}
+	if err != nil {
+		return fmt.Errorf("get two-factor: %w", err)
+	}
+	if !tf.IsEnabled() {
+		return domainerrors.Validation("two-factor authentication is not enabled")
+	}
+
+	if step, ok := auth.ValidateTOTP(tf.Secret, code, time.Now()); ok {
+		if err := s.store.UseTOTPStep(ctx, userID, step); err != nil {
+			if errors.Is(err, store.ErrTwoFactorNotFound) {
+				// Replayed code
+				return errInvalidTwoFactorCode
+			}
+			return fmt.Errorf("record totp step: %w", err)
+		}
+		return nil
+	}
+
+	return s.useRecoveryCode(ctx, userID, code)
+}
+
+// useRecoveryCode redeems a recovery code. Codes are salted, so each
+// unused one is checked in turn.
+func (s *TwoFactorService) useRecoveryCode(ctx context.Context, userID, code string) error {
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != recoveryCodeEncoding.EncodedLen(recoveryCodeBytes) {
+		return errInvalidTwoFactorCode
+	}
+
+	codes, err := s.store.ListRecoveryCodes(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list recovery codes: %w", err)
+	}
+
+	remaining := 0
+	var match *domain.RecoveryCode
+	for _, c := range codes {
+		if c.IsUsed() {
+			continue
+		}
+		remaining++
+		if match != nil {
+			continue
+		}
+		ok, err := auth.VerifyPassword(c.CodeHash, normalized)
+		if err != nil {
+			return fmt.Errorf("verify recovery code: %w", err)
+		}
+		if ok {
+			match = c
+		}
+	}
+	if match == nil {
+		return errInvalidTwoFactorCode
+	}
+
+	if err := s.store.UseRecoveryCode(ctx, match.ID, time.Now()); err != nil {
+		if errors.Is(err, store.ErrRecoveryCodeNotFound) {
+			return errInvalidTwoFactorCode
+		}
+		return fmt.Errorf("use recovery code: %w", err)
+	}
+
+	s.logger.Warn("Recovery code used", "user_id", userID, "remaining", remaining-1)
+	return nil
+}
+
+// replaceRecoveryCodes issues a fresh set of recovery codes and returns
+// them formatted for display.
+func (s *TwoFactorService) replaceRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	now := time.Now()
+	plain := make([]string, 0, recoveryCodeCount)
+	records := make([]*domain.RecoveryCode, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("generate recovery code: %w", err)
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+
+		hash, err := auth.HashPassword(code)
+		if err != nil {
+			return nil, fmt.Errorf("hash recovery code: %w", err)
+		}
+		codeID, err := id.Generate("recovery")
+		if err != nil {
+			return nil, fmt.Errorf("generate recovery code ID: %w", err)
+		}
+
+		records = append(records, &domain.RecoveryCode{
+			ID:        codeID,
+			UserID:    userID,
+			CodeHash:  hash,
+			CreatedAt: now,
+		})
+		plain = append(plain, code[:8]+"-"+code[8:])
+	}
+
+	if err := s.store.ReplaceRecoveryCodes(ctx, userID, records); err != nil {
+		return nil, fmt.Errorf("save recovery codes: %w", err)
+	}
+	return plain, nil
+}
+
+// issuer returns the name shown for this server in authenticator apps.
+func (s *TwoFactorService) issuer(ctx context.Context) string {
+	settings, err := s.store.GetServerSettings(ctx)
+	if err != nil || strings.TrimSpace(settings.Name) == "" {
+		return defaultTOTPIssuer
+	}
+	return strings.TrimSpace(settings.Name)
+}
+
+// getUser loads a user, mapping a missing user to NotFound.
+func (s *TwoFactorService) getUser(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, domainerrors.NotFound("user not found")
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return user, nil
+}
+
+// normalizeRecoveryCode strips the separators people type or paste.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
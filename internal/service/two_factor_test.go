@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/listenupapp/listenup-server/internal/auth"
+	"github.com/listenupapp/listenup-server/internal/config"
+	"github.com/listenupapp/listenup-server/internal/domain"
+	domainerrors "github.com/listenupapp/listenup-server/internal/errors"
+	"github.com/listenupapp/listenup-server/internal/store/sqlite"
+)
+
+func setupTwoFactorTest(t *testing.T) (*AuthService, *TwoFactorService, *sqlite.Store) {
+	t.Helper()
+
+	s, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	key, err := auth.LoadOrGenerateKey(t.TempDir())
+	require.NoError(t, err)
+	tokenService, err := auth.NewTokenService(hex.EncodeToString(key), 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	logger := slog.New(slog.DiscardHandler)
+	sessions := NewSessionService(s, tokenService, nil, logger)
+	instance := NewInstanceService(s, logger, &config.Config{})
+	twoFactor := NewTwoFactorService(s, logger)
+	return NewAuthService(s, tokenService, sessions, instance, twoFactor, logger), twoFactor, s
+}
+
+// enrollUser turns two-factor on for a user and returns the secret and recovery codes.
+func enrollUser(t *testing.T, svc *TwoFactorService, userID string) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	enrollment, err := svc.BeginEnrollment(ctx, userID)
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/")
+
+	// Use the previous period's code so the login below can use the current one.
+	code, err := auth.TOTPCode(enrollment.Secret, auth.TOTPStep(time.Now())-1)
+	require.NoError(t, err)
+	codes, err := svc.ConfirmEnrollment(ctx, userID, code)
+	require.NoError(t, err)
+	return enrollment.Secret, codes
+}
+
+func TestTwoFactor_Login(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	authSvc, svc, s := setupTwoFactorTest(t)
+	user := createTestUserWithPermissions(t, s, "user@example.com", true)
+	hash, err := auth.HashPassword("SecurePassword123!")
+	require.NoError(t, err)
+	user.PasswordHash = hash
+	require.NoError(t, s.UpdateUser(ctx, user))
+
+	secret, recovery := enrollUser(t, svc, user.ID)
+	require.Len(t, recovery, recoveryCodeCount)
+
+	device := auth.DeviceInfo{DeviceType: "mobile", Platform: "iOS"}
+	resp, err := authSvc.Login(ctx, LoginRequest{Email: "user@example.com", Password: "SecurePassword123!", DeviceInfo: device})
+	require.NoError(t, err)
+	require.NotNil(t, resp.TwoFactorChallenge)
+	assert.Empty(t, resp.AccessToken)
+	assert.Nil(t, resp.User)
+
+	// The challenge is not an access token.
+	_, _, err = authSvc.VerifyAccessToken(ctx, resp.TwoFactorChallenge.Token)
+	assert.Error(t, err)
+
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	req := TwoFactorLoginRequest{ChallengeToken: resp.TwoFactorChallenge.Token, Code: code, DeviceInfo: device}
+	done, err := authSvc.LoginTwoFactor(ctx, req)
+	require.NoError(t, err)
+	assert.NotEmpty(t, done.AccessToken)
+	assert.Equal(t, user.ID, done.User.ID)
+
+	// A TOTP code works once.
+	_, err = authSvc.LoginTwoFactor(ctx, req)
+	assert.ErrorIs(t, err, domainerrors.InvalidCredentials(""))
+
+	// So does a recovery code, however it is typed.
+	req.Code = strings.ToUpper(strings.ReplaceAll(recovery[0], "-", " "))
+	_, err = authSvc.LoginTwoFactor(ctx, req)
+	require.NoError(t, err)
+	req.Code = recovery[0]
+	_, err = authSvc.LoginTwoFactor(ctx, req)
+	assert.ErrorIs(t, err, domainerrors.InvalidCredentials(""))
+
+	status, err := svc.GetStatus(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, recoveryCodeCount-1, status.RecoveryCodesRemaining)
+
+	req.ChallengeToken = "v4.local.bogus"
+	req.Code = recovery[1]
+	_, err = authSvc.LoginTwoFactor(ctx, req)
+	assert.ErrorIs(t, err, domainerrors.TokenExpired(""))
+}
+
+func TestTwoFactor_LoginLockout(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	authSvc, svc, s := setupTwoFactorTest(t)
+	user := createTestUserWithPermissions(t, s, "user@example.com", true)
+	hash, err := auth.HashPassword("SecurePassword123!")
+	require.NoError(t, err)
+	user.PasswordHash = hash
+	require.NoError(t, s.UpdateUser(ctx, user))
+	secret, _ := enrollUser(t, svc, user.ID)
+
+	device := auth.DeviceInfo{DeviceType: "mobile", Platform: "iOS"}
+	login := func() string {
+		resp, err := authSvc.Login(ctx, LoginRequest{Email: "user@example.com", Password: "SecurePassword123!", DeviceInfo: device})
+		require.NoError(t, err)
+		require.NotNil(t, resp.TwoFactorChallenge)
+		return resp.TwoFactorChallenge.Token
+	}
+	challenge := login()
+
+	// Each wrong code comes from a different address; the count is per challenge.
+	for i := range maxTwoFactorAttempts - 1 {
+		_, err := authSvc.LoginTwoFactor(ctx, TwoFactorLoginRequest{
+			ChallengeToken: challenge, Code: "000000", DeviceInfo: device, IPAddress: fmt.Sprintf("203.0.113.%d", i),
+		})
+		assert.ErrorIs(t, err, domainerrors.InvalidCredentials(""))
+	}
+	_, err = authSvc.LoginTwoFactor(ctx, TwoFactorLoginRequest{
+		ChallengeToken: challenge, Code: "000000", DeviceInfo: device, IPAddress: "198.51.100.1",
+	})
+	assert.ErrorIs(t, err, domainerrors.TokenExpired(""), "the last allowed wrong code revokes the challenge")
+
+	// The 6th attempt is refused from a fresh address, even with the right code.
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	_, err = authSvc.LoginTwoFactor(ctx, TwoFactorLoginRequest{
+		ChallengeToken: challenge, Code: code, DeviceInfo: device, IPAddress: "198.51.100.2",
+	})
+	assert.ErrorIs(t, err, domainerrors.TokenExpired(""))
+
+	// Signing in with the password again starts a fresh challenge, and
+	// revokes the one before it.
+	older := login()
+	fresh := login()
+	_, err = authSvc.LoginTwoFactor(ctx, TwoFactorLoginRequest{ChallengeToken: older, Code: code, DeviceInfo: device})
+	assert.ErrorIs(t, err, domainerrors.TokenExpired(""))
+	done, err := authSvc.LoginTwoFactor(ctx, TwoFactorLoginRequest{ChallengeToken: fresh, Code: code, DeviceInfo: device})
+	require.NoError(t, err)
+	assert.NotEmpty(t, done.AccessToken)
+}
+
+func TestTwoFactor_RequiredForAdmins(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, svc, s := setupTwoFactorTest(t)
+	admin := createTestUserWithPermissions(t, s, "admin@example.com", true)
+	admin.Role = domain.RoleAdmin
+	hash, err := auth.HashPassword("SecurePassword123!")
+	require.NoError(t, err)
+	admin.PasswordHash = hash
+	require.NoError(t, s.UpdateUser(ctx, admin))
+
+	require.NoError(t, svc.CheckAdminAccess(ctx, admin), "not required by default")
+
+	settings, err := s.GetServerSettings(ctx)
+	require.NoError(t, err)
+	settings.RequireAdminTwoFactor = true
+	require.NoError(t, s.UpdateServerSettings(ctx, settings))
+
+	assert.ErrorIs(t, svc.CheckAdminAccess(ctx, admin), domainerrors.Forbidden(""))
+
+	_, recovery := enrollUser(t, svc, admin.ID)
+	require.NoError(t, svc.CheckAdminAccess(ctx, admin))
+
+	err = svc.Disable(ctx, admin.ID, DisableTwoFactorRequest{Password: "SecurePassword123!", Code: recovery[0]})
+	assert.ErrorIs(t, err, domainerrors.Forbidden(""), "admins can't opt out while required")
+}
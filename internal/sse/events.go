@@ -67,6 +67,9 @@ const (
 	// EventUserDeleted represents a user being deleted.
 	// Sent to the deleted user so they can clear auth and show appropriate message.
 	EventUserDeleted EventType = "user.deleted"
+	// EventSessionRevoked represents one or more auth sessions being revoked.
+	// Sent to the session owner; clients whose session is listed should clear auth.
+	EventSessionRevoked EventType = "session.revoked"
 
 	// Collection events (admin-only).
 	EventCollectionCreated     EventType = "collection.created"
@@ -91,12 +94,22 @@ const (
 	EventInboxBookAdded    EventType = "inbox.book_added"
 	EventInboxBookReleased EventType = "inbox.book_released"
 
+	// Scheduled backup events (admin-only).
+	EventBackupCompleted EventType = "backup.completed"
+	EventBackupFailed    EventType = "backup.failed"
+
 	// Listening events (user-specific).
 	EventProgressUpdated       EventType = "listening.progress_updated"
 	EventProgressDeleted       EventType = "listening.progress_deleted"
 	EventListeningEventCreated EventType = "listening.event_created"
+	EventEbookPositionUpdated  EventType = "listening.ebook_position_updated"
 	EventReadingSessionUpdated EventType = "reading_session.updated"
 
+	// Bookmark events (user-specific).
+	EventBookmarkCreated EventType = "bookmark.created"
+	EventBookmarkUpdated EventType = "bookmark.updated"
+	EventBookmarkDeleted EventType = "bookmark.deleted"
+
 	// Active session events (broadcast to all for "Currently Listening" feature).
 	EventSessionStarted EventType = "session.started"
 	EventSessionEnded   EventType = "session.ended"
@@ -109,6 +122,16 @@ const (
 
 	// User stats events (broadcast to all for leaderboard caching).
 	EventUserStatsUpdated EventType = "user_stats.updated"
+
+	// Listening goal events (user-specific).
+	EventGoalUpdated EventType = "goal.updated"
+	EventGoalDeleted EventType = "goal.deleted"
+
+	// Podcast events (broadcast to all; episode progress is user-specific).
+	EventPodcastUpdated         EventType = "podcast.updated"
+	EventPodcastDeleted         EventType = "podcast.deleted"
+	EventPodcastEpisodeUpdated  EventType = "podcast.episode_updated"
+	EventPodcastEpisodeProgress EventType = "podcast.episode_progress"
 )
 
 // Event represents an SSE event to be sent to clients.
@@ -228,6 +251,14 @@ type UserDeletedEventData struct {
 	Reason string `json:"reason,omitempty"`
 }
 
+// SessionRevokedEventData is the data payload for session revoked events.
+// Clients compare their own session ID against SessionIDs.
+type SessionRevokedEventData struct {
+	UserID     string   `json:"user_id"`
+	SessionIDs []string `json:"session_ids"`
+	Reason     string   `json:"reason,omitempty"`
+}
+
 // CollectionEventData is the data payload for collection CRUD events.
 type CollectionEventData struct {
 	ID        string    `json:"id"`
@@ -480,6 +511,18 @@ func NewUserDeletedEvent(userID, reason string) Event {
 	}
 }
 
+// NewSessionRevokedEvent creates a session.revoked event for a specific user.
+// The access tokens of revoked sessions stay valid until they expire, so the
+// affected clients rely on this event to sign out immediately.
+func NewSessionRevokedEvent(userID string, sessionIDs []string, reason string) Event {
+	return Event{
+		Type:      EventSessionRevoked,
+		UserID:    userID,
+		Data:      SessionRevokedEventData{UserID: userID, SessionIDs: sessionIDs, Reason: reason},
+		Timestamp: time.Now(),
+	}
+}
+
 // NewCollectionCreatedEvent creates a collection.created event.
 func NewCollectionCreatedEvent(id, name string, bookCount int) Event {
 	return Event{
@@ -715,6 +758,34 @@ func NewInboxBookReleasedEvent(bookID string) Event {
 	}
 }
 
+// BackupEventData is the data payload for scheduled backup events.
+type BackupEventData struct {
+	BackupID    string    `json:"backup_id,omitempty"`
+	Size        int64     `json:"size,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+	Pruned      []string  `json:"pruned,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// NewBackupCompletedEvent creates a backup.completed event for admin users.
+func NewBackupCompletedEvent(data BackupEventData) Event {
+	return Event{
+		Type:      EventBackupCompleted,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewBackupFailedEvent creates a backup.failed event for admin users.
+func NewBackupFailedEvent(data BackupEventData) Event {
+	return Event{
+		Type:      EventBackupFailed,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+}
+
 // ProgressUpdatedEventData is the data payload for listening.progress_updated events.
 // FinishedAt and StartedAt are included so client stateful-merge handlers preserve
 // completion and start timestamps on cross-device echoes; omitted when absent.
@@ -766,6 +837,17 @@ func NewProgressDeletedEvent(userID, bookID string) Event {
 	}
 }
 
+// NewEbookPositionUpdatedEvent creates a listening.ebook_position_updated event
+// for a specific user, so their other devices can pick up where they stopped reading.
+func NewEbookPositionUpdatedEvent(position *domain.EbookPosition) Event {
+	return Event{
+		Type:      EventEbookPositionUpdated,
+		Data:      position,
+		UserID:    position.UserID, // Only send to this user
+		Timestamp: time.Now(),
+	}
+}
+
 // ListeningEventCreatedEventData is the data payload for listening.event_created events.
 // Sent to other devices when a listening event is recorded, enabling offline-first stats.
 type ListeningEventCreatedEventData struct {
@@ -801,6 +883,52 @@ func NewListeningEventCreatedEvent(userID string, event *domain.ListeningEvent)
 	}
 }
 
+// BookmarkEventData is the data payload for bookmark.created and bookmark.updated events.
+type BookmarkEventData struct {
+	Bookmark *domain.Bookmark `json:"bookmark"`
+}
+
+// BookmarkDeletedEventData is the data payload for bookmark.deleted events.
+type BookmarkDeletedEventData struct {
+	BookmarkID string    `json:"bookmark_id"`
+	BookID     string    `json:"book_id"`
+	DeletedAt  time.Time `json:"deleted_at"`
+}
+
+// NewBookmarkCreatedEvent creates a bookmark.created event for the bookmark's owner.
+func NewBookmarkCreatedEvent(bookmark *domain.Bookmark) Event {
+	return Event{
+		Type:      EventBookmarkCreated,
+		Data:      BookmarkEventData{Bookmark: bookmark},
+		UserID:    bookmark.UserID, // Only send to this user's devices
+		Timestamp: time.Now(),
+	}
+}
+
+// NewBookmarkUpdatedEvent creates a bookmark.updated event for the bookmark's owner.
+func NewBookmarkUpdatedEvent(bookmark *domain.Bookmark) Event {
+	return Event{
+		Type:      EventBookmarkUpdated,
+		Data:      BookmarkEventData{Bookmark: bookmark},
+		UserID:    bookmark.UserID, // Only send to this user's devices
+		Timestamp: time.Now(),
+	}
+}
+
+// NewBookmarkDeletedEvent creates a bookmark.deleted event for the bookmark's owner.
+func NewBookmarkDeletedEvent(userID, bookID, bookmarkID string, deletedAt time.Time) Event {
+	return Event{
+		Type: EventBookmarkDeleted,
+		Data: BookmarkDeletedEventData{
+			BookmarkID: bookmarkID,
+			BookID:     bookID,
+			DeletedAt:  deletedAt,
+		},
+		UserID:    userID, // Only send to this user's devices
+		Timestamp: time.Now(),
+	}
+}
+
 // ReadingSessionUpdatedEventData is the data payload for reading_session.updated events.
 type ReadingSessionUpdatedEventData struct {
 	SessionID    string     `json:"session_id"`
@@ -849,6 +977,7 @@ type ActivityEventData struct {
 	MilestoneUnit   string    `json:"milestone_unit,omitempty"`
 	ShelfID         string    `json:"shelf_id,omitempty"`
 	ShelfName       string    `json:"shelf_name,omitempty"`
+	Rating          float64   `json:"rating,omitempty"` // For reviewed_book activities
 }
 
 // NewActivityEvent creates an activity.created event.
@@ -876,6 +1005,7 @@ func NewActivityEvent(activity *domain.Activity) Event {
 			MilestoneUnit:   activity.MilestoneUnit,
 			ShelfID:         activity.ShelfID,
 			ShelfName:       activity.ShelfName,
+			Rating:          activity.Rating,
 		},
 		Timestamp: time.Now(),
 	}
@@ -967,3 +1097,101 @@ func NewUserStatsUpdatedEvent(data UserStatsUpdatedEventData) Event {
 		Timestamp: time.Now(),
 	}
 }
+
+// NewGoalUpdatedEvent creates a goal.updated event for the goal's owner.
+// Sent whenever a goal is created or edited and whenever listening moves its
+// progress, so every device can show the current standing without polling.
+func NewGoalUpdatedEvent(progress domain.GoalProgress) Event {
+	return Event{
+		Type:      EventGoalUpdated,
+		Data:      progress,
+		UserID:    progress.Goal.UserID, // Only send to this user's devices
+		Timestamp: time.Now(),
+	}
+}
+
+// GoalDeletedEventData is the data payload for goal.deleted events.
+type GoalDeletedEventData struct {
+	GoalID string `json:"goal_id"`
+}
+
+// NewGoalDeletedEvent creates a goal.deleted event for the goal's owner.
+func NewGoalDeletedEvent(userID, goalID string) Event {
+	return Event{
+		Type:      EventGoalDeleted,
+		Data:      GoalDeletedEventData{GoalID: goalID},
+		UserID:    userID, // Only send to this user's devices
+		Timestamp: time.Now(),
+	}
+}
+
+// PodcastEventData is the data payload for podcast.updated events.
+type PodcastEventData struct {
+	Podcast *domain.Podcast `json:"podcast"`
+}
+
+// PodcastDeletedEventData is the data payload for podcast.deleted events.
+type PodcastDeletedEventData struct {
+	PodcastID string `json:"podcast_id"`
+}
+
+// PodcastEpisodeEventData is the data payload for podcast.episode_updated events.
+type PodcastEpisodeEventData struct {
+	Episode *domain.PodcastEpisode `json:"episode"`
+}
+
+// EpisodeProgressEventData is the data payload for podcast.episode_progress events.
+type EpisodeProgressEventData struct {
+	PodcastID         string    `json:"podcast_id"`
+	EpisodeID         string    `json:"episode_id"`
+	CurrentPositionMs int64     `json:"current_position_ms"`
+	Progress          float64   `json:"progress"`
+	IsFinished        bool      `json:"is_finished"`
+	LastPlayedAt      time.Time `json:"last_played_at"`
+}
+
+// NewPodcastUpdatedEvent creates a podcast.updated event. It is emitted on
+// subscribe, settings changes and feed refreshes that add episodes.
+func NewPodcastUpdatedEvent(podcast *domain.Podcast) Event {
+	return Event{
+		Type:      EventPodcastUpdated,
+		Data:      PodcastEventData{Podcast: podcast},
+		Timestamp: time.Now(),
+	}
+}
+
+// NewPodcastDeletedEvent creates a podcast.deleted event.
+func NewPodcastDeletedEvent(podcastID string) Event {
+	return Event{
+		Type:      EventPodcastDeleted,
+		Data:      PodcastDeletedEventData{PodcastID: podcastID},
+		Timestamp: time.Now(),
+	}
+}
+
+// NewPodcastEpisodeUpdatedEvent creates a podcast.episode_updated event.
+// Clients use it to follow an episode through the download queue.
+func NewPodcastEpisodeUpdatedEvent(episode *domain.PodcastEpisode) Event {
+	return Event{
+		Type:      EventPodcastEpisodeUpdated,
+		Data:      PodcastEpisodeEventData{Episode: episode},
+		Timestamp: time.Now(),
+	}
+}
+
+// NewEpisodeProgressEvent creates a podcast.episode_progress event for a specific user.
+func NewEpisodeProgressEvent(userID string, episode *domain.PodcastEpisode, state *domain.PlaybackState) Event {
+	return Event{
+		Type: EventPodcastEpisodeProgress,
+		Data: EpisodeProgressEventData{
+			PodcastID:         episode.PodcastID,
+			EpisodeID:         episode.ID,
+			CurrentPositionMs: state.CurrentPositionMs,
+			Progress:          state.ComputeProgress(episode.DurationMs),
+			IsFinished:        state.IsFinished,
+			LastPlayedAt:      state.LastPlayedAt,
+		},
+		UserID:    userID,
+		Timestamp: time.Now(),
+	}
+}
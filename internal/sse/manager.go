@@ -152,7 +152,9 @@ func isAdminOnlyEvent(eventType EventType) bool {
 		EventScanStarted,
 		EventScanComplete,
 		EventInboxBookAdded,
-		EventInboxBookReleased:
+		EventInboxBookReleased,
+		EventBackupCompleted,
+		EventBackupFailed:
 		return true
 	default:
 		return false
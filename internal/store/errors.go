@@ -27,6 +27,20 @@ var (
 	ErrInviteCodeExists        = errors.New("invite code already exists")
 	ErrProgressNotFound        = errors.New("playback progress not found")
 	ErrBookPreferencesNotFound = errors.New("book preferences not found")
+	ErrBookmarkNotFound        = errors.New("bookmark not found")
+	ErrEbookPositionNotFound   = errors.New("ebook position not found")
+	ErrGoalNotFound            = errors.New("listening goal not found")
+	ErrRecapNotFound           = errors.New("listening recap not found")
+	ErrTrashedBookNotFound     = errors.New("book not found in trash")
+	ErrReviewNotFound          = errors.New("review not found")
+	ErrPodcastNotFound         = errors.New("podcast not found")
+	ErrEpisodeNotFound         = errors.New("episode not found")
+	ErrFeedTokenNotFound       = errors.New("feed token not found")
+	ErrPasswordResetNotFound   = errors.New("password reset not found")
+	ErrTwoFactorNotFound       = errors.New("two-factor enrollment not found")
+	ErrRecoveryCodeNotFound    = errors.New("recovery code not found")
+	ErrOIDCIdentityNotFound    = errors.New("oidc identity not found")
+	ErrOIDCIdentityExists      = errors.New("oidc identity already linked")
 	ErrProfileNotFound         = errors.New("profile not found")
 	ErrTagNotFound             = errors.New("tag not found")
 	ErrGenreNotFound           = errors.New("genre not found")
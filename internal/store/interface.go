@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/metadata"
 	"github.com/listenupapp/listenup-server/internal/metadata/audible"
 )
 
@@ -65,6 +66,7 @@ type BookStore interface {
 	GetBookByID(ctx context.Context, id string) (*domain.Book, error)
 	GetBookByPath(ctx context.Context, path string) (*domain.Book, error)
 	GetBookByInode(ctx context.Context, inode int64) (*domain.Book, error)
+	SetAudioFileLoudness(ctx context.Context, bookID, audioFileID string, loudness domain.Loudness) error
 	GetBookByASIN(ctx context.Context, asin string) (*domain.Book, error)
 	GetBookByISBN(ctx context.Context, isbn string) (*domain.Book, error)
 	BookExists(ctx context.Context, id string) (bool, error)
@@ -74,6 +76,8 @@ type BookStore interface {
 	ListAllBooks(ctx context.Context) ([]*domain.Book, error)
 	CountBooks(ctx context.Context) (int, error)
 	GetAllBookIDs(ctx context.Context) ([]string, error)
+	FilterBookIDs(ctx context.Context, filter BookFilter) ([]string, error)
+	AssignBooksToLibrary(ctx context.Context, libraryID, scanPath string) ([]string, error)
 	GetBooksByCollectionPaginated(ctx context.Context, userID, collectionID string, params PaginationParams) (*PaginatedResult[*domain.Book], error)
 	GetBooksDeletedAfter(ctx context.Context, timestamp time.Time) ([]string, error)
 	SearchBooksByTitle(ctx context.Context, title string) ([]*domain.Book, error)
@@ -92,6 +96,9 @@ type LibraryStore interface {
 	UpdateLibrary(ctx context.Context, lib *domain.Library) error
 	DeleteLibrary(ctx context.Context, id string) error
 	ListLibraries(ctx context.Context) ([]*domain.Library, error)
+	GetLibraryForPath(ctx context.Context, path string) (*domain.Library, error)
+	GetLibraryStats(ctx context.Context, libraryID string) (*LibraryStats, error)
+	CanUserAccessLibrary(ctx context.Context, userID, libraryID string) (bool, error)
 	EnsureLibrary(ctx context.Context, scanPath string, userID string) (*BootstrapResult, error)
 }
 
@@ -266,8 +273,8 @@ type ShelfStore interface {
 }
 
 // ListeningStore covers listening events, playback state, book preferences,
-// reading sessions, activities, and user-stats accumulation. These all share
-// the "what a user has been doing with a book" concern.
+// ebook positions, reading sessions, activities, and user-stats accumulation.
+// These all share the "what a user has been doing with a book" concern.
 type ListeningStore interface {
 	// Listening
 	CreateListeningEvent(ctx context.Context, event *domain.ListeningEvent) error
@@ -291,6 +298,12 @@ type ListeningStore interface {
 	DeleteBookPreferences(ctx context.Context, userID, bookID string) error
 	GetAllBookPreferences(ctx context.Context, userID string) ([]*domain.BookPreferences, error)
 
+	// Ebook Positions
+	GetEbookPosition(ctx context.Context, userID, bookID string) (*domain.EbookPosition, error)
+	UpsertEbookPosition(ctx context.Context, pos *domain.EbookPosition) error
+	DeleteEbookPosition(ctx context.Context, userID, bookID string) error
+	GetEbookPositionsForUser(ctx context.Context, userID string) ([]*domain.EbookPosition, error)
+
 	// Reading Sessions
 	CreateReadingSession(ctx context.Context, session *domain.BookReadingSession) error
 	GetReadingSession(ctx context.Context, id string) (*domain.BookReadingSession, error)
@@ -327,6 +340,121 @@ type ListeningStore interface {
 	ClearAllUserStats(ctx context.Context) error
 }
 
+// GoalStore covers per-user listening goals.
+type GoalStore interface {
+	GetListeningGoal(ctx context.Context, id string) (*domain.ListeningGoal, error)
+	UpsertListeningGoal(ctx context.Context, goal *domain.ListeningGoal) error
+	DeleteListeningGoal(ctx context.Context, id string) error
+	ListListeningGoals(ctx context.Context, userID string) ([]*domain.ListeningGoal, error)
+}
+
+// RecapStore covers the per-user listening recap cache.
+type RecapStore interface {
+	GetListeningRecap(ctx context.Context, userID, period string) (*domain.ListeningRecap, error)
+	GetListeningRecapByShareID(ctx context.Context, shareID string) (*domain.ListeningRecap, error)
+	SaveListeningRecap(ctx context.Context, recap *domain.ListeningRecap) error
+	ShareListeningRecap(ctx context.Context, userID, period, shareID string) (string, error)
+	UnshareListeningRecap(ctx context.Context, userID, period string) error
+}
+
+// BookmarkStore covers per-user bookmarks and clips.
+type BookmarkStore interface {
+	CreateBookmark(ctx context.Context, bookmark *domain.Bookmark) error
+	GetBookmark(ctx context.Context, id string) (*domain.Bookmark, error)
+	UpdateBookmark(ctx context.Context, bookmark *domain.Bookmark) error
+	DeleteBookmark(ctx context.Context, id string) error
+	ListBookmarksForUserBook(ctx context.Context, userID, bookID string) ([]*domain.Bookmark, error)
+	ListBookmarksForUser(ctx context.Context, userID string) ([]*domain.Bookmark, error)
+	GetBookmarksForUserUpdatedAfter(ctx context.Context, userID string, timestamp time.Time) ([]*domain.Bookmark, error)
+	GetBookmarksDeletedAfter(ctx context.Context, userID string, timestamp time.Time) ([]string, error)
+}
+
+// TrashStore covers the book trash: soft-deleted books that can still be
+// restored until they are purged.
+type TrashStore interface {
+	TrashBook(ctx context.Context, entry *domain.TrashedBook) error
+	GetTrashedBook(ctx context.Context, bookID string) (*domain.TrashedBook, error)
+	ListTrashedBooks(ctx context.Context) ([]*domain.TrashedBook, error)
+	ListTrashedBooksDue(ctx context.Context, now time.Time) ([]*domain.TrashedBook, error)
+	RestoreTrashedBook(ctx context.Context, bookID string) error
+	DeleteTrashedBook(ctx context.Context, bookID string) error
+}
+
+// ReviewStore covers book ratings and reviews.
+type ReviewStore interface {
+	CreateReview(ctx context.Context, r *domain.BookReview) error
+	GetReview(ctx context.Context, id string) (*domain.BookReview, error)
+	GetReviewForUserBook(ctx context.Context, userID, bookID string) (*domain.BookReview, error)
+	UpdateReview(ctx context.Context, r *domain.BookReview) error
+	DeleteReview(ctx context.Context, id string) error
+	ListReviewsForBook(ctx context.Context, bookID string, limit, offset int) ([]*domain.BookReview, error)
+	GetRatingSummary(ctx context.Context, bookID string) (domain.RatingSummary, error)
+	GetRatingSummaries(ctx context.Context, bookIDs []string) (map[string]domain.RatingSummary, error)
+}
+
+// PodcastStore covers podcast subscriptions, their episodes and per-user
+// episode playback. Episode listening reuses domain.ListeningEvent and
+// domain.PlaybackState with the episode ID in BookID.
+type PodcastStore interface {
+	CreatePodcast(ctx context.Context, p *domain.Podcast) error
+	GetPodcast(ctx context.Context, id string) (*domain.Podcast, error)
+	GetPodcastByFeedURL(ctx context.Context, libraryID, feedURL string) (*domain.Podcast, error)
+	ListPodcasts(ctx context.Context) ([]*domain.Podcast, error)
+	ListPodcastsForLibrary(ctx context.Context, libraryID string) ([]*domain.Podcast, error)
+	UpdatePodcast(ctx context.Context, p *domain.Podcast) error
+	DeletePodcast(ctx context.Context, id string) error
+	CreatePodcastEpisode(ctx context.Context, e *domain.PodcastEpisode) error
+	GetPodcastEpisode(ctx context.Context, id string) (*domain.PodcastEpisode, error)
+	GetPodcastEpisodeByGUID(ctx context.Context, podcastID, guid string) (*domain.PodcastEpisode, error)
+	ListPodcastEpisodes(ctx context.Context, podcastID string) ([]*domain.PodcastEpisode, error)
+	ListPodcastEpisodesByStatus(ctx context.Context, status domain.EpisodeStatus) ([]*domain.PodcastEpisode, error)
+	UpdatePodcastEpisode(ctx context.Context, e *domain.PodcastEpisode) error
+	CreateEpisodeListeningEvent(ctx context.Context, event *domain.ListeningEvent) error
+	GetEpisodePlaybackState(ctx context.Context, userID, episodeID string) (*domain.PlaybackState, error)
+	UpsertEpisodePlaybackState(ctx context.Context, state *domain.PlaybackState) error
+	ListEpisodePlaybackStates(ctx context.Context, userID, podcastID string) ([]*domain.PlaybackState, error)
+}
+
+// FeedTokenStore covers the per-user tokens used by OPDS and podcast feeds.
+type FeedTokenStore interface {
+	CreateFeedToken(ctx context.Context, t *domain.FeedToken) error
+	GetFeedToken(ctx context.Context, id string) (*domain.FeedToken, error)
+	GetFeedTokenByHash(ctx context.Context, tokenHash string) (*domain.FeedToken, error)
+	ListFeedTokensForUser(ctx context.Context, userID string) ([]*domain.FeedToken, error)
+	DeleteFeedToken(ctx context.Context, id string) error
+	TouchFeedToken(ctx context.Context, id string, usedAt time.Time) error
+}
+
+// PasswordResetStore covers one-time password reset codes.
+type PasswordResetStore interface {
+	CreatePasswordReset(ctx context.Context, r *domain.PasswordReset) error
+	GetPasswordResetByHash(ctx context.Context, codeHash string) (*domain.PasswordReset, error)
+	UsePasswordReset(ctx context.Context, id string, usedAt time.Time) error
+	DeletePasswordResetsForUser(ctx context.Context, userID string) error
+}
+
+// TwoFactorStore covers TOTP enrollments and recovery codes.
+type TwoFactorStore interface {
+	GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error)
+	SaveTwoFactor(ctx context.Context, tf *domain.TwoFactor) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) error
+	StartTwoFactorChallenge(ctx context.Context, userID, challengeID string) error
+	RecordTwoFactorFailure(ctx context.Context, userID, challengeID string) (int, error)
+	RevokeTwoFactorChallenge(ctx context.Context, userID, challengeID string) error
+	DeleteTwoFactor(ctx context.Context, userID string) error
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*domain.RecoveryCode) error
+	ListRecoveryCodes(ctx context.Context, userID string) ([]*domain.RecoveryCode, error)
+	UseRecoveryCode(ctx context.Context, id string, usedAt time.Time) error
+}
+
+// OIDCIdentityStore covers links between single sign-on accounts and users.
+type OIDCIdentityStore interface {
+	GetOIDCIdentity(ctx context.Context, issuer, subject string) (*domain.OIDCIdentity, error)
+	CreateOIDCIdentity(ctx context.Context, identity *domain.OIDCIdentity) error
+	TouchOIDCIdentity(ctx context.Context, issuer, subject string, at time.Time) error
+	ListOIDCIdentities(ctx context.Context, userID string) ([]*domain.OIDCIdentity, error)
+}
+
 // InviteStore covers invites.
 type InviteStore interface {
 	CreateInvite(ctx context.Context, invite *domain.Invite) error
@@ -365,6 +493,18 @@ type MetadataCacheStore interface {
 	DeleteCachedSearch(ctx context.Context, region audible.Region, query string) error
 }
 
+// ProviderCacheStore covers the response cache of the metadata providers
+// other than Audible.
+type ProviderCacheStore interface {
+	GetCachedProviderBook(ctx context.Context, provider, id string) (*CachedProviderBook, error)
+	SetCachedProviderBook(ctx context.Context, provider, id string, book *metadata.Book) error
+	DeleteCachedProviderBook(ctx context.Context, provider, id string) error
+	GetCachedProviderSearch(ctx context.Context, provider, query string) (*CachedProviderSearch, error)
+	SetCachedProviderSearch(ctx context.Context, provider, query string, results []metadata.SearchResult) error
+	GetCachedProviderContributor(ctx context.Context, provider, id string) (*CachedProviderContributor, error)
+	SetCachedProviderContributor(ctx context.Context, provider, id string, profile *metadata.ContributorProfile) error
+}
+
 // TranscodeStore covers transcode-job rows.
 type TranscodeStore interface {
 	CreateTranscodeJob(ctx context.Context, job *domain.TranscodeJob) error
@@ -405,6 +545,7 @@ type ABSImportStore interface {
 	RecalculateSessionStatusesForUser(ctx context.Context, importID, absUserID string) error
 	GetABSImportStats(ctx context.Context, importID string) (mapped, unmapped, ready, imported int, err error)
 	CreateABSImportProgress(ctx context.Context, progress *domain.ABSImportProgress) error
+	UpdateABSImportProgress(ctx context.Context, progress *domain.ABSImportProgress) error
 	GetABSImportProgress(ctx context.Context, importID, absUserID, absMediaID string) (*domain.ABSImportProgress, error)
 	ListABSImportProgressForUser(ctx context.Context, importID, absUserID string) ([]*domain.ABSImportProgress, error)
 	FindABSImportProgressByListenUpBook(ctx context.Context, importID, absUserID, listenUpBookID string) (*domain.ABSImportProgress, error)
@@ -422,6 +563,14 @@ type BackupStore interface {
 	StreamShelves(ctx context.Context) iter.Seq2[*domain.Shelf, error]
 	StreamActivities(ctx context.Context) iter.Seq2[*domain.Activity, error]
 	StreamListeningEvents(ctx context.Context) iter.Seq2[*domain.ListeningEvent, error]
+	StreamBookmarks(ctx context.Context) iter.Seq2[*domain.Bookmark, error]
+	StreamBookReviews(ctx context.Context) iter.Seq2[*domain.BookReview, error]
+	StreamPodcasts(ctx context.Context) iter.Seq2[*domain.Podcast, error]
+	StreamPodcastEpisodes(ctx context.Context) iter.Seq2[*domain.PodcastEpisode, error]
+	StreamEpisodeListeningEvents(ctx context.Context) iter.Seq2[*domain.ListeningEvent, error]
+	StreamEpisodePlaybackStates(ctx context.Context) iter.Seq2[*domain.PlaybackState, error]
+	StreamEbookPositions(ctx context.Context) iter.Seq2[*domain.EbookPosition, error]
+	StreamListeningGoals(ctx context.Context) iter.Seq2[*domain.ListeningGoal, error]
 	StreamProfiles(ctx context.Context) iter.Seq2[*domain.UserProfile, error]
 	ClearAllData(ctx context.Context) error
 	ClearAllProgress(ctx context.Context) error
@@ -453,10 +602,21 @@ type Store interface {
 	TagStore
 	ShelfStore
 	ListeningStore
+	GoalStore
+	RecapStore
+	BookmarkStore
+	TrashStore
+	ReviewStore
+	PodcastStore
+	FeedTokenStore
+	PasswordResetStore
+	TwoFactorStore
+	OIDCIdentityStore
 	InviteStore
 	InstanceStore
 	SettingsStore
 	MetadataCacheStore
+	ProviderCacheStore
 	TranscodeStore
 	ABSImportStore
 	BackupStore
@@ -494,6 +654,8 @@ type SearchIndexer interface {
 	DeleteContributor(ctx context.Context, contributorID string) error
 	IndexSeries(ctx context.Context, s *domain.Series) error
 	DeleteSeries(ctx context.Context, seriesID string) error
+	IndexPodcast(ctx context.Context, p *domain.Podcast) error
+	DeletePodcast(ctx context.Context, podcastID string) error
 }
 
 // NoopSearchIndexer is a no-op implementation for testing.
@@ -505,6 +667,8 @@ func (NoopSearchIndexer) IndexContributor(context.Context, *domain.Contributor)
 func (NoopSearchIndexer) DeleteContributor(context.Context, string) error             { return nil }
 func (NoopSearchIndexer) IndexSeries(context.Context, *domain.Series) error           { return nil }
 func (NoopSearchIndexer) DeleteSeries(context.Context, string) error                  { return nil }
+func (NoopSearchIndexer) IndexPodcast(context.Context, *domain.Podcast) error         { return nil }
+func (NoopSearchIndexer) DeletePodcast(context.Context, string) error                 { return nil }
 
 // NewNoopSearchIndexer creates a new no-op search indexer for testing.
 func NewNoopSearchIndexer() SearchIndexer { return NoopSearchIndexer{} }
@@ -11,6 +11,7 @@ type PaginationParams struct {
 	Cursor       string
 	Limit        int
 	UpdatedAfter time.Time
+	LibraryID    string // Optional: restrict to one library's books
 }
 
 // PaginatedResult contains paginated data and metadata.
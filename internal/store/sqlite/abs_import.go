@@ -17,17 +17,19 @@ import (
 
 // absImportColumns is the ordered list of columns selected in abs_imports queries.
 const absImportColumns = `id, name, backup_path, status, created_at, updated_at, completed_at,
-	total_users, total_books, total_sessions, users_mapped, books_mapped, sessions_imported`
+	total_users, total_books, total_sessions, users_mapped, books_mapped, sessions_imported,
+	server_url, api_token, last_synced_at`
 
 // scanABSImport scans a sql.Row (or sql.Rows via its Scan method) into a domain.ABSImport.
 func scanABSImport(scanner interface{ Scan(dest ...any) error }) (*domain.ABSImport, error) {
 	var imp domain.ABSImport
 
 	var (
-		status      string
-		createdAt   string
-		updatedAt   string
-		completedAt sql.NullString
+		status       string
+		createdAt    string
+		updatedAt    string
+		completedAt  sql.NullString
+		lastSyncedAt sql.NullString
 	)
 
 	err := scanner.Scan(
@@ -44,6 +46,9 @@ func scanABSImport(scanner interface{ Scan(dest ...any) error }) (*domain.ABSImp
 		&imp.UsersMapped,
 		&imp.BooksMapped,
 		&imp.SessionsImported,
+		&imp.ServerURL,
+		&imp.APIToken,
+		&lastSyncedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -63,6 +68,10 @@ func scanABSImport(scanner interface{ Scan(dest ...any) error }) (*domain.ABSImp
 	if err != nil {
 		return nil, err
 	}
+	imp.LastSyncedAt, err = parseNullableTime(lastSyncedAt)
+	if err != nil {
+		return nil, err
+	}
 
 	return &imp, nil
 }
@@ -73,8 +82,9 @@ func (s *Store) CreateABSImport(ctx context.Context, imp *domain.ABSImport) erro
 	_, err := s.db.ExecContext(ctx, `
 		INSERT INTO abs_imports (
 			id, name, backup_path, status, created_at, updated_at, completed_at,
-			total_users, total_books, total_sessions, users_mapped, books_mapped, sessions_imported
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			total_users, total_books, total_sessions, users_mapped, books_mapped, sessions_imported,
+			server_url, api_token, last_synced_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		imp.ID,
 		imp.Name,
 		imp.BackupPath,
@@ -88,6 +98,9 @@ func (s *Store) CreateABSImport(ctx context.Context, imp *domain.ABSImport) erro
 		imp.UsersMapped,
 		imp.BooksMapped,
 		imp.SessionsImported,
+		imp.ServerURL,
+		imp.APIToken,
+		nullTimeString(imp.LastSyncedAt),
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -153,7 +166,10 @@ func (s *Store) UpdateABSImport(ctx context.Context, imp *domain.ABSImport) erro
 			total_sessions = ?,
 			users_mapped = ?,
 			books_mapped = ?,
-			sessions_imported = ?
+			sessions_imported = ?,
+			server_url = ?,
+			api_token = ?,
+			last_synced_at = ?
 		WHERE id = ?`,
 		imp.Name,
 		imp.BackupPath,
@@ -167,6 +183,9 @@ func (s *Store) UpdateABSImport(ctx context.Context, imp *domain.ABSImport) erro
 		imp.UsersMapped,
 		imp.BooksMapped,
 		imp.SessionsImported,
+		imp.ServerURL,
+		imp.APIToken,
+		nullTimeString(imp.LastSyncedAt),
 		imp.ID,
 	)
 	if err != nil {
@@ -865,8 +884,9 @@ func (s *Store) GetABSImportStats(ctx context.Context, importID string) (mapped,
 // --- ABSImportProgress ---
 
 // absImportProgressColumns is the ordered list of columns selected in abs_import_progress queries.
+// current_time is quoted: unquoted, SQLite reads it as the CURRENT_TIME keyword.
 const absImportProgressColumns = `import_id, abs_user_id, abs_media_id,
-	current_time, duration, progress, is_finished, finished_at,
+	"current_time", duration, progress, is_finished, finished_at,
 	last_update, status, imported_at`
 
 // scanABSImportProgress scans a sql.Row (or sql.Rows via its Scan method) into a domain.ABSImportProgress.
@@ -948,6 +968,43 @@ func (s *Store) CreateABSImportProgress(ctx context.Context, progress *domain.AB
 	return nil
 }
 
+// UpdateABSImportProgress refreshes a staged progress entry with newer
+// position data from ABS. Its import status is left alone.
+// Returns store.ErrNotFound if the progress entry does not exist.
+func (s *Store) UpdateABSImportProgress(ctx context.Context, progress *domain.ABSImportProgress) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE abs_import_progress SET
+			current_time = ?,
+			duration = ?,
+			progress = ?,
+			is_finished = ?,
+			finished_at = ?,
+			last_update = ?
+		WHERE import_id = ? AND abs_user_id = ? AND abs_media_id = ?`,
+		progress.CurrentTime,
+		progress.Duration,
+		progress.Progress,
+		boolToInt(progress.IsFinished),
+		nullTimeString(progress.FinishedAt),
+		formatTime(progress.LastUpdate),
+		progress.ImportID,
+		progress.ABSUserID,
+		progress.ABSMediaID,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
 // GetABSImportProgress retrieves an ABS import progress entry.
 // Returns store.ErrNotFound if the progress entry does not exist.
 func (s *Store) GetABSImportProgress(ctx context.Context, importID, absUserID, absMediaID string) (*domain.ABSImportProgress, error) {
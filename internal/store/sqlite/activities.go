@@ -16,7 +16,7 @@ import (
 const activityColumns = `id, user_id, type, created_at,
 	user_display_name, user_avatar_color, user_avatar_type, user_avatar_value,
 	book_id, book_title, book_author_name, book_cover_path, is_reread,
-	duration_ms, milestone_value, milestone_unit, lens_id, lens_name`
+	duration_ms, milestone_value, milestone_unit, lens_id, lens_name, rating`
 
 // scanActivity scans a sql.Row (or sql.Rows via its Scan method) into a domain.Activity.
 func scanActivity(scanner interface{ Scan(dest ...any) error }) (*domain.Activity, error) {
@@ -54,6 +54,7 @@ func scanActivity(scanner interface{ Scan(dest ...any) error }) (*domain.Activit
 		&milestoneUnit,
 		&lensID,
 		&lensName,
+		&a.Rating,
 	)
 	if err != nil {
 		return nil, err
@@ -105,8 +106,8 @@ func (s *Store) CreateActivity(ctx context.Context, activity *domain.Activity) e
 			id, user_id, type, created_at,
 			user_display_name, user_avatar_color, user_avatar_type, user_avatar_value,
 			book_id, book_title, book_author_name, book_cover_path, is_reread,
-			duration_ms, milestone_value, milestone_unit, lens_id, lens_name
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			duration_ms, milestone_value, milestone_unit, lens_id, lens_name, rating
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		activity.ID,
 		activity.UserID,
 		string(activity.Type),
@@ -125,6 +126,7 @@ func (s *Store) CreateActivity(ctx context.Context, activity *domain.Activity) e
 		nullString(activity.MilestoneUnit),
 		nullString(activity.ShelfID),
 		nullString(activity.ShelfName),
+		activity.Rating,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
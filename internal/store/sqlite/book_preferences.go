@@ -17,6 +17,7 @@ func scanBookPreferences(scanner interface{ Scan(dest ...any) error }) (*domain.
 		playbackSpeed             sql.NullFloat64
 		skipForwardSec            sql.NullInt64
 		hideFromContinueListening int
+		privateListening          int
 		updatedAt                 string
 	)
 
@@ -26,6 +27,7 @@ func scanBookPreferences(scanner interface{ Scan(dest ...any) error }) (*domain.
 		&playbackSpeed,
 		&skipForwardSec,
 		&hideFromContinueListening,
+		&privateListening,
 		&updatedAt,
 	)
 	if err != nil {
@@ -44,6 +46,7 @@ func scanBookPreferences(scanner interface{ Scan(dest ...any) error }) (*domain.
 
 	// Boolean fields.
 	bp.HideFromContinueListening = hideFromContinueListening != 0
+	bp.PrivateListening = privateListening != 0
 
 	// Parse timestamp.
 	bp.UpdatedAt, err = parseTime(updatedAt)
@@ -59,7 +62,7 @@ func scanBookPreferences(scanner interface{ Scan(dest ...any) error }) (*domain.
 func (s *Store) GetBookPreferences(ctx context.Context, userID, bookID string) (*domain.BookPreferences, error) {
 	row := s.db.QueryRowContext(ctx, `
 		SELECT user_id, book_id, playback_speed, skip_forward_sec,
-		       hide_from_continue_listening, updated_at
+		       hide_from_continue_listening, private_listening, updated_at
 		FROM book_preferences
 		WHERE user_id = ? AND book_id = ?`,
 		userID, bookID)
@@ -90,13 +93,14 @@ func (s *Store) UpsertBookPreferences(ctx context.Context, prefs *domain.BookPre
 	_, err := s.db.ExecContext(ctx, `
 		INSERT OR REPLACE INTO book_preferences (
 			user_id, book_id, playback_speed, skip_forward_sec,
-			hide_from_continue_listening, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?)`,
+			hide_from_continue_listening, private_listening, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`,
 		prefs.UserID,
 		prefs.BookID,
 		playbackSpeed,
 		skipForwardSec,
 		boolToInt(prefs.HideFromContinueListening),
+		boolToInt(prefs.PrivateListening),
 		formatTime(prefs.UpdatedAt),
 	)
 	return err
@@ -115,7 +119,7 @@ func (s *Store) DeleteBookPreferences(ctx context.Context, userID, bookID string
 func (s *Store) GetAllBookPreferences(ctx context.Context, userID string) ([]*domain.BookPreferences, error) {
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT user_id, book_id, playback_speed, skip_forward_sec,
-		       hide_from_continue_listening, updated_at
+		       hide_from_continue_listening, private_listening, updated_at
 		FROM book_preferences
 		WHERE user_id = ?
 		ORDER BY updated_at DESC`,
@@ -28,6 +28,7 @@ func TestUpsertAndGetBookPreferences(t *testing.T) {
 		PlaybackSpeed:             &speed,
 		SkipForwardSec:            &skip,
 		HideFromContinueListening: true,
+		PrivateListening:          true,
 		UpdatedAt:                 now,
 	}
 
@@ -62,6 +63,9 @@ func TestUpsertAndGetBookPreferences(t *testing.T) {
 	if !got.HideFromContinueListening {
 		t.Error("HideFromContinueListening: expected true")
 	}
+	if !got.PrivateListening {
+		t.Error("PrivateListening: expected true")
+	}
 	if got.UpdatedAt.Unix() != now.Unix() {
 		t.Errorf("UpdatedAt: got %v, want %v", got.UpdatedAt, now)
 	}
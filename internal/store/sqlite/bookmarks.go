@@ -0,0 +1,271 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// bookmarkColumns is the ordered list of columns selected in bookmark queries.
+// Must match the scan order in scanBookmark.
+const bookmarkColumns = `id, created_at, updated_at, deleted_at, user_id, book_id,
+	audio_file_id, position_ms, end_position_ms, title, note`
+
+// scanBookmark scans a sql.Row (or sql.Rows via its Scan method) into a domain.Bookmark.
+func scanBookmark(scanner interface{ Scan(dest ...any) error }) (*domain.Bookmark, error) {
+	var b domain.Bookmark
+
+	var (
+		createdAt     string
+		updatedAt     string
+		deletedAt     sql.NullString
+		audioFileID   sql.NullString
+		endPositionMs sql.NullInt64
+		title         sql.NullString
+		note          sql.NullString
+	)
+
+	err := scanner.Scan(
+		&b.ID,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
+		&b.UserID,
+		&b.BookID,
+		&audioFileID,
+		&b.PositionMs,
+		&endPositionMs,
+		&title,
+		&note,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse timestamps.
+	b.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	b.UpdatedAt, err = parseTime(updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	b.DeletedAt, err = parseNullableTime(deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Optional fields.
+	if audioFileID.Valid {
+		b.AudioFileID = audioFileID.String
+	}
+	if endPositionMs.Valid {
+		v := endPositionMs.Int64
+		b.EndPositionMs = &v
+	}
+	if title.Valid {
+		b.Title = title.String
+	}
+	if note.Valid {
+		b.Note = note.String
+	}
+
+	return &b, nil
+}
+
+// queryBookmarks runs a bookmark SELECT and scans every row.
+func (s *Store) queryBookmarks(ctx context.Context, query string, args ...any) ([]*domain.Bookmark, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookmarks []*domain.Bookmark
+	for rows.Next() {
+		b, err := scanBookmark(rows)
+		if err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return bookmarks, nil
+}
+
+// nullEndPosition converts an optional clip end position to a SQL value.
+func nullEndPosition(v *int64) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *v, Valid: true}
+}
+
+// CreateBookmark inserts a new bookmark.
+// Returns store.ErrAlreadyExists on duplicate ID.
+func (s *Store) CreateBookmark(ctx context.Context, b *domain.Bookmark) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO bookmarks (
+			id, created_at, updated_at, deleted_at, user_id, book_id,
+			audio_file_id, position_ms, end_position_ms, title, note
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		b.ID,
+		formatTime(b.CreatedAt),
+		formatTime(b.UpdatedAt),
+		nullTimeString(b.DeletedAt),
+		b.UserID,
+		b.BookID,
+		nullString(b.AudioFileID),
+		b.PositionMs,
+		nullEndPosition(b.EndPositionMs),
+		nullString(b.Title),
+		nullString(b.Note),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetBookmark retrieves a non-deleted bookmark by ID.
+// Returns store.ErrBookmarkNotFound if the bookmark does not exist.
+func (s *Store) GetBookmark(ctx context.Context, id string) (*domain.Bookmark, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+bookmarkColumns+` FROM bookmarks WHERE id = ? AND deleted_at IS NULL`, id)
+
+	b, err := scanBookmark(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrBookmarkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// UpdateBookmark replaces all mutable fields of an existing bookmark.
+// Returns store.ErrBookmarkNotFound if the bookmark does not exist.
+func (s *Store) UpdateBookmark(ctx context.Context, b *domain.Bookmark) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE bookmarks SET
+			created_at = ?,
+			updated_at = ?,
+			deleted_at = ?,
+			user_id = ?,
+			book_id = ?,
+			audio_file_id = ?,
+			position_ms = ?,
+			end_position_ms = ?,
+			title = ?,
+			note = ?
+		WHERE id = ?`,
+		formatTime(b.CreatedAt),
+		formatTime(b.UpdatedAt),
+		nullTimeString(b.DeletedAt),
+		b.UserID,
+		b.BookID,
+		nullString(b.AudioFileID),
+		b.PositionMs,
+		nullEndPosition(b.EndPositionMs),
+		nullString(b.Title),
+		nullString(b.Note),
+		b.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrBookmarkNotFound
+	}
+	return nil
+}
+
+// DeleteBookmark soft-deletes a bookmark so the deletion reaches other devices via delta sync.
+// Returns store.ErrBookmarkNotFound if the bookmark does not exist or is already deleted.
+func (s *Store) DeleteBookmark(ctx context.Context, id string) error {
+	now := formatTime(time.Now())
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE bookmarks SET deleted_at = ?, updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL`,
+		now, now, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrBookmarkNotFound
+	}
+	return nil
+}
+
+// ListBookmarksForUserBook returns a user's non-deleted bookmarks for a book, ordered by position.
+func (s *Store) ListBookmarksForUserBook(ctx context.Context, userID, bookID string) ([]*domain.Bookmark, error) {
+	return s.queryBookmarks(ctx,
+		`SELECT `+bookmarkColumns+` FROM bookmarks
+		WHERE user_id = ? AND book_id = ? AND deleted_at IS NULL
+		ORDER BY position_ms ASC, created_at ASC`,
+		userID, bookID)
+}
+
+// ListBookmarksForUser returns all of a user's non-deleted bookmarks, ordered by creation time.
+func (s *Store) ListBookmarksForUser(ctx context.Context, userID string) ([]*domain.Bookmark, error) {
+	return s.queryBookmarks(ctx,
+		`SELECT `+bookmarkColumns+` FROM bookmarks
+		WHERE user_id = ? AND deleted_at IS NULL
+		ORDER BY created_at ASC`,
+		userID)
+}
+
+// GetBookmarksForUserUpdatedAfter returns a user's non-deleted bookmarks updated after the given timestamp.
+func (s *Store) GetBookmarksForUserUpdatedAfter(ctx context.Context, userID string, timestamp time.Time) ([]*domain.Bookmark, error) {
+	return s.queryBookmarks(ctx,
+		`SELECT `+bookmarkColumns+` FROM bookmarks
+		WHERE user_id = ? AND updated_at > ? AND deleted_at IS NULL
+		ORDER BY updated_at ASC`,
+		userID, formatTime(timestamp))
+}
+
+// GetBookmarksDeletedAfter returns the IDs of a user's bookmarks soft-deleted after the given timestamp.
+func (s *Store) GetBookmarksDeletedAfter(ctx context.Context, userID string, timestamp time.Time) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id FROM bookmarks WHERE user_id = ? AND deleted_at > ?`,
+		userID, formatTime(timestamp))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
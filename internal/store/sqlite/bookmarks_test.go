@@ -0,0 +1,227 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func makeTestBookmark(id, userID, bookID string, positionMs int64) *domain.Bookmark {
+	now := time.Now().UTC()
+	return &domain.Bookmark{
+		Syncable: domain.Syncable{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserID:     userID,
+		BookID:     bookID,
+		PositionMs: positionMs,
+	}
+}
+
+func TestCreateAndGetBookmark(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-bm-1")
+	insertTestBook(t, s, "book-bm-1", "Bookmark Book", "/books/bm-1")
+
+	end := int64(95_000)
+	bm := makeTestBookmark("bm-1", "user-bm-1", "book-bm-1", 60_000)
+	bm.AudioFileID = "af-1"
+	bm.EndPositionMs = &end
+	bm.Title = "Great quote"
+	bm.Note = "Chapter 3 opening"
+
+	if err := s.CreateBookmark(ctx, bm); err != nil {
+		t.Fatalf("CreateBookmark: %v", err)
+	}
+
+	got, err := s.GetBookmark(ctx, "bm-1")
+	if err != nil {
+		t.Fatalf("GetBookmark: %v", err)
+	}
+
+	if got.UserID != bm.UserID || got.BookID != bm.BookID {
+		t.Errorf("owner: got %s/%s, want %s/%s", got.UserID, got.BookID, bm.UserID, bm.BookID)
+	}
+	if got.AudioFileID != "af-1" {
+		t.Errorf("AudioFileID: got %q, want %q", got.AudioFileID, "af-1")
+	}
+	if got.PositionMs != 60_000 {
+		t.Errorf("PositionMs: got %d, want %d", got.PositionMs, 60_000)
+	}
+	if got.EndPositionMs == nil || *got.EndPositionMs != end {
+		t.Errorf("EndPositionMs: got %v, want %d", got.EndPositionMs, end)
+	}
+	if got.Title != bm.Title || got.Note != bm.Note {
+		t.Errorf("Title/Note: got %q/%q, want %q/%q", got.Title, got.Note, bm.Title, bm.Note)
+	}
+	if !got.IsClip() || got.DurationMs() != 35_000 {
+		t.Errorf("clip: IsClip=%v DurationMs=%d", got.IsClip(), got.DurationMs())
+	}
+
+	// Duplicate ID.
+	if err := s.CreateBookmark(ctx, bm); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate create: got %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestGetBookmark_NotFound(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	_, err := s.GetBookmark(ctx, "nope")
+	if !errors.Is(err, store.ErrBookmarkNotFound) {
+		t.Errorf("expected ErrBookmarkNotFound, got %v", err)
+	}
+}
+
+func TestUpdateBookmark(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-bm-2")
+	insertTestBook(t, s, "book-bm-2", "Bookmark Book", "/books/bm-2")
+
+	end := int64(5_000)
+	bm := makeTestBookmark("bm-2", "user-bm-2", "book-bm-2", 1_000)
+	bm.EndPositionMs = &end
+	if err := s.CreateBookmark(ctx, bm); err != nil {
+		t.Fatalf("CreateBookmark: %v", err)
+	}
+
+	bm.PositionMs = 2_000
+	bm.EndPositionMs = nil
+	bm.Note = "updated"
+	bm.UpdatedAt = time.Now().UTC()
+	if err := s.UpdateBookmark(ctx, bm); err != nil {
+		t.Fatalf("UpdateBookmark: %v", err)
+	}
+
+	got, err := s.GetBookmark(ctx, "bm-2")
+	if err != nil {
+		t.Fatalf("GetBookmark: %v", err)
+	}
+	if got.PositionMs != 2_000 || got.EndPositionMs != nil || got.Note != "updated" {
+		t.Errorf("unexpected bookmark after update: %+v", got)
+	}
+
+	missing := makeTestBookmark("bm-missing", "user-bm-2", "book-bm-2", 0)
+	if err := s.UpdateBookmark(ctx, missing); !errors.Is(err, store.ErrBookmarkNotFound) {
+		t.Errorf("update missing: got %v, want ErrBookmarkNotFound", err)
+	}
+}
+
+func TestListBookmarksForUserBook(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-bm-3")
+	insertTestUser(t, s, "user-bm-3b")
+	insertTestBook(t, s, "book-bm-3", "Bookmark Book", "/books/bm-3")
+	insertTestBook(t, s, "book-bm-3b", "Other Book", "/books/bm-3b")
+
+	for _, bm := range []*domain.Bookmark{
+		makeTestBookmark("bm-3c", "user-bm-3", "book-bm-3", 30_000),
+		makeTestBookmark("bm-3a", "user-bm-3", "book-bm-3", 10_000),
+		makeTestBookmark("bm-3b", "user-bm-3", "book-bm-3", 20_000),
+		makeTestBookmark("bm-3-other-book", "user-bm-3", "book-bm-3b", 0),
+		makeTestBookmark("bm-3-other-user", "user-bm-3b", "book-bm-3", 0),
+	} {
+		if err := s.CreateBookmark(ctx, bm); err != nil {
+			t.Fatalf("CreateBookmark(%s): %v", bm.ID, err)
+		}
+	}
+
+	got, err := s.ListBookmarksForUserBook(ctx, "user-bm-3", "book-bm-3")
+	if err != nil {
+		t.Fatalf("ListBookmarksForUserBook: %v", err)
+	}
+	want := []string{"bm-3a", "bm-3b", "bm-3c"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d bookmarks, got %d", len(want), len(got))
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("bookmarks[%d]: got %s, want %s", i, got[i].ID, id)
+		}
+	}
+
+	all, err := s.ListBookmarksForUser(ctx, "user-bm-3")
+	if err != nil {
+		t.Fatalf("ListBookmarksForUser: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("expected 4 bookmarks for user, got %d", len(all))
+	}
+}
+
+func TestDeleteBookmark_DeltaSync(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-bm-4")
+	insertTestBook(t, s, "book-bm-4", "Bookmark Book", "/books/bm-4")
+
+	before := time.Now().UTC().Add(-time.Second)
+
+	keep := makeTestBookmark("bm-4-keep", "user-bm-4", "book-bm-4", 1_000)
+	gone := makeTestBookmark("bm-4-gone", "user-bm-4", "book-bm-4", 2_000)
+	for _, bm := range []*domain.Bookmark{keep, gone} {
+		if err := s.CreateBookmark(ctx, bm); err != nil {
+			t.Fatalf("CreateBookmark(%s): %v", bm.ID, err)
+		}
+	}
+
+	if err := s.DeleteBookmark(ctx, "bm-4-gone"); err != nil {
+		t.Fatalf("DeleteBookmark: %v", err)
+	}
+	if err := s.DeleteBookmark(ctx, "bm-4-gone"); !errors.Is(err, store.ErrBookmarkNotFound) {
+		t.Errorf("second delete: got %v, want ErrBookmarkNotFound", err)
+	}
+	if _, err := s.GetBookmark(ctx, "bm-4-gone"); !errors.Is(err, store.ErrBookmarkNotFound) {
+		t.Errorf("get deleted: got %v, want ErrBookmarkNotFound", err)
+	}
+
+	updated, err := s.GetBookmarksForUserUpdatedAfter(ctx, "user-bm-4", before)
+	if err != nil {
+		t.Fatalf("GetBookmarksForUserUpdatedAfter: %v", err)
+	}
+	if len(updated) != 1 || updated[0].ID != "bm-4-keep" {
+		t.Errorf("updated after: got %v, want [bm-4-keep]", updated)
+	}
+
+	deleted, err := s.GetBookmarksDeletedAfter(ctx, "user-bm-4", before)
+	if err != nil {
+		t.Fatalf("GetBookmarksDeletedAfter: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "bm-4-gone" {
+		t.Errorf("deleted after: got %v, want [bm-4-gone]", deleted)
+	}
+
+	// Soft-deleted rows are still exported so deletions survive a restore.
+	var streamed int
+	for b, err := range s.StreamBookmarks(ctx) {
+		if err != nil {
+			t.Fatalf("StreamBookmarks: %v", err)
+		}
+		if b.ID == "bm-4-gone" && !b.IsDeleted() {
+			t.Error("expected streamed bm-4-gone to be marked deleted")
+		}
+		streamed++
+	}
+	if streamed != 2 {
+		t.Errorf("expected 2 streamed bookmarks, got %d", streamed)
+	}
+}
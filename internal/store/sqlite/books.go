@@ -6,6 +6,7 @@ import (
 	"encoding/json/v2"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -24,7 +25,7 @@ const bookColumns = `id, created_at, updated_at, deleted_at, scanned_at,
 	total_duration, total_size, abridged,
 	cover_path, cover_filename, cover_format, cover_size,
 	cover_inode, cover_mod_time, cover_blur_hash,
-	staged_collection_ids`
+	staged_collection_ids, library_id, ebook_files`
 
 // scanBook scans a sql.Row (or sql.Rows via its Scan method) into a domain.Book.
 func scanBook(scanner interface{ Scan(dest ...any) error }) (*domain.Book, error) {
@@ -54,6 +55,8 @@ func scanBook(scanner interface{ Scan(dest ...any) error }) (*domain.Book, error
 		coverBlurHash sql.NullString
 
 		stagedCollIDs string
+		libraryID     sql.NullString
+		ebookFiles    string
 	)
 
 	err := scanner.Scan(
@@ -83,6 +86,8 @@ func scanBook(scanner interface{ Scan(dest ...any) error }) (*domain.Book, error
 		&coverModTime,
 		&coverBlurHash,
 		&stagedCollIDs,
+		&libraryID,
+		&ebookFiles,
 	)
 	if err != nil {
 		return nil, err
@@ -131,6 +136,9 @@ func scanBook(scanner interface{ Scan(dest ...any) error }) (*domain.Book, error
 	if audibleReg.Valid {
 		b.AudibleRegion = audibleReg.String
 	}
+	if libraryID.Valid {
+		b.LibraryID = libraryID.String
+	}
 
 	// Boolean fields.
 	b.Abridged = abridged != 0
@@ -153,6 +161,13 @@ func scanBook(scanner interface{ Scan(dest ...any) error }) (*domain.Book, error
 		return nil, fmt.Errorf("unmarshal staged_collection_ids: %w", err)
 	}
 
+	// Parse ebook_files JSON array; most books have none.
+	if ebookFiles != "[]" {
+		if err := json.Unmarshal([]byte(ebookFiles), &b.EbookFiles); err != nil {
+			return nil, fmt.Errorf("unmarshal ebook_files: %w", err)
+		}
+	}
+
 	return &b, nil
 }
 
@@ -161,7 +176,8 @@ func (s *Store) loadBookAudioFiles(ctx context.Context, querier interface {
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 }, bookID string) ([]domain.AudioFileInfo, error) {
 	rows, err := querier.QueryContext(ctx, `
-		SELECT id, path, filename, format, codec, size, duration, bitrate, inode, mod_time
+		SELECT id, path, filename, format, codec, size, duration, bitrate, inode, mod_time,
+			loudness_lufs, true_peak_dbtp
 		FROM book_audio_files
 		WHERE book_id = ?
 		ORDER BY sort_order ASC`, bookID)
@@ -175,9 +191,11 @@ func (s *Store) loadBookAudioFiles(ctx context.Context, querier interface {
 		var af domain.AudioFileInfo
 		var codec sql.NullString
 		var bitrate sql.NullInt64
+		var loudness, truePeak sql.NullFloat64
 		err := rows.Scan(
 			&af.ID, &af.Path, &af.Filename, &af.Format, &codec,
 			&af.Size, &af.Duration, &bitrate, &af.Inode, &af.ModTime,
+			&loudness, &truePeak,
 		)
 		if err != nil {
 			return nil, err
@@ -188,6 +206,9 @@ func (s *Store) loadBookAudioFiles(ctx context.Context, querier interface {
 		if bitrate.Valid {
 			af.Bitrate = int(bitrate.Int64)
 		}
+		if loudness.Valid && truePeak.Valid {
+			af.Loudness = &domain.Loudness{IntegratedLUFS: loudness.Float64, TruePeakDBTP: truePeak.Float64}
+		}
 		files = append(files, af)
 	}
 	if err := rows.Err(); err != nil {
@@ -229,17 +250,34 @@ func (s *Store) loadBookChapters(ctx context.Context, querier interface {
 	return chapters, nil
 }
 
+// marshalEbookFiles encodes a book's companion ebooks for the ebook_files
+// column. A book without ebooks is stored as an empty array.
+func marshalEbookFiles(files []domain.EbookFileInfo) (string, error) {
+	data, err := json.Marshal(files)
+	if err != nil {
+		return "", fmt.Errorf("marshal ebook_files: %w", err)
+	}
+	return string(data), nil
+}
+
 // insertBookAudioFiles inserts audio files for a book within a transaction.
 func insertBookAudioFiles(ctx context.Context, tx *sql.Tx, bookID string, files []domain.AudioFileInfo) error {
 	for i, af := range files {
+		var loudness, truePeak sql.NullFloat64
+		if af.Loudness != nil {
+			loudness = sql.NullFloat64{Float64: af.Loudness.IntegratedLUFS, Valid: true}
+			truePeak = sql.NullFloat64{Float64: af.Loudness.TruePeakDBTP, Valid: true}
+		}
 		_, err := tx.ExecContext(ctx, `
 			INSERT INTO book_audio_files (
 				id, book_id, path, filename, format, codec,
-				size, duration, bitrate, inode, mod_time, sort_order
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				size, duration, bitrate, inode, mod_time, sort_order,
+				loudness_lufs, true_peak_dbtp
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			af.ID, bookID, af.Path, af.Filename, af.Format, nullString(af.Codec),
 			af.Size, af.Duration, nullInt64(int64(af.Bitrate)),
 			int64(af.Inode), af.ModTime, i,
+			loudness, truePeak,
 		)
 		if err != nil {
 			return fmt.Errorf("insert audio file %s: %w", af.ID, err)
@@ -303,6 +341,10 @@ func createBookTx(ctx context.Context, tx *sql.Tx, book *domain.Book) error {
 	if err != nil {
 		return fmt.Errorf("marshal staged_collection_ids: %w", err)
 	}
+	ebookJSON, err := marshalEbookFiles(book.EbookFiles)
+	if err != nil {
+		return err
+	}
 
 	coverPath, coverFilename, coverFormat, coverSize, coverInode, coverModTime, coverBlurHash := coverArgs(book.CoverImage)
 
@@ -314,8 +356,8 @@ func createBookTx(ctx context.Context, tx *sql.Tx, book *domain.Book) error {
 			total_duration, total_size, abridged,
 			cover_path, cover_filename, cover_format, cover_size,
 			cover_inode, cover_mod_time, cover_blur_hash,
-			staged_collection_ids
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			staged_collection_ids, library_id, ebook_files
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		book.ID,
 		formatTime(book.CreatedAt),
 		formatTime(book.UpdatedAt),
@@ -337,6 +379,8 @@ func createBookTx(ctx context.Context, tx *sql.Tx, book *domain.Book) error {
 		coverPath, coverFilename, coverFormat, coverSize,
 		coverInode, coverModTime, coverBlurHash,
 		string(stagedJSON),
+		nullString(book.LibraryID),
+		ebookJSON,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -434,6 +478,7 @@ func (s *Store) GetBookByPath(ctx context.Context, path string) (*domain.Book, e
 }
 
 // ListBooks returns a paginated list of non-deleted books ordered by updated_at, id.
+// If params.LibraryID is set, only that library's books are listed.
 // Audio files and chapters are loaded for each book.
 func (s *Store) ListBooks(ctx context.Context, params store.PaginationParams) (*store.PaginatedResult[*domain.Book], error) {
 	params.Validate()
@@ -453,30 +498,33 @@ func (s *Store) ListBooks(ctx context.Context, params store.PaginationParams) (*
 		cursorID = parts[1]
 	}
 
+	where := "deleted_at IS NULL"
+	var args []any
+	if params.LibraryID != "" {
+		where += " AND library_id = ?"
+		args = append(args, params.LibraryID)
+	}
+
 	// Count total non-deleted books.
 	var total int
 	err := s.db.QueryRowContext(ctx,
-		`SELECT COUNT(*) FROM books WHERE deleted_at IS NULL`).Scan(&total)
+		`SELECT COUNT(*) FROM books WHERE `+where, args...).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
 
-	// Fetch limit+1 rows to determine hasMore.
-	var rows *sql.Rows
-	if cursorTime == "" {
-		rows, err = s.db.QueryContext(ctx,
-			`SELECT `+bookColumns+` FROM books
-			WHERE deleted_at IS NULL
-			ORDER BY updated_at ASC, id ASC
-			LIMIT ?`, params.Limit+1)
-	} else {
-		rows, err = s.db.QueryContext(ctx,
-			`SELECT `+bookColumns+` FROM books
-			WHERE deleted_at IS NULL
-			AND (updated_at > ? OR (updated_at = ? AND id > ?))
-			ORDER BY updated_at ASC, id ASC
-			LIMIT ?`, cursorTime, cursorTime, cursorID, params.Limit+1)
+	if cursorTime != "" {
+		where += " AND (updated_at > ? OR (updated_at = ? AND id > ?))"
+		args = append(args, cursorTime, cursorTime, cursorID)
 	}
+	args = append(args, params.Limit+1)
+
+	// Fetch limit+1 rows to determine hasMore.
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+bookColumns+` FROM books
+		WHERE `+where+`
+		ORDER BY updated_at ASC, id ASC
+		LIMIT ?`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -534,6 +582,10 @@ func (s *Store) UpdateBook(ctx context.Context, book *domain.Book) error {
 	if err != nil {
 		return fmt.Errorf("marshal staged_collection_ids: %w", err)
 	}
+	ebookJSON, err := marshalEbookFiles(book.EbookFiles)
+	if err != nil {
+		return err
+	}
 
 	coverPath, coverFilename, coverFormat, coverSize, coverInode, coverModTime, coverBlurHash := coverArgs(book.CoverImage)
 
@@ -552,7 +604,7 @@ func (s *Store) UpdateBook(ctx context.Context, book *domain.Book) error {
 			total_duration = ?, total_size = ?, abridged = ?,
 			cover_path = ?, cover_filename = ?, cover_format = ?, cover_size = ?,
 			cover_inode = ?, cover_mod_time = ?, cover_blur_hash = ?,
-			staged_collection_ids = ?
+			staged_collection_ids = ?, library_id = ?, ebook_files = ?
 		WHERE id = ? AND deleted_at IS NULL`,
 		formatTime(book.CreatedAt),
 		formatTime(book.UpdatedAt),
@@ -573,6 +625,8 @@ func (s *Store) UpdateBook(ctx context.Context, book *domain.Book) error {
 		coverPath, coverFilename, coverFormat, coverSize,
 		coverInode, coverModTime, coverBlurHash,
 		string(stagedJSON),
+		nullString(book.LibraryID),
+		ebookJSON,
 		book.ID,
 	)
 	if err != nil {
@@ -778,6 +832,29 @@ func (s *Store) GetBookByInode(ctx context.Context, inode int64) (*domain.Book,
 	return s.GetBookByID(ctx, bookID)
 }
 
+// SetAudioFileLoudness records the loudness measurement of one of a book's
+// audio files. The book's updated_at is left alone: clients learn the gain
+// when they prepare playback, so there is nothing to sync.
+// Returns store.ErrNotFound if the audio file does not exist.
+func (s *Store) SetAudioFileLoudness(ctx context.Context, bookID, audioFileID string, loudness domain.Loudness) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE book_audio_files SET loudness_lufs = ?, true_peak_dbtp = ?
+		WHERE book_id = ? AND id = ?`,
+		loudness.IntegratedLUFS, loudness.TruePeakDBTP, bookID, audioFileID)
+	if err != nil {
+		return fmt.Errorf("set audio file loudness: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
 // GetBookByASIN retrieves a book by its Amazon ASIN.
 // Returns store.ErrNotFound if no non-deleted book with this ASIN exists.
 func (s *Store) GetBookByASIN(ctx context.Context, asin string) (*domain.Book, error) {
@@ -925,6 +1002,112 @@ func (s *Store) GetAllBookIDs(ctx context.Context) ([]string, error) {
 	return ids, nil
 }
 
+// AssignBooksToLibrary moves every non-deleted book at or under scanPath
+// into the library, bumping updated_at so clients pick up the change.
+// Returns the IDs of the books that moved.
+func (s *Store) AssignBooksToLibrary(ctx context.Context, libraryID, scanPath string) ([]string, error) {
+	root := filepath.Clean(scanPath)
+	// Everything under root/ sorts between root+"/" and root+"0" ('0' follows '/').
+	lower, upper := root+"/", root+"0"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id FROM books
+		WHERE deleted_at IS NULL
+		AND (library_id IS NULL OR library_id != ?)
+		AND (path = ? OR (path >= ? AND path < ?))`,
+		libraryID, root, lower, upper)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	now := formatTime(time.Now())
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE books SET library_id = ?, updated_at = ? WHERE id = ?`,
+			libraryID, now, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FilterBookIDs returns the IDs of non-deleted books matching the filter,
+// most recently added first. A book with no publish year never matches a
+// year bound.
+func (s *Store) FilterBookIDs(ctx context.Context, filter store.BookFilter) ([]string, error) {
+	var (
+		conds = []string{"deleted_at IS NULL"}
+		args  []any
+	)
+	if filter.MinDurationMs > 0 {
+		conds = append(conds, "total_duration >= ?")
+		args = append(args, filter.MinDurationMs)
+	}
+	if filter.MaxDurationMs > 0 {
+		conds = append(conds, "total_duration <= ?")
+		args = append(args, filter.MaxDurationMs)
+	}
+	// publish_year is free text from metadata ("2019", "2019-05-01"); compare
+	// on its leading four digits.
+	if filter.MinYear > 0 {
+		conds = append(conds, "CAST(substr(publish_year, 1, 4) AS INTEGER) >= ?")
+		args = append(args, filter.MinYear)
+	}
+	if filter.MaxYear > 0 {
+		conds = append(conds, "publish_year != ''",
+			"CAST(substr(publish_year, 1, 4) AS INTEGER) <= ?")
+		args = append(args, filter.MaxYear)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conds = append(conds, "created_at >= ?")
+		args = append(args, formatTime(filter.CreatedAfter))
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id FROM books WHERE `+strings.Join(conds, " AND ")+` ORDER BY created_at DESC, id`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // GetBooksByCollectionPaginated returns a paginated list of books in a collection.
 // Books are fetched via the collection_books join table.
 func (s *Store) GetBooksByCollectionPaginated(ctx context.Context, _, collectionID string, params store.PaginationParams) (*store.PaginatedResult[*domain.Book], error) {
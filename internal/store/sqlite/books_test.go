@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -674,6 +675,150 @@ func TestGetBooksDeletedAfter(t *testing.T) {
 	}
 }
 
+func TestFilterBookIDs(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	old := makeTestBook("book-old", "Old Epic", "/audiobooks/old")
+	old.CreatedAt = time.Now().AddDate(0, 0, -60)
+	old.PublishYear = "1954"
+	old.TotalDuration = 40 * 60 * 60 * 1000
+
+	recent := makeTestBook("book-recent", "Recent Novella", "/audiobooks/recent")
+	recent.PublishYear = "2021-03-09"
+	recent.TotalDuration = 3 * 60 * 60 * 1000
+
+	undated := makeTestBook("book-undated", "Undated", "/audiobooks/undated")
+	undated.TotalDuration = 5 * 60 * 60 * 1000
+
+	for _, b := range []*domain.Book{old, recent, undated} {
+		if err := s.CreateBook(ctx, b); err != nil {
+			t.Fatalf("CreateBook %s: %v", b.ID, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter store.BookFilter
+		want   []string
+	}{
+		{"no filter", store.BookFilter{}, []string{"book-recent", "book-undated", "book-old"}},
+		{"max duration", store.BookFilter{MaxDurationMs: 10 * 60 * 60 * 1000}, []string{"book-recent", "book-undated"}},
+		{"min duration", store.BookFilter{MinDurationMs: 4 * 60 * 60 * 1000}, []string{"book-undated", "book-old"}},
+		{"year range", store.BookFilter{MinYear: 2000, MaxYear: 2030}, []string{"book-recent"}},
+		{"max year skips undated", store.BookFilter{MaxYear: 1999}, []string{"book-old"}},
+		{"added within", store.BookFilter{CreatedAfter: time.Now().AddDate(0, 0, -30)}, []string{"book-recent", "book-undated"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.FilterBookIDs(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("FilterBookIDs: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			gotSet := make(map[string]bool, len(got))
+			for _, id := range got {
+				gotSet[id] = true
+			}
+			for _, id := range tt.want {
+				if !gotSet[id] {
+					t.Errorf("got %v, missing %s", got, id)
+				}
+			}
+		})
+	}
+}
+
+func TestListBooks_LibraryFilter(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	kids := makeTestBook("book-kids", "Gruffalo", "/media/kids/gruffalo")
+	kids.LibraryID = "lib-kids"
+	adults := makeTestBook("book-adults", "Dune", "/media/adults/dune")
+	adults.LibraryID = "lib-adults"
+	for _, b := range []*domain.Book{kids, adults} {
+		if err := s.CreateBook(ctx, b); err != nil {
+			t.Fatalf("CreateBook %s: %v", b.ID, err)
+		}
+	}
+
+	got, err := s.GetBook(ctx, "book-kids", "")
+	if err != nil {
+		t.Fatalf("GetBook: %v", err)
+	}
+	if got.LibraryID != "lib-kids" {
+		t.Errorf("LibraryID: got %q, want lib-kids", got.LibraryID)
+	}
+
+	result, err := s.ListBooks(ctx, store.PaginationParams{Limit: 10, LibraryID: "lib-kids"})
+	if err != nil {
+		t.Fatalf("ListBooks: %v", err)
+	}
+	if result.Total != 1 || len(result.Items) != 1 || result.Items[0].ID != "book-kids" {
+		t.Errorf("ListBooks(lib-kids): total=%d items=%v", result.Total, result.Items)
+	}
+
+	result, err = s.ListBooks(ctx, store.PaginationParams{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListBooks: %v", err)
+	}
+	if result.Total != 2 {
+		t.Errorf("ListBooks(all): total=%d, want 2", result.Total)
+	}
+}
+
+func TestAssignBooksToLibrary(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	inside := makeTestBook("book-inside", "Inside", "/media/kids/inside")
+	inside.LibraryID = "lib-adults"
+	root := makeTestBook("book-root", "Root", "/media/kids")
+	sibling := makeTestBook("book-sibling", "Sibling", "/media/kids-old/sibling")
+	sibling.LibraryID = "lib-adults"
+	already := makeTestBook("book-already", "Already", "/media/kids/already")
+	already.LibraryID = "lib-kids"
+	for _, b := range []*domain.Book{inside, root, sibling, already} {
+		if err := s.CreateBook(ctx, b); err != nil {
+			t.Fatalf("CreateBook %s: %v", b.ID, err)
+		}
+	}
+
+	moved, err := s.AssignBooksToLibrary(ctx, "lib-kids", "/media/kids/")
+	if err != nil {
+		t.Fatalf("AssignBooksToLibrary: %v", err)
+	}
+	slices.Sort(moved)
+	if !slices.Equal(moved, []string{"book-inside", "book-root"}) {
+		t.Errorf("moved = %v, want [book-inside book-root]", moved)
+	}
+
+	got, err := s.GetBookByID(ctx, "book-sibling")
+	if err != nil {
+		t.Fatalf("GetBookByID: %v", err)
+	}
+	if got.LibraryID != "lib-adults" {
+		t.Errorf("sibling LibraryID: got %q, want lib-adults", got.LibraryID)
+	}
+
+	got, err = s.GetBookByID(ctx, "book-inside")
+	if err != nil {
+		t.Fatalf("GetBookByID: %v", err)
+	}
+	if got.LibraryID != "lib-kids" {
+		t.Errorf("inside LibraryID: got %q, want lib-kids", got.LibraryID)
+	}
+	if !got.UpdatedAt.After(inside.UpdatedAt) {
+		t.Error("expected updated_at to be bumped")
+	}
+}
+
 func TestBook_NilCoverImage(t *testing.T) {
 	t.Parallel()
 	s := newTestStore(t)
@@ -696,6 +841,52 @@ func TestBook_NilCoverImage(t *testing.T) {
 	}
 }
 
+func TestSetAudioFileLoudness(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	book := makeTestBook("book-loud", "Loud Book", "/audiobooks/loud")
+	book.AudioFiles = []domain.AudioFileInfo{
+		{ID: "af-1", Path: "/audiobooks/loud/01.mp3", Filename: "01.mp3", Format: "mp3", Size: 1000, Duration: 60000, Inode: 11, ModTime: 1},
+		{ID: "af-2", Path: "/audiobooks/loud/02.mp3", Filename: "02.mp3", Format: "mp3", Size: 1000, Duration: 60000, Inode: 12, ModTime: 1},
+	}
+	if err := s.CreateBook(ctx, book); err != nil {
+		t.Fatalf("CreateBook: %v", err)
+	}
+
+	loudness := domain.Loudness{IntegratedLUFS: -21.3, TruePeakDBTP: -2.4}
+	if err := s.SetAudioFileLoudness(ctx, "book-loud", "af-2", loudness); err != nil {
+		t.Fatalf("SetAudioFileLoudness: %v", err)
+	}
+	if err := s.SetAudioFileLoudness(ctx, "book-loud", "af-missing", loudness); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("unknown audio file: expected ErrNotFound, got %v", err)
+	}
+
+	got, err := s.GetBookByID(ctx, "book-loud")
+	if err != nil {
+		t.Fatalf("GetBookByID: %v", err)
+	}
+	if got.AudioFiles[0].Loudness != nil {
+		t.Errorf("af-1 loudness: expected nil, got %+v", got.AudioFiles[0].Loudness)
+	}
+	if got.AudioFiles[1].Loudness == nil || *got.AudioFiles[1].Loudness != loudness {
+		t.Errorf("af-2 loudness: got %+v, want %+v", got.AudioFiles[1].Loudness, loudness)
+	}
+
+	// The measurement survives a full update of the book.
+	if err := s.UpdateBook(ctx, got); err != nil {
+		t.Fatalf("UpdateBook: %v", err)
+	}
+	got, err = s.GetBookByID(ctx, "book-loud")
+	if err != nil {
+		t.Fatalf("GetBookByID after update: %v", err)
+	}
+	if got.AudioFiles[1].Loudness == nil || *got.AudioFiles[1].Loudness != loudness {
+		t.Errorf("af-2 loudness after update: got %+v, want %+v", got.AudioFiles[1].Loudness, loudness)
+	}
+}
+
 func BenchmarkListBooks_Pagination(b *testing.B) {
 	s := newTestStore(b)
 	ctx := context.Background()
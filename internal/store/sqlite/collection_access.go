@@ -19,7 +19,7 @@ var (
 		"b.total_duration, b.total_size, b.abridged, " +
 		"b.cover_path, b.cover_filename, b.cover_format, b.cover_size, " +
 		"b.cover_inode, b.cover_mod_time, b.cover_blur_hash, " +
-		"b.staged_collection_ids"
+		"b.staged_collection_ids, b.library_id, b.ebook_files"
 )
 
 // GetCollectionsForUser returns all collections a user has access to,
@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// ebookPositionColumns is the ordered list of columns selected in ebook
+// position queries. Must match the scan order in scanEbookPosition.
+const ebookPositionColumns = `user_id, book_id, ebook_file_id, cfi, progress, device_id, updated_at`
+
+// scanEbookPosition scans a sql.Row (or sql.Rows via its Scan method) into a domain.EbookPosition.
+func scanEbookPosition(scanner interface{ Scan(dest ...any) error }) (*domain.EbookPosition, error) {
+	var pos domain.EbookPosition
+
+	var (
+		deviceID  sql.NullString
+		updatedAt string
+	)
+
+	err := scanner.Scan(
+		&pos.UserID,
+		&pos.BookID,
+		&pos.EbookFileID,
+		&pos.CFI,
+		&pos.Progress,
+		&deviceID,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if deviceID.Valid {
+		pos.DeviceID = deviceID.String
+	}
+
+	pos.UpdatedAt, err = parseTime(updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pos, nil
+}
+
+// GetEbookPosition retrieves a user's reading position in a book's ebook.
+// Returns store.ErrEbookPositionNotFound if the user hasn't opened it.
+func (s *Store) GetEbookPosition(ctx context.Context, userID, bookID string) (*domain.EbookPosition, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+ebookPositionColumns+` FROM ebook_positions WHERE user_id = ? AND book_id = ?`,
+		userID, bookID)
+
+	pos, err := scanEbookPosition(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrEbookPositionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pos, nil
+}
+
+// UpsertEbookPosition creates or replaces a user's reading position in a book's ebook.
+func (s *Store) UpsertEbookPosition(ctx context.Context, pos *domain.EbookPosition) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO ebook_positions (
+			user_id, book_id, ebook_file_id, cfi, progress, device_id, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		pos.UserID,
+		pos.BookID,
+		pos.EbookFileID,
+		pos.CFI,
+		pos.Progress,
+		nullString(pos.DeviceID),
+		formatTime(pos.UpdatedAt),
+	)
+	return err
+}
+
+// DeleteEbookPosition removes a user's reading position in a book's ebook.
+// This operation is idempotent.
+func (s *Store) DeleteEbookPosition(ctx context.Context, userID, bookID string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM ebook_positions WHERE user_id = ? AND book_id = ?`,
+		userID, bookID)
+	return err
+}
+
+// GetEbookPositionsForUser retrieves all of a user's ebook reading positions,
+// most recently updated first.
+func (s *Store) GetEbookPositionsForUser(ctx context.Context, userID string) ([]*domain.EbookPosition, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+ebookPositionColumns+` FROM ebook_positions WHERE user_id = ? ORDER BY updated_at DESC`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []*domain.EbookPosition
+	for rows.Next() {
+		pos, err := scanEbookPosition(rows)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, pos)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func TestBookEbookFilesRoundTrip(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	book := makeTestBook("book-eb-1", "Ebook Book", "/books/eb-1")
+	book.EbookFiles = []domain.EbookFileInfo{
+		{ID: "eb-1", Path: "/books/eb-1/Book.epub", Filename: "Book.epub", Format: domain.EbookFormatEPUB, Size: 2048, Inode: 1, ModTime: 1700000000000},
+	}
+	if err := s.CreateBook(ctx, book); err != nil {
+		t.Fatalf("CreateBook: %v", err)
+	}
+
+	got, err := s.GetBookByID(ctx, "book-eb-1")
+	if err != nil {
+		t.Fatalf("GetBookByID: %v", err)
+	}
+	if len(got.EbookFiles) != 1 || got.EbookFiles[0] != book.EbookFiles[0] {
+		t.Errorf("EbookFiles: got %+v, want %+v", got.EbookFiles, book.EbookFiles)
+	}
+
+	got.EbookFiles = nil
+	if err := s.UpdateBook(ctx, got); err != nil {
+		t.Fatalf("UpdateBook: %v", err)
+	}
+	got, err = s.GetBookByID(ctx, "book-eb-1")
+	if err != nil {
+		t.Fatalf("GetBookByID after update: %v", err)
+	}
+	if len(got.EbookFiles) != 0 {
+		t.Errorf("EbookFiles after removal: got %+v, want none", got.EbookFiles)
+	}
+}
+
+func TestUpsertAndGetEbookPosition(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-ep-1")
+	insertTestBook(t, s, "book-ep-1", "Position Book", "/books/ep-1")
+	insertTestBook(t, s, "book-ep-2", "Other Book", "/books/ep-2")
+
+	_, err := s.GetEbookPosition(ctx, "user-ep-1", "book-ep-1")
+	if !errors.Is(err, store.ErrEbookPositionNotFound) {
+		t.Fatalf("expected ErrEbookPositionNotFound, got %v", err)
+	}
+
+	now := time.Now().UTC()
+	pos := &domain.EbookPosition{
+		UserID:      "user-ep-1",
+		BookID:      "book-ep-1",
+		EbookFileID: "eb-1",
+		CFI:         "epubcfi(/6/4!/4/2/1:0)",
+		Progress:    0.1,
+		DeviceID:    "phone",
+		UpdatedAt:   now.Add(-time.Hour),
+	}
+	if err := s.UpsertEbookPosition(ctx, pos); err != nil {
+		t.Fatalf("UpsertEbookPosition: %v", err)
+	}
+
+	pos.CFI = "epubcfi(/6/8!/4/2/1:0)"
+	pos.Progress = 0.2
+	pos.DeviceID = ""
+	if err := s.UpsertEbookPosition(ctx, pos); err != nil {
+		t.Fatalf("UpsertEbookPosition (update): %v", err)
+	}
+
+	got, err := s.GetEbookPosition(ctx, "user-ep-1", "book-ep-1")
+	if err != nil {
+		t.Fatalf("GetEbookPosition: %v", err)
+	}
+	if got.CFI != pos.CFI || got.Progress != 0.2 || got.DeviceID != "" || got.EbookFileID != "eb-1" {
+		t.Errorf("got %+v, want %+v", got, pos)
+	}
+
+	if err := s.UpsertEbookPosition(ctx, &domain.EbookPosition{
+		UserID: "user-ep-1", BookID: "book-ep-2", EbookFileID: "eb-2", CFI: "page=3", UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("UpsertEbookPosition (second book): %v", err)
+	}
+	all, err := s.GetEbookPositionsForUser(ctx, "user-ep-1")
+	if err != nil {
+		t.Fatalf("GetEbookPositionsForUser: %v", err)
+	}
+	if len(all) != 2 || all[0].BookID != "book-ep-2" {
+		t.Errorf("expected 2 positions, most recent first; got %+v", all)
+	}
+
+	if err := s.DeleteEbookPosition(ctx, "user-ep-1", "book-ep-1"); err != nil {
+		t.Fatalf("DeleteEbookPosition: %v", err)
+	}
+	if _, err := s.GetEbookPosition(ctx, "user-ep-1", "book-ep-1"); !errors.Is(err, store.ErrEbookPositionNotFound) {
+		t.Errorf("after delete: expected ErrEbookPositionNotFound, got %v", err)
+	}
+}
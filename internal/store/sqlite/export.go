@@ -305,6 +305,295 @@ func (s *Store) StreamListeningEvents(ctx context.Context) iter.Seq2[*domain.Lis
 	}
 }
 
+// StreamBookmarks returns an iterator over all bookmarks, including soft-deleted
+// ones so that deletions survive a restore and still propagate via delta sync.
+func (s *Store) StreamBookmarks(ctx context.Context) iter.Seq2[*domain.Bookmark, error] {
+	return func(yield func(*domain.Bookmark, error) bool) {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT `+bookmarkColumns+` FROM bookmarks ORDER BY created_at ASC`)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			b, err := scanBookmark(rows)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(b, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// StreamBookReviews returns an iterator over all book reviews.
+func (s *Store) StreamBookReviews(ctx context.Context) iter.Seq2[*domain.BookReview, error] {
+	return func(yield func(*domain.BookReview, error) bool) {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT `+reviewColumns+` FROM book_reviews ORDER BY created_at ASC`)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			r, err := scanReview(rows)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(r, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// StreamPodcasts returns an iterator over all podcasts.
+func (s *Store) StreamPodcasts(ctx context.Context) iter.Seq2[*domain.Podcast, error] {
+	return func(yield func(*domain.Podcast, error) bool) {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT `+podcastColumns+` FROM podcasts ORDER BY created_at ASC`)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			p, err := scanPodcast(rows)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(p, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// StreamPodcastEpisodes returns an iterator over all podcast episodes.
+func (s *Store) StreamPodcastEpisodes(ctx context.Context) iter.Seq2[*domain.PodcastEpisode, error] {
+	return func(yield func(*domain.PodcastEpisode, error) bool) {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT `+episodeColumns+` FROM podcast_episodes ORDER BY created_at ASC`)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			e, err := scanEpisode(rows)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(e, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// StreamEpisodeListeningEvents returns an iterator over all episode listening events.
+func (s *Store) StreamEpisodeListeningEvents(ctx context.Context) iter.Seq2[*domain.ListeningEvent, error] {
+	return func(yield func(*domain.ListeningEvent, error) bool) {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT `+episodeListeningEventColumns+` FROM episode_listening_events ORDER BY created_at ASC`)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			e, err := scanListeningEvent(rows)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(e, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// StreamEpisodePlaybackStates returns an iterator over all episode playback states.
+func (s *Store) StreamEpisodePlaybackStates(ctx context.Context) iter.Seq2[*domain.PlaybackState, error] {
+	return func(yield func(*domain.PlaybackState, error) bool) {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT `+episodePlaybackStateColumns+` FROM episode_playback_state ORDER BY updated_at ASC`)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			ps, err := scanPlaybackState(rows)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(ps, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// StreamEbookPositions returns an iterator over all ebook reading positions.
+func (s *Store) StreamEbookPositions(ctx context.Context) iter.Seq2[*domain.EbookPosition, error] {
+	return func(yield func(*domain.EbookPosition, error) bool) {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT `+ebookPositionColumns+` FROM ebook_positions ORDER BY updated_at ASC`)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			pos, err := scanEbookPosition(rows)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(pos, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// StreamListeningGoals returns an iterator over all listening goals.
+func (s *Store) StreamListeningGoals(ctx context.Context) iter.Seq2[*domain.ListeningGoal, error] {
+	return func(yield func(*domain.ListeningGoal, error) bool) {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT `+listeningGoalColumns+` FROM listening_goals ORDER BY created_at ASC`)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			g, err := scanListeningGoal(rows)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(g, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
 // StreamProfiles returns an iterator over all user profiles.
 func (s *Store) StreamProfiles(ctx context.Context) iter.Seq2[*domain.UserProfile, error] {
 	return func(yield func(*domain.UserProfile, error) bool) {
@@ -400,10 +689,18 @@ func (s *Store) ClearAllData(ctx context.Context) error {
 		"user_stats",
 		"user_milestone_states",
 		"activities",
+		"bookmarks",
 		"book_reading_sessions",
 		"book_preferences",
+		"ebook_positions",
+		"listening_goals",
+		"listening_recaps",
 		"playback_state",
 		"listening_events",
+		"episode_playback_state",
+		"episode_listening_events",
+		"podcast_episodes",
+		"podcasts",
 		"shelf_books",
 		"shelves",
 		"collection_shares",
@@ -415,6 +712,8 @@ func (s *Store) ClearAllData(ctx context.Context) error {
 		"book_audio_files",
 		"book_contributors",
 		"book_series",
+		"book_trash",
+		"book_reviews",
 		"books",
 		"genre_aliases",
 		"unmapped_genres",
@@ -426,6 +725,7 @@ func (s *Store) ClearAllData(ctx context.Context) error {
 		"user_profiles",
 		"user_settings",
 		"sessions",
+		"feed_tokens",
 		"libraries",
 		"server_settings",
 		"instance",
@@ -433,6 +733,7 @@ func (s *Store) ClearAllData(ctx context.Context) error {
 		"audible_cache_books",
 		"audible_cache_chapters",
 		"audible_cache_search",
+		"metadata_provider_cache",
 	}
 
 	for _, table := range tables {
@@ -0,0 +1,155 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// feedTokenColumns is the ordered list of columns selected in feed token
+// queries. Must match the scan order in scanFeedToken.
+const feedTokenColumns = `id, user_id, name, token_hash, created_at, last_used_at`
+
+// scanFeedToken scans a sql.Row (or sql.Rows via its Scan method) into a domain.FeedToken.
+func scanFeedToken(scanner interface{ Scan(dest ...any) error }) (*domain.FeedToken, error) {
+	var t domain.FeedToken
+
+	var (
+		createdAt  string
+		lastUsedAt sql.NullString
+	)
+
+	err := scanner.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Name,
+		&t.TokenHash,
+		&createdAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	t.LastUsedAt, err = parseNullableTime(lastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// CreateFeedToken inserts a new feed token.
+// Returns store.ErrAlreadyExists if the ID or hash is taken.
+func (s *Store) CreateFeedToken(ctx context.Context, t *domain.FeedToken) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO feed_tokens (`+feedTokenColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		t.ID,
+		t.UserID,
+		t.Name,
+		t.TokenHash,
+		formatTime(t.CreatedAt),
+		nullTimeString(t.LastUsedAt),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetFeedToken retrieves a feed token by ID.
+// Returns store.ErrFeedTokenNotFound if it does not exist.
+func (s *Store) GetFeedToken(ctx context.Context, id string) (*domain.FeedToken, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+feedTokenColumns+` FROM feed_tokens WHERE id = ?`, id)
+
+	t, err := scanFeedToken(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrFeedTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetFeedTokenByHash retrieves a feed token by the hash of its secret.
+// Returns store.ErrFeedTokenNotFound if no token matches.
+func (s *Store) GetFeedTokenByHash(ctx context.Context, tokenHash string) (*domain.FeedToken, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+feedTokenColumns+` FROM feed_tokens WHERE token_hash = ?`, tokenHash)
+
+	t, err := scanFeedToken(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrFeedTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListFeedTokensForUser returns a user's feed tokens, newest first.
+func (s *Store) ListFeedTokensForUser(ctx context.Context, userID string) ([]*domain.FeedToken, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+feedTokenColumns+` FROM feed_tokens
+		WHERE user_id = ?
+		ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*domain.FeedToken
+	for rows.Next() {
+		t, err := scanFeedToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// DeleteFeedToken removes a feed token, revoking it.
+// Returns store.ErrFeedTokenNotFound if it does not exist.
+func (s *Store) DeleteFeedToken(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx,
+		`DELETE FROM feed_tokens WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrFeedTokenNotFound
+	}
+	return nil
+}
+
+// TouchFeedToken records when a feed token was last used.
+func (s *Store) TouchFeedToken(ctx context.Context, id string, usedAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE feed_tokens SET last_used_at = ? WHERE id = ?`,
+		formatTime(usedAt), id)
+	return err
+}
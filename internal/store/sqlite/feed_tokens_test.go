@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func TestFeedTokenLifecycle(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-ft-1")
+
+	tok := &domain.FeedToken{
+		ID:        "ft-1",
+		UserID:    "user-ft-1",
+		Name:      "Podcast app",
+		TokenHash: "hash-1",
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.CreateFeedToken(ctx, tok); err != nil {
+		t.Fatalf("CreateFeedToken: %v", err)
+	}
+
+	dup := *tok
+	dup.ID = "ft-2"
+	if err := s.CreateFeedToken(ctx, &dup); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate hash: got %v, want ErrAlreadyExists", err)
+	}
+
+	got, err := s.GetFeedTokenByHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("GetFeedTokenByHash: %v", err)
+	}
+	if got.ID != "ft-1" || got.Name != "Podcast app" || got.LastUsedAt != nil {
+		t.Errorf("got %+v", got)
+	}
+
+	usedAt := time.Now().UTC().Add(time.Minute)
+	if err := s.TouchFeedToken(ctx, "ft-1", usedAt); err != nil {
+		t.Fatalf("TouchFeedToken: %v", err)
+	}
+	got, err = s.GetFeedToken(ctx, "ft-1")
+	if err != nil {
+		t.Fatalf("GetFeedToken: %v", err)
+	}
+	if got.LastUsedAt == nil || got.LastUsedAt.Unix() != usedAt.Unix() {
+		t.Errorf("LastUsedAt: got %v, want %v", got.LastUsedAt, usedAt)
+	}
+
+	tokens, err := s.ListFeedTokensForUser(ctx, "user-ft-1")
+	if err != nil {
+		t.Fatalf("ListFeedTokensForUser: %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Fatalf("ListFeedTokensForUser: got %d tokens, want 1", len(tokens))
+	}
+
+	if err := s.DeleteFeedToken(ctx, "ft-1"); err != nil {
+		t.Fatalf("DeleteFeedToken: %v", err)
+	}
+	if _, err := s.GetFeedTokenByHash(ctx, "hash-1"); !errors.Is(err, store.ErrFeedTokenNotFound) {
+		t.Errorf("after delete: got %v, want ErrFeedTokenNotFound", err)
+	}
+	if err := s.DeleteFeedToken(ctx, "ft-1"); !errors.Is(err, store.ErrFeedTokenNotFound) {
+		t.Errorf("second delete: got %v, want ErrFeedTokenNotFound", err)
+	}
+}
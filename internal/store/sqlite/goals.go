@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// listeningGoalColumns is the ordered list of columns selected in goal queries.
+// Must match the scan order in scanListeningGoal.
+const listeningGoalColumns = `id, user_id, metric, period, target,
+	starts_at, ends_at, completed_at, created_at, updated_at`
+
+// scanListeningGoal scans a sql.Row (or sql.Rows via its Scan method) into a domain.ListeningGoal.
+func scanListeningGoal(scanner interface{ Scan(dest ...any) error }) (*domain.ListeningGoal, error) {
+	var g domain.ListeningGoal
+
+	var (
+		metric      string
+		period      string
+		startsAt    sql.NullString
+		endsAt      sql.NullString
+		completedAt sql.NullString
+		createdAt   string
+		updatedAt   string
+	)
+
+	err := scanner.Scan(
+		&g.ID,
+		&g.UserID,
+		&metric,
+		&period,
+		&g.Target,
+		&startsAt,
+		&endsAt,
+		&completedAt,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Metric = domain.GoalMetric(metric)
+	g.Period = domain.GoalPeriod(period)
+
+	if g.StartsAt, err = parseNullableTime(startsAt); err != nil {
+		return nil, err
+	}
+	if g.EndsAt, err = parseNullableTime(endsAt); err != nil {
+		return nil, err
+	}
+	if g.CompletedAt, err = parseNullableTime(completedAt); err != nil {
+		return nil, err
+	}
+	if g.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if g.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+// GetListeningGoal retrieves a listening goal by ID.
+// Returns store.ErrGoalNotFound if the goal does not exist.
+func (s *Store) GetListeningGoal(ctx context.Context, id string) (*domain.ListeningGoal, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+listeningGoalColumns+` FROM listening_goals WHERE id = ?`, id)
+
+	g, err := scanListeningGoal(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrGoalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// UpsertListeningGoal creates or replaces a listening goal.
+func (s *Store) UpsertListeningGoal(ctx context.Context, g *domain.ListeningGoal) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO listening_goals (
+			id, user_id, metric, period, target,
+			starts_at, ends_at, completed_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		g.ID,
+		g.UserID,
+		string(g.Metric),
+		string(g.Period),
+		g.Target,
+		nullTimeString(g.StartsAt),
+		nullTimeString(g.EndsAt),
+		nullTimeString(g.CompletedAt),
+		formatTime(g.CreatedAt),
+		formatTime(g.UpdatedAt),
+	)
+	return err
+}
+
+// DeleteListeningGoal removes a listening goal.
+// Returns store.ErrGoalNotFound if the goal does not exist.
+func (s *Store) DeleteListeningGoal(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM listening_goals WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrGoalNotFound
+	}
+	return nil
+}
+
+// ListListeningGoals retrieves all of a user's listening goals, oldest first.
+func (s *Store) ListListeningGoals(ctx context.Context, userID string) ([]*domain.ListeningGoal, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+listeningGoalColumns+` FROM listening_goals WHERE user_id = ? ORDER BY created_at ASC, id ASC`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var goals []*domain.ListeningGoal
+	for rows.Next() {
+		g, err := scanListeningGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
@@ -0,0 +1,99 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func TestListeningGoalCRUD(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-goal-1")
+	insertTestUser(t, s, "user-goal-2")
+
+	if _, err := s.GetListeningGoal(ctx, "goal-1"); !errors.Is(err, store.ErrGoalNotFound) {
+		t.Fatalf("expected ErrGoalNotFound, got %v", err)
+	}
+
+	now := time.Now().UTC()
+	yearly := &domain.ListeningGoal{
+		ID:        "goal-1",
+		UserID:    "user-goal-1",
+		Metric:    domain.GoalMetricBooks,
+		Period:    domain.GoalPeriodYear,
+		Target:    24,
+		CreatedAt: now.Add(-time.Hour),
+		UpdatedAt: now.Add(-time.Hour),
+	}
+	if err := s.UpsertListeningGoal(ctx, yearly); err != nil {
+		t.Fatalf("UpsertListeningGoal: %v", err)
+	}
+
+	startsAt := now.Truncate(time.Second)
+	endsAt := startsAt.AddDate(0, 1, 0)
+	custom := &domain.ListeningGoal{
+		ID:        "goal-2",
+		UserID:    "user-goal-1",
+		Metric:    domain.GoalMetricHours,
+		Period:    domain.GoalPeriodCustom,
+		Target:    30,
+		StartsAt:  &startsAt,
+		EndsAt:    &endsAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.UpsertListeningGoal(ctx, custom); err != nil {
+		t.Fatalf("UpsertListeningGoal (custom): %v", err)
+	}
+	if err := s.UpsertListeningGoal(ctx, &domain.ListeningGoal{
+		ID: "goal-3", UserID: "user-goal-2", Metric: domain.GoalMetricHours, Period: domain.GoalPeriodWeek,
+		Target: 5, CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("UpsertListeningGoal (other user): %v", err)
+	}
+
+	completedAt := now
+	yearly.Target = 12
+	yearly.CompletedAt = &completedAt
+	if err := s.UpsertListeningGoal(ctx, yearly); err != nil {
+		t.Fatalf("UpsertListeningGoal (update): %v", err)
+	}
+
+	got, err := s.GetListeningGoal(ctx, "goal-1")
+	if err != nil {
+		t.Fatalf("GetListeningGoal: %v", err)
+	}
+	if got.Target != 12 || got.CompletedAt == nil || got.StartsAt != nil || got.Period != domain.GoalPeriodYear {
+		t.Errorf("got %+v, want %+v", got, yearly)
+	}
+
+	got, err = s.GetListeningGoal(ctx, "goal-2")
+	if err != nil {
+		t.Fatalf("GetListeningGoal (custom): %v", err)
+	}
+	if got.StartsAt == nil || !got.StartsAt.Equal(startsAt) || got.EndsAt == nil || !got.EndsAt.Equal(endsAt) {
+		t.Errorf("custom range: got %v-%v, want %v-%v", got.StartsAt, got.EndsAt, startsAt, endsAt)
+	}
+
+	goals, err := s.ListListeningGoals(ctx, "user-goal-1")
+	if err != nil {
+		t.Fatalf("ListListeningGoals: %v", err)
+	}
+	if len(goals) != 2 || goals[0].ID != "goal-1" || goals[1].ID != "goal-2" {
+		t.Errorf("expected user's 2 goals, oldest first; got %+v", goals)
+	}
+
+	if err := s.DeleteListeningGoal(ctx, "goal-1"); err != nil {
+		t.Fatalf("DeleteListeningGoal: %v", err)
+	}
+	if err := s.DeleteListeningGoal(ctx, "goal-1"); !errors.Is(err, store.ErrGoalNotFound) {
+		t.Errorf("second delete: expected ErrGoalNotFound, got %v", err)
+	}
+}
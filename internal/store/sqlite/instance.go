@@ -48,11 +48,13 @@ func (s *Store) GetServerSettings(ctx context.Context) (*domain.ServerSettings,
 		return nil, err
 	}
 
-	var settings domain.ServerSettings
-	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+	// Decode over the defaults so settings saved by older versions pick up
+	// sensible values for fields they don't contain yet.
+	settings := domain.NewServerSettings()
+	if err := json.Unmarshal([]byte(value), settings); err != nil {
 		return nil, err
 	}
-	return &settings, nil
+	return settings, nil
 }
 
 // UpdateServerSettings persists server-wide settings.
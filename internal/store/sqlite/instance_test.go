@@ -133,3 +133,31 @@ func TestUpdateAndGetServerSettings(t *testing.T) {
 		t.Errorf("InboxEnabled: got %v, want false", got.InboxEnabled)
 	}
 }
+
+func TestGetServerSettings_LegacyRowGetsBackupDefaults(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	// Settings written before scheduled backups existed have no "backup" key.
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO server_settings (key, value, updated_at) VALUES ('server', ?, ?)`,
+		`{"name":"Home","inbox_enabled":true}`,
+		formatTime(time.Now().UTC()),
+	)
+	if err != nil {
+		t.Fatalf("insert legacy settings: %v", err)
+	}
+
+	got, err := s.GetServerSettings(ctx)
+	if err != nil {
+		t.Fatalf("GetServerSettings: %v", err)
+	}
+
+	if got.Name != "Home" || !got.InboxEnabled {
+		t.Errorf("legacy fields not decoded: %+v", got)
+	}
+	if got.Backup != domain.DefaultBackupSchedule() {
+		t.Errorf("Backup: got %+v, want defaults", got.Backup)
+	}
+}
@@ -16,7 +16,7 @@ import (
 
 // libraryColumns is the ordered list of columns selected in library queries.
 // Must match the scan order in scanLibrary.
-const libraryColumns = `id, created_at, updated_at, owner_id, name, scan_paths, skip_inbox, access_mode`
+const libraryColumns = `id, created_at, updated_at, owner_id, name, scan_paths, skip_inbox, access_mode, metadata_providers, metadata_region, type`
 
 // scanLibrary scans a sql.Row (or sql.Rows via its Scan method) into a domain.Library.
 func scanLibrary(scanner interface{ Scan(dest ...any) error }) (*domain.Library, error) {
@@ -28,6 +28,8 @@ func scanLibrary(scanner interface{ Scan(dest ...any) error }) (*domain.Library,
 		scanPaths  string
 		skipInbox  int
 		accessMode string
+		providers  string
+		libType    string
 	)
 
 	err := scanner.Scan(
@@ -39,6 +41,9 @@ func scanLibrary(scanner interface{ Scan(dest ...any) error }) (*domain.Library,
 		&scanPaths,
 		&skipInbox,
 		&accessMode,
+		&providers,
+		&lib.MetadataRegion,
+		&libType,
 	)
 	if err != nil {
 		return nil, err
@@ -59,11 +64,17 @@ func scanLibrary(scanner interface{ Scan(dest ...any) error }) (*domain.Library,
 		return nil, err
 	}
 
+	// Parse metadata_providers JSON array.
+	if err := json.Unmarshal([]byte(providers), &lib.MetadataProviders); err != nil {
+		return nil, err
+	}
+
 	// Boolean fields.
 	lib.SkipInbox = skipInbox != 0
 
 	// Enum fields.
 	lib.AccessMode = domain.AccessMode(accessMode)
+	lib.Type = domain.LibraryType(libType)
 
 	return &lib, nil
 }
@@ -75,11 +86,15 @@ func (s *Store) CreateLibrary(ctx context.Context, lib *domain.Library) error {
 	if err != nil {
 		return err
 	}
+	providersJSON, err := json.Marshal(lib.MetadataProviders)
+	if err != nil {
+		return err
+	}
 
 	_, err = s.db.ExecContext(ctx, `
 		INSERT INTO libraries (
-			id, created_at, updated_at, owner_id, name, scan_paths, skip_inbox, access_mode
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, created_at, updated_at, owner_id, name, scan_paths, skip_inbox, access_mode, metadata_providers, metadata_region, type
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		lib.ID,
 		formatTime(lib.CreatedAt),
 		formatTime(lib.UpdatedAt),
@@ -88,6 +103,9 @@ func (s *Store) CreateLibrary(ctx context.Context, lib *domain.Library) error {
 		string(scanPathsJSON),
 		boolToInt(lib.SkipInbox),
 		string(lib.AccessMode),
+		string(providersJSON),
+		lib.MetadataRegion,
+		string(lib.GetType()),
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -144,6 +162,10 @@ func (s *Store) UpdateLibrary(ctx context.Context, lib *domain.Library) error {
 	if err != nil {
 		return err
 	}
+	providersJSON, err := json.Marshal(lib.MetadataProviders)
+	if err != nil {
+		return err
+	}
 
 	result, err := s.db.ExecContext(ctx, `
 		UPDATE libraries SET
@@ -153,7 +175,10 @@ func (s *Store) UpdateLibrary(ctx context.Context, lib *domain.Library) error {
 			name = ?,
 			scan_paths = ?,
 			skip_inbox = ?,
-			access_mode = ?
+			access_mode = ?,
+			metadata_providers = ?,
+			metadata_region = ?,
+			type = ?
 		WHERE id = ?`,
 		formatTime(lib.CreatedAt),
 		formatTime(lib.UpdatedAt),
@@ -162,6 +187,9 @@ func (s *Store) UpdateLibrary(ctx context.Context, lib *domain.Library) error {
 		string(scanPathsJSON),
 		boolToInt(lib.SkipInbox),
 		string(lib.AccessMode),
+		string(providersJSON),
+		lib.MetadataRegion,
+		string(lib.GetType()),
 		lib.ID,
 	)
 	if err != nil {
@@ -178,19 +206,108 @@ func (s *Store) UpdateLibrary(ctx context.Context, lib *domain.Library) error {
 	return nil
 }
 
-// GetDefaultLibrary returns the first library in the store.
-// Returns store.ErrNotFound if no libraries exist.
+// GetDefaultLibrary returns the first audiobook library in the store.
+// Podcast libraries are never the default.
+// Returns store.ErrNotFound if no audiobook libraries exist.
 func (s *Store) GetDefaultLibrary(ctx context.Context) (*domain.Library, error) {
 	libraries, err := s.ListLibraries(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(libraries) == 0 {
+	for _, lib := range libraries {
+		if !lib.IsPodcast() {
+			return lib, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+// GetLibraryForPath returns the library whose scan path contains path.
+// When scan paths nest, the deepest one wins.
+// Returns store.ErrNotFound if no library contains the path.
+func (s *Store) GetLibraryForPath(ctx context.Context, path string) (*domain.Library, error) {
+	libraries, err := s.ListLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lib := domain.LibraryForPath(libraries, path)
+	if lib == nil {
 		return nil, store.ErrNotFound
 	}
+	return lib, nil
+}
 
-	return libraries[0], nil
+// GetLibraryStats returns book totals for a library.
+func (s *Store) GetLibraryStats(ctx context.Context, libraryID string) (*store.LibraryStats, error) {
+	stats := &store.LibraryStats{LibraryID: libraryID}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(total_duration), 0), COALESCE(SUM(total_size), 0)
+		FROM books WHERE library_id = ? AND deleted_at IS NULL`,
+		libraryID).Scan(&stats.BookCount, &stats.TotalDuration, &stats.TotalSize)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT bc.contributor_id) FROM book_contributors bc
+		JOIN books b ON b.id = bc.book_id
+		WHERE b.library_id = ? AND b.deleted_at IS NULL`,
+		libraryID).Scan(&stats.ContributorCount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT bs.series_id) FROM book_series bs
+		JOIN books b ON b.id = bs.book_id
+		WHERE b.library_id = ? AND b.deleted_at IS NULL`,
+		libraryID).Scan(&stats.SeriesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// CanUserAccessLibrary checks whether the user can see a library's content
+// that isn't organized into collections, such as podcasts. Open libraries are
+// visible to everyone. Restricted libraries are visible to their owner, to
+// admins, and to users granted any collection in them.
+// Returns store.ErrNotFound if the library does not exist.
+func (s *Store) CanUserAccessLibrary(ctx context.Context, userID, libraryID string) (bool, error) {
+	lib, err := s.GetLibrary(ctx, libraryID)
+	if err != nil {
+		return false, err
+	}
+	if lib.IsOpen() || lib.OwnerID == userID {
+		return true, nil
+	}
+
+	var count int
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM users
+		WHERE id = ? AND deleted_at IS NULL AND (is_root = 1 OR role = ?)`,
+		userID, string(domain.RoleAdmin)).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("check admin: %w", err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM collections c
+		LEFT JOIN collection_shares cs
+			ON cs.collection_id = c.id AND cs.shared_with_user_id = ? AND cs.deleted_at IS NULL
+		WHERE c.library_id = ?
+			AND (c.owner_id = ? OR c.is_global_access = 1 OR cs.id IS NOT NULL)`,
+		userID, libraryID, userID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("check user access to library: %w", err)
+	}
+	return count > 0, nil
 }
 
 // DeleteLibrary deletes a library and all its collections in a single transaction.
@@ -263,7 +380,7 @@ func (s *Store) EnsureLibrary(ctx context.Context, scanPath string, userID strin
 	result := &store.BootstrapResult{}
 
 	// Try to get existing library.
-	row := tx.QueryRowContext(ctx, `SELECT `+libraryColumns+` FROM libraries ORDER BY created_at ASC LIMIT 1`)
+	row := tx.QueryRowContext(ctx, `SELECT `+libraryColumns+` FROM libraries WHERE type != 'podcast' ORDER BY created_at ASC LIMIT 1`)
 	library, err := scanLibrary(row)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -3,6 +3,8 @@ package sqlite
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -46,6 +48,7 @@ func TestCreateAndGetLibrary(t *testing.T) {
 	lib.ScanPaths = []string{"/media/audiobooks", "/mnt/nas/books"}
 	lib.SkipInbox = true
 	lib.AccessMode = domain.AccessModeRestricted
+	lib.MetadataRegion = "uk"
 
 	if err := s.CreateLibrary(ctx, lib); err != nil {
 		t.Fatalf("CreateLibrary: %v", err)
@@ -81,6 +84,9 @@ func TestCreateAndGetLibrary(t *testing.T) {
 	if got.AccessMode != domain.AccessModeRestricted {
 		t.Errorf("AccessMode: got %q, want %q", got.AccessMode, domain.AccessModeRestricted)
 	}
+	if got.MetadataRegion != "uk" {
+		t.Errorf("MetadataRegion: got %q, want %q", got.MetadataRegion, "uk")
+	}
 
 	// Timestamps should round-trip through RFC3339Nano.
 	if got.CreatedAt.Unix() != lib.CreatedAt.Unix() {
@@ -186,6 +192,7 @@ func TestUpdateLibrary(t *testing.T) {
 	lib.ScanPaths = []string{"/new/path/one", "/new/path/two"}
 	lib.SkipInbox = true
 	lib.AccessMode = domain.AccessModeRestricted
+	lib.MetadataProviders = []string{"hardcover", "audible"}
 	lib.UpdatedAt = time.Now()
 
 	if err := s.UpdateLibrary(ctx, lib); err != nil {
@@ -215,6 +222,9 @@ func TestUpdateLibrary(t *testing.T) {
 	if got.AccessMode != domain.AccessModeRestricted {
 		t.Errorf("AccessMode: got %q, want %q", got.AccessMode, domain.AccessModeRestricted)
 	}
+	if !slices.Equal(got.MetadataProviders, []string{"hardcover", "audible"}) {
+		t.Errorf("MetadataProviders: got %v, want [hardcover audible]", got.MetadataProviders)
+	}
 }
 
 func TestUpdateLibrary_NotFound(t *testing.T) {
@@ -305,3 +315,131 @@ func TestLibrary_EmptyScanPaths(t *testing.T) {
 		t.Errorf("ScanPaths: got %d paths, want 0", len(got.ScanPaths))
 	}
 }
+
+func TestGetLibraryForPath(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	createTestOwner(t, s, "owner-1")
+
+	adults := makeTestLibrary("lib-adults", "owner-1", "Adults")
+	adults.ScanPaths = []string{"/media/adults"}
+	kids := makeTestLibrary("lib-kids", "owner-1", "Kids")
+	kids.ScanPaths = []string{"/media/kids", "/mnt/nas/kids"}
+	for _, lib := range []*domain.Library{adults, kids} {
+		if err := s.CreateLibrary(ctx, lib); err != nil {
+			t.Fatalf("CreateLibrary %s: %v", lib.ID, err)
+		}
+	}
+
+	got, err := s.GetLibraryForPath(ctx, "/mnt/nas/kids/Gruffalo")
+	if err != nil {
+		t.Fatalf("GetLibraryForPath: %v", err)
+	}
+	if got.ID != "lib-kids" {
+		t.Errorf("got library %q, want lib-kids", got.ID)
+	}
+
+	_, err = s.GetLibraryForPath(ctx, "/media/kids-old/Gruffalo")
+	if !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for path outside libraries, got %v", err)
+	}
+}
+
+func TestGetLibraryStats(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	createTestOwner(t, s, "owner-1")
+	lib := makeTestLibrary("lib-1", "owner-1", "Books")
+	if err := s.CreateLibrary(ctx, lib); err != nil {
+		t.Fatalf("CreateLibrary: %v", err)
+	}
+
+	for i, path := range []string{"/media/audiobooks/a", "/media/audiobooks/b"} {
+		b := makeTestBook(fmt.Sprintf("book-%d", i), "Book", path)
+		b.LibraryID = lib.ID
+		if err := s.CreateBook(ctx, b); err != nil {
+			t.Fatalf("CreateBook: %v", err)
+		}
+	}
+	other := makeTestBook("book-other", "Other", "/elsewhere/c")
+	other.LibraryID = "lib-other"
+	if err := s.CreateBook(ctx, other); err != nil {
+		t.Fatalf("CreateBook: %v", err)
+	}
+
+	stats, err := s.GetLibraryStats(ctx, lib.ID)
+	if err != nil {
+		t.Fatalf("GetLibraryStats: %v", err)
+	}
+	if stats.BookCount != 2 {
+		t.Errorf("BookCount: got %d, want 2", stats.BookCount)
+	}
+	if stats.TotalDuration != 2*36000 {
+		t.Errorf("TotalDuration: got %d, want %d", stats.TotalDuration, 2*36000)
+	}
+	if stats.TotalSize != 2*500000000 {
+		t.Errorf("TotalSize: got %d, want %d", stats.TotalSize, 2*500000000)
+	}
+}
+
+func TestCanUserAccessLibrary(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	createTestOwner(t, s, "owner-1")
+	createTestOwner(t, s, "member-1")
+	admin := makeTestUser("admin-1", "admin-1@example.com")
+	admin.Role = domain.RoleAdmin
+	if err := s.CreateUser(ctx, admin); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	lib := makeTestLibrary("lib-1", "owner-1", "Podcasts")
+	if err := s.CreateLibrary(ctx, lib); err != nil {
+		t.Fatalf("CreateLibrary: %v", err)
+	}
+
+	check := func(userID string, want bool) {
+		t.Helper()
+		got, err := s.CanUserAccessLibrary(ctx, userID, lib.ID)
+		if err != nil {
+			t.Fatalf("CanUserAccessLibrary(%s): %v", userID, err)
+		}
+		if got != want {
+			t.Errorf("CanUserAccessLibrary(%s): got %v, want %v", userID, got, want)
+		}
+	}
+
+	// Open libraries are visible to everyone.
+	check("member-1", true)
+
+	lib.AccessMode = domain.AccessModeRestricted
+	if err := s.UpdateLibrary(ctx, lib); err != nil {
+		t.Fatalf("UpdateLibrary: %v", err)
+	}
+	check("owner-1", true)
+	check("admin-1", true)
+	check("member-1", false)
+
+	// A share of any collection in the library grants access.
+	now := time.Now()
+	coll := &domain.Collection{ID: "coll-1", LibraryID: lib.ID, OwnerID: "owner-1", Name: "Shared", CreatedAt: now, UpdatedAt: now}
+	if err := s.CreateCollection(ctx, coll); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	check("member-1", false)
+	share := &domain.CollectionShare{CollectionID: coll.ID, SharedWithUserID: "member-1", SharedByUserID: "owner-1"}
+	if err := s.CreateShare(ctx, share); err != nil {
+		t.Fatalf("CreateShare: %v", err)
+	}
+	check("member-1", true)
+
+	if _, err := s.CanUserAccessLibrary(ctx, "member-1", "missing"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("missing library: got %v, want ErrNotFound", err)
+	}
+}
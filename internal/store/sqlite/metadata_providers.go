@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json/v2"
+	"errors"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// Kinds of provider response, each with its own lifetime.
+const (
+	providerCacheBook        = "book"
+	providerCacheSearch      = "search"
+	providerCacheContributor = "contributor"
+)
+
+// GetCachedProviderBook retrieves cached book metadata from a provider.
+// Returns nil, nil if not found or expired.
+func (s *Store) GetCachedProviderBook(ctx context.Context, provider, id string) (*store.CachedProviderBook, error) {
+	var book metadata.Book
+	fetchedAt, ok, err := s.getProviderCache(ctx, provider, providerCacheBook, id, bookCacheDuration, &book)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &store.CachedProviderBook{Book: &book, FetchedAt: fetchedAt}, nil
+}
+
+// SetCachedProviderBook stores book metadata from a provider in cache.
+func (s *Store) SetCachedProviderBook(ctx context.Context, provider, id string, book *metadata.Book) error {
+	return s.setProviderCache(ctx, provider, providerCacheBook, id, book)
+}
+
+// DeleteCachedProviderBook removes cached book metadata.
+// This operation is idempotent.
+func (s *Store) DeleteCachedProviderBook(ctx context.Context, provider, id string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM metadata_provider_cache WHERE provider = ? AND kind = ? AND key = ?`,
+		provider, providerCacheBook, id)
+	return err
+}
+
+// GetCachedProviderSearch retrieves a provider's cached search results.
+// Returns nil, nil if not found or expired.
+func (s *Store) GetCachedProviderSearch(ctx context.Context, provider, query string) (*store.CachedProviderSearch, error) {
+	var results []metadata.SearchResult
+	fetchedAt, ok, err := s.getProviderCache(ctx, provider, providerCacheSearch, searchCacheQueryKey(query), searchCacheDuration, &results)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &store.CachedProviderSearch{Results: results, FetchedAt: fetchedAt, Query: query}, nil
+}
+
+// SetCachedProviderSearch stores a provider's search results in cache.
+func (s *Store) SetCachedProviderSearch(ctx context.Context, provider, query string, results []metadata.SearchResult) error {
+	return s.setProviderCache(ctx, provider, providerCacheSearch, searchCacheQueryKey(query), results)
+}
+
+// GetCachedProviderContributor retrieves a provider's cached contributor profile.
+// Returns nil, nil if not found or expired.
+func (s *Store) GetCachedProviderContributor(ctx context.Context, provider, id string) (*store.CachedProviderContributor, error) {
+	var profile metadata.ContributorProfile
+	fetchedAt, ok, err := s.getProviderCache(ctx, provider, providerCacheContributor, id, bookCacheDuration, &profile)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &store.CachedProviderContributor{Profile: &profile, FetchedAt: fetchedAt}, nil
+}
+
+// SetCachedProviderContributor stores a provider's contributor profile in cache.
+func (s *Store) SetCachedProviderContributor(ctx context.Context, provider, id string, profile *metadata.ContributorProfile) error {
+	return s.setProviderCache(ctx, provider, providerCacheContributor, id, profile)
+}
+
+// getProviderCache decodes a cached response into v. It reports false when
+// there is no entry or the entry is older than maxAge.
+func (s *Store) getProviderCache(ctx context.Context, provider, kind, key string, maxAge time.Duration, v any) (time.Time, bool, error) {
+	var (
+		data      string
+		fetchedAt string
+	)
+
+	err := s.db.QueryRowContext(ctx,
+		`SELECT data, fetched_at FROM metadata_provider_cache WHERE provider = ? AND kind = ? AND key = ?`,
+		provider, kind, key).Scan(&data, &fetchedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	fetchedTime, err := parseTime(fetchedAt)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if time.Since(fetchedTime) > maxAge {
+		return time.Time{}, false, nil // Treat as cache miss
+	}
+
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		return time.Time{}, false, err
+	}
+	return fetchedTime, true, nil
+}
+
+func (s *Store) setProviderCache(ctx context.Context, provider, kind, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT OR REPLACE INTO metadata_provider_cache (provider, kind, key, data, fetched_at) VALUES (?, ?, ?, ?, ?)`,
+		provider, kind, key, string(data), formatTime(time.Now().UTC()))
+	return err
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/metadata"
+)
+
+func TestProviderCache_BookRoundTrip(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	got, err := s.GetCachedProviderBook(ctx, metadata.ProviderOpenLibrary, "OL1W")
+	if err != nil {
+		t.Fatalf("GetCachedProviderBook (miss): %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected cache miss, got %+v", got)
+	}
+
+	book := &metadata.Book{
+		Provider: metadata.ProviderOpenLibrary,
+		ID:       "OL1W",
+		Title:    "Dune",
+		Authors:  []metadata.Contributor{{ID: "OL2A", Name: "Frank Herbert"}},
+	}
+	if err := s.SetCachedProviderBook(ctx, metadata.ProviderOpenLibrary, "OL1W", book); err != nil {
+		t.Fatalf("SetCachedProviderBook: %v", err)
+	}
+
+	got, err = s.GetCachedProviderBook(ctx, metadata.ProviderOpenLibrary, "OL1W")
+	if err != nil {
+		t.Fatalf("GetCachedProviderBook: %v", err)
+	}
+	if got == nil || got.Book.Title != "Dune" || len(got.Book.Authors) != 1 {
+		t.Fatalf("cached book = %+v", got)
+	}
+
+	// Same ID under another provider is a different entry.
+	other, err := s.GetCachedProviderBook(ctx, metadata.ProviderGoogleBooks, "OL1W")
+	if err != nil {
+		t.Fatalf("GetCachedProviderBook (other provider): %v", err)
+	}
+	if other != nil {
+		t.Errorf("expected miss for other provider, got %+v", other)
+	}
+
+	if err := s.DeleteCachedProviderBook(ctx, metadata.ProviderOpenLibrary, "OL1W"); err != nil {
+		t.Fatalf("DeleteCachedProviderBook: %v", err)
+	}
+	got, err = s.GetCachedProviderBook(ctx, metadata.ProviderOpenLibrary, "OL1W")
+	if err != nil {
+		t.Fatalf("GetCachedProviderBook (deleted): %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected miss after delete, got %+v", got)
+	}
+}
+
+func TestProviderCache_SearchExpires(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	results := []metadata.SearchResult{{Provider: metadata.ProviderHardcover, ID: "1", Title: "Dune"}}
+	if err := s.SetCachedProviderSearch(ctx, metadata.ProviderHardcover, "dune", results); err != nil {
+		t.Fatalf("SetCachedProviderSearch: %v", err)
+	}
+
+	got, err := s.GetCachedProviderSearch(ctx, metadata.ProviderHardcover, "dune")
+	if err != nil {
+		t.Fatalf("GetCachedProviderSearch: %v", err)
+	}
+	if got == nil || len(got.Results) != 1 || got.Results[0].ID != "1" {
+		t.Fatalf("cached search = %+v", got)
+	}
+
+	// Age the entry past the search lifetime.
+	stale := formatTime(time.Now().UTC().Add(-searchCacheDuration - time.Minute))
+	if _, err := s.db.Exec(`UPDATE metadata_provider_cache SET fetched_at = ?`, stale); err != nil {
+		t.Fatalf("age entry: %v", err)
+	}
+
+	got, err = s.GetCachedProviderSearch(ctx, metadata.ProviderHardcover, "dune")
+	if err != nil {
+		t.Fatalf("GetCachedProviderSearch (stale): %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected stale entry to miss, got %+v", got)
+	}
+}
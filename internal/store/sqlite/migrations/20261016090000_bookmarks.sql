@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id              TEXT PRIMARY KEY,
+    created_at      TEXT NOT NULL,
+    updated_at      TEXT NOT NULL,
+    deleted_at      TEXT,
+    user_id         TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    book_id         TEXT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    audio_file_id   TEXT,
+    position_ms     INTEGER NOT NULL,
+    end_position_ms INTEGER,
+    title           TEXT,
+    note            TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_user_book ON bookmarks(user_id, book_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_bookmarks_user_updated ON bookmarks(user_id, updated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_bookmarks_user_updated;
+DROP INDEX IF EXISTS idx_bookmarks_user_book;
+DROP TABLE IF EXISTS bookmarks;
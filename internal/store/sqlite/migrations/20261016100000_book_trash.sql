@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS book_trash (
+    book_id         TEXT PRIMARY KEY REFERENCES books(id),
+    title           TEXT NOT NULL,
+    path            TEXT NOT NULL,
+    deleted_by      TEXT NOT NULL,
+    deleted_at      TEXT NOT NULL,
+    purge_at        TEXT,
+    delete_files    INTEGER NOT NULL DEFAULT 0,
+    tag_ids         TEXT NOT NULL DEFAULT '[]',
+    collection_ids  TEXT NOT NULL DEFAULT '[]',
+    shelves         TEXT NOT NULL DEFAULT '[]'
+);
+CREATE INDEX IF NOT EXISTS idx_book_trash_purge_at ON book_trash(purge_at) WHERE purge_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_book_trash_purge_at;
+DROP TABLE IF EXISTS book_trash;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS book_reviews (
+    id                TEXT PRIMARY KEY,
+    user_id           TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    book_id           TEXT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    rating            REAL NOT NULL,
+    text              TEXT,
+    contains_spoilers INTEGER NOT NULL DEFAULT 0,
+    created_at        TEXT NOT NULL,
+    updated_at        TEXT NOT NULL,
+    UNIQUE (user_id, book_id)
+);
+CREATE INDEX IF NOT EXISTS idx_book_reviews_book ON book_reviews(book_id, updated_at DESC);
+
+ALTER TABLE activities ADD COLUMN rating REAL NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE activities DROP COLUMN rating;
+DROP INDEX IF EXISTS idx_book_reviews_book;
+DROP TABLE IF EXISTS book_reviews;
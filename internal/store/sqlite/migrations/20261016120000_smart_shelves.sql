@@ -0,0 +1,6 @@
+-- +goose Up
+-- Smart shelves store their rules as JSON; manual shelves leave this NULL.
+ALTER TABLE shelves ADD COLUMN rules TEXT;
+
+-- +goose Down
+ALTER TABLE shelves DROP COLUMN rules;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Response cache for the metadata providers other than Audible, which keeps
+-- its own region-keyed tables.
+CREATE TABLE IF NOT EXISTS metadata_provider_cache (
+    provider    TEXT NOT NULL,
+    kind        TEXT NOT NULL,
+    key         TEXT NOT NULL,
+    data        TEXT NOT NULL,
+    fetched_at  TEXT NOT NULL,
+    PRIMARY KEY (provider, kind, key)
+);
+
+-- Provider names in the order a library tries them; empty uses the default order.
+ALTER TABLE libraries ADD COLUMN metadata_providers TEXT NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE libraries DROP COLUMN metadata_providers;
+DROP TABLE IF EXISTS metadata_provider_cache;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Books belong to the library whose scan path contains them. NULL means the
+-- book predates library tracking; it is treated as part of the default library
+-- until the next scan assigns it. No FOREIGN KEY so the column can be dropped
+-- again; LibraryService refuses to delete a library that still has books.
+ALTER TABLE books ADD COLUMN library_id TEXT;
+
+-- Assign existing books by the longest scan path that contains them.
+UPDATE books SET library_id = (
+    SELECT l.id FROM libraries l, json_each(l.scan_paths) p
+    WHERE books.path = rtrim(p.value, '/')
+       OR (books.path >= rtrim(p.value, '/') || '/' AND books.path < rtrim(p.value, '/') || '0')
+    ORDER BY length(rtrim(p.value, '/')) DESC
+    LIMIT 1
+);
+
+-- Anything left over goes to the oldest library, which was the only one.
+UPDATE books SET library_id = (SELECT id FROM libraries ORDER BY created_at ASC LIMIT 1)
+WHERE library_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_books_library ON books(library_id) WHERE deleted_at IS NULL;
+
+-- Audible marketplace used for this library's matches; empty uses the server default.
+ALTER TABLE libraries ADD COLUMN metadata_region TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE libraries DROP COLUMN metadata_region;
+DROP INDEX IF EXISTS idx_books_library;
+ALTER TABLE books DROP COLUMN library_id;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS oidc_identities (
+    issuer          TEXT NOT NULL,
+    subject         TEXT NOT NULL,
+    user_id         TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email           TEXT,
+    created_at      TEXT NOT NULL,
+    last_login_at   TEXT NOT NULL,
+    PRIMARY KEY (issuer, subject)
+);
+CREATE INDEX IF NOT EXISTS idx_oidc_identities_user ON oidc_identities(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_oidc_identities_user;
+DROP TABLE IF EXISTS oidc_identities;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Live imports read from a running ABS server; backup_path is empty for them.
+ALTER TABLE abs_imports ADD COLUMN server_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE abs_imports ADD COLUMN api_token TEXT NOT NULL DEFAULT '';
+ALTER TABLE abs_imports ADD COLUMN last_synced_at TEXT;
+
+-- +goose Down
+ALTER TABLE abs_imports DROP COLUMN last_synced_at;
+ALTER TABLE abs_imports DROP COLUMN api_token;
+ALTER TABLE abs_imports DROP COLUMN server_url;
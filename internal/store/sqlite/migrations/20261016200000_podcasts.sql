@@ -0,0 +1,94 @@
+-- +goose Up
+-- Podcast libraries hold feed subscriptions instead of scanned audiobooks.
+ALTER TABLE libraries ADD COLUMN type TEXT NOT NULL DEFAULT 'audiobook';
+
+CREATE TABLE IF NOT EXISTS podcasts (
+    id                  TEXT PRIMARY KEY,
+    created_at          TEXT NOT NULL,
+    updated_at          TEXT NOT NULL,
+    library_id          TEXT NOT NULL REFERENCES libraries(id) ON DELETE CASCADE,
+    feed_url            TEXT NOT NULL,
+    title               TEXT NOT NULL,
+    author              TEXT,
+    description         TEXT,
+    image_url           TEXT,
+    link                TEXT,
+    language            TEXT,
+    explicit            INTEGER NOT NULL DEFAULT 0,
+    dir                 TEXT NOT NULL,
+    auto_download       INTEGER NOT NULL DEFAULT 0,
+    keep_episodes       INTEGER NOT NULL DEFAULT 0,
+    keep_days           INTEGER NOT NULL DEFAULT 0,
+    etag                TEXT,
+    last_modified       TEXT,
+    last_refreshed_at   TEXT,
+    last_error          TEXT,
+    UNIQUE (library_id, feed_url)
+);
+
+CREATE TABLE IF NOT EXISTS podcast_episodes (
+    id              TEXT PRIMARY KEY,
+    created_at      TEXT NOT NULL,
+    updated_at      TEXT NOT NULL,
+    podcast_id      TEXT NOT NULL REFERENCES podcasts(id) ON DELETE CASCADE,
+    guid            TEXT NOT NULL,
+    title           TEXT NOT NULL,
+    description     TEXT,
+    published_at    TEXT,
+    duration_ms     INTEGER NOT NULL DEFAULT 0,
+    season          INTEGER NOT NULL DEFAULT 0,
+    number          INTEGER NOT NULL DEFAULT 0,
+    enclosure_url   TEXT NOT NULL,
+    enclosure_type  TEXT,
+    enclosure_size  INTEGER NOT NULL DEFAULT 0,
+    status          TEXT NOT NULL,
+    file_path       TEXT,
+    file_size       INTEGER NOT NULL DEFAULT 0,
+    downloaded_at   TEXT,
+    error           TEXT,
+    UNIQUE (podcast_id, guid)
+);
+CREATE INDEX IF NOT EXISTS idx_podcast_episodes_published ON podcast_episodes(podcast_id, published_at DESC);
+CREATE INDEX IF NOT EXISTS idx_podcast_episodes_status ON podcast_episodes(status);
+
+-- Episode listening mirrors listening_events and playback_state, keyed by
+-- episode instead of book, so episodes stay out of book stats and activity.
+CREATE TABLE IF NOT EXISTS episode_listening_events (
+    id                  TEXT PRIMARY KEY,
+    user_id             TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    episode_id          TEXT NOT NULL REFERENCES podcast_episodes(id) ON DELETE CASCADE,
+    start_position_ms   INTEGER NOT NULL,
+    end_position_ms     INTEGER NOT NULL,
+    started_at          TEXT NOT NULL,
+    ended_at            TEXT NOT NULL,
+    playback_speed      REAL NOT NULL,
+    device_id           TEXT NOT NULL,
+    device_name         TEXT,
+    source              TEXT NOT NULL,
+    duration_ms         INTEGER NOT NULL,
+    created_at          TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ele_user_episode ON episode_listening_events(user_id, episode_id);
+
+CREATE TABLE IF NOT EXISTS episode_playback_state (
+    user_id                 TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    episode_id              TEXT NOT NULL REFERENCES podcast_episodes(id) ON DELETE CASCADE,
+    current_position_ms     INTEGER NOT NULL DEFAULT 0,
+    is_finished             INTEGER NOT NULL DEFAULT 0,
+    finished_at             TEXT,
+    started_at              TEXT NOT NULL,
+    last_played_at          TEXT NOT NULL,
+    total_listen_time_ms    INTEGER NOT NULL DEFAULT 0,
+    updated_at              TEXT NOT NULL,
+    PRIMARY KEY (user_id, episode_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS episode_playback_state;
+DROP INDEX IF EXISTS idx_ele_user_episode;
+DROP TABLE IF EXISTS episode_listening_events;
+DROP INDEX IF EXISTS idx_podcast_episodes_status;
+DROP INDEX IF EXISTS idx_podcast_episodes_published;
+DROP TABLE IF EXISTS podcast_episodes;
+DROP TABLE IF EXISTS podcasts;
+ALTER TABLE libraries DROP COLUMN type;
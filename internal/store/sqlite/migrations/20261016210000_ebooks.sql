@@ -0,0 +1,20 @@
+-- +goose Up
+-- Companion ebooks found in a book's folder, as a JSON array of EbookFileInfo.
+ALTER TABLE books ADD COLUMN ebook_files TEXT NOT NULL DEFAULT '[]';
+
+-- Reading position in a companion ebook, kept next to the audio playback
+-- state so clients can hand off between listening and reading.
+CREATE TABLE IF NOT EXISTS ebook_positions (
+    user_id         TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    book_id         TEXT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    ebook_file_id   TEXT NOT NULL,
+    cfi             TEXT NOT NULL,
+    progress        REAL NOT NULL DEFAULT 0,
+    device_id       TEXT,
+    updated_at      TEXT NOT NULL,
+    PRIMARY KEY (user_id, book_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS ebook_positions;
+ALTER TABLE books DROP COLUMN ebook_files;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- EBU R128 measurement of each audio file, filled in by the loudness analysis
+-- job. NULL until the file has been analyzed.
+ALTER TABLE book_audio_files ADD COLUMN loudness_lufs REAL;
+ALTER TABLE book_audio_files ADD COLUMN true_peak_dbtp REAL;
+
+-- +goose Down
+ALTER TABLE book_audio_files DROP COLUMN true_peak_dbtp;
+ALTER TABLE book_audio_files DROP COLUMN loudness_lufs;
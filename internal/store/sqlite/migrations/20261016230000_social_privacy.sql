@@ -0,0 +1,15 @@
+-- +goose Up
+-- Social privacy settings. Each hides the user's listening from other users
+-- only; the user still sees their own activity, stats and sessions.
+ALTER TABLE user_profiles ADD COLUMN hide_from_leaderboard INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_profiles ADD COLUMN hide_activity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_profiles ADD COLUMN hide_currently_listening INTEGER NOT NULL DEFAULT 0;
+
+-- Per-book private listening: keeps one book out of everything social.
+ALTER TABLE book_preferences ADD COLUMN private_listening INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE book_preferences DROP COLUMN private_listening;
+ALTER TABLE user_profiles DROP COLUMN hide_currently_listening;
+ALTER TABLE user_profiles DROP COLUMN hide_activity;
+ALTER TABLE user_profiles DROP COLUMN hide_from_leaderboard;
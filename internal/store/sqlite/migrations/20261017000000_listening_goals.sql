@@ -0,0 +1,20 @@
+-- +goose Up
+-- Listening goals. Progress is computed from listening events and finished
+-- playback states; only the last completion is stored, so that finishing a
+-- goal is announced once per period.
+CREATE TABLE IF NOT EXISTS listening_goals (
+    id              TEXT PRIMARY KEY,
+    user_id         TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    metric          TEXT NOT NULL,
+    period          TEXT NOT NULL,
+    target          INTEGER NOT NULL,
+    starts_at       TEXT,
+    ends_at         TEXT,
+    completed_at    TEXT,
+    created_at      TEXT NOT NULL,
+    updated_at      TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_listening_goals_user ON listening_goals(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS listening_goals;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Cached listening recaps, one per user and period ("2025" or
+-- "2025-06-01..2025-08-31"). data holds the computed recap as JSON; it is
+-- rebuilt when stale. share_id is set while the recap is shared publicly.
+CREATE TABLE IF NOT EXISTS listening_recaps (
+    user_id         TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period          TEXT NOT NULL,
+    data            TEXT NOT NULL,
+    generated_at    TEXT NOT NULL,
+    share_id        TEXT UNIQUE,
+    PRIMARY KEY (user_id, period)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS listening_recaps;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- The current two-factor login challenge and how many wrong codes it has
+-- taken. Too many revokes it, so the password step must be passed again.
+ALTER TABLE user_two_factor ADD COLUMN challenge_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_two_factor ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE user_two_factor DROP COLUMN failed_attempts;
+ALTER TABLE user_two_factor DROP COLUMN challenge_id;
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// oidcIdentityColumns is the ordered list of columns selected in OIDC
+// identity queries. Must match the scan order in scanOIDCIdentity.
+const oidcIdentityColumns = `issuer, subject, user_id, email, created_at, last_login_at`
+
+// scanOIDCIdentity scans a sql.Row (or sql.Rows via its Scan method) into a domain.OIDCIdentity.
+func scanOIDCIdentity(scanner interface{ Scan(dest ...any) error }) (*domain.OIDCIdentity, error) {
+	var ident domain.OIDCIdentity
+
+	var (
+		email       sql.NullString
+		createdAt   string
+		lastLoginAt string
+	)
+
+	err := scanner.Scan(
+		&ident.Issuer,
+		&ident.Subject,
+		&ident.UserID,
+		&email,
+		&createdAt,
+		&lastLoginAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ident.Email = email.String
+	ident.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	ident.LastLoginAt, err = parseTime(lastLoginAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ident, nil
+}
+
+// GetOIDCIdentity retrieves the link for a provider account.
+// Returns store.ErrOIDCIdentityNotFound if the account isn't linked.
+func (s *Store) GetOIDCIdentity(ctx context.Context, issuer, subject string) (*domain.OIDCIdentity, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+oidcIdentityColumns+` FROM oidc_identities WHERE issuer = ? AND subject = ?`,
+		issuer, subject)
+
+	ident, err := scanOIDCIdentity(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrOIDCIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ident, nil
+}
+
+// CreateOIDCIdentity links a provider account to a user.
+// Returns store.ErrOIDCIdentityExists if the account is already linked.
+func (s *Store) CreateOIDCIdentity(ctx context.Context, ident *domain.OIDCIdentity) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO oidc_identities (`+oidcIdentityColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		ident.Issuer,
+		ident.Subject,
+		ident.UserID,
+		nullString(ident.Email),
+		formatTime(ident.CreatedAt),
+		formatTime(ident.LastLoginAt),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrOIDCIdentityExists
+		}
+		return err
+	}
+	return nil
+}
+
+// TouchOIDCIdentity records a login through a linked provider account.
+func (s *Store) TouchOIDCIdentity(ctx context.Context, issuer, subject string, at time.Time) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE oidc_identities SET last_login_at = ? WHERE issuer = ? AND subject = ?`,
+		formatTime(at), issuer, subject)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrOIDCIdentityNotFound
+	}
+	return nil
+}
+
+// ListOIDCIdentities returns the provider accounts linked to a user.
+func (s *Store) ListOIDCIdentities(ctx context.Context, userID string) ([]*domain.OIDCIdentity, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+oidcIdentityColumns+` FROM oidc_identities WHERE user_id = ? ORDER BY created_at ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var idents []*domain.OIDCIdentity
+	for rows.Next() {
+		ident, err := scanOIDCIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		idents = append(idents, ident)
+	}
+	return idents, rows.Err()
+}
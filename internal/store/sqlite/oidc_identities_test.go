@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func TestOIDCIdentityLifecycle(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-oidc-1")
+	const issuer = "https://auth.example.com"
+
+	if _, err := s.GetOIDCIdentity(ctx, issuer, "sub-1"); !errors.Is(err, store.ErrOIDCIdentityNotFound) {
+		t.Fatalf("before linking: got %v, want ErrOIDCIdentityNotFound", err)
+	}
+
+	now := time.Now().UTC()
+	ident := &domain.OIDCIdentity{
+		Issuer: issuer, Subject: "sub-1", UserID: "user-oidc-1",
+		Email: "one@example.com", CreatedAt: now, LastLoginAt: now,
+	}
+	if err := s.CreateOIDCIdentity(ctx, ident); err != nil {
+		t.Fatalf("CreateOIDCIdentity: %v", err)
+	}
+	if err := s.CreateOIDCIdentity(ctx, ident); !errors.Is(err, store.ErrOIDCIdentityExists) {
+		t.Errorf("linking twice: got %v, want ErrOIDCIdentityExists", err)
+	}
+
+	// The same subject at another issuer is a different account.
+	other := *ident
+	other.Issuer = "https://other.example.com"
+	other.Email = ""
+	if err := s.CreateOIDCIdentity(ctx, &other); err != nil {
+		t.Fatalf("CreateOIDCIdentity (other issuer): %v", err)
+	}
+
+	later := now.Add(time.Hour)
+	if err := s.TouchOIDCIdentity(ctx, issuer, "sub-1", later); err != nil {
+		t.Fatalf("TouchOIDCIdentity: %v", err)
+	}
+	if err := s.TouchOIDCIdentity(ctx, issuer, "nobody", later); !errors.Is(err, store.ErrOIDCIdentityNotFound) {
+		t.Errorf("touch unknown: got %v, want ErrOIDCIdentityNotFound", err)
+	}
+
+	got, err := s.GetOIDCIdentity(ctx, issuer, "sub-1")
+	if err != nil {
+		t.Fatalf("GetOIDCIdentity: %v", err)
+	}
+	if got.UserID != "user-oidc-1" || got.Email != "one@example.com" || !got.LastLoginAt.Equal(later) {
+		t.Errorf("got %+v", got)
+	}
+
+	listed, err := s.ListOIDCIdentities(ctx, "user-oidc-1")
+	if err != nil {
+		t.Fatalf("ListOIDCIdentities: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Errorf("listed %d identities, want 2", len(listed))
+	}
+}
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// passwordResetColumns is the ordered list of columns selected in password
+// reset queries. Must match the scan order in scanPasswordReset.
+const passwordResetColumns = `id, user_id, code_hash, created_by, created_at, expires_at, used_at`
+
+// scanPasswordReset scans a sql.Row (or sql.Rows via its Scan method) into a domain.PasswordReset.
+func scanPasswordReset(scanner interface{ Scan(dest ...any) error }) (*domain.PasswordReset, error) {
+	var r domain.PasswordReset
+
+	var (
+		createdBy sql.NullString
+		createdAt string
+		expiresAt string
+		usedAt    sql.NullString
+	)
+
+	err := scanner.Scan(
+		&r.ID,
+		&r.UserID,
+		&r.CodeHash,
+		&createdBy,
+		&createdAt,
+		&expiresAt,
+		&usedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.CreatedBy = createdBy.String
+	r.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	r.ExpiresAt, err = parseTime(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	r.UsedAt, err = parseNullableTime(usedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// CreatePasswordReset inserts a new password reset code.
+// Returns store.ErrAlreadyExists if the ID or hash is taken.
+func (s *Store) CreatePasswordReset(ctx context.Context, r *domain.PasswordReset) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO password_resets (`+passwordResetColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		r.ID,
+		r.UserID,
+		r.CodeHash,
+		nullString(r.CreatedBy),
+		formatTime(r.CreatedAt),
+		formatTime(r.ExpiresAt),
+		nullTimeString(r.UsedAt),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetPasswordResetByHash retrieves a password reset by the hash of its code.
+// Used and expired codes are returned too; callers check IsValid.
+// Returns store.ErrPasswordResetNotFound if no code matches.
+func (s *Store) GetPasswordResetByHash(ctx context.Context, codeHash string) (*domain.PasswordReset, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+passwordResetColumns+` FROM password_resets WHERE code_hash = ?`, codeHash)
+
+	r, err := scanPasswordReset(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrPasswordResetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UsePasswordReset marks a code as redeemed. Only one caller can redeem a
+// code: a second call returns store.ErrPasswordResetNotFound.
+func (s *Store) UsePasswordReset(ctx context.Context, id string, usedAt time.Time) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE password_resets SET used_at = ? WHERE id = ? AND used_at IS NULL`,
+		formatTime(usedAt), id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrPasswordResetNotFound
+	}
+	return nil
+}
+
+// DeletePasswordResetsForUser removes every reset code issued for a user.
+// This operation is idempotent.
+func (s *Store) DeletePasswordResetsForUser(ctx context.Context, userID string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM password_resets WHERE user_id = ?`, userID)
+	return err
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func TestPasswordResetLifecycle(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-pr-1")
+	insertTestUser(t, s, "admin-pr-1")
+
+	now := time.Now().UTC()
+	reset := &domain.PasswordReset{
+		ID:        "pr-1",
+		UserID:    "user-pr-1",
+		CodeHash:  "hash-1",
+		CreatedBy: "admin-pr-1",
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	if err := s.CreatePasswordReset(ctx, reset); err != nil {
+		t.Fatalf("CreatePasswordReset: %v", err)
+	}
+
+	dup := *reset
+	dup.ID = "pr-2"
+	if err := s.CreatePasswordReset(ctx, &dup); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate hash: got %v, want ErrAlreadyExists", err)
+	}
+
+	got, err := s.GetPasswordResetByHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("GetPasswordResetByHash: %v", err)
+	}
+	if got.UserID != "user-pr-1" || got.CreatedBy != "admin-pr-1" || !got.IsValid() {
+		t.Errorf("got %+v", got)
+	}
+
+	// A code can only be redeemed once.
+	if err := s.UsePasswordReset(ctx, "pr-1", now); err != nil {
+		t.Fatalf("UsePasswordReset: %v", err)
+	}
+	if err := s.UsePasswordReset(ctx, "pr-1", now); !errors.Is(err, store.ErrPasswordResetNotFound) {
+		t.Errorf("second use: got %v, want ErrPasswordResetNotFound", err)
+	}
+	got, err = s.GetPasswordResetByHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("GetPasswordResetByHash (used): %v", err)
+	}
+	if !got.IsUsed() || got.IsValid() {
+		t.Errorf("expected used code, got %+v", got)
+	}
+
+	if err := s.DeletePasswordResetsForUser(ctx, "user-pr-1"); err != nil {
+		t.Fatalf("DeletePasswordResetsForUser: %v", err)
+	}
+	if _, err := s.GetPasswordResetByHash(ctx, "hash-1"); !errors.Is(err, store.ErrPasswordResetNotFound) {
+		t.Errorf("after delete: got %v, want ErrPasswordResetNotFound", err)
+	}
+}
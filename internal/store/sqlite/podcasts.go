@@ -0,0 +1,609 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// podcastColumns is the ordered list of columns selected in podcast queries.
+// Must match the scan order in scanPodcast.
+const podcastColumns = `id, created_at, updated_at, library_id, feed_url, title, author,
+	description, image_url, link, language, explicit, dir, auto_download,
+	keep_episodes, keep_days, etag, last_modified, last_refreshed_at, last_error`
+
+// scanPodcast scans a sql.Row (or sql.Rows via its Scan method) into a domain.Podcast.
+func scanPodcast(scanner interface{ Scan(dest ...any) error }) (*domain.Podcast, error) {
+	var p domain.Podcast
+
+	var (
+		createdAt       string
+		updatedAt       string
+		author          sql.NullString
+		description     sql.NullString
+		imageURL        sql.NullString
+		link            sql.NullString
+		language        sql.NullString
+		explicit        int
+		autoDownload    int
+		etag            sql.NullString
+		lastModified    sql.NullString
+		lastRefreshedAt sql.NullString
+		lastError       sql.NullString
+	)
+
+	err := scanner.Scan(
+		&p.ID,
+		&createdAt,
+		&updatedAt,
+		&p.LibraryID,
+		&p.FeedURL,
+		&p.Title,
+		&author,
+		&description,
+		&imageURL,
+		&link,
+		&language,
+		&explicit,
+		&p.Dir,
+		&autoDownload,
+		&p.KeepEpisodes,
+		&p.KeepDays,
+		&etag,
+		&lastModified,
+		&lastRefreshedAt,
+		&lastError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	p.UpdatedAt, err = parseTime(updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	p.LastRefreshedAt, err = parseNullableTime(lastRefreshedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Author = author.String
+	p.Description = description.String
+	p.ImageURL = imageURL.String
+	p.Link = link.String
+	p.Language = language.String
+	p.ETag = etag.String
+	p.LastModified = lastModified.String
+	p.LastError = lastError.String
+	p.Explicit = explicit != 0
+	p.AutoDownload = autoDownload != 0
+
+	return &p, nil
+}
+
+// CreatePodcast inserts a new podcast subscription.
+// Returns store.ErrAlreadyExists if the ID is taken or the library is
+// already subscribed to the feed.
+func (s *Store) CreatePodcast(ctx context.Context, p *domain.Podcast) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO podcasts (`+podcastColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		p.ID,
+		formatTime(p.CreatedAt),
+		formatTime(p.UpdatedAt),
+		p.LibraryID,
+		p.FeedURL,
+		p.Title,
+		nullString(p.Author),
+		nullString(p.Description),
+		nullString(p.ImageURL),
+		nullString(p.Link),
+		nullString(p.Language),
+		boolToInt(p.Explicit),
+		p.Dir,
+		boolToInt(p.AutoDownload),
+		p.KeepEpisodes,
+		p.KeepDays,
+		nullString(p.ETag),
+		nullString(p.LastModified),
+		nullTimeString(p.LastRefreshedAt),
+		nullString(p.LastError),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetPodcast retrieves a podcast by ID.
+// Returns store.ErrPodcastNotFound if it does not exist.
+func (s *Store) GetPodcast(ctx context.Context, id string) (*domain.Podcast, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+podcastColumns+` FROM podcasts WHERE id = ?`, id)
+
+	p, err := scanPodcast(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrPodcastNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetPodcastByFeedURL retrieves a library's subscription to a feed.
+// Returns store.ErrPodcastNotFound if the library isn't subscribed.
+func (s *Store) GetPodcastByFeedURL(ctx context.Context, libraryID, feedURL string) (*domain.Podcast, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+podcastColumns+` FROM podcasts WHERE library_id = ? AND feed_url = ?`,
+		libraryID, feedURL)
+
+	p, err := scanPodcast(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrPodcastNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPodcasts returns every podcast, ordered by title.
+func (s *Store) ListPodcasts(ctx context.Context) ([]*domain.Podcast, error) {
+	return s.queryPodcasts(ctx,
+		`SELECT `+podcastColumns+` FROM podcasts ORDER BY title COLLATE NOCASE ASC`)
+}
+
+// ListPodcastsForLibrary returns a library's podcasts, ordered by title.
+func (s *Store) ListPodcastsForLibrary(ctx context.Context, libraryID string) ([]*domain.Podcast, error) {
+	return s.queryPodcasts(ctx,
+		`SELECT `+podcastColumns+` FROM podcasts WHERE library_id = ? ORDER BY title COLLATE NOCASE ASC`,
+		libraryID)
+}
+
+func (s *Store) queryPodcasts(ctx context.Context, query string, args ...any) ([]*domain.Podcast, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var podcasts []*domain.Podcast
+	for rows.Next() {
+		p, err := scanPodcast(rows)
+		if err != nil {
+			return nil, err
+		}
+		podcasts = append(podcasts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return podcasts, nil
+}
+
+// UpdatePodcast performs a full row update on an existing podcast. The
+// library and feed URL are fixed at subscription.
+// Returns store.ErrPodcastNotFound if it does not exist.
+func (s *Store) UpdatePodcast(ctx context.Context, p *domain.Podcast) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE podcasts SET
+			updated_at = ?,
+			title = ?,
+			author = ?,
+			description = ?,
+			image_url = ?,
+			link = ?,
+			language = ?,
+			explicit = ?,
+			dir = ?,
+			auto_download = ?,
+			keep_episodes = ?,
+			keep_days = ?,
+			etag = ?,
+			last_modified = ?,
+			last_refreshed_at = ?,
+			last_error = ?
+		WHERE id = ?`,
+		formatTime(p.UpdatedAt),
+		p.Title,
+		nullString(p.Author),
+		nullString(p.Description),
+		nullString(p.ImageURL),
+		nullString(p.Link),
+		nullString(p.Language),
+		boolToInt(p.Explicit),
+		p.Dir,
+		boolToInt(p.AutoDownload),
+		p.KeepEpisodes,
+		p.KeepDays,
+		nullString(p.ETag),
+		nullString(p.LastModified),
+		nullTimeString(p.LastRefreshedAt),
+		nullString(p.LastError),
+		p.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrPodcastNotFound
+	}
+	return nil
+}
+
+// DeletePodcast deletes a podcast. Its episodes and their playback state
+// go with it.
+// Returns store.ErrPodcastNotFound if it does not exist.
+func (s *Store) DeletePodcast(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM podcasts WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrPodcastNotFound
+	}
+	return nil
+}
+
+// episodeColumns is the ordered list of columns selected in episode queries.
+// Must match the scan order in scanEpisode.
+const episodeColumns = `id, created_at, updated_at, podcast_id, guid, title, description,
+	published_at, duration_ms, season, number, enclosure_url, enclosure_type,
+	enclosure_size, status, file_path, file_size, downloaded_at, error`
+
+// scanEpisode scans a sql.Row (or sql.Rows via its Scan method) into a domain.PodcastEpisode.
+func scanEpisode(scanner interface{ Scan(dest ...any) error }) (*domain.PodcastEpisode, error) {
+	var e domain.PodcastEpisode
+
+	var (
+		createdAt     string
+		updatedAt     string
+		description   sql.NullString
+		publishedAt   sql.NullString
+		enclosureType sql.NullString
+		status        string
+		filePath      sql.NullString
+		downloadedAt  sql.NullString
+		errMsg        sql.NullString
+	)
+
+	err := scanner.Scan(
+		&e.ID,
+		&createdAt,
+		&updatedAt,
+		&e.PodcastID,
+		&e.GUID,
+		&e.Title,
+		&description,
+		&publishedAt,
+		&e.DurationMs,
+		&e.Season,
+		&e.Number,
+		&e.EnclosureURL,
+		&enclosureType,
+		&e.EnclosureSize,
+		&status,
+		&filePath,
+		&e.FileSize,
+		&downloadedAt,
+		&errMsg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	e.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	e.UpdatedAt, err = parseTime(updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	e.PublishedAt, err = parseNullableTime(publishedAt)
+	if err != nil {
+		return nil, err
+	}
+	e.DownloadedAt, err = parseNullableTime(downloadedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Description = description.String
+	e.EnclosureType = enclosureType.String
+	e.FilePath = filePath.String
+	e.Error = errMsg.String
+	e.Status = domain.EpisodeStatus(status)
+
+	return &e, nil
+}
+
+// CreatePodcastEpisode inserts a new episode.
+// Returns store.ErrAlreadyExists if the ID is taken or the podcast already
+// has an episode with the same GUID.
+func (s *Store) CreatePodcastEpisode(ctx context.Context, e *domain.PodcastEpisode) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO podcast_episodes (`+episodeColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.ID,
+		formatTime(e.CreatedAt),
+		formatTime(e.UpdatedAt),
+		e.PodcastID,
+		e.GUID,
+		e.Title,
+		nullString(e.Description),
+		nullTimeString(e.PublishedAt),
+		e.DurationMs,
+		e.Season,
+		e.Number,
+		e.EnclosureURL,
+		nullString(e.EnclosureType),
+		e.EnclosureSize,
+		string(e.Status),
+		nullString(e.FilePath),
+		e.FileSize,
+		nullTimeString(e.DownloadedAt),
+		nullString(e.Error),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetPodcastEpisode retrieves an episode by ID.
+// Returns store.ErrEpisodeNotFound if it does not exist.
+func (s *Store) GetPodcastEpisode(ctx context.Context, id string) (*domain.PodcastEpisode, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+episodeColumns+` FROM podcast_episodes WHERE id = ?`, id)
+
+	e, err := scanEpisode(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrEpisodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// GetPodcastEpisodeByGUID retrieves a podcast's episode by its feed GUID.
+// Returns store.ErrEpisodeNotFound if the podcast has no such episode.
+func (s *Store) GetPodcastEpisodeByGUID(ctx context.Context, podcastID, guid string) (*domain.PodcastEpisode, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+episodeColumns+` FROM podcast_episodes WHERE podcast_id = ? AND guid = ?`,
+		podcastID, guid)
+
+	e, err := scanEpisode(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrEpisodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ListPodcastEpisodes returns a podcast's episodes, newest first.
+func (s *Store) ListPodcastEpisodes(ctx context.Context, podcastID string) ([]*domain.PodcastEpisode, error) {
+	return s.queryEpisodes(ctx, `
+		SELECT `+episodeColumns+` FROM podcast_episodes WHERE podcast_id = ?
+		ORDER BY COALESCE(published_at, created_at) DESC, id DESC`,
+		podcastID)
+}
+
+// ListPodcastEpisodesByStatus returns episodes in a download state across
+// all podcasts, oldest first.
+func (s *Store) ListPodcastEpisodesByStatus(ctx context.Context, status domain.EpisodeStatus) ([]*domain.PodcastEpisode, error) {
+	return s.queryEpisodes(ctx, `
+		SELECT `+episodeColumns+` FROM podcast_episodes WHERE status = ?
+		ORDER BY COALESCE(published_at, created_at) ASC, id ASC`,
+		string(status))
+}
+
+func (s *Store) queryEpisodes(ctx context.Context, query string, args ...any) ([]*domain.PodcastEpisode, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var episodes []*domain.PodcastEpisode
+	for rows.Next() {
+		e, err := scanEpisode(rows)
+		if err != nil {
+			return nil, err
+		}
+		episodes = append(episodes, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return episodes, nil
+}
+
+// UpdatePodcastEpisode performs a full row update on an existing episode.
+// Returns store.ErrEpisodeNotFound if it does not exist.
+func (s *Store) UpdatePodcastEpisode(ctx context.Context, e *domain.PodcastEpisode) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE podcast_episodes SET
+			updated_at = ?,
+			guid = ?,
+			title = ?,
+			description = ?,
+			published_at = ?,
+			duration_ms = ?,
+			season = ?,
+			number = ?,
+			enclosure_url = ?,
+			enclosure_type = ?,
+			enclosure_size = ?,
+			status = ?,
+			file_path = ?,
+			file_size = ?,
+			downloaded_at = ?,
+			error = ?
+		WHERE id = ?`,
+		formatTime(e.UpdatedAt),
+		e.GUID,
+		e.Title,
+		nullString(e.Description),
+		nullTimeString(e.PublishedAt),
+		e.DurationMs,
+		e.Season,
+		e.Number,
+		e.EnclosureURL,
+		nullString(e.EnclosureType),
+		e.EnclosureSize,
+		string(e.Status),
+		nullString(e.FilePath),
+		e.FileSize,
+		nullTimeString(e.DownloadedAt),
+		nullString(e.Error),
+		e.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrEpisodeNotFound
+	}
+	return nil
+}
+
+// episodeListeningEventColumns mirrors listeningEventColumns with the
+// episode in the book's place, so scanListeningEvent reads both.
+const episodeListeningEventColumns = `id, user_id, episode_id,
+	start_position_ms, end_position_ms, started_at, ended_at,
+	playback_speed, device_id, device_name, source, duration_ms, created_at`
+
+// episodePlaybackStateColumns mirrors playbackStateColumns with the
+// episode in the book's place, so scanPlaybackState reads both.
+const episodePlaybackStateColumns = `user_id, episode_id, current_position_ms, is_finished, finished_at,
+	started_at, last_played_at, total_listen_time_ms, updated_at`
+
+// CreateEpisodeListeningEvent inserts a listening event for an episode.
+// The event's BookID holds the episode ID.
+// Returns store.ErrAlreadyExists if the event ID already exists.
+func (s *Store) CreateEpisodeListeningEvent(ctx context.Context, event *domain.ListeningEvent) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO episode_listening_events (`+episodeListeningEventColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.ID,
+		event.UserID,
+		event.BookID,
+		event.StartPositionMs,
+		event.EndPositionMs,
+		formatTime(event.StartedAt),
+		formatTime(event.EndedAt),
+		event.PlaybackSpeed,
+		event.DeviceID,
+		nullString(event.DeviceName),
+		event.Source,
+		event.DurationMs,
+		formatTime(event.CreatedAt),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetEpisodePlaybackState retrieves a user's playback state for an episode.
+// The state's BookID holds the episode ID.
+// Returns store.ErrProgressNotFound if the user hasn't played the episode.
+func (s *Store) GetEpisodePlaybackState(ctx context.Context, userID, episodeID string) (*domain.PlaybackState, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+episodePlaybackStateColumns+` FROM episode_playback_state WHERE user_id = ? AND episode_id = ?`,
+		userID, episodeID)
+
+	state, err := scanPlaybackState(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrProgressNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// UpsertEpisodePlaybackState creates or replaces a user's playback state
+// for an episode. The state's BookID holds the episode ID.
+func (s *Store) UpsertEpisodePlaybackState(ctx context.Context, state *domain.PlaybackState) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO episode_playback_state (`+episodePlaybackStateColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		state.UserID,
+		state.BookID,
+		state.CurrentPositionMs,
+		boolToInt(state.IsFinished),
+		nullTimeString(state.FinishedAt),
+		formatTime(state.StartedAt),
+		formatTime(state.LastPlayedAt),
+		state.TotalListenTimeMs,
+		formatTime(state.UpdatedAt),
+	)
+	return err
+}
+
+// ListEpisodePlaybackStates returns a user's playback state for the
+// episodes of a podcast they have played.
+func (s *Store) ListEpisodePlaybackStates(ctx context.Context, userID, podcastID string) ([]*domain.PlaybackState, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT eps.user_id, eps.episode_id, eps.current_position_ms, eps.is_finished, eps.finished_at,
+			eps.started_at, eps.last_played_at, eps.total_listen_time_ms, eps.updated_at
+		FROM episode_playback_state eps
+		JOIN podcast_episodes pe ON pe.id = eps.episode_id
+		WHERE eps.user_id = ? AND pe.podcast_id = ?`,
+		userID, podcastID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var states []*domain.PlaybackState
+	for rows.Next() {
+		state, err := scanPlaybackState(rows)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
@@ -0,0 +1,254 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func makeTestPodcast(id, libraryID, feedURL string) *domain.Podcast {
+	now := time.Now().UTC()
+	return &domain.Podcast{
+		ID:        id,
+		LibraryID: libraryID,
+		FeedURL:   feedURL,
+		Title:     "Podcast " + id,
+		Dir:       "/podcasts/" + id,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func makeTestEpisode(id, podcastID, guid string, published time.Time) *domain.PodcastEpisode {
+	now := time.Now().UTC()
+	return &domain.PodcastEpisode{
+		ID:           id,
+		PodcastID:    podcastID,
+		GUID:         guid,
+		Title:        "Episode " + id,
+		PublishedAt:  &published,
+		EnclosureURL: "https://cdn.example.com/" + id + ".mp3",
+		Status:       domain.EpisodeStatusAvailable,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// setupTestPodcast creates a user, a podcast library and one podcast.
+func setupTestPodcast(t *testing.T, s *Store, suffix string) *domain.Podcast {
+	t.Helper()
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-pc-"+suffix)
+	lib := makeTestLibrary("lib-pc-"+suffix, "user-pc-"+suffix, "Podcasts")
+	lib.Type = domain.LibraryTypePodcast
+	lib.ScanPaths = []string{"/podcasts"}
+	if err := s.CreateLibrary(ctx, lib); err != nil {
+		t.Fatalf("CreateLibrary: %v", err)
+	}
+
+	p := makeTestPodcast("pc-"+suffix, lib.ID, "https://example.com/"+suffix+".xml")
+	if err := s.CreatePodcast(ctx, p); err != nil {
+		t.Fatalf("CreatePodcast: %v", err)
+	}
+	return p
+}
+
+func TestPodcastLibraryType(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	createTestOwner(t, s, "user-lt")
+	books := makeTestLibrary("lib-lt-books", "user-lt", "Books")
+	books.CreatedAt = time.Now().Add(time.Hour) // created after the podcast library
+	pods := makeTestLibrary("lib-lt-pods", "user-lt", "Podcasts")
+	pods.Type = domain.LibraryTypePodcast
+	pods.ScanPaths = []string{"/media/podcasts"}
+	for _, lib := range []*domain.Library{pods, books} {
+		if err := s.CreateLibrary(ctx, lib); err != nil {
+			t.Fatalf("CreateLibrary: %v", err)
+		}
+	}
+
+	got, err := s.GetLibrary(ctx, pods.ID)
+	if err != nil {
+		t.Fatalf("GetLibrary: %v", err)
+	}
+	if !got.IsPodcast() {
+		t.Errorf("Type = %q, want podcast", got.Type)
+	}
+
+	// Podcast libraries are never the default.
+	def, err := s.GetDefaultLibrary(ctx)
+	if err != nil {
+		t.Fatalf("GetDefaultLibrary: %v", err)
+	}
+	if def.ID != books.ID {
+		t.Errorf("GetDefaultLibrary = %s, want %s", def.ID, books.ID)
+	}
+}
+
+func TestCreateAndGetPodcast(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := setupTestPodcast(t, s, "1")
+
+	got, err := s.GetPodcastByFeedURL(ctx, p.LibraryID, p.FeedURL)
+	if err != nil {
+		t.Fatalf("GetPodcastByFeedURL: %v", err)
+	}
+	if got.ID != p.ID || got.Dir != p.Dir || got.LastRefreshedAt != nil {
+		t.Errorf("got %+v", got)
+	}
+
+	// A library subscribes to a feed once.
+	dup := makeTestPodcast("pc-dup", p.LibraryID, p.FeedURL)
+	if err := s.CreatePodcast(ctx, dup); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate feed: got %v, want ErrAlreadyExists", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	got.AutoDownload = true
+	got.KeepEpisodes = 5
+	got.ETag = `"abc"`
+	got.LastRefreshedAt = &now
+	got.Author = "Jane Host"
+	if err := s.UpdatePodcast(ctx, got); err != nil {
+		t.Fatalf("UpdatePodcast: %v", err)
+	}
+	updated, err := s.GetPodcast(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("GetPodcast: %v", err)
+	}
+	if !updated.AutoDownload || updated.KeepEpisodes != 5 || updated.ETag != `"abc"` ||
+		updated.Author != "Jane Host" || updated.LastRefreshedAt == nil || !updated.LastRefreshedAt.Equal(now) {
+		t.Errorf("after update: %+v", updated)
+	}
+
+	list, err := s.ListPodcastsForLibrary(ctx, p.LibraryID)
+	if err != nil || len(list) != 1 {
+		t.Errorf("ListPodcastsForLibrary = %d, %v", len(list), err)
+	}
+
+	if _, err := s.GetPodcast(ctx, "missing"); !errors.Is(err, store.ErrPodcastNotFound) {
+		t.Errorf("GetPodcast(missing): got %v, want ErrPodcastNotFound", err)
+	}
+	if err := s.DeletePodcast(ctx, "missing"); !errors.Is(err, store.ErrPodcastNotFound) {
+		t.Errorf("DeletePodcast(missing): got %v, want ErrPodcastNotFound", err)
+	}
+}
+
+func TestPodcastEpisodes(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := setupTestPodcast(t, s, "2")
+
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	older := makeTestEpisode("ep-old", p.ID, "guid-old", base)
+	newer := makeTestEpisode("ep-new", p.ID, "guid-new", base.AddDate(0, 0, 7))
+	for _, e := range []*domain.PodcastEpisode{older, newer} {
+		if err := s.CreatePodcastEpisode(ctx, e); err != nil {
+			t.Fatalf("CreatePodcastEpisode: %v", err)
+		}
+	}
+	if err := s.CreatePodcastEpisode(ctx, makeTestEpisode("ep-dup", p.ID, "guid-old", base)); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate GUID: got %v, want ErrAlreadyExists", err)
+	}
+
+	episodes, err := s.ListPodcastEpisodes(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("ListPodcastEpisodes: %v", err)
+	}
+	if len(episodes) != 2 || episodes[0].ID != "ep-new" {
+		t.Fatalf("episodes not newest first: %+v", episodes)
+	}
+
+	got, err := s.GetPodcastEpisodeByGUID(ctx, p.ID, "guid-old")
+	if err != nil {
+		t.Fatalf("GetPodcastEpisodeByGUID: %v", err)
+	}
+	got.Status = domain.EpisodeStatusDownloaded
+	got.FilePath = "/podcasts/pc-2/old.mp3"
+	got.FileSize = 1024
+	got.DownloadedAt = &base
+	if err := s.UpdatePodcastEpisode(ctx, got); err != nil {
+		t.Fatalf("UpdatePodcastEpisode: %v", err)
+	}
+
+	downloaded, err := s.ListPodcastEpisodesByStatus(ctx, domain.EpisodeStatusDownloaded)
+	if err != nil {
+		t.Fatalf("ListPodcastEpisodesByStatus: %v", err)
+	}
+	if len(downloaded) != 1 || !downloaded[0].IsDownloaded() || downloaded[0].FileSize != 1024 {
+		t.Errorf("downloaded = %+v", downloaded)
+	}
+
+	if _, err := s.GetPodcastEpisode(ctx, "missing"); !errors.Is(err, store.ErrEpisodeNotFound) {
+		t.Errorf("GetPodcastEpisode(missing): got %v, want ErrEpisodeNotFound", err)
+	}
+
+	// Deleting the podcast removes its episodes.
+	if err := s.DeletePodcast(ctx, p.ID); err != nil {
+		t.Fatalf("DeletePodcast: %v", err)
+	}
+	if _, err := s.GetPodcastEpisode(ctx, "ep-new"); !errors.Is(err, store.ErrEpisodeNotFound) {
+		t.Errorf("episode survived its podcast: %v", err)
+	}
+}
+
+func TestEpisodePlaybackState(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := setupTestPodcast(t, s, "3")
+	userID := "user-pc-3"
+
+	ep := makeTestEpisode("ep-play", p.ID, "guid-play", time.Now().UTC())
+	if err := s.CreatePodcastEpisode(ctx, ep); err != nil {
+		t.Fatalf("CreatePodcastEpisode: %v", err)
+	}
+
+	if _, err := s.GetEpisodePlaybackState(ctx, userID, ep.ID); !errors.Is(err, store.ErrProgressNotFound) {
+		t.Errorf("before playing: got %v, want ErrProgressNotFound", err)
+	}
+
+	start := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	event := domain.NewListeningEvent("ele-1", userID, ep.ID, 0, 60000, start, start.Add(time.Minute), 1, "dev-1", "Phone")
+	if err := s.CreateEpisodeListeningEvent(ctx, event); err != nil {
+		t.Fatalf("CreateEpisodeListeningEvent: %v", err)
+	}
+	if err := s.CreateEpisodeListeningEvent(ctx, event); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate event: got %v, want ErrAlreadyExists", err)
+	}
+
+	state := domain.NewPlaybackState(event, 1800000)
+	if err := s.UpsertEpisodePlaybackState(ctx, state); err != nil {
+		t.Fatalf("UpsertEpisodePlaybackState: %v", err)
+	}
+
+	got, err := s.GetEpisodePlaybackState(ctx, userID, ep.ID)
+	if err != nil {
+		t.Fatalf("GetEpisodePlaybackState: %v", err)
+	}
+	if got.BookID != ep.ID || got.CurrentPositionMs != 60000 || got.IsFinished {
+		t.Errorf("state = %+v", got)
+	}
+
+	states, err := s.ListEpisodePlaybackStates(ctx, userID, p.ID)
+	if err != nil || len(states) != 1 {
+		t.Errorf("ListEpisodePlaybackStates = %d, %v", len(states), err)
+	}
+
+	// Episode listening stays out of book listening.
+	events, err := s.GetListeningEvents(ctx, userID)
+	if err != nil || len(events) != 0 {
+		t.Errorf("book listening events = %d, %v", len(events), err)
+	}
+}
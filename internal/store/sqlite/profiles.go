@@ -13,16 +13,21 @@ import (
 
 // profileColumns is the ordered list of columns selected in profile queries.
 // Must match the scan order in scanProfile.
-const profileColumns = `user_id, avatar_type, avatar_value, tagline, created_at, updated_at`
+const profileColumns = `user_id, avatar_type, avatar_value, tagline,
+	hide_from_leaderboard, hide_activity, hide_currently_listening,
+	created_at, updated_at`
 
 // scanProfile scans a sql.Row (or sql.Rows via its Scan method) into a domain.UserProfile.
 func scanProfile(scanner interface{ Scan(dest ...any) error }) (*domain.UserProfile, error) {
 	var p domain.UserProfile
 
 	var (
-		avatarType string
-		createdAt  string
-		updatedAt  string
+		avatarType             string
+		hideFromLeaderboard    int
+		hideActivity           int
+		hideCurrentlyListening int
+		createdAt              string
+		updatedAt              string
 	)
 
 	err := scanner.Scan(
@@ -30,6 +35,9 @@ func scanProfile(scanner interface{ Scan(dest ...any) error }) (*domain.UserProf
 		&avatarType,
 		&p.AvatarValue,
 		&p.Tagline,
+		&hideFromLeaderboard,
+		&hideActivity,
+		&hideCurrentlyListening,
 		&createdAt,
 		&updatedAt,
 	)
@@ -38,6 +46,9 @@ func scanProfile(scanner interface{ Scan(dest ...any) error }) (*domain.UserProf
 	}
 
 	p.AvatarType = domain.AvatarType(avatarType)
+	p.HideFromLeaderboard = hideFromLeaderboard != 0
+	p.HideActivity = hideActivity != 0
+	p.HideCurrentlyListening = hideCurrentlyListening != 0
 
 	p.CreatedAt, err = parseTime(createdAt)
 	if err != nil {
@@ -112,12 +123,17 @@ func (s *Store) GetUserProfilesByIDs(ctx context.Context, userIDs []string) (map
 func (s *Store) SaveUserProfile(ctx context.Context, profile *domain.UserProfile) error {
 	_, err := s.db.ExecContext(ctx, `
 		INSERT OR REPLACE INTO user_profiles (
-			user_id, avatar_type, avatar_value, tagline, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?)`,
+			user_id, avatar_type, avatar_value, tagline,
+			hide_from_leaderboard, hide_activity, hide_currently_listening,
+			created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		profile.UserID,
 		string(profile.AvatarType),
 		profile.AvatarValue,
 		profile.Tagline,
+		boolToInt(profile.HideFromLeaderboard),
+		boolToInt(profile.HideActivity),
+		boolToInt(profile.HideCurrentlyListening),
 		formatTime(profile.CreatedAt),
 		formatTime(profile.UpdatedAt),
 	)
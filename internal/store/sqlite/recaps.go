@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json/v2"
+	"errors"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// scanListeningRecap scans a (data, generated_at, share_id) row into a domain.ListeningRecap.
+func scanListeningRecap(scanner interface{ Scan(dest ...any) error }) (*domain.ListeningRecap, error) {
+	var (
+		data        string
+		generatedAt string
+		shareID     sql.NullString
+	)
+	if err := scanner.Scan(&data, &generatedAt, &shareID); err != nil {
+		return nil, err
+	}
+
+	var recap domain.ListeningRecap
+	if err := json.Unmarshal([]byte(data), &recap); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if recap.GeneratedAt, err = parseTime(generatedAt); err != nil {
+		return nil, err
+	}
+	recap.ShareID = shareID.String
+
+	return &recap, nil
+}
+
+// GetListeningRecap retrieves a user's cached recap for a period.
+// Returns store.ErrRecapNotFound if none is cached.
+func (s *Store) GetListeningRecap(ctx context.Context, userID, period string) (*domain.ListeningRecap, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT data, generated_at, share_id FROM listening_recaps WHERE user_id = ? AND period = ?`,
+		userID, period)
+
+	recap, err := scanListeningRecap(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrRecapNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return recap, nil
+}
+
+// GetListeningRecapByShareID retrieves a shared recap by its share ID.
+// Returns store.ErrRecapNotFound if no recap is shared under the ID.
+func (s *Store) GetListeningRecapByShareID(ctx context.Context, shareID string) (*domain.ListeningRecap, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT data, generated_at, share_id FROM listening_recaps WHERE share_id = ?`,
+		shareID)
+
+	recap, err := scanListeningRecap(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrRecapNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return recap, nil
+}
+
+// SaveListeningRecap creates or replaces a user's cached recap for a period.
+// The share ID is only written when the recap is first cached; replacing a
+// recap keeps the stored one, so a rebuild can't undo a concurrent unshare.
+// Use ShareListeningRecap and UnshareListeningRecap to change it.
+func (s *Store) SaveListeningRecap(ctx context.Context, recap *domain.ListeningRecap) error {
+	data, err := json.Marshal(recap)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO listening_recaps (user_id, period, data, generated_at, share_id)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(user_id, period) DO UPDATE SET
+			data = excluded.data,
+			generated_at = excluded.generated_at`,
+		recap.UserID,
+		recap.Period,
+		string(data),
+		formatTime(recap.GeneratedAt),
+		nullString(recap.ShareID),
+	)
+	return err
+}
+
+// ShareListeningRecap shares a cached recap under shareID unless it is
+// already shared, and returns the share ID it ends up with.
+// Returns store.ErrRecapNotFound if no recap is cached for the period.
+func (s *Store) ShareListeningRecap(ctx context.Context, userID, period, shareID string) (string, error) {
+	var current string
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE listening_recaps SET share_id = COALESCE(share_id, ?)
+		WHERE user_id = ? AND period = ?
+		RETURNING share_id`,
+		shareID, userID, period).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", store.ErrRecapNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return current, nil
+}
+
+// UnshareListeningRecap takes a cached recap off its share page.
+// Returns store.ErrRecapNotFound if no recap is cached for the period.
+func (s *Store) UnshareListeningRecap(ctx context.Context, userID, period string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE listening_recaps SET share_id = NULL WHERE user_id = ? AND period = ?`,
+		userID, period)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrRecapNotFound
+	}
+	return nil
+}
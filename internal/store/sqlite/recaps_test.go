@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func TestListeningRecapCache(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-recap-1")
+
+	if _, err := s.GetListeningRecap(ctx, "user-recap-1", "2025"); !errors.Is(err, store.ErrRecapNotFound) {
+		t.Fatalf("expected ErrRecapNotFound, got %v", err)
+	}
+
+	now := time.Now().UTC()
+	recap := &domain.ListeningRecap{
+		UserID:            "user-recap-1",
+		Period:            "2025",
+		StartDate:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:           time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		GeneratedAt:       now.Add(-time.Hour),
+		TotalListenTimeMs: 3_600_000,
+		BooksFinished:     2,
+		TopAuthors:        []domain.RecapRanking{{ID: "c-1", Name: "Ursula K. Le Guin", ListenTimeMs: 3_600_000}},
+		FirstBook:         &domain.RecapBook{BookID: "b-1", Title: "The Dispossessed", ListenedAt: now},
+	}
+	recap.HourlyHeatmap[time.Tuesday][21] = 3_600_000
+	if err := s.SaveListeningRecap(ctx, recap); err != nil {
+		t.Fatalf("SaveListeningRecap: %v", err)
+	}
+
+	got, err := s.GetListeningRecap(ctx, "user-recap-1", "2025")
+	if err != nil {
+		t.Fatalf("GetListeningRecap: %v", err)
+	}
+	if got.TotalListenTimeMs != 3_600_000 || got.BooksFinished != 2 || got.ShareID != "" ||
+		len(got.TopAuthors) != 1 || got.TopAuthors[0].Name != "Ursula K. Le Guin" ||
+		got.FirstBook == nil || got.FirstBook.Title != "The Dispossessed" ||
+		got.HourlyHeatmap[time.Tuesday][21] != 3_600_000 {
+		t.Errorf("got %+v, want %+v", got, recap)
+	}
+	if !got.GeneratedAt.Equal(recap.GeneratedAt) {
+		t.Errorf("GeneratedAt: got %v, want %v", got.GeneratedAt, recap.GeneratedAt)
+	}
+
+	shareID, err := s.ShareListeningRecap(ctx, "user-recap-1", "2025", "recap-share-1")
+	if err != nil {
+		t.Fatalf("ShareListeningRecap: %v", err)
+	}
+	if shareID != "recap-share-1" {
+		t.Errorf("ShareListeningRecap: got %q, want recap-share-1", shareID)
+	}
+	// Sharing again keeps the first share ID.
+	if shareID, err = s.ShareListeningRecap(ctx, "user-recap-1", "2025", "recap-share-2"); err != nil || shareID != "recap-share-1" {
+		t.Errorf("ShareListeningRecap (again): got %q, %v, want recap-share-1", shareID, err)
+	}
+
+	// Replacing the recap keeps its share ID, whatever the recap carries.
+	recap.GeneratedAt = now
+	if err := s.SaveListeningRecap(ctx, recap); err != nil {
+		t.Fatalf("SaveListeningRecap (rebuild): %v", err)
+	}
+	got, err = s.GetListeningRecapByShareID(ctx, "recap-share-1")
+	if err != nil {
+		t.Fatalf("GetListeningRecapByShareID: %v", err)
+	}
+	if got.UserID != "user-recap-1" || got.Period != "2025" || got.ShareID != "recap-share-1" {
+		t.Errorf("shared recap: got %+v", got)
+	}
+
+	if err := s.UnshareListeningRecap(ctx, "user-recap-1", "2025"); err != nil {
+		t.Fatalf("UnshareListeningRecap: %v", err)
+	}
+	if _, err := s.GetListeningRecapByShareID(ctx, "recap-share-1"); !errors.Is(err, store.ErrRecapNotFound) {
+		t.Errorf("after unshare: expected ErrRecapNotFound, got %v", err)
+	}
+
+	// A rebuild that still carries the old share ID doesn't share it again.
+	recap.ShareID = "recap-share-1"
+	if err := s.SaveListeningRecap(ctx, recap); err != nil {
+		t.Fatalf("SaveListeningRecap (stale share): %v", err)
+	}
+	if _, err := s.GetListeningRecapByShareID(ctx, "recap-share-1"); !errors.Is(err, store.ErrRecapNotFound) {
+		t.Errorf("after stale save: expected ErrRecapNotFound, got %v", err)
+	}
+
+	if _, err := s.ShareListeningRecap(ctx, "user-recap-1", "1999", "recap-share-3"); !errors.Is(err, store.ErrRecapNotFound) {
+		t.Errorf("share missing recap: expected ErrRecapNotFound, got %v", err)
+	}
+	if err := s.UnshareListeningRecap(ctx, "user-recap-1", "1999"); !errors.Is(err, store.ErrRecapNotFound) {
+		t.Errorf("unshare missing recap: expected ErrRecapNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// reviewColumns is the ordered list of columns selected in review queries.
+// Must match the scan order in scanReview.
+const reviewColumns = `id, user_id, book_id, rating, text, contains_spoilers,
+	created_at, updated_at`
+
+// scanReview scans a sql.Row (or sql.Rows via its Scan method) into a domain.BookReview.
+func scanReview(scanner interface{ Scan(dest ...any) error }) (*domain.BookReview, error) {
+	var r domain.BookReview
+
+	var (
+		text             sql.NullString
+		containsSpoilers int
+		createdAt        string
+		updatedAt        string
+	)
+
+	err := scanner.Scan(
+		&r.ID,
+		&r.UserID,
+		&r.BookID,
+		&r.Rating,
+		&text,
+		&containsSpoilers,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	r.UpdatedAt, err = parseTime(updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if text.Valid {
+		r.Text = text.String
+	}
+	r.ContainsSpoilers = containsSpoilers != 0
+
+	return &r, nil
+}
+
+// CreateReview inserts a new review.
+// Returns store.ErrAlreadyExists if the ID is taken or the user already
+// reviewed the book.
+func (s *Store) CreateReview(ctx context.Context, r *domain.BookReview) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO book_reviews (`+reviewColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.ID,
+		r.UserID,
+		r.BookID,
+		r.Rating,
+		nullString(r.Text),
+		boolToInt(r.ContainsSpoilers),
+		formatTime(r.CreatedAt),
+		formatTime(r.UpdatedAt),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetReview retrieves a review by ID.
+// Returns store.ErrReviewNotFound if it does not exist.
+func (s *Store) GetReview(ctx context.Context, id string) (*domain.BookReview, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+reviewColumns+` FROM book_reviews WHERE id = ?`, id)
+
+	r, err := scanReview(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetReviewForUserBook retrieves a user's review of a book.
+// Returns store.ErrReviewNotFound if the user has not reviewed the book.
+func (s *Store) GetReviewForUserBook(ctx context.Context, userID, bookID string) (*domain.BookReview, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+reviewColumns+` FROM book_reviews WHERE user_id = ? AND book_id = ?`,
+		userID, bookID)
+
+	r, err := scanReview(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UpdateReview replaces the rating, text and spoiler flag of a review.
+// Returns store.ErrReviewNotFound if it does not exist.
+func (s *Store) UpdateReview(ctx context.Context, r *domain.BookReview) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE book_reviews SET
+			rating = ?, text = ?, contains_spoilers = ?, updated_at = ?
+		WHERE id = ?`,
+		r.Rating,
+		nullString(r.Text),
+		boolToInt(r.ContainsSpoilers),
+		formatTime(r.UpdatedAt),
+		r.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrReviewNotFound
+	}
+	return nil
+}
+
+// DeleteReview removes a review.
+// Returns store.ErrReviewNotFound if it does not exist.
+func (s *Store) DeleteReview(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx,
+		`DELETE FROM book_reviews WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrReviewNotFound
+	}
+	return nil
+}
+
+// ListReviewsForBook returns the reviews of a book, most recently updated first.
+func (s *Store) ListReviewsForBook(ctx context.Context, bookID string, limit, offset int) ([]*domain.BookReview, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+reviewColumns+` FROM book_reviews
+		WHERE book_id = ?
+		ORDER BY updated_at DESC, id DESC
+		LIMIT ? OFFSET ?`,
+		bookID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []*domain.BookReview
+	for rows.Next() {
+		r, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GetRatingSummaries returns the average rating and review count for each of
+// the given books. Books nobody has rated are absent from the map.
+func (s *Store) GetRatingSummaries(ctx context.Context, bookIDs []string) (map[string]domain.RatingSummary, error) {
+	result := make(map[string]domain.RatingSummary, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(bookIDs))
+	args := make([]any, len(bookIDs))
+	for i, bid := range bookIDs {
+		placeholders[i] = "?"
+		args[i] = bid
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT book_id, AVG(rating), COUNT(*)
+		FROM book_reviews
+		WHERE book_id IN (`+strings.Join(placeholders, ",")+`)
+		GROUP BY book_id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query rating summaries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			bookID  string
+			summary domain.RatingSummary
+		)
+		if err := rows.Scan(&bookID, &summary.Average, &summary.Count); err != nil {
+			return nil, fmt.Errorf("scan rating summary: %w", err)
+		}
+		result[bookID] = summary
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetRatingSummary returns the average rating and review count for a book.
+// A book nobody has rated has a zero summary.
+func (s *Store) GetRatingSummary(ctx context.Context, bookID string) (domain.RatingSummary, error) {
+	var summary domain.RatingSummary
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(AVG(rating), 0), COUNT(*)
+		FROM book_reviews WHERE book_id = ?`, bookID).
+		Scan(&summary.Average, &summary.Count)
+	if err != nil {
+		return domain.RatingSummary{}, err
+	}
+	return summary, nil
+}
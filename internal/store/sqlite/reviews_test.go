@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func makeTestReview(id, userID, bookID string, rating float64) *domain.BookReview {
+	now := time.Now().UTC()
+	return &domain.BookReview{
+		ID:        id,
+		UserID:    userID,
+		BookID:    bookID,
+		Rating:    rating,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestCreateAndGetReview(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-rv-1")
+	insertTestBook(t, s, "book-rv-1", "Review Book", "/books/rv-1")
+
+	r := makeTestReview("rv-1", "user-rv-1", "book-rv-1", 4.5)
+	r.Text = "The ending landed."
+	r.ContainsSpoilers = true
+
+	if err := s.CreateReview(ctx, r); err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+
+	got, err := s.GetReviewForUserBook(ctx, "user-rv-1", "book-rv-1")
+	if err != nil {
+		t.Fatalf("GetReviewForUserBook: %v", err)
+	}
+	if got.ID != "rv-1" || got.Rating != 4.5 {
+		t.Errorf("got %s/%v, want rv-1/4.5", got.ID, got.Rating)
+	}
+	if got.Text != r.Text || !got.ContainsSpoilers {
+		t.Errorf("Text/ContainsSpoilers: got %q/%v", got.Text, got.ContainsSpoilers)
+	}
+
+	// A second review of the same book by the same user is rejected.
+	dup := makeTestReview("rv-2", "user-rv-1", "book-rv-1", 3)
+	if err := s.CreateReview(ctx, dup); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate create: got %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestGetReview_NotFound(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	if _, err := s.GetReview(ctx, "missing"); !errors.Is(err, store.ErrReviewNotFound) {
+		t.Errorf("GetReview: got %v, want ErrReviewNotFound", err)
+	}
+	if _, err := s.GetReviewForUserBook(ctx, "u", "b"); !errors.Is(err, store.ErrReviewNotFound) {
+		t.Errorf("GetReviewForUserBook: got %v, want ErrReviewNotFound", err)
+	}
+	if err := s.DeleteReview(ctx, "missing"); !errors.Is(err, store.ErrReviewNotFound) {
+		t.Errorf("DeleteReview: got %v, want ErrReviewNotFound", err)
+	}
+}
+
+func TestUpdateAndDeleteReview(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-rv-2")
+	insertTestBook(t, s, "book-rv-2", "Review Book", "/books/rv-2")
+
+	r := makeTestReview("rv-3", "user-rv-2", "book-rv-2", 2)
+	r.Text = "Slow start."
+	if err := s.CreateReview(ctx, r); err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+
+	r.Rating = 3.5
+	r.Text = ""
+	r.UpdatedAt = r.UpdatedAt.Add(time.Minute)
+	if err := s.UpdateReview(ctx, r); err != nil {
+		t.Fatalf("UpdateReview: %v", err)
+	}
+
+	got, err := s.GetReview(ctx, "rv-3")
+	if err != nil {
+		t.Fatalf("GetReview: %v", err)
+	}
+	if got.Rating != 3.5 || got.Text != "" {
+		t.Errorf("after update: got %v/%q, want 3.5/\"\"", got.Rating, got.Text)
+	}
+
+	if err := s.DeleteReview(ctx, "rv-3"); err != nil {
+		t.Fatalf("DeleteReview: %v", err)
+	}
+	if _, err := s.GetReview(ctx, "rv-3"); !errors.Is(err, store.ErrReviewNotFound) {
+		t.Errorf("after delete: got %v, want ErrReviewNotFound", err)
+	}
+}
+
+func TestListReviewsAndRatingSummaries(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-rv-a")
+	insertTestUser(t, s, "user-rv-b")
+	insertTestBook(t, s, "book-rv-a", "Rated Book", "/books/rv-a")
+	insertTestBook(t, s, "book-rv-b", "Unrated Book", "/books/rv-b")
+
+	first := makeTestReview("rv-a", "user-rv-a", "book-rv-a", 5)
+	second := makeTestReview("rv-b", "user-rv-b", "book-rv-a", 4)
+	second.UpdatedAt = first.UpdatedAt.Add(time.Second)
+	for _, r := range []*domain.BookReview{first, second} {
+		if err := s.CreateReview(ctx, r); err != nil {
+			t.Fatalf("CreateReview %s: %v", r.ID, err)
+		}
+	}
+
+	reviews, err := s.ListReviewsForBook(ctx, "book-rv-a", 10, 0)
+	if err != nil {
+		t.Fatalf("ListReviewsForBook: %v", err)
+	}
+	if len(reviews) != 2 || reviews[0].ID != "rv-b" {
+		t.Fatalf("ListReviewsForBook: got %d reviews, want newest (rv-b) first", len(reviews))
+	}
+
+	summaries, err := s.GetRatingSummaries(ctx, []string{"book-rv-a", "book-rv-b"})
+	if err != nil {
+		t.Fatalf("GetRatingSummaries: %v", err)
+	}
+	if got := summaries["book-rv-a"]; got.Average != 4.5 || got.Count != 2 {
+		t.Errorf("book-rv-a summary: got %+v, want 4.5 over 2", got)
+	}
+	if _, ok := summaries["book-rv-b"]; ok {
+		t.Error("book-rv-b: unrated book should be absent from summaries")
+	}
+
+	summary, err := s.GetRatingSummary(ctx, "book-rv-b")
+	if err != nil {
+		t.Fatalf("GetRatingSummary: %v", err)
+	}
+	if summary.Count != 0 || summary.Average != 0 {
+		t.Errorf("unrated summary: got %+v, want zero", summary)
+	}
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json/v2"
 	"errors"
 	"fmt"
 	"strings"
@@ -13,7 +14,7 @@ import (
 
 // shelfColumns is the ordered list of columns selected in shelf queries.
 // Must match the scan order in scanShelf.
-const shelfColumns = `id, created_at, updated_at, owner_id, name, description, color, icon`
+const shelfColumns = `id, created_at, updated_at, owner_id, name, description, color, icon, rules`
 
 // scanShelf scans a sql.Row (or sql.Rows via its Scan method) into a domain.Shelf.
 func scanShelf(scanner interface{ Scan(dest ...any) error }) (*domain.Shelf, error) {
@@ -25,6 +26,7 @@ func scanShelf(scanner interface{ Scan(dest ...any) error }) (*domain.Shelf, err
 		description sql.NullString
 		color       sql.NullString
 		icon        sql.NullString
+		rules       sql.NullString
 	)
 
 	err := scanner.Scan(
@@ -36,6 +38,7 @@ func scanShelf(scanner interface{ Scan(dest ...any) error }) (*domain.Shelf, err
 		&description,
 		&color,
 		&icon,
+		&rules,
 	)
 	if err != nil {
 		return nil, err
@@ -62,9 +65,30 @@ func scanShelf(scanner interface{ Scan(dest ...any) error }) (*domain.Shelf, err
 		l.Icon = icon.String
 	}
 
+	// Smart shelf rules (JSON).
+	if rules.Valid {
+		l.Rules = &domain.ShelfRules{}
+		if err := json.Unmarshal([]byte(rules.String), l.Rules); err != nil {
+			return nil, fmt.Errorf("unmarshal shelf rules: %w", err)
+		}
+	}
+
 	return &l, nil
 }
 
+// shelfRulesJSON encodes smart shelf rules for the rules column.
+// Manual shelves (nil rules) are stored as NULL.
+func shelfRulesJSON(rules *domain.ShelfRules) (sql.NullString, error) {
+	if rules == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("marshal shelf rules: %w", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
 // loadShelfBookIDs loads the ordered book IDs for a shelf from shelf_books.
 func (s *Store) loadShelfBookIDs(ctx context.Context, shelfID string) ([]string, error) {
 	rows, err := s.db.QueryContext(ctx,
@@ -91,6 +115,11 @@ func (s *Store) loadShelfBookIDs(ctx context.Context, shelfID string) ([]string,
 // CreateLens inserts a new shelf and its book associations.
 // Returns store.ErrAlreadyExists on duplicate ID.
 func (s *Store) CreateLens(ctx context.Context, lens *domain.Shelf) error {
+	rules, err := shelfRulesJSON(lens.Rules)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -99,8 +128,8 @@ func (s *Store) CreateLens(ctx context.Context, lens *domain.Shelf) error {
 
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO shelves (
-			id, created_at, updated_at, owner_id, name, description, color, icon
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, created_at, updated_at, owner_id, name, description, color, icon, rules
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		lens.ID,
 		formatTime(lens.CreatedAt),
 		formatTime(lens.UpdatedAt),
@@ -109,6 +138,7 @@ func (s *Store) CreateLens(ctx context.Context, lens *domain.Shelf) error {
 		nullString(lens.Description),
 		nullString(lens.Color),
 		nullString(lens.Icon),
+		rules,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -157,6 +187,11 @@ func (s *Store) GetLens(ctx context.Context, id string) (*domain.Shelf, error) {
 // UpdateLens updates a shelf row and replaces its book associations in a transaction.
 // Returns store.ErrNotFound if the shelf does not exist.
 func (s *Store) UpdateLens(ctx context.Context, lens *domain.Shelf) error {
+	rules, err := shelfRulesJSON(lens.Rules)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -171,7 +206,8 @@ func (s *Store) UpdateLens(ctx context.Context, lens *domain.Shelf) error {
 			name = ?,
 			description = ?,
 			color = ?,
-			icon = ?
+			icon = ?,
+			rules = ?
 		WHERE id = ?`,
 		formatTime(lens.CreatedAt),
 		formatTime(lens.UpdatedAt),
@@ -180,6 +216,7 @@ func (s *Store) UpdateLens(ctx context.Context, lens *domain.Shelf) error {
 		nullString(lens.Description),
 		nullString(lens.Color),
 		nullString(lens.Icon),
+		rules,
 		lens.ID,
 	)
 	if err != nil {
@@ -336,7 +373,7 @@ func (s *Store) ListAllLenses(ctx context.Context) ([]*domain.Shelf, error) {
 func (s *Store) GetLensesContainingBook(ctx context.Context, bookID string) ([]*domain.Shelf, error) {
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT s.id, s.created_at, s.updated_at, s.owner_id, s.name,
-		       s.description, s.color, s.icon
+		       s.description, s.color, s.icon, s.rules
 		FROM shelves s
 		INNER JOIN shelf_books sb ON sb.shelf_id = s.id
 		WHERE sb.book_id = ?
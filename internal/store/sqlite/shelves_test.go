@@ -80,6 +80,58 @@ func TestCreateAndGetLens(t *testing.T) {
 	}
 }
 
+func TestSmartShelfRules_RoundTrip(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-1")
+
+	now := time.Now()
+	shelf := &domain.Shelf{
+		CreatedAt: now,
+		UpdatedAt: now,
+		ID:        "shelf-smart",
+		OwnerID:   "user-1",
+		Name:      "Short Unread Fantasy",
+		Rules: &domain.ShelfRules{
+			GenreIDs:      []string{"genre-fantasy"},
+			MaxDurationMs: 8 * 60 * 60 * 1000,
+			Progress:      domain.ShelfProgressNotStarted,
+		},
+	}
+	if err := s.CreateShelf(ctx, shelf); err != nil {
+		t.Fatalf("CreateShelf: %v", err)
+	}
+
+	got, err := s.GetShelf(ctx, "shelf-smart")
+	if err != nil {
+		t.Fatalf("GetShelf: %v", err)
+	}
+	if !got.IsSmart() {
+		t.Fatal("expected smart shelf after round-trip")
+	}
+	if len(got.Rules.GenreIDs) != 1 || got.Rules.GenreIDs[0] != "genre-fantasy" {
+		t.Errorf("GenreIDs: got %v", got.Rules.GenreIDs)
+	}
+	if got.Rules.MaxDurationMs != shelf.Rules.MaxDurationMs || got.Rules.Progress != domain.ShelfProgressNotStarted {
+		t.Errorf("Rules: got %+v", got.Rules)
+	}
+
+	// Turning the shelf back into a manual one clears the rules column.
+	got.Rules = nil
+	if err := s.UpdateShelf(ctx, got); err != nil {
+		t.Fatalf("UpdateShelf: %v", err)
+	}
+	got, err = s.GetShelf(ctx, "shelf-smart")
+	if err != nil {
+		t.Fatalf("GetShelf after update: %v", err)
+	}
+	if got.IsSmart() {
+		t.Errorf("expected manual shelf, got rules %+v", got.Rules)
+	}
+}
+
 func TestGetLens_NotFound(t *testing.T) {
 	t.Parallel()
 	s := newTestStore(t)
@@ -196,11 +196,13 @@ func (s *Store) DeleteTranscodeJob(ctx context.Context, id string) error {
 }
 
 // GetTranscodeJobByAudioFile retrieves the first transcode job for a given audio file ID.
+// Loudness analysis jobs produce no stream and are never returned.
 // Returns store.ErrNotFound if no job exists for the audio file.
 func (s *Store) GetTranscodeJobByAudioFile(ctx context.Context, audioFileID string) (*domain.TranscodeJob, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT `+transcodeJobColumns+` FROM transcode_jobs WHERE audio_file_id = ? LIMIT 1`,
-		audioFileID)
+		`SELECT `+transcodeJobColumns+` FROM transcode_jobs
+		WHERE audio_file_id = ? AND variant != ? LIMIT 1`,
+		audioFileID, string(domain.TranscodeVariantLoudness))
 
 	job, err := scanTranscodeJob(row)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -0,0 +1,338 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// trashColumns is the ordered list of columns selected in trash queries.
+// Must match the scan order in scanTrashedBook.
+const trashColumns = `book_id, title, path, deleted_by, deleted_at, purge_at,
+	delete_files, tag_ids, collection_ids, shelves`
+
+// scanTrashedBook scans a sql.Row (or sql.Rows via its Scan method) into a domain.TrashedBook.
+func scanTrashedBook(scanner interface{ Scan(dest ...any) error }) (*domain.TrashedBook, error) {
+	var t domain.TrashedBook
+
+	var (
+		deletedAt     string
+		purgeAt       sql.NullString
+		deleteFiles   int
+		tagIDs        string
+		collectionIDs string
+		shelves       string
+	)
+
+	err := scanner.Scan(
+		&t.BookID,
+		&t.Title,
+		&t.Path,
+		&t.DeletedBy,
+		&deletedAt,
+		&purgeAt,
+		&deleteFiles,
+		&tagIDs,
+		&collectionIDs,
+		&shelves,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.DeletedAt, err = parseTime(deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	t.PurgeAt, err = parseNullableTime(purgeAt)
+	if err != nil {
+		return nil, err
+	}
+	t.DeleteFiles = deleteFiles != 0
+
+	if err := json.Unmarshal([]byte(tagIDs), &t.TagIDs); err != nil {
+		return nil, fmt.Errorf("unmarshal tag_ids: %w", err)
+	}
+	if err := json.Unmarshal([]byte(collectionIDs), &t.CollectionIDs); err != nil {
+		return nil, fmt.Errorf("unmarshal collection_ids: %w", err)
+	}
+	if err := json.Unmarshal([]byte(shelves), &t.Shelves); err != nil {
+		return nil, fmt.Errorf("unmarshal shelves: %w", err)
+	}
+
+	return &t, nil
+}
+
+// TrashBook soft-deletes a book and moves it to the trash in one transaction.
+//
+// The book's tags, shelf placements and collection memberships are recorded on
+// entry (overwriting whatever the caller set) so RestoreTrashedBook can put the
+// book back. Shelf and collection rows are removed here; tag rows are left for
+// CleanupTagsForDeletedBook, which the tag cascade already goes through.
+// Returns store.ErrBookNotFound if the book does not exist or is already deleted.
+func (s *Store) TrashBook(ctx context.Context, entry *domain.TrashedBook) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := formatTime(entry.DeletedAt)
+	result, err := tx.ExecContext(ctx, `
+		UPDATE books SET deleted_at = ?, updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL`,
+		now, now, entry.BookID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrBookNotFound
+	}
+
+	entry.TagIDs, err = queryStrings(ctx, tx,
+		`SELECT tag_id FROM book_tags WHERE book_id = ? ORDER BY tag_id`, entry.BookID)
+	if err != nil {
+		return fmt.Errorf("snapshot tags: %w", err)
+	}
+	entry.CollectionIDs, err = queryStrings(ctx, tx,
+		`SELECT collection_id FROM collection_books WHERE book_id = ? ORDER BY collection_id`, entry.BookID)
+	if err != nil {
+		return fmt.Errorf("snapshot collections: %w", err)
+	}
+	entry.Shelves, err = snapshotShelves(ctx, tx, entry.BookID)
+	if err != nil {
+		return fmt.Errorf("snapshot shelves: %w", err)
+	}
+
+	tagJSON, err := json.Marshal(emptyIfNil(entry.TagIDs))
+	if err != nil {
+		return fmt.Errorf("marshal tag_ids: %w", err)
+	}
+	collectionJSON, err := json.Marshal(emptyIfNil(entry.CollectionIDs))
+	if err != nil {
+		return fmt.Errorf("marshal collection_ids: %w", err)
+	}
+	shelvesJSON, err := json.Marshal(emptyIfNil(entry.Shelves))
+	if err != nil {
+		return fmt.Errorf("marshal shelves: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT OR REPLACE INTO book_trash (`+trashColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.BookID,
+		entry.Title,
+		entry.Path,
+		entry.DeletedBy,
+		now,
+		nullTimeString(entry.PurgeAt),
+		boolToInt(entry.DeleteFiles),
+		string(tagJSON),
+		string(collectionJSON),
+		string(shelvesJSON),
+	)
+	if err != nil {
+		return fmt.Errorf("insert trash entry: %w", err)
+	}
+
+	for _, q := range []string{
+		`DELETE FROM collection_books WHERE book_id = ?`,
+		`DELETE FROM shelf_books WHERE book_id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, q, entry.BookID); err != nil {
+			return fmt.Errorf("detach book: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetTrashedBook retrieves a trash entry by book ID.
+// Returns store.ErrTrashedBookNotFound if the book is not in the trash.
+func (s *Store) GetTrashedBook(ctx context.Context, bookID string) (*domain.TrashedBook, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+trashColumns+` FROM book_trash WHERE book_id = ?`, bookID)
+
+	t, err := scanTrashedBook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrTrashedBookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListTrashedBooks returns every book in the trash, most recently deleted first.
+func (s *Store) ListTrashedBooks(ctx context.Context) ([]*domain.TrashedBook, error) {
+	return s.queryTrashedBooks(ctx,
+		`SELECT `+trashColumns+` FROM book_trash ORDER BY deleted_at DESC`)
+}
+
+// ListTrashedBooksDue returns trashed books whose purge time is at or before now.
+func (s *Store) ListTrashedBooksDue(ctx context.Context, now time.Time) ([]*domain.TrashedBook, error) {
+	return s.queryTrashedBooks(ctx,
+		`SELECT `+trashColumns+` FROM book_trash
+		WHERE purge_at IS NOT NULL AND purge_at <= ?
+		ORDER BY purge_at ASC`,
+		formatTime(now))
+}
+
+// RestoreTrashedBook takes a book out of the trash in one transaction.
+//
+// Collection memberships, shelf placements and tags are put back before the
+// book is undeleted, so a book that lived in a private collection is never
+// briefly visible to everyone. Shelves, collections and tags deleted in the
+// meantime are skipped. Returns store.ErrTrashedBookNotFound if the book is not
+// in the trash.
+func (s *Store) RestoreTrashedBook(ctx context.Context, bookID string) error {
+	entry, err := s.GetTrashedBook(ctx, bookID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := formatTime(time.Now())
+
+	for _, collectionID := range entry.CollectionIDs {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO collection_books (collection_id, book_id)
+			SELECT id, ? FROM collections WHERE id = ?`,
+			bookID, collectionID); err != nil {
+			return fmt.Errorf("restore collection %s: %w", collectionID, err)
+		}
+	}
+	for _, shelf := range entry.Shelves {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO shelf_books (shelf_id, book_id, sort_order)
+			SELECT id, ?, ? FROM shelves WHERE id = ?`,
+			bookID, shelf.SortOrder, shelf.ShelfID); err != nil {
+			return fmt.Errorf("restore shelf %s: %w", shelf.ShelfID, err)
+		}
+	}
+	for _, tagID := range entry.TagIDs {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO book_tags (book_id, tag_id, created_at)
+			SELECT ?, id, ? FROM tags WHERE id = ?`,
+			bookID, now, tagID); err != nil {
+			return fmt.Errorf("restore tag %s: %w", tagID, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE books SET deleted_at = NULL, updated_at = ?
+		WHERE id = ?`,
+		now, bookID); err != nil {
+		return fmt.Errorf("undelete book: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM book_trash WHERE book_id = ?`, bookID); err != nil {
+		return fmt.Errorf("delete trash entry: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteTrashedBook removes a book's trash entry, making its deletion permanent.
+// The book row stays soft-deleted so listening history keeps its references.
+// Returns store.ErrTrashedBookNotFound if the book is not in the trash.
+func (s *Store) DeleteTrashedBook(ctx context.Context, bookID string) error {
+	result, err := s.db.ExecContext(ctx,
+		`DELETE FROM book_trash WHERE book_id = ?`, bookID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrTrashedBookNotFound
+	}
+	return nil
+}
+
+// queryTrashedBooks runs a trash query and scans every row.
+func (s *Store) queryTrashedBooks(ctx context.Context, query string, args ...any) ([]*domain.TrashedBook, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.TrashedBook
+	for rows.Next() {
+		t, err := scanTrashedBook(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// snapshotShelves records the shelves a book is on and its position on each.
+func snapshotShelves(ctx context.Context, tx *sql.Tx, bookID string) ([]domain.TrashedShelfItem, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT shelf_id, sort_order FROM shelf_books WHERE book_id = ? ORDER BY shelf_id`, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shelves []domain.TrashedShelfItem
+	for rows.Next() {
+		var item domain.TrashedShelfItem
+		if err := rows.Scan(&item.ShelfID, &item.SortOrder); err != nil {
+			return nil, err
+		}
+		shelves = append(shelves, item)
+	}
+	return shelves, rows.Err()
+}
+
+// queryStrings runs a single-column query inside a transaction.
+func queryStrings(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+// emptyIfNil returns an empty slice for nil so JSON columns hold [] rather than null.
+func emptyIfNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,194 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// setupTrashFixture creates a book that is tagged, shelved and collected.
+func setupTrashFixture(t *testing.T, s *Store, bookID string) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	insertTestUser(t, s, "user-"+bookID)
+	insertTestLibrary(t, s, "lib-"+bookID, "user-"+bookID)
+	insertTestBook(t, s, bookID, "Trash Book", "/books/"+bookID)
+
+	if err := s.CreateTag(ctx, &domain.Tag{ID: "tag-" + bookID, Slug: "slow-burn-" + bookID, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	if err := s.AddTagToBook(ctx, bookID, "tag-"+bookID); err != nil {
+		t.Fatalf("AddTagToBook: %v", err)
+	}
+
+	shelf := &domain.Shelf{ID: "shelf-" + bookID, OwnerID: "user-" + bookID, Name: "Favourites", CreatedAt: now, UpdatedAt: now}
+	if err := s.CreateShelf(ctx, shelf); err != nil {
+		t.Fatalf("CreateShelf: %v", err)
+	}
+	if err := s.AddBookToShelf(ctx, shelf.ID, bookID); err != nil {
+		t.Fatalf("AddBookToShelf: %v", err)
+	}
+
+	coll := &domain.Collection{ID: "coll-" + bookID, LibraryID: "lib-" + bookID, OwnerID: "user-" + bookID, Name: "Private", CreatedAt: now, UpdatedAt: now}
+	if err := s.CreateCollection(ctx, coll); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	if err := s.AdminAddBookToCollection(ctx, bookID, coll.ID); err != nil {
+		t.Fatalf("AdminAddBookToCollection: %v", err)
+	}
+}
+
+func TestTrashBook_SnapshotsAndDetaches(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+	setupTrashFixture(t, s, "book-tr-1")
+
+	purgeAt := time.Now().UTC().Add(30 * 24 * time.Hour)
+	entry := &domain.TrashedBook{
+		BookID:      "book-tr-1",
+		Title:       "Trash Book",
+		Path:        "/books/book-tr-1",
+		DeletedBy:   "user-book-tr-1",
+		DeletedAt:   time.Now().UTC(),
+		PurgeAt:     &purgeAt,
+		DeleteFiles: true,
+	}
+	if err := s.TrashBook(ctx, entry); err != nil {
+		t.Fatalf("TrashBook: %v", err)
+	}
+	if err := s.CleanupTagsForDeletedBook(ctx, "book-tr-1"); err != nil {
+		t.Fatalf("CleanupTagsForDeletedBook: %v", err)
+	}
+
+	if _, err := s.GetBook(ctx, "book-tr-1", ""); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetBook after trash: got %v, want ErrNotFound", err)
+	}
+	if ids, _ := s.GetTagIDsForBook(ctx, "book-tr-1"); len(ids) != 0 {
+		t.Errorf("tags not detached: %v", ids)
+	}
+	if shelves, _ := s.GetShelvesContainingBook(ctx, "book-tr-1"); len(shelves) != 0 {
+		t.Errorf("shelves not detached: %d", len(shelves))
+	}
+	if colls, _ := s.GetCollectionsForBook(ctx, "book-tr-1"); len(colls) != 0 {
+		t.Errorf("collections not detached: %d", len(colls))
+	}
+
+	got, err := s.GetTrashedBook(ctx, "book-tr-1")
+	if err != nil {
+		t.Fatalf("GetTrashedBook: %v", err)
+	}
+	if !got.DeleteFiles || got.PurgeAt == nil {
+		t.Errorf("entry fields lost: %+v", got)
+	}
+	if len(got.TagIDs) != 1 || got.TagIDs[0] != "tag-book-tr-1" {
+		t.Errorf("TagIDs: got %v", got.TagIDs)
+	}
+	if len(got.CollectionIDs) != 1 || got.CollectionIDs[0] != "coll-book-tr-1" {
+		t.Errorf("CollectionIDs: got %v", got.CollectionIDs)
+	}
+	if len(got.Shelves) != 1 || got.Shelves[0].ShelfID != "shelf-book-tr-1" {
+		t.Errorf("Shelves: got %v", got.Shelves)
+	}
+
+	// Trashing twice fails: the book is already deleted.
+	if err := s.TrashBook(ctx, entry); !errors.Is(err, store.ErrBookNotFound) {
+		t.Errorf("second TrashBook: got %v, want ErrBookNotFound", err)
+	}
+}
+
+func TestRestoreTrashedBook(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+	setupTrashFixture(t, s, "book-tr-2")
+
+	entry := &domain.TrashedBook{BookID: "book-tr-2", Title: "Trash Book", Path: "/books/book-tr-2", DeletedBy: "admin", DeletedAt: time.Now().UTC()}
+	if err := s.TrashBook(ctx, entry); err != nil {
+		t.Fatalf("TrashBook: %v", err)
+	}
+	if err := s.CleanupTagsForDeletedBook(ctx, "book-tr-2"); err != nil {
+		t.Fatalf("CleanupTagsForDeletedBook: %v", err)
+	}
+
+	// The shelf is deleted while the book sits in the trash.
+	if err := s.DeleteShelf(ctx, "shelf-book-tr-2"); err != nil {
+		t.Fatalf("DeleteShelf: %v", err)
+	}
+
+	if err := s.RestoreTrashedBook(ctx, "book-tr-2"); err != nil {
+		t.Fatalf("RestoreTrashedBook: %v", err)
+	}
+
+	if _, err := s.GetBook(ctx, "book-tr-2", ""); err != nil {
+		t.Errorf("GetBook after restore: %v", err)
+	}
+	if ids, _ := s.GetTagIDsForBook(ctx, "book-tr-2"); len(ids) != 1 {
+		t.Errorf("tags not restored: %v", ids)
+	}
+	if colls, _ := s.GetCollectionsForBook(ctx, "book-tr-2"); len(colls) != 1 {
+		t.Errorf("collections not restored: %d", len(colls))
+	}
+	if shelves, _ := s.GetShelvesContainingBook(ctx, "book-tr-2"); len(shelves) != 0 {
+		t.Errorf("deleted shelf came back: %d", len(shelves))
+	}
+	if _, err := s.GetTrashedBook(ctx, "book-tr-2"); !errors.Is(err, store.ErrTrashedBookNotFound) {
+		t.Errorf("trash entry not removed: %v", err)
+	}
+
+	if err := s.RestoreTrashedBook(ctx, "book-tr-2"); !errors.Is(err, store.ErrTrashedBookNotFound) {
+		t.Errorf("second restore: got %v, want ErrTrashedBookNotFound", err)
+	}
+}
+
+func TestListTrashedBooksDue(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	entries := []*domain.TrashedBook{
+		{BookID: "book-due", PurgeAt: &past},
+		{BookID: "book-later", PurgeAt: &future},
+		{BookID: "book-never"},
+	}
+	for _, e := range entries {
+		insertTestBook(t, s, e.BookID, e.BookID, "/books/"+e.BookID)
+		e.DeletedBy = "admin"
+		e.DeletedAt = now
+		if err := s.TrashBook(ctx, e); err != nil {
+			t.Fatalf("TrashBook(%s): %v", e.BookID, err)
+		}
+	}
+
+	all, err := s.ListTrashedBooks(ctx)
+	if err != nil {
+		t.Fatalf("ListTrashedBooks: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("ListTrashedBooks: got %d, want 3", len(all))
+	}
+
+	due, err := s.ListTrashedBooksDue(ctx, now)
+	if err != nil {
+		t.Fatalf("ListTrashedBooksDue: %v", err)
+	}
+	if len(due) != 1 || due[0].BookID != "book-due" {
+		t.Errorf("ListTrashedBooksDue: got %v", due)
+	}
+
+	if err := s.DeleteTrashedBook(ctx, "book-due"); err != nil {
+		t.Fatalf("DeleteTrashedBook: %v", err)
+	}
+	if err := s.DeleteTrashedBook(ctx, "book-due"); !errors.Is(err, store.ErrTrashedBookNotFound) {
+		t.Errorf("second DeleteTrashedBook: got %v, want ErrTrashedBookNotFound", err)
+	}
+}
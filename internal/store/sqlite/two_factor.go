@@ -0,0 +1,286 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+// twoFactorColumns is the ordered list of columns selected in two-factor
+// queries. Must match the scan order in scanTwoFactor.
+const twoFactorColumns = `user_id, secret, last_used_step, challenge_id, failed_attempts, confirmed_at, created_at, updated_at`
+
+// recoveryCodeColumns is the ordered list of columns selected in recovery
+// code queries. Must match the scan order in scanRecoveryCode.
+const recoveryCodeColumns = `id, user_id, code_hash, created_at, used_at`
+
+// scanTwoFactor scans a sql.Row (or sql.Rows via its Scan method) into a domain.TwoFactor.
+func scanTwoFactor(scanner interface{ Scan(dest ...any) error }) (*domain.TwoFactor, error) {
+	var tf domain.TwoFactor
+
+	var (
+		confirmedAt sql.NullString
+		createdAt   string
+		updatedAt   string
+	)
+
+	err := scanner.Scan(
+		&tf.UserID,
+		&tf.Secret,
+		&tf.LastUsedStep,
+		&tf.ChallengeID,
+		&tf.FailedAttempts,
+		&confirmedAt,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tf.ConfirmedAt, err = parseNullableTime(confirmedAt)
+	if err != nil {
+		return nil, err
+	}
+	tf.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	tf.UpdatedAt, err = parseTime(updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tf, nil
+}
+
+// scanRecoveryCode scans a sql.Row (or sql.Rows via its Scan method) into a domain.RecoveryCode.
+func scanRecoveryCode(scanner interface{ Scan(dest ...any) error }) (*domain.RecoveryCode, error) {
+	var c domain.RecoveryCode
+
+	var (
+		createdAt string
+		usedAt    sql.NullString
+	)
+
+	err := scanner.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.CodeHash,
+		&createdAt,
+		&usedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	c.UsedAt, err = parseNullableTime(usedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// GetTwoFactor retrieves a user's TOTP enrollment, confirmed or not.
+// Returns store.ErrTwoFactorNotFound if the user never started enrolling.
+func (s *Store) GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+twoFactorColumns+` FROM user_two_factor WHERE user_id = ?`, userID)
+
+	tf, err := scanTwoFactor(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrTwoFactorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tf, nil
+}
+
+// SaveTwoFactor inserts or replaces a user's TOTP enrollment.
+func (s *Store) SaveTwoFactor(ctx context.Context, tf *domain.TwoFactor) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO user_two_factor (`+twoFactorColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			secret = excluded.secret,
+			last_used_step = excluded.last_used_step,
+			confirmed_at = excluded.confirmed_at,
+			updated_at = excluded.updated_at`,
+		tf.UserID,
+		tf.Secret,
+		tf.LastUsedStep,
+		tf.ChallengeID,
+		tf.FailedAttempts,
+		nullTimeString(tf.ConfirmedAt),
+		formatTime(tf.CreatedAt),
+		formatTime(tf.UpdatedAt),
+	)
+	return err
+}
+
+// UseTOTPStep records that a code from the given time step was accepted.
+// Only one caller can use a step: if it is not later than the last used
+// step, store.ErrTwoFactorNotFound is returned.
+func (s *Store) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE user_two_factor SET last_used_step = ?, updated_at = ? WHERE user_id = ? AND last_used_step < ?`,
+		step, formatTime(time.Now()), userID, step)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrTwoFactorNotFound
+	}
+	return nil
+}
+
+// StartTwoFactorChallenge makes challengeID the user's current login
+// challenge with no failed attempts, revoking any earlier challenge.
+// Returns store.ErrTwoFactorNotFound if the user has no enrollment.
+func (s *Store) StartTwoFactorChallenge(ctx context.Context, userID, challengeID string) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE user_two_factor SET challenge_id = ?, failed_attempts = 0, updated_at = ? WHERE user_id = ?`,
+		challengeID, formatTime(time.Now()), userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrTwoFactorNotFound
+	}
+	return nil
+}
+
+// RecordTwoFactorFailure counts a wrong code against a login challenge and
+// returns the failed attempts so far. Returns store.ErrTwoFactorNotFound if
+// challengeID is not the user's current challenge.
+func (s *Store) RecordTwoFactorFailure(ctx context.Context, userID, challengeID string) (int, error) {
+	var attempts int
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE user_two_factor SET failed_attempts = failed_attempts + 1, updated_at = ?
+		WHERE user_id = ? AND challenge_id = ? AND challenge_id != ''
+		RETURNING failed_attempts`,
+		formatTime(time.Now()), userID, challengeID).Scan(&attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, store.ErrTwoFactorNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return attempts, nil
+}
+
+// RevokeTwoFactorChallenge ends a login challenge if it is still current.
+// This operation is idempotent.
+func (s *Store) RevokeTwoFactorChallenge(ctx context.Context, userID, challengeID string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE user_two_factor SET challenge_id = '', updated_at = ? WHERE user_id = ? AND challenge_id = ?`,
+		formatTime(time.Now()), userID, challengeID)
+	return err
+}
+
+// DeleteTwoFactor removes a user's TOTP enrollment and recovery codes.
+// This operation is idempotent.
+func (s *Store) DeleteTwoFactor(ctx context.Context, userID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_two_factor WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReplaceRecoveryCodes swaps a user's recovery codes for a new set.
+func (s *Store) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*domain.RecoveryCode) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	for _, c := range codes {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO recovery_codes (`+recoveryCodeColumns+`)
+			VALUES (?, ?, ?, ?, ?)`,
+			c.ID,
+			userID,
+			c.CodeHash,
+			formatTime(c.CreatedAt),
+			nullTimeString(c.UsedAt),
+		)
+		if err != nil {
+			return fmt.Errorf("insert recovery code: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListRecoveryCodes returns a user's recovery codes, used ones included.
+func (s *Store) ListRecoveryCodes(ctx context.Context, userID string) ([]*domain.RecoveryCode, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+recoveryCodeColumns+` FROM recovery_codes WHERE user_id = ? ORDER BY created_at ASC, id ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []*domain.RecoveryCode
+	for rows.Next() {
+		c, err := scanRecoveryCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+	}
+	return codes, rows.Err()
+}
+
+// UseRecoveryCode marks a recovery code as redeemed. Only one caller can
+// redeem a code: a second call returns store.ErrRecoveryCodeNotFound.
+func (s *Store) UseRecoveryCode(ctx context.Context, id string, usedAt time.Time) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE recovery_codes SET used_at = ? WHERE id = ? AND used_at IS NULL`,
+		formatTime(usedAt), id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrRecoveryCodeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/store"
+)
+
+func TestTwoFactorLifecycle(t *testing.T) {
+	t.Parallel()
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	insertTestUser(t, s, "user-2fa-1")
+
+	if _, err := s.GetTwoFactor(ctx, "user-2fa-1"); !errors.Is(err, store.ErrTwoFactorNotFound) {
+		t.Fatalf("before enrolling: got %v, want ErrTwoFactorNotFound", err)
+	}
+
+	now := time.Now().UTC()
+	tf := &domain.TwoFactor{UserID: "user-2fa-1", Secret: "SECRET1", CreatedAt: now, UpdatedAt: now}
+	if err := s.SaveTwoFactor(ctx, tf); err != nil {
+		t.Fatalf("SaveTwoFactor: %v", err)
+	}
+
+	// Saving again confirms the enrollment in place.
+	tf.ConfirmedAt = &now
+	if err := s.SaveTwoFactor(ctx, tf); err != nil {
+		t.Fatalf("SaveTwoFactor (confirm): %v", err)
+	}
+	got, err := s.GetTwoFactor(ctx, "user-2fa-1")
+	if err != nil {
+		t.Fatalf("GetTwoFactor: %v", err)
+	}
+	if got.Secret != "SECRET1" || !got.IsEnabled() {
+		t.Errorf("got %+v", got)
+	}
+
+	// Each time step is accepted once, and never an earlier one.
+	if err := s.UseTOTPStep(ctx, "user-2fa-1", 100); err != nil {
+		t.Fatalf("UseTOTPStep: %v", err)
+	}
+	for _, step := range []int64{100, 99} {
+		if err := s.UseTOTPStep(ctx, "user-2fa-1", step); !errors.Is(err, store.ErrTwoFactorNotFound) {
+			t.Errorf("step %d after 100: got %v, want ErrTwoFactorNotFound", step, err)
+		}
+	}
+
+	codes := []*domain.RecoveryCode{
+		{ID: "rc-1", CodeHash: "hash-1", CreatedAt: now},
+		{ID: "rc-2", CodeHash: "hash-2", CreatedAt: now},
+	}
+	if err := s.ReplaceRecoveryCodes(ctx, "user-2fa-1", codes); err != nil {
+		t.Fatalf("ReplaceRecoveryCodes: %v", err)
+	}
+	if err := s.UseRecoveryCode(ctx, "rc-1", now); err != nil {
+		t.Fatalf("UseRecoveryCode: %v", err)
+	}
+	if err := s.UseRecoveryCode(ctx, "rc-1", now); !errors.Is(err, store.ErrRecoveryCodeNotFound) {
+		t.Errorf("second use: got %v, want ErrRecoveryCodeNotFound", err)
+	}
+	listed, err := s.ListRecoveryCodes(ctx, "user-2fa-1")
+	if err != nil {
+		t.Fatalf("ListRecoveryCodes: %v", err)
+	}
+	if len(listed) != 2 || !listed[0].IsUsed() || listed[1].IsUsed() || listed[0].UserID != "user-2fa-1" {
+		t.Errorf("listed %+v", listed)
+	}
+
+	// Replacing drops the old set.
+	if err := s.ReplaceRecoveryCodes(ctx, "user-2fa-1", codes[1:]); err != nil {
+		t.Fatalf("ReplaceRecoveryCodes (again): %v", err)
+	}
+	if listed, _ = s.ListRecoveryCodes(ctx, "user-2fa-1"); len(listed) != 1 {
+		t.Errorf("after replace: %d codes, want 1", len(listed))
+	}
+
+	// Wrong codes count against the current challenge only.
+	if err := s.StartTwoFactorChallenge(ctx, "user-2fa-1", "challenge-1"); err != nil {
+		t.Fatalf("StartTwoFactorChallenge: %v", err)
+	}
+	for want := 1; want <= 2; want++ {
+		attempts, err := s.RecordTwoFactorFailure(ctx, "user-2fa-1", "challenge-1")
+		if err != nil || attempts != want {
+			t.Errorf("RecordTwoFactorFailure: got %d, %v; want %d", attempts, err, want)
+		}
+	}
+	if err := s.StartTwoFactorChallenge(ctx, "user-2fa-1", "challenge-2"); err != nil {
+		t.Fatalf("StartTwoFactorChallenge (again): %v", err)
+	}
+	if _, err := s.RecordTwoFactorFailure(ctx, "user-2fa-1", "challenge-1"); !errors.Is(err, store.ErrTwoFactorNotFound) {
+		t.Errorf("replaced challenge: got %v, want ErrTwoFactorNotFound", err)
+	}
+	if got, _ = s.GetTwoFactor(ctx, "user-2fa-1"); got.ChallengeID != "challenge-2" || got.FailedAttempts != 0 {
+		t.Errorf("new challenge: got %q with %d attempts", got.ChallengeID, got.FailedAttempts)
+	}
+	if err := s.RevokeTwoFactorChallenge(ctx, "user-2fa-1", "challenge-2"); err != nil {
+		t.Fatalf("RevokeTwoFactorChallenge: %v", err)
+	}
+	if _, err := s.RecordTwoFactorFailure(ctx, "user-2fa-1", "challenge-2"); !errors.Is(err, store.ErrTwoFactorNotFound) {
+		t.Errorf("revoked challenge: got %v, want ErrTwoFactorNotFound", err)
+	}
+
+	if err := s.DeleteTwoFactor(ctx, "user-2fa-1"); err != nil {
+		t.Fatalf("DeleteTwoFactor: %v", err)
+	}
+	if _, err := s.GetTwoFactor(ctx, "user-2fa-1"); !errors.Is(err, store.ErrTwoFactorNotFound) {
+		t.Errorf("after delete: got %v, want ErrTwoFactorNotFound", err)
+	}
+	if listed, _ = s.ListRecoveryCodes(ctx, "user-2fa-1"); len(listed) != 0 {
+		t.Errorf("after delete: %d recovery codes, want 0", len(listed))
+	}
+}
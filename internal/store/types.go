@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/listenupapp/listenup-server/internal/domain"
+	"github.com/listenupapp/listenup-server/internal/metadata"
 	"github.com/listenupapp/listenup-server/internal/metadata/audible"
 )
 
@@ -19,6 +20,26 @@ type SeriesInput struct {
 	Sequence string `json:"sequence"`
 }
 
+// BookFilter narrows books by their scalar attributes.
+// Zero-valued fields are not applied.
+type BookFilter struct {
+	MinDurationMs int64
+	MaxDurationMs int64
+	MinYear       int
+	MaxYear       int
+	CreatedAfter  time.Time
+}
+
+// LibraryStats holds totals for the books in one library.
+type LibraryStats struct {
+	LibraryID        string `json:"library_id"`
+	BookCount        int    `json:"book_count"`
+	ContributorCount int    `json:"contributor_count"`
+	SeriesCount      int    `json:"series_count"`
+	TotalDuration    int64  `json:"total_duration"` // milliseconds
+	TotalSize        int64  `json:"total_size"`     // bytes
+}
+
 // BootstrapResult contains the initialized library and collections.
 type BootstrapResult struct {
 	Library         *domain.Library
@@ -47,3 +68,22 @@ type CachedSearch struct {
 	Region    audible.Region         `json:"region"`
 	Query     string                 `json:"query"`
 }
+
+// CachedProviderBook wraps book metadata from a provider with cache info.
+type CachedProviderBook struct {
+	Book      *metadata.Book `json:"book"`
+	FetchedAt time.Time      `json:"fetched_at"`
+}
+
+// CachedProviderSearch wraps a provider's search results with cache info.
+type CachedProviderSearch struct {
+	Results   []metadata.SearchResult `json:"results"`
+	FetchedAt time.Time               `json:"fetched_at"`
+	Query     string                  `json:"query"`
+}
+
+// CachedProviderContributor wraps a provider's contributor profile with cache info.
+type CachedProviderContributor struct {
+	Profile   *metadata.ContributorProfile `json:"profile"`
+	FetchedAt time.Time                    `json:"fetched_at"`
+}